@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const usage = `Usage: benchgate -baseline <file> -current <file> [-threshold 10]
+
+Compares two "go test -bench" outputs and fails when any benchmark present in
+both got slower than the threshold (percent, median ns/op across -count runs).
+
+  go test -run '^$' -bench . -count 5 ./internal/... > current.txt
+  benchgate -baseline baseline.txt -current current.txt
+`
+
+type comparison struct {
+	name     string
+	baseline float64
+	current  float64
+	delta    float64
+}
+
+func main() {
+	baselinePath := flag.String("baseline", "", "benchmark output to compare against")
+	currentPath := flag.String("current", "", "benchmark output of the change under test")
+	threshold := flag.Float64("threshold", 10, "maximum allowed slowdown in percent")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if *baselinePath == "" || *currentPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	regressed, err := run(*baselinePath, *currentPath, *threshold, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "benchgate: %v\n", err)
+		os.Exit(1)
+	}
+	if regressed {
+		os.Exit(1)
+	}
+}
+
+func run(baselinePath, currentPath string, threshold float64, out io.Writer) (bool, error) {
+	baseline, err := parseFile(baselinePath)
+	if err != nil {
+		return false, err
+	}
+	current, err := parseFile(currentPath)
+	if err != nil {
+		return false, err
+	}
+
+	var comparisons []comparison
+	for name, samples := range current {
+		base, ok := baseline[name]
+		if !ok {
+			fmt.Fprintf(out, "new       %-60s %14.0f ns/op\n", name, median(samples))
+			continue
+		}
+		b, c := median(base), median(samples)
+		comparisons = append(comparisons, comparison{name: name, baseline: b, current: c, delta: (c - b) / b * 100})
+	}
+	slices.SortFunc(comparisons, func(a, b comparison) int { return strings.Compare(a.name, b.name) })
+
+	var regressed bool
+	for _, c := range comparisons {
+		status := "ok"
+		if c.delta > threshold {
+			status = "REGRESSED"
+			regressed = true
+		}
+		fmt.Fprintf(out, "%-9s %-60s %14.0f -> %14.0f ns/op  %+7.2f%%\n", status, c.name, c.baseline, c.current, c.delta)
+	}
+	return regressed, nil
+}
+
+func parseFile(path string) (map[string][]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+func parse(r io.Reader) (map[string][]float64, error) {
+	results := make(map[string][]float64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		for i := 2; i+1 < len(fields); i += 2 {
+			if fields[i+1] != "ns/op" {
+				continue
+			}
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse %q: %w", scanner.Text(), err)
+			}
+			results[fields[0]] = append(results[fields[0]], value)
+		}
+	}
+	return results, scanner.Err()
+}
+
+func median(samples []float64) float64 {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
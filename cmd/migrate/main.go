@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/repository/memory"
+	"finance_manager/internal/repository/schema"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+const usage = `Usage: migrate -from <source> -to <target> [flags]
+       migrate schema -dsn <url> [-driver postgres] [-status]
+
+Sources and targets:
+  file:<path>  JSON repository snapshot (as served by GET /api/v1/admin/snapshot)
+  <path>       shorthand for file:<path>
+
+Flags:
+`
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		if err := runSchema(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	from := fs.String("from", "", "source backend")
+	to := fs.String("to", "", "target backend (ignored with -dry-run)")
+	dryRun := fs.Bool("dry-run", false, "validate the source without writing the target")
+	batchSize := fs.Int("batch", 500, "transactions written per batch")
+	quiet := fs.Bool("quiet", false, "suppress progress output")
+	fs.Parse(os.Args[1:])
+
+	if *from == "" || (*to == "" && !*dryRun) {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, *from, *to, *dryRun, *batchSize, *quiet); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, from, to string, dryRun bool, batchSize int, quiet bool) error {
+	sourcePath, err := filePath(from)
+	if err != nil {
+		return err
+	}
+	var targetPath string
+	if !dryRun {
+		if targetPath, err = filePath(to); err != nil {
+			return err
+		}
+	}
+
+	in, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	source, err := repository.ReadSnapshotFile(in)
+	in.Close()
+	if err != nil {
+		return err
+	}
+
+	accounts := memory.NewAccountRepository()
+	transactions := memory.NewTransactionRepository()
+	report, err := repository.Migrate(ctx, source, accounts, transactions, repository.MigrationOptions{
+		DryRun:    dryRun,
+		BatchSize: batchSize,
+		Progress: func(p repository.MigrationProgress) {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "%-12s %d/%d\n", p.Stage, p.Done, p.Total)
+			}
+		},
+	})
+	if report != nil {
+		printReport(report)
+	}
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+
+	accountSnapshot, err := accounts.SnapshotAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	defer accountSnapshot.Release()
+	txSnapshot, err := transactions.SnapshotTransactions(ctx)
+	if err != nil {
+		return err
+	}
+	defer txSnapshot.Release()
+
+	out, err := os.Create(targetPath)
+	if err != nil {
+		return err
+	}
+	if err := repository.WriteSnapshotFile(ctx, out, accountSnapshot, txSnapshot); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func runSchema(args []string) error {
+	fs := flag.NewFlagSet("schema", flag.ExitOnError)
+	driver := fs.String("driver", "postgres", "database/sql driver name")
+	dsn := fs.String("dsn", os.Getenv("DATABASE_URL"), "database connection string")
+	statusOnly := fs.Bool("status", false, "report applied and pending migrations without applying")
+	fs.Parse(args)
+
+	if *dsn == "" {
+		return fmt.Errorf("-dsn or DATABASE_URL is required")
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := schema.NewMigrator(db, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !*statusOnly {
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version %d of %d\n", status.CurrentVersion, status.LatestVersion)
+	for _, migration := range status.Pending {
+		fmt.Printf("  pending %04d_%s\n", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+func filePath(backend string) (string, error) {
+	scheme, rest, found := strings.Cut(backend, ":")
+	switch {
+	case !found:
+		return backend, nil
+	case scheme == "file":
+		return rest, nil
+	case scheme == "postgres" || scheme == "postgresql":
+		return "", fmt.Errorf("postgres backend is not available in this build")
+	default:
+		return "", fmt.Errorf("unknown backend %q", scheme)
+	}
+}
+
+func printReport(report *repository.MigrationReport) {
+	mode := "migrated"
+	if report.DryRun {
+		mode = "validated (dry run)"
+	}
+	fmt.Printf("%s %d accounts and %d transactions in %s\n", mode, report.Accounts, report.Transactions, report.Duration.Round(1e6))
+
+	currencies := make([]string, 0, len(report.Balances))
+	for currency := range report.Balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		fmt.Printf("  %s total balance %.2f\n", currency, report.Balances[currency])
+	}
+	for _, mismatch := range report.Mismatches {
+		fmt.Printf("  MISMATCH %s\n", mismatch)
+	}
+}
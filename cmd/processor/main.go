@@ -2,44 +2,564 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"finance_manager/internal/api"
 	"finance_manager/internal/processor"
 	"finance_manager/internal/repository/memory"
+	"finance_manager/internal/repository/schema"
 	"finance_manager/internal/service"
 	"finance_manager/pkg/crypto"
 	"finance_manager/pkg/metrics"
+	"finance_manager/pkg/money"
+	"finance_manager/pkg/objectstore"
+	"finance_manager/pkg/scheduler"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 )
 
 const (
-	appName = "finance_manager"
+	appName            = "finance_manager"
+	defaultEnvironment = "production"
+	sandboxEnvironment = "sandbox"
 )
 
 func main() {
 	logger := setupLogger()
 	logger.Info("Starting application",
-		slog.String("name", appName))
+		slog.String("name", appName),
+		slog.String("environment", environment()))
 
 	metricsCollector := metrics.NewMetricsCollector(logger)
 	signer := crypto.NewSigner("your-secret-key-here", logger)
 	txRepo := memory.NewTransactionRepository()
 	accountRepo := memory.NewAccountRepository()
 	ruleRepo := memory.NewRuleRepository()
-	txProcessor := processor.NewTransactionProcessor(txRepo, accountRepo, ruleRepo, 10)
+	ruleGroupRepo := memory.NewRuleGroupRepository()
+	txProcessor := processor.NewTransactionProcessor(txRepo, accountRepo, ruleRepo, workerCount(logger))
+	txProcessor.RuleEngine().
+		WithRuleGroups(ruleGroupRepo, environment()).
+		WithLookupTables(memory.NewLookupTableRepository())
+	txProcessor.WorkerPool().SetObserver(metricsCollector)
+	txProcessor.FraudDetector().
+		WithAmountHeuristics(processor.DefaultAmountHeuristicsConfig()).
+		WithTimeModifiers(timeModifierConfig(logger))
+	txProcessor.WithAmountTokenization(amountTokenizationConfig(logger))
+	transferGraph := processor.NewTransferGraph(processor.DefaultGraphAnalysisConfig())
+	txProcessor.WithTransferGraph(transferGraph)
+	auditRepo := memory.NewAuditRepository()
+	txProcessor.WithAuditLog(auditRepo).WithDecisionTracing()
+	txProcessor.WithAccountProfiles(processor.NewProfileTracker(memory.NewProfileRepository(), processor.DefaultProfileConfig()))
+	beneficiaryRepo := memory.NewBeneficiaryRepository()
+	txProcessor.WithTrustedBeneficiaries(beneficiaryRepo, processor.DefaultTrustedFastPathConfig())
+	productRepo := memory.NewProductRepository()
+	txProcessor.WithProducts(productRepo)
+	txProcessor.WithPositivePay(memory.NewPositivePayRepository())
+	txProcessor.WithSpendingControls(memory.NewSpendingControlsRepository())
+	txProcessor.WithSuspense(memory.NewSuspenseRepository())
+	txProcessor.WithDegradedMode(degradedModeConfig(logger))
+	txProcessor.WithAdmissionControl(admissionConfig(logger))
+	txProcessor.WithMaintenanceWindows(maintenanceWindows(logger))
+	txProcessor.WithDepositRetry(depositRetryConfig(logger), nil)
+	if err := txProcessor.EnsureSystemAccounts(context.Background(), systemAccountConfig()); err != nil {
+		logger.Error("Failed to provision system accounts", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	if shards := shardCount(logger); shards > 0 {
+		txProcessor.WithSharding(processor.NewShardedPool(processor.DefaultShardingConfig(shards), nil, logger))
+	}
+	if corridors, enabled := corridorConfig(logger); enabled {
+		txProcessor.WithCorridors(corridors)
+	}
+	txProcessor.WithPurposeCodes(purposeCodeConfig(logger))
+	if environment() == sandboxEnvironment {
+		txProcessor.WithSandbox(processor.DefaultSandboxConfig())
+		logger.Warn("Sandbox mode enabled, magic account IDs and amounts trigger canned outcomes")
+	}
+	if cfg, enabled := shadowConfig(logger); enabled {
+		txProcessor.WithShadowPipeline(processor.NewDryRunPipeline(txProcessor), cfg)
+	}
+	chargebackTracker := processor.NewChargebackTracker()
+	chargebackTracker.SetObserver(metricsCollector)
+	txProcessor.WithChargebackTracking(chargebackTracker)
 	notificationService := setupNotificationService(logger)
-	apiHandler := api.NewAPIHandler(txProcessor, metricsCollector, signer, logger)
+	notificationService.SetObserver(metricsCollector)
+	inboxRepo := memory.NewInboxRepository()
+	inbox := service.NewInboxService(inboxRepo)
+	consents := service.NewConsentService(memory.NewConsentRepository(), logger)
+	notificationService.
+		WithInbox(inbox).
+		WithConsent(consents).
+		WithSMSFormatting(service.DefaultSMSConfig()).
+		WithFailover(memory.NewNotificationPreferenceRepository(), service.DefaultFailoverConfig()).
+		WithDeliveryHistory(memory.NewDeliveryRepository())
+	transactionExpiry := service.NewTransactionExpiryService(txProcessor, accountRepo, notificationService, service.DefaultTransactionExpiryConfig(), logger)
+	reviewSLA := service.NewReviewSLAService(txProcessor, notificationService, reviewSLAConfig(logger), logger).WithMetrics(metricsCollector)
+	scheduledNotifications := service.NewScheduledNotificationService(memory.NewScheduledNotificationRepository(), notificationService, service.DefaultScheduledNotificationConfig(), logger)
+	limitChangeRepo := memory.NewLimitChangeRepository()
+	limitChanges := service.NewLimitChangeService(accountRepo, limitChangeRepo, signer, notificationService, service.DefaultLimitChangeConfig(), logger).
+		WithProducts(productRepo)
+	txProcessor.WithLimitThresholds(limitThresholdConfig(logger), limitChanges)
+	beneficiaries := service.NewBeneficiaryService(accountRepo, beneficiaryRepo, signer, notificationService, service.DefaultBeneficiaryConfig(), logger)
+	budgetRepo := memory.NewBudgetRepository()
+	budgets := service.NewBudgetService(budgetRepo, accountRepo, txProcessor, notificationService, logger)
+	txProcessor.WithBudgets(budgetRepo, budgets)
+	disputeRepo := memory.NewDisputeRepository()
+	disputes := service.NewDisputeService(txRepo, disputeRepo, txProcessor, service.DefaultDisputeConfig(), logger)
+	chargebacks := service.NewChargebackService(txRepo, memory.NewChargebackRepository(), txProcessor, chargebackTracker, logger)
+	wallets := service.NewWalletService(accountRepo, txProcessor, service.NewFXService("USD", service.DefaultFXRates()), logger)
+	products := service.NewProductService(productRepo, accountRepo, logger)
+	dualControl := service.NewDualControlService(memory.NewApprovalRepository(), auditRepo, logger).WithAdminActions(txProcessor)
+	installments := service.NewInstallmentService(memory.NewInstallmentPlanRepository(), txRepo, txProcessor, service.DefaultInstallmentConfig(), logger)
+	payouts := service.NewPayoutService(memory.NewPayoutBatchRepository(), txRepo, txProcessor, service.DefaultPayoutConfig(), logger)
+	exposure := service.NewExposureService(txProcessor, wallets.FX(), exposureConfig(logger), logger).WithNotifications(notificationService)
+	snapshots := service.NewEODSnapshotService(txProcessor, memory.NewEODSnapshotRepository(), service.DefaultEODSnapshotConfig(), logger)
+	dataQuality := service.NewDataQualityService(txProcessor, service.DefaultDataQualityConfig(), logger).WithMetrics(metricsCollector)
+	reserves := service.NewReservesService(txProcessor, service.DefaultReservesConfig(), logger).WithSnapshots(snapshots)
+	partnerRepo := memory.NewPartnerRepository()
+	webhooks := service.NewWebhookService(partnerRepo, service.DefaultWebhookConfig(), logger).
+		WithEventLog(memory.NewWebhookEventRepository())
+	webhooks.Start()
+	txProcessor.WithEventPublisher(webhooks)
+	if routing := notificationRouting(logger); routing != nil {
+		notificationService.WithRouting(routing)
+		txProcessor.WithRoutingHints().WithEventPublisher(notificationService)
+	}
+	archive := archiveService(txProcessor, logger)
+	jobScheduler := setupScheduler(logger, txProcessor, transferGraph, limitChanges, scheduledNotifications, transactionExpiry, reviewSLA, installments, payouts, exposure, reserves, snapshots, dataQuality, webhooks, archive, ruleRepo, accountRepo, txRepo)
+	apiHandler := api.NewAPIHandler(txProcessor, metricsCollector, signer, logger).
+		WithLimitChanges(limitChanges).
+		WithBeneficiaries(beneficiaries).
+		WithDelegations(service.NewDelegationService(memory.NewDelegationRepository(), accountRepo, auditRepo, logger)).
+		WithDisputes(disputes).
+		WithChargebacks(chargebacks).
+		WithWallets(wallets).
+		WithProducts(products).
+		WithInstallments(installments).
+		WithPayouts(payouts).
+		WithDualControl(dualControl).
+		WithQuotas(service.NewQuotaService(memory.NewQuotaRepository(), logger)).
+		WithPartners(service.NewPartnerService(partnerRepo, accountRepo, logger)).
+		WithWebhooks(webhooks).
+		WithAliases(service.NewAliasService(memory.NewAccountAliasRepository(), accountRepo, logger)).
+		WithReserves(reserves).
+		WithExposure(exposure).
+		WithArchive(archive).
+		WithScheduler(jobScheduler).
+		WithInbox(inbox).
+		WithNotifications(notificationService).
+		WithConsent(consents).
+		WithResponseCache(api.DefaultResponseCacheConfig()).
+		WithLoadShedding(loadSheddingConfig(logger)).
+		WithActivity(service.NewActivityService(accountRepo, txRepo, logger).
+			WithLimitChanges(limitChangeRepo).
+			WithAuditLog(auditRepo).
+			WithInbox(inboxRepo)).
+		WithBudgets(budgets).
+		WithSearch(service.NewSearchService(txRepo, accountRepo, logger).WithCases(disputeRepo)).
+		WithEODSnapshots(snapshots).
+		WithDataQuality(dataQuality).
+		WithImpersonation(service.NewImpersonationService(memory.NewImpersonationRepository(), auditRepo, impersonationConfig(), logger)).
+		WithReconciliation(service.NewReconciliationService(txRepo, memory.NewReconciliationRepository(), service.DefaultReconciliationConfig(), logger).
+			WithSnapshots(snapshots))
+	if migrator := setupSchema(logger); migrator != nil {
+		apiHandler.WithSchemaMigrator(migrator)
+	}
+	if tokens := bearerTokens("OPERATOR_TOKENS", logger); tokens != nil {
+		apiHandler.WithOperatorAuthenticator(api.OperatorTokens(tokens))
+	} else {
+		logger.Warn("OPERATOR_TOKENS not set, dual control trusts the X-Operator-ID header")
+	}
+	if tokens := bearerTokens("USER_TOKENS", logger); tokens != nil {
+		apiHandler.WithUserAuthenticator(api.UserTokens(tokens))
+	} else {
+		logger.Warn("USER_TOKENS not set, delegated access trusts the X-User-ID header")
+	}
 	metricsServer := metricsCollector.StartMetricsServer(":9090")
 	httpServer := startHTTPServer(apiHandler, logger)
-	waitForShutdown(logger, httpServer, metricsServer, notificationService)
+	waitForShutdown(logger, httpServer, metricsServer, jobScheduler, txProcessor, notificationService, webhooks)
 	logger.Info("Application shutdown complete")
 }
 
+func environment() string {
+	if env := os.Getenv("APP_ENV"); env != "" {
+		return env
+	}
+	return defaultEnvironment
+}
+
+func systemAccountConfig() processor.SystemAccountConfig {
+	cfg := processor.DefaultSystemAccountConfig()
+	if currencies := os.Getenv("SYSTEM_ACCOUNT_CURRENCIES"); currencies != "" {
+		cfg.Currencies = strings.Split(currencies, ",")
+	}
+	return cfg
+}
+
+func instanceID() string {
+	if id := os.Getenv("INSTANCE_ID"); id != "" {
+		return id
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return appName
+	}
+	return hostname
+}
+
+func shardCount(logger *slog.Logger) int {
+	raw := os.Getenv("PROCESSOR_SHARDS")
+	if raw == "" {
+		return 0
+	}
+	shards, err := strconv.Atoi(raw)
+	if err != nil || shards <= 0 {
+		logger.Warn("Ignoring invalid processor shard count", slog.String("value", raw))
+		return 0
+	}
+	return shards
+}
+
+func shadowConfig(logger *slog.Logger) (processor.ShadowConfig, bool) {
+	cfg := processor.DefaultShadowConfig()
+	rate := os.Getenv("SHADOW_SAMPLE_RATE")
+	if rate == "" {
+		return cfg, false
+	}
+	parsed, err := strconv.ParseFloat(rate, 64)
+	if err != nil || parsed <= 0 {
+		logger.Warn("Ignoring invalid shadow sample rate", slog.String("value", rate))
+		return cfg, false
+	}
+	cfg.SampleRate = parsed
+	return cfg, true
+}
+
+func corridorConfig(logger *slog.Logger) ([]processor.Corridor, bool) {
+	spec := os.Getenv("TRANSACTION_CORRIDORS")
+	if spec == "" {
+		return nil, false
+	}
+	corridors, err := processor.ParseCorridors(spec)
+	if err != nil {
+		logger.Warn("Ignoring invalid transaction corridors", slog.String("error", err.Error()))
+		return nil, false
+	}
+	return corridors, true
+}
+
+func purposeCodeConfig(logger *slog.Logger) []processor.PurposeCode {
+	spec := os.Getenv("PURPOSE_CODES")
+	if spec == "" {
+		return processor.DefaultPurposeCodes()
+	}
+	codes, err := processor.ParsePurposeCodes(spec)
+	if err != nil {
+		logger.Warn("Ignoring invalid purpose code catalog", slog.String("error", err.Error()))
+		return processor.DefaultPurposeCodes()
+	}
+	return codes
+}
+
+func bearerTokens(envVar string, logger *slog.Logger) map[string]string {
+	spec := os.Getenv(envVar)
+	if spec == "" {
+		return nil
+	}
+	tokens := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		identity, token, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || identity == "" || token == "" {
+			logger.Warn("Ignoring invalid token entry", slog.String("variable", envVar))
+			continue
+		}
+		tokens[token] = identity
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	return tokens
+}
+
+func archiveService(txProcessor *processor.TransactionProcessor, logger *slog.Logger) *service.ArchiveService {
+	spec := os.Getenv("ARCHIVE_STORE")
+	if spec == "" {
+		return nil
+	}
+	store, err := objectstore.Open(spec)
+	if err != nil {
+		logger.Warn("Cold storage disabled, invalid archive store", slog.String("error", err.Error()))
+		return nil
+	}
+	return service.NewArchiveService(txProcessor, store, service.DefaultArchiveConfig(), logger)
+}
+
+func workerCount(logger *slog.Logger) int {
+	raw := os.Getenv("WORKER_POOL_SIZE")
+	if raw == "" {
+		return 10
+	}
+	workers, err := strconv.Atoi(raw)
+	if err != nil || workers <= 0 {
+		logger.Warn("Ignoring invalid worker pool size", slog.String("value", raw))
+		return 10
+	}
+	return workers
+}
+
+func exposureConfig(logger *slog.Logger) service.ExposureConfig {
+	cfg := service.DefaultExposureConfig()
+	if raw := os.Getenv("EXPOSURE_ALERT_THRESHOLD"); raw != "" {
+		if threshold, err := strconv.ParseFloat(raw, 64); err != nil || threshold < 0 {
+			logger.Warn("Ignoring invalid exposure alert threshold", slog.String("value", raw))
+		} else {
+			cfg.DefaultThreshold = threshold
+		}
+	}
+	if spec := os.Getenv("EXPOSURE_THRESHOLDS"); spec != "" {
+		if thresholds, err := service.ParseExposureThresholds(spec); err != nil {
+			logger.Warn("Ignoring invalid exposure thresholds", slog.String("error", err.Error()))
+		} else {
+			cfg.Thresholds = thresholds
+		}
+	}
+	return cfg
+}
+
+func reviewSLAConfig(logger *slog.Logger) service.ReviewSLAConfig {
+	cfg := service.DefaultReviewSLAConfig()
+	if raw := os.Getenv("REVIEW_SLA"); raw != "" {
+		if sla, err := time.ParseDuration(raw); err != nil || sla <= 0 {
+			logger.Warn("Ignoring invalid review SLA", slog.String("value", raw))
+		} else {
+			cfg.SLA = sla
+		}
+	}
+	if policy := os.Getenv("REVIEW_SLA_POLICY"); policy != "" {
+		if policy != processor.ReviewSLAReject && policy != processor.ReviewSLAEscalate {
+			logger.Warn("Ignoring invalid review SLA policy", slog.String("value", policy))
+		} else {
+			cfg.Policy = policy
+		}
+	}
+	return cfg
+}
+
+func limitThresholdConfig(logger *slog.Logger) processor.LimitThresholdConfig {
+	cfg := processor.DefaultLimitThresholdConfig()
+	if spec := os.Getenv("LIMIT_THRESHOLDS"); spec != "" {
+		if percentages, err := processor.ParseLimitThresholds(spec); err != nil {
+			logger.Warn("Ignoring invalid limit thresholds", slog.String("error", err.Error()))
+		} else {
+			cfg.Percentages = percentages
+		}
+	}
+	return cfg
+}
+
+func notificationRouting(logger *slog.Logger) *service.NotificationRoutingTable {
+	spec := os.Getenv("NOTIFICATION_ROUTES")
+	if spec == "" {
+		return nil
+	}
+	routing, err := service.ParseNotificationRoutes(spec)
+	if err != nil {
+		logger.Warn("Ignoring invalid notification routes", slog.String("error", err.Error()))
+		return nil
+	}
+	return routing
+}
+
+func impersonationConfig() service.ImpersonationConfig {
+	cfg := service.DefaultImpersonationConfig()
+	for _, operator := range strings.Split(os.Getenv("IMPERSONATION_OPERATORS"), ",") {
+		if operator = strings.TrimSpace(operator); operator != "" {
+			cfg.SupportOperators = append(cfg.SupportOperators, operator)
+		}
+	}
+	return cfg
+}
+
+func loadSheddingConfig(logger *slog.Logger) api.LoadSheddingConfig {
+	cfg := api.DefaultLoadSheddingConfig()
+	if spec := os.Getenv("LATENCY_SLOS"); spec != "" {
+		if slos, err := api.ParseLatencySLOs(spec); err != nil {
+			logger.Warn("Ignoring invalid latency SLOs", slog.String("error", err.Error()))
+		} else {
+			for endpoint, slo := range slos {
+				if endpoint == "default" {
+					cfg.DefaultSLO = slo
+					continue
+				}
+				cfg.SLOs[endpoint] = slo
+			}
+		}
+	}
+	return cfg
+}
+
+func degradedModeConfig(logger *slog.Logger) processor.DegradedModeConfig {
+	cfg := processor.DefaultDegradedModeConfig()
+	if raw := os.Getenv("DEGRADED_FAILURE_THRESHOLD"); raw != "" {
+		if threshold, err := strconv.Atoi(raw); err != nil || threshold <= 0 {
+			logger.Warn("Ignoring invalid degraded mode failure threshold", slog.String("value", raw))
+		} else {
+			cfg.FailureThreshold = threshold
+		}
+	}
+	if raw := os.Getenv("DEGRADED_COOLDOWN"); raw != "" {
+		if cooldown, err := time.ParseDuration(raw); err != nil || cooldown < 0 {
+			logger.Warn("Ignoring invalid degraded mode cooldown", slog.String("value", raw))
+		} else {
+			cfg.Cooldown = cooldown
+		}
+	}
+	return cfg
+}
+
+func admissionConfig(logger *slog.Logger) processor.AdmissionConfig {
+	cfg := processor.DefaultAdmissionConfig()
+	if raw := os.Getenv("ADMISSION_MAX_QUEUE_DEPTH"); raw != "" {
+		if depth, err := strconv.Atoi(raw); err != nil || depth < 0 {
+			logger.Warn("Ignoring invalid admission queue depth", slog.String("value", raw))
+		} else {
+			cfg.MaxQueueDepth = depth
+		}
+	}
+	if raw := os.Getenv("ADMISSION_MAX_REPOSITORY_LATENCY"); raw != "" {
+		if latency, err := time.ParseDuration(raw); err != nil || latency < 0 {
+			logger.Warn("Ignoring invalid admission repository latency", slog.String("value", raw))
+		} else {
+			cfg.MaxRepositoryLatency = latency
+		}
+	}
+	return cfg
+}
+
+func maintenanceWindows(logger *slog.Logger) []processor.MaintenanceWindow {
+	spec := os.Getenv("MAINTENANCE_WINDOWS")
+	if spec == "" {
+		return nil
+	}
+	windows, err := processor.ParseMaintenanceWindows(spec)
+	if err != nil {
+		logger.Warn("Ignoring invalid maintenance windows", slog.String("error", err.Error()))
+		return nil
+	}
+	return windows
+}
+
+func depositRetryConfig(logger *slog.Logger) processor.DepositRetryConfig {
+	cfg := processor.DefaultDepositRetryConfig()
+	if raw := os.Getenv("DEPOSIT_RETRY_MAX_ATTEMPTS"); raw != "" {
+		if attempts, err := strconv.Atoi(raw); err != nil || attempts <= 0 {
+			logger.Warn("Ignoring invalid deposit retry attempts", slog.String("value", raw))
+		} else {
+			cfg.MaxAttempts = attempts
+		}
+	}
+	if raw := os.Getenv("DEPOSIT_RETRY_BACKOFF"); raw != "" {
+		if backoff, err := time.ParseDuration(raw); err != nil || backoff < 0 {
+			logger.Warn("Ignoring invalid deposit retry backoff", slog.String("value", raw))
+		} else {
+			cfg.Backoff = backoff
+		}
+	}
+	return cfg
+}
+
+func timeModifierConfig(logger *slog.Logger) processor.TimeModifierConfig {
+	cfg := processor.DefaultTimeModifierConfig()
+	if spec := os.Getenv("RISKY_HOURS"); spec != "" {
+		if windows, err := processor.ParseRiskyHours(spec); err != nil {
+			logger.Warn("Ignoring invalid risky hours", slog.String("error", err.Error()))
+		} else {
+			cfg.RiskyHours = windows
+		}
+	}
+	if spec := os.Getenv("RISK_HOLIDAYS"); spec != "" {
+		if calendar, err := processor.ParseHolidayCalendar(spec); err != nil {
+			logger.Warn("Ignoring invalid holiday calendar", slog.String("error", err.Error()))
+		} else {
+			cfg.Holidays = calendar
+		}
+	}
+	if raw := os.Getenv("RISK_HOLIDAY_POINTS"); raw != "" {
+		if points, err := strconv.Atoi(raw); err != nil {
+			logger.Warn("Ignoring invalid holiday risk points", slog.String("value", raw))
+		} else {
+			cfg.HolidayPoints = points
+		}
+	}
+	if name := os.Getenv("RISK_TIMEZONE"); name != "" {
+		if location, err := time.LoadLocation(name); err != nil {
+			logger.Warn("Ignoring invalid risk timezone", slog.String("value", name))
+		} else {
+			cfg.Location = location
+		}
+	}
+	return cfg
+}
+
+func amountTokenizationConfig(logger *slog.Logger) processor.AmountTokenizationConfig {
+	cfg := processor.DefaultAmountTokenizationConfig()
+	if spec := os.Getenv("AMOUNT_TOKENIZED_SINKS"); spec != "" {
+		if sinks, err := processor.ParseAmountSinks(spec); err != nil {
+			logger.Warn("Ignoring invalid amount tokenization sinks", slog.String("error", err.Error()))
+		} else {
+			cfg.Sinks = sinks
+		}
+	}
+	if spec := os.Getenv("AMOUNT_BUCKETS"); spec != "" {
+		if buckets, err := money.ParseAmountBuckets(spec); err != nil {
+			logger.Warn("Ignoring invalid amount buckets", slog.String("error", err.Error()))
+		} else {
+			cfg.Buckets = buckets
+		}
+	}
+	return cfg
+}
+
+func setupSchema(logger *slog.Logger) *schema.Migrator {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil
+	}
+	driver := os.Getenv("DATABASE_DRIVER")
+	if driver == "" {
+		driver = "postgres"
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		logger.Error("Failed to open database", slog.String("driver", driver), slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	migrator, err := schema.NewMigrator(db, logger)
+	if err != nil {
+		logger.Error("Failed to load schema migrations", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	if os.Getenv("SCHEMA_AUTO_MIGRATE") == "true" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			logger.Error("Schema migration failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		logger.Info("Schema is up to date", slog.Int("applied", len(applied)))
+	}
+	return migrator
+}
+
 func setupLogger() *slog.Logger {
 	opts := &slog.HandlerOptions{
 		Level: slog.LevelInfo,
@@ -58,11 +578,107 @@ func setupNotificationService(logger *slog.Logger) *service.NotificationService
 		smsService,
 		nil,
 		nil,
-		3,
+		service.DefaultNotificationScalingConfig(),
 		logger,
 	)
 }
 
+func setupScheduler(
+	logger *slog.Logger,
+	txProcessor *processor.TransactionProcessor,
+	transferGraph *processor.TransferGraph,
+	limitChanges *service.LimitChangeService,
+	scheduledNotifications *service.ScheduledNotificationService,
+	transactionExpiry *service.TransactionExpiryService,
+	reviewSLA *service.ReviewSLAService,
+	installments *service.InstallmentService,
+	payouts *service.PayoutService,
+	exposure *service.ExposureService,
+	reserves *service.ReservesService,
+	snapshots *service.EODSnapshotService,
+	dataQuality *service.DataQualityService,
+	webhooks *service.WebhookService,
+	archive *service.ArchiveService,
+	ruleRepo *memory.RuleRepository,
+	accountRepo *memory.AccountRepository,
+	txRepo *memory.TransactionRepository,
+) *scheduler.Scheduler {
+	jobScheduler := scheduler.New(logger).
+		WithLocker(scheduler.NewMemoryLocker(), instanceID()).
+		WithHold(txProcessor.MaintenanceHold)
+
+	ruleEvaluator := processor.NewScheduledRuleEvaluator(txProcessor.RuleEngine(), ruleRepo, accountRepo, txRepo, logger).
+		WithEventPublisher(webhooks)
+	if err := ruleEvaluator.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register scheduled rules job", slog.String("error", err.Error()))
+	}
+
+	if err := txProcessor.RegisterDegradedDrain(jobScheduler); err != nil {
+		logger.Error("Failed to register degraded drain job", slog.String("error", err.Error()))
+	}
+	if err := txProcessor.RegisterDepositRetry(jobScheduler); err != nil {
+		logger.Error("Failed to register deposit retry job", slog.String("error", err.Error()))
+	}
+
+	graphAnalyzer := processor.NewGraphAnalyzer(transferGraph, accountRepo, logger)
+	if err := graphAnalyzer.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register graph analysis job", slog.String("error", err.Error()))
+	}
+
+	if err := limitChanges.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register limit activation job", slog.String("error", err.Error()))
+	}
+
+	if err := scheduledNotifications.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register scheduled notification job", slog.String("error", err.Error()))
+	}
+
+	if err := transactionExpiry.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register transaction expiry job", slog.String("error", err.Error()))
+	}
+
+	if err := reviewSLA.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register review SLA job", slog.String("error", err.Error()))
+	}
+
+	if err := installments.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register installment dispatch job", slog.String("error", err.Error()))
+	}
+
+	if err := payouts.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register payout dispatch job", slog.String("error", err.Error()))
+	}
+
+	if err := exposure.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register currency exposure job", slog.String("error", err.Error()))
+	}
+
+	if err := reserves.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register reserves report job", slog.String("error", err.Error()))
+	}
+
+	if err := snapshots.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register end-of-day snapshot job", slog.String("error", err.Error()))
+	}
+
+	if err := dataQuality.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register data quality job", slog.String("error", err.Error()))
+	}
+
+	if err := webhooks.Register(jobScheduler); err != nil {
+		logger.Error("Failed to register webhook event log prune job", slog.String("error", err.Error()))
+	}
+
+	if archive != nil {
+		if err := archive.Register(jobScheduler); err != nil {
+			logger.Error("Failed to register cold storage archive job", slog.String("error", err.Error()))
+		}
+	}
+
+	jobScheduler.Start()
+	return jobScheduler
+}
+
 func startHTTPServer(apiHandler *api.APIHandler, logger *slog.Logger) *http.Server {
 	mux := http.NewServeMux()
 
@@ -75,7 +691,7 @@ func startHTTPServer(apiHandler *api.APIHandler, logger *slog.Logger) *http.Serv
 
 	server := &http.Server{
 		Addr:         ":8080",
-		Handler:      mux,
+		Handler:      apiHandler.LoadSheddingMiddleware(apiHandler.QuotaMiddleware(apiHandler.ImpersonationMiddleware(mux))),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -96,7 +712,10 @@ func waitForShutdown(
 	logger *slog.Logger,
 	httpServer *http.Server,
 	metricsServer *http.Server,
+	jobScheduler *scheduler.Scheduler,
+	txProcessor *processor.TransactionProcessor,
 	notificationService *service.NotificationService,
+	webhooks *service.WebhookService,
 ) {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -115,9 +734,20 @@ func waitForShutdown(
 		logger.Error("Metrics server shutdown failed", slog.String("error", err.Error()))
 	}
 
+	if err := jobScheduler.Stop(ctx); err != nil {
+		logger.Error("Scheduler shutdown failed", slog.String("error", err.Error()))
+	}
+
+	if err := txProcessor.Shutdown(ctx); err != nil {
+		logger.Error("Transaction processor shutdown failed", slog.String("error", err.Error()))
+	}
+
 	if err := notificationService.Shutdown(ctx); err != nil {
 		logger.Error("Notification service shutdown failed", slog.String("error", err.Error()))
 	}
+	if err := webhooks.Shutdown(ctx); err != nil {
+		logger.Error("Webhook service shutdown failed", slog.String("error", err.Error()))
+	}
 	if err := metrics.NewMetricsCollector(logger).Shutdown(ctx); err != nil {
 		logger.Error("Metrics collector shutdown failed", slog.String("error", err.Error()))
 	}
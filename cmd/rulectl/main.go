@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const usage = `Usage: rulectl <command> [flags]
+
+Commands:
+  export    Download the active rule set from the API
+  import    Upload a rule set file to the API (validated, then swapped atomically)
+  validate  Validate a rule set file locally without contacting the API
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(os.Args[2:])
+	case "import":
+		err = runImport(os.Args[2:])
+	case "validate":
+		err = runValidate(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rulectl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	addr := fs.String("addr", "http://localhost:8080", "API base URL")
+	format := fs.String("format", processor.RuleSetFormatYAML, "output format (yaml or json)")
+	out := fs.String("out", "", "output file (defaults to stdout)")
+	fs.Parse(args)
+
+	url := fmt.Sprintf("%s/api/v1/rules/export?format=%s", strings.TrimRight(*addr, "/"), *format)
+	body, err := doRequest(http.MethodGet, url, "", nil)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(body)
+		return err
+	}
+	return os.WriteFile(*out, body, 0o644)
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	addr := fs.String("addr", "http://localhost:8080", "API base URL")
+	file := fs.String("file", "", "rule set file to import")
+	dryRun := fs.Bool("dry-run", false, "validate on the server without applying")
+	fs.Parse(args)
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	format := formatFromPath(*file)
+	url := fmt.Sprintf("%s/api/v1/rules/import?format=%s&dry_run=%t", strings.TrimRight(*addr, "/"), format, *dryRun)
+	body, err := doRequest(http.MethodPost, url, "application/"+format, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(body)
+	return err
+}
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	file := fs.String("file", "", "rule set file to validate")
+	fs.Parse(args)
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	doc, err := processor.UnmarshalRuleSet(data, formatFromPath(*file))
+	if err != nil {
+		return err
+	}
+
+	engine := processor.NewRuleEngine(memory.NewRuleRepository(), nil)
+	if err := engine.ValidateRuleSet(doc); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d rules OK\n", len(doc.Rules))
+	return nil
+}
+
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return processor.RuleSetFormatYAML
+	default:
+		return processor.RuleSetFormatJSON
+	}
+}
+
+func doRequest(method, url, contentType string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s: %s: %s", method, url, resp.Status, respBody)
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 200
+)
+
+func (h *APIHandler) WithActivity(activity *service.ActivityService) *APIHandler {
+	h.activity = activity
+	return h
+}
+
+func (h *APIHandler) AccountActivityHandler(w http.ResponseWriter, r *http.Request) {
+	if h.activity == nil {
+		h.sendError(w, "Activity feed is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	limit := defaultActivityLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxActivityLimit {
+			h.sendError(w, fmt.Sprintf("limit must be between 1 and %d", maxActivityLimit), http.StatusBadRequest, "INVALID_REQUEST")
+			return
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	feed, err := h.activity.Feed(ctx, r.PathValue("id"), r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			h.sendError(w, "Account not found", http.StatusNotFound, "NOT_FOUND")
+		case errors.Is(err, service.ErrInvalidActivityCursor):
+			h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		default:
+			h.sendError(w, "Failed to load account activity", http.StatusInternalServerError, "SERVER_ERROR")
+		}
+		return
+	}
+
+	h.sendJSON(w, feed, http.StatusOK)
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+const operatorHeader = "X-Operator-ID"
+
+type RiskOverrideRequest struct {
+	Outcome   processor.OverrideOutcome `json:"outcome"`
+	RiskScore *int                      `json:"risk_score,omitempty"`
+	Reason    string                    `json:"reason"`
+}
+
+func (h *APIHandler) OverrideRiskHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator, ok := h.approvalOperator(w, r)
+	if !ok {
+		return
+	}
+
+	var req RiskOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	override := processor.RiskOverride{
+		Outcome:   req.Outcome,
+		RiskScore: req.RiskScore,
+		Reason:    req.Reason,
+		Operator:  operator,
+	}
+	if h.dualControl != nil {
+		h.stageAction(ctx, w, service.ActionRiskOverride, operator, req.Reason, service.TransactionRiskOverride{
+			TransactionID: r.PathValue("id"),
+			Override:      override,
+		})
+		return
+	}
+
+	tx, err := h.processor.OverrideRiskDecision(ctx, r.PathValue("id"), override)
+	if err != nil {
+		h.logger.Error("Risk override failed",
+			slog.String("transaction_id", r.PathValue("id")),
+			slog.String("operator", operator),
+			slog.String("error", err.Error()))
+		h.sendOverrideError(w, err)
+		return
+	}
+
+	h.sendJSON(w, newTransactionResponse(tx), http.StatusOK)
+}
+
+func (h *APIHandler) sendOverrideError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, processor.ErrInvalidOverride):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, "Transaction not found", http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrTransactionConflict):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	case errors.Is(err, processor.ErrAuditNotConfigured):
+		h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, repository.ErrInsufficientFunds), errors.Is(err, repository.ErrAccountSuspended):
+		h.sendError(w, fmt.Sprintf("Execution failed: %v", err), http.StatusUnprocessableEntity, "EXECUTION_FAILED")
+	case errors.Is(err, context.DeadlineExceeded):
+		h.sendError(w, fmt.Sprintf("Override timed out: %v", err), http.StatusGatewayTimeout, "PROCESSING_TIMEOUT")
+	default:
+		h.sendError(w, fmt.Sprintf("Override failed: %v", err), http.StatusInternalServerError, "PROCESSING_ERROR")
+	}
+}
+
+type BalanceAdjustmentRequest struct {
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+}
+
+type BalanceMigrationRequest struct {
+	Reference   string                     `json:"reference"`
+	Adjustments []BalanceAdjustmentRequest `json:"adjustments"`
+}
+
+type BalanceMigrationResponse struct {
+	Reference    string                `json:"reference"`
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+func (h *APIHandler) ApplyBalanceMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator, ok := h.approvalOperator(w, r)
+	if !ok {
+		return
+	}
+
+	var req BalanceMigrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	migration := processor.BalanceMigration{Reference: req.Reference, Operator: operator}
+	for _, adjustment := range req.Adjustments {
+		migration.Adjustments = append(migration.Adjustments, processor.BalanceAdjustment{
+			AccountID: adjustment.AccountID,
+			Amount:    adjustment.Amount,
+			Reason:    adjustment.Reason,
+		})
+	}
+
+	if h.dualControl != nil {
+		h.stageAction(ctx, w, service.ActionBalanceMigration, operator, req.Reference, migration)
+		return
+	}
+
+	txs, err := h.processor.ApplyBalanceMigration(ctx, migration)
+	if err != nil {
+		h.logger.Error("Balance migration failed",
+			slog.String("migration_reference", req.Reference),
+			slog.String("operator", operator),
+			slog.String("error", err.Error()))
+		switch {
+		case errors.Is(err, processor.ErrInvalidMigration):
+			h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+		case errors.Is(err, repository.ErrNotFound):
+			h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+		case errors.Is(err, repository.ErrDuplicate):
+			h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+		case errors.Is(err, processor.ErrAuditNotConfigured):
+			h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+		default:
+			h.sendError(w, fmt.Sprintf("Migration failed: %v", err), http.StatusInternalServerError, "PROCESSING_ERROR")
+		}
+		return
+	}
+
+	resp := BalanceMigrationResponse{Reference: req.Reference, Transactions: make([]TransactionResponse, 0, len(txs))}
+	for _, tx := range txs {
+		resp.Transactions = append(resp.Transactions, newTransactionResponse(tx))
+	}
+	h.sendJSON(w, resp, http.StatusCreated)
+}
+
+func (h *APIHandler) ExportDecisionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, to, format, err := parseExportRequest(r)
+	if err != nil {
+		h.sendExportRequestError(w, err)
+		return
+	}
+
+	h.streamExport(w, format, "decisions", func(out io.Writer) error {
+		return h.processor.ExportDecisions(ctx, out, from, to, format)
+	})
+}
+
+func (h *APIHandler) ListSystemAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	accounts, err := h.processor.SystemAccounts(ctx)
+	if err != nil {
+		h.sendError(w, "Failed to list system accounts", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, accounts, http.StatusOK)
+}
+
+func (h *APIHandler) ShadowReportHandler(w http.ResponseWriter, r *http.Request) {
+	report, enabled := h.processor.ShadowReport()
+	if !enabled {
+		h.sendError(w, "Shadow pipeline is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) ResetShadowReportHandler(w http.ResponseWriter, r *http.Request) {
+	if _, enabled := h.processor.ShadowReport(); !enabled {
+		h.sendError(w, "Shadow pipeline is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	h.processor.ResetShadowReport()
+	w.WriteHeader(http.StatusNoContent)
+}
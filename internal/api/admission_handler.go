@@ -0,0 +1,38 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/processor"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+func (h *APIHandler) admitTransaction(w http.ResponseWriter, r *http.Request) bool {
+	err := h.processor.Admit()
+	if err == nil {
+		return true
+	}
+
+	var overCapacity *processor.OverCapacityError
+	if errors.As(err, &overCapacity) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(overCapacity.RetryAfter.Seconds()))))
+		h.logger.WarnContext(r.Context(), "Transaction rejected by admission control",
+			slog.String("signal", overCapacity.Signal),
+			slog.Float64("value", overCapacity.Value),
+			slog.Float64("limit", overCapacity.Limit))
+	}
+	h.sendError(w, "Service is over capacity, retry later", http.StatusServiceUnavailable, "OVER_CAPACITY")
+	return false
+}
+
+func (h *APIHandler) AdmissionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status, enabled := h.processor.AdmissionStatus()
+	if !enabled {
+		h.sendError(w, "Admission control is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
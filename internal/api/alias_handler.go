@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type RegisterAliasRequest struct {
+	Type  domain.AliasType `json:"type"`
+	Value string           `json:"value"`
+}
+
+func (h *APIHandler) WithAliases(aliases *service.AliasService) *APIHandler {
+	h.aliases = aliases
+	return h
+}
+
+func (h *APIHandler) resolveAccountAliases(ctx context.Context, tx *domain.Transaction) error {
+	if h.aliases == nil {
+		return nil
+	}
+
+	for _, ref := range []struct {
+		accountID *string
+		metadata  string
+	}{
+		{&tx.FromAccountID, service.MetadataFromAccountAlias},
+		{&tx.ToAccountID, service.MetadataToAccountAlias},
+	} {
+		if *ref.accountID == "" {
+			continue
+		}
+		resolved, aliased, err := h.aliases.ResolveAccountID(ctx, *ref.accountID)
+		if err != nil {
+			return err
+		}
+		if aliased {
+			tx.AddMetadata(ref.metadata, *ref.accountID)
+			*ref.accountID = resolved
+		}
+	}
+	return nil
+}
+
+func (h *APIHandler) RegisterAliasHandler(w http.ResponseWriter, r *http.Request) {
+	if h.aliases == nil {
+		h.sendError(w, "Account aliases are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req RegisterAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	alias, err := h.aliases.Register(ctx, r.PathValue("id"), req.Type, req.Value)
+	if err != nil {
+		h.sendAliasError(w, err)
+		return
+	}
+
+	h.sendJSON(w, alias, http.StatusCreated)
+}
+
+func (h *APIHandler) ListAliasesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.aliases == nil {
+		h.sendError(w, "Account aliases are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	aliases, err := h.aliases.List(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendAliasError(w, err)
+		return
+	}
+
+	h.sendJSON(w, aliases, http.StatusOK)
+}
+
+func (h *APIHandler) DeleteAliasHandler(w http.ResponseWriter, r *http.Request) {
+	if h.aliases == nil {
+		h.sendError(w, "Account aliases are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	err := h.aliases.Remove(ctx, r.PathValue("id"), domain.AliasType(r.PathValue("type")), r.PathValue("value"))
+	if err != nil {
+		h.sendAliasError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIHandler) ResolveAliasHandler(w http.ResponseWriter, r *http.Request) {
+	if h.aliases == nil {
+		h.sendError(w, "Account aliases are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	alias, err := h.aliases.Lookup(ctx, domain.AliasType(r.PathValue("type")), r.PathValue("value"))
+	if err != nil {
+		h.sendAliasError(w, err)
+		return
+	}
+
+	h.sendJSON(w, alias, http.StatusOK)
+}
+
+func (h *APIHandler) sendAliasError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidAlias):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, err.Error(), http.StatusConflict, "DUPLICATE")
+	default:
+		h.sendError(w, "Failed to process alias request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
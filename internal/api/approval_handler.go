@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+var errOperatorCredentials = errors.New("missing or unknown operator credentials")
+
+type LimitOverrideRequest struct {
+	LimitType domain.LimitType `json:"limit_type"`
+	Limit     float64          `json:"limit"`
+	Reason    string           `json:"reason"`
+}
+
+type ApprovalDecisionRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// OperatorAuthenticator resolves the operator from request credentials.
+type OperatorAuthenticator func(r *http.Request) (string, error)
+
+func OperatorTokens(tokens map[string]string) OperatorAuthenticator {
+	return bearerTokens(tokens, errOperatorCredentials)
+}
+
+func bearerTokens(tokens map[string]string, errCredentials error) func(r *http.Request) (string, error) {
+	return func(r *http.Request) (string, error) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || presented == "" {
+			return "", errCredentials
+		}
+		for token, identity := range tokens {
+			if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+				return identity, nil
+			}
+		}
+		return "", errCredentials
+	}
+}
+
+func (h *APIHandler) WithDualControl(dualControl *service.DualControlService) *APIHandler {
+	h.dualControl = dualControl
+	return h
+}
+
+func (h *APIHandler) WithOperatorAuthenticator(authenticate OperatorAuthenticator) *APIHandler {
+	h.operatorAuth = authenticate
+	return h
+}
+
+func (h *APIHandler) approvalOperator(w http.ResponseWriter, r *http.Request) (string, bool) {
+	asserted := r.Header.Get(operatorHeader)
+	if h.operatorAuth == nil {
+		if asserted == "" {
+			h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+			return "", false
+		}
+		return asserted, true
+	}
+
+	operator, err := h.operatorAuth(r)
+	if err != nil || operator == "" {
+		h.sendError(w, "Operator credentials are required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return "", false
+	}
+	if asserted != "" && asserted != operator {
+		h.sendError(w, "Operator identity does not match credentials", http.StatusForbidden, "OPERATOR_MISMATCH")
+		return "", false
+	}
+	return operator, true
+}
+
+func (h *APIHandler) stageAction(ctx context.Context, w http.ResponseWriter, action, maker, reason string, payload interface{}) {
+	approval, err := h.dualControl.Stage(ctx, action, maker, reason, payload)
+	if err != nil {
+		h.sendApprovalError(w, err)
+		return
+	}
+	h.sendJSON(w, approval, http.StatusAccepted)
+}
+
+func (h *APIHandler) OverrideLimitHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator, ok := h.approvalOperator(w, r)
+	if !ok {
+		return
+	}
+
+	var req LimitOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	override := processor.LimitOverride{
+		AccountID: r.PathValue("id"),
+		LimitType: req.LimitType,
+		Limit:     req.Limit,
+		Reason:    req.Reason,
+		Operator:  operator,
+	}
+	if h.dualControl != nil {
+		h.stageAction(ctx, w, service.ActionLimitOverride, operator, req.Reason, override)
+		return
+	}
+
+	account, err := h.processor.OverrideAccountLimit(ctx, override)
+	if err != nil {
+		h.sendApprovalError(w, err)
+		return
+	}
+
+	h.sendJSON(w, account, http.StatusOK)
+}
+
+func (h *APIHandler) ListApprovalsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.dualControl == nil {
+		h.sendError(w, "Dual control is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	approvals, err := h.dualControl.List(ctx, domain.ApprovalStatus(r.URL.Query().Get("status")))
+	if err != nil {
+		h.sendApprovalError(w, err)
+		return
+	}
+	if approvals == nil {
+		approvals = []*domain.ApprovalRequest{}
+	}
+
+	h.sendJSON(w, approvals, http.StatusOK)
+}
+
+func (h *APIHandler) GetApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	if h.dualControl == nil {
+		h.sendError(w, "Dual control is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	approval, err := h.dualControl.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendApprovalError(w, err)
+		return
+	}
+
+	h.sendJSON(w, approval, http.StatusOK)
+}
+
+func (h *APIHandler) ApproveHandler(w http.ResponseWriter, r *http.Request) {
+	if h.dualControl == nil {
+		h.sendError(w, "Dual control is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	checker, ok := h.approvalOperator(w, r)
+	if !ok {
+		return
+	}
+
+	approval, err := h.dualControl.Approve(ctx, r.PathValue("id"), checker)
+	if err != nil {
+		h.logger.Error("Approval failed",
+			slog.String("approval_id", r.PathValue("id")),
+			slog.String("checker", checker),
+			slog.String("error", err.Error()))
+		h.sendApprovalError(w, err)
+		return
+	}
+
+	h.sendJSON(w, approval, http.StatusOK)
+}
+
+func (h *APIHandler) RejectHandler(w http.ResponseWriter, r *http.Request) {
+	if h.dualControl == nil {
+		h.sendError(w, "Dual control is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	checker, ok := h.approvalOperator(w, r)
+	if !ok {
+		return
+	}
+
+	var req ApprovalDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	approval, err := h.dualControl.Reject(ctx, r.PathValue("id"), checker, req.Note)
+	if err != nil {
+		h.sendApprovalError(w, err)
+		return
+	}
+
+	h.sendJSON(w, approval, http.StatusOK)
+}
+
+func (h *APIHandler) sendApprovalError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrSelfApproval):
+		h.sendError(w, err.Error(), http.StatusForbidden, "SELF_APPROVAL")
+	case errors.Is(err, service.ErrApprovalState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	case errors.Is(err, service.ErrApprovalExecution):
+		h.sendError(w, err.Error(), http.StatusUnprocessableEntity, "EXECUTION_FAILED")
+	case errors.Is(err, service.ErrInvalidApproval), errors.Is(err, service.ErrUnknownAction), errors.Is(err, processor.ErrInvalidLimitOverride):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, processor.ErrAuditNotConfigured):
+		h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+	default:
+		h.sendError(w, "Failed to process approval", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/service"
+	"finance_manager/pkg/objectstore"
+	"net/http"
+)
+
+type CreateArchiveRequest struct {
+	Period string `json:"period"`
+}
+
+func (h *APIHandler) WithArchive(archive *service.ArchiveService) *APIHandler {
+	h.archive = archive
+	return h
+}
+
+func (h *APIHandler) CreateArchiveHandler(w http.ResponseWriter, r *http.Request) {
+	if h.archive == nil {
+		h.sendError(w, "Cold storage is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req CreateArchiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	manifest, err := h.archive.Archive(ctx, req.Period)
+	if err != nil {
+		h.sendArchiveError(w, err)
+		return
+	}
+
+	h.sendJSON(w, manifest, http.StatusCreated)
+}
+
+func (h *APIHandler) GetArchiveManifestHandler(w http.ResponseWriter, r *http.Request) {
+	if h.archive == nil {
+		h.sendError(w, "Cold storage is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	manifest, err := h.archive.Manifest(ctx, r.PathValue("period"))
+	if err != nil {
+		h.sendArchiveError(w, err)
+		return
+	}
+
+	h.sendJSON(w, manifest, http.StatusOK)
+}
+
+func (h *APIHandler) sendArchiveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidArchivePeriod):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrPeriodNotClosed):
+		h.sendError(w, err.Error(), http.StatusUnprocessableEntity, "PERIOD_NOT_CLOSED")
+	case errors.Is(err, service.ErrAlreadyArchived):
+		h.sendError(w, err.Error(), http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, objectstore.ErrNotFound):
+		h.sendError(w, "Archive not found", http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrArchiveCorrupt):
+		h.sendError(w, err.Error(), http.StatusUnprocessableEntity, "ARCHIVE_CORRUPT")
+	default:
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
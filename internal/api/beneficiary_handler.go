@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type AddBeneficiaryRequest struct {
+	BeneficiaryAccountID string `json:"beneficiary_account_id"`
+}
+
+type ConfirmBeneficiaryRequest struct {
+	OTP string `json:"otp"`
+}
+
+func (h *APIHandler) WithBeneficiaries(beneficiaries *service.BeneficiaryService) *APIHandler {
+	h.beneficiaries = beneficiaries
+	return h
+}
+
+func (h *APIHandler) AddBeneficiaryHandler(w http.ResponseWriter, r *http.Request) {
+	if h.beneficiaries == nil {
+		h.sendError(w, "Trusted beneficiaries are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req AddBeneficiaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	beneficiary, err := h.beneficiaries.AddTrusted(ctx, r.PathValue("id"), req.BeneficiaryAccountID)
+	if err != nil {
+		h.sendBeneficiaryError(w, err)
+		return
+	}
+
+	h.sendJSON(w, beneficiary, http.StatusAccepted)
+}
+
+func (h *APIHandler) ListBeneficiariesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.beneficiaries == nil {
+		h.sendError(w, "Trusted beneficiaries are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	beneficiaries, err := h.beneficiaries.List(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendBeneficiaryError(w, err)
+		return
+	}
+
+	h.sendJSON(w, beneficiaries, http.StatusOK)
+}
+
+func (h *APIHandler) ConfirmBeneficiaryHandler(w http.ResponseWriter, r *http.Request) {
+	if h.beneficiaries == nil {
+		h.sendError(w, "Trusted beneficiaries are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req ConfirmBeneficiaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OTP == "" {
+		h.sendError(w, "OTP is required", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	beneficiary, err := h.beneficiaries.Confirm(ctx, r.PathValue("id"), req.OTP)
+	if err != nil {
+		h.sendBeneficiaryError(w, err)
+		return
+	}
+
+	h.sendJSON(w, beneficiary, http.StatusOK)
+}
+
+func (h *APIHandler) RevokeBeneficiaryHandler(w http.ResponseWriter, r *http.Request) {
+	if h.beneficiaries == nil {
+		h.sendError(w, "Trusted beneficiaries are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	beneficiary, err := h.beneficiaries.Revoke(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendBeneficiaryError(w, err)
+		return
+	}
+
+	h.sendJSON(w, beneficiary, http.StatusOK)
+}
+
+func (h *APIHandler) sendBeneficiaryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, "Beneficiary already registered", http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidBeneficiary):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrInvalidOTP):
+		h.sendError(w, "Invalid verification code", http.StatusUnauthorized, "INVALID_OTP")
+	case errors.Is(err, service.ErrOTPExpired):
+		h.sendError(w, "Verification code expired", http.StatusGone, "OTP_EXPIRED")
+	case errors.Is(err, service.ErrBeneficiaryState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process beneficiary", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type CreateBudgetRequest struct {
+	Category string              `json:"category"`
+	Limit    float64             `json:"limit"`
+	Period   domain.BudgetPeriod `json:"period"`
+}
+
+func (h *APIHandler) WithBudgets(budgets *service.BudgetService) *APIHandler {
+	h.budgets = budgets
+	return h
+}
+
+func (h *APIHandler) CreateBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	if h.budgets == nil {
+		h.sendError(w, "Budgets are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req CreateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	budget, err := h.budgets.Create(ctx, r.PathValue("id"), req.Category, req.Limit, req.Period)
+	if err != nil {
+		h.sendBudgetError(w, err)
+		return
+	}
+
+	h.sendJSON(w, budget, http.StatusCreated)
+}
+
+func (h *APIHandler) ListBudgetsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.budgets == nil {
+		h.sendError(w, "Budgets are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	budgets, err := h.budgets.List(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendBudgetError(w, err)
+		return
+	}
+
+	h.sendJSON(w, budgets, http.StatusOK)
+}
+
+func (h *APIHandler) DeleteBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	if h.budgets == nil {
+		h.sendError(w, "Budgets are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if err := h.budgets.Delete(ctx, r.PathValue("id"), r.PathValue("budgetId")); err != nil {
+		h.sendBudgetError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIHandler) sendBudgetError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, "Budget already exists for this category", http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidBudget):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	default:
+		h.sendError(w, "Failed to process budget", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type ReceiveChargebackRequest struct {
+	TransactionID string `json:"transaction_id"`
+	ReasonCode    string `json:"reason_code"`
+}
+
+type RepresentChargebackRequest struct {
+	Note string `json:"note"`
+}
+
+type DecideChargebackRequest struct {
+	Outcome domain.ChargebackStatus `json:"outcome"`
+}
+
+func (h *APIHandler) WithChargebacks(chargebacks *service.ChargebackService) *APIHandler {
+	h.chargebacks = chargebacks
+	return h
+}
+
+func (h *APIHandler) ReceiveChargebackHandler(w http.ResponseWriter, r *http.Request) {
+	if h.chargebacks == nil {
+		h.sendError(w, "Chargebacks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req ReceiveChargebackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	chargeback, err := h.chargebacks.Receive(ctx, req.TransactionID, req.ReasonCode)
+	if err != nil {
+		h.sendChargebackError(w, err)
+		return
+	}
+
+	h.sendJSON(w, chargeback, http.StatusCreated)
+}
+
+func (h *APIHandler) GetChargebackHandler(w http.ResponseWriter, r *http.Request) {
+	if h.chargebacks == nil {
+		h.sendError(w, "Chargebacks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	chargeback, err := h.chargebacks.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendChargebackError(w, err)
+		return
+	}
+
+	h.sendJSON(w, chargeback, http.StatusOK)
+}
+
+func (h *APIHandler) RepresentChargebackHandler(w http.ResponseWriter, r *http.Request) {
+	if h.chargebacks == nil {
+		h.sendError(w, "Chargebacks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req RepresentChargebackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	chargeback, err := h.chargebacks.Represent(ctx, r.PathValue("id"), req.Note)
+	if err != nil {
+		h.sendChargebackError(w, err)
+		return
+	}
+
+	h.sendJSON(w, chargeback, http.StatusOK)
+}
+
+func (h *APIHandler) DecideChargebackHandler(w http.ResponseWriter, r *http.Request) {
+	if h.chargebacks == nil {
+		h.sendError(w, "Chargebacks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req DecideChargebackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	chargeback, err := h.chargebacks.Decide(ctx, r.PathValue("id"), req.Outcome)
+	if err != nil {
+		h.sendChargebackError(w, err)
+		return
+	}
+
+	h.sendJSON(w, chargeback, http.StatusOK)
+}
+
+func (h *APIHandler) MerchantChargebackStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.chargebacks == nil {
+		h.sendError(w, "Chargebacks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, h.chargebacks.MerchantStats(r.PathValue("id")), http.StatusOK)
+}
+
+func (h *APIHandler) sendChargebackError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, "Transaction already has a chargeback", http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidChargeback):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrChargebackState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process chargeback", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type RecordConsentRequest struct {
+	Channel string                `json:"channel"`
+	Purpose domain.ConsentPurpose `json:"purpose"`
+	Granted bool                  `json:"granted"`
+	Source  string                `json:"source"`
+}
+
+func (h *APIHandler) WithConsent(consents *service.ConsentService) *APIHandler {
+	h.consents = consents
+	return h
+}
+
+func (h *APIHandler) RecordConsentHandler(w http.ResponseWriter, r *http.Request) {
+	if h.consents == nil {
+		h.sendError(w, "Consent tracking is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req RecordConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	record, err := h.consents.Record(ctx, &domain.ConsentRecord{
+		UserID:  r.PathValue("id"),
+		Channel: req.Channel,
+		Purpose: req.Purpose,
+		Granted: req.Granted,
+		Source:  req.Source,
+	})
+	if err != nil {
+		h.sendConsentError(w, err)
+		return
+	}
+
+	h.sendJSON(w, record, http.StatusCreated)
+}
+
+func (h *APIHandler) ListConsentsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.consents == nil {
+		h.sendError(w, "Consent tracking is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	records, err := h.consents.Current(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendConsentError(w, err)
+		return
+	}
+
+	h.sendJSON(w, records, http.StatusOK)
+}
+
+func (h *APIHandler) ConsentHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if h.consents == nil {
+		h.sendError(w, "Consent tracking is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	records, err := h.consents.History(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendConsentError(w, err)
+		return
+	}
+
+	h.sendJSON(w, records, http.StatusOK)
+}
+
+func (h *APIHandler) sendConsentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidConsent):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	default:
+		h.sendError(w, "Failed to process consent request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
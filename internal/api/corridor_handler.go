@@ -0,0 +1,23 @@
+package api
+
+import "net/http"
+
+func (h *APIHandler) ListCorridorsHandler(w http.ResponseWriter, r *http.Request) {
+	corridors, enabled := h.processor.Corridors()
+	if !enabled {
+		h.sendError(w, "Transaction corridors are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, corridors, http.StatusOK)
+}
+
+func (h *APIHandler) ListPurposeCodesHandler(w http.ResponseWriter, r *http.Request) {
+	codes, enabled := h.processor.PurposeCodes()
+	if !enabled {
+		h.sendError(w, "Purpose codes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, codes, http.StatusOK)
+}
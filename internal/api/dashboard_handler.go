@@ -0,0 +1,131 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultDashboardWindow = 24 * time.Hour
+	defaultDashboardLimit  = 10
+	maxDashboardLimit      = 100
+)
+
+func parseDashboardWindow(r *http.Request) (time.Time, time.Time, error) {
+	to := time.Now()
+	from := to.Add(-defaultDashboardWindow)
+	var err error
+	if raw := r.URL.Query().Get("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, fmt.Errorf("invalid from timestamp, expected RFC3339")
+		}
+	}
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, fmt.Errorf("invalid to timestamp, expected RFC3339")
+		}
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("from must not be after to")
+	}
+	return from, to, nil
+}
+
+func parseDashboardLimit(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return defaultDashboardLimit, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 || limit > maxDashboardLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxDashboardLimit)
+	}
+	return limit, nil
+}
+
+func (h *APIHandler) DashboardStatusCountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	from, to, err := parseDashboardWindow(r)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	counts, err := h.processor.TransactionStatusCounts(ctx, from, to)
+	if err != nil {
+		h.sendError(w, "Failed to aggregate transactions", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, counts, http.StatusOK)
+}
+
+func (h *APIHandler) DashboardApprovalQueueHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	stats, err := h.processor.ApprovalQueue(ctx, time.Now())
+	if err != nil {
+		h.sendError(w, "Failed to aggregate approval queue", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, stats, http.StatusOK)
+}
+
+func (h *APIHandler) DashboardFraudFlagsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	from, to, err := parseDashboardWindow(r)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	rates, err := h.processor.FraudFlagRates(ctx, from, to)
+	if err != nil {
+		h.sendError(w, "Failed to aggregate fraud flags", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, rates, http.StatusOK)
+}
+
+func (h *APIHandler) DashboardBusiestAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	from, to, err := parseDashboardWindow(r)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+	limit, err := parseDashboardLimit(r)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	accounts, err := h.processor.BusiestAccounts(ctx, from, to, limit)
+	if err != nil {
+		h.sendError(w, "Failed to aggregate account activity", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, accounts, http.StatusOK)
+}
+
+func (h *APIHandler) DashboardRuleLeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseDashboardLimit(r)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	h.sendJSON(w, h.processor.RuleEngine().RuleLeaderboard(limit), http.StatusOK)
+}
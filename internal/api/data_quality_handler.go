@@ -0,0 +1,66 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+func (h *APIHandler) WithDataQuality(dataQuality *service.DataQualityService) *APIHandler {
+	h.dataQuality = dataQuality
+	return h
+}
+
+func (h *APIHandler) RunDataQualityCheckHandler(w http.ResponseWriter, r *http.Request) {
+	if h.dataQuality == nil {
+		h.sendError(w, "Data quality checks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	report, err := h.dataQuality.Run(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrSnapshotUnsupported) {
+			h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_SUPPORTED")
+			return
+		}
+		h.sendError(w, "Failed to run data quality check", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) LatestDataQualityReportHandler(w http.ResponseWriter, r *http.Request) {
+	if h.dataQuality == nil {
+		h.sendError(w, "Data quality checks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	report, err := h.dataQuality.Latest()
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+
+	if severity := processor.DataQualitySeverity(r.URL.Query().Get("severity")); severity != "" {
+		filtered := *report
+		filtered.Findings = []processor.DataQualityFinding{}
+		for _, finding := range report.Findings {
+			if finding.Severity == severity {
+				filtered.Findings = append(filtered.Findings, finding)
+			}
+		}
+		report = &filtered
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
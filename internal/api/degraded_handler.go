@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const degradedModeHeader = "X-Degraded-Mode"
+
+type SetDegradedModeRequest struct {
+	Active bool   `json:"active"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (h *APIHandler) DegradedStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status, enabled := h.processor.DegradedStatus()
+	if !enabled {
+		h.sendError(w, "Degraded mode is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
+
+func (h *APIHandler) SetDegradedModeHandler(w http.ResponseWriter, r *http.Request) {
+	if _, enabled := h.processor.DegradedStatus(); !enabled {
+		h.sendError(w, "Degraded mode is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req SetDegradedModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+	if req.Reason == "" {
+		req.Reason = "set by " + operator
+	}
+
+	status, err := h.processor.SetDegraded(req.Active, req.Reason)
+	if err != nil {
+		h.sendError(w, "Failed to update degraded mode", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
+
+func (h *APIHandler) DrainDegradedQueueHandler(w http.ResponseWriter, r *http.Request) {
+	if _, enabled := h.processor.DegradedStatus(); !enabled {
+		h.sendError(w, "Degraded mode is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	result, err := h.processor.DrainDegradedQueue(ctx)
+	if err != nil {
+		h.sendError(w, "Failed to drain queued transactions", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, result, http.StatusOK)
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+const userHeader = "X-User-ID"
+
+var errUserCredentials = errors.New("missing or unknown user credentials")
+
+type impersonatedUserKey struct{}
+
+// UserAuthenticator resolves the customer from request credentials.
+type UserAuthenticator func(r *http.Request) (string, error)
+
+func UserTokens(tokens map[string]string) UserAuthenticator {
+	return bearerTokens(tokens, errUserCredentials)
+}
+
+type GrantDelegationRequest struct {
+	DelegateID string                 `json:"delegate_id"`
+	Scope      domain.DelegationScope `json:"scope"`
+	MaxAmount  float64                `json:"max_amount,omitempty"`
+}
+
+func (h *APIHandler) WithDelegations(delegations *service.DelegationService) *APIHandler {
+	h.delegations = delegations
+	return h
+}
+
+func (h *APIHandler) WithUserAuthenticator(authenticate UserAuthenticator) *APIHandler {
+	h.userAuth = authenticate
+	return h
+}
+
+func (h *APIHandler) requestUser(r *http.Request) (string, error) {
+	if userID, ok := r.Context().Value(impersonatedUserKey{}).(string); ok {
+		return userID, nil
+	}
+	if h.userAuth != nil {
+		userID, err := h.userAuth(r)
+		if err != nil || userID == "" {
+			return "", errUserCredentials
+		}
+		return userID, nil
+	}
+	if userID := r.Header.Get(userHeader); userID != "" {
+		return userID, nil
+	}
+	return "", errUserCredentials
+}
+
+func (h *APIHandler) requireAccountAccess(scope domain.DelegationScope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.delegations == nil {
+			next(w, r)
+			return
+		}
+
+		userID, err := h.requestUser(r)
+		if err != nil {
+			h.sendDelegationError(w, err)
+			return
+		}
+		if _, err := h.delegations.Authorize(r.Context(), userID, r.PathValue("id"), scope, 0); err != nil {
+			h.sendDelegationError(w, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (h *APIHandler) authorizeDelegate(ctx context.Context, r *http.Request, tx *domain.Transaction) error {
+	if h.delegations == nil || tx.FromAccountID == "" {
+		return nil
+	}
+	userID, err := h.requestUser(r)
+	if err != nil {
+		return err
+	}
+
+	delegation, err := h.delegations.Authorize(ctx, userID, tx.FromAccountID, domain.DelegationInitiate, tx.Amount)
+	if err != nil || delegation == nil {
+		return err
+	}
+	tx.AddMetadata(service.MetadataDelegationID, delegation.ID)
+	tx.AddMetadata(service.MetadataInitiatedBy, userID)
+	return nil
+}
+
+func (h *APIHandler) GrantDelegationHandler(w http.ResponseWriter, r *http.Request) {
+	if h.delegations == nil {
+		h.sendError(w, "Delegated access is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	userID, err := h.requestUser(r)
+	if err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	var req GrantDelegationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	delegation, err := h.delegations.Grant(ctx, r.PathValue("id"), userID, req.DelegateID, req.Scope, req.MaxAmount)
+	if err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, delegation, http.StatusCreated)
+}
+
+func (h *APIHandler) ListDelegationsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.delegations == nil {
+		h.sendError(w, "Delegated access is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	userID, err := h.requestUser(r)
+	if err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	delegations, err := h.delegations.List(ctx, r.PathValue("id"), userID)
+	if err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, delegations, http.StatusOK)
+}
+
+func (h *APIHandler) RevokeDelegationHandler(w http.ResponseWriter, r *http.Request) {
+	if h.delegations == nil {
+		h.sendError(w, "Delegated access is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	userID, err := h.requestUser(r)
+	if err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	delegation, err := h.delegations.Revoke(ctx, r.PathValue("id"), userID)
+	if err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, delegation, http.StatusOK)
+}
+
+func (h *APIHandler) sendDelegationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUserCredentials):
+		h.sendError(w, "User identity is required", http.StatusUnauthorized, "MISSING_USER")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, err.Error(), http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidDelegation):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrDelegationRevoked):
+		h.sendError(w, err.Error(), http.StatusConflict, "INVALID_STATE")
+	case errors.Is(err, service.ErrAccessDenied):
+		h.sendError(w, err.Error(), http.StatusForbidden, "ACCESS_DENIED")
+	default:
+		h.sendError(w, "Failed to process delegation", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/processor"
+	"net/http"
+)
+
+func (h *APIHandler) DepositRetryStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := h.processor.DepositRetryStatus()
+	if errors.Is(err, processor.ErrDepositRetryDisabled) {
+		h.sendError(w, "Deposit retry is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
+
+func (h *APIHandler) RunDepositRetryHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.processor.DepositRetryStatus(); errors.Is(err, processor.ErrDepositRetryDisabled) {
+		h.sendError(w, "Deposit retry is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	result, err := h.processor.RetryParkedDeposits(ctx)
+	if err != nil {
+		h.sendError(w, "Failed to retry parked deposits", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, result, http.StatusOK)
+}
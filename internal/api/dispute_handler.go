@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type OpenDisputeRequest struct {
+	TransactionID string                      `json:"transaction_id"`
+	Reason        string                      `json:"reason"`
+	Evidence      []domain.EvidenceAttachment `json:"evidence,omitempty"`
+}
+
+type UpdateDisputeRequest struct {
+	Status   domain.DisputeStatus        `json:"status,omitempty"`
+	Evidence []domain.EvidenceAttachment `json:"evidence,omitempty"`
+	Note     string                      `json:"note,omitempty"`
+}
+
+type ResolveDisputeRequest struct {
+	Outcome domain.DisputeOutcome `json:"outcome"`
+	Note    string                `json:"note,omitempty"`
+}
+
+func (h *APIHandler) WithDisputes(disputes *service.DisputeService) *APIHandler {
+	h.disputes = disputes
+	return h
+}
+
+func (h *APIHandler) OpenDisputeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.disputes == nil {
+		h.sendError(w, "Disputes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req OpenDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	dispute, err := h.disputes.Open(ctx, req.TransactionID, req.Reason, req.Evidence)
+	if err != nil {
+		h.sendDisputeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, dispute, http.StatusCreated)
+}
+
+func (h *APIHandler) GetDisputeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.disputes == nil {
+		h.sendError(w, "Disputes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	dispute, err := h.disputes.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendDisputeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, dispute, http.StatusOK)
+}
+
+func (h *APIHandler) UpdateDisputeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.disputes == nil {
+		h.sendError(w, "Disputes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req UpdateDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	dispute, err := h.disputes.Update(ctx, r.PathValue("id"), service.DisputeUpdate{
+		Status:   req.Status,
+		Evidence: req.Evidence,
+		Note:     req.Note,
+	})
+	if err != nil {
+		h.sendDisputeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, dispute, http.StatusOK)
+}
+
+func (h *APIHandler) ResolveDisputeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.disputes == nil {
+		h.sendError(w, "Disputes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req ResolveDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	dispute, err := h.disputes.Resolve(ctx, r.PathValue("id"), req.Outcome, req.Note)
+	if err != nil {
+		h.sendDisputeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, dispute, http.StatusOK)
+}
+
+func (h *APIHandler) sendDisputeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, "Transaction already has an active dispute", http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidDispute):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrDisputeState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process dispute", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+const snapshotIDHeader = "X-EOD-Snapshot-ID"
+
+type TakeSnapshotRequest struct {
+	BusinessDate string `json:"business_date"`
+}
+
+func (h *APIHandler) WithEODSnapshots(snapshots *service.EODSnapshotService) *APIHandler {
+	h.snapshots = snapshots
+	return h
+}
+
+func (h *APIHandler) TakeEODSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if h.snapshots == nil {
+		h.sendError(w, "End-of-day snapshots are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req TakeSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	snapshot, err := h.snapshots.Take(ctx, req.BusinessDate)
+	if err != nil {
+		h.sendSnapshotError(w, err)
+		return
+	}
+
+	h.sendJSON(w, snapshot, http.StatusOK)
+}
+
+func (h *APIHandler) ListEODSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.snapshots == nil {
+		h.sendError(w, "End-of-day snapshots are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	snapshots, err := h.snapshots.List(ctx)
+	if err != nil {
+		h.sendSnapshotError(w, err)
+		return
+	}
+
+	h.sendJSON(w, snapshots, http.StatusOK)
+}
+
+func (h *APIHandler) GetEODSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if h.snapshots == nil {
+		h.sendError(w, "End-of-day snapshots are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	snapshot, err := h.snapshots.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendSnapshotError(w, err)
+		return
+	}
+
+	h.sendJSON(w, snapshot, http.StatusOK)
+}
+
+func (h *APIHandler) sendSnapshotError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidBusinessDate):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrSnapshotUnsupported):
+		h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_SUPPORTED")
+	default:
+		h.sendError(w, "Failed to process snapshot request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"finance_manager/internal/domain"
+	"net/http"
+)
+
+func (h *APIHandler) ListEventSchemasHandler(w http.ResponseWriter, r *http.Request) {
+	h.sendJSON(w, domain.EventCatalog(), http.StatusOK)
+}
+
+func (h *APIHandler) GetEventSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	schema, ok := domain.LookupEventSchema(r.PathValue("type"), r.URL.Query().Get("version"))
+	if !ok {
+		h.sendError(w, "Unknown event type or schema version", http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+
+	h.sendJSON(w, schema, http.StatusOK)
+}
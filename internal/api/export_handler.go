@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const exportBufferSize = 32 << 10
+
+type flushWriter struct {
+	w          io.Writer
+	controller *http.ResponseController
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if err := f.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return n, err
+	}
+	return n, nil
+}
+
+func setExportHeaders(w http.ResponseWriter, format, filename string) {
+	contentType := "text/csv"
+	if format == processor.DecisionFormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+}
+
+func (h *APIHandler) streamExport(w http.ResponseWriter, format, filename string, export func(io.Writer) error) {
+	setExportHeaders(w, format, filename)
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+	buffered := bufio.NewWriterSize(&flushWriter{w: w, controller: controller}, exportBufferSize)
+
+	err := export(buffered)
+	if flushErr := buffered.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		h.logger.Error("Failed to stream export",
+			slog.String("export", filename),
+			slog.String("error", err.Error()))
+	}
+}
+
+func parseExportRequest(r *http.Request) (time.Time, time.Time, string, error) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	var err error
+	if raw := r.URL.Query().Get("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, "", fmt.Errorf("invalid from timestamp, expected RFC3339")
+		}
+	}
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, "", fmt.Errorf("invalid to timestamp, expected RFC3339")
+		}
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = processor.DecisionFormatCSV
+	}
+	if format != processor.DecisionFormatCSV && format != processor.DecisionFormatJSONL {
+		return from, to, format, fmt.Errorf("%w: %q", processor.ErrUnsupportedExportFormat, format)
+	}
+	return from, to, format, nil
+}
+
+func (h *APIHandler) sendExportRequestError(w http.ResponseWriter, err error) {
+	if errors.Is(err, processor.ErrUnsupportedExportFormat) {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_FORMAT")
+		return
+	}
+	h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+}
+
+func (h *APIHandler) ExportTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, to, format, err := parseExportRequest(r)
+	if err != nil {
+		h.sendExportRequestError(w, err)
+		return
+	}
+
+	filter := repository.TransactionFilter{AccountID: r.URL.Query().Get("account_id"), From: from, To: to}
+	h.streamExport(w, format, "transactions", func(out io.Writer) error {
+		return h.processor.ExportTransactions(ctx, out, filter, format)
+	})
+}
+
+func (h *APIHandler) AccountStatementHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, to, format, err := parseExportRequest(r)
+	if err != nil {
+		h.sendExportRequestError(w, err)
+		return
+	}
+
+	accountID := r.PathValue("id")
+	statement, err := h.processor.Statement(ctx, accountID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			h.sendError(w, "Account not found", http.StatusNotFound, "NOT_FOUND")
+		case errors.Is(err, repository.ErrSnapshotUnsupported):
+			h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+		default:
+			h.sendError(w, "Failed to prepare statement", http.StatusInternalServerError, "SERVER_ERROR")
+		}
+		return
+	}
+	defer statement.Release()
+
+	if h.snapshots != nil {
+		if snapshotID := h.snapshots.SnapshotIDFor(ctx, to); snapshotID != "" {
+			w.Header().Set(snapshotIDHeader, snapshotID)
+		}
+	}
+	if key, cacheable := h.statementCacheKey(accountID, from, to, format); cacheable {
+		h.serveCachedStatement(w, ctx, key, format, statement)
+		return
+	}
+
+	setStatementHeaders(w, statement.OpeningBalance, statement.ClosingBalance, statement.TakenAt)
+	h.streamExport(w, format, "statement-"+accountID, func(out io.Writer) error {
+		return statement.Write(ctx, out, format)
+	})
+}
+
+func (h *APIHandler) ExportSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.processor.ExportSnapshot(r.Context(), &buf); err != nil {
+		if errors.Is(err, repository.ErrSnapshotUnsupported) {
+			h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_SUPPORTED")
+			return
+		}
+		h.sendError(w, "Failed to export snapshot", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.logger.Info("Repository snapshot exported", slog.String("operator", operator))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="snapshot.json"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type SetExposureThresholdRequest struct {
+	Currency  string  `json:"currency,omitempty"`
+	Threshold float64 `json:"threshold"`
+}
+
+type ExposureThresholdsResponse struct {
+	Default    float64            `json:"default"`
+	Currencies map[string]float64 `json:"currencies"`
+}
+
+func (h *APIHandler) WithExposure(exposure *service.ExposureService) *APIHandler {
+	h.exposure = exposure
+	return h
+}
+
+func (h *APIHandler) CurrencyExposureHandler(w http.ResponseWriter, r *http.Request) {
+	if h.exposure == nil {
+		h.sendError(w, "Exposure tracking is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	report, err := h.exposure.Report(ctx)
+	if err != nil {
+		h.sendError(w, "Failed to compute currency exposure", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) GetExposureThresholdsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.exposure == nil {
+		h.sendError(w, "Exposure tracking is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	defaultThreshold, thresholds := h.exposure.Thresholds()
+	h.sendJSON(w, ExposureThresholdsResponse{Default: defaultThreshold, Currencies: thresholds}, http.StatusOK)
+}
+
+func (h *APIHandler) SetExposureThresholdHandler(w http.ResponseWriter, r *http.Request) {
+	if h.exposure == nil {
+		h.sendError(w, "Exposure tracking is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req SetExposureThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	if err := h.exposure.SetThreshold(req.Currency, req.Threshold); err != nil {
+		if errors.Is(err, service.ErrInvalidExposureThreshold) {
+			h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+			return
+		}
+		h.sendError(w, "Failed to update exposure threshold", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	defaultThreshold, thresholds := h.exposure.Thresholds()
+	h.sendJSON(w, ExposureThresholdsResponse{Default: defaultThreshold, Currencies: thresholds}, http.StatusOK)
+}
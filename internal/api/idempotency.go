@@ -0,0 +1,87 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	signatureMaxSkew          = 5 * time.Minute
+)
+
+type idempotentResponse struct {
+	done        bool
+	fingerprint string
+	status      int
+	body        interface{}
+	expiresAt   time.Time
+}
+
+type idempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	lastSweep time.Time
+	entries   map[string]*idempotentResponse
+}
+
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{ttl: ttl, lastSweep: time.Now(), entries: make(map[string]*idempotentResponse)}
+}
+
+func requestFingerprint(req CreateTransactionRequest) string {
+	req.Signature, req.Timestamp = "", 0
+	body, _ := json.Marshal(req)
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *idempotencyStore) begin(key, fingerprint string) (idempotentResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= s.ttl {
+		s.sweepLocked(now)
+	}
+
+	if entry, exists := s.entries[key]; exists && !entry.expired(now) {
+		return *entry, true
+	}
+	s.entries[key] = &idempotentResponse{fingerprint: fingerprint}
+	return idempotentResponse{}, false
+}
+
+func (s *idempotencyStore) complete(key string, status int, body interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, exists := s.entries[key]
+	if !exists {
+		return
+	}
+	entry.done, entry.status, entry.body = true, status, body
+	entry.expiresAt = time.Now().Add(s.ttl)
+}
+
+func (s *idempotencyStore) abandon(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+func (s *idempotencyStore) sweepLocked(now time.Time) {
+	for key, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+func (r *idempotentResponse) expired(now time.Time) bool {
+	return r.done && now.After(r.expiresAt)
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+const (
+	impersonationHeader     = "X-Impersonation-ID"
+	impersonatedUserHeader  = "X-Impersonated-User"
+	impersonationOperatorID = "X-Impersonated-By"
+)
+
+type StartImpersonationRequest struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type ImpersonationSessionResponse struct {
+	Session *domain.ImpersonationSession `json:"session"`
+	Audit   []*domain.AuditEntry         `json:"audit"`
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (h *APIHandler) WithImpersonation(impersonation *service.ImpersonationService) *APIHandler {
+	h.impersonation = impersonation
+	return h
+}
+
+func (h *APIHandler) ImpersonationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(impersonationHeader)
+		if sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if h.impersonation == nil {
+			h.sendError(w, "Impersonation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+			return
+		}
+
+		session, err := h.impersonation.Authorize(r.Context(), sessionID, r.Header.Get(operatorHeader))
+		if err != nil {
+			h.sendImpersonationError(w, err)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			h.impersonation.RecordRequest(r.Context(), session, service.ImpersonatedRequest{
+				Method: r.Method,
+				Path:   r.URL.Path,
+				Status: http.StatusForbidden,
+			})
+			h.sendError(w, "Impersonation sessions are read-only", http.StatusForbidden, "IMPERSONATION_READ_ONLY")
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), impersonatedUserKey{}, session.UserID))
+		w.Header().Set(impersonatedUserHeader, session.UserID)
+		w.Header().Set(impersonationOperatorID, session.OperatorID)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		h.impersonation.RecordRequest(r.Context(), session, service.ImpersonatedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Status: recorder.status,
+		})
+	})
+}
+
+func (h *APIHandler) StartImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	if h.impersonation == nil {
+		h.sendError(w, "Impersonation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req StartImpersonationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	session, err := h.impersonation.Start(ctx, operator, req.UserID, req.Reason)
+	if err != nil {
+		h.sendImpersonationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, session, http.StatusCreated)
+}
+
+func (h *APIHandler) EndImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	if h.impersonation == nil {
+		h.sendError(w, "Impersonation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	session, err := h.impersonation.End(ctx, r.PathValue("id"), operator)
+	if err != nil {
+		h.sendImpersonationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, session, http.StatusOK)
+}
+
+func (h *APIHandler) ListImpersonationsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.impersonation == nil {
+		h.sendError(w, "Impersonation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	sessions, err := h.impersonation.List(ctx)
+	if err != nil {
+		h.sendImpersonationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, sessions, http.StatusOK)
+}
+
+func (h *APIHandler) GetImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	if h.impersonation == nil {
+		h.sendError(w, "Impersonation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	session, trail, err := h.impersonation.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendImpersonationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, ImpersonationSessionResponse{Session: session, Audit: trail}, http.StatusOK)
+}
+
+func (h *APIHandler) sendImpersonationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidImpersonation):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrImpersonationForbidden):
+		h.sendError(w, err.Error(), http.StatusForbidden, "IMPERSONATION_FORBIDDEN")
+	case errors.Is(err, service.ErrImpersonationEnded):
+		h.sendError(w, err.Error(), http.StatusConflict, "INVALID_STATE")
+	default:
+		h.sendError(w, "Failed to process impersonation request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
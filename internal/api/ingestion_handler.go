@@ -0,0 +1,44 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+func (h *APIHandler) WithIngestion(ingestion *service.IngestionService) *APIHandler {
+	h.ingestion = ingestion
+	return h
+}
+
+func (h *APIHandler) IngestionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if h.ingestion == nil {
+		h.sendError(w, "Queue ingestion is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	record, err := h.ingestion.Status(ctx, r.PathValue("key"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		h.sendError(w, "Failed to read ingestion status", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, record, http.StatusOK)
+}
+
+func (h *APIHandler) IngestionStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.ingestion == nil {
+		h.sendError(w, "Queue ingestion is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, h.ingestion.Stats(), http.StatusOK)
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+	"time"
+)
+
+type CreateInstallmentPlanRequest struct {
+	FromAccountID string    `json:"from_account_id"`
+	ToAccountID   string    `json:"to_account_id"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Installments  int       `json:"installments"`
+	IntervalDays  int       `json:"interval_days"`
+	FirstDueAt    time.Time `json:"first_due_at,omitempty"`
+	Description   string    `json:"description,omitempty"`
+}
+
+type InstallmentPlanResponse struct {
+	Plan         *domain.InstallmentPlan `json:"plan"`
+	Installments []*domain.Transaction   `json:"installments"`
+}
+
+func (h *APIHandler) WithInstallments(installments *service.InstallmentService) *APIHandler {
+	h.installments = installments
+	return h
+}
+
+func (h *APIHandler) CreateInstallmentPlanHandler(w http.ResponseWriter, r *http.Request) {
+	if h.installments == nil {
+		h.sendError(w, "Installment plans are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req CreateInstallmentPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	plan, txs, err := h.installments.Create(ctx, service.InstallmentRequest{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Count:         req.Installments,
+		Interval:      time.Duration(req.IntervalDays) * 24 * time.Hour,
+		FirstDueAt:    req.FirstDueAt,
+		Description:   req.Description,
+	})
+	if err != nil {
+		h.sendInstallmentError(w, err)
+		return
+	}
+
+	h.sendJSON(w, InstallmentPlanResponse{Plan: plan, Installments: txs}, http.StatusCreated)
+}
+
+func (h *APIHandler) GetInstallmentPlanHandler(w http.ResponseWriter, r *http.Request) {
+	if h.installments == nil {
+		h.sendError(w, "Installment plans are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	plan, txs, err := h.installments.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendInstallmentError(w, err)
+		return
+	}
+
+	h.sendJSON(w, InstallmentPlanResponse{Plan: plan, Installments: txs}, http.StatusOK)
+}
+
+func (h *APIHandler) CancelInstallmentPlanHandler(w http.ResponseWriter, r *http.Request) {
+	if h.installments == nil {
+		h.sendError(w, "Installment plans are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if _, err := h.installments.Cancel(ctx, r.PathValue("id")); err != nil {
+		h.sendInstallmentError(w, err)
+		return
+	}
+
+	plan, txs, err := h.installments.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendInstallmentError(w, err)
+		return
+	}
+
+	h.sendJSON(w, InstallmentPlanResponse{Plan: plan, Installments: txs}, http.StatusOK)
+}
+
+func (h *APIHandler) sendInstallmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, processor.ErrInvalidInstallmentPlan), errors.Is(err, processor.ErrTransactionTypeNotAllowed):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, processor.ErrInstallmentLimit):
+		h.sendError(w, err.Error(), http.StatusUnprocessableEntity, "LIMIT_EXCEEDED")
+	case errors.Is(err, service.ErrInstallmentPlanState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process installment plan", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
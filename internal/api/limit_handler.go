@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type LimitChangeRequest struct {
+	LimitType domain.LimitType `json:"limit_type"`
+	NewLimit  float64          `json:"new_limit"`
+}
+
+type ConfirmLimitChangeRequest struct {
+	OTP string `json:"otp"`
+}
+
+func (h *APIHandler) WithLimitChanges(limitChanges *service.LimitChangeService) *APIHandler {
+	h.limitChanges = limitChanges
+	return h
+}
+
+func (h *APIHandler) RequestLimitChangeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.limitChanges == nil {
+		h.sendError(w, "Limit changes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req LimitChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	change, err := h.limitChanges.RequestChange(ctx, r.PathValue("id"), req.LimitType, req.NewLimit)
+	if err != nil {
+		h.sendLimitChangeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if change.Status != domain.LimitChangeApplied {
+		status = http.StatusAccepted
+	}
+	h.sendJSON(w, change, status)
+}
+
+func (h *APIHandler) GetLimitChangeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.limitChanges == nil {
+		h.sendError(w, "Limit changes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	change, err := h.limitChanges.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendLimitChangeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, change, http.StatusOK)
+}
+
+func (h *APIHandler) ConfirmLimitChangeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.limitChanges == nil {
+		h.sendError(w, "Limit changes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req ConfirmLimitChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OTP == "" {
+		h.sendError(w, "OTP is required", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	change, err := h.limitChanges.Confirm(ctx, r.PathValue("id"), req.OTP)
+	if err != nil {
+		h.sendLimitChangeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, change, http.StatusOK)
+}
+
+func (h *APIHandler) CancelLimitChangeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.limitChanges == nil {
+		h.sendError(w, "Limit changes are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	change, err := h.limitChanges.Cancel(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendLimitChangeError(w, err)
+		return
+	}
+
+	h.sendJSON(w, change, http.StatusOK)
+}
+
+func (h *APIHandler) sendLimitChangeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidLimitChange):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrInvalidOTP):
+		h.sendError(w, "Invalid verification code", http.StatusUnauthorized, "INVALID_OTP")
+	case errors.Is(err, service.ErrOTPExpired):
+		h.sendError(w, "Verification code expired", http.StatusGone, "OTP_EXPIRED")
+	case errors.Is(err, service.ErrLimitChangeState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process limit change", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,303 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RequestPriority string
+
+const (
+	PriorityCritical RequestPriority = "critical"
+	PriorityNormal   RequestPriority = "normal"
+	PriorityLow      RequestPriority = "low"
+)
+
+const (
+	loadShedNone = iota
+	loadShedLow
+	loadShedNormal
+)
+
+var ErrInvalidLatencySLO = errors.New("invalid latency SLO")
+
+type LoadSheddingConfig struct {
+	SLOs          map[string]time.Duration
+	DefaultSLO    time.Duration
+	Window        time.Duration
+	MaxSamples    int
+	MinSamples    int
+	Percentile    float64
+	EvaluateEvery time.Duration
+	RetryAfter    time.Duration
+}
+
+func DefaultLoadSheddingConfig() LoadSheddingConfig {
+	return LoadSheddingConfig{
+		SLOs: map[string]time.Duration{
+			"POST /api/v1/transactions":       500 * time.Millisecond,
+			"POST /api/v1/transactions/batch": 2 * time.Second,
+		},
+		DefaultSLO:    time.Second,
+		Window:        time.Minute,
+		MaxSamples:    500,
+		MinSamples:    20,
+		Percentile:    95,
+		EvaluateEvery: time.Second,
+		RetryAfter:    5 * time.Second,
+	}
+}
+
+func ParseLatencySLOs(spec string) (map[string]time.Duration, error) {
+	slos := make(map[string]time.Duration)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		endpoint, raw, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(endpoint) == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLatencySLO, part)
+		}
+		slo, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil || slo <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLatencySLO, part)
+		}
+		slos[strings.TrimSpace(endpoint)] = slo
+	}
+	if len(slos) == 0 {
+		return nil, fmt.Errorf("%w: no endpoints", ErrInvalidLatencySLO)
+	}
+	return slos, nil
+}
+
+type EndpointLatency struct {
+	Endpoint  string          `json:"endpoint"`
+	Priority  RequestPriority `json:"priority"`
+	SLO       time.Duration   `json:"slo"`
+	Latency   time.Duration   `json:"latency"`
+	Samples   int             `json:"samples"`
+	Breaching bool            `json:"breaching"`
+}
+
+type LoadSheddingStatus struct {
+	Shedding    []RequestPriority         `json:"shedding"`
+	Percentile  float64                   `json:"percentile"`
+	Endpoints   []EndpointLatency         `json:"endpoints"`
+	Shed        map[RequestPriority]int64 `json:"shed"`
+	EvaluatedAt time.Time                 `json:"evaluated_at"`
+}
+
+type latencySample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+type endpointSamples struct {
+	priority RequestPriority
+	samples  []latencySample
+}
+
+type loadShedder struct {
+	cfg LoadSheddingConfig
+
+	mu          sync.Mutex
+	endpoints   map[string]*endpointSamples
+	level       int
+	latencies   []EndpointLatency
+	shed        map[RequestPriority]int64
+	evaluatedAt time.Time
+}
+
+func (h *APIHandler) WithLoadShedding(cfg LoadSheddingConfig) *APIHandler {
+	defaults := DefaultLoadSheddingConfig()
+	if cfg.Window <= 0 {
+		cfg.Window = defaults.Window
+	}
+	if cfg.MaxSamples <= 0 {
+		cfg.MaxSamples = defaults.MaxSamples
+	}
+	if cfg.Percentile <= 0 || cfg.Percentile > 100 {
+		cfg.Percentile = defaults.Percentile
+	}
+	if cfg.DefaultSLO <= 0 {
+		cfg.DefaultSLO = defaults.DefaultSLO
+	}
+	h.shedder = &loadShedder{
+		cfg:       cfg,
+		endpoints: make(map[string]*endpointSamples),
+		shed:      make(map[RequestPriority]int64),
+	}
+	return h
+}
+
+func requestPriority(r *http.Request) RequestPriority {
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/api/v1/admin/"), path == "/api/health":
+		return PriorityCritical
+	case r.Method == http.MethodPost && (path == "/api/v1/transactions" || path == "/api/v1/transactions/batch"):
+		return PriorityCritical
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return PriorityLow
+	default:
+		return PriorityNormal
+	}
+}
+
+func (s *loadShedder) sheds(priority RequestPriority, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.evaluatedAt) >= s.cfg.EvaluateEvery {
+		s.evaluate(now)
+	}
+
+	shed := false
+	switch priority {
+	case PriorityLow:
+		shed = s.level >= loadShedLow
+	case PriorityNormal:
+		shed = s.level >= loadShedNormal
+	}
+	if shed {
+		s.shed[priority]++
+	}
+	return shed
+}
+
+func (s *loadShedder) observe(endpoint string, priority RequestPriority, duration time.Duration, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	samples, ok := s.endpoints[endpoint]
+	if !ok {
+		samples = &endpointSamples{priority: priority}
+		s.endpoints[endpoint] = samples
+	}
+	samples.samples = append(samples.samples, latencySample{at: now, duration: duration})
+	if excess := len(samples.samples) - s.cfg.MaxSamples; excess > 0 {
+		samples.samples = samples.samples[excess:]
+	}
+}
+
+func (s *loadShedder) slo(endpoint string) time.Duration {
+	if slo, ok := s.cfg.SLOs[endpoint]; ok {
+		return slo
+	}
+	return s.cfg.DefaultSLO
+}
+
+func (s *loadShedder) evaluate(now time.Time) {
+	cutoff := now.Add(-s.cfg.Window)
+	level := loadShedNone
+	latencies := make([]EndpointLatency, 0, len(s.endpoints))
+	for endpoint, samples := range s.endpoints {
+		samples.samples = slices.DeleteFunc(samples.samples, func(sample latencySample) bool { return sample.at.Before(cutoff) })
+		if len(samples.samples) == 0 {
+			delete(s.endpoints, endpoint)
+			continue
+		}
+
+		latency := EndpointLatency{
+			Endpoint: endpoint,
+			Priority: samples.priority,
+			SLO:      s.slo(endpoint),
+			Latency:  percentile(samples.samples, s.cfg.Percentile),
+			Samples:  len(samples.samples),
+		}
+		latency.Breaching = latency.Samples >= s.cfg.MinSamples && latency.Latency > latency.SLO
+		latencies = append(latencies, latency)
+
+		if !latency.Breaching {
+			continue
+		}
+		// A slow payment path sheds everything but critical traffic; any other
+		// breach only sheds low-priority reads.
+		if samples.priority == PriorityCritical {
+			level = max(level, loadShedNormal)
+		} else {
+			level = max(level, loadShedLow)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i].Endpoint < latencies[j].Endpoint })
+
+	s.level = level
+	s.latencies = latencies
+	s.evaluatedAt = now
+}
+
+func percentile(samples []latencySample, p float64) time.Duration {
+	durations := make([]time.Duration, len(samples))
+	for i, sample := range samples {
+		durations[i] = sample.duration
+	}
+	slices.Sort(durations)
+	index := int(math.Ceil(p/100*float64(len(durations)))) - 1
+	return durations[max(index, 0)]
+}
+
+func (s *loadShedder) status() LoadSheddingStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := LoadSheddingStatus{
+		Shedding:    []RequestPriority{},
+		Percentile:  s.cfg.Percentile,
+		Endpoints:   append([]EndpointLatency{}, s.latencies...),
+		Shed:        make(map[RequestPriority]int64, len(s.shed)),
+		EvaluatedAt: s.evaluatedAt,
+	}
+	if s.level >= loadShedLow {
+		status.Shedding = append(status.Shedding, PriorityLow)
+	}
+	if s.level >= loadShedNormal {
+		status.Shedding = append(status.Shedding, PriorityNormal)
+	}
+	for priority, count := range s.shed {
+		status.Shed[priority] = count
+	}
+	return status
+}
+
+func (h *APIHandler) LoadSheddingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.shedder == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		priority := requestPriority(r)
+		start := time.Now()
+		if h.shedder.sheds(priority, start) {
+			h.logger.WarnContext(r.Context(), "Request shed under load",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("priority", string(priority)))
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(h.shedder.cfg.RetryAfter.Seconds()))))
+			h.sendError(w, "Service is shedding load, retry later", http.StatusServiceUnavailable, "LOAD_SHED")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+		if r.Pattern != "" {
+			h.shedder.observe(r.Pattern, priority, time.Since(start), time.Now())
+		}
+	})
+}
+
+func (h *APIHandler) LoadSheddingStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if h.shedder == nil {
+		h.sendError(w, "Load shedding is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, h.shedder.status(), http.StatusOK)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"finance_manager/internal/domain"
+	"finance_manager/pkg/money"
+	"net/http"
+)
+
+type TransactionDisplay struct {
+	Locale    string `json:"locale"`
+	Amount    string `json:"amount"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type localizedTransaction struct {
+	*domain.Transaction
+	Display *TransactionDisplay `json:"display,omitempty"`
+}
+
+var displayRounding = money.DefaultRoundingPolicies()
+
+var statusLabels = map[string]map[domain.TransactionStatus]string{
+	"en": {
+		domain.StatusPending:    "Pending",
+		domain.StatusProcessing: "Processing",
+		domain.StatusCompleted:  "Completed",
+		domain.StatusFailed:     "Failed",
+		domain.StatusSuspicious: "Under review",
+		domain.StatusExpired:    "Expired",
+		domain.StatusScheduled:  "Scheduled",
+		domain.StatusCancelled:  "Cancelled",
+	},
+	"de": {
+		domain.StatusPending:    "Ausstehend",
+		domain.StatusProcessing: "In Bearbeitung",
+		domain.StatusCompleted:  "Abgeschlossen",
+		domain.StatusFailed:     "Fehlgeschlagen",
+		domain.StatusSuspicious: "In Prüfung",
+		domain.StatusExpired:    "Abgelaufen",
+		domain.StatusScheduled:  "Geplant",
+		domain.StatusCancelled:  "Storniert",
+	},
+	"fr": {
+		domain.StatusPending:    "En attente",
+		domain.StatusProcessing: "En cours",
+		domain.StatusCompleted:  "Effectuée",
+		domain.StatusFailed:     "Échouée",
+		domain.StatusSuspicious: "En vérification",
+		domain.StatusExpired:    "Expirée",
+		domain.StatusScheduled:  "Programmée",
+		domain.StatusCancelled:  "Annulée",
+	},
+	"es": {
+		domain.StatusPending:    "Pendiente",
+		domain.StatusProcessing: "En proceso",
+		domain.StatusCompleted:  "Completada",
+		domain.StatusFailed:     "Fallida",
+		domain.StatusSuspicious: "En revisión",
+		domain.StatusExpired:    "Vencida",
+		domain.StatusScheduled:  "Programada",
+		domain.StatusCancelled:  "Cancelada",
+	},
+	"it": {
+		domain.StatusPending:    "In attesa",
+		domain.StatusProcessing: "In elaborazione",
+		domain.StatusCompleted:  "Completata",
+		domain.StatusFailed:     "Non riuscita",
+		domain.StatusSuspicious: "In verifica",
+		domain.StatusExpired:    "Scaduta",
+		domain.StatusScheduled:  "Programmata",
+		domain.StatusCancelled:  "Annullata",
+	},
+	"pt": {
+		domain.StatusPending:    "Pendente",
+		domain.StatusProcessing: "Em processamento",
+		domain.StatusCompleted:  "Concluída",
+		domain.StatusFailed:     "Falhou",
+		domain.StatusSuspicious: "Em análise",
+		domain.StatusExpired:    "Expirada",
+		domain.StatusScheduled:  "Agendada",
+		domain.StatusCancelled:  "Cancelada",
+	},
+	"ja": {
+		domain.StatusPending:    "保留中",
+		domain.StatusProcessing: "処理中",
+		domain.StatusCompleted:  "完了",
+		domain.StatusFailed:     "失敗",
+		domain.StatusSuspicious: "審査中",
+		domain.StatusExpired:    "期限切れ",
+		domain.StatusScheduled:  "予約済み",
+		domain.StatusCancelled:  "キャンセル済み",
+	},
+}
+
+// requestLocale resolves the response locale from Accept-Language.
+func requestLocale(w http.ResponseWriter, r *http.Request) (money.Locale, bool) {
+	w.Header().Add("Vary", "Accept-Language")
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return money.Locale{}, false
+	}
+	locale, supported := money.ParseAcceptLanguage(header)
+	if !supported {
+		return money.Locale{}, false
+	}
+	w.Header().Set("Content-Language", locale.Tag)
+	return locale, true
+}
+
+func statusLabel(locale money.Locale, status domain.TransactionStatus) string {
+	if label, ok := statusLabels[locale.Language()][status]; ok {
+		return label
+	}
+	if label, ok := statusLabels["en"][status]; ok {
+		return label
+	}
+	return string(status)
+}
+
+func transactionDisplay(locale money.Locale, tx *domain.Transaction) *TransactionDisplay {
+	return &TransactionDisplay{
+		Locale:    locale.Tag,
+		Amount:    locale.FormatAmount(tx.Amount, tx.Currency, displayRounding),
+		Status:    statusLabel(locale, tx.Status),
+		CreatedAt: locale.FormatDate(tx.CreatedAt),
+	}
+}
+
+func localize(w http.ResponseWriter, r *http.Request, tx *domain.Transaction) interface{} {
+	locale, ok := requestLocale(w, r)
+	if !ok {
+		return tx
+	}
+	return localizedTransaction{Transaction: tx, Display: transactionDisplay(locale, tx)}
+}
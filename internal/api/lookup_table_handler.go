@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"net/http"
+)
+
+type SaveLookupTableRequest struct {
+	Description string             `json:"description,omitempty"`
+	Entries     map[string]float64 `json:"entries"`
+	Default     *float64           `json:"default,omitempty"`
+}
+
+func (h *APIHandler) SaveLookupTableHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req SaveLookupTableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	table := &domain.LookupTable{
+		Name:        r.PathValue("name"),
+		Description: req.Description,
+		Entries:     req.Entries,
+		Default:     req.Default,
+	}
+	if err := h.processor.RuleEngine().SaveLookupTable(ctx, table); err != nil {
+		h.sendLookupTableError(w, err)
+		return
+	}
+
+	h.sendJSON(w, table, http.StatusOK)
+}
+
+func (h *APIHandler) ListLookupTablesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	tables, err := h.processor.RuleEngine().ListLookupTables(ctx)
+	if err != nil {
+		h.sendLookupTableError(w, err)
+		return
+	}
+
+	h.sendJSON(w, tables, http.StatusOK)
+}
+
+func (h *APIHandler) GetLookupTableHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	table, err := h.processor.RuleEngine().GetLookupTable(ctx, r.PathValue("name"))
+	if err != nil {
+		h.sendLookupTableError(w, err)
+		return
+	}
+
+	h.sendJSON(w, table, http.StatusOK)
+}
+
+func (h *APIHandler) DeleteLookupTableHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if err := h.processor.RuleEngine().DeleteLookupTable(ctx, r.PathValue("name")); err != nil {
+		h.sendLookupTableError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIHandler) sendLookupTableError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, processor.ErrLookupTablesNotConfigured):
+		h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, processor.ErrInvalidLookupTable):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, "Lookup table not found", http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, processor.ErrLookupTableInUse):
+		h.sendError(w, err.Error(), http.StatusConflict, "TABLE_IN_USE")
+	default:
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/processor"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const maintenanceHeader = "X-Maintenance-Window"
+
+type ScheduleMaintenanceRequest struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Mode   string    `json:"mode,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+func (h *APIHandler) MaintenanceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	h.sendJSON(w, h.processor.MaintenanceStatus(time.Now()), http.StatusOK)
+}
+
+func (h *APIHandler) ScheduleMaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req ScheduleMaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+	if req.Start.IsZero() {
+		req.Start = time.Now()
+	}
+	if req.Reason == "" {
+		req.Reason = "scheduled by " + operator
+	}
+
+	window, err := h.processor.ScheduleMaintenance(processor.MaintenanceWindow{
+		Start:     req.Start,
+		End:       req.End,
+		Mode:      req.Mode,
+		Reason:    req.Reason,
+		CreatedBy: operator,
+	})
+	if err != nil {
+		h.sendMaintenanceError(w, err)
+		return
+	}
+
+	h.sendJSON(w, window, http.StatusCreated)
+}
+
+func (h *APIHandler) CancelMaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	if err := h.processor.CancelMaintenance(r.PathValue("id")); err != nil {
+		h.sendMaintenanceError(w, err)
+		return
+	}
+
+	h.sendJSON(w, h.processor.MaintenanceStatus(time.Now()), http.StatusOK)
+}
+
+func (h *APIHandler) sendMaintenanceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, processor.ErrMaintenanceDisabled):
+		h.sendError(w, "Maintenance windows are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, processor.ErrInvalidMaintenanceWindow):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_MAINTENANCE_WINDOW")
+	case errors.Is(err, processor.ErrMaintenanceWindowNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	default:
+		h.sendError(w, "Failed to update maintenance windows", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
+
+func setMaintenanceHeaders(w http.ResponseWriter, window processor.MaintenanceWindow) {
+	w.Header().Set(maintenanceHeader, window.ID)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(window.End).Seconds()))))
+}
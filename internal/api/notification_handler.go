@@ -0,0 +1,233 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type TemplatePreviewRequest struct {
+	Template *service.NotificationTemplate `json:"template,omitempty"`
+	Data     map[string]any                `json:"data"`
+}
+
+type TemplateTestSendRequest struct {
+	TemplatePreviewRequest
+	Channel   service.NotificationType `json:"channel"`
+	Recipient string                   `json:"recipient"`
+}
+
+type TemplateTestSendResponse struct {
+	MessageID string                   `json:"message_id"`
+	Channel   service.NotificationType `json:"channel"`
+	Recipient string                   `json:"recipient"`
+	Subject   string                   `json:"subject"`
+	Body      string                   `json:"body"`
+}
+
+func (h *APIHandler) WithInbox(inbox *service.InboxService) *APIHandler {
+	h.inbox = inbox
+	return h
+}
+
+func (h *APIHandler) ListNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.inbox == nil {
+		h.sendError(w, "Notification inbox is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var unreadOnly bool
+	if raw := r.URL.Query().Get("unread"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			h.sendError(w, "Invalid unread filter", http.StatusBadRequest, "VALIDATION_ERROR")
+			return
+		}
+		unreadOnly = parsed
+	}
+
+	inbox, err := h.inbox.List(ctx, r.PathValue("id"), unreadOnly)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, inbox, http.StatusOK)
+}
+
+func (h *APIHandler) MarkNotificationReadHandler(w http.ResponseWriter, r *http.Request) {
+	if h.inbox == nil {
+		h.sendError(w, "Notification inbox is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	notification, err := h.inbox.MarkRead(ctx, r.PathValue("id"), r.PathValue("notificationId"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			h.sendError(w, "Notification not found", http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, notification, http.StatusOK)
+}
+
+func (h *APIHandler) MarkAllNotificationsReadHandler(w http.ResponseWriter, r *http.Request) {
+	if h.inbox == nil {
+		h.sendError(w, "Notification inbox is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	marked, err := h.inbox.MarkAllRead(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, map[string]int{"marked": marked}, http.StatusOK)
+}
+
+func (h *APIHandler) WithNotifications(notifications *service.NotificationService) *APIHandler {
+	h.notifications = notifications
+	return h
+}
+
+func (h *APIHandler) ListTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.notifications == nil {
+		h.sendError(w, "Notifications are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	h.sendJSON(w, h.notifications.Templates().List(), http.StatusOK)
+}
+
+func (h *APIHandler) ListNotificationRoutesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.notifications == nil {
+		h.sendError(w, "Notifications are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	routes := []service.NotificationRoute{}
+	if table := h.notifications.Routing(); table != nil {
+		routes = table.Routes()
+	}
+	h.sendJSON(w, routes, http.StatusOK)
+}
+
+func (h *APIHandler) PreviewTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	if h.notifications == nil {
+		h.sendError(w, "Notifications are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req TemplatePreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	template, err := h.resolveTemplate(r.PathValue("name"), req.Template)
+	if err != nil {
+		h.sendTemplateError(w, err)
+		return
+	}
+	rendered, err := service.RenderTemplate(template, req.Data)
+	if err != nil {
+		h.sendTemplateError(w, err)
+		return
+	}
+
+	h.sendJSON(w, rendered, http.StatusOK)
+}
+
+func (h *APIHandler) TestSendTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	if h.notifications == nil {
+		h.sendError(w, "Notifications are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req TemplateTestSendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	template, err := h.resolveTemplate(r.PathValue("name"), req.Template)
+	if err != nil {
+		h.sendTemplateError(w, err)
+		return
+	}
+	msg, err := h.notifications.SendTest(ctx, template, req.Data, req.Channel, req.Recipient)
+	if err != nil {
+		h.sendTemplateError(w, err)
+		return
+	}
+
+	h.logger.Info("Template test send requested",
+		slog.String("template", template.Name),
+		slog.String("operator", operator),
+		slog.String("message_id", msg.ID))
+	h.sendJSON(w, TemplateTestSendResponse{
+		MessageID: msg.ID,
+		Channel:   msg.Type,
+		Recipient: msg.Recipient,
+		Subject:   msg.Subject,
+		Body:      msg.Message,
+	}, http.StatusAccepted)
+}
+
+func (h *APIHandler) resolveTemplate(name string, draft *service.NotificationTemplate) (service.NotificationTemplate, error) {
+	if draft != nil {
+		template := *draft
+		template.Name = name
+		return template, nil
+	}
+	return h.notifications.Templates().Get(name)
+}
+
+func (h *APIHandler) sendTemplateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrTemplateNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidTemplate):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	default:
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+func (h *APIHandler) WithPartners(partners *service.PartnerService) *APIHandler {
+	h.partners = partners
+	return h
+}
+
+func (h *APIHandler) authorizePartner(ctx context.Context, r *http.Request, tx *domain.Transaction) error {
+	apiKey := r.Header.Get(apiKeyHeader)
+	if h.partners == nil || apiKey == "" {
+		return nil
+	}
+
+	partner, err := h.partners.Authorize(ctx, apiKey, tx.Type)
+	if err != nil || partner == nil {
+		return err
+	}
+	tx.AddMetadata(service.MetadataPartnerID, partner.ID)
+	return nil
+}
+
+func (h *APIHandler) CreatePartnerHandler(w http.ResponseWriter, r *http.Request) {
+	if h.partners == nil {
+		h.sendError(w, "Partners are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var partner domain.Partner
+	if err := json.NewDecoder(r.Body).Decode(&partner); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	created, err := h.partners.Create(ctx, &partner)
+	if err != nil {
+		h.sendPartnerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, created, http.StatusCreated)
+}
+
+func (h *APIHandler) ListPartnersHandler(w http.ResponseWriter, r *http.Request) {
+	if h.partners == nil {
+		h.sendError(w, "Partners are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	partners, err := h.partners.List(ctx)
+	if err != nil {
+		h.sendPartnerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, partners, http.StatusOK)
+}
+
+func (h *APIHandler) GetPartnerHandler(w http.ResponseWriter, r *http.Request) {
+	if h.partners == nil {
+		h.sendError(w, "Partners are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	partner, err := h.partners.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendPartnerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, partner, http.StatusOK)
+}
+
+func (h *APIHandler) UpdatePartnerHandler(w http.ResponseWriter, r *http.Request) {
+	if h.partners == nil {
+		h.sendError(w, "Partners are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var partner domain.Partner
+	if err := json.NewDecoder(r.Body).Decode(&partner); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+	partner.ID = r.PathValue("id")
+
+	updated, err := h.partners.Update(ctx, &partner)
+	if err != nil {
+		h.sendPartnerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, updated, http.StatusOK)
+}
+
+func (h *APIHandler) DeletePartnerHandler(w http.ResponseWriter, r *http.Request) {
+	if h.partners == nil {
+		h.sendError(w, "Partners are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if err := h.partners.Delete(ctx, r.PathValue("id")); err != nil {
+		h.sendPartnerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIHandler) sendPartnerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, err.Error(), http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidPartner):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrPartnerSuspended), errors.Is(err, service.ErrPartnerNotAllowed):
+		h.sendError(w, err.Error(), http.StatusForbidden, "PARTNER_FORBIDDEN")
+	default:
+		h.sendError(w, "Failed to process partner request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
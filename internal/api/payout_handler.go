@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+func (h *APIHandler) WithPayouts(payouts *service.PayoutService) *APIHandler {
+	h.payouts = payouts
+	return h
+}
+
+func (h *APIHandler) SubmitPayoutBatchHandler(w http.ResponseWriter, r *http.Request) {
+	if h.payouts == nil {
+		h.sendError(w, "Payout batches are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req service.PayoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	batch, err := h.payouts.Submit(ctx, req)
+	if err != nil {
+		h.sendPayoutError(w, err)
+		return
+	}
+
+	h.sendJSON(w, batch, http.StatusAccepted)
+}
+
+func (h *APIHandler) GetPayoutBatchHandler(w http.ResponseWriter, r *http.Request) {
+	if h.payouts == nil {
+		h.sendError(w, "Payout batches are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	batch, err := h.payouts.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendPayoutError(w, err)
+		return
+	}
+
+	h.sendJSON(w, batch, http.StatusOK)
+}
+
+func (h *APIHandler) GetPayoutProgressHandler(w http.ResponseWriter, r *http.Request) {
+	if h.payouts == nil {
+		h.sendError(w, "Payout batches are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	batch, err := h.payouts.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendPayoutError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]interface{}{
+		"batch_id": batch.ID,
+		"status":   batch.Status,
+		"progress": batch.Progress,
+	}, http.StatusOK)
+}
+
+func (h *APIHandler) sendPayoutError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidPayoutBatch):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	default:
+		h.sendError(w, "Failed to process payout batch", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"log/slog"
+	"net/http"
+)
+
+type PositivePayListRequest struct {
+	Payees []domain.PositivePayPayee `json:"payees"`
+}
+
+type PositivePayReviewRequest struct {
+	Outcome processor.OverrideOutcome `json:"outcome"`
+	Reason  string                    `json:"reason"`
+}
+
+func (h *APIHandler) SetPositivePayListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req PositivePayListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	list := &domain.PositivePayList{AccountID: r.PathValue("id"), Payees: req.Payees}
+	if err := h.processor.SetPositivePayList(ctx, list); err != nil {
+		h.sendPositivePayError(w, err)
+		return
+	}
+
+	h.sendJSON(w, list, http.StatusOK)
+}
+
+func (h *APIHandler) GetPositivePayListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	list, err := h.processor.PositivePayList(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendPositivePayError(w, err)
+		return
+	}
+
+	h.sendJSON(w, list, http.StatusOK)
+}
+
+func (h *APIHandler) DeletePositivePayListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if err := h.processor.RemovePositivePayList(ctx, r.PathValue("id")); err != nil {
+		h.sendPositivePayError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIHandler) ListPositivePayExceptionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	exceptions, err := h.processor.PositivePayExceptions(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendPositivePayError(w, err)
+		return
+	}
+
+	h.sendJSON(w, exceptions, http.StatusOK)
+}
+
+func (h *APIHandler) ReviewPositivePayExceptionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req PositivePayReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	tx, err := h.processor.ReviewPositivePayException(ctx, r.PathValue("id"), r.PathValue("transactionId"), processor.RiskOverride{
+		Outcome:  req.Outcome,
+		Reason:   req.Reason,
+		Operator: operator,
+	})
+	if err != nil {
+		h.logger.Error("Positive pay review failed",
+			slog.String("transaction_id", r.PathValue("transactionId")),
+			slog.String("operator", operator),
+			slog.String("error", err.Error()))
+		h.sendPositivePayError(w, err)
+		return
+	}
+
+	h.sendJSON(w, newTransactionResponse(tx), http.StatusOK)
+}
+
+func (h *APIHandler) sendPositivePayError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, processor.ErrPositivePayNotConfigured):
+		h.sendError(w, "Positive pay is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, processor.ErrInvalidPositivePayList):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, processor.ErrNotPositivePayException):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	default:
+		h.sendOverrideError(w, err)
+	}
+}
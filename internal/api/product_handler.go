@@ -0,0 +1,161 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type AssignProductRequest struct {
+	ProductID string `json:"product_id"`
+}
+
+func (h *APIHandler) WithProducts(products *service.ProductService) *APIHandler {
+	h.products = products
+	return h
+}
+
+func (h *APIHandler) CreateProductHandler(w http.ResponseWriter, r *http.Request) {
+	if h.products == nil {
+		h.sendError(w, "Products are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var product domain.Product
+	if err := json.NewDecoder(r.Body).Decode(&product); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	created, err := h.products.Create(ctx, &product)
+	if err != nil {
+		h.sendProductError(w, err)
+		return
+	}
+
+	h.sendJSON(w, created, http.StatusCreated)
+}
+
+func (h *APIHandler) ListProductsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.products == nil {
+		h.sendError(w, "Products are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	products, err := h.products.List(ctx)
+	if err != nil {
+		h.sendProductError(w, err)
+		return
+	}
+
+	h.sendJSON(w, products, http.StatusOK)
+}
+
+func (h *APIHandler) GetProductHandler(w http.ResponseWriter, r *http.Request) {
+	if h.products == nil {
+		h.sendError(w, "Products are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	product, err := h.products.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendProductError(w, err)
+		return
+	}
+
+	h.sendJSON(w, product, http.StatusOK)
+}
+
+func (h *APIHandler) UpdateProductHandler(w http.ResponseWriter, r *http.Request) {
+	if h.products == nil {
+		h.sendError(w, "Products are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var product domain.Product
+	if err := json.NewDecoder(r.Body).Decode(&product); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+	product.ID = r.PathValue("id")
+
+	updated, err := h.products.Update(ctx, &product)
+	if err != nil {
+		h.sendProductError(w, err)
+		return
+	}
+
+	h.sendJSON(w, updated, http.StatusOK)
+}
+
+func (h *APIHandler) RetireProductHandler(w http.ResponseWriter, r *http.Request) {
+	if h.products == nil {
+		h.sendError(w, "Products are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	product, err := h.products.Retire(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendProductError(w, err)
+		return
+	}
+
+	h.sendJSON(w, product, http.StatusOK)
+}
+
+func (h *APIHandler) AssignProductHandler(w http.ResponseWriter, r *http.Request) {
+	if h.products == nil {
+		h.sendError(w, "Products are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req AssignProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	account, err := h.products.AssignToAccount(ctx, r.PathValue("id"), req.ProductID)
+	if err != nil {
+		h.sendProductError(w, err)
+		return
+	}
+
+	h.sendJSON(w, account, http.StatusOK)
+}
+
+func (h *APIHandler) sendProductError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, "Product already exists", http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, service.ErrInvalidProduct):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrProductRetired):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process product request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
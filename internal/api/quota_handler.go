@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	apiKeyHeader            = "X-API-Key"
+	quotaWarningHeader      = "X-Quota-Warning"
+	quotaRemainingHeader    = "X-Quota-Requests-Remaining"
+	quotaWarningRequests    = "monthly request quota exceeded"
+	quotaWarningTransaction = "monthly transaction volume quota exceeded"
+)
+
+type APIKeyQuotaRequest struct {
+	MonthlyRequests int64                   `json:"monthly_requests"`
+	MonthlyVolume   float64                 `json:"monthly_volume"`
+	Enforcement     domain.QuotaEnforcement `json:"enforcement"`
+}
+
+func (h *APIHandler) WithQuotas(quotas *service.QuotaService) *APIHandler {
+	h.quotas = quotas
+	return h
+}
+
+func (h *APIHandler) QuotaMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := r.Header.Get(apiKeyHeader)
+		if h.quotas == nil || apiKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		check, err := h.quotas.RecordRequest(r.Context(), apiKey)
+		if err != nil {
+			if errors.Is(err, service.ErrQuotaExceeded) {
+				h.sendQuotaError(w, err)
+				return
+			}
+			h.logger.ErrorContext(r.Context(), "Failed to record api key usage",
+				slog.String("api_key", apiKey),
+				slog.String("error", err.Error()))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if remaining := check.RemainingRequests(); remaining >= 0 {
+			w.Header().Set(quotaRemainingHeader, strconv.FormatInt(remaining, 10))
+		}
+		if check.Exceeded {
+			w.Header().Add(quotaWarningHeader, quotaWarningRequests)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *APIHandler) reserveQuotaVolume(ctx context.Context, w http.ResponseWriter, r *http.Request, amount float64) (*service.QuotaCheck, error) {
+	apiKey := r.Header.Get(apiKeyHeader)
+	if h.quotas == nil || apiKey == "" {
+		return nil, nil
+	}
+
+	check, err := h.quotas.ReserveVolume(ctx, apiKey, amount)
+	if err != nil {
+		return nil, err
+	}
+	if check.Exceeded {
+		w.Header().Add(quotaWarningHeader, quotaWarningTransaction)
+	}
+	return check, nil
+}
+
+func (h *APIHandler) releaseQuotaVolume(ctx context.Context, check *service.QuotaCheck) {
+	if h.quotas == nil {
+		return
+	}
+	h.quotas.ReleaseVolume(ctx, check)
+}
+
+func (h *APIHandler) SetAPIKeyQuotaHandler(w http.ResponseWriter, r *http.Request) {
+	if h.quotas == nil {
+		h.sendError(w, "API key quotas are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req APIKeyQuotaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	quota := &domain.APIKeyQuota{
+		APIKey:          r.PathValue("key"),
+		MonthlyRequests: req.MonthlyRequests,
+		MonthlyVolume:   req.MonthlyVolume,
+		Enforcement:     req.Enforcement,
+	}
+	if err := h.quotas.SetQuota(ctx, quota); err != nil {
+		h.sendQuotaError(w, err)
+		return
+	}
+
+	h.logger.InfoContext(ctx, "API key quota set by operator",
+		slog.String("api_key", quota.APIKey),
+		slog.String("operator", operator))
+	h.sendJSON(w, quota, http.StatusOK)
+}
+
+func (h *APIHandler) GetAPIKeyQuotaHandler(w http.ResponseWriter, r *http.Request) {
+	if h.quotas == nil {
+		h.sendError(w, "API key quotas are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	quota, err := h.quotas.GetQuota(ctx, r.PathValue("key"))
+	if err != nil {
+		h.sendQuotaError(w, err)
+		return
+	}
+
+	h.sendJSON(w, quota, http.StatusOK)
+}
+
+func (h *APIHandler) GetAPIKeyUsageHandler(w http.ResponseWriter, r *http.Request) {
+	if h.quotas == nil {
+		h.sendError(w, "API key quotas are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	report, err := h.quotas.Usage(ctx, r.PathValue("key"), r.URL.Query().Get("period"))
+	if err != nil {
+		h.sendQuotaError(w, err)
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) GetOwnUsageHandler(w http.ResponseWriter, r *http.Request) {
+	if h.quotas == nil {
+		h.sendError(w, "API key quotas are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	apiKey := r.Header.Get(apiKeyHeader)
+	if apiKey == "" {
+		h.sendError(w, "API key is required", http.StatusUnauthorized, "MISSING_API_KEY")
+		return
+	}
+
+	report, err := h.quotas.Usage(ctx, apiKey, r.URL.Query().Get("period"))
+	if err != nil {
+		h.sendQuotaError(w, err)
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) sendQuotaError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidQuota):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrQuotaExceeded):
+		h.sendError(w, err.Error(), http.StatusTooManyRequests, "QUOTA_EXCEEDED")
+	default:
+		h.sendError(w, "Failed to process quota request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
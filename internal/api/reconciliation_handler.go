@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"io"
+	"net/http"
+)
+
+const maxSettlementFileSize = 20 << 20
+
+func (h *APIHandler) WithReconciliation(reconciliation *service.ReconciliationService) *APIHandler {
+	h.reconciliation = reconciliation
+	return h
+}
+
+func (h *APIHandler) ImportSettlementHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliation == nil {
+		h.sendError(w, "Reconciliation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	report, err := h.reconciliation.Import(ctx, r.URL.Query().Get("source"), io.LimitReader(r.Body, maxSettlementFileSize))
+	if err != nil {
+		h.sendReconciliationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusCreated)
+}
+
+func (h *APIHandler) ListReconciliationsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliation == nil {
+		h.sendError(w, "Reconciliation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	reports, err := h.reconciliation.List(ctx)
+	if err != nil {
+		h.sendReconciliationError(w, err)
+		return
+	}
+	for _, report := range reports {
+		report.Items = nil
+	}
+
+	h.sendJSON(w, reports, http.StatusOK)
+}
+
+func (h *APIHandler) GetReconciliationHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliation == nil {
+		h.sendError(w, "Reconciliation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	report, err := h.reconciliation.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendReconciliationError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	if status, open := query.Get("status"), query.Get("open") == "true"; status != "" || open {
+		items := report.Items[:0]
+		for _, item := range report.Items {
+			if (status == "" || string(item.Status) == status) && (!open || item.IsOpen()) {
+				items = append(items, item)
+			}
+		}
+		report.Items = items
+	}
+	if report.Items == nil {
+		report.Items = []domain.ReconciliationItem{}
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) ResolveReconciliationItemHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reconciliation == nil {
+		h.sendError(w, "Reconciliation is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator := r.Header.Get(operatorHeader)
+	if operator == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return
+	}
+
+	var req service.BreakResolution
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	report, err := h.reconciliation.Resolve(ctx, r.PathValue("id"), r.PathValue("itemId"), operator, req)
+	if err != nil {
+		h.sendReconciliationError(w, err)
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) sendReconciliationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidSettlementFile), errors.Is(err, service.ErrInvalidResolution):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, service.ErrReconciliationState):
+		h.sendError(w, err.Error(), http.StatusConflict, "CONFLICT")
+	default:
+		h.sendError(w, "Failed to process reconciliation", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+func (h *APIHandler) WithReserves(reserves *service.ReservesService) *APIHandler {
+	h.reserves = reserves
+	return h
+}
+
+func (h *APIHandler) GenerateReservesReportHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reserves == nil {
+		h.sendError(w, "Reserves reporting is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	report, err := h.reserves.Generate(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrSnapshotUnsupported) {
+			h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_SUPPORTED")
+			return
+		}
+		h.sendError(w, "Failed to generate reserves report", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
+
+func (h *APIHandler) LatestReservesReportHandler(w http.ResponseWriter, r *http.Request) {
+	if h.reserves == nil {
+		h.sendError(w, "Reserves reporting is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	report, err := h.reserves.Latest()
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+
+	h.sendJSON(w, report, http.StatusOK)
+}
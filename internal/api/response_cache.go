@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/pkg/cache"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	cacheStatusHeader = "X-Cache"
+	immutableVersion  = "final"
+)
+
+type ResponseCacheConfig struct {
+	TTL                  time.Duration
+	MaxEntries           int
+	StatementClosedAfter time.Duration
+	MaxStatementBytes    int
+}
+
+func DefaultResponseCacheConfig() ResponseCacheConfig {
+	return ResponseCacheConfig{
+		TTL:                  5 * time.Minute,
+		MaxEntries:           10000,
+		StatementClosedAfter: 24 * time.Hour,
+		MaxStatementBytes:    1 << 20,
+	}
+}
+
+type FXRatesResponse struct {
+	Base    string             `json:"base"`
+	Version uint64             `json:"version"`
+	Rates   map[string]float64 `json:"rates"`
+}
+
+type cachedStatement struct {
+	openingBalance float64
+	closingBalance float64
+	takenAt        time.Time
+	body           []byte
+}
+
+type responseCaches struct {
+	cfg          ResponseCacheConfig
+	transactions *cache.Cache[string, *domain.Transaction]
+	statements   *cache.Cache[string, cachedStatement]
+	fxRates      *cache.Cache[string, FXRatesResponse]
+}
+
+func (h *APIHandler) WithResponseCache(cfg ResponseCacheConfig) *APIHandler {
+	caches := &responseCaches{
+		cfg:          cfg,
+		transactions: cache.New[string, *domain.Transaction]("transactions", cfg.TTL, cfg.MaxEntries),
+		statements:   cache.New[string, cachedStatement]("statements", cfg.TTL, cfg.MaxEntries),
+		fxRates:      cache.New[string, FXRatesResponse]("fx_rates", cfg.TTL, 1),
+	}
+	if h.metrics != nil {
+		caches.transactions.SetObserver(h.metrics)
+		caches.statements.SetObserver(h.metrics)
+		caches.fxRates.SetObserver(h.metrics)
+	}
+	h.cache = caches
+	return h
+}
+
+func (h *APIHandler) cachedTransaction(id string) (*domain.Transaction, bool) {
+	if h.cache == nil || id == "" {
+		return nil, false
+	}
+	return h.cache.transactions.Get(id, immutableVersion)
+}
+
+func (h *APIHandler) cacheTransaction(tx *domain.Transaction) {
+	if h.cache == nil {
+		return
+	}
+	switch tx.Status {
+	case domain.StatusCompleted, domain.StatusExpired, domain.StatusCancelled:
+		h.cache.transactions.Set(tx.ID, immutableVersion, tx.Clone())
+	}
+}
+
+func (h *APIHandler) statementCacheKey(accountID string, from, to time.Time, format string) (string, bool) {
+	if h.cache == nil || !to.Before(time.Now().Add(-h.cache.cfg.StatementClosedAfter)) {
+		return "", false
+	}
+	return fmt.Sprintf("%s|%d|%d|%s", accountID, from.UnixNano(), to.UnixNano(), format), true
+}
+
+func statementVersion(account *domain.Account) string {
+	return strconv.FormatInt(account.LastActivityAt.UnixNano(), 10) + "|" + strconv.FormatFloat(account.Balance, 'f', -1, 64)
+}
+
+func setStatementHeaders(w http.ResponseWriter, opening, closing float64, takenAt time.Time) {
+	w.Header().Set("X-Statement-Opening-Balance", strconv.FormatFloat(opening, 'f', 2, 64))
+	w.Header().Set("X-Statement-Closing-Balance", strconv.FormatFloat(closing, 'f', 2, 64))
+	w.Header().Set("X-Statement-Snapshot-At", takenAt.UTC().Format(time.RFC3339Nano))
+}
+
+func (h *APIHandler) serveCachedStatement(w http.ResponseWriter, ctx context.Context, key, format string, statement *processor.Statement) {
+	version := statementVersion(statement.Account)
+	cached, hit := h.cache.statements.Get(key, version)
+	if hit {
+		w.Header().Set(cacheStatusHeader, "HIT")
+	} else {
+		var buf bytes.Buffer
+		if err := statement.Write(ctx, &buf, format); err != nil {
+			h.logger.Error("Failed to render statement", slog.String("account_id", statement.Account.ID), slog.String("error", err.Error()))
+			h.sendError(w, "Failed to prepare statement", http.StatusInternalServerError, "SERVER_ERROR")
+			return
+		}
+		cached = cachedStatement{
+			openingBalance: statement.OpeningBalance,
+			closingBalance: statement.ClosingBalance,
+			takenAt:        statement.TakenAt,
+			body:           buf.Bytes(),
+		}
+		if len(cached.body) <= h.cache.cfg.MaxStatementBytes {
+			h.cache.statements.Set(key, version, cached)
+		}
+		w.Header().Set(cacheStatusHeader, "MISS")
+	}
+
+	setStatementHeaders(w, cached.openingBalance, cached.closingBalance, cached.takenAt)
+	setExportHeaders(w, format, "statement-"+statement.Account.ID)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(cached.body); err != nil {
+		h.logger.Error("Failed to write statement", slog.String("account_id", statement.Account.ID), slog.String("error", err.Error()))
+	}
+}
+
+func (h *APIHandler) FXRatesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.wallets == nil {
+		h.sendError(w, "Wallets are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	fx := h.wallets.FX()
+	rates, version := fx.Rates()
+	versionKey := strconv.FormatUint(version, 10)
+	w.Header().Set("ETag", `"fx-`+versionKey+`"`)
+
+	if h.cache != nil {
+		if cached, ok := h.cache.fxRates.Get(fx.Base(), versionKey); ok {
+			w.Header().Set(cacheStatusHeader, "HIT")
+			h.sendJSON(w, cached, http.StatusOK)
+			return
+		}
+		w.Header().Set(cacheStatusHeader, "MISS")
+	}
+
+	response := FXRatesResponse{Base: fx.Base(), Version: version, Rates: rates}
+	if h.cache != nil {
+		h.cache.fxRates.Set(fx.Base(), versionKey, response)
+	}
+	h.sendJSON(w, response, http.StatusOK)
+}
+
+func (h *APIHandler) ResponseCacheStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.cache == nil {
+		h.sendError(w, "Response cache is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, []cache.Stats{
+		h.cache.transactions.Stats(),
+		h.cache.statements.Stats(),
+		h.cache.fxRates.Stats(),
+	}, http.StatusOK)
+}
@@ -3,14 +3,20 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"finance_manager/internal/domain"
 	"finance_manager/internal/processor"
 	"finance_manager/internal/repository"
+	"finance_manager/internal/repository/schema"
+	"finance_manager/internal/service"
 	"finance_manager/pkg/crypto"
 	"finance_manager/pkg/metrics"
+	"finance_manager/pkg/scheduler"
+	"finance_manager/pkg/validator"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -20,6 +26,42 @@ type APIHandler struct {
 	signer         *crypto.Signer
 	logger         *slog.Logger
 	requestTimeout time.Duration
+	metadata       *validator.MetadataSchemaRegistry
+	limitChanges   *service.LimitChangeService
+	beneficiaries  *service.BeneficiaryService
+	disputes       *service.DisputeService
+	chargebacks    *service.ChargebackService
+	wallets        *service.WalletService
+	products       *service.ProductService
+	inbox          *service.InboxService
+	notifications  *service.NotificationService
+	installments   *service.InstallmentService
+	dualControl    *service.DualControlService
+	operatorAuth   OperatorAuthenticator
+	userAuth       UserAuthenticator
+	quotas         *service.QuotaService
+	partners       *service.PartnerService
+	reserves       *service.ReservesService
+	schema         *schema.Migrator
+	scheduler      *scheduler.Scheduler
+	ingestion      *service.IngestionService
+	cache          *responseCaches
+	activity       *service.ActivityService
+	search         *service.SearchService
+	budgets        *service.BudgetService
+	reconciliation *service.ReconciliationService
+	payouts        *service.PayoutService
+	exposure       *service.ExposureService
+	aliases        *service.AliasService
+	consents       *service.ConsentService
+	webhooks       *service.WebhookService
+	archive        *service.ArchiveService
+	delegations    *service.DelegationService
+	idempotency    *idempotencyStore
+	shedder        *loadShedder
+	snapshots      *service.EODSnapshotService
+	dataQuality    *service.DataQualityService
+	impersonation  *service.ImpersonationService
 }
 
 func NewAPIHandler(
@@ -38,26 +80,55 @@ func NewAPIHandler(
 		signer:         signer,
 		logger:         logger,
 		requestTimeout: 30 * time.Second,
+		metadata:       validator.MustDefaultMetadataSchemas(),
+		idempotency:    newIdempotencyStore(24 * time.Hour),
 	}
 }
 
 type CreateTransactionRequest struct {
-	Type          domain.TransactionType `json:"type"`
-	Amount        float64                `json:"amount"`
-	Currency      string                 `json:"currency"`
-	FromAccountID string                 `json:"from_account_id,omitempty"`
-	ToAccountID   string                 `json:"to_account_id,omitempty"`
-	Description   string                 `json:"description,omitempty"`
-	Metadata      map[string]string      `json:"metadata,omitempty"`
-	Signature     string                 `json:"signature,omitempty"`
+	Type          domain.TransactionType  `json:"type"`
+	Amount        float64                 `json:"amount"`
+	Currency      string                  `json:"currency"`
+	FromAccountID string                  `json:"from_account_id,omitempty"`
+	ToAccountID   string                  `json:"to_account_id,omitempty"`
+	Legs          []domain.TransactionLeg `json:"legs,omitempty"`
+	Description   string                  `json:"description,omitempty"`
+	PurposeCode   string                  `json:"purpose_code,omitempty"`
+	Memo          string                  `json:"memo,omitempty"`
+	Metadata      map[string]string       `json:"metadata,omitempty"`
+	SchemaVersion string                  `json:"metadata_schema_version,omitempty"`
+	Timestamp     int64                   `json:"timestamp,omitempty"`
+	Signature     string                  `json:"signature,omitempty"`
 }
 
 type TransactionResponse struct {
-	ID         string                   `json:"id"`
-	Status     domain.TransactionStatus `json:"status"`
-	RiskScore  int                      `json:"risk_score"`
-	FraudFlags []string                 `json:"fraud_flags,omitempty"`
-	Message    string                   `json:"message,omitempty"`
+	ID            string                   `json:"id"`
+	Reference     string                   `json:"reference,omitempty"`
+	Status        domain.TransactionStatus `json:"status"`
+	RiskScore     int                      `json:"risk_score"`
+	FraudFlags    []string                 `json:"fraud_flags,omitempty"`
+	DecidedByRule string                   `json:"decided_by_rule,omitempty"`
+	Explanation   *domain.RiskExplanation  `json:"explanation,omitempty"`
+	Queued        bool                     `json:"queued,omitempty"`
+	Message       string                   `json:"message,omitempty"`
+	Display       *TransactionDisplay      `json:"display,omitempty"`
+}
+
+type BatchTransactionRequest struct {
+	Transactions []CreateTransactionRequest `json:"transactions"`
+}
+
+type BatchTransactionResult struct {
+	Index       int                  `json:"index"`
+	Transaction *TransactionResponse `json:"transaction,omitempty"`
+	Error       string               `json:"error,omitempty"`
+	Code        string               `json:"code,omitempty"`
+}
+
+type BatchTransactionResponse struct {
+	Results   []BatchTransactionResult `json:"results"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
 }
 
 type ErrorResponse struct {
@@ -66,12 +137,23 @@ type ErrorResponse struct {
 	Details string `json:"details,omitempty"`
 }
 
+type MetadataErrorResponse struct {
+	Error   string                         `json:"error"`
+	Code    string                         `json:"code"`
+	Version string                         `json:"schema_version"`
+	Errors  []validator.MetadataFieldError `json:"errors"`
+}
+
 func (h *APIHandler) CreateTransactionHandler(w http.ResponseWriter, r *http.Request) {
 	startTime := time.Now()
 
-	ctx, cancel := context.WithTimeout(r.Context(), h.requestTimeout)
+	ctx, cancel := h.requestContext(r)
 	defer cancel()
 
+	if !h.admitTransaction(w, r) {
+		return
+	}
+
 	var req CreateTransactionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
@@ -79,16 +161,24 @@ func (h *APIHandler) CreateTransactionHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	if err := h.validateTransactionRequest(req); err != nil {
-		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+		h.sendRequestValidationError(w, err)
 		return
 	}
 
 	if req.Signature != "" {
+		signedAt := req.Timestamp
+		if signedAt == 0 {
+			signedAt = time.Now().Unix()
+		}
+		if skew := time.Since(time.Unix(signedAt, 0)); skew > signatureMaxSkew || skew < -signatureMaxSkew {
+			h.sendError(w, "Signature timestamp outside allowed window", http.StatusUnauthorized, "INVALID_SIGNATURE")
+			return
+		}
 		if valid, err := h.signer.VerifyTransaction(
 			"",
 			req.Amount,
 			req.Currency,
-			time.Now().Unix(),
+			signedAt,
 			req.Signature,
 		); !valid || err != nil {
 			h.sendError(w, "Invalid signature", http.StatusUnauthorized, "INVALID_SIGNATURE")
@@ -96,56 +186,282 @@ func (h *APIHandler) CreateTransactionHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	tx := domain.NewTransaction(req.Type, req.Amount, req.Currency).
-		WithDescription(req.Description).
-		WithAccounts(req.FromAccountID, req.ToAccountID)
+	idempotencyKey := r.Header.Get(idempotencyKeyHeader)
+	if idempotencyKey != "" {
+		fingerprint := requestFingerprint(req)
+		if previous, exists := h.idempotency.begin(idempotencyKey, fingerprint); exists {
+			if previous.fingerprint != fingerprint {
+				h.sendError(w, "Idempotency key was already used with a different request", http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED")
+				return
+			}
+			if !previous.done {
+				h.sendError(w, "A request with this idempotency key is still in progress", http.StatusConflict, "IDEMPOTENCY_CONFLICT")
+				return
+			}
+			w.Header().Set(idempotencyReplayedHeader, "true")
+			h.sendJSON(w, previous.body, previous.status)
+			return
+		}
+	}
 
-	for k, v := range req.Metadata {
-		tx.AddMetadata(k, v)
+	tx := h.buildTransaction(req)
+	if err := h.resolveAccountAliases(ctx, tx); err != nil {
+		if idempotencyKey != "" {
+			h.idempotency.abandon(idempotencyKey)
+		}
+		h.sendAliasError(w, err)
+		return
+	}
+	if err := h.authorizePartner(ctx, r, tx); err != nil {
+		if idempotencyKey != "" {
+			h.idempotency.abandon(idempotencyKey)
+		}
+		h.sendPartnerError(w, err)
+		return
+	}
+	if err := h.authorizeDelegate(ctx, r, tx); err != nil {
+		if idempotencyKey != "" {
+			h.idempotency.abandon(idempotencyKey)
+		}
+		h.sendDelegationError(w, err)
+		return
 	}
 
-	err := h.processor.ProcessTransaction(ctx, tx)
+	reservation, err := h.reserveQuotaVolume(ctx, w, r, req.Amount)
+	if err != nil {
+		if idempotencyKey != "" {
+			h.idempotency.abandon(idempotencyKey)
+		}
+		h.sendQuotaError(w, err)
+		return
+	}
+
+	err = h.processor.ProcessTransaction(ctx, tx)
 	duration := time.Since(startTime)
 
 	success := err == nil
-	h.metrics.RecordTransaction(duration, tx.RiskScore, success)
+	h.metrics.RecordTransaction(ctx, duration, tx.RiskScore, success, exemplarAccount(tx))
 
 	if err != nil {
+		h.releaseQuotaVolume(ctx, reservation)
+		if idempotencyKey != "" {
+			h.idempotency.abandon(idempotencyKey)
+		}
 		h.logger.Error("Transaction processing failed",
 			slog.String("error", err.Error()),
 			slog.String("transaction_id", tx.ID))
-		h.sendError(w, fmt.Sprintf("Transaction failed: %v", err), http.StatusInternalServerError, "PROCESSING_ERROR")
+		h.sendProcessingError(w, err)
 		return
 	}
 
-	response := TransactionResponse{
-		ID:         tx.ID,
-		Status:     tx.Status,
-		RiskScore:  tx.RiskScore,
-		FraudFlags: tx.FraudFlags,
-		Message:    "Transaction processed successfully",
+	response := newTransactionResponse(tx)
+	if locale, ok := requestLocale(w, r); ok {
+		response.Display = transactionDisplay(locale, tx)
 	}
-
-	h.sendJSON(w, response, http.StatusCreated)
+	status := http.StatusCreated
+	if response.Queued {
+		status = http.StatusAccepted
+		if tx.Metadata[processor.MetadataDegradedQueued] == "true" {
+			w.Header().Set(degradedModeHeader, "true")
+		}
+		if id := tx.Metadata[processor.MetadataMaintenanceWindow]; id != "" {
+			w.Header().Set(maintenanceHeader, id)
+		}
+	}
+	if idempotencyKey != "" {
+		h.idempotency.complete(idempotencyKey, status, response)
+	}
+	h.sendJSON(w, response, status)
 	h.logger.Info("Transaction processed successfully",
 		slog.String("transaction_id", tx.ID),
 		slog.String("status", string(tx.Status)),
 		slog.Int("risk_score", tx.RiskScore))
 }
 
+func (h *APIHandler) BatchTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req BatchTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	const maxBatchSize = 1000
+	if len(req.Transactions) == 0 {
+		h.sendError(w, "Batch must contain at least one transaction", http.StatusBadRequest, "VALIDATION_ERROR")
+		return
+	}
+	if len(req.Transactions) > maxBatchSize {
+		h.sendError(w, fmt.Sprintf("Batch size exceeds maximum of %d", maxBatchSize), http.StatusBadRequest, "VALIDATION_ERROR")
+		return
+	}
+
+	response := BatchTransactionResponse{
+		Results: make([]BatchTransactionResult, len(req.Transactions)),
+	}
+
+	var txs []*domain.Transaction
+	var indexes []int
+	var reservations []*service.QuotaCheck
+	for i, item := range req.Transactions {
+		response.Results[i].Index = i
+		if err := h.validateTransactionRequest(item); err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+		tx := h.buildTransaction(item)
+		if err := h.resolveAccountAliases(ctx, tx); err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+		if err := h.authorizePartner(ctx, r, tx); err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+		if err := h.authorizeDelegate(ctx, r, tx); err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+		reservation, err := h.reserveQuotaVolume(ctx, w, r, item.Amount)
+		if err != nil {
+			response.Results[i].Error = err.Error()
+			continue
+		}
+		txs = append(txs, tx)
+		indexes = append(indexes, i)
+		reservations = append(reservations, reservation)
+	}
+
+	locale, localized := requestLocale(w, r)
+	startTime := time.Now()
+	errs := h.processor.ProcessBatch(ctx, txs)
+	duration := time.Since(startTime)
+
+	for j, tx := range txs {
+		i := indexes[j]
+		h.metrics.RecordTransaction(ctx, duration, tx.RiskScore, errs[j] == nil, exemplarAccount(tx))
+		if errs[j] != nil {
+			h.releaseQuotaVolume(ctx, reservations[j])
+			response.Results[i].Error = errs[j].Error()
+			_, response.Results[i].Code = processingErrorStatus(errs[j])
+			continue
+		}
+		txResponse := newTransactionResponse(tx)
+		if localized {
+			txResponse.Display = transactionDisplay(locale, tx)
+		}
+		response.Results[i].Transaction = &txResponse
+	}
+
+	for _, result := range response.Results {
+		if result.Error != "" {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+	h.logger.Info("Batch processed",
+		slog.Int("succeeded", response.Succeeded),
+		slog.Int("failed", response.Failed))
+}
+
+func processingErrorStatus(err error) (int, string) {
+	var timeout *processor.StageTimeoutError
+	switch {
+	case errors.As(err, &timeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "PROCESSING_TIMEOUT"
+	case errors.Is(err, validator.ErrDuplicateTransaction), errors.Is(err, repository.ErrDuplicate), errors.Is(err, repository.ErrTransactionConflict):
+		return http.StatusConflict, "CONFLICT"
+	case errors.Is(err, processor.ErrValidationFailed), errors.Is(err, processor.ErrUnknownTransactionType), errors.Is(err, processor.ErrAccountRequired):
+		return http.StatusBadRequest, "VALIDATION_ERROR"
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound, "ACCOUNT_NOT_FOUND"
+	case errors.Is(err, repository.ErrInsufficientFunds):
+		return http.StatusUnprocessableEntity, "INSUFFICIENT_FUNDS"
+	case errors.Is(err, processor.ErrLimitExceeded):
+		return http.StatusUnprocessableEntity, "LIMIT_EXCEEDED"
+	case errors.Is(err, processor.ErrAccountInactive), errors.Is(err, repository.ErrAccountSuspended):
+		return http.StatusUnprocessableEntity, "ACCOUNT_INACTIVE"
+	case errors.Is(err, processor.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity, "CURRENCY_MISMATCH"
+	case errors.Is(err, processor.ErrTransactionTypeNotAllowed):
+		return http.StatusUnprocessableEntity, "TYPE_NOT_ALLOWED"
+	case errors.Is(err, processor.ErrCorridorNotSupported):
+		return http.StatusUnprocessableEntity, "CORRIDOR_NOT_SUPPORTED"
+	case errors.Is(err, processor.ErrBlockedBySpendingControls):
+		return http.StatusUnprocessableEntity, "SPENDING_CONTROL_BLOCKED"
+	case errors.Is(err, processor.ErrInvalidPurposeCode):
+		return http.StatusUnprocessableEntity, "INVALID_PURPOSE_CODE"
+	case errors.Is(err, processor.ErrInvalidMemo):
+		return http.StatusUnprocessableEntity, "INVALID_MEMO"
+	case errors.Is(err, processor.ErrDependencyUnavailable):
+		return http.StatusServiceUnavailable, "DEPENDENCY_UNAVAILABLE"
+	case errors.Is(err, processor.ErrUnderMaintenance):
+		return http.StatusServiceUnavailable, "MAINTENANCE"
+	default:
+		return http.StatusInternalServerError, "PROCESSING_ERROR"
+	}
+}
+
+func (h *APIHandler) sendRequestValidationError(w http.ResponseWriter, err error) {
+	var metadataErr *validator.MetadataValidationError
+	if errors.As(err, &metadataErr) {
+		h.sendJSON(w, MetadataErrorResponse{
+			Error:   "Invalid transaction metadata",
+			Code:    "INVALID_METADATA",
+			Version: metadataErr.Version,
+			Errors:  metadataErr.Errors,
+		}, http.StatusBadRequest)
+		return
+	}
+	h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+}
+
+func (h *APIHandler) sendProcessingError(w http.ResponseWriter, err error) {
+	status, code := processingErrorStatus(err)
+	var maintenance *processor.MaintenanceError
+	if errors.As(err, &maintenance) {
+		setMaintenanceHeaders(w, maintenance.Window)
+	}
+	if status == http.StatusGatewayTimeout {
+		h.sendError(w, fmt.Sprintf("Transaction timed out: %v", err), status, code)
+		return
+	}
+	h.sendError(w, fmt.Sprintf("Transaction failed: %v", err), status, code)
+}
+
 func (h *APIHandler) GetTransactionHandler(w http.ResponseWriter, r *http.Request) {
 	transactionID := r.URL.Query().Get("id")
-	if transactionID == "" {
+	reference := r.URL.Query().Get("reference")
+	if transactionID == "" && reference == "" {
 		h.sendError(w, "Transaction ID is required", http.StatusBadRequest, "MISSING_ID")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), h.requestTimeout)
+	ctx, cancel := h.requestContext(r)
 	defer cancel()
 
-	tx, err := h.processor.GetTransaction(ctx, transactionID)
+	if tx, hit := h.cachedTransaction(transactionID); hit {
+		w.Header().Set(cacheStatusHeader, "HIT")
+		h.sendJSON(w, localize(w, r, tx), http.StatusOK)
+		return
+	}
+
+	var tx *domain.Transaction
+	var err error
+	if transactionID != "" {
+		tx, err = h.processor.GetTransaction(ctx, transactionID)
+	} else {
+		tx, err = h.processor.GetTransactionByReference(ctx, reference)
+	}
 	if err != nil {
-		if err == repository.ErrNotFound {
+		if errors.Is(err, processor.ErrInvalidReference) {
+			h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REFERENCE")
+		} else if errors.Is(err, repository.ErrNotFound) {
 			h.sendError(w, "Transaction not found", http.StatusNotFound, "NOT_FOUND")
 		} else {
 			h.sendError(w, "Failed to get transaction", http.StatusInternalServerError, "SERVER_ERROR")
@@ -153,7 +469,8 @@ func (h *APIHandler) GetTransactionHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	h.sendJSON(w, tx, http.StatusOK)
+	h.cacheTransaction(tx)
+	h.sendJSON(w, localize(w, r, tx), http.StatusOK)
 }
 
 func (h *APIHandler) HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
@@ -162,9 +479,95 @@ func (h *APIHandler) HealthCheckHandler(w http.ResponseWriter, r *http.Request)
 		"timestamp": time.Now().UTC(),
 		"version":   "1.0.0",
 	}
+	if maintenance := h.processor.MaintenanceStatus(time.Now()); maintenance.Active {
+		response["status"] = "maintenance"
+		response["maintenance"] = maintenance.Window
+		setMaintenanceHeaders(w, *maintenance.Window)
+	} else if len(maintenance.Upcoming) > 0 {
+		response["planned_maintenance"] = maintenance.Upcoming
+	}
 	h.sendJSON(w, response, http.StatusOK)
 }
 
+func newTransactionResponse(tx *domain.Transaction) TransactionResponse {
+	response := TransactionResponse{
+		ID:            tx.ID,
+		Reference:     tx.Reference,
+		Status:        tx.Status,
+		RiskScore:     tx.RiskScore,
+		FraudFlags:    tx.FraudFlags,
+		DecidedByRule: tx.Metadata[processor.MetadataDecidedByRule],
+		Explanation:   tx.Explanation,
+		Message:       "Transaction processed successfully",
+	}
+
+	switch tx.Status {
+	case domain.StatusFailed:
+		response.Message = "Transaction blocked by rule"
+	case domain.StatusPending:
+		response.Message = "Transaction is pending review"
+		if tx.Metadata[processor.MetadataDegradedQueued] == "true" {
+			response.Queued = true
+			response.Message = "Transaction accepted and queued; processing is degraded"
+			if tx.Metadata[processor.MetadataMaintenanceWindow] != "" {
+				response.Message = "Transaction accepted and queued until scheduled maintenance ends"
+			}
+		}
+		if tx.Metadata[processor.MetadataDepositRetry] == processor.DepositRetryParked {
+			response.Queued = true
+			response.Message = "Deposit accepted and queued for automatic retry"
+		}
+	case domain.StatusSuspicious:
+		response.Message = "Transaction held as suspicious"
+	}
+
+	return response
+}
+
+func (h *APIHandler) requestContext(r *http.Request) (context.Context, context.CancelFunc) {
+	timeout := h.requestTimeout
+	if raw := r.Header.Get("X-Request-Timeout-Ms"); raw != "" {
+		if ms, err := strconv.Atoi(raw); err == nil && ms > 0 {
+			if requested := time.Duration(ms) * time.Millisecond; requested < timeout {
+				timeout = requested
+			}
+		}
+	}
+	ctx := r.Context()
+	if traceID, ok := metrics.ParseTraceparent(r.Header.Get(metrics.TraceparentHeader)); ok {
+		ctx = metrics.ContextWithTraceID(ctx, traceID)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func exemplarAccount(tx *domain.Transaction) string {
+	if tx.FromAccountID != "" {
+		return tx.FromAccountID
+	}
+	return tx.ToAccountID
+}
+
+func (h *APIHandler) buildTransaction(req CreateTransactionRequest) *domain.Transaction {
+	tx := domain.NewTransaction(req.Type, req.Amount, req.Currency).
+		WithDescription(req.Description).
+		WithAccounts(req.FromAccountID, req.ToAccountID)
+	if len(req.Legs) > 0 {
+		tx.WithLegs(req.Legs...)
+	}
+
+	for k, v := range req.Metadata {
+		tx.AddMetadata(k, v)
+	}
+	if req.PurposeCode != "" {
+		tx.AddMetadata(processor.MetadataPurposeCode, req.PurposeCode)
+	}
+	if req.Memo != "" {
+		tx.AddMetadata(processor.MetadataMemo, req.Memo)
+	}
+
+	return tx
+}
+
 func (h *APIHandler) validateTransactionRequest(req CreateTransactionRequest) error {
 	if req.Amount <= 0 {
 		return fmt.Errorf("amount must be positive")
@@ -176,8 +579,18 @@ func (h *APIHandler) validateTransactionRequest(req CreateTransactionRequest) er
 		return fmt.Errorf("currency must be 3 letters")
 	}
 
+	if len(req.Legs) > 0 && req.Type != domain.TypeTransfer {
+		return fmt.Errorf("only transfers can have legs")
+	}
+
 	switch req.Type {
 	case domain.TypeTransfer:
+		if len(req.Legs) > 0 {
+			if req.FromAccountID == "" || req.ToAccountID != "" {
+				return fmt.Errorf("multi-leg transfers need from_account_id and a to_account_id per leg")
+			}
+			break
+		}
 		if req.FromAccountID == "" || req.ToAccountID == "" {
 			return fmt.Errorf("from_account_id and to_account_id are required for transfers")
 		}
@@ -193,7 +606,10 @@ func (h *APIHandler) validateTransactionRequest(req CreateTransactionRequest) er
 		return fmt.Errorf("unknown transaction type: %s", req.Type)
 	}
 
-	return nil
+	if err := processor.CheckClientMetadata(req.Metadata); err != nil {
+		return err
+	}
+	return h.metadata.Validate(req.SchemaVersion, req.Metadata)
 }
 
 func (h *APIHandler) sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
@@ -223,6 +639,161 @@ func (h *APIHandler) sendError(w http.ResponseWriter, message string, statusCode
 
 func (h *APIHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/transactions", h.CreateTransactionHandler)
+	mux.HandleFunc("POST /api/v1/transactions/batch", h.BatchTransactionsHandler)
+	mux.HandleFunc("POST /api/v1/transactions/validate", h.ValidateTransactionHandler)
 	mux.HandleFunc("GET /api/v1/transactions", h.GetTransactionHandler)
+	mux.HandleFunc("GET /api/v1/transactions/export", h.ExportTransactionsHandler)
+	mux.HandleFunc("GET /api/v1/transactions/{id}/trace", h.TransactionTraceHandler)
+	mux.HandleFunc("GET /api/v1/rules/export", h.ExportRulesHandler)
+	mux.HandleFunc("POST /api/v1/rules/import", h.ImportRulesHandler)
+	mux.HandleFunc("GET /api/v1/rules/stats", h.AllRuleStatsHandler)
+	mux.HandleFunc("GET /api/v1/rules/{id}/stats", h.RuleStatsHandler)
+	mux.HandleFunc("POST /api/v1/rule-groups", h.CreateRuleGroupHandler)
+	mux.HandleFunc("GET /api/v1/rule-groups", h.ListRuleGroupsHandler)
+	mux.HandleFunc("GET /api/v1/rule-groups/{id}", h.GetRuleGroupHandler)
+	mux.HandleFunc("POST /api/v1/rule-groups/{id}/activate", h.ActivateRuleGroupHandler)
+	mux.HandleFunc("POST /api/v1/rule-groups/{id}/deactivate", h.DeactivateRuleGroupHandler)
+	mux.HandleFunc("GET /api/v1/lookup-tables", h.ListLookupTablesHandler)
+	mux.HandleFunc("GET /api/v1/lookup-tables/{name}", h.GetLookupTableHandler)
+	mux.HandleFunc("PUT /api/v1/lookup-tables/{name}", h.SaveLookupTableHandler)
+	mux.HandleFunc("DELETE /api/v1/lookup-tables/{name}", h.DeleteLookupTableHandler)
+	mux.HandleFunc("POST /api/v1/admin/transactions/{id}/override", h.OverrideRiskHandler)
+	mux.HandleFunc("POST /api/v1/accounts/{id}/limits", h.RequestLimitChangeHandler)
+	mux.HandleFunc("POST /api/v1/accounts/{id}/beneficiaries", h.AddBeneficiaryHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/beneficiaries", h.requireAccountAccess(domain.DelegationViewOnly, h.ListBeneficiariesHandler))
+	mux.HandleFunc("PUT /api/v1/accounts/{id}/positive-pay", h.SetPositivePayListHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/positive-pay", h.GetPositivePayListHandler)
+	mux.HandleFunc("DELETE /api/v1/accounts/{id}/positive-pay", h.DeletePositivePayListHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/positive-pay/exceptions", h.ListPositivePayExceptionsHandler)
+	mux.HandleFunc("POST /api/v1/accounts/{id}/positive-pay/exceptions/{transactionId}/review", h.ReviewPositivePayExceptionHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/spending-controls", h.requireAccountAccess(domain.DelegationViewOnly, h.GetSpendingControlsHandler))
+	mux.HandleFunc("PATCH /api/v1/accounts/{id}/spending-controls", h.UpdateSpendingControlsHandler)
+	mux.HandleFunc("POST /api/v1/beneficiaries/{id}/confirm", h.ConfirmBeneficiaryHandler)
+	mux.HandleFunc("DELETE /api/v1/beneficiaries/{id}", h.RevokeBeneficiaryHandler)
+	mux.HandleFunc("POST /api/v1/accounts/{id}/delegations", h.GrantDelegationHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/delegations", h.ListDelegationsHandler)
+	mux.HandleFunc("DELETE /api/v1/delegations/{id}", h.RevokeDelegationHandler)
+	mux.HandleFunc("GET /api/v1/limit-changes/{id}", h.GetLimitChangeHandler)
+	mux.HandleFunc("POST /api/v1/limit-changes/{id}/confirm", h.ConfirmLimitChangeHandler)
+	mux.HandleFunc("POST /api/v1/limit-changes/{id}/cancel", h.CancelLimitChangeHandler)
+	mux.HandleFunc("POST /api/v1/disputes", h.OpenDisputeHandler)
+	mux.HandleFunc("GET /api/v1/disputes/{id}", h.GetDisputeHandler)
+	mux.HandleFunc("PATCH /api/v1/disputes/{id}", h.UpdateDisputeHandler)
+	mux.HandleFunc("POST /api/v1/disputes/{id}/resolve", h.ResolveDisputeHandler)
+	mux.HandleFunc("POST /api/v1/installment-plans", h.CreateInstallmentPlanHandler)
+	mux.HandleFunc("GET /api/v1/installment-plans/{id}", h.GetInstallmentPlanHandler)
+	mux.HandleFunc("POST /api/v1/installment-plans/{id}/cancel", h.CancelInstallmentPlanHandler)
+	mux.HandleFunc("GET /api/v1/sandbox/scenarios", h.SandboxScenariosHandler)
+	mux.HandleFunc("GET /api/v1/corridors", h.ListCorridorsHandler)
+	mux.HandleFunc("GET /api/v1/purpose-codes", h.ListPurposeCodesHandler)
+	mux.HandleFunc("GET /api/v1/events/schemas", h.ListEventSchemasHandler)
+	mux.HandleFunc("GET /api/v1/events/schemas/{type}", h.GetEventSchemaHandler)
+	mux.HandleFunc("POST /api/v1/payout-batches", h.SubmitPayoutBatchHandler)
+	mux.HandleFunc("GET /api/v1/payout-batches/{id}", h.GetPayoutBatchHandler)
+	mux.HandleFunc("GET /api/v1/payout-batches/{id}/progress", h.GetPayoutProgressHandler)
+	mux.HandleFunc("POST /api/v1/chargebacks", h.ReceiveChargebackHandler)
+	mux.HandleFunc("GET /api/v1/chargebacks/{id}", h.GetChargebackHandler)
+	mux.HandleFunc("POST /api/v1/chargebacks/{id}/represent", h.RepresentChargebackHandler)
+	mux.HandleFunc("POST /api/v1/chargebacks/{id}/decide", h.DecideChargebackHandler)
+	mux.HandleFunc("GET /api/v1/merchants/{id}/chargeback-stats", h.MerchantChargebackStatsHandler)
+	mux.HandleFunc("GET /api/v1/users/{id}/wallet", h.GetWalletHandler)
+	mux.HandleFunc("POST /api/v1/users/{id}/wallet/exchange", h.ExchangeHandler)
+	mux.HandleFunc("GET /api/v1/users/{id}/notifications", h.ListNotificationsHandler)
+	mux.HandleFunc("POST /api/v1/users/{id}/consents", h.RecordConsentHandler)
+	mux.HandleFunc("GET /api/v1/users/{id}/consents", h.ListConsentsHandler)
+	mux.HandleFunc("GET /api/v1/users/{id}/consents/history", h.ConsentHistoryHandler)
+	mux.HandleFunc("POST /api/v1/users/{id}/notifications/read", h.MarkAllNotificationsReadHandler)
+	mux.HandleFunc("POST /api/v1/users/{id}/notifications/{notificationId}/read", h.MarkNotificationReadHandler)
+	mux.HandleFunc("POST /api/v1/products", h.CreateProductHandler)
+	mux.HandleFunc("GET /api/v1/products", h.ListProductsHandler)
+	mux.HandleFunc("GET /api/v1/products/{id}", h.GetProductHandler)
+	mux.HandleFunc("PUT /api/v1/products/{id}", h.UpdateProductHandler)
+	mux.HandleFunc("POST /api/v1/products/{id}/retire", h.RetireProductHandler)
+	mux.HandleFunc("PUT /api/v1/accounts/{id}/product", h.AssignProductHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/statement", h.requireAccountAccess(domain.DelegationViewOnly, h.AccountStatementHandler))
+	mux.HandleFunc("GET /api/v1/accounts/{id}/activity", h.requireAccountAccess(domain.DelegationViewOnly, h.AccountActivityHandler))
+	mux.HandleFunc("POST /api/v1/accounts/{id}/budgets", h.CreateBudgetHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/budgets", h.requireAccountAccess(domain.DelegationViewOnly, h.ListBudgetsHandler))
+	mux.HandleFunc("DELETE /api/v1/accounts/{id}/budgets/{budgetId}", h.DeleteBudgetHandler)
+	mux.HandleFunc("GET /api/v1/admin/decisions/export", h.ExportDecisionsHandler)
+	mux.HandleFunc("GET /api/v1/admin/snapshot", h.ExportSnapshotHandler)
+	mux.HandleFunc("POST /api/v1/admin/snapshots/eod", h.TakeEODSnapshotHandler)
+	mux.HandleFunc("GET /api/v1/snapshots/eod", h.ListEODSnapshotsHandler)
+	mux.HandleFunc("GET /api/v1/snapshots/eod/{id}", h.GetEODSnapshotHandler)
+	mux.HandleFunc("POST /api/v1/admin/archives", h.CreateArchiveHandler)
+	mux.HandleFunc("GET /api/v1/admin/archives/{period}", h.GetArchiveManifestHandler)
+	mux.HandleFunc("GET /api/v1/admin/schema/migrations", h.SchemaMigrationStatusHandler)
+	mux.HandleFunc("GET /api/v1/admin/notification-templates", h.ListTemplatesHandler)
+	mux.HandleFunc("GET /api/v1/admin/notification-routes", h.ListNotificationRoutesHandler)
+	mux.HandleFunc("POST /api/v1/admin/notification-templates/{name}/preview", h.PreviewTemplateHandler)
+	mux.HandleFunc("POST /api/v1/admin/notification-templates/{name}/test-send", h.TestSendTemplateHandler)
+	mux.HandleFunc("POST /api/v1/admin/migrations/balances", h.ApplyBalanceMigrationHandler)
+	mux.HandleFunc("POST /api/v1/accounts/{id}/aliases", h.RegisterAliasHandler)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/aliases", h.ListAliasesHandler)
+	mux.HandleFunc("DELETE /api/v1/accounts/{id}/aliases/{type}/{value}", h.DeleteAliasHandler)
+	mux.HandleFunc("GET /api/v1/aliases/{type}/{value}", h.ResolveAliasHandler)
+	mux.HandleFunc("GET /api/v1/admin/system-accounts", h.ListSystemAccountsHandler)
+	mux.HandleFunc("GET /api/v1/admin/suspense", h.ListSuspenseItemsHandler)
+	mux.HandleFunc("GET /api/v1/admin/suspense/{id}", h.GetSuspenseItemHandler)
+	mux.HandleFunc("POST /api/v1/admin/suspense/{id}/reassign", h.ReassignSuspenseHandler)
+	mux.HandleFunc("POST /api/v1/admin/suspense/{id}/return", h.ReturnSuspenseHandler)
+	mux.HandleFunc("POST /api/v1/admin/reserves/reports", h.GenerateReservesReportHandler)
+	mux.HandleFunc("GET /api/v1/admin/reserves/reports/latest", h.LatestReservesReportHandler)
+	mux.HandleFunc("POST /api/v1/admin/data-quality/reports", h.RunDataQualityCheckHandler)
+	mux.HandleFunc("GET /api/v1/admin/data-quality/reports/latest", h.LatestDataQualityReportHandler)
+	mux.HandleFunc("POST /api/v1/admin/impersonations", h.StartImpersonationHandler)
+	mux.HandleFunc("GET /api/v1/admin/impersonations", h.ListImpersonationsHandler)
+	mux.HandleFunc("GET /api/v1/admin/impersonations/{id}", h.GetImpersonationHandler)
+	mux.HandleFunc("DELETE /api/v1/admin/impersonations/{id}", h.EndImpersonationHandler)
+	mux.HandleFunc("GET /api/v1/admin/exposure", h.CurrencyExposureHandler)
+	mux.HandleFunc("GET /api/v1/admin/exposure/thresholds", h.GetExposureThresholdsHandler)
+	mux.HandleFunc("PUT /api/v1/admin/exposure/thresholds", h.SetExposureThresholdHandler)
+	mux.HandleFunc("POST /api/v1/admin/reconciliations", h.ImportSettlementHandler)
+	mux.HandleFunc("GET /api/v1/admin/reconciliations", h.ListReconciliationsHandler)
+	mux.HandleFunc("GET /api/v1/admin/reconciliations/{id}", h.GetReconciliationHandler)
+	mux.HandleFunc("POST /api/v1/admin/reconciliations/{id}/items/{itemId}/resolve", h.ResolveReconciliationItemHandler)
+	mux.HandleFunc("GET /api/v1/admin/degraded-mode", h.DegradedStatusHandler)
+	mux.HandleFunc("GET /api/v1/admin/maintenance", h.MaintenanceStatusHandler)
+	mux.HandleFunc("POST /api/v1/admin/maintenance", h.ScheduleMaintenanceHandler)
+	mux.HandleFunc("DELETE /api/v1/admin/maintenance/{id}", h.CancelMaintenanceHandler)
+	mux.HandleFunc("PUT /api/v1/admin/degraded-mode", h.SetDegradedModeHandler)
+	mux.HandleFunc("POST /api/v1/admin/degraded-mode/drain", h.DrainDegradedQueueHandler)
+	mux.HandleFunc("GET /api/v1/admin/deposit-retries", h.DepositRetryStatusHandler)
+	mux.HandleFunc("GET /api/v1/admin/load-shedding", h.LoadSheddingStatusHandler)
+	mux.HandleFunc("GET /api/v1/admin/admission", h.AdmissionStatusHandler)
+	mux.HandleFunc("POST /api/v1/admin/deposit-retries/run", h.RunDepositRetryHandler)
+	mux.HandleFunc("GET /api/v1/admin/shadow/report", h.ShadowReportHandler)
+	mux.HandleFunc("DELETE /api/v1/admin/shadow/report", h.ResetShadowReportHandler)
+	mux.HandleFunc("GET /api/v1/fx/rates", h.FXRatesHandler)
+	mux.HandleFunc("GET /api/v1/search", h.SearchHandler)
+	mux.HandleFunc("GET /api/v1/admin/cache/stats", h.ResponseCacheStatsHandler)
+	mux.HandleFunc("GET /api/v1/ingestion/{key}", h.IngestionStatusHandler)
+	mux.HandleFunc("GET /api/v1/admin/ingestion/stats", h.IngestionStatsHandler)
+	mux.HandleFunc("GET /api/v1/admin/jobs", h.ListJobsHandler)
+	mux.HandleFunc("GET /api/v1/admin/jobs/{name}/runs", h.JobHistoryHandler)
+	mux.HandleFunc("POST /api/v1/admin/jobs/{name}/trigger", h.TriggerJobHandler)
+	mux.HandleFunc("POST /api/v1/admin/jobs/{name}/pause", h.PauseJobHandler)
+	mux.HandleFunc("POST /api/v1/admin/jobs/{name}/resume", h.ResumeJobHandler)
+	mux.HandleFunc("PUT /api/v1/admin/jobs/{name}/schedule", h.RescheduleJobHandler)
+	mux.HandleFunc("POST /api/v1/admin/accounts/{id}/limit-override", h.OverrideLimitHandler)
+	mux.HandleFunc("GET /api/v1/admin/dashboard/transactions", h.DashboardStatusCountsHandler)
+	mux.HandleFunc("GET /api/v1/admin/dashboard/approval-queue", h.DashboardApprovalQueueHandler)
+	mux.HandleFunc("GET /api/v1/admin/dashboard/fraud-flags", h.DashboardFraudFlagsHandler)
+	mux.HandleFunc("GET /api/v1/admin/dashboard/accounts", h.DashboardBusiestAccountsHandler)
+	mux.HandleFunc("GET /api/v1/admin/dashboard/rules", h.DashboardRuleLeaderboardHandler)
+	mux.HandleFunc("GET /api/v1/admin/approvals", h.ListApprovalsHandler)
+	mux.HandleFunc("GET /api/v1/admin/approvals/{id}", h.GetApprovalHandler)
+	mux.HandleFunc("POST /api/v1/admin/approvals/{id}/approve", h.ApproveHandler)
+	mux.HandleFunc("POST /api/v1/admin/approvals/{id}/reject", h.RejectHandler)
+	mux.HandleFunc("PUT /api/v1/admin/api-keys/{key}/quota", h.SetAPIKeyQuotaHandler)
+	mux.HandleFunc("GET /api/v1/admin/api-keys/{key}/quota", h.GetAPIKeyQuotaHandler)
+	mux.HandleFunc("GET /api/v1/admin/api-keys/{key}/usage", h.GetAPIKeyUsageHandler)
+	mux.HandleFunc("GET /api/v1/usage", h.GetOwnUsageHandler)
+	mux.HandleFunc("POST /api/v1/admin/partners", h.CreatePartnerHandler)
+	mux.HandleFunc("GET /api/v1/admin/partners", h.ListPartnersHandler)
+	mux.HandleFunc("GET /api/v1/admin/partners/{id}", h.GetPartnerHandler)
+	mux.HandleFunc("PUT /api/v1/admin/partners/{id}", h.UpdatePartnerHandler)
+	mux.HandleFunc("DELETE /api/v1/admin/partners/{id}", h.DeletePartnerHandler)
+	mux.HandleFunc("POST /api/v1/webhooks/{id}/replay", h.ReplayWebhooksHandler)
 	mux.HandleFunc("GET /api/health", h.HealthCheckHandler)
 }
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type CreateRuleGroupRequest struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Environments []string `json:"environments,omitempty"`
+	IsActive     bool     `json:"is_active"`
+}
+
+type RuleGroupResponse struct {
+	Group   *domain.RuleGroup `json:"group"`
+	Rules   []*domain.Rule    `json:"rules"`
+	Enabled bool              `json:"enabled"`
+}
+
+func (h *APIHandler) CreateRuleGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req CreateRuleGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	group := &domain.RuleGroup{
+		ID:           req.ID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Environments: req.Environments,
+		IsActive:     req.IsActive,
+	}
+
+	if err := h.processor.RuleEngine().CreateRuleGroup(ctx, group); err != nil {
+		h.sendRuleGroupError(w, err)
+		return
+	}
+
+	h.sendJSON(w, group, http.StatusCreated)
+}
+
+func (h *APIHandler) ListRuleGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	groups, err := h.processor.RuleEngine().ListRuleGroups(ctx)
+	if err != nil {
+		h.sendRuleGroupError(w, err)
+		return
+	}
+
+	h.sendJSON(w, groups, http.StatusOK)
+}
+
+func (h *APIHandler) GetRuleGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	engine := h.processor.RuleEngine()
+	group, rules, err := engine.GetRuleGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendRuleGroupError(w, err)
+		return
+	}
+
+	h.sendJSON(w, RuleGroupResponse{
+		Group:   group,
+		Rules:   rules,
+		Enabled: group.EnabledIn(engine.Environment()),
+	}, http.StatusOK)
+}
+
+func (h *APIHandler) ActivateRuleGroupHandler(w http.ResponseWriter, r *http.Request) {
+	h.setRuleGroupActive(w, r, true)
+}
+
+func (h *APIHandler) DeactivateRuleGroupHandler(w http.ResponseWriter, r *http.Request) {
+	h.setRuleGroupActive(w, r, false)
+}
+
+func (h *APIHandler) setRuleGroupActive(w http.ResponseWriter, r *http.Request, active bool) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	id := r.PathValue("id")
+	if h.dualControl != nil {
+		operator, ok := h.approvalOperator(w, r)
+		if !ok {
+			return
+		}
+		h.stageAction(ctx, w, service.ActionRuleGroupActivation, operator, "", service.RuleGroupActivation{GroupID: id, Active: active})
+		return
+	}
+
+	engine := h.processor.RuleEngine()
+	if err := engine.SetRuleGroupActive(ctx, id, active); err != nil {
+		h.sendRuleGroupError(w, err)
+		return
+	}
+
+	group, _, err := engine.GetRuleGroup(ctx, id)
+	if err != nil {
+		h.sendRuleGroupError(w, err)
+		return
+	}
+
+	h.sendJSON(w, group, http.StatusOK)
+}
+
+func (h *APIHandler) sendRuleGroupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, "Rule group not found", http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, repository.ErrDuplicate):
+		h.sendError(w, "Rule group already exists", http.StatusConflict, "DUPLICATE")
+	case errors.Is(err, processor.ErrRuleGroupsNotConfigured):
+		h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+	default:
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	}
+}
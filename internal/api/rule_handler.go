@@ -0,0 +1,163 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const maxRuleSetSize = 10 << 20
+
+type RuleImportResponse struct {
+	Imported int    `json:"imported"`
+	DryRun   bool   `json:"dry_run"`
+	Message  string `json:"message,omitempty"`
+}
+
+type RuleImportErrorResponse struct {
+	Error  string                          `json:"error"`
+	Code   string                          `json:"code"`
+	Errors []processor.RuleValidationError `json:"errors,omitempty"`
+}
+
+func (h *APIHandler) ExportRulesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	format := ruleSetFormat(r, processor.RuleSetFormatYAML)
+
+	doc, err := h.processor.RuleEngine().ExportRules(ctx)
+	if err != nil {
+		h.sendError(w, "Failed to export rules", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	data, err := processor.MarshalRuleSet(doc, format)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_FORMAT")
+		return
+	}
+
+	w.Header().Set("Content-Type", ruleSetContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="rules.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (h *APIHandler) ImportRulesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	format := ruleSetFormat(r, processor.RuleSetFormatJSON)
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRuleSetSize))
+	if err != nil {
+		h.sendError(w, "Failed to read request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	doc, err := processor.UnmarshalRuleSet(data, format)
+	if err != nil {
+		h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	engine := h.processor.RuleEngine()
+	staged := !dryRun && h.dualControl != nil
+	var operator string
+	if staged {
+		var ok bool
+		if operator, ok = h.approvalOperator(w, r); !ok {
+			return
+		}
+	}
+	if dryRun || staged {
+		err = engine.ValidateRuleSet(doc)
+	} else {
+		err = engine.ImportRules(ctx, doc)
+	}
+
+	var validationErr *processor.RuleSetValidationError
+	if errors.As(err, &validationErr) {
+		h.sendJSON(w, RuleImportErrorResponse{
+			Error:  "Rule set validation failed",
+			Code:   "VALIDATION_ERROR",
+			Errors: validationErr.Errors,
+		}, http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		h.sendError(w, "Failed to import rules", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+	if staged {
+		h.stageAction(ctx, w, service.ActionRuleImport, operator, "", doc)
+		return
+	}
+
+	h.logger.Info("Rule set imported",
+		slog.Int("rules", len(doc.Rules)),
+		slog.Bool("dry_run", dryRun))
+
+	h.sendJSON(w, RuleImportResponse{
+		Imported: len(doc.Rules),
+		DryRun:   dryRun,
+	}, http.StatusOK)
+}
+
+func (h *APIHandler) RuleStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	ruleID := r.PathValue("id")
+	engine := h.processor.RuleEngine()
+
+	if _, err := engine.GetRule(ctx, ruleID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			h.sendError(w, "Rule not found", http.StatusNotFound, "NOT_FOUND")
+		} else {
+			h.sendError(w, "Failed to get rule", http.StatusInternalServerError, "SERVER_ERROR")
+		}
+		return
+	}
+
+	h.sendJSON(w, engine.RuleStats(ruleID), http.StatusOK)
+}
+
+func (h *APIHandler) AllRuleStatsHandler(w http.ResponseWriter, r *http.Request) {
+	h.sendJSON(w, h.processor.RuleEngine().AllRuleStats(), http.StatusOK)
+}
+
+func ruleSetFormat(r *http.Request, fallback string) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if r.Method == http.MethodGet {
+		contentType = r.Header.Get("Accept")
+	}
+
+	switch {
+	case strings.Contains(contentType, "yaml"):
+		return processor.RuleSetFormatYAML
+	case strings.Contains(contentType, "json"):
+		return processor.RuleSetFormatJSON
+	default:
+		return fallback
+	}
+}
+
+func ruleSetContentType(format string) string {
+	if format == processor.RuleSetFormatYAML {
+		return "application/yaml"
+	}
+	return "application/json"
+}
@@ -0,0 +1,13 @@
+package api
+
+import "net/http"
+
+func (h *APIHandler) SandboxScenariosHandler(w http.ResponseWriter, r *http.Request) {
+	cfg, enabled := h.processor.Sandbox()
+	if !enabled {
+		h.sendError(w, "Sandbox mode is not enabled", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, cfg, http.StatusOK)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/pkg/scheduler"
+	"net/http"
+	"strconv"
+)
+
+const defaultJobHistoryLimit = 20
+
+func (h *APIHandler) WithScheduler(sched *scheduler.Scheduler) *APIHandler {
+	h.scheduler = sched
+	return h
+}
+
+func (h *APIHandler) ListJobsHandler(w http.ResponseWriter, r *http.Request) {
+	if h.scheduler == nil {
+		h.sendError(w, "Scheduler is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	h.sendJSON(w, h.scheduler.Jobs(), http.StatusOK)
+}
+
+func (h *APIHandler) JobHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if h.scheduler == nil {
+		h.sendError(w, "Scheduler is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	limit := defaultJobHistoryLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			h.sendError(w, "limit must be a positive integer", http.StatusBadRequest, "INVALID_REQUEST")
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.scheduler.History(ctx, r.PathValue("name"), limit)
+	if err != nil {
+		h.sendSchedulerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, runs, http.StatusOK)
+}
+
+func (h *APIHandler) TriggerJobHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.requireSchedulerOperator(w, r) {
+		return
+	}
+
+	name := r.PathValue("name")
+	if err := h.scheduler.Trigger(name); err != nil {
+		h.sendSchedulerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, map[string]string{"job": name, "status": "triggered"}, http.StatusAccepted)
+}
+
+func (h *APIHandler) PauseJobHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.requireSchedulerOperator(w, r) {
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	status, err := h.scheduler.Pause(ctx, r.PathValue("name"))
+	if err != nil {
+		h.sendSchedulerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
+
+func (h *APIHandler) ResumeJobHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.requireSchedulerOperator(w, r) {
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	status, err := h.scheduler.Resume(ctx, r.PathValue("name"))
+	if err != nil {
+		h.sendSchedulerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
+
+func (h *APIHandler) RescheduleJobHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.requireSchedulerOperator(w, r) {
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req struct {
+		Schedule string `json:"schedule"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	status, err := h.scheduler.Reschedule(ctx, r.PathValue("name"), req.Schedule)
+	if err != nil {
+		h.sendSchedulerError(w, err)
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
+
+func (h *APIHandler) requireSchedulerOperator(w http.ResponseWriter, r *http.Request) bool {
+	if h.scheduler == nil {
+		h.sendError(w, "Scheduler is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return false
+	}
+	if r.Header.Get(operatorHeader) == "" {
+		h.sendError(w, "Operator identity is required", http.StatusUnauthorized, "MISSING_OPERATOR")
+		return false
+	}
+	return true
+}
+
+func (h *APIHandler) sendSchedulerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, scheduler.ErrInvalidSchedule):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, scheduler.ErrTriggerPending), errors.Is(err, scheduler.ErrNotStarted):
+		h.sendError(w, err.Error(), http.StatusConflict, "JOB_BUSY")
+	default:
+		h.sendError(w, "Failed to process job request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"finance_manager/internal/repository/schema"
+	"net/http"
+)
+
+func (h *APIHandler) WithSchemaMigrator(migrator *schema.Migrator) *APIHandler {
+	h.schema = migrator
+	return h
+}
+
+func (h *APIHandler) SchemaMigrationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if h.schema == nil {
+		h.sendError(w, "No SQL database is configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	status, err := h.schema.Status(ctx)
+	if err != nil {
+		h.sendError(w, "Failed to read schema migration status", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, status, http.StatusOK)
+}
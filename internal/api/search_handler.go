@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/service"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+func (h *APIHandler) WithSearch(search *service.SearchService) *APIHandler {
+	h.search = search
+	return h
+}
+
+func (h *APIHandler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	if h.search == nil {
+		h.sendError(w, "Search is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	limit := defaultSearchLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxSearchLimit {
+			h.sendError(w, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit), http.StatusBadRequest, "INVALID_REQUEST")
+			return
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	results, err := h.search.Search(ctx, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSearchQuery) {
+			h.sendError(w, err.Error(), http.StatusBadRequest, "INVALID_REQUEST")
+			return
+		}
+		h.logger.Error("Search failed", slog.String("error", err.Error()))
+		h.sendError(w, "Search failed", http.StatusInternalServerError, "SERVER_ERROR")
+		return
+	}
+
+	h.sendJSON(w, results, http.StatusOK)
+}
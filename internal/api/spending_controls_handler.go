@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"net/http"
+)
+
+type SpendingControlsRequest struct {
+	BlockInternational   *bool     `json:"block_international"`
+	BlockOnline          *bool     `json:"block_online"`
+	BlockedCategories    *[]string `json:"blocked_categories"`
+	MaxTransactionAmount *float64  `json:"max_transaction_amount"`
+}
+
+func (h *APIHandler) GetSpendingControlsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	controls, err := h.processor.SpendingControls(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendSpendingControlsError(w, err)
+		return
+	}
+
+	h.sendJSON(w, controls, http.StatusOK)
+}
+
+func (h *APIHandler) UpdateSpendingControlsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req SpendingControlsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	controls, err := h.processor.SpendingControls(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendSpendingControlsError(w, err)
+		return
+	}
+	if req.BlockInternational != nil {
+		controls.BlockInternational = *req.BlockInternational
+	}
+	if req.BlockOnline != nil {
+		controls.BlockOnline = *req.BlockOnline
+	}
+	if req.BlockedCategories != nil {
+		controls.BlockedCategories = *req.BlockedCategories
+	}
+	if req.MaxTransactionAmount != nil {
+		controls.MaxTransactionAmount = *req.MaxTransactionAmount
+	}
+
+	if err := h.processor.SetSpendingControls(ctx, controls); err != nil {
+		h.sendSpendingControlsError(w, err)
+		return
+	}
+
+	h.sendJSON(w, controls, http.StatusOK)
+}
+
+func (h *APIHandler) sendSpendingControlsError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, processor.ErrSpendingControlsNotConfigured):
+		h.sendError(w, "Spending controls are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, processor.ErrInvalidSpendingControls):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	default:
+		h.sendError(w, "Failed to process spending controls", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type SuspenseRepairRequest struct {
+	AccountID string `json:"account_id,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+func (h *APIHandler) ListSuspenseItemsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	status := domain.SuspenseStatus(r.URL.Query().Get("status"))
+	if status == "" {
+		status = domain.SuspenseOpen
+	} else if status == "all" {
+		status = ""
+	}
+
+	items, err := h.processor.SuspenseItems(ctx, status)
+	if err != nil {
+		h.sendSuspenseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, items, http.StatusOK)
+}
+
+func (h *APIHandler) GetSuspenseItemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	item, err := h.processor.SuspenseItem(ctx, r.PathValue("id"))
+	if err != nil {
+		h.sendSuspenseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, item, http.StatusOK)
+}
+
+func (h *APIHandler) ReassignSuspenseHandler(w http.ResponseWriter, r *http.Request) {
+	h.repairSuspense(w, r, service.ActionSuspenseReassign, h.processor.ReassignSuspense)
+}
+
+func (h *APIHandler) ReturnSuspenseHandler(w http.ResponseWriter, r *http.Request) {
+	h.repairSuspense(w, r, service.ActionSuspenseReturn, h.processor.ReturnSuspense)
+}
+
+func (h *APIHandler) repairSuspense(w http.ResponseWriter, r *http.Request, action string, repair func(ctx context.Context, transactionID string, repair processor.SuspenseRepair) (*domain.SuspenseItem, error)) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	operator, ok := h.approvalOperator(w, r)
+	if !ok {
+		return
+	}
+
+	var req SuspenseRepairRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	suspenseRepair := processor.SuspenseRepair{
+		AccountID: req.AccountID,
+		Reason:    req.Reason,
+		Operator:  operator,
+	}
+	if h.dualControl != nil {
+		h.stageAction(ctx, w, action, operator, req.Reason, service.TransactionSuspenseRepair{
+			TransactionID: r.PathValue("id"),
+			Repair:        suspenseRepair,
+		})
+		return
+	}
+
+	item, err := repair(ctx, r.PathValue("id"), suspenseRepair)
+	if err != nil {
+		h.logger.Error("Suspense repair failed",
+			slog.String("transaction_id", r.PathValue("id")),
+			slog.String("operator", operator),
+			slog.String("error", err.Error()))
+		h.sendSuspenseError(w, err)
+		return
+	}
+
+	h.sendJSON(w, item, http.StatusOK)
+}
+
+func (h *APIHandler) sendSuspenseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, processor.ErrSuspenseNotConfigured), errors.Is(err, processor.ErrSystemAccountMissing):
+		h.sendError(w, "Suspense handling is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, processor.ErrInvalidSuspenseRepair):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, processor.ErrSuspenseItemResolved):
+		h.sendError(w, err.Error(), http.StatusConflict, "INVALID_STATE")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	default:
+		h.sendProcessingError(w, err)
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"errors"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"net/http"
+)
+
+func (h *APIHandler) TransactionTraceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	trace, err := h.processor.Trace(ctx, r.PathValue("id"))
+	switch {
+	case errors.Is(err, processor.ErrTraceUnavailable):
+		h.sendError(w, "Decision tracing is not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, "Transaction not found", http.StatusNotFound, "NOT_FOUND")
+	case err != nil:
+		h.sendError(w, "Failed to get transaction trace", http.StatusInternalServerError, "SERVER_ERROR")
+	default:
+		h.sendJSON(w, trace, http.StatusOK)
+	}
+}
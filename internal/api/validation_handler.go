@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"net/http"
+)
+
+// ValidateTransactionResponse is the outcome a transaction would reach if it
+// were submitted now. Nothing is persisted or executed to produce it.
+type ValidateTransactionResponse struct {
+	Accepted      bool                     `json:"accepted"`
+	Status        domain.TransactionStatus `json:"status"`
+	RiskScore     int                      `json:"risk_score"`
+	FraudFlags    []string                 `json:"fraud_flags,omitempty"`
+	DecidedByRule string                   `json:"decided_by_rule,omitempty"`
+	Explanation   *domain.RiskExplanation  `json:"explanation,omitempty"`
+	Queued        bool                     `json:"queued,omitempty"`
+	Error         string                   `json:"error,omitempty"`
+	Code          string                   `json:"code,omitempty"`
+	Display       *TransactionDisplay      `json:"display,omitempty"`
+}
+
+func (h *APIHandler) ValidateTransactionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req CreateTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	if err := h.validateTransactionRequest(req); err != nil {
+		h.sendRequestValidationError(w, err)
+		return
+	}
+
+	tx := h.buildTransaction(req)
+	if err := h.resolveAccountAliases(ctx, tx); err != nil {
+		h.sendAliasError(w, err)
+		return
+	}
+	if err := h.authorizePartner(ctx, r, tx); err != nil {
+		h.sendPartnerError(w, err)
+		return
+	}
+	if err := h.authorizeDelegate(ctx, r, tx); err != nil {
+		h.sendDelegationError(w, err)
+		return
+	}
+
+	result := h.processor.Prevalidate(ctx, tx)
+	evaluated := result.Transaction
+	response := ValidateTransactionResponse{
+		Accepted:      result.Err == nil && evaluated.Status != domain.StatusFailed,
+		Status:        evaluated.Status,
+		RiskScore:     evaluated.RiskScore,
+		FraudFlags:    evaluated.FraudFlags,
+		DecidedByRule: evaluated.Metadata[processor.MetadataDecidedByRule],
+		Explanation:   evaluated.Explanation,
+		Queued:        evaluated.Metadata[processor.MetadataDegradedQueued] == "true",
+	}
+	if result.Err != nil {
+		_, response.Code = processingErrorStatus(result.Err)
+		response.Error = result.Err.Error()
+	}
+	if locale, ok := requestLocale(w, r); ok {
+		response.Display = transactionDisplay(locale, evaluated)
+	}
+
+	h.sendJSON(w, response, http.StatusOK)
+}
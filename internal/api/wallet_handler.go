@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+type ExchangeRequest struct {
+	FromAccountID string  `json:"from_account_id"`
+	ToAccountID   string  `json:"to_account_id"`
+	Amount        float64 `json:"amount"`
+}
+
+func (h *APIHandler) WithWallets(wallets *service.WalletService) *APIHandler {
+	h.wallets = wallets
+	return h
+}
+
+func (h *APIHandler) GetWalletHandler(w http.ResponseWriter, r *http.Request) {
+	if h.wallets == nil {
+		h.sendError(w, "Wallets are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	wallet, err := h.wallets.Get(ctx, r.PathValue("id"), r.URL.Query().Get("currency"))
+	if err != nil {
+		h.sendWalletError(w, err)
+		return
+	}
+
+	h.sendJSON(w, wallet, http.StatusOK)
+}
+
+func (h *APIHandler) ExchangeHandler(w http.ResponseWriter, r *http.Request) {
+	if h.wallets == nil {
+		h.sendError(w, "Wallets are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req ExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	exchange, err := h.wallets.Exchange(ctx, r.PathValue("id"), req.FromAccountID, req.ToAccountID, req.Amount)
+	if err != nil {
+		h.sendWalletError(w, err)
+		return
+	}
+
+	h.sendJSON(w, exchange, http.StatusCreated)
+}
+
+func (h *APIHandler) sendWalletError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, err.Error(), http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrInvalidExchange), errors.Is(err, service.ErrUnsupportedCurrency):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrInsufficientFunds), errors.Is(err, repository.ErrAccountSuspended):
+		h.sendError(w, err.Error(), http.StatusUnprocessableEntity, "EXECUTION_FAILED")
+	default:
+		h.sendError(w, "Failed to process wallet request", http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/service"
+	"net/http"
+)
+
+func (h *APIHandler) WithWebhooks(webhooks *service.WebhookService) *APIHandler {
+	h.webhooks = webhooks
+	return h
+}
+
+func (h *APIHandler) ReplayWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	if h.webhooks == nil {
+		h.sendError(w, "Webhooks are not configured", http.StatusNotImplemented, "NOT_CONFIGURED")
+		return
+	}
+
+	ctx, cancel := h.requestContext(r)
+	defer cancel()
+
+	var req service.WebhookReplayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, "Invalid request body", http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	result, err := h.webhooks.Replay(ctx, r.PathValue("id"), req)
+	if err != nil {
+		h.sendWebhookError(w, err)
+		return
+	}
+
+	h.sendJSON(w, result, http.StatusAccepted)
+}
+
+func (h *APIHandler) sendWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrEventLogNotConfigured):
+		h.sendError(w, err.Error(), http.StatusNotImplemented, "NOT_CONFIGURED")
+	case errors.Is(err, service.ErrInvalidReplay):
+		h.sendError(w, err.Error(), http.StatusBadRequest, "VALIDATION_ERROR")
+	case errors.Is(err, repository.ErrNotFound):
+		h.sendError(w, "Webhook subscriber not found", http.StatusNotFound, "NOT_FOUND")
+	case errors.Is(err, service.ErrWebhookQueueFull):
+		h.sendError(w, err.Error(), http.StatusServiceUnavailable, "QUEUE_FULL")
+	default:
+		h.sendError(w, err.Error(), http.StatusInternalServerError, "SERVER_ERROR")
+	}
+}
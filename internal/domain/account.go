@@ -17,12 +17,20 @@ type Account struct {
 	UserID         string        `json:"user_id"`
 	Balance        float64       `json:"balance"`
 	Currency       string        `json:"currency"`
+	ProductID      string        `json:"product_id,omitempty"`
 	Status         AccountStatus `json:"status"`
 	DailyLimit     float64       `json:"daily_limit"`
 	MonthlyLimit   float64       `json:"monthly_limit"`
 	CreatedAt      time.Time     `json:"created_at"`
 	LastActivityAt time.Time     `json:"last_activity_at"`
 	RiskCategory   string        `json:"risk_category"`
+	Timezone       string        `json:"timezone,omitempty"`
+	Country        string        `json:"country,omitempty"`
+	SystemRole     SystemRole    `json:"system_role,omitempty"`
+}
+
+func (a *Account) IsSystem() bool {
+	return a.SystemRole != ""
 }
 
 type BalanceUpdate struct {
@@ -31,3 +39,8 @@ type BalanceUpdate struct {
 	Type      string
 	Timestamp time.Time
 }
+
+func (a *Account) Clone() *Account {
+	clone := *a
+	return &clone
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type AliasType string
+
+const (
+	AliasIBAN       AliasType = "iban"
+	AliasCardToken  AliasType = "card_token"
+	AliasLegacyID   AliasType = "legacy_id"
+	AliasPartnerRef AliasType = "partner_ref"
+)
+
+type AccountAlias struct {
+	Type      AliasType `json:"type"`
+	Value     string    `json:"value"`
+	AccountID string    `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t AliasType) Valid() bool {
+	switch t {
+	case AliasIBAN, AliasCardToken, AliasLegacyID, AliasPartnerRef:
+		return true
+	default:
+		return false
+	}
+}
+
+func NormalizeAliasValue(aliasType AliasType, value string) string {
+	value = strings.TrimSpace(value)
+	if aliasType == AliasIBAN {
+		return strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+	}
+	return value
+}
+
+func ParseAccountRef(ref string) (AliasType, string, bool) {
+	prefix, value, found := strings.Cut(ref, ":")
+	aliasType := AliasType(prefix)
+	if !found || !aliasType.Valid() {
+		return "", "", false
+	}
+	return aliasType, NormalizeAliasValue(aliasType, value), true
+}
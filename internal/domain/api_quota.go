@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+)
+
+type QuotaEnforcement string
+
+const (
+	QuotaSoft QuotaEnforcement = "soft"
+	QuotaHard QuotaEnforcement = "hard"
+)
+
+type APIKeyQuota struct {
+	APIKey          string           `json:"api_key"`
+	MonthlyRequests int64            `json:"monthly_requests,omitempty"`
+	MonthlyVolume   float64          `json:"monthly_volume,omitempty"`
+	Enforcement     QuotaEnforcement `json:"enforcement"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+type APIKeyUsage struct {
+	APIKey       string    `json:"api_key"`
+	Period       string    `json:"period"`
+	Requests     int64     `json:"requests"`
+	Transactions int64     `json:"transactions"`
+	Volume       float64   `json:"volume"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func UsagePeriod(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
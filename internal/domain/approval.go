@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalExecuted ApprovalStatus = "executed"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalFailed   ApprovalStatus = "failed"
+)
+
+type ApprovalRequest struct {
+	ID           string          `json:"id"`
+	Action       string          `json:"action"`
+	Payload      json.RawMessage `json:"payload"`
+	Maker        string          `json:"maker"`
+	Reason       string          `json:"reason,omitempty"`
+	Checker      string          `json:"checker,omitempty"`
+	DecisionNote string          `json:"decision_note,omitempty"`
+	Status       ApprovalStatus  `json:"status"`
+	Result       json.RawMessage `json:"result,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	DecidedAt    *time.Time      `json:"decided_at,omitempty"`
+}
+
+func NewApprovalRequest(action, maker, reason string, payload json.RawMessage) *ApprovalRequest {
+	return &ApprovalRequest{
+		ID:        NewID(),
+		Action:    action,
+		Payload:   payload,
+		Maker:     maker,
+		Reason:    reason,
+		Status:    ApprovalPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (a *ApprovalRequest) Clone() *ApprovalRequest {
+	clone := *a
+	clone.Payload = slices.Clone(a.Payload)
+	clone.Result = slices.Clone(a.Result)
+	if a.DecidedAt != nil {
+		decidedAt := *a.DecidedAt
+		clone.DecidedAt = &decidedAt
+	}
+	return &clone
+}
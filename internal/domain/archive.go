@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+type ArchiveFileKind string
+
+const (
+	ArchiveTransactions ArchiveFileKind = "transactions"
+	ArchiveStatements   ArchiveFileKind = "statements"
+
+	ArchiveFormatJSONLinesGzip = "jsonl+gzip"
+)
+
+type ArchiveFile struct {
+	Key     string          `json:"key"`
+	Kind    ArchiveFileKind `json:"kind"`
+	Format  string          `json:"format"`
+	Records int             `json:"records"`
+	Bytes   int             `json:"bytes"`
+	SHA256  string          `json:"sha256"`
+}
+
+type ArchiveManifest struct {
+	Version   int           `json:"version"`
+	Period    string        `json:"period"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	CreatedAt time.Time     `json:"created_at"`
+	Files     []ArchiveFile `json:"files"`
+}
+
+func (m *ArchiveManifest) File(kind ArchiveFileKind) (ArchiveFile, bool) {
+	for _, file := range m.Files {
+		if file.Kind == kind {
+			return file, true
+		}
+	}
+	return ArchiveFile{}, false
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+type AuditEntry struct {
+	ID         string            `json:"id"`
+	Action     string            `json:"action"`
+	EntityType string            `json:"entity_type"`
+	EntityID   string            `json:"entity_id"`
+	Actor      string            `json:"actor"`
+	Reason     string            `json:"reason"`
+	Details    map[string]string `json:"details,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+func NewAuditEntry(action, entityType, entityID, actor, reason string) *AuditEntry {
+	return &AuditEntry{
+		ID:         NewID(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Actor:      actor,
+		Reason:     reason,
+		Details:    make(map[string]string),
+		CreatedAt:  time.Now(),
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+)
+
+type BeneficiaryStatus string
+
+const (
+	BeneficiaryPendingVerification BeneficiaryStatus = "pending_verification"
+	BeneficiaryTrusted             BeneficiaryStatus = "trusted"
+	BeneficiaryRevoked             BeneficiaryStatus = "revoked"
+)
+
+type TrustedBeneficiary struct {
+	ID                   string            `json:"id"`
+	AccountID            string            `json:"account_id"`
+	BeneficiaryAccountID string            `json:"beneficiary_account_id"`
+	Status               BeneficiaryStatus `json:"status"`
+	OTPHash              string            `json:"-"`
+	OTPExpiresAt         time.Time         `json:"-"`
+	OTPAttempts          int               `json:"-"`
+	TrustedAt            *time.Time        `json:"trusted_at,omitempty"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+}
+
+func NewTrustedBeneficiary(accountID, beneficiaryAccountID string) *TrustedBeneficiary {
+	return &TrustedBeneficiary{
+		ID:                   NewID(),
+		AccountID:            accountID,
+		BeneficiaryAccountID: beneficiaryAccountID,
+		Status:               BeneficiaryPendingVerification,
+		CreatedAt:            time.Now(),
+	}
+}
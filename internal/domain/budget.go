@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+type BudgetPeriod string
+
+const (
+	BudgetWeekly  BudgetPeriod = "weekly"
+	BudgetMonthly BudgetPeriod = "monthly"
+)
+
+type Budget struct {
+	ID        string       `json:"id"`
+	AccountID string       `json:"account_id"`
+	Category  string       `json:"category"`
+	Limit     float64      `json:"limit"`
+	Period    BudgetPeriod `json:"period"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+func NewBudget(accountID, category string, limit float64, period BudgetPeriod) *Budget {
+	return &Budget{
+		ID:        NewID(),
+		AccountID: accountID,
+		Category:  category,
+		Limit:     limit,
+		Period:    period,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (b *Budget) PeriodStart(at time.Time) time.Time {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	if b.Period == BudgetWeekly {
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+	return day.AddDate(0, 0, 1-day.Day())
+}
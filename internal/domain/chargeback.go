@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+)
+
+type ChargebackStatus string
+
+const (
+	ChargebackReceived    ChargebackStatus = "received"
+	ChargebackRepresented ChargebackStatus = "represented"
+	ChargebackWon         ChargebackStatus = "won"
+	ChargebackLost        ChargebackStatus = "lost"
+)
+
+type Chargeback struct {
+	ID                string           `json:"id"`
+	TransactionID     string           `json:"transaction_id"`
+	MerchantAccountID string           `json:"merchant_account_id"`
+	CustomerAccountID string           `json:"customer_account_id"`
+	Amount            float64          `json:"amount"`
+	Currency          string           `json:"currency"`
+	ReasonCode        string           `json:"reason_code"`
+	Status            ChargebackStatus `json:"status"`
+	ChargebackTxID    string           `json:"chargeback_transaction_id,omitempty"`
+	RepresentmentNote string           `json:"representment_note,omitempty"`
+	ReversalTxID      string           `json:"reversal_transaction_id,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	ClosedAt          *time.Time       `json:"closed_at,omitempty"`
+}
+
+func NewChargeback(tx *Transaction, reasonCode string) *Chargeback {
+	return &Chargeback{
+		ID:                NewID(),
+		TransactionID:     tx.ID,
+		MerchantAccountID: tx.ToAccountID,
+		CustomerAccountID: tx.FromAccountID,
+		Amount:            tx.Amount,
+		Currency:          tx.Currency,
+		ReasonCode:        reasonCode,
+		Status:            ChargebackReceived,
+		CreatedAt:         time.Now(),
+	}
+}
+
+func (c *Chargeback) IsClosed() bool {
+	return c.Status == ChargebackWon || c.Status == ChargebackLost
+}
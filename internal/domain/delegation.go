@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+)
+
+type DelegationScope string
+
+const (
+	DelegationViewOnly DelegationScope = "view_only"
+	DelegationInitiate DelegationScope = "initiate"
+)
+
+type Delegation struct {
+	ID         string          `json:"id"`
+	AccountID  string          `json:"account_id"`
+	GrantorID  string          `json:"grantor_id"`
+	DelegateID string          `json:"delegate_id"`
+	Scope      DelegationScope `json:"scope"`
+	MaxAmount  float64         `json:"max_amount,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	RevokedAt  *time.Time      `json:"revoked_at,omitempty"`
+	RevokedBy  string          `json:"revoked_by,omitempty"`
+}
+
+func NewDelegation(accountID, grantorID, delegateID string, scope DelegationScope, maxAmount float64) *Delegation {
+	return &Delegation{
+		ID:         NewID(),
+		AccountID:  accountID,
+		GrantorID:  grantorID,
+		DelegateID: delegateID,
+		Scope:      scope,
+		MaxAmount:  maxAmount,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (d *Delegation) Active() bool {
+	return d.RevokedAt == nil
+}
+
+func (d *Delegation) Permits(scope DelegationScope, amount float64) bool {
+	if !d.Active() {
+		return false
+	}
+	switch scope {
+	case DelegationViewOnly:
+		return true
+	case DelegationInitiate:
+		return d.Scope == DelegationInitiate && (d.MaxAmount == 0 || amount <= d.MaxAmount)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+)
+
+type DisputeStatus string
+type DisputeOutcome string
+
+const (
+	DisputeOpen        DisputeStatus = "open"
+	DisputeUnderReview DisputeStatus = "under_review"
+	DisputeResolved    DisputeStatus = "resolved"
+
+	DisputeOutcomeCustomer DisputeOutcome = "customer"
+	DisputeOutcomeMerchant DisputeOutcome = "merchant"
+)
+
+type EvidenceAttachment struct {
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	StorageKey  string    `json:"storage_key"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+type Dispute struct {
+	ID                  string               `json:"id"`
+	TransactionID       string               `json:"transaction_id"`
+	AccountID           string               `json:"account_id"`
+	Amount              float64              `json:"amount"`
+	Currency            string               `json:"currency"`
+	Reason              string               `json:"reason"`
+	Status              DisputeStatus        `json:"status"`
+	Evidence            []EvidenceAttachment `json:"evidence,omitempty"`
+	Notes               []string             `json:"notes,omitempty"`
+	ProvisionalCreditID string               `json:"provisional_credit_id,omitempty"`
+	ReversalID          string               `json:"reversal_id,omitempty"`
+	Outcome             DisputeOutcome       `json:"outcome,omitempty"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	ResolvedAt          *time.Time           `json:"resolved_at,omitempty"`
+}
+
+func NewDispute(tx *Transaction, accountID, reason string) *Dispute {
+	return &Dispute{
+		ID:            NewID(),
+		TransactionID: tx.ID,
+		AccountID:     accountID,
+		Amount:        tx.Amount,
+		Currency:      tx.Currency,
+		Reason:        reason,
+		Status:        DisputeOpen,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func (d *Dispute) IsActive() bool {
+	return d.Status != DisputeResolved
+}
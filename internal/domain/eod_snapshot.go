@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type SnapshotBalance struct {
+	AccountID string        `json:"account_id"`
+	Currency  string        `json:"currency"`
+	Balance   float64       `json:"balance"`
+	Status    AccountStatus `json:"status"`
+}
+
+type SnapshotTransaction struct {
+	TransactionID string            `json:"transaction_id"`
+	Type          TransactionType   `json:"type"`
+	Status        TransactionStatus `json:"status"`
+	FromAccountID string            `json:"from_account_id,omitempty"`
+	ToAccountID   string            `json:"to_account_id,omitempty"`
+	Amount        float64           `json:"amount"`
+	Currency      string            `json:"currency"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+type EODSnapshot struct {
+	ID           string                `json:"id"`
+	BusinessDate string                `json:"business_date"`
+	AsOf         time.Time             `json:"as_of"`
+	TakenAt      time.Time             `json:"taken_at"`
+	Balances     []SnapshotBalance     `json:"balances"`
+	Holds        []SnapshotTransaction `json:"holds"`
+	Pending      []SnapshotTransaction `json:"pending"`
+	Checksum     string                `json:"checksum"`
+}
+
+func EODSnapshotID(businessDate string) string {
+	return "eod-" + businessDate
+}
+
+func (s *EODSnapshot) Clone() *EODSnapshot {
+	clone := *s
+	clone.Balances = slices.Clone(s.Balances)
+	clone.Holds = slices.Clone(s.Holds)
+	clone.Pending = slices.Clone(s.Pending)
+	return &clone
+}
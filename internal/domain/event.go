@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+const (
+	EventTransactionCompleted  = "transaction.completed"
+	EventTransactionSuspicious = "transaction.suspicious"
+	EventCaseOpened            = "case.opened"
+	EventAccountFrozen         = "account.frozen"
+)
+
+type EventSchema struct {
+	Type        string          `json:"type"`
+	Version     string          `json:"version"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+type WebhookEvent struct {
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	SchemaVersion string      `json:"schema_version"`
+	OccurredAt    time.Time   `json:"occurred_at"`
+	Data          interface{} `json:"data"`
+}
+
+type TransactionEventData struct {
+	TransactionID string            `json:"transaction_id"`
+	Reference     string            `json:"reference,omitempty"`
+	Type          TransactionType   `json:"type"`
+	Status        TransactionStatus `json:"status"`
+	Amount        interface{}       `json:"amount"`
+	Currency      string            `json:"currency"`
+	FromAccountID string            `json:"from_account_id,omitempty"`
+	ToAccountID   string            `json:"to_account_id,omitempty"`
+	RiskScore     int               `json:"risk_score"`
+	FraudFlags    []string          `json:"fraud_flags,omitempty"`
+	Routing       map[string]string `json:"-"`
+}
+
+type CaseEventData struct {
+	CaseID        string        `json:"case_id"`
+	TransactionID string        `json:"transaction_id"`
+	AccountID     string        `json:"account_id"`
+	Reason        string        `json:"reason"`
+	Status        DisputeStatus `json:"status"`
+}
+
+type AccountEventData struct {
+	AccountID string        `json:"account_id"`
+	Status    AccountStatus `json:"status"`
+	Reason    string        `json:"reason,omitempty"`
+	RuleID    string        `json:"rule_id,omitempty"`
+}
+
+var (
+	transactionEventProperties = map[string]interface{}{
+		"transaction_id":  map[string]string{"type": "string"},
+		"reference":       map[string]string{"type": "string"},
+		"type":            map[string]interface{}{"enum": []TransactionType{TypeDeposit, TypeWithdrawal, TypeTransfer, TypeChargeback}},
+		"status":          map[string]string{"type": "string"},
+		"amount":          map[string]interface{}{"type": []string{"number", "string"}, "description": "exact amount, or a bucket label when amounts are tokenized for webhooks"},
+		"currency":        map[string]interface{}{"type": "string", "pattern": "^[A-Z]{3}$"},
+		"from_account_id": map[string]string{"type": "string"},
+		"to_account_id":   map[string]string{"type": "string"},
+		"risk_score":      map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 100},
+		"fraud_flags":     map[string]interface{}{"type": "array", "items": map[string]string{"type": "string"}},
+	}
+	transactionEventRequired = []string{"transaction_id", "type", "status", "amount", "currency", "risk_score"}
+
+	eventCatalog = []EventSchema{
+		{
+			Type:        EventTransactionCompleted,
+			Version:     "1.0",
+			Description: "A transaction was executed and balances were updated.",
+			Schema:      eventSchemaDocument(EventTransactionCompleted, "1.0", transactionEventProperties, transactionEventRequired),
+		},
+		{
+			Type:        EventTransactionSuspicious,
+			Version:     "1.0",
+			Description: "A transaction was held as suspicious and was not executed.",
+			Schema:      eventSchemaDocument(EventTransactionSuspicious, "1.0", transactionEventProperties, append(slices.Clone(transactionEventRequired), "fraud_flags")),
+		},
+		{
+			Type:        EventCaseOpened,
+			Version:     "1.0",
+			Description: "A dispute case was opened against a completed transaction.",
+			Schema: eventSchemaDocument(EventCaseOpened, "1.0", map[string]interface{}{
+				"case_id":        map[string]string{"type": "string"},
+				"transaction_id": map[string]string{"type": "string"},
+				"account_id":     map[string]string{"type": "string"},
+				"reason":         map[string]string{"type": "string"},
+				"status":         map[string]interface{}{"enum": []DisputeStatus{DisputeOpen, DisputeUnderReview, DisputeResolved}},
+			}, []string{"case_id", "transaction_id", "account_id", "reason", "status"}),
+		},
+		{
+			Type:        EventAccountFrozen,
+			Version:     "1.0",
+			Description: "An account was suspended and can no longer send or receive funds.",
+			Schema: eventSchemaDocument(EventAccountFrozen, "1.0", map[string]interface{}{
+				"account_id": map[string]string{"type": "string"},
+				"status":     map[string]interface{}{"enum": []AccountStatus{AccountSuspended}},
+				"reason":     map[string]string{"type": "string"},
+				"rule_id":    map[string]string{"type": "string"},
+			}, []string{"account_id", "status"}),
+		},
+	}
+)
+
+func eventSchemaDocument(eventType, version string, data map[string]interface{}, required []string) json.RawMessage {
+	document, _ := json.Marshal(map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     eventType + "/" + version,
+		"title":   eventType,
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"id":             map[string]string{"type": "string"},
+			"type":           map[string]string{"const": eventType},
+			"schema_version": map[string]string{"const": version},
+			"occurred_at":    map[string]string{"type": "string", "format": "date-time"},
+			"data": map[string]interface{}{
+				"type":       "object",
+				"properties": data,
+				"required":   required,
+			},
+		},
+		"required": []string{"id", "type", "schema_version", "occurred_at", "data"},
+	})
+	return document
+}
+
+func EventCatalog() []EventSchema {
+	return slices.Clone(eventCatalog)
+}
+
+func LookupEventSchema(eventType, version string) (EventSchema, bool) {
+	var latest EventSchema
+	found := false
+	for _, schema := range eventCatalog {
+		if schema.Type != eventType {
+			continue
+		}
+		if version != "" {
+			if schema.Version == version {
+				return schema, true
+			}
+			continue
+		}
+		latest, found = schema, true
+	}
+	return latest, found
+}
+
+func NewWebhookEvent(eventType string, data interface{}) (*WebhookEvent, bool) {
+	schema, ok := LookupEventSchema(eventType, "")
+	if !ok {
+		return nil, false
+	}
+	return &WebhookEvent{
+		ID:            NewID(),
+		Type:          eventType,
+		SchemaVersion: schema.Version,
+		OccurredAt:    time.Now(),
+		Data:          data,
+	}, true
+}
@@ -0,0 +1,58 @@
+package domain
+
+type RiskExplanation struct {
+	FraudPatterns []PatternMatch `json:"fraud_patterns,omitempty"`
+	TimeModifier  int            `json:"time_modifier,omitempty"`
+	Rules         []RuleMatch    `json:"rules,omitempty"`
+	LimitChecks   []LimitCheck   `json:"limit_checks,omitempty"`
+}
+
+type PatternMatch struct {
+	Name   string `json:"name"`
+	Flag   string `json:"flag"`
+	Weight int    `json:"weight"`
+}
+
+type RuleMatch struct {
+	RuleID   string `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	Action   string `json:"action"`
+	Applied  bool   `json:"applied"`
+	Decisive bool   `json:"decisive"`
+}
+
+type LimitCheck struct {
+	Name   string  `json:"name"`
+	Limit  float64 `json:"limit"`
+	Amount float64 `json:"amount"`
+	Passed bool    `json:"passed"`
+}
+
+func (tx *Transaction) explanation() *RiskExplanation {
+	if tx.Explanation == nil {
+		tx.Explanation = &RiskExplanation{}
+	}
+	return tx.Explanation
+}
+
+func (tx *Transaction) ExplainFraud(patterns []PatternMatch, timeModifier int) {
+	explanation := tx.explanation()
+	explanation.FraudPatterns = patterns
+	explanation.TimeModifier = timeModifier
+}
+
+func (tx *Transaction) ExplainRules(rules []RuleMatch) {
+	tx.explanation().Rules = rules
+}
+
+func (tx *Transaction) AddLimitCheck(name string, limit, amount float64) bool {
+	passed := amount <= limit
+	explanation := tx.explanation()
+	explanation.LimitChecks = append(explanation.LimitChecks, LimitCheck{
+		Name:   name,
+		Limit:  limit,
+		Amount: amount,
+		Passed: passed,
+	})
+	return passed
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+type IDGenerator interface {
+	NewID() string
+}
+
+var (
+	idGeneratorMu sync.RWMutex
+	idGenerator   IDGenerator = NewULIDGenerator(rand.Reader)
+)
+
+func SetIDGenerator(generator IDGenerator) {
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	idGenerator = generator
+}
+
+func NewID() string {
+	idGeneratorMu.RLock()
+	generator := idGenerator
+	idGeneratorMu.RUnlock()
+	return generator.NewID()
+}
+
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	entropy io.Reader
+	now     func() time.Time
+	lastMs  uint64
+	last    [10]byte
+}
+
+func NewULIDGenerator(entropy io.Reader) *ULIDGenerator {
+	return &ULIDGenerator{entropy: entropy, now: time.Now}
+}
+
+func (g *ULIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms <= g.lastMs && g.increment() {
+		ms = g.lastMs
+	} else {
+		if ms <= g.lastMs {
+			ms = g.lastMs + 1
+		}
+		if _, err := io.ReadFull(g.entropy, g.last[:]); err != nil {
+			panic(fmt.Sprintf("domain: reading ID entropy: %v", err))
+		}
+		g.lastMs = ms
+	}
+
+	var id [16]byte
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(id[6:], g.last[:])
+	return encodeULID(id)
+}
+
+func (g *ULIDGenerator) increment() bool {
+	for i := len(g.last) - 1; i >= 0; i-- {
+		g.last[i]++
+		if g.last[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeULID(id [16]byte) string {
+	out := make([]byte, 26)
+	var acc uint32
+	var bits uint
+	pos := len(out) - 1
+	for i := len(id) - 1; i >= 0; i-- {
+		acc |= uint32(id[i]) << bits
+		bits += 8
+		for bits >= 5 {
+			out[pos] = crockfordAlphabet[acc&31]
+			pos--
+			acc >>= 5
+			bits -= 5
+		}
+	}
+	out[pos] = crockfordAlphabet[acc&31]
+	return string(out)
+}
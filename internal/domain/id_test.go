@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULIDGenerator_MonotonicWithinMillisecond(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	gen := NewULIDGenerator(bytes.NewReader(bytes.Repeat([]byte{0x7f}, 20)))
+	gen.now = func() time.Time { return now }
+
+	first := gen.NewID()
+	second := gen.NewID()
+	now = now.Add(time.Millisecond)
+	third := gen.NewID()
+
+	if len(first) != 26 || strings.Trim(first, crockfordAlphabet) != "" {
+		t.Fatalf("expected 26 character Crockford ID, got %q", first)
+	}
+	if !(first < second && second < third) {
+		t.Errorf("expected sortable IDs, got %s, %s, %s", first, second, third)
+	}
+}
+
+func TestULIDGenerator_PanicsWithoutEntropy(t *testing.T) {
+	gen := NewULIDGenerator(bytes.NewReader(nil))
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected exhausted entropy to panic instead of returning a zero ID")
+		}
+	}()
+	gen.NewID()
+}
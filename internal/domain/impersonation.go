@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+)
+
+type ImpersonationSession struct {
+	ID         string     `json:"id"`
+	OperatorID string     `json:"operator_id"`
+	UserID     string     `json:"user_id"`
+	Reason     string     `json:"reason"`
+	StartedAt  time.Time  `json:"started_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	Requests   int        `json:"requests"`
+}
+
+func NewImpersonationSession(operatorID, userID, reason string, duration time.Duration) *ImpersonationSession {
+	now := time.Now()
+	return &ImpersonationSession{
+		ID:         NewID(),
+		OperatorID: operatorID,
+		UserID:     userID,
+		Reason:     reason,
+		StartedAt:  now,
+		ExpiresAt:  now.Add(duration),
+	}
+}
+
+func (s *ImpersonationSession) Active(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+type IngestionStatus string
+
+const (
+	IngestionProcessing   IngestionStatus = "processing"
+	IngestionCompleted    IngestionStatus = "completed"
+	IngestionRejected     IngestionStatus = "rejected"
+	IngestionDeadLettered IngestionStatus = "dead_lettered"
+)
+
+type IngestionRecord struct {
+	Key               string            `json:"idempotency_key"`
+	TransactionID     string            `json:"transaction_id,omitempty"`
+	Status            IngestionStatus   `json:"status"`
+	TransactionStatus TransactionStatus `json:"transaction_status,omitempty"`
+	Attempts          int               `json:"attempts"`
+	Error             string            `json:"error,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+func (r *IngestionRecord) Terminal() bool {
+	return r.Status != IngestionProcessing
+}
+
+func (r *IngestionRecord) Clone() *IngestionRecord {
+	clone := *r
+	return &clone
+}
+
+type ProcessedMessage struct {
+	MessageID      string    `json:"message_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	TransactionID  string    `json:"transaction_id,omitempty"`
+	ProcessedAt    time.Time `json:"processed_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
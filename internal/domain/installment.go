@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type InstallmentPlanStatus string
+
+const (
+	InstallmentPlanActive    InstallmentPlanStatus = "active"
+	InstallmentPlanCompleted InstallmentPlanStatus = "completed"
+	InstallmentPlanCancelled InstallmentPlanStatus = "cancelled"
+)
+
+type InstallmentPlan struct {
+	ID             string                `json:"id"`
+	FromAccountID  string                `json:"from_account_id"`
+	ToAccountID    string                `json:"to_account_id"`
+	Amount         float64               `json:"amount"`
+	Currency       string                `json:"currency"`
+	Description    string                `json:"description,omitempty"`
+	DueDates       []time.Time           `json:"due_dates"`
+	TransactionIDs []string              `json:"transaction_ids"`
+	Status         InstallmentPlanStatus `json:"status"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	CancelledAt    *time.Time            `json:"cancelled_at,omitempty"`
+}
+
+func NewInstallmentPlan(fromAccountID, toAccountID string, amount float64, currency string, dueDates []time.Time) *InstallmentPlan {
+	return &InstallmentPlan{
+		ID:            NewID(),
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Currency:      currency,
+		DueDates:      dueDates,
+		Status:        InstallmentPlanActive,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func (p *InstallmentPlan) Clone() *InstallmentPlan {
+	clone := *p
+	clone.DueDates = slices.Clone(p.DueDates)
+	clone.TransactionIDs = slices.Clone(p.TransactionIDs)
+	if p.CancelledAt != nil {
+		cancelledAt := *p.CancelledAt
+		clone.CancelledAt = &cancelledAt
+	}
+	return &clone
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+)
+
+type LimitType string
+type LimitChangeStatus string
+
+const (
+	LimitDaily   LimitType = "daily"
+	LimitMonthly LimitType = "monthly"
+
+	LimitChangePendingConfirmation LimitChangeStatus = "pending_confirmation"
+	LimitChangeCoolingOff          LimitChangeStatus = "cooling_off"
+	LimitChangeApplied             LimitChangeStatus = "applied"
+	LimitChangeCancelled           LimitChangeStatus = "cancelled"
+	LimitChangeExpired             LimitChangeStatus = "expired"
+)
+
+type LimitChange struct {
+	ID             string            `json:"id"`
+	AccountID      string            `json:"account_id"`
+	LimitType      LimitType         `json:"limit_type"`
+	PreviousLimit  float64           `json:"previous_limit"`
+	RequestedLimit float64           `json:"requested_limit"`
+	Status         LimitChangeStatus `json:"status"`
+	OTPHash        string            `json:"-"`
+	OTPExpiresAt   time.Time         `json:"-"`
+	OTPAttempts    int               `json:"-"`
+	EffectiveAt    *time.Time        `json:"effective_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func NewLimitChange(accountID string, limitType LimitType, previous, requested float64) *LimitChange {
+	return &LimitChange{
+		ID:             NewID(),
+		AccountID:      accountID,
+		LimitType:      limitType,
+		PreviousLimit:  previous,
+		RequestedLimit: requested,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func (a *Account) Limit(limitType LimitType) float64 {
+	if limitType == LimitMonthly {
+		return a.MonthlyLimit
+	}
+	return a.DailyLimit
+}
+
+func (a *Account) SetLimit(limitType LimitType, value float64) {
+	if limitType == LimitMonthly {
+		a.MonthlyLimit = value
+		return
+	}
+	a.DailyLimit = value
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliverySent       DeliveryStatus = "sent"
+	DeliveryFailed     DeliveryStatus = "failed"
+	DeliverySuppressed DeliveryStatus = "suppressed"
+)
+
+type ConsentPurpose string
+
+const (
+	ConsentTransactional ConsentPurpose = "transactional"
+	ConsentMarketing     ConsentPurpose = "marketing"
+)
+
+type ConsentRecord struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	Channel    string         `json:"channel"`
+	Purpose    ConsentPurpose `json:"purpose"`
+	Granted    bool           `json:"granted"`
+	Source     string         `json:"source"`
+	RecordedAt time.Time      `json:"recorded_at"`
+}
+
+type ContactChannel struct {
+	Channel string `json:"channel"`
+	Address string `json:"address"`
+}
+
+type NotificationPreferences struct {
+	UserID    string           `json:"user_id"`
+	Channels  []ContactChannel `json:"channels"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type NotificationDelivery struct {
+	ID           string         `json:"id"`
+	MessageID    string         `json:"message_id"`
+	UserID       string         `json:"user_id,omitempty"`
+	Channel      string         `json:"channel"`
+	Recipient    string         `json:"recipient"`
+	Status       DeliveryStatus `json:"status"`
+	Error        string         `json:"error,omitempty"`
+	FailoverFrom string         `json:"failover_from,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+func NewNotificationDelivery(messageID, userID, channel, recipient string) *NotificationDelivery {
+	return &NotificationDelivery{
+		ID:        NewID(),
+		MessageID: messageID,
+		UserID:    userID,
+		Channel:   channel,
+		Recipient: recipient,
+		CreatedAt: time.Now(),
+	}
+}
+
+type ScheduledNotificationStatus string
+
+const (
+	ScheduledNotificationPending   ScheduledNotificationStatus = "scheduled"
+	ScheduledNotificationSent      ScheduledNotificationStatus = "sent"
+	ScheduledNotificationCancelled ScheduledNotificationStatus = "cancelled"
+	ScheduledNotificationFailed    ScheduledNotificationStatus = "failed"
+)
+
+type ScheduledNotification struct {
+	ID              string                      `json:"id"`
+	UserID          string                      `json:"user_id,omitempty"`
+	Channel         string                      `json:"channel"`
+	SendAt          time.Time                   `json:"send_at"`
+	HonorQuietHours bool                        `json:"honor_quiet_hours"`
+	Status          ScheduledNotificationStatus `json:"status"`
+	Payload         []byte                      `json:"-"`
+	Attempts        int                         `json:"attempts"`
+	LastError       string                      `json:"last_error,omitempty"`
+	SentAt          *time.Time                  `json:"sent_at,omitempty"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+}
+
+func NewScheduledNotification(userID, channel string, sendAt time.Time, payload []byte) *ScheduledNotification {
+	return &ScheduledNotification{
+		ID:        NewID(),
+		UserID:    userID,
+		Channel:   channel,
+		SendAt:    sendAt,
+		Status:    ScheduledNotificationPending,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+}
+
+type InboxNotification struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"user_id"`
+	MessageID string            `json:"message_id"`
+	Subject   string            `json:"subject,omitempty"`
+	Message   string            `json:"message"`
+	Priority  int               `json:"priority"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Read      bool              `json:"read"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
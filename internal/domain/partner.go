@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type PartnerStatus string
+
+const (
+	PartnerActive    PartnerStatus = "active"
+	PartnerSuspended PartnerStatus = "suspended"
+)
+
+type PartnerSigningKey struct {
+	KeyID     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+type WebhookEndpoint struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+}
+
+type Partner struct {
+	ID                  string              `json:"id"`
+	Name                string              `json:"name"`
+	Status              PartnerStatus       `json:"status"`
+	APIKeys             []string            `json:"api_keys"`
+	SigningKeys         []PartnerSigningKey `json:"signing_keys,omitempty"`
+	Webhooks            []WebhookEndpoint   `json:"webhooks,omitempty"`
+	AllowedTypes        []TransactionType   `json:"allowed_types,omitempty"`
+	SettlementAccountID string              `json:"settlement_account_id"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+}
+
+func (p *Partner) Allows(txType TransactionType) bool {
+	return len(p.AllowedTypes) == 0 || slices.Contains(p.AllowedTypes, txType)
+}
+
+func (p *Partner) Clone() *Partner {
+	copied := *p
+	copied.APIKeys = slices.Clone(p.APIKeys)
+	copied.SigningKeys = slices.Clone(p.SigningKeys)
+	copied.AllowedTypes = slices.Clone(p.AllowedTypes)
+	copied.Webhooks = make([]WebhookEndpoint, len(p.Webhooks))
+	for i, webhook := range p.Webhooks {
+		webhook.Events = slices.Clone(webhook.Events)
+		copied.Webhooks[i] = webhook
+	}
+	return &copied
+}
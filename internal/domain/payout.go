@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type PayoutBatchStatus string
+type PayoutItemStatus string
+
+const (
+	PayoutBatchScheduled PayoutBatchStatus = "scheduled"
+	PayoutBatchRunning   PayoutBatchStatus = "running"
+	PayoutBatchCompleted PayoutBatchStatus = "completed"
+
+	PayoutItemScheduled PayoutItemStatus = "scheduled"
+	PayoutItemExecuted  PayoutItemStatus = "executed"
+	PayoutItemFailed    PayoutItemStatus = "failed"
+)
+
+type PayoutItem struct {
+	Index             int               `json:"index"`
+	ToAccountID       string            `json:"to_account_id"`
+	Amount            float64           `json:"amount"`
+	Description       string            `json:"description,omitempty"`
+	Status            PayoutItemStatus  `json:"status"`
+	ScheduledAt       time.Time         `json:"scheduled_at"`
+	Deferrals         int               `json:"deferrals,omitempty"`
+	TransactionID     string            `json:"transaction_id,omitempty"`
+	TransactionStatus TransactionStatus `json:"transaction_status,omitempty"`
+	ExecutedAt        *time.Time        `json:"executed_at,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+type PayoutBatch struct {
+	ID            string            `json:"id"`
+	FromAccountID string            `json:"from_account_id"`
+	Currency      string            `json:"currency"`
+	Status        PayoutBatchStatus `json:"status"`
+	Items         []PayoutItem      `json:"items"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	CompletedAt   *time.Time        `json:"completed_at,omitempty"`
+}
+
+type PayoutBatchProgress struct {
+	Total            int        `json:"total"`
+	Scheduled        int        `json:"scheduled"`
+	Executed         int        `json:"executed"`
+	Failed           int        `json:"failed"`
+	Deferred         int        `json:"deferred"`
+	ExecutedAmount   float64    `json:"executed_amount"`
+	RemainingAmount  float64    `json:"remaining_amount"`
+	NextScheduledAt  *time.Time `json:"next_scheduled_at,omitempty"`
+	ExpectedFinishAt *time.Time `json:"expected_finish_at,omitempty"`
+}
+
+func (b *PayoutBatch) Progress() PayoutBatchProgress {
+	progress := PayoutBatchProgress{Total: len(b.Items)}
+	for _, item := range b.Items {
+		if item.Deferrals > 0 {
+			progress.Deferred++
+		}
+		switch item.Status {
+		case PayoutItemExecuted:
+			progress.Executed++
+			progress.ExecutedAmount += item.Amount
+		case PayoutItemFailed:
+			progress.Failed++
+		default:
+			progress.Scheduled++
+			progress.RemainingAmount += item.Amount
+			at := item.ScheduledAt
+			if progress.NextScheduledAt == nil || at.Before(*progress.NextScheduledAt) {
+				progress.NextScheduledAt = &at
+			}
+			if progress.ExpectedFinishAt == nil || at.After(*progress.ExpectedFinishAt) {
+				progress.ExpectedFinishAt = &at
+			}
+		}
+	}
+	return progress
+}
+
+func (b *PayoutBatch) Clone() *PayoutBatch {
+	clone := *b
+	clone.Items = slices.Clone(b.Items)
+	for i := range clone.Items {
+		if executedAt := clone.Items[i].ExecutedAt; executedAt != nil {
+			at := *executedAt
+			clone.Items[i].ExecutedAt = &at
+		}
+	}
+	if b.CompletedAt != nil {
+		completedAt := *b.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	return &clone
+}
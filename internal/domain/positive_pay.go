@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type PositivePayPayee struct {
+	PayeeAccountID string  `json:"payee_account_id"`
+	MinAmount      float64 `json:"min_amount"`
+	MaxAmount      float64 `json:"max_amount,omitempty"`
+	Description    string  `json:"description,omitempty"`
+}
+
+type PositivePayList struct {
+	AccountID string             `json:"account_id"`
+	Payees    []PositivePayPayee `json:"payees"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func (l *PositivePayList) Authorizes(payeeAccountID string, amount float64) bool {
+	for _, payee := range l.Payees {
+		if payee.PayeeAccountID != payeeAccountID || amount < payee.MinAmount {
+			continue
+		}
+		if payee.MaxAmount == 0 || amount <= payee.MaxAmount {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *PositivePayList) Clone() *PositivePayList {
+	clone := *l
+	clone.Payees = slices.Clone(l.Payees)
+	return &clone
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type ProductStatus string
+
+const (
+	ProductActive  ProductStatus = "active"
+	ProductRetired ProductStatus = "retired"
+)
+
+type Fee struct {
+	Fixed   float64 `json:"fixed,omitempty"`
+	Percent float64 `json:"percent,omitempty"`
+}
+
+type FeeSchedule struct {
+	Deposit            Fee     `json:"deposit"`
+	Withdrawal         Fee     `json:"withdrawal"`
+	Transfer           Fee     `json:"transfer"`
+	MonthlyMaintenance float64 `json:"monthly_maintenance,omitempty"`
+}
+
+func (f Fee) Amount(amount float64) float64 {
+	return f.Fixed + amount*f.Percent/100
+}
+
+func (s FeeSchedule) For(txType TransactionType) Fee {
+	switch txType {
+	case TypeDeposit:
+		return s.Deposit
+	case TypeWithdrawal:
+		return s.Withdrawal
+	case TypeTransfer:
+		return s.Transfer
+	default:
+		return Fee{}
+	}
+}
+
+type Product struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Currency       string            `json:"currency,omitempty"`
+	InterestRate   float64           `json:"interest_rate"`
+	Fees           FeeSchedule       `json:"fees"`
+	DailyLimit     float64           `json:"daily_limit"`
+	MonthlyLimit   float64           `json:"monthly_limit"`
+	OverdraftLimit float64           `json:"overdraft_limit"`
+	AllowedTypes   []TransactionType `json:"allowed_types,omitempty"`
+	Status         ProductStatus     `json:"status"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+func (p *Product) Allows(txType TransactionType) bool {
+	return len(p.AllowedTypes) == 0 || slices.Contains(p.AllowedTypes, txType)
+}
+
+func (p *Product) Limit(limitType LimitType) float64 {
+	if limitType == LimitMonthly {
+		return p.MonthlyLimit
+	}
+	return p.DailyLimit
+}
+
+func (p *Product) Clone() *Product {
+	copied := *p
+	copied.AllowedTypes = slices.Clone(p.AllowedTypes)
+	return &copied
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const MaxProfileCounterparties = 50
+
+type AccountProfile struct {
+	AccountID        string         `json:"account_id"`
+	TransactionCount int            `json:"transaction_count"`
+	AmountMean       float64        `json:"amount_mean"`
+	AmountM2         float64        `json:"amount_m2"`
+	MaxAmount        float64        `json:"max_amount"`
+	HourCounts       [24]int        `json:"hour_counts"`
+	Counterparties   map[string]int `json:"counterparties"`
+	Currencies       map[string]int `json:"currencies"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+func NewAccountProfile(accountID string) *AccountProfile {
+	return &AccountProfile{
+		AccountID:      accountID,
+		Counterparties: make(map[string]int),
+		Currencies:     make(map[string]int),
+	}
+}
+
+func (p *AccountProfile) Observe(amount float64, currency, counterparty string, at time.Time) {
+	p.TransactionCount++
+	delta := amount - p.AmountMean
+	p.AmountMean += delta / float64(p.TransactionCount)
+	p.AmountM2 += delta * (amount - p.AmountMean)
+	p.MaxAmount = max(p.MaxAmount, amount)
+
+	p.HourCounts[at.Hour()]++
+
+	if currency != "" {
+		p.Currencies[currency]++
+	}
+	if counterparty != "" {
+		p.Counterparties[counterparty]++
+		if len(p.Counterparties) > MaxProfileCounterparties {
+			p.evictRarestCounterparty(counterparty)
+		}
+	}
+
+	p.UpdatedAt = at
+}
+
+func (p *AccountProfile) AmountStdDev() float64 {
+	if p.TransactionCount < 2 {
+		return 0
+	}
+	return math.Sqrt(p.AmountM2 / float64(p.TransactionCount-1))
+}
+
+func (p *AccountProfile) HourShare(hour int) float64 {
+	if p.TransactionCount == 0 {
+		return 0
+	}
+	return float64(p.HourCounts[hour]) / float64(p.TransactionCount)
+}
+
+func (p *AccountProfile) KnowsCounterparty(accountID string) bool {
+	return p.Counterparties[accountID] > 0
+}
+
+func (p *AccountProfile) UsesCurrency(currency string) bool {
+	return p.Currencies[currency] > 0
+}
+
+func (p *AccountProfile) Clone() *AccountProfile {
+	clone := *p
+	clone.Counterparties = make(map[string]int, len(p.Counterparties))
+	for k, v := range p.Counterparties {
+		clone.Counterparties[k] = v
+	}
+	clone.Currencies = make(map[string]int, len(p.Currencies))
+	for k, v := range p.Currencies {
+		clone.Currencies[k] = v
+	}
+	return &clone
+}
+
+func (p *AccountProfile) evictRarestCounterparty(keep string) {
+	rarest, rarestCount := "", math.MaxInt
+	for id, count := range p.Counterparties {
+		if id != keep && count < rarestCount {
+			rarest, rarestCount = id, count
+		}
+	}
+	delete(p.Counterparties, rarest)
+}
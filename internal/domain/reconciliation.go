@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type ReconciliationItemStatus string
+type ReconciliationResolution string
+
+const (
+	ReconciliationMatched         ReconciliationItemStatus = "matched"
+	ReconciliationMismatched      ReconciliationItemStatus = "mismatched"
+	ReconciliationMissingInternal ReconciliationItemStatus = "missing_internal"
+	ReconciliationMissingExternal ReconciliationItemStatus = "missing_external"
+
+	ResolutionAcceptInternal ReconciliationResolution = "accept_internal"
+	ResolutionAcceptExternal ReconciliationResolution = "accept_external"
+	ResolutionLinked         ReconciliationResolution = "linked"
+	ResolutionWrittenOff     ReconciliationResolution = "written_off"
+)
+
+type ReconciliationItem struct {
+	ID               string                   `json:"id"`
+	Status           ReconciliationItemStatus `json:"status"`
+	Line             int                      `json:"line,omitempty"`
+	Reference        string                   `json:"reference,omitempty"`
+	ExternalAmount   float64                  `json:"external_amount,omitempty"`
+	ExternalCurrency string                   `json:"external_currency,omitempty"`
+	ExternalDate     *time.Time               `json:"external_date,omitempty"`
+	TransactionID    string                   `json:"transaction_id,omitempty"`
+	InternalAmount   float64                  `json:"internal_amount,omitempty"`
+	InternalCurrency string                   `json:"internal_currency,omitempty"`
+	InternalDate     *time.Time               `json:"internal_date,omitempty"`
+	MatchedBy        string                   `json:"matched_by,omitempty"`
+	Differences      []string                 `json:"differences,omitempty"`
+	Resolution       ReconciliationResolution `json:"resolution,omitempty"`
+	ResolvedBy       string                   `json:"resolved_by,omitempty"`
+	ResolutionNote   string                   `json:"resolution_note,omitempty"`
+	ResolvedAt       *time.Time               `json:"resolved_at,omitempty"`
+}
+
+func (i *ReconciliationItem) IsBreak() bool {
+	return i.Status != ReconciliationMatched
+}
+
+func (i *ReconciliationItem) IsOpen() bool {
+	return i.IsBreak() && i.Resolution == ""
+}
+
+type ReconciliationSummary struct {
+	Entries         int `json:"entries"`
+	Matched         int `json:"matched"`
+	Mismatched      int `json:"mismatched"`
+	MissingInternal int `json:"missing_internal"`
+	MissingExternal int `json:"missing_external"`
+	OpenBreaks      int `json:"open_breaks"`
+}
+
+type ReconciliationReport struct {
+	ID          string                `json:"id"`
+	Source      string                `json:"source"`
+	PeriodStart time.Time             `json:"period_start"`
+	PeriodEnd   time.Time             `json:"period_end"`
+	SnapshotID  string                `json:"snapshot_id,omitempty"`
+	Summary     ReconciliationSummary `json:"summary"`
+	Items       []ReconciliationItem  `json:"items"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+func (r *ReconciliationReport) Summarize() {
+	summary := ReconciliationSummary{}
+	for i := range r.Items {
+		item := &r.Items[i]
+		if item.Status != ReconciliationMissingExternal {
+			summary.Entries++
+		}
+		switch item.Status {
+		case ReconciliationMatched:
+			summary.Matched++
+		case ReconciliationMismatched:
+			summary.Mismatched++
+		case ReconciliationMissingInternal:
+			summary.MissingInternal++
+		case ReconciliationMissingExternal:
+			summary.MissingExternal++
+		}
+		if item.IsOpen() {
+			summary.OpenBreaks++
+		}
+	}
+	r.Summary = summary
+}
+
+func (r *ReconciliationReport) Item(id string) *ReconciliationItem {
+	for i := range r.Items {
+		if r.Items[i].ID == id {
+			return &r.Items[i]
+		}
+	}
+	return nil
+}
+
+func (r *ReconciliationReport) Clone() *ReconciliationReport {
+	copied := *r
+	copied.Items = slices.Clone(r.Items)
+	for i := range copied.Items {
+		copied.Items[i].Differences = slices.Clone(copied.Items[i].Differences)
+	}
+	return &copied
+}
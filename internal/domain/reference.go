@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const ReferencePrefix = "REF"
+
+func FormatReference(year int, sequence int64) string {
+	digits := fmt.Sprintf("%04d%05d", year, sequence)
+	return fmt.Sprintf("%s-%04d-%05d-%d", ReferencePrefix, year, sequence, luhnCheckDigit(digits))
+}
+
+func NormalizeReference(reference string) (string, bool) {
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(reference)), "-")
+	if len(parts) != 4 || parts[0] != ReferencePrefix || len(parts[1]) != 4 || len(parts[2]) < 5 || len(parts[3]) != 1 {
+		return "", false
+	}
+
+	year, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", false
+	}
+	sequence, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || sequence < 0 {
+		return "", false
+	}
+
+	normalized := FormatReference(year, sequence)
+	if normalized[len(normalized)-1] != parts[3][0] {
+		return "", false
+	}
+	return normalized, true
+}
+
+func luhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
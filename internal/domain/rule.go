@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"slices"
+	"time"
+)
+
 type RuleType string
 
 const (
@@ -9,13 +14,54 @@ const (
 )
 
 type Rule struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Type        RuleType `json:"type"`
-	Description string   `json:"description"`
-	Condition   string   `json:"condition"`
-	Action      string   `json:"action"`
-	Priority    int      `json:"priority"`
-	IsActive    bool     `json:"is_active"`
-	Version     int      `json:"version"`
+	ID          string   `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Type        RuleType `json:"type" yaml:"type"`
+	Description string   `json:"description" yaml:"description"`
+	Condition   string   `json:"condition" yaml:"condition"`
+	Action      string   `json:"action" yaml:"action"`
+	Priority    int      `json:"priority" yaml:"priority"`
+	IsActive    bool     `json:"is_active" yaml:"is_active"`
+	Version     int      `json:"version" yaml:"version"`
+	Schedule    string   `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	GroupID     string   `json:"group_id,omitempty" yaml:"group_id,omitempty"`
+}
+
+type RuleGroup struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	IsActive     bool      `json:"is_active"`
+	Environments []string  `json:"environments,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (g *RuleGroup) EnabledIn(environment string) bool {
+	if !g.IsActive {
+		return false
+	}
+	return len(g.Environments) == 0 || slices.Contains(g.Environments, environment)
+}
+
+func (r *Rule) IsScheduled() bool {
+	return r.Schedule != ""
+}
+
+type LookupTable struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Entries     map[string]float64 `json:"entries"`
+	Default     *float64           `json:"default,omitempty"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+func (t *LookupTable) Lookup(key string) (float64, bool) {
+	if value, exists := t.Entries[key]; exists {
+		return value, true
+	}
+	if t.Default != nil {
+		return *t.Default, true
+	}
+	return 0, false
 }
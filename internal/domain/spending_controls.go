@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+type SpendingControls struct {
+	AccountID            string    `json:"account_id"`
+	BlockInternational   bool      `json:"block_international"`
+	BlockOnline          bool      `json:"block_online"`
+	BlockedCategories    []string  `json:"blocked_categories,omitempty"`
+	MaxTransactionAmount float64   `json:"max_transaction_amount,omitempty"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+func (c *SpendingControls) BlocksCategory(category string) bool {
+	if category == "" {
+		return false
+	}
+	return slices.ContainsFunc(c.BlockedCategories, func(blocked string) bool {
+		return strings.EqualFold(blocked, category)
+	})
+}
+
+func (c *SpendingControls) Clone() *SpendingControls {
+	clone := *c
+	clone.BlockedCategories = slices.Clone(c.BlockedCategories)
+	return &clone
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+type SuspenseStatus string
+
+const (
+	SuspenseOpen       SuspenseStatus = "open"
+	SuspenseReassigned SuspenseStatus = "reassigned"
+	SuspenseReturned   SuspenseStatus = "returned"
+)
+
+type SuspenseItem struct {
+	TransactionID           string         `json:"transaction_id"`
+	Reference               string         `json:"reference,omitempty"`
+	IntendedAccountID       string         `json:"intended_account_id"`
+	Amount                  float64        `json:"amount"`
+	Currency                string         `json:"currency"`
+	Status                  SuspenseStatus `json:"status"`
+	ResolvedAccountID       string         `json:"resolved_account_id,omitempty"`
+	ResolutionTransactionID string         `json:"resolution_transaction_id,omitempty"`
+	ResolvedBy              string         `json:"resolved_by,omitempty"`
+	Reason                  string         `json:"reason,omitempty"`
+	CreatedAt               time.Time      `json:"created_at"`
+	ResolvedAt              *time.Time     `json:"resolved_at,omitempty"`
+}
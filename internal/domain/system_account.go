@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"strings"
+)
+
+type SystemRole string
+
+const (
+	SystemRoleFees       SystemRole = "fees"
+	SystemRoleSuspense   SystemRole = "suspense"
+	SystemRoleFXPosition SystemRole = "fx_position"
+	SystemRoleSettlement SystemRole = "settlement"
+
+	SystemUserID = "system"
+)
+
+func SystemRoles() []SystemRole {
+	return []SystemRole{SystemRoleFees, SystemRoleSuspense, SystemRoleFXPosition, SystemRoleSettlement}
+}
+
+func SystemAccountID(role SystemRole, currency string) string {
+	return "sys-" + strings.ReplaceAll(string(role), "_", "-") + "-" + strings.ToUpper(currency)
+}
@@ -1,8 +1,8 @@
 package domain
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"maps"
+	"slices"
 	"time"
 )
 
@@ -13,16 +13,22 @@ const (
 	TypeDeposit    TransactionType = "deposit"
 	TypeWithdrawal TransactionType = "withdrawal"
 	TypeTransfer   TransactionType = "transfer"
+	TypeChargeback TransactionType = "chargeback"
+	TypeAdjustment TransactionType = "adjustment"
 
 	StatusPending    TransactionStatus = "pending"
 	StatusProcessing TransactionStatus = "processing"
 	StatusCompleted  TransactionStatus = "completed"
 	StatusFailed     TransactionStatus = "failed"
 	StatusSuspicious TransactionStatus = "suspicious"
+	StatusExpired    TransactionStatus = "expired"
+	StatusScheduled  TransactionStatus = "scheduled"
+	StatusCancelled  TransactionStatus = "cancelled"
 )
 
 type Transaction struct {
 	ID            string            `json:"id"`
+	Reference     string            `json:"reference,omitempty"`
 	Type          TransactionType   `json:"type"`
 	Amount        float64           `json:"amount"`
 	Currency      string            `json:"currency"`
@@ -35,6 +41,21 @@ type Transaction struct {
 	Metadata      map[string]string `json:"metadata,omitempty"`
 	RiskScore     int               `json:"risk_score"`
 	FraudFlags    []string          `json:"fraud_flags,omitempty"`
+	Explanation   *RiskExplanation  `json:"explanation,omitempty"`
+	Legs          []TransactionLeg  `json:"legs,omitempty"`
+	// SystemPosting is why the system posted the transaction itself, e.g. a fee.
+	// Only processor.PostSystemTransaction sets it; it exempts the posting from
+	// customer-facing checks.
+	SystemPosting string `json:"system_posting,omitempty"`
+}
+
+// TransactionLeg is one credit of a multi-leg transfer. The legs of a transfer
+// sum to its amount and are all funded by its single debit.
+type TransactionLeg struct {
+	ToAccountID string  `json:"to_account_id"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description,omitempty"`
+	Corridor    string  `json:"corridor,omitempty"`
 }
 
 type TransactionEvent struct {
@@ -46,7 +67,7 @@ type TransactionEvent struct {
 
 func NewTransaction(t TransactionType, amount float64, currency string) *Transaction {
 	return &Transaction{
-		ID:        generateTransactionID(),
+		ID:        NewID(),
 		Type:      t,
 		Amount:    amount,
 		Currency:  currency,
@@ -67,6 +88,11 @@ func (tx *Transaction) WithAccounts(fromID, toID string) *Transaction {
 	return tx
 }
 
+func (tx *Transaction) WithLegs(legs ...TransactionLeg) *Transaction {
+	tx.Legs = legs
+	return tx
+}
+
 func (tx *Transaction) AddMetadata(key, value string) {
 	if tx.Metadata == nil {
 		tx.Metadata = make(map[string]string)
@@ -74,8 +100,17 @@ func (tx *Transaction) AddMetadata(key, value string) {
 	tx.Metadata[key] = value
 }
 
-func generateTransactionID() string {
-	b := make([]byte, 8)
-	_, _ = rand.Read(b)
-	return hex.EncodeToString(b)
+func (tx *Transaction) Clone() *Transaction {
+	clone := *tx
+	clone.Metadata = maps.Clone(tx.Metadata)
+	clone.FraudFlags = slices.Clone(tx.FraudFlags)
+	clone.Legs = slices.Clone(tx.Legs)
+	if tx.Explanation != nil {
+		explanation := *tx.Explanation
+		explanation.FraudPatterns = slices.Clone(tx.Explanation.FraudPatterns)
+		explanation.Rules = slices.Clone(tx.Explanation.Rules)
+		explanation.LimitChecks = slices.Clone(tx.Explanation.LimitChecks)
+		clone.Explanation = &explanation
+	}
+	return &clone
 }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+)
+
+type WalletAccount struct {
+	AccountID        string        `json:"account_id"`
+	Currency         string        `json:"currency"`
+	Status           AccountStatus `json:"status"`
+	Balance          float64       `json:"balance"`
+	Rate             float64       `json:"rate"`
+	ConvertedBalance float64       `json:"converted_balance"`
+}
+
+type Wallet struct {
+	UserID       string          `json:"user_id"`
+	BaseCurrency string          `json:"base_currency"`
+	Accounts     []WalletAccount `json:"accounts"`
+	TotalBalance float64         `json:"total_balance"`
+	AsOf         time.Time       `json:"as_of"`
+}
+
+type CurrencyExchange struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"`
+	FromAccountID string    `json:"from_account_id"`
+	ToAccountID   string    `json:"to_account_id"`
+	FromCurrency  string    `json:"from_currency"`
+	ToCurrency    string    `json:"to_currency"`
+	DebitAmount   float64   `json:"debit_amount"`
+	CreditAmount  float64   `json:"credit_amount"`
+	Rate          float64   `json:"rate"`
+	DebitTxID     string    `json:"debit_transaction_id"`
+	CreditTxID    string    `json:"credit_transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -3,10 +3,15 @@ package internal_test
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -15,8 +20,13 @@ import (
 	"finance_manager/internal/domain"
 	"finance_manager/internal/processor"
 	"finance_manager/internal/repository/memory"
+	"finance_manager/internal/service"
+	"finance_manager/pkg/cache"
+	"finance_manager/pkg/client"
 	"finance_manager/pkg/crypto"
+	"finance_manager/pkg/fmtest"
 	"finance_manager/pkg/metrics"
+	"finance_manager/pkg/scheduler"
 )
 
 type testEnv struct {
@@ -121,6 +131,28 @@ func TestIntegration_DepositSuccess(t *testing.T) {
 	}
 }
 
+func TestIntegration_ReservedMetadataRejected(t *testing.T) {
+	env := setup(t)
+
+	mustCreateAccount(t, env, "A1", "USD", 0)
+
+	for _, key := range []string{processor.MetadataDegradedQueued, processor.MetadataCorridor, processor.MetadataLinkedTransaction} {
+		_, code := callCreateTransaction(t, env, api.CreateTransactionRequest{
+			Type:        domain.TypeDeposit,
+			Amount:      150.0,
+			Currency:    "USD",
+			ToAccountID: "A1",
+			Metadata:    map[string]string{key: "true"},
+		})
+		if code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", key, code)
+		}
+	}
+	if acc, _ := env.accRepo.GetByID(context.Background(), "A1"); acc.Balance != 0 {
+		t.Fatalf("expected rejected deposits to leave balance at 0, got %v", acc.Balance)
+	}
+}
+
 func TestIntegration_WithdrawalInsufficientFunds(t *testing.T) {
 	env := setup(t)
 
@@ -184,7 +216,7 @@ func TestIntegration_FraudHighAmount(t *testing.T) {
 	}
 
 	resp, code := callCreateTransaction(t, env, req)
-	if code != 200 && code != 201 && code != 202 && code != 500 {
+	if code != 200 && code != 201 && code != 202 && code != http.StatusUnprocessableEntity {
 		t.Fatalf("unexpected response code %d", code)
 	}
 
@@ -268,6 +300,76 @@ func TestIntegration_GetTransactionByID(t *testing.T) {
 	}
 }
 
+func TestIntegration_LocalizedTransactionDisplay(t *testing.T) {
+	env := setup(t)
+	mustCreateAccount(t, env, "L1", "EUR", 0)
+
+	b, _ := json.Marshal(api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 1234.5, Currency: "EUR", ToAccountID: "L1"})
+	r := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewReader(b))
+	r.Header.Set("Accept-Language", "de-CH, de;q=0.9, en;q=0.5")
+	w := httptest.NewRecorder()
+	env.handler.CreateTransactionHandler(w, r)
+	var created api.TransactionResponse
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("create failed: %d %v", w.Code, err)
+	}
+	if created.Display == nil || created.Display.Amount != "1.234,50 €" || created.Display.Status != "Abgeschlossen" || w.Header().Get("Content-Language") != "de-DE" {
+		t.Fatalf("expected german display strings, got %+v (%s)", created.Display, w.Header().Get("Content-Language"))
+	}
+
+	r = httptest.NewRequest("GET", "/api/v1/transactions?id="+created.ID, nil)
+	w = httptest.NewRecorder()
+	env.handler.GetTransactionHandler(w, r)
+	if strings.Contains(w.Body.String(), `"display"`) {
+		t.Errorf("expected raw response without Accept-Language, got %s", w.Body.String())
+	}
+
+	r = httptest.NewRequest("GET", "/api/v1/transactions?id="+created.ID, nil)
+	r.Header.Set("Accept-Language", "en-US")
+	w = httptest.NewRecorder()
+	env.handler.GetTransactionHandler(w, r)
+	var got struct {
+		domain.Transaction
+		Display *api.TransactionDisplay `json:"display"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if got.ID != created.ID || got.Amount != 1234.5 || got.Display == nil || got.Display.Amount != "€1,234.50" || got.Display.Status != "Completed" {
+		t.Errorf("expected raw fields alongside english display strings, got %+v %+v", got.Transaction, got.Display)
+	}
+}
+
+func TestIntegration_ValidateTransactionDoesNotExecute(t *testing.T) {
+	env := setup(t)
+	mustCreateAccount(t, env, "V1", "USD", 100)
+	mustCreateAccount(t, env, "V2", "USD", 0)
+
+	validate := func(amount float64) api.ValidateTransactionResponse {
+		t.Helper()
+		b, _ := json.Marshal(api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: amount, Currency: "USD", FromAccountID: "V1", ToAccountID: "V2"})
+		w := httptest.NewRecorder()
+		env.handler.ValidateTransactionHandler(w, httptest.NewRequest("POST", "/api/v1/transactions/validate", bytes.NewReader(b)))
+		var resp api.ValidateTransactionResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("validate failed: %d %v", w.Code, err)
+		}
+		return resp
+	}
+
+	if resp := validate(40.5); !resp.Accepted || resp.Status != domain.StatusCompleted {
+		t.Errorf("expected transfer to be accepted, got %+v", resp)
+	}
+	if resp := validate(250.5); resp.Accepted || resp.Code != "INSUFFICIENT_FUNDS" {
+		t.Errorf("expected insufficient funds, got %+v", resp)
+	}
+
+	account, _ := env.accRepo.GetByID(context.Background(), "V1")
+	if account.Balance != 100 {
+		t.Errorf("expected balance untouched by validation, got %.2f", account.Balance)
+	}
+}
+
 func TestIntegration_GetTransactionMissingID(t *testing.T) {
 	env := setup(t)
 	r := httptest.NewRequest("GET", "/api/v1/transactions", nil)
@@ -280,6 +382,8 @@ func TestIntegration_GetTransactionMissingID(t *testing.T) {
 
 func TestIntegration_EventOnSuspiciousTransaction(t *testing.T) {
 	env := setup(t)
+	midday := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	env.processor.FraudDetector().WithClock(func() time.Time { return midday })
 
 	mustCreateAccount(t, env, "A8", "USD", 9_000_000)
 	req := api.CreateTransactionRequest{
@@ -292,8 +396,8 @@ func TestIntegration_EventOnSuspiciousTransaction(t *testing.T) {
 
 	_, code := callCreateTransaction(t, env, req)
 
-	if code != 500 {
-		t.Fatalf("expected 500 due to daily withdrawal limit exceeded, got %d", code)
+	if code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 due to daily withdrawal limit exceeded, got %d", code)
 	}
 }
 
@@ -345,3 +449,2121 @@ func TestIntegration_InvalidRequestValidation(t *testing.T) {
 		t.Fatalf("expected 400 for invalid request, got %d", w.Result().StatusCode)
 	}
 }
+
+func TestIntegration_RuleImportExportRoundTrip(t *testing.T) {
+	env := setup(t)
+	_ = env.ruleRepo.Save(context.Background(), &domain.Rule{
+		ID:        "r1",
+		Name:      "Flag large",
+		Type:      domain.RuleTypeFraud,
+		Condition: `{"field":"amount","operator":">","value":1000}`,
+		Action:    `{"type":"flag_transaction","params":{"reason":"large"}}`,
+		IsActive:  true,
+		Priority:  5,
+	})
+
+	w := httptest.NewRecorder()
+	env.handler.ExportRulesHandler(w, httptest.NewRequest("GET", "/api/v1/rules/export?format=yaml", nil))
+	if w.Result().StatusCode != 200 {
+		t.Fatalf("expected 200 on export, got %d", w.Result().StatusCode)
+	}
+	exported := w.Body.Bytes()
+
+	invalid := []byte("rules:\n  - id: bad\n    name: Bad\n    condition: '{\"field\":\"unknown\",\"operator\":\"==\",\"value\":1}'\n    action: '{\"type\":\"flag_transaction\"}'\n")
+	w = httptest.NewRecorder()
+	env.handler.ImportRulesHandler(w, httptest.NewRequest("POST", "/api/v1/rules/import?format=yaml", bytes.NewReader(invalid)))
+	if w.Result().StatusCode != 422 {
+		t.Fatalf("expected 422 for invalid rule set, got %d", w.Result().StatusCode)
+	}
+	if _, err := env.ruleRepo.GetByID(context.Background(), "r1"); err != nil {
+		t.Fatalf("expected existing rules to survive a rejected import: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	env.handler.ImportRulesHandler(w, httptest.NewRequest("POST", "/api/v1/rules/import?format=yaml", bytes.NewReader(exported)))
+	if w.Result().StatusCode != 200 {
+		t.Fatalf("expected 200 on import, got %d: %s", w.Result().StatusCode, w.Body.String())
+	}
+	rule, err := env.ruleRepo.GetByID(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("expected rule r1 after import: %v", err)
+	}
+	if rule.Version != 2 || rule.Priority != 5 {
+		t.Errorf("expected version 2 and priority 5, got version=%d priority=%d", rule.Version, rule.Priority)
+	}
+}
+
+func TestIntegration_RuleStats(t *testing.T) {
+	env := setup(t)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.ruleRepo.Save(context.Background(), &domain.Rule{
+		ID:        "r-stats",
+		Name:      "Flag deposits over 100",
+		Condition: `{"field":"amount","operator":">","value":100}`,
+		Action:    `{"type":"flag_transaction","params":{"reason":"over_100"}}`,
+		IsActive:  true,
+	})
+	mustCreateAccount(t, env, "S1", "USD", 0)
+
+	for _, amount := range []float64{50, 150, 200} {
+		callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: amount, Currency: "USD", ToAccountID: "S1"})
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/rules/r-stats/stats", nil))
+	if w.Result().StatusCode != 200 {
+		t.Fatalf("expected 200, got %d", w.Result().StatusCode)
+	}
+	var stats processor.RuleStats
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if stats.Evaluations != 3 || stats.Skipped != 1 || stats.Triggers != 2 || stats.Errors != 0 || stats.LastTriggeredAt == nil {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/rules/missing/stats", nil))
+	if w.Result().StatusCode != 404 {
+		t.Errorf("expected 404 for unknown rule, got %d", w.Result().StatusCode)
+	}
+}
+
+func TestIntegration_RiskExplanation(t *testing.T) {
+	env := setup(t)
+
+	mustCreateAccount(t, env, "X1", "USD", 1000)
+
+	req := api.CreateTransactionRequest{
+		Type:          domain.TypeWithdrawal,
+		Amount:        100,
+		Currency:      "USD",
+		FromAccountID: "X1",
+		Metadata:      map[string]string{"location": "high_risk_country"},
+	}
+
+	resp, code := callCreateTransaction(t, env, req)
+
+	if code != 201 || resp == nil || resp.Explanation == nil {
+		t.Fatalf("expected 201 with explanation, got %d / %+v", code, resp)
+	}
+	patterns := resp.Explanation.FraudPatterns
+	if len(patterns) != 1 || patterns[0].Name != "geographical_anomaly" || patterns[0].Weight != 35 {
+		t.Errorf("expected geographical_anomaly pattern with weight 35, got %+v", patterns)
+	}
+	checks := resp.Explanation.LimitChecks
+	if len(checks) != 1 || checks[0].Name != "daily_withdrawal" || !checks[0].Passed {
+		t.Errorf("expected passed daily_withdrawal check, got %+v", checks)
+	}
+}
+
+func TestIntegration_RiskOverride(t *testing.T) {
+	env := setup(t)
+	auditRepo := memory.NewAuditRepository()
+	env.processor.WithAuditLog(auditRepo)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.ruleRepo.Save(context.Background(), &domain.Rule{
+		ID:        "r-approval",
+		Name:      "Approve all deposits",
+		Condition: `{"field":"amount","operator":">","value":0}`,
+		Action:    `{"type":"require_approval","params":{}}`,
+		IsActive:  true,
+	})
+	mustCreateAccount(t, env, "O1", "USD", 0)
+	resp, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 250, Currency: "USD", ToAccountID: "O1"})
+	if resp == nil || resp.Status != domain.StatusPending {
+		t.Fatalf("expected pending transaction, got %+v", resp)
+	}
+	override := func(body string) int {
+		r := httptest.NewRequest("POST", "/api/v1/admin/transactions/"+resp.ID+"/override", bytes.NewBufferString(body))
+		r.Header.Set("X-Operator-ID", "op-1")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+
+	missingReason := override(`{"outcome":"approve"}`)
+	approved := override(`{"outcome":"approve","risk_score":5,"reason":"verified with customer"}`)
+	again := override(`{"outcome":"reject","reason":"changed mind"}`)
+
+	if missingReason != 400 || approved != 200 || again != 409 {
+		t.Fatalf("expected 400/200/409, got %d/%d/%d", missingReason, approved, again)
+	}
+	if acc, _ := env.accRepo.GetByID(context.Background(), "O1"); acc.Balance != 250 {
+		t.Errorf("expected balance 250 after approval, got %f", acc.Balance)
+	}
+	entries, _ := auditRepo.GetByEntity(context.Background(), processor.AuditEntityTransaction, resp.ID)
+	if len(entries) != 1 || entries[0].Actor != "op-1" || entries[0].Details["previous_status"] != "pending" {
+		t.Errorf("expected one audit entry by op-1, got %+v", entries)
+	}
+}
+
+func TestIntegration_RejectsMalformedMetadata(t *testing.T) {
+	env := setup(t)
+	mustCreateAccount(t, env, "M1", "USD", 100)
+	b, _ := json.Marshal(api.CreateTransactionRequest{
+		Type:        domain.TypeDeposit,
+		Amount:      10,
+		Currency:    "USD",
+		ToAccountID: "M1",
+		Metadata:    map[string]string{"velocity": "very-high"},
+	})
+	w := httptest.NewRecorder()
+
+	env.handler.CreateTransactionHandler(w, httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewReader(b)))
+
+	if w.Result().StatusCode != 400 {
+		t.Fatalf("expected 400, got %d", w.Result().StatusCode)
+	}
+	var resp api.MetadataErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if resp.Code != "INVALID_METADATA" || len(resp.Errors) != 1 || resp.Errors[0].Field != "velocity" {
+		t.Errorf("unexpected error response: %+v", resp)
+	}
+}
+
+func TestIntegration_LimitIncreaseWithCoolingOff(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "L1", UserID: "U1", Currency: "USD", Status: domain.AccountActive, DailyLimit: 1000})
+	notifier := fmtest.NewRecordingNotifier()
+	cfg := service.DefaultLimitChangeConfig()
+	cfg.CoolingOff = 0
+	limits := service.NewLimitChangeService(env.accRepo, memory.NewLimitChangeRepository(), crypto.NewSigner("test-secret", nil), notifier, cfg, nil)
+
+	small, _ := limits.RequestChange(ctx, "L1", domain.LimitDaily, 1500)
+	large, err := limits.RequestChange(ctx, "L1", domain.LimitDaily, 10000)
+	if err != nil || small.Status != domain.LimitChangeApplied || large.Status != domain.LimitChangePendingConfirmation {
+		t.Fatalf("expected small change applied and large pending, got %v / %v / %v", small, large, err)
+	}
+	fields := strings.Fields(notifier.Messages()[0].Message)
+	otp := strings.TrimSuffix(fields[len(fields)-1], ".")
+
+	_, wrongErr := limits.Confirm(ctx, large.ID, "000000x")
+	confirmed, err := limits.Confirm(ctx, large.ID, otp)
+	if !errors.Is(wrongErr, service.ErrInvalidOTP) || err != nil || confirmed.Status != domain.LimitChangeCoolingOff {
+		t.Fatalf("expected wrong otp rejected and cooling off after confirm, got %v / %v / %v", wrongErr, err, confirmed)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "L1"); acc.DailyLimit != 1500 {
+		t.Errorf("expected limit unchanged during cooling-off, got %f", acc.DailyLimit)
+	}
+
+	if err := limits.ActivateDue(ctx); err != nil {
+		t.Fatalf("unexpected activation error: %v", err)
+	}
+
+	if acc, _ := env.accRepo.GetByID(ctx, "L1"); acc.DailyLimit != 10000 {
+		t.Errorf("expected limit 10000 after activation, got %f", acc.DailyLimit)
+	}
+	if last, _ := notifier.Last(); last.Subject != "Limit increase activated" || last.Recipient != "U1" {
+		t.Errorf("expected activation notification to U1, got %+v", last)
+	}
+}
+
+func TestIntegration_ChainedLimitIncreasesRequireConfirmation(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "L3", UserID: "U3", Currency: "USD", Status: domain.AccountActive, DailyLimit: 1000})
+	limits := service.NewLimitChangeService(env.accRepo, memory.NewLimitChangeRepository(), crypto.NewSigner("test-secret", nil), nil, service.DefaultLimitChangeConfig(), nil)
+
+	first, _ := limits.RequestChange(ctx, "L3", domain.LimitDaily, 1900)
+	second, err := limits.RequestChange(ctx, "L3", domain.LimitDaily, 2800)
+	if err != nil || first.Status != domain.LimitChangeApplied || second.Status != domain.LimitChangePendingConfirmation {
+		t.Fatalf("expected the second just-under-threshold increase to need confirmation, got %v / %v / %v", first, second, err)
+	}
+	if second.PreviousLimit != 1900 {
+		t.Errorf("expected previous limit 1900, got %f", second.PreviousLimit)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "L3"); acc.DailyLimit != 1900 {
+		t.Errorf("expected limit to stay at 1900, got %f", acc.DailyLimit)
+	}
+}
+
+func TestIntegration_LimitIncreaseFromProductDefault(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	products := memory.NewProductRepository()
+	_ = products.Save(ctx, &domain.Product{ID: "basic", Name: "Basic", DailyLimit: 5000, Status: domain.ProductActive})
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "L2", UserID: "U2", Currency: "USD", Status: domain.AccountActive, ProductID: "basic"})
+	limits := service.NewLimitChangeService(env.accRepo, memory.NewLimitChangeRepository(), crypto.NewSigner("test-secret", nil), nil, service.DefaultLimitChangeConfig(), nil).
+		WithProducts(products)
+
+	change, err := limits.RequestChange(ctx, "L2", domain.LimitDaily, 5500)
+	if err != nil || change.PreviousLimit != 5000 || change.Status != domain.LimitChangeApplied {
+		t.Fatalf("expected a small increase over the product limit to apply, got %+v / %v", change, err)
+	}
+}
+
+func TestIntegration_DisputeProvisionalCreditAndReversal(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	disputes := service.NewDisputeService(env.txRepo, memory.NewDisputeRepository(), env.processor, service.DefaultDisputeConfig(), nil)
+	env.handler.WithDisputes(disputes)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "D1", "USD", 500)
+	resp, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeWithdrawal, Amount: 200, Currency: "USD", FromAccountID: "D1"})
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	opened := post("/api/v1/disputes", fmt.Sprintf(`{"transaction_id":%q,"reason":"not recognised"}`, resp.ID))
+	duplicate := post("/api/v1/disputes", fmt.Sprintf(`{"transaction_id":%q,"reason":"again"}`, resp.ID))
+
+	if opened.Code != 201 || duplicate.Code != 409 {
+		t.Fatalf("expected 201 then 409, got %d / %d", opened.Code, duplicate.Code)
+	}
+	var dispute domain.Dispute
+	_ = json.NewDecoder(opened.Body).Decode(&dispute)
+	if acc, _ := env.accRepo.GetByID(ctx, "D1"); dispute.ProvisionalCreditID == "" || acc.Balance != 500 {
+		t.Fatalf("expected provisional credit restoring balance to 500, got %q / %f", dispute.ProvisionalCreditID, acc.Balance)
+	}
+
+	resolved := post("/api/v1/disputes/"+dispute.ID+"/resolve", `{"outcome":"merchant","note":"evidence insufficient"}`)
+
+	if resolved.Code != 200 {
+		t.Fatalf("expected 200, got %d", resolved.Code)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "D1"); acc.Balance != 300 {
+		t.Errorf("expected provisional credit reversed to 300, got %f", acc.Balance)
+	}
+	credit, _ := env.txRepo.GetByID(ctx, dispute.ProvisionalCreditID)
+	if credit.Metadata[processor.MetadataLinkedTransaction] != resp.ID {
+		t.Errorf("expected credit linked to %s, got %v", resp.ID, credit.Metadata)
+	}
+}
+
+func TestIntegration_ReopenedDisputeGetsNoSecondCredit(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	env.handler.WithDisputes(service.NewDisputeService(env.txRepo, memory.NewDisputeRepository(), env.processor, service.DefaultDisputeConfig(), nil))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "D2", "USD", 500)
+	resp, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeWithdrawal, Amount: 200, Currency: "USD", FromAccountID: "D2"})
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	opened := post("/api/v1/disputes", fmt.Sprintf(`{"transaction_id":%q,"reason":"not recognised"}`, resp.ID))
+	var dispute domain.Dispute
+	_ = json.NewDecoder(opened.Body).Decode(&dispute)
+	resolved := post("/api/v1/disputes/"+dispute.ID+"/resolve", `{"outcome":"customer","note":"refund confirmed"}`)
+	reopened := post("/api/v1/disputes", fmt.Sprintf(`{"transaction_id":%q,"reason":"still not recognised"}`, resp.ID))
+
+	if opened.Code != 201 || resolved.Code != 200 || reopened.Code != 201 {
+		t.Fatalf("expected 201/200/201, got %d/%d/%d", opened.Code, resolved.Code, reopened.Code)
+	}
+	var second domain.Dispute
+	_ = json.NewDecoder(reopened.Body).Decode(&second)
+	if second.ProvisionalCreditID != "" {
+		t.Errorf("expected no provisional credit on the reopened dispute, got %s", second.ProvisionalCreditID)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "D2"); acc.Balance != 500 {
+		t.Errorf("expected a single provisional credit, got balance %f", acc.Balance)
+	}
+}
+
+func TestIntegration_ChargebackRepresentedAndWon(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	tracker := processor.NewChargebackTracker()
+	env.processor.WithChargebackTracking(tracker)
+	env.handler.WithChargebacks(service.NewChargebackService(env.txRepo, memory.NewChargebackRepository(), env.processor, tracker, nil))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "CUST", "USD", 500)
+	mustCreateAccount(t, env, "MERCH", "USD", 0)
+	resp, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 100, Currency: "USD", FromAccountID: "CUST", ToAccountID: "MERCH"})
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBufferString(body)))
+		return w
+	}
+	balances := func() (float64, float64) {
+		cust, _ := env.accRepo.GetByID(ctx, "CUST")
+		merch, _ := env.accRepo.GetByID(ctx, "MERCH")
+		return cust.Balance, merch.Balance
+	}
+
+	received := post("/api/v1/chargebacks", fmt.Sprintf(`{"transaction_id":%q,"reason_code":"10.4"}`, resp.ID))
+
+	if received.Code != 201 {
+		t.Fatalf("expected 201, got %d: %s", received.Code, received.Body.String())
+	}
+	var chargeback domain.Chargeback
+	_ = json.NewDecoder(received.Body).Decode(&chargeback)
+	if cust, merch := balances(); cust != 500 || merch != 0 {
+		t.Fatalf("expected funds returned to customer, got %f / %f", cust, merch)
+	}
+
+	early := post("/api/v1/chargebacks/"+chargeback.ID+"/decide", `{"outcome":"won"}`)
+	represented := post("/api/v1/chargebacks/"+chargeback.ID+"/represent", `{"note":"signed delivery receipt"}`)
+	won := post("/api/v1/chargebacks/"+chargeback.ID+"/decide", `{"outcome":"won"}`)
+
+	if early.Code != 409 || represented.Code != 200 || won.Code != 200 {
+		t.Fatalf("expected 409, 200, 200, got %d, %d, %d", early.Code, represented.Code, won.Code)
+	}
+	if cust, merch := balances(); cust != 400 || merch != 100 {
+		t.Errorf("expected funds re-credited to merchant, got %f / %f", cust, merch)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/merchants/MERCH/chargeback-stats", nil))
+	var stats processor.MerchantChargebackStats
+	_ = json.NewDecoder(w.Body).Decode(&stats)
+	if stats.Chargebacks != 1 || stats.Won != 1 || stats.Rate != 1 {
+		t.Errorf("unexpected merchant stats: %+v", stats)
+	}
+}
+
+func TestIntegration_WalletExchangeBetweenOwnAccounts(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	fx := service.NewFXService("USD", map[string]float64{"EUR": 0.5})
+	env.handler.WithWallets(service.NewWalletService(env.accRepo, env.processor, fx, nil))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	for _, acc := range []*domain.Account{
+		{ID: "W-USD", UserID: "wallet-user", Balance: 1000, Currency: "USD", Status: domain.AccountActive},
+		{ID: "W-EUR", UserID: "wallet-user", Balance: 100, Currency: "EUR", Status: domain.AccountActive},
+		{ID: "OTHER", UserID: "someone-else", Balance: 100, Currency: "EUR", Status: domain.AccountActive},
+	} {
+		_ = env.accRepo.Save(ctx, acc)
+	}
+	exchange := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/users/wallet-user/wallet/exchange", bytes.NewBufferString(body)))
+		return w
+	}
+
+	ok := exchange(`{"from_account_id":"W-USD","to_account_id":"W-EUR","amount":200}`)
+	foreign := exchange(`{"from_account_id":"W-USD","to_account_id":"OTHER","amount":10}`)
+	overdraw := exchange(`{"from_account_id":"W-USD","to_account_id":"W-EUR","amount":5000}`)
+
+	if ok.Code != 201 || foreign.Code != 404 || overdraw.Code != 422 {
+		t.Fatalf("expected 201, 404, 422, got %d, %d, %d", ok.Code, foreign.Code, overdraw.Code)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/users/wallet-user/wallet?currency=EUR", nil))
+	var wallet domain.Wallet
+	_ = json.NewDecoder(w.Body).Decode(&wallet)
+	if len(wallet.Accounts) != 2 || wallet.Accounts[0].Balance != 200 || wallet.Accounts[1].Balance != 800 {
+		t.Fatalf("unexpected wallet accounts: %+v", wallet.Accounts)
+	}
+	if wallet.BaseCurrency != "EUR" || wallet.TotalBalance != 600 {
+		t.Errorf("expected consolidated 600 EUR, got %f %s", wallet.TotalBalance, wallet.BaseCurrency)
+	}
+}
+
+func TestIntegration_ProductTermsGovernAccount(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	productRepo := memory.NewProductRepository()
+	env.processor.WithProducts(productRepo)
+	env.handler.WithProducts(service.NewProductService(productRepo, env.accRepo, nil))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "P1", "USD", 100)
+	mustCreateAccount(t, env, "P2", "USD", 0)
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	created := send("POST", "/api/v1/products", `{"id":"overdraft","name":"Overdraft current account","currency":"USD","overdraft_limit":200,"allowed_types":["deposit","withdrawal"]}`)
+	invalid := send("POST", "/api/v1/products", `{"id":"bad","name":"Bad","allowed_types":["loan"]}`)
+	assigned := send("PUT", "/api/v1/accounts/P1/product", `{"product_id":"overdraft"}`)
+
+	if created.Code != 201 || invalid.Code != 400 || assigned.Code != 200 {
+		t.Fatalf("expected 201, 400, 200, got %d, %d, %d", created.Code, invalid.Code, assigned.Code)
+	}
+
+	withdrawal := domain.NewTransaction(domain.TypeWithdrawal, 250, "USD").WithAccounts("P1", "")
+	if err := env.processor.ProcessTransaction(ctx, withdrawal); err != nil {
+		t.Fatalf("expected withdrawal into overdraft to succeed, got %v", err)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "P1"); acc.Balance != -150 {
+		t.Errorf("expected balance -150, got %f", acc.Balance)
+	}
+
+	transfer := domain.NewTransaction(domain.TypeTransfer, 10, "USD").WithAccounts("P1", "P2")
+	if err := env.processor.ProcessTransaction(ctx, transfer); !errors.Is(err, processor.ErrTransactionTypeNotAllowed) {
+		t.Errorf("expected transfer to be rejected by product, got %v", err)
+	}
+}
+
+func TestIntegration_GetTransactionByReference(t *testing.T) {
+	env := setup(t)
+	mustCreateAccount(t, env, "R1", "USD", 0)
+	resp, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 25, Currency: "USD", ToAccountID: "R1"})
+	get := func(reference string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		env.handler.GetTransactionHandler(w, httptest.NewRequest("GET", "/api/v1/transactions?reference="+reference, nil))
+		return w
+	}
+
+	found := get(strings.ToLower(resp.Reference))
+	malformed := get("REF-2024-00123")
+
+	if found.Code != 200 || malformed.Code != 400 {
+		t.Fatalf("expected 200 and 400, got %d and %d", found.Code, malformed.Code)
+	}
+	var got domain.Transaction
+	_ = json.NewDecoder(found.Body).Decode(&got)
+	if got.ID != resp.ID {
+		t.Errorf("expected %s, got %s", resp.ID, got.ID)
+	}
+}
+
+func TestIntegration_ClientSignedIdempotentTransaction(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mustCreateAccount(t, env, "SDK1", "USD", 0)
+
+	sdk := client.New(server.URL, server.Client()).WithSigner(crypto.NewSigner("test-secret", nil))
+	req := client.CreateTransactionRequest{Type: client.TypeDeposit, Amount: 12.5, Currency: "USD", ToAccountID: "SDK1"}
+
+	first, err := sdk.CreateTransactionWithKey(ctx, "deposit-1", req)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	replay, err := sdk.CreateTransactionWithKey(ctx, "deposit-1", req)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	_, forged := client.New(server.URL, server.Client()).WithSigner(crypto.NewSigner("wrong-secret", nil)).CreateTransaction(ctx, req)
+
+	if replay.ID != first.ID || !replay.Replayed {
+		t.Errorf("expected replayed response for %s, got %+v", first.ID, replay)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "SDK1"); acc.Balance != 12.5 {
+		t.Errorf("expected a single deposit of 12.5, got balance %f", acc.Balance)
+	}
+	if !client.IsStatus(forged, http.StatusUnauthorized) {
+		t.Errorf("expected 401 for wrong signing key, got %v", forged)
+	}
+	if tx, err := sdk.GetTransactionByReference(ctx, first.Reference); err != nil || tx.ID != first.ID {
+		t.Errorf("expected lookup by reference to return %s, got %v / %v", first.ID, tx, err)
+	}
+}
+
+func TestIntegration_StreamedStatementAndExport(t *testing.T) {
+	env := setup(t)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "ST1", "USD", 1000)
+	mustCreateAccount(t, env, "ST2", "USD", 0)
+
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 100, Currency: "USD", FromAccountID: "ST1", ToAccountID: "ST2"})
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 50, Currency: "USD", ToAccountID: "ST2"})
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 25, Currency: "USD", ToAccountID: "ST1"})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/accounts/ST2/statement?format=jsonl", nil))
+	if w.Code != 200 || w.Header().Get("Content-Type") != "application/x-ndjson" || !w.Flushed {
+		t.Fatalf("expected flushed ndjson statement, got %d %q flushed=%v", w.Code, w.Header().Get("Content-Type"), w.Flushed)
+	}
+	var records []processor.TransactionExportRecord
+	for decoder := json.NewDecoder(w.Body); decoder.More(); {
+		var record processor.TransactionExportRecord
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 || records[0].Amount != 100 || records[0].Direction != processor.DirectionCredit || records[1].Amount != 50 {
+		t.Errorf("unexpected statement records: %+v", records)
+	}
+	if len(records) == 2 && (records[1].BalanceAfter == nil || *records[1].BalanceAfter != 150) {
+		t.Errorf("expected running balance of 150 after the last entry, got %v", records[1].BalanceAfter)
+	}
+	if w.Header().Get("X-Statement-Opening-Balance") != "0.00" || w.Header().Get("X-Statement-Closing-Balance") != "150.00" {
+		t.Errorf("unexpected statement balances: %v", w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/transactions/export", nil))
+	if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); w.Code != 200 || len(lines) != 4 || !strings.HasPrefix(lines[0], "transaction_id,reference") {
+		t.Errorf("expected csv header and 3 rows, got %d: %q", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/accounts/missing/statement", nil))
+	if w.Code != 404 {
+		t.Errorf("expected 404 for unknown account, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/transactions/export?format=xml", nil))
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "INVALID_FORMAT") {
+		t.Errorf("expected 400 INVALID_FORMAT, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestIntegration_BalanceMigration(t *testing.T) {
+	env := setup(t)
+	auditRepo := memory.NewAuditRepository()
+	env.processor.WithAuditLog(auditRepo)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "L1", "USD", 0)
+	mustCreateAccount(t, env, "L2", "USD", 0)
+	migrate := func(operator, body string) int {
+		r := httptest.NewRequest("POST", "/api/v1/admin/migrations/balances", bytes.NewBufferString(body))
+		if operator != "" {
+			r.Header.Set("X-Operator-ID", operator)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+	body := `{"reference":"core-2024-01","adjustments":[{"account_id":"L1","amount":500,"reason":"opening"},{"account_id":"L2","amount":120.5,"reason":"opening"}]}`
+
+	anonymous := migrate("", body)
+	applied := migrate("op-1", body)
+	replayed := migrate("op-1", body)
+
+	if anonymous != 401 || applied != 201 || replayed != 409 {
+		t.Fatalf("expected 401/201/409, got %d/%d/%d", anonymous, applied, replayed)
+	}
+	if acc, _ := env.accRepo.GetByID(context.Background(), "L2"); acc.Balance != 120.5 {
+		t.Errorf("expected balance 120.5, got %f", acc.Balance)
+	}
+	entries, _ := auditRepo.GetByEntity(context.Background(), processor.AuditEntityMigration, "core-2024-01")
+	if len(entries) != 1 || entries[0].Details["adjustments"] != "2" {
+		t.Errorf("expected one audit entry for the migration, got %+v", entries)
+	}
+}
+
+func TestIntegration_NotificationInbox(t *testing.T) {
+	env := setup(t)
+	inbox := service.NewInboxService(memory.NewInboxRepository())
+	env.handler.WithInbox(inbox)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	notifications := service.NewNotificationService(nil, nil, nil, nil, service.NotificationScalingConfig{MinWorkers: 1, MaxWorkers: 1}, env.logger).WithInbox(inbox)
+	defer notifications.Shutdown(context.Background())
+
+	for _, subject := range []string{"Statement ready", "Payment due"} {
+		_ = notifications.Enqueue(context.Background(), service.NotificationMessage{UserID: "u1", Type: service.NotificationInbox, Subject: subject, Message: subject})
+	}
+	list := func(query string) service.Inbox {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/users/u1/notifications"+query, nil))
+		var result service.Inbox
+		_ = json.NewDecoder(w.Body).Decode(&result)
+		return result
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(list("").Notifications) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	feed := list("")
+	if len(feed.Notifications) != 2 || feed.Unread != 2 || feed.Notifications[0].Subject != "Payment due" {
+		t.Fatalf("expected two unread notifications newest first, got %+v", feed)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/users/u1/notifications/"+feed.Notifications[1].ID+"/read", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if unread := list("?unread=true"); len(unread.Notifications) != 1 || unread.Notifications[0].Subject != "Payment due" {
+		t.Errorf("expected only the unread notification, got %+v", unread)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/users/u2/notifications/"+feed.Notifications[0].ID+"/read", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user's notification, got %d", w.Code)
+	}
+}
+
+func TestIntegration_NotificationTemplatePreviewAndTestSend(t *testing.T) {
+	env := setup(t)
+	inbox := service.NewInboxService(memory.NewInboxRepository())
+	notifications := service.NewNotificationService(nil, nil, nil, nil, service.NotificationScalingConfig{MinWorkers: 1, MaxWorkers: 1}, env.logger).WithInbox(inbox)
+	defer notifications.Shutdown(context.Background())
+	env.handler.WithNotifications(notifications)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	call := func(path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v1/admin/notification-templates/"+path, bytes.NewBufferString(body))
+		r.Header.Set("X-Operator-ID", "op-1")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	w := call("transaction_failed/preview", `{"data":{"Amount":12.5,"Currency":"EUR","FailureReason":"insufficient funds"}}`)
+	var rendered service.RenderedTemplate
+	_ = json.NewDecoder(w.Body).Decode(&rendered)
+	if w.Code != http.StatusOK || rendered.Body != "Your transaction of 12.50 EUR has failed. Reason: insufficient funds" {
+		t.Fatalf("expected rendered default template, got %d %+v", w.Code, rendered)
+	}
+	if w := call("transaction_failed/preview", `{"data":{"Amount":12.5}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected missing sample data to be reported, got %d", w.Code)
+	}
+	if w := call("unknown/preview", `{"data":{}}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown template, got %d", w.Code)
+	}
+
+	w = call("statement_ready/test-send", `{"template":{"subject":"Statement for {{.Month}}","body":"Your {{.Month}} statement is ready."},"data":{"Month":"March"},"channel":"inbox","recipient":"u1"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	deadline := time.Now().Add(time.Second)
+	var feed *service.Inbox
+	for time.Now().Before(deadline) {
+		if feed, _ = inbox.List(context.Background(), "u1", false); len(feed.Notifications) > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(feed.Notifications) != 1 || feed.Notifications[0].Subject != "Statement for March" || feed.Notifications[0].Metadata["test_send"] != "true" {
+		t.Errorf("expected the draft to be test-sent to the inbox, got %+v", feed)
+	}
+}
+
+func TestIntegration_PendingTransactionExpiry(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	env.processor.WithAuditLog(memory.NewAuditRepository())
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "r-approval",
+		Name:      "Approve all deposits",
+		Condition: `{"field":"amount","operator":">","value":0}`,
+		Action:    `{"type":"require_approval","params":{}}`,
+		IsActive:  true,
+	})
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "E1", UserID: "U7", Currency: "USD", Status: domain.AccountActive})
+	stale, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 100, Currency: "USD", ToAccountID: "E1"})
+	fresh, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 50, Currency: "USD", ToAccountID: "E1"})
+	if stale == nil || fresh == nil || stale.Status != domain.StatusPending || fresh.Status != domain.StatusPending {
+		t.Fatalf("expected two pending transactions, got %+v / %+v", stale, fresh)
+	}
+	staleTx, _ := env.txRepo.GetByID(ctx, stale.ID)
+	staleTx.CreatedAt = staleTx.CreatedAt.Add(-2 * time.Hour)
+	notifier := fmtest.NewRecordingNotifier()
+	cfg := service.DefaultTransactionExpiryConfig()
+	cfg.TTL = time.Hour
+	expiry := service.NewTransactionExpiryService(env.processor, env.accRepo, notifier, cfg, nil)
+
+	if err := expiry.Sweep(ctx); err != nil {
+		t.Fatalf("unexpected sweep error: %v", err)
+	}
+
+	if tx, _ := env.txRepo.GetByID(ctx, stale.ID); tx.Status != domain.StatusExpired || tx.Metadata[processor.MetadataExpiredAt] == "" {
+		t.Errorf("expected stale transaction expired, got %+v", tx)
+	}
+	if tx, _ := env.txRepo.GetByID(ctx, fresh.ID); tx.Status != domain.StatusPending {
+		t.Errorf("expected fresh transaction still pending, got %s", tx.Status)
+	}
+	if msgs := notifier.Messages(); len(msgs) != 1 || msgs[0].Recipient != "U7" || msgs[0].Metadata["transaction_id"] != stale.ID {
+		t.Errorf("expected one expiry notification to U7, got %+v", msgs)
+	}
+	r := httptest.NewRequest("POST", "/api/v1/admin/transactions/"+stale.ID+"/override", bytes.NewBufferString(`{"outcome":"approve","reason":"late"}`))
+	r.Header.Set("X-Operator-ID", "op-1")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != 409 {
+		t.Errorf("expected 409 overriding expired transaction, got %d", w.Code)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "E1"); acc.Balance != 0 {
+		t.Errorf("expected balance untouched, got %f", acc.Balance)
+	}
+}
+
+func TestIntegration_SuspiciousReviewSLA(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	env.processor.WithAuditLog(memory.NewAuditRepository())
+	_ = env.ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "r-risky",
+		Name:      "Treat all deposits as risky",
+		Condition: `{"field":"amount","operator":">","value":0}`,
+		Action:    `{"type":"adjust_risk_score","params":{"adjustment":90}}`,
+		IsActive:  true,
+	})
+	mustCreateAccount(t, env, "S1", "USD", 0)
+	first, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 120.4, Currency: "USD", ToAccountID: "S1"})
+	second, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 75.9, Currency: "USD", ToAccountID: "S1"})
+	if first == nil || second == nil || first.Status != domain.StatusSuspicious || second.Status != domain.StatusSuspicious {
+		t.Fatalf("expected two suspicious transactions, got %+v / %+v", first, second)
+	}
+	age := func(id string) {
+		tx, _ := env.txRepo.GetByID(ctx, id)
+		tx.CreatedAt = tx.CreatedAt.Add(-3 * time.Hour)
+	}
+	age(first.ID)
+
+	notifier := fmtest.NewRecordingNotifier()
+	cfg := service.DefaultReviewSLAConfig()
+	cfg.SLA = time.Hour
+	escalation := service.NewReviewSLAService(env.processor, notifier, cfg, nil)
+	for range 2 {
+		if err := escalation.Sweep(ctx); err != nil {
+			t.Fatalf("unexpected sweep error: %v", err)
+		}
+	}
+	if tx, _ := env.txRepo.GetByID(ctx, first.ID); tx.Status != domain.StatusSuspicious || tx.Metadata[processor.MetadataReviewSLAOutcome] != processor.ReviewSLAEscalate {
+		t.Errorf("expected overdue transaction escalated and still suspicious, got %+v", tx)
+	}
+	if msgs := notifier.Messages(); len(msgs) != 1 || msgs[0].Recipient != "#fraud-alerts" || msgs[0].Metadata["transaction_id"] != first.ID {
+		t.Errorf("expected a single escalation to the fraud team, got %+v", msgs)
+	}
+
+	age(second.ID)
+	cfg.Policy = processor.ReviewSLAReject
+	if err := service.NewReviewSLAService(env.processor, notifier, cfg, nil).Sweep(ctx); err != nil {
+		t.Fatalf("unexpected sweep error: %v", err)
+	}
+	if tx, _ := env.txRepo.GetByID(ctx, second.ID); tx.Status != domain.StatusFailed {
+		t.Errorf("expected overdue transaction auto-rejected, got %s", tx.Status)
+	}
+	if tx, _ := env.txRepo.GetByID(ctx, first.ID); tx.Status != domain.StatusSuspicious {
+		t.Errorf("expected escalated transaction left for review, got %s", tx.Status)
+	}
+	if got := env.processor.GetMetrics()["review_sla_breached"]; got != 2 {
+		t.Errorf("expected two SLA breaches recorded, got %d", got)
+	}
+}
+
+func TestIntegration_InstallmentPlan(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	installments := service.NewInstallmentService(memory.NewInstallmentPlanRepository(), env.txRepo, env.processor, service.DefaultInstallmentConfig(), nil)
+	env.handler.WithInstallments(installments)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "I1", Currency: "USD", Status: domain.AccountActive, Balance: 1000, DailyLimit: 400})
+	mustCreateAccount(t, env, "I2", "USD", 0)
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBufferString(body)))
+		return w
+	}
+	first := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	overLimit := post("/api/v1/installment-plans", `{"from_account_id":"I1","to_account_id":"I2","amount":1000,"currency":"USD","installments":2,"interval_days":30}`)
+	created := post("/api/v1/installment-plans", fmt.Sprintf(`{"from_account_id":"I1","to_account_id":"I2","amount":900,"currency":"USD","installments":3,"interval_days":30,"first_due_at":%q}`, first))
+
+	if overLimit.Code != 422 || created.Code != 201 {
+		t.Fatalf("expected 422 then 201, got %d / %d: %s", overLimit.Code, created.Code, created.Body.String())
+	}
+	if scheduled, _ := env.txRepo.GetByStatus(ctx, domain.StatusScheduled); len(scheduled) != 3 {
+		t.Fatalf("expected only the accepted plan's 3 installments scheduled, got %d", len(scheduled))
+	}
+	var resp api.InstallmentPlanResponse
+	_ = json.NewDecoder(created.Body).Decode(&resp)
+	if len(resp.Installments) != 3 || resp.Installments[0].Amount != 300 || resp.Installments[0].Metadata[processor.MetadataInstallmentPlan] != resp.Plan.ID {
+		t.Fatalf("expected three linked installments of 300, got %+v", resp.Installments)
+	}
+
+	if err := installments.ProcessDue(ctx); err != nil {
+		t.Fatalf("unexpected dispatch error: %v", err)
+	}
+
+	if acc, _ := env.accRepo.GetByID(ctx, "I2"); acc.Balance != 300 {
+		t.Errorf("expected only the first installment executed, got balance %f", acc.Balance)
+	}
+	cancelled := post("/api/v1/installment-plans/"+resp.Plan.ID+"/cancel", "")
+	again := post("/api/v1/installment-plans/"+resp.Plan.ID+"/cancel", "")
+	if cancelled.Code != 200 || again.Code != 409 {
+		t.Fatalf("expected 200 then 409, got %d / %d", cancelled.Code, again.Code)
+	}
+	_ = json.NewDecoder(cancelled.Body).Decode(&resp)
+	statuses := []domain.TransactionStatus{resp.Installments[0].Status, resp.Installments[1].Status, resp.Installments[2].Status}
+	if resp.Plan.Status != domain.InstallmentPlanCancelled || !slices.Equal(statuses, []domain.TransactionStatus{domain.StatusCompleted, domain.StatusCancelled, domain.StatusCancelled}) {
+		t.Errorf("expected first installment completed and the rest cancelled, got %s / %v", resp.Plan.Status, statuses)
+	}
+}
+
+func TestIntegration_PositivePayHoldsUnlistedPayments(t *testing.T) {
+	env := setup(t)
+	env.processor.WithAuditLog(memory.NewAuditRepository()).WithPositivePay(memory.NewPositivePayRepository())
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "C1", "USD", 5000)
+	mustCreateAccount(t, env, "P1", "USD", 0)
+	mustCreateAccount(t, env, "P2", "USD", 0)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		r.Header.Set("X-Operator-ID", "op-1")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	uploaded := do("PUT", "/api/v1/accounts/C1/positive-pay", `{"payees":[{"payee_account_id":"P1","min_amount":100,"max_amount":500}]}`)
+	listed, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 200, Currency: "USD", FromAccountID: "C1", ToAccountID: "P1"})
+	tooLarge, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 900, Currency: "USD", FromAccountID: "C1", ToAccountID: "P1"})
+	unlisted, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 200, Currency: "USD", FromAccountID: "C1", ToAccountID: "P2"})
+
+	if uploaded.Code != 200 {
+		t.Fatalf("expected list upload to succeed, got %d: %s", uploaded.Code, uploaded.Body.String())
+	}
+	if listed.Status != domain.StatusCompleted || tooLarge.Status != domain.StatusPending || unlisted.Status != domain.StatusPending {
+		t.Fatalf("expected listed payment completed and others held, got %s / %s / %s", listed.Status, tooLarge.Status, unlisted.Status)
+	}
+	var exceptions []*domain.Transaction
+	_ = json.NewDecoder(do("GET", "/api/v1/accounts/C1/positive-pay/exceptions", "").Body).Decode(&exceptions)
+	reasons := map[string]string{}
+	for _, tx := range exceptions {
+		reasons[tx.ID] = tx.Metadata[processor.MetadataPositivePayReason]
+	}
+	if len(exceptions) != 2 || reasons[tooLarge.ID] != processor.PositivePayExceptionAmount || reasons[unlisted.ID] != processor.PositivePayExceptionPayee {
+		t.Fatalf("expected two exceptions with amount and payee reasons, got %v", reasons)
+	}
+
+	approved := do("POST", "/api/v1/accounts/C1/positive-pay/exceptions/"+unlisted.ID+"/review", `{"outcome":"approve","reason":"new supplier confirmed"}`)
+	notException := do("POST", "/api/v1/accounts/C1/positive-pay/exceptions/"+listed.ID+"/review", `{"outcome":"approve","reason":"n/a"}`)
+
+	if approved.Code != 200 || notException.Code != 404 {
+		t.Fatalf("expected 200 then 404, got %d / %d", approved.Code, notException.Code)
+	}
+	if acc, _ := env.accRepo.GetByID(context.Background(), "P2"); acc.Balance != 200 {
+		t.Errorf("expected approved exception to settle, got balance %f", acc.Balance)
+	}
+}
+
+func TestIntegration_DualControlRequiresSecondOperator(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	auditRepo := memory.NewAuditRepository()
+	env.processor.WithAuditLog(auditRepo)
+	dualControl := service.NewDualControlService(memory.NewApprovalRepository(), auditRepo, nil).WithAdminActions(env.processor)
+	env.handler.WithDualControl(dualControl)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "M1", Currency: "USD", Status: domain.AccountActive, Balance: 100, DailyLimit: 1000})
+	post := func(path, operator, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+		r.Header.Set("X-Operator-ID", operator)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	staged := post("/api/v1/admin/migrations/balances", "maker", `{"reference":"fix-1","adjustments":[{"account_id":"M1","amount":50,"reason":"correction"}]}`)
+	var approval domain.ApprovalRequest
+	_ = json.NewDecoder(staged.Body).Decode(&approval)
+	if acc, _ := env.accRepo.GetByID(ctx, "M1"); staged.Code != 202 || approval.Status != domain.ApprovalPending || acc.Balance != 100 {
+		t.Fatalf("expected staged migration with balance untouched, got %d / %s / %f", staged.Code, approval.Status, acc.Balance)
+	}
+
+	self := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "maker", "")
+	approved := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "checker", "")
+	again := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "checker", "")
+
+	if self.Code != 403 || approved.Code != 200 || again.Code != 409 {
+		t.Fatalf("expected 403/200/409, got %d/%d/%d", self.Code, approved.Code, again.Code)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "M1"); acc.Balance != 150 {
+		t.Errorf("expected approved migration applied, got balance %f", acc.Balance)
+	}
+	entries, _ := auditRepo.GetByEntity(ctx, service.AuditEntityApproval, approval.ID)
+	if len(entries) != 2 || entries[1].Action != service.AuditActionApprovalExecuted || entries[1].Details["checker"] != "checker" || entries[1].Details["maker"] != "maker" {
+		t.Errorf("expected staged and executed audit entries naming both operators, got %+v", entries)
+	}
+
+	limit := post("/api/v1/admin/accounts/M1/limit-override", "maker", `{"limit_type":"daily","limit":50000,"reason":"vip"}`)
+	_ = json.NewDecoder(limit.Body).Decode(&approval)
+	rejected := post("/api/v1/admin/approvals/"+approval.ID+"/reject", "checker", `{"note":"not justified"}`)
+	if acc, _ := env.accRepo.GetByID(ctx, "M1"); limit.Code != 202 || rejected.Code != 200 || acc.DailyLimit != 1000 {
+		t.Errorf("expected rejected limit override to leave limit at 1000, got %d / %d / %f", limit.Code, rejected.Code, acc.DailyLimit)
+	}
+}
+
+func TestIntegration_DualControlStagesRiskOverride(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	auditRepo := memory.NewAuditRepository()
+	env.processor.WithAuditLog(auditRepo)
+	env.handler.WithDualControl(service.NewDualControlService(memory.NewApprovalRepository(), auditRepo, nil).WithAdminActions(env.processor))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "r-approval",
+		Name:      "Approve all deposits",
+		Condition: `{"field":"amount","operator":">","value":0}`,
+		Action:    `{"type":"require_approval","params":{}}`,
+		IsActive:  true,
+	})
+	mustCreateAccount(t, env, "O1", "USD", 0)
+	resp, _ := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 250, Currency: "USD", ToAccountID: "O1"})
+	post := func(path, operator, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+		r.Header.Set("X-Operator-ID", operator)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	staged := post("/api/v1/admin/transactions/"+resp.ID+"/override", "maker", `{"outcome":"approve","reason":"verified with customer"}`)
+	var approval domain.ApprovalRequest
+	_ = json.NewDecoder(staged.Body).Decode(&approval)
+	if acc, _ := env.accRepo.GetByID(ctx, "O1"); staged.Code != 202 || approval.Action != service.ActionRiskOverride || acc.Balance != 0 {
+		t.Fatalf("expected staged override with balance untouched, got %d / %s / %f", staged.Code, approval.Action, acc.Balance)
+	}
+
+	approved := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "checker", "")
+
+	if approved.Code != 200 {
+		t.Fatalf("expected approval to execute the override, got %d: %s", approved.Code, approved.Body.String())
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "O1"); acc.Balance != 250 {
+		t.Errorf("expected approved override to settle the deposit, got balance %f", acc.Balance)
+	}
+	entries, _ := auditRepo.GetByEntity(ctx, processor.AuditEntityTransaction, resp.ID)
+	if len(entries) != 1 || entries[0].Actor != "maker" {
+		t.Errorf("expected the override audited under its maker, got %+v", entries)
+	}
+}
+
+func TestIntegration_DualControlBindsOperatorToCredentials(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	auditRepo := memory.NewAuditRepository()
+	env.processor.WithAuditLog(auditRepo)
+	env.handler.
+		WithDualControl(service.NewDualControlService(memory.NewApprovalRepository(), auditRepo, nil).WithAdminActions(env.processor)).
+		WithOperatorAuthenticator(api.OperatorTokens(map[string]string{"maker-token": "maker", "checker-token": "checker"}))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "M1", "USD", 100)
+	post := func(path, token, operator, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if operator != "" {
+			r.Header.Set("X-Operator-ID", operator)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	staged := post("/api/v1/admin/migrations/balances", "maker-token", "", `{"reference":"fix-1","adjustments":[{"account_id":"M1","amount":50,"reason":"correction"}]}`)
+	var approval domain.ApprovalRequest
+	_ = json.NewDecoder(staged.Body).Decode(&approval)
+	if staged.Code != 202 || approval.Maker != "maker" {
+		t.Fatalf("expected migration staged by the authenticated maker, got %d / %q", staged.Code, approval.Maker)
+	}
+
+	unauthenticated := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "", "checker", "")
+	spoofed := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "maker-token", "checker", "")
+	self := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "maker-token", "", "")
+	approved := post("/api/v1/admin/approvals/"+approval.ID+"/approve", "checker-token", "", "")
+
+	if unauthenticated.Code != 401 || spoofed.Code != 403 || self.Code != 403 || approved.Code != 200 {
+		t.Fatalf("expected 401/403/403/200, got %d/%d/%d/%d", unauthenticated.Code, spoofed.Code, self.Code, approved.Code)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "M1"); acc.Balance != 150 {
+		t.Errorf("expected migration applied once a distinct operator approved, got balance %f", acc.Balance)
+	}
+}
+
+func TestIntegration_OpsDashboard(t *testing.T) {
+	env := setup(t)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.ruleRepo.Save(context.Background(), &domain.Rule{
+		ID:        "r-large",
+		Name:      "Review large deposits",
+		Condition: `{"field":"amount","operator":">","value":1000}`,
+		Action:    `{"type":"require_approval","params":{}}`,
+		IsActive:  true,
+	})
+	mustCreateAccount(t, env, "B1", "USD", 0)
+	mustCreateAccount(t, env, "B2", "USD", 0)
+	for _, amount := range []float64{100, 200, 5000} {
+		callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: amount, Currency: "USD", ToAccountID: "B1"})
+	}
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 50, Currency: "USD", FromAccountID: "B1", ToAccountID: "B2"})
+	get := func(path string, out interface{}) int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		_ = json.NewDecoder(w.Body).Decode(out)
+		return w.Code
+	}
+
+	var counts processor.StatusCounts
+	var queue processor.ApprovalQueueStats
+	var accounts []processor.AccountActivity
+	var rules []processor.RuleStats
+	var flags processor.FraudFlagRates
+	codes := []int{
+		get("/api/v1/admin/dashboard/fraud-flags", &flags),
+		get("/api/v1/admin/dashboard/transactions", &counts),
+		get("/api/v1/admin/dashboard/approval-queue", &queue),
+		get("/api/v1/admin/dashboard/accounts?limit=1", &accounts),
+		get("/api/v1/admin/dashboard/rules", &rules),
+		get("/api/v1/admin/dashboard/accounts?limit=0", &struct{}{}),
+	}
+
+	if !slices.Equal(codes, []int{200, 200, 200, 200, 200, 400}) {
+		t.Fatalf("unexpected status codes %v", codes)
+	}
+	if counts.Total != 4 || counts.ByStatus[domain.StatusCompleted] != 3 || counts.ByStatus[domain.StatusPending] != 1 {
+		t.Errorf("expected 3 completed and 1 pending, got %+v", counts)
+	}
+	if flags.Transactions != 4 {
+		t.Errorf("expected fraud flag rates over 4 transactions, got %+v", flags)
+	}
+	if queue.Depth != 1 || queue.P50AgeSeconds < 0 || queue.OldestAgeSeconds < queue.P50AgeSeconds {
+		t.Errorf("expected one queued transaction, got %+v", queue)
+	}
+	if len(accounts) != 1 || accounts[0].AccountID != "B1" || accounts[0].Transactions != 4 {
+		t.Errorf("expected B1 as busiest account with 4 transactions, got %+v", accounts)
+	}
+	if len(rules) == 0 || rules[0].RuleID != "r-large" || rules[0].Triggers != 1 {
+		t.Errorf("expected r-large leading with one trigger, got %+v", rules)
+	}
+}
+
+func TestIntegration_APIKeyQuotas(t *testing.T) {
+	env := setup(t)
+	env.handler.WithQuotas(service.NewQuotaService(memory.NewQuotaRepository(), env.logger))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	server := env.handler.QuotaMiddleware(mux)
+	mustCreateAccount(t, env, "Q1", "USD", 0)
+	do := func(method, path, apiKey string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		_ = json.NewEncoder(&payload).Encode(body)
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("X-Operator-ID", "ops-1")
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+	deposit := api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 400, Currency: "USD", ToAccountID: "Q1"}
+
+	if w := do("PUT", "/api/v1/admin/api-keys/partner-a/quota", "", api.APIKeyQuotaRequest{MonthlyRequests: 3, MonthlyVolume: 500}); w.Code != http.StatusOK {
+		t.Fatalf("expected quota to be set, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do("PUT", "/api/v1/admin/api-keys/partner-b/quota", "", api.APIKeyQuotaRequest{MonthlyRequests: 1, Enforcement: domain.QuotaSoft}); w.Code != http.StatusOK {
+		t.Fatalf("expected soft quota to be set, got %d", w.Code)
+	}
+
+	if w := do("POST", "/api/v1/transactions", "partner-a", deposit); w.Code != http.StatusCreated || w.Header().Get("X-Quota-Requests-Remaining") != "2" {
+		t.Fatalf("expected first deposit to succeed with 2 requests remaining, got %d %q", w.Code, w.Header().Get("X-Quota-Requests-Remaining"))
+	}
+	if w := do("POST", "/api/v1/transactions", "partner-a", deposit); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected volume quota to reject second deposit, got %d", w.Code)
+	}
+	if w := do("POST", "/api/v1/transactions", "partner-a", deposit); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected request quota to reject further calls, got %d", w.Code)
+	}
+
+	for range 2 {
+		do("POST", "/api/v1/transactions", "partner-b", deposit)
+	}
+	if w := do("POST", "/api/v1/transactions", "partner-b", deposit); w.Code != http.StatusCreated || w.Header().Get("X-Quota-Warning") == "" {
+		t.Fatalf("expected soft quota to warn but allow, got %d %q", w.Code, w.Header().Get("X-Quota-Warning"))
+	}
+
+	var report service.UsageReport
+	w := do("GET", "/api/v1/admin/api-keys/partner-a/usage", "", nil)
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected usage report, got %d: %v", w.Code, err)
+	}
+	if report.Usage.Requests != 3 || report.Usage.Transactions != 1 || report.Usage.Volume != 400 {
+		t.Errorf("expected 3 requests and one 400 transaction, got %+v", report.Usage)
+	}
+	if report.RemainingRequests == nil || *report.RemainingRequests != 0 || report.RemainingVolume == nil || *report.RemainingVolume != 100 {
+		t.Errorf("expected no requests and 100 volume remaining, got %+v", report)
+	}
+
+	if w := do("GET", "/api/v1/usage", "", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected own usage without key to be rejected, got %d", w.Code)
+	}
+	if w := do("GET", "/api/v1/admin/api-keys/partner-a/usage?period=2026", "", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("expected malformed period to be rejected, got %d", w.Code)
+	}
+	account, _ := env.accRepo.GetByID(context.Background(), "Q1")
+	if account.Balance != 1600 {
+		t.Errorf("expected four accepted deposits totalling 1600, got %.2f", account.Balance)
+	}
+}
+
+func TestIntegration_PartnerOnboarding(t *testing.T) {
+	env := setup(t)
+	env.handler.WithPartners(service.NewPartnerService(memory.NewPartnerRepository(), env.accRepo, env.logger))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "SETTLE", "USD", 0)
+	mustCreateAccount(t, env, "P1", "USD", 0)
+	do := func(method, path, apiKey string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		_ = json.NewEncoder(&payload).Encode(body)
+		req := httptest.NewRequest(method, path, &payload)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	publicKey := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+	invalid := domain.Partner{Name: "Acme", SettlementAccountID: "missing", Webhooks: []domain.WebhookEndpoint{{URL: "ftp://acme"}}}
+	if w := do("POST", "/api/v1/admin/partners", "", invalid); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected invalid partner to be rejected, got %d", w.Code)
+	}
+
+	w := do("POST", "/api/v1/admin/partners", "", domain.Partner{
+		Name:                "Acme Payments",
+		SigningKeys:         []domain.PartnerSigningKey{{KeyID: "k1", PublicKey: publicKey}},
+		Webhooks:            []domain.WebhookEndpoint{{URL: "https://acme.example/hooks", Events: []string{"transaction.completed"}}},
+		AllowedTypes:        []domain.TransactionType{domain.TypeDeposit},
+		SettlementAccountID: "SETTLE",
+	})
+	var partner domain.Partner
+	if err := json.NewDecoder(w.Body).Decode(&partner); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("expected partner to be created, got %d: %v", w.Code, err)
+	}
+	if partner.ID == "" || len(partner.APIKeys) != 1 || partner.Status != domain.PartnerActive || partner.SigningKeys[0].Algorithm != "ed25519" {
+		t.Fatalf("expected active partner with generated api key, got %+v", partner)
+	}
+	apiKey := partner.APIKeys[0]
+
+	w = do("POST", "/api/v1/transactions", apiKey, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 10, Currency: "USD", ToAccountID: "P1"})
+	var resp api.TransactionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("expected partner deposit to succeed, got %d: %v", w.Code, err)
+	}
+	tx, _ := env.txRepo.GetByID(context.Background(), resp.ID)
+	if tx.Metadata[service.MetadataPartnerID] != partner.ID {
+		t.Errorf("expected transaction tagged with partner, got %v", tx.Metadata)
+	}
+	if w := do("POST", "/api/v1/transactions", apiKey, api.CreateTransactionRequest{Type: domain.TypeWithdrawal, Amount: 5, Currency: "USD", FromAccountID: "P1"}); w.Code != http.StatusForbidden {
+		t.Errorf("expected disallowed transaction type to be forbidden, got %d", w.Code)
+	}
+
+	partner.Status = domain.PartnerSuspended
+	if w := do("PUT", "/api/v1/admin/partners/"+partner.ID, "", partner); w.Code != http.StatusOK {
+		t.Fatalf("expected partner update, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do("POST", "/api/v1/transactions", apiKey, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 10, Currency: "USD", ToAccountID: "P1"}); w.Code != http.StatusForbidden {
+		t.Errorf("expected suspended partner to be forbidden, got %d", w.Code)
+	}
+
+	if w := do("DELETE", "/api/v1/admin/partners/"+partner.ID, "", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected partner deletion, got %d", w.Code)
+	}
+	if w := do("GET", "/api/v1/admin/partners/"+partner.ID, "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected deleted partner to be gone, got %d", w.Code)
+	}
+}
+
+func TestIntegration_SystemAccounts(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	productRepo := memory.NewProductRepository()
+	env.processor.WithProducts(productRepo)
+	if err := env.processor.EnsureSystemAccounts(ctx, processor.SystemAccountConfig{Currencies: []string{"usd", "EUR"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+	if err := env.processor.EnsureSystemAccounts(ctx, processor.SystemAccountConfig{Currencies: []string{"USD", "EUR"}}); err != nil {
+		t.Fatalf("expected provisioning to be idempotent, got %v", err)
+	}
+	_ = productRepo.Save(ctx, &domain.Product{ID: "basic", Name: "Basic", Fees: domain.FeeSchedule{Transfer: domain.Fee{Fixed: 0.5, Percent: 1}}})
+	mustCreateAccount(t, env, "F1", "USD", 500)
+	mustCreateAccount(t, env, "F2", "USD", 0)
+	mustCreateAccount(t, env, "F3", "EUR", 0)
+	payer, _ := env.accRepo.GetByID(ctx, "F1")
+	payer.ProductID = "basic"
+	_ = env.accRepo.Update(ctx, payer)
+
+	if _, code := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 100, Currency: "USD", FromAccountID: "F1", ToAccountID: "F2"}); code != http.StatusCreated {
+		t.Fatalf("expected transfer to succeed, got %d", code)
+	}
+	wallets := service.NewWalletService(env.accRepo, env.processor, service.NewFXService("USD", map[string]float64{"EUR": 0.5}), nil)
+	f3, _ := env.accRepo.GetByID(ctx, "F3")
+	f3.UserID = "user-F1"
+	_ = env.accRepo.Update(ctx, f3)
+	if _, err := wallets.Exchange(ctx, "user-F1", "F1", "F3", 40); err != nil {
+		t.Fatalf("exchange failed: %v", err)
+	}
+
+	balance := func(id string) float64 {
+		account, err := env.accRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("get account %s: %v", id, err)
+		}
+		return account.Balance
+	}
+	if got := balance(domain.SystemAccountID(domain.SystemRoleFees, "USD")); got != 1.5 {
+		t.Errorf("expected 1.50 fee collected, got %.2f", got)
+	}
+	if got := balance("F1"); got != 358.5 {
+		t.Errorf("expected payer balance 358.50 after transfer, fee and exchange, got %.2f", got)
+	}
+	if got := balance(domain.SystemAccountID(domain.SystemRoleFXPosition, "USD")); got != 40 {
+		t.Errorf("expected USD FX position of 40, got %.2f", got)
+	}
+	if got := balance(domain.SystemAccountID(domain.SystemRoleFXPosition, "EUR")); got != -20 {
+		t.Errorf("expected EUR FX position of -20, got %.2f", got)
+	}
+	if total := balance("F1") + balance("F2") + balance(domain.SystemAccountID(domain.SystemRoleFees, "USD")) + balance(domain.SystemAccountID(domain.SystemRoleFXPosition, "USD")); total != 500 {
+		t.Errorf("expected USD money to be conserved at 500, got %.2f", total)
+	}
+
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/system-accounts", nil))
+	var accounts []domain.Account
+	if err := json.NewDecoder(w.Body).Decode(&accounts); err != nil || len(accounts) != 8 {
+		t.Fatalf("expected 8 system accounts, got %d (%v)", len(accounts), err)
+	}
+	if !accounts[0].IsSystem() || accounts[0].UserID != domain.SystemUserID {
+		t.Errorf("expected system-owned accounts, got %+v", accounts[0])
+	}
+}
+
+func TestIntegration_ReservesReport(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	if err := env.processor.EnsureSystemAccounts(ctx, processor.SystemAccountConfig{Currencies: []string{"USD"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+	env.handler.WithReserves(service.NewReservesService(env.processor, service.DefaultReservesConfig(), env.logger))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "R1", "USD", 0)
+	mustCreateAccount(t, env, "R2", "USD", 0)
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 300, Currency: "USD", ToAccountID: "R1"})
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeTransfer, Amount: 50, Currency: "USD", FromAccountID: "R1", ToAccountID: "R2"})
+	callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeWithdrawal, Amount: 20, Currency: "USD", FromAccountID: "R2"})
+	provisional := domain.NewTransaction(domain.TypeDeposit, 5, "USD").WithAccounts("", "R2")
+	if err := env.processor.PostSystemTransaction(ctx, provisional, "test_credit"); err != nil {
+		t.Fatalf("PostSystemTransaction failed: %v", err)
+	}
+	generate := func() (*processor.ReservesReport, int) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/admin/reserves/reports", nil))
+		var report processor.ReservesReport
+		_ = json.NewDecoder(w.Body).Decode(&report)
+		return &report, w.Code
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/reserves/reports/latest", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected no report before generation, got %d", w.Code)
+	}
+
+	report, code := generate()
+	if code != http.StatusOK || len(report.Currencies) != 1 {
+		t.Fatalf("expected one currency in report, got %d %+v", code, report)
+	}
+	usd := report.Currencies[0]
+	if !report.Consistent || usd.Liabilities != 285 || usd.SystemPositions[domain.SystemRoleSuspense] != -5 || usd.LedgerNet != 280 || usd.CustomerAccounts != 2 {
+		t.Errorf("expected consistent USD reserves, got %+v", usd)
+	}
+
+	_ = env.accRepo.UpdateBalance(ctx, "R1", 10)
+	report, _ = generate()
+	if report.Consistent || report.Currencies[0].Discrepancy != 10 {
+		t.Errorf("expected unledgered balance change to be flagged, got %+v", report.Currencies[0])
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/reserves/reports/latest", nil))
+	var latest processor.ReservesReport
+	if err := json.NewDecoder(w.Body).Decode(&latest); err != nil || latest.ID != report.ID {
+		t.Errorf("expected latest report %s, got %s (%v)", report.ID, latest.ID, err)
+	}
+}
+
+func TestIntegration_SchedulerAdmin(t *testing.T) {
+	env := setup(t)
+	sched := scheduler.New(env.logger)
+	ran := make(chan struct{}, 1)
+	_ = sched.Register(scheduler.Job{Name: "nightly_sweep", Schedule: scheduler.Daily(2, 0), Run: func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}})
+	sched.Start()
+	defer sched.Stop(context.Background())
+	env.handler.WithScheduler(sched)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+
+	call := func(method, path, operator string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if operator != "" {
+			req.Header.Set("X-Operator-ID", operator)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := call("POST", "/api/v1/admin/jobs/nightly_sweep/pause", ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected operator to be required, got %d", w.Code)
+	}
+	if w := call("POST", "/api/v1/admin/jobs/nightly_sweep/pause", "ops"); w.Code != http.StatusOK {
+		t.Fatalf("expected pause to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := call("POST", "/api/v1/admin/jobs/unknown/trigger", "ops"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected unknown job to 404, got %d", w.Code)
+	}
+	if w := call("POST", "/api/v1/admin/jobs/nightly_sweep/trigger", "ops"); w.Code != http.StatusAccepted {
+		t.Fatalf("expected trigger to be accepted, got %d", w.Code)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected triggered job to run")
+	}
+
+	var jobs []scheduler.JobStatus
+	if err := json.NewDecoder(call("GET", "/api/v1/admin/jobs", "").Body).Decode(&jobs); err != nil || len(jobs) != 1 {
+		t.Fatalf("expected one job, got %+v (%v)", jobs, err)
+	}
+	if !jobs[0].Paused || jobs[0].Spec != "@daily 02:00" {
+		t.Errorf("expected paused nightly job, got %+v", jobs[0])
+	}
+}
+
+func TestIntegration_ResponseCache(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	fx := service.NewFXService("USD", map[string]float64{"EUR": 0.5})
+	env.handler.WithWallets(service.NewWalletService(env.accRepo, env.processor, fx, nil)).
+		WithResponseCache(api.DefaultResponseCacheConfig())
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "CACHE-1", Balance: 0, Currency: "USD", Status: domain.AccountActive})
+
+	tx := domain.NewTransaction(domain.TypeDeposit, 50.0, "USD").WithAccounts("", "CACHE-1")
+	if err := env.processor.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	first := get("/api/v1/transactions?id=" + tx.ID)
+	second := get("/api/v1/transactions?id=" + tx.ID)
+	if first.Code != http.StatusOK || second.Code != http.StatusOK {
+		t.Fatalf("expected 200s, got %d and %d", first.Code, second.Code)
+	}
+	if first.Header().Get("X-Cache") != "" || second.Header().Get("X-Cache") != "HIT" {
+		t.Fatalf("expected second read to hit the cache, got %q then %q", first.Header().Get("X-Cache"), second.Header().Get("X-Cache"))
+	}
+
+	rates := get("/api/v1/fx/rates")
+	etag := rates.Header().Get("ETag")
+	if rates.Header().Get("X-Cache") != "MISS" || get("/api/v1/fx/rates").Header().Get("X-Cache") != "HIT" {
+		t.Fatal("expected fx rates to be served from cache on the second read")
+	}
+	_ = fx.SetRate("EUR", 0.6)
+	updated := get("/api/v1/fx/rates")
+	var body api.FXRatesResponse
+	_ = json.NewDecoder(updated.Body).Decode(&body)
+	if updated.Header().Get("X-Cache") != "MISS" || updated.Header().Get("ETag") == etag || body.Rates["EUR"] != 0.6 {
+		t.Fatalf("expected rate change to invalidate cache, got %q %q %+v", updated.Header().Get("X-Cache"), updated.Header().Get("ETag"), body)
+	}
+
+	var stats []cache.Stats
+	_ = json.NewDecoder(get("/api/v1/admin/cache/stats").Body).Decode(&stats)
+	if len(stats) != 3 || stats[0].Name != "transactions" || stats[0].Hits != 1 || stats[2].Hits != 1 {
+		t.Fatalf("unexpected cache stats: %+v", stats)
+	}
+}
+
+func TestIntegration_AccountActivityFeed(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	limitRepo := memory.NewLimitChangeRepository()
+	inboxRepo := memory.NewInboxRepository()
+	env.handler.WithActivity(service.NewActivityService(env.accRepo, env.txRepo, nil).
+		WithLimitChanges(limitRepo).
+		WithInbox(inboxRepo))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "FEED-1", UserID: "feed-user", Balance: 0, Currency: "USD", Status: domain.AccountActive, DailyLimit: 1000})
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "FEED-2", UserID: "other", Balance: 0, Currency: "USD", Status: domain.AccountActive})
+
+	deposit := domain.NewTransaction(domain.TypeDeposit, 200.0, "USD").WithAccounts("", "FEED-1")
+	if err := env.processor.ProcessTransaction(ctx, deposit); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	transfer := domain.NewTransaction(domain.TypeTransfer, 50.0, "USD").WithAccounts("FEED-1", "FEED-2")
+	if err := env.processor.ProcessTransaction(ctx, transfer); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	change := domain.NewLimitChange("FEED-1", domain.LimitDaily, 1000, 5000)
+	change.Status = domain.LimitChangeCoolingOff
+	_ = limitRepo.Save(ctx, change)
+	_ = service.NewInboxService(inboxRepo).Deliver(ctx, service.NotificationMessage{
+		UserID:   "feed-user",
+		Subject:  "Limit change requested",
+		Metadata: map[string]string{"account_id": "FEED-1"},
+	})
+	_ = service.NewInboxService(inboxRepo).Deliver(ctx, service.NotificationMessage{
+		UserID:   "feed-user",
+		Subject:  "Unrelated",
+		Metadata: map[string]string{"account_id": "ELSEWHERE"},
+	})
+
+	page := func(query string) (int, service.ActivityFeed) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/accounts/FEED-1/activity"+query, nil))
+		var feed service.ActivityFeed
+		_ = json.NewDecoder(w.Body).Decode(&feed)
+		return w.Code, feed
+	}
+
+	code, first := page("?limit=2")
+	if code != http.StatusOK || len(first.Entries) != 2 || first.NextCursor == "" {
+		t.Fatalf("unexpected first page: %d %+v", code, first)
+	}
+	if first.Entries[0].Kind != service.ActivityNotification || first.Entries[1].Kind != service.ActivityLimitChange {
+		t.Fatalf("expected newest entries first, got %+v", first.Entries)
+	}
+	_, second := page("?limit=2&cursor=" + first.NextCursor)
+	if len(second.Entries) != 2 || second.NextCursor != "" {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+	if second.Entries[0].Summary != "Transfer of 50.00 USD to FEED-2" || second.Entries[0].Direction != "debit" {
+		t.Errorf("unexpected transfer entry: %+v", second.Entries[0])
+	}
+	if second.Entries[1].Summary != "Deposit of 200.00 USD" || second.Entries[1].Direction != "credit" {
+		t.Errorf("unexpected deposit entry: %+v", second.Entries[1])
+	}
+
+	if code, _ := page("?cursor=not-a-cursor"); code != http.StatusBadRequest {
+		t.Errorf("expected invalid cursor to be rejected, got %d", code)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/accounts/MISSING/activity", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected unknown account to return 404, got %d", w.Code)
+	}
+}
+
+func TestIntegration_SearchAsYouType(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	disputeRepo := memory.NewDisputeRepository()
+	env.handler.WithSearch(service.NewSearchService(env.txRepo, env.accRepo, nil).WithCases(disputeRepo))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "SRCH-ACME", UserID: "acme-corp", Currency: "USD", Status: domain.AccountActive})
+
+	tx := domain.NewTransaction(domain.TypeDeposit, 75.0, "USD").WithAccounts("", "SRCH-ACME").WithDescription("Quarterly rebate from Acme")
+	if err := env.processor.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	_ = disputeRepo.Save(ctx, &domain.Dispute{ID: "case-1", TransactionID: tx.ID, AccountID: "SRCH-ACME", Reason: "Duplicate rebate", Status: domain.DisputeOpen})
+
+	search := func(query string) (int, service.SearchResults) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/search?q="+query, nil))
+		var results service.SearchResults
+		_ = json.NewDecoder(w.Body).Decode(&results)
+		return w.Code, results
+	}
+
+	code, results := search("srch-acme")
+	if code != http.StatusOK || len(results.Results) != 3 || results.Results[0].Type != service.SearchResultAccount {
+		t.Fatalf("expected exact account match first, got %d %+v", code, results)
+	}
+	_, results = search("reba")
+	types := map[service.SearchResultType]bool{}
+	for _, result := range results.Results {
+		types[result.Type] = true
+	}
+	if len(results.Results) != 2 || !types[service.SearchResultTransaction] || !types[service.SearchResultCase] {
+		t.Fatalf("expected transaction and case for prefix query, got %+v", results.Results)
+	}
+	_, results = search(tx.Reference)
+	if len(results.Results) != 1 || results.Results[0].ID != tx.ID {
+		t.Fatalf("expected reference lookup to find the transaction, got %+v", results.Results)
+	}
+	if code, _ := search(""); code != http.StatusBadRequest {
+		t.Errorf("expected empty query to be rejected, got %d", code)
+	}
+}
+
+func TestIntegration_SettlementReconciliation(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	env.handler.WithReconciliation(service.NewReconciliationService(env.txRepo, memory.NewReconciliationRepository(), service.DefaultReconciliationConfig(), nil))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "RECON-1", "USD", 0)
+
+	deposit := func(amount float64) *domain.Transaction {
+		tx := domain.NewTransaction(domain.TypeDeposit, amount, "USD").WithAccounts("", "RECON-1")
+		if err := env.processor.ProcessTransaction(ctx, tx); err != nil {
+			t.Fatalf("deposit failed: %v", err)
+		}
+		return tx
+	}
+	byReference, byAmount, mismatched, unsettled := deposit(100), deposit(250), deposit(40), deposit(60)
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	file := strings.Join([]string{
+		"Reference,Amount,Currency,Date",
+		strings.ToLower(byReference.Reference) + ",100.00,usd," + today,
+		",250.00,USD," + today,
+		mismatched.Reference + ",45.00,USD," + today,
+		"EXT-404,60.00,EUR," + today,
+	}, "\n")
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/admin/reconciliations?source=acme", strings.NewReader(file)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var report domain.ReconciliationReport
+	_ = json.NewDecoder(w.Body).Decode(&report)
+
+	want := domain.ReconciliationSummary{Entries: 4, Matched: 2, Mismatched: 1, MissingInternal: 1, MissingExternal: 1, OpenBreaks: 3}
+	if report.Summary != want {
+		t.Fatalf("expected summary %+v, got %+v", want, report.Summary)
+	}
+	items := map[string]domain.ReconciliationItem{}
+	var missing domain.ReconciliationItem
+	for _, item := range report.Items {
+		items[item.TransactionID] = item
+		if item.Status == domain.ReconciliationMissingInternal {
+			missing = item
+		}
+	}
+	if items[byReference.ID].MatchedBy != service.MatchedByReference || items[byAmount.ID].MatchedBy != service.MatchedByAmountDate {
+		t.Errorf("expected reference and amount/date matches, got %+v / %+v", items[byReference.ID], items[byAmount.ID])
+	}
+	if item := items[mismatched.ID]; item.Status != domain.ReconciliationMismatched || len(item.Differences) != 1 {
+		t.Errorf("expected amount mismatch, got %+v", item)
+	}
+	if items[unsettled.ID].Status != domain.ReconciliationMissingExternal {
+		t.Errorf("expected unsettled transaction to be missing externally, got %+v", items[unsettled.ID])
+	}
+
+	resolve := func(itemID, operator string, body string) int {
+		req := httptest.NewRequest("POST", "/api/v1/admin/reconciliations/"+report.ID+"/items/"+itemID+"/resolve", strings.NewReader(body))
+		if operator != "" {
+			req.Header.Set("X-Operator-ID", operator)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := resolve(missing.ID, "", `{"resolution":"written_off"}`); code != http.StatusUnauthorized {
+		t.Errorf("expected missing operator to be rejected, got %d", code)
+	}
+	if code := resolve(items[byReference.ID].ID, "ops-1", `{"resolution":"accept_internal"}`); code != http.StatusConflict {
+		t.Errorf("expected matched item to be rejected, got %d", code)
+	}
+	if code := resolve(missing.ID, "ops-1", `{"resolution":"linked","transaction_id":"`+unsettled.ID+`","note":"currency keyed wrong"}`); code != http.StatusOK {
+		t.Fatalf("expected link to succeed, got %d", code)
+	}
+	if code := resolve(items[mismatched.ID].ID, "ops-1", `{"resolution":"accept_internal"}`); code != http.StatusOK {
+		t.Fatalf("expected resolution to succeed, got %d", code)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/reconciliations/"+report.ID+"?open=true", nil))
+	report = domain.ReconciliationReport{}
+	_ = json.NewDecoder(w.Body).Decode(&report)
+	if report.Summary.OpenBreaks != 0 || len(report.Items) != 0 {
+		t.Errorf("expected all breaks resolved, got %+v", report)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/admin/reconciliations?source=acme", strings.NewReader("reference,amount\nx,1")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected malformed file to be rejected, got %d", w.Code)
+	}
+}
+
+func TestIntegration_TransactionTrace(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	auditRepo := memory.NewAuditRepository()
+	env.processor.WithAuditLog(auditRepo).WithDecisionTracing()
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "TRACE-1", UserID: "u-trace", Balance: 1000, Currency: "USD", Status: domain.AccountActive})
+	_ = env.ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "r-trace",
+		Name:      "Flag withdrawals over 100",
+		Condition: `{"field":"amount","operator":">","value":100}`,
+		Action:    `{"type":"flag_transaction","params":{"reason":"over_100"}}`,
+		IsActive:  true,
+	})
+
+	tx := domain.NewTransaction(domain.TypeWithdrawal, 200, "USD").WithAccounts("TRACE-1", "")
+	if err := env.processor.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("withdrawal failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/transactions/"+tx.ID+"/trace", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var trace processor.TransactionTrace
+	_ = json.NewDecoder(w.Body).Decode(&trace)
+	if trace.Status != domain.StatusCompleted {
+		t.Errorf("expected completed status, got %s", trace.Status)
+	}
+
+	steps := map[string]processor.TraceStep{}
+	var stages []string
+	for _, step := range trace.Steps {
+		steps[step.Stage+"/"+step.Name] = step
+		if len(stages) == 0 || stages[len(stages)-1] != step.Stage {
+			stages = append(stages, step.Stage)
+		}
+	}
+	if want := []string{"validation", "fraud", "rules", "repository", "limits", "decision", "repository"}; !slices.Equal(stages, want) {
+		t.Errorf("expected stages %v, got %v", want, stages)
+	}
+	if step := steps["rules/r-trace"]; step.Outcome != processor.TraceOutcomeTriggered || step.Details["action"] != "flag_transaction" {
+		t.Errorf("expected triggered rule step, got %+v", step)
+	}
+	if step := steps["limits/daily_withdrawal"]; step.Outcome != processor.TraceOutcomePassed || step.Details["limit"] == "" || step.Details["amount"] != "200.00" {
+		t.Errorf("expected daily limit values at decision time, got %+v", step)
+	}
+	if step := steps["repository/accounts.update"]; step.Details["entity_id"] != "TRACE-1" {
+		t.Errorf("expected account update step, got %+v", step)
+	}
+	if _, ok := steps["repository/transactions.save"]; !ok {
+		t.Error("expected transaction save step")
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/transactions/missing/trace", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown transaction, got %d", w.Code)
+	}
+}
+
+func TestIntegration_CurrencyExposure(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	if err := env.processor.EnsureSystemAccounts(ctx, processor.SystemAccountConfig{Currencies: []string{"USD", "EUR"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+	fx := service.NewFXService("USD", map[string]float64{"EUR": 0.5})
+	wallets := service.NewWalletService(env.accRepo, env.processor, fx, nil)
+	env.handler.WithWallets(wallets).
+		WithExposure(service.NewExposureService(env.processor, fx, service.ExposureConfig{DefaultThreshold: 1000}, env.logger))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "X-USD", UserID: "trader", Balance: 1000, Currency: "USD", Status: domain.AccountActive})
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "X-EUR", UserID: "trader", Currency: "EUR", Status: domain.AccountActive})
+	if _, err := wallets.Exchange(ctx, "trader", "X-USD", "X-EUR", 200); err != nil {
+		t.Fatalf("exchange failed: %v", err)
+	}
+
+	setThreshold := func(body string) int {
+		req := httptest.NewRequest("PUT", "/api/v1/admin/exposure/thresholds", bytes.NewBufferString(body))
+		req.Header.Set("X-Operator-ID", "treasury")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+	report := func() service.ExposureReport {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/exposure", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var report service.ExposureReport
+		_ = json.NewDecoder(w.Body).Decode(&report)
+		return report
+	}
+
+	if code := setThreshold(`{"currency":"eur","threshold":150}`); code != http.StatusOK {
+		t.Fatalf("expected threshold update to succeed, got %d", code)
+	}
+	if code := setThreshold(`{"threshold":-1}`); code != http.StatusBadRequest {
+		t.Errorf("expected negative threshold to be rejected, got %d", code)
+	}
+
+	exposure := report()
+	if len(exposure.Currencies) != 2 || exposure.Currencies[0].Currency != "EUR" || exposure.Currencies[1].Currency != "USD" {
+		t.Fatalf("unexpected currencies: %+v", exposure.Currencies)
+	}
+	eur, usd := exposure.Currencies[0], exposure.Currencies[1]
+	if eur.NetPosition != -100 || eur.BaseEquivalent != -200 || !eur.Breached {
+		t.Errorf("expected short 100 EUR breaching threshold, got %+v", eur)
+	}
+	if usd.NetPosition != 200 || usd.Breached {
+		t.Errorf("expected long 200 USD within threshold, got %+v", usd)
+	}
+	if exposure.NetExposure != 0 || exposure.GrossExposure != 400 {
+		t.Errorf("expected net 0 and gross 400, got %v and %v", exposure.NetExposure, exposure.GrossExposure)
+	}
+	if len(exposure.Alerts) != 1 || exposure.Alerts[0].Currency != "EUR" {
+		t.Fatalf("expected EUR alert, got %+v", exposure.Alerts)
+	}
+
+	setThreshold(`{"currency":"EUR","threshold":500}`)
+	if exposure := report(); len(exposure.Alerts) != 0 {
+		t.Errorf("expected alert to clear after raising the threshold, got %+v", exposure.Alerts)
+	}
+}
+
+func TestIntegration_WebhookEventSchemas(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/events/schemas", nil))
+	var catalog []domain.EventSchema
+	_ = json.NewDecoder(w.Body).Decode(&catalog)
+	if w.Code != http.StatusOK || len(catalog) != 4 {
+		t.Fatalf("expected four event schemas, got %d: %+v", w.Code, catalog)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/events/schemas/transaction.completed?version=1.0", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "schema_version") {
+		t.Errorf("expected versioned transaction.completed schema, got %d: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/events/schemas/transaction.completed?version=9.9", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected unknown schema version to 404, got %d", w.Code)
+	}
+
+	delivered := make(chan *http.Request, 4)
+	bodies := make(chan domain.WebhookEvent, 4)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event domain.WebhookEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		delivered <- r
+		bodies <- event
+	}))
+	defer receiver.Close()
+
+	partnerRepo := memory.NewPartnerRepository()
+	partners := service.NewPartnerService(partnerRepo, env.accRepo, env.logger)
+	_, err := partners.Create(ctx, &domain.Partner{
+		Name:     "Hooks",
+		Status:   domain.PartnerActive,
+		Webhooks: []domain.WebhookEndpoint{{URL: receiver.URL, Events: []string{"transaction.refunded"}}},
+	})
+	if !errors.Is(err, service.ErrInvalidPartner) || !strings.Contains(err.Error(), "transaction.refunded") {
+		t.Errorf("expected subscription to an unknown event type to be rejected, got %v", err)
+	}
+	_ = partnerRepo.Save(ctx, &domain.Partner{
+		ID:       "hooks",
+		Status:   domain.PartnerActive,
+		Webhooks: []domain.WebhookEndpoint{{URL: receiver.URL, Events: []string{domain.EventTransactionCompleted}}},
+	})
+
+	webhooks := service.NewWebhookService(partnerRepo, service.DefaultWebhookConfig(), env.logger)
+	webhooks.Start()
+	defer webhooks.Shutdown(ctx)
+	env.processor.WithEventPublisher(webhooks)
+
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "HOOK-1", UserID: "u", Currency: "USD", Status: domain.AccountActive})
+	tx := domain.NewTransaction(domain.TypeDeposit, 25, "USD").WithAccounts("", "HOOK-1")
+	if err := env.processor.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+
+	select {
+	case r := <-delivered:
+		event := <-bodies
+		if r.Header.Get(service.WebhookSchemaVersionHeader) != "1.0" || r.Header.Get(service.WebhookEventHeader) != domain.EventTransactionCompleted {
+			t.Errorf("unexpected webhook headers: %v", r.Header)
+		}
+		if event.Type != domain.EventTransactionCompleted || event.SchemaVersion != "1.0" || event.ID == "" {
+			t.Errorf("unexpected webhook envelope: %+v", event)
+		}
+		data, _ := event.Data.(map[string]interface{})
+		if data["transaction_id"] != tx.ID {
+			t.Errorf("expected event data for %s, got %+v", tx.ID, event.Data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+}
+
+func TestIntegration_WebhookReplay(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+
+	deliveries := make(chan *http.Request, 8)
+	events := make(chan domain.WebhookEvent, 8)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event domain.WebhookEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		deliveries <- r
+		events <- event
+	}))
+	defer receiver.Close()
+
+	partnerRepo := memory.NewPartnerRepository()
+	_ = partnerRepo.Save(ctx, &domain.Partner{
+		ID:       "hooks",
+		Status:   domain.PartnerActive,
+		Webhooks: []domain.WebhookEndpoint{{URL: receiver.URL, Events: []string{domain.EventTransactionCompleted}}},
+	})
+	webhooks := service.NewWebhookService(partnerRepo, service.DefaultWebhookConfig(), env.logger).
+		WithEventLog(memory.NewWebhookEventRepository())
+	webhooks.Start()
+	defer webhooks.Shutdown(ctx)
+	env.processor.WithEventPublisher(webhooks)
+	env.handler.WithWebhooks(webhooks)
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+
+	receive := func() (*http.Request, domain.WebhookEvent) {
+		t.Helper()
+		select {
+		case r := <-deliveries:
+			return r, <-events
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for webhook delivery")
+			return nil, domain.WebhookEvent{}
+		}
+	}
+
+	since := time.Now().Add(-time.Second)
+	_ = env.accRepo.Save(ctx, &domain.Account{ID: "REPLAY-1", UserID: "u", Currency: "USD", Status: domain.AccountActive})
+	if err := env.processor.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeDeposit, 25, "USD").WithAccounts("", "REPLAY-1")); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	r, original := receive()
+	if r.Header.Get(service.WebhookReplayHeader) != "" {
+		t.Errorf("expected live delivery without the replay header, got %v", r.Header)
+	}
+
+	replay := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/webhooks/hooks/replay", strings.NewReader(body)))
+		return w
+	}
+
+	w := replay(`{"event_ids":["` + original.ID + `","missing"]}`)
+	var result service.WebhookReplayResult
+	_ = json.NewDecoder(w.Body).Decode(&result)
+	if w.Code != http.StatusAccepted || result.Queued != 1 || len(result.Missing) != 1 {
+		t.Fatalf("expected one event queued and one missing, got %d: %+v", w.Code, result)
+	}
+	r, replayed := receive()
+	if r.Header.Get(service.WebhookReplayHeader) != "true" || replayed.ID != original.ID {
+		t.Errorf("expected original event redelivered with replay header, got %s %v", replayed.ID, r.Header)
+	}
+
+	w = replay(`{"from":"` + since.Format(time.RFC3339Nano) + `"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected time range replay to be accepted, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, replayed := receive(); replayed.ID != original.ID {
+		t.Errorf("expected time range replay to redeliver %s, got %s", original.ID, replayed.ID)
+	}
+
+	if w := replay(`{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected replay without ids or range to be rejected, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/webhooks/nobody/replay", strings.NewReader(`{"event_ids":["x"]}`)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected unknown subscriber to 404, got %d", w.Code)
+	}
+}
+
+func TestIntegration_ProcessingErrorCodes(t *testing.T) {
+	env := setup(t)
+	mustCreateAccount(t, env, "E1", "USD", 100)
+	mustCreateAccount(t, env, "E2", "USD", 0)
+	_ = env.accRepo.UpdateStatus(context.Background(), "E2", domain.AccountSuspended)
+
+	cases := []struct {
+		name string
+		req  api.CreateTransactionRequest
+		code int
+		err  string
+	}{
+		{"missing account", api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 10, Currency: "USD", ToAccountID: "NOPE"}, http.StatusNotFound, "ACCOUNT_NOT_FOUND"},
+		{"insufficient funds", api.CreateTransactionRequest{Type: domain.TypeWithdrawal, Amount: 500, Currency: "USD", FromAccountID: "E1"}, http.StatusUnprocessableEntity, "INSUFFICIENT_FUNDS"},
+		{"inactive account", api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 10, Currency: "USD", ToAccountID: "E2"}, http.StatusUnprocessableEntity, "ACCOUNT_INACTIVE"},
+	}
+	for _, tc := range cases {
+		b, _ := json.Marshal(tc.req)
+		w := httptest.NewRecorder()
+		env.handler.CreateTransactionHandler(w, httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewReader(b)))
+		if w.Code != tc.code || !strings.Contains(w.Body.String(), tc.err) {
+			t.Errorf("%s: expected %d %s, got %d %s", tc.name, tc.code, tc.err, w.Code, w.Body.String())
+		}
+	}
+
+	b, _ := json.Marshal(api.BatchTransactionRequest{Transactions: []api.CreateTransactionRequest{cases[1].req, {Type: domain.TypeDeposit, Amount: 5, Currency: "USD", ToAccountID: "E1"}}})
+	w := httptest.NewRecorder()
+	env.handler.BatchTransactionsHandler(w, httptest.NewRequest("POST", "/api/v1/transactions/batch", bytes.NewReader(b)))
+	var batch api.BatchTransactionResponse
+	_ = json.NewDecoder(w.Body).Decode(&batch)
+	if batch.Failed != 1 || batch.Results[0].Code != "INSUFFICIENT_FUNDS" || batch.Results[1].Code != "" {
+		t.Errorf("expected per-item error codes in batch response, got %+v", batch.Results)
+	}
+}
+
+func TestIntegration_AccountAliases(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+	mustCreateAccount(t, env, "AL1", "USD", 0)
+	mustCreateAccount(t, env, "AL2", "USD", 500)
+	env.handler.WithAliases(service.NewAliasService(memory.NewAccountAliasRepository(), env.accRepo, env.logger))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+
+	register := func(accountID, body string) int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/accounts/"+accountID+"/aliases", bytes.NewBufferString(body)))
+		return w.Code
+	}
+	if code := register("AL1", `{"type":"iban","value":"de89 3704 0044 0532 0130 00"}`); code != http.StatusCreated {
+		t.Fatalf("expected IBAN alias to register, got %d", code)
+	}
+	if code := register("AL2", `{"type":"legacy_id","value":"CORE-000042"}`); code != http.StatusCreated {
+		t.Fatalf("expected legacy alias to register, got %d", code)
+	}
+	if code := register("AL2", `{"type":"iban","value":"DE89370400440532013000"}`); code != http.StatusConflict {
+		t.Errorf("expected alias already owned by another account to conflict, got %d", code)
+	}
+	if code := register("AL2", `{"type":"iban","value":"DE00370400440532013000"}`); code != http.StatusBadRequest {
+		t.Errorf("expected bad IBAN checksum to be rejected, got %d", code)
+	}
+
+	resp, code := callCreateTransaction(t, env, api.CreateTransactionRequest{
+		Type:          domain.TypeTransfer,
+		Amount:        120,
+		Currency:      "USD",
+		FromAccountID: "legacy_id:CORE-000042",
+		ToAccountID:   "iban:DE89370400440532013000",
+	})
+	if code != http.StatusCreated {
+		t.Fatalf("expected transfer by alias to succeed, got %d", code)
+	}
+	tx, _ := env.txRepo.GetByID(ctx, resp.ID)
+	if tx.FromAccountID != "AL2" || tx.ToAccountID != "AL1" {
+		t.Errorf("expected aliases resolved to internal IDs, got %s -> %s", tx.FromAccountID, tx.ToAccountID)
+	}
+	if tx.Metadata[service.MetadataToAccountAlias] != "iban:DE89370400440532013000" {
+		t.Errorf("expected original alias kept in metadata, got %v", tx.Metadata)
+	}
+	if acc, _ := env.accRepo.GetByID(ctx, "AL1"); acc.Balance != 120 {
+		t.Errorf("expected aliased account credited, got %v", acc.Balance)
+	}
+
+	b, _ := json.Marshal(api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: 10, Currency: "USD", ToAccountID: "card_token:tok_missing"})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewReader(b)))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected unknown alias to 404, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/aliases/legacy_id/CORE-000042", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"account_id":"AL2"`) {
+		t.Errorf("expected alias lookup to resolve AL2, got %d %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/accounts/AL1/aliases/legacy_id/CORE-000042", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected removing another account's alias to 404, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/accounts/AL2/aliases/legacy_id/CORE-000042", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected alias removal, got %d", w.Code)
+	}
+}
+
+func TestIntegration_DelegatedAccessRequiresUserIdentity(t *testing.T) {
+	env := setup(t)
+	env.handler.WithDelegations(service.NewDelegationService(memory.NewDelegationRepository(), env.accRepo, memory.NewAuditRepository(), nil))
+	mux := http.NewServeMux()
+	env.handler.RegisterRoutes(mux)
+	mustCreateAccount(t, env, "G1", "USD", 500)
+	statement := func(header, value string) int {
+		r := httptest.NewRequest("GET", "/api/v1/accounts/G1/statement", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := statement("", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a user, got %d", code)
+	}
+	if code := statement("X-User-ID", "user-G1"); code == http.StatusUnauthorized || code == http.StatusForbidden {
+		t.Errorf("expected owner to read the statement, got %d", code)
+	}
+	if _, code := callCreateTransaction(t, env, api.CreateTransactionRequest{Type: domain.TypeWithdrawal, Amount: 10, Currency: "USD", FromAccountID: "G1"}); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a debit without a user, got %d", code)
+	}
+
+	env.handler.WithUserAuthenticator(api.UserTokens(map[string]string{"g1-token": "user-G1"}))
+	if code := statement("X-User-ID", "user-G1"); code != http.StatusUnauthorized {
+		t.Errorf("expected asserted header to be ignored once users authenticate, got %d", code)
+	}
+	if code := statement("Authorization", "Bearer g1-token"); code == http.StatusUnauthorized || code == http.StatusForbidden {
+		t.Errorf("expected authenticated owner to read the statement, got %d", code)
+	}
+}
+
+func TestIntegration_IdempotencyKeyBoundToRequest(t *testing.T) {
+	env := setup(t)
+	mustCreateAccount(t, env, "K1", "USD", 0)
+	post := func(amount float64) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(api.CreateTransactionRequest{Type: domain.TypeDeposit, Amount: amount, Currency: "USD", ToAccountID: "K1"})
+		r := httptest.NewRequest("POST", "/api/v1/transactions", bytes.NewReader(b))
+		r.Header.Set("Idempotency-Key", "deposit-1")
+		w := httptest.NewRecorder()
+		env.handler.CreateTransactionHandler(w, r)
+		return w
+	}
+
+	first, replay, changed := post(100), post(100), post(150)
+
+	if first.Code != http.StatusCreated || replay.Code != http.StatusCreated || replay.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected 201 then a replayed 201, got %d / %d", first.Code, replay.Code)
+	}
+	if changed.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a key reused with a different body, got %d", changed.Code)
+	}
+	if acc, _ := env.accRepo.GetByID(context.Background(), "K1"); acc.Balance != 100 {
+		t.Errorf("expected a single deposit of 100, got %f", acc.Balance)
+	}
+}
@@ -0,0 +1,177 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	AdmissionSignalWorkerLoad        = "worker_load"
+	AdmissionSignalQueueDepth        = "queue_depth"
+	AdmissionSignalEventBacklog      = "event_backlog"
+	AdmissionSignalRepositoryLatency = "repository_latency"
+)
+
+var ErrOverCapacity = errors.New("processor is over capacity")
+
+// AdmissionConfig bounds the saturation signals; a zero limit disables a signal.
+type AdmissionConfig struct {
+	// MaxWorkerLoad is running plus queued jobs per worker.
+	MaxWorkerLoad        float64
+	MaxQueueDepth        int
+	MaxEventBacklog      int
+	MaxRepositoryLatency time.Duration
+	// LatencySmoothing weights the newest sample in the latency moving average.
+	LatencySmoothing float64
+	RetryAfter       time.Duration
+}
+
+func DefaultAdmissionConfig() AdmissionConfig {
+	return AdmissionConfig{
+		MaxWorkerLoad:        4,
+		MaxQueueDepth:        5000,
+		MaxEventBacklog:      900,
+		MaxRepositoryLatency: time.Second,
+		LatencySmoothing:     0.2,
+		RetryAfter:           5 * time.Second,
+	}
+}
+
+type OverCapacityError struct {
+	Signal     string
+	Value      float64
+	Limit      float64
+	RetryAfter time.Duration
+}
+
+func (e *OverCapacityError) Error() string {
+	return fmt.Sprintf("%v: %s at %g exceeds %g", ErrOverCapacity, e.Signal, e.Value, e.Limit)
+}
+
+func (e *OverCapacityError) Unwrap() error {
+	return ErrOverCapacity
+}
+
+type AdmissionStatus struct {
+	Admitting         bool             `json:"admitting"`
+	Signal            string           `json:"signal,omitempty"`
+	WorkerLoad        float64          `json:"worker_load"`
+	QueueDepth        int              `json:"queue_depth"`
+	EventBacklog      int              `json:"event_backlog"`
+	RepositoryLatency time.Duration    `json:"repository_latency_ns"`
+	Rejected          map[string]int64 `json:"rejected"`
+}
+
+type admissionControl struct {
+	cfg AdmissionConfig
+
+	mu       sync.Mutex
+	latency  time.Duration
+	rejected map[string]int64
+}
+
+func (p *TransactionProcessor) WithAdmissionControl(cfg AdmissionConfig) *TransactionProcessor {
+	if cfg.LatencySmoothing <= 0 || cfg.LatencySmoothing > 1 {
+		cfg.LatencySmoothing = DefaultAdmissionConfig().LatencySmoothing
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = DefaultAdmissionConfig().RetryAfter
+	}
+	p.admission = &admissionControl{cfg: cfg, rejected: make(map[string]int64)}
+	return p
+}
+
+// Admit returns an *OverCapacityError when a saturation signal is exceeded.
+func (p *TransactionProcessor) Admit() error {
+	if p.admission == nil {
+		return nil
+	}
+	status := p.admissionSignals()
+	err := p.admission.check(status)
+	if err != nil {
+		p.admission.mu.Lock()
+		p.admission.rejected[err.Signal]++
+		p.admission.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (p *TransactionProcessor) AdmissionStatus() (AdmissionStatus, bool) {
+	if p.admission == nil {
+		return AdmissionStatus{}, false
+	}
+	status := p.admissionSignals()
+	status.Admitting = true
+	if err := p.admission.check(status); err != nil {
+		status.Admitting = false
+		status.Signal = err.Signal
+	}
+
+	p.admission.mu.Lock()
+	defer p.admission.mu.Unlock()
+	status.Rejected = make(map[string]int64, len(p.admission.rejected))
+	for signal, count := range p.admission.rejected {
+		status.Rejected[signal] = count
+	}
+	return status, true
+}
+
+func (p *TransactionProcessor) admissionSignals() AdmissionStatus {
+	stats := p.workerPool.Stats()
+	busy, workers, queued := stats.Busy, stats.Workers, 0
+	for _, depth := range stats.Queued {
+		queued += depth
+	}
+	if p.shards != nil {
+		shardStats := p.shards.Stats()
+		busy += shardStats.Busy
+		workers += len(shardStats.Shards)
+		queued += shardStats.Queued
+	}
+
+	status := AdmissionStatus{
+		QueueDepth:   queued,
+		EventBacklog: len(p.eventCh),
+	}
+	if workers > 0 {
+		status.WorkerLoad = float64(busy+queued) / float64(workers)
+	}
+	p.admission.mu.Lock()
+	status.RepositoryLatency = p.admission.latency
+	p.admission.mu.Unlock()
+	return status
+}
+
+func (a *admissionControl) check(status AdmissionStatus) *OverCapacityError {
+	reject := func(signal string, value, limit float64) *OverCapacityError {
+		return &OverCapacityError{Signal: signal, Value: value, Limit: limit, RetryAfter: a.cfg.RetryAfter}
+	}
+	switch {
+	case a.cfg.MaxWorkerLoad > 0 && status.WorkerLoad > a.cfg.MaxWorkerLoad:
+		return reject(AdmissionSignalWorkerLoad, status.WorkerLoad, a.cfg.MaxWorkerLoad)
+	case a.cfg.MaxQueueDepth > 0 && status.QueueDepth > a.cfg.MaxQueueDepth:
+		return reject(AdmissionSignalQueueDepth, float64(status.QueueDepth), float64(a.cfg.MaxQueueDepth))
+	case a.cfg.MaxEventBacklog > 0 && status.EventBacklog > a.cfg.MaxEventBacklog:
+		return reject(AdmissionSignalEventBacklog, float64(status.EventBacklog), float64(a.cfg.MaxEventBacklog))
+	case a.cfg.MaxRepositoryLatency > 0 && status.RepositoryLatency > a.cfg.MaxRepositoryLatency:
+		return reject(AdmissionSignalRepositoryLatency, status.RepositoryLatency.Seconds(), a.cfg.MaxRepositoryLatency.Seconds())
+	}
+	return nil
+}
+
+func (p *TransactionProcessor) observeRepositoryLatency(latency time.Duration) {
+	if p.admission == nil {
+		return
+	}
+	a := p.admission
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.latency == 0 {
+		a.latency = latency
+		return
+	}
+	a.latency += time.Duration(a.cfg.LatencySmoothing * float64(latency-a.latency))
+}
@@ -0,0 +1,73 @@
+package processor
+
+import (
+	"finance_manager/internal/domain"
+	"math"
+)
+
+const (
+	FlagRoundAmount        = "round_amount"
+	FlagThresholdAvoidance = "threshold_avoidance"
+)
+
+type AmountHeuristicsConfig struct {
+	RoundUnit           float64
+	MinRoundAmount      float64
+	RoundWeight         int
+	ReportingThresholds []float64
+	ThresholdMargin     float64
+	ThresholdWeight     int
+}
+
+func DefaultAmountHeuristicsConfig() AmountHeuristicsConfig {
+	return AmountHeuristicsConfig{
+		RoundUnit:           1000,
+		MinRoundAmount:      5000,
+		RoundWeight:         10,
+		ReportingThresholds: []float64{10000},
+		ThresholdMargin:     0.02,
+		ThresholdWeight:     20,
+	}
+}
+
+// WithAmountHeuristics adds the round-amount and threshold-avoidance patterns.
+// They are opt-in: NewTransactionProcessor leaves them off so existing risk
+// scores don't shift.
+func (fd *FraudDetector) WithAmountHeuristics(cfg AmountHeuristicsConfig) *FraudDetector {
+	if cfg.RoundUnit > 0 && cfg.RoundWeight > 0 {
+		fd.patterns = append(fd.patterns, FraudPattern{
+			Name:        FlagRoundAmount,
+			Description: "Suspiciously round transaction amount",
+			Detect: func(tx *domain.Transaction) (bool, string) {
+				return isRoundAmount(tx.Amount, cfg.RoundUnit, cfg.MinRoundAmount), FlagRoundAmount
+			},
+			Weight: cfg.RoundWeight,
+		})
+	}
+
+	if len(cfg.ReportingThresholds) > 0 && cfg.ThresholdMargin > 0 && cfg.ThresholdWeight > 0 {
+		fd.patterns = append(fd.patterns, FraudPattern{
+			Name:        FlagThresholdAvoidance,
+			Description: "Amount just under a reporting threshold",
+			Detect: func(tx *domain.Transaction) (bool, string) {
+				for _, threshold := range cfg.ReportingThresholds {
+					if tx.Amount < threshold && tx.Amount >= threshold*(1-cfg.ThresholdMargin) {
+						return true, FlagThresholdAvoidance
+					}
+				}
+				return false, ""
+			},
+			Weight: cfg.ThresholdWeight,
+		})
+	}
+
+	return fd
+}
+
+func isRoundAmount(amount, unit, minAmount float64) bool {
+	if amount < minAmount || amount < unit {
+		return false
+	}
+	remainder := math.Mod(amount, unit)
+	return remainder < 0.005 || unit-remainder < 0.005
+}
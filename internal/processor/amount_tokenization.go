@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"finance_manager/pkg/money"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type AmountSink string
+
+const (
+	AmountSinkLogs     AmountSink = "logs"
+	AmountSinkEvents   AmountSink = "events"
+	AmountSinkWebhooks AmountSink = "webhooks"
+)
+
+type AmountTokenizationConfig struct {
+	Buckets money.AmountBuckets
+	Sinks   []AmountSink
+}
+
+func DefaultAmountTokenizationConfig() AmountTokenizationConfig {
+	return AmountTokenizationConfig{Buckets: money.DefaultAmountBuckets()}
+}
+
+func ParseAmountSinks(spec string) ([]AmountSink, error) {
+	var sinks []AmountSink
+	for _, part := range strings.Split(spec, ",") {
+		sink := AmountSink(strings.ToLower(strings.TrimSpace(part)))
+		switch sink {
+		case "":
+			continue
+		case AmountSinkLogs, AmountSinkEvents, AmountSinkWebhooks:
+			sinks = append(sinks, sink)
+		default:
+			return nil, fmt.Errorf("unknown amount sink %q", part)
+		}
+	}
+	return sinks, nil
+}
+
+type amountTokenization struct {
+	buckets money.AmountBuckets
+	sinks   map[AmountSink]bool
+}
+
+func (p *TransactionProcessor) WithAmountTokenization(cfg AmountTokenizationConfig) *TransactionProcessor {
+	if len(cfg.Buckets) == 0 {
+		cfg.Buckets = money.DefaultAmountBuckets()
+	}
+	tokens := &amountTokenization{buckets: cfg.Buckets, sinks: make(map[AmountSink]bool, len(cfg.Sinks))}
+	for _, sink := range cfg.Sinks {
+		tokens.sinks[sink] = true
+	}
+	p.amountTokens = tokens
+	return p
+}
+
+func (p *TransactionProcessor) TokenizesAmounts(sink AmountSink) bool {
+	return p.amountTokens != nil && p.amountTokens.sinks[sink]
+}
+
+func (p *TransactionProcessor) AmountFor(sink AmountSink, amount float64) interface{} {
+	if !p.TokenizesAmounts(sink) {
+		return amount
+	}
+	return p.amountTokens.buckets.Bucket(amount)
+}
+
+func (p *TransactionProcessor) logAmount(key string, amount float64) slog.Attr {
+	if !p.TokenizesAmounts(AmountSinkLogs) {
+		return slog.Float64(key, amount)
+	}
+	return slog.String(key, p.amountTokens.buckets.Bucket(amount))
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository/memory"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newBenchmarkProcessor(b *testing.B, accounts int) *TransactionProcessor {
+	b.Helper()
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	for i := 0; i < accounts; i++ {
+		acc := &domain.Account{ID: fmt.Sprintf("acc-%d", i), UserID: fmt.Sprintf("user-%d", i), Currency: "USD", Balance: 1e12, Status: domain.AccountActive}
+		if err := accRepo.Save(ctx, acc); err != nil {
+			b.Fatalf("save account: %v", err)
+		}
+	}
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 4)
+	p.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	return p
+}
+
+func BenchmarkProcessTransaction(b *testing.B) {
+	ctx := context.Background()
+
+	b.Run("deposit", func(b *testing.B) {
+		p := newBenchmarkProcessor(b, 1)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tx := domain.NewTransaction(domain.TypeDeposit, 100, "USD")
+			tx.ToAccountID = "acc-0"
+			if err := p.ProcessTransaction(ctx, tx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("transfer_parallel", func(b *testing.B) {
+		const accounts = 64
+		p := newBenchmarkProcessor(b, accounts)
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				tx := domain.NewTransaction(domain.TypeTransfer, 1, "USD")
+				tx.FromAccountID = fmt.Sprintf("acc-%d", i%accounts)
+				tx.ToAccountID = fmt.Sprintf("acc-%d", (i+1)%accounts)
+				if err := p.ProcessTransaction(ctx, tx); err != nil {
+					b.Fatal(err)
+				}
+				i++
+			}
+		})
+	})
+}
+
+func BenchmarkRuleEngine_Evaluate1kRules(b *testing.B) {
+	ctx := context.Background()
+	ruleRepo := memory.NewRuleRepository()
+	for i := 0; i < 1000; i++ {
+		_ = ruleRepo.Save(ctx, &domain.Rule{
+			ID:        fmt.Sprintf("r%d", i),
+			Name:      fmt.Sprintf("amount_over_%d", i),
+			Type:      domain.RuleTypeFraud,
+			IsActive:  true,
+			Priority:  i % 100,
+			Condition: fmt.Sprintf(`{"field":"amount","operator":">","value":%d}`, i*10),
+			Action:    `{"type":"flag_transaction","params":{"reason":"bench"}}`,
+		})
+	}
+	engine := NewRuleEngine(ruleRepo, slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	tx := &domain.Transaction{ID: "tx1", Type: domain.TypeTransfer, Amount: 5000, Currency: "USD"}
+
+	if _, err := engine.EvaluateRules(ctx, tx); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.EvaluateRules(ctx, tx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
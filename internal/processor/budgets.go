@@ -0,0 +1,134 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	ActionWarnBudgetExceeded = "warn_budget_exceeded"
+
+	MetadataCategory       = "category"
+	MetadataBudgetCheck    = "budget_check"
+	MetadataBudgetExceeded = "budget_exceeded"
+	MetadataBudgetID       = "budget_id"
+	MetadataBudgetSpent    = "budget_spent"
+)
+
+type BudgetWarning struct {
+	BudgetID      string              `json:"budget_id"`
+	AccountID     string              `json:"account_id"`
+	TransactionID string              `json:"transaction_id"`
+	Category      string              `json:"category"`
+	Limit         float64             `json:"limit"`
+	Spent         float64             `json:"spent"`
+	Period        domain.BudgetPeriod `json:"period"`
+	PeriodStart   time.Time           `json:"period_start"`
+}
+
+type BudgetObserver interface {
+	BudgetExceeded(ctx context.Context, warning BudgetWarning)
+}
+
+type budgetEnforcement struct {
+	repo     repository.BudgetRepository
+	observer BudgetObserver
+}
+
+func (p *TransactionProcessor) WithBudgets(repo repository.BudgetRepository, observer BudgetObserver) *TransactionProcessor {
+	p.spending = &budgetEnforcement{repo: repo, observer: observer}
+	return p
+}
+
+func (p *TransactionProcessor) BudgetSpent(ctx context.Context, budget *domain.Budget, at time.Time) (float64, error) {
+	filter := repository.TransactionFilter{
+		AccountID: budget.AccountID,
+		Status:    domain.StatusCompleted,
+		From:      budget.PeriodStart(at),
+	}
+
+	var spent float64
+	err := p.txRepo.Iterate(ctx, filter, func(tx *domain.Transaction) error {
+		if tx.FromAccountID == budget.AccountID && tx.Metadata[MetadataCategory] == budget.Category {
+			spent += tx.Amount
+		}
+		return nil
+	})
+	return spent, err
+}
+
+func (p *TransactionProcessor) checkBudget(ctx context.Context, tx *domain.Transaction) *BudgetWarning {
+	if p.spending == nil || tx.Metadata[MetadataBudgetCheck] != "warn" || tx.FromAccountID == "" {
+		return nil
+	}
+	category := tx.Metadata[MetadataCategory]
+	if category == "" {
+		return nil
+	}
+
+	budget, err := p.spending.repo.GetByCategory(ctx, tx.FromAccountID, category)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			p.logger.WarnContext(ctx, "Failed to load budget",
+				slog.String("transaction_id", tx.ID),
+				slog.String("category", category),
+				slog.String("error", err.Error()))
+		}
+		return nil
+	}
+
+	previous, err := p.BudgetSpent(ctx, budget, tx.CreatedAt)
+	if err != nil {
+		p.logger.WarnContext(ctx, "Failed to compute budget spending",
+			slog.String("transaction_id", tx.ID),
+			slog.String("budget_id", budget.ID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	spent := previous + tx.Amount
+	if spent <= budget.Limit {
+		return nil
+	}
+
+	tx.AddMetadata(MetadataBudgetExceeded, "true")
+	tx.AddMetadata(MetadataBudgetID, budget.ID)
+	tx.AddMetadata(MetadataBudgetSpent, strconv.FormatFloat(spent, 'f', 2, 64))
+	if previous > budget.Limit {
+		return nil
+	}
+
+	return &BudgetWarning{
+		BudgetID:      budget.ID,
+		AccountID:     budget.AccountID,
+		TransactionID: tx.ID,
+		Category:      category,
+		Limit:         budget.Limit,
+		Spent:         spent,
+		Period:        budget.Period,
+		PeriodStart:   budget.PeriodStart(tx.CreatedAt),
+	}
+}
+
+func (p *TransactionProcessor) warnBudgetExceeded(ctx context.Context, warning *BudgetWarning) {
+	if warning == nil {
+		return
+	}
+	p.logger.InfoContext(ctx, "Budget exceeded",
+		slog.String("transaction_id", warning.TransactionID),
+		slog.String("budget_id", warning.BudgetID),
+		p.logAmount("spent", warning.Spent),
+		slog.Float64("limit", warning.Limit))
+	if p.spending.observer != nil {
+		p.spending.observer.BudgetExceeded(ctx, *warning)
+	}
+}
+
+func (e *RuleEngine) handleBudgetWarningAction(ctx context.Context, action RuleAction, tx *domain.Transaction) error {
+	tx.AddMetadata(MetadataBudgetCheck, "warn")
+	return nil
+}
@@ -0,0 +1,124 @@
+package processor
+
+import (
+	"finance_manager/internal/domain"
+	"sync"
+)
+
+const FieldMerchantChargebackRate = "merchant_chargeback_rate"
+
+type ChargebackObserver interface {
+	ObserveChargebackRate(merchantID string, rate float64)
+}
+
+type MerchantChargebackStats struct {
+	MerchantID       string  `json:"merchant_id"`
+	Payments         int     `json:"payments"`
+	PaymentVolume    float64 `json:"payment_volume"`
+	Chargebacks      int     `json:"chargebacks"`
+	ChargebackVolume float64 `json:"chargeback_volume"`
+	Won              int     `json:"won"`
+	Lost             int     `json:"lost"`
+	Rate             float64 `json:"chargeback_rate"`
+}
+
+type ChargebackTracker struct {
+	mu        sync.RWMutex
+	merchants map[string]*MerchantChargebackStats
+	observer  ChargebackObserver
+}
+
+func NewChargebackTracker() *ChargebackTracker {
+	return &ChargebackTracker{
+		merchants: make(map[string]*MerchantChargebackStats),
+	}
+}
+
+func (t *ChargebackTracker) SetObserver(observer ChargebackObserver) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.observer = observer
+}
+
+func (t *ChargebackTracker) ObservePayment(tx *domain.Transaction) {
+	if tx.Type != domain.TypeTransfer || tx.ToAccountID == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.merchantLocked(tx.ToAccountID)
+	stats.Payments++
+	stats.PaymentVolume += tx.Amount
+	t.refreshLocked(stats)
+}
+
+func (t *ChargebackTracker) RecordChargeback(chargeback *domain.Chargeback) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.merchantLocked(chargeback.MerchantAccountID)
+	stats.Chargebacks++
+	stats.ChargebackVolume += chargeback.Amount
+	t.refreshLocked(stats)
+}
+
+func (t *ChargebackTracker) RecordOutcome(chargeback *domain.Chargeback) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.merchantLocked(chargeback.MerchantAccountID)
+	switch chargeback.Status {
+	case domain.ChargebackWon:
+		stats.Won++
+	case domain.ChargebackLost:
+		stats.Lost++
+	}
+}
+
+func (t *ChargebackTracker) Rate(merchantID string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if stats, exists := t.merchants[merchantID]; exists {
+		return stats.Rate
+	}
+	return 0
+}
+
+func (t *ChargebackTracker) Stats(merchantID string) MerchantChargebackStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if stats, exists := t.merchants[merchantID]; exists {
+		return *stats
+	}
+	return MerchantChargebackStats{MerchantID: merchantID}
+}
+
+func (t *ChargebackTracker) merchantLocked(merchantID string) *MerchantChargebackStats {
+	stats, exists := t.merchants[merchantID]
+	if !exists {
+		stats = &MerchantChargebackStats{MerchantID: merchantID}
+		t.merchants[merchantID] = stats
+	}
+	return stats
+}
+
+func (t *ChargebackTracker) refreshLocked(stats *MerchantChargebackStats) {
+	if stats.Payments > 0 {
+		stats.Rate = float64(stats.Chargebacks) / float64(stats.Payments)
+	}
+	if t.observer != nil {
+		t.observer.ObserveChargebackRate(stats.MerchantID, stats.Rate)
+	}
+}
+
+func (p *TransactionProcessor) WithChargebackTracking(tracker *ChargebackTracker) *TransactionProcessor {
+	p.chargebacks = tracker
+	p.ruleEngine.WithNumericField(FieldMerchantChargebackRate, func(tx *domain.Transaction) float64 {
+		return tracker.Rate(tx.ToAccountID)
+	})
+	return p
+}
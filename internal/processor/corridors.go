@@ -0,0 +1,244 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	MetadataCorridor   = "corridor"
+	PostingCorridorFee = "corridor_fee"
+)
+
+var (
+	ErrCorridorNotSupported = errors.New("corridor not supported")
+	ErrInvalidCorridor      = errors.New("invalid corridor")
+)
+
+type Corridor struct {
+	ID                  string     `json:"id"`
+	SourceCurrency      string     `json:"source_currency,omitempty"`
+	SourceCountry       string     `json:"source_country,omitempty"`
+	DestinationCurrency string     `json:"destination_currency,omitempty"`
+	DestinationCountry  string     `json:"destination_country,omitempty"`
+	MaxAmount           float64    `json:"max_amount,omitempty"`
+	DailyLimit          float64    `json:"daily_limit,omitempty"`
+	Fee                 domain.Fee `json:"fee"`
+	RequirePurposeCode  bool       `json:"require_purpose_code,omitempty"`
+	PurposeCodes        []string   `json:"purpose_codes,omitempty"`
+	RequireMemo         bool       `json:"require_memo,omitempty"`
+	MaxMemoLength       int        `json:"max_memo_length,omitempty"`
+}
+
+func (c Corridor) matches(from, to *domain.Account) bool {
+	return corridorField(c.SourceCurrency, from.Currency) &&
+		corridorField(c.SourceCountry, from.Country) &&
+		corridorField(c.DestinationCurrency, to.Currency) &&
+		corridorField(c.DestinationCountry, to.Country)
+}
+
+func corridorField(configured, actual string) bool {
+	return configured == "" || configured == "*" || strings.EqualFold(configured, actual)
+}
+
+func ParseCorridors(spec string) ([]Corridor, error) {
+	var corridors []Corridor
+	if err := json.Unmarshal([]byte(spec), &corridors); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCorridor, err)
+	}
+
+	seen := make(map[string]bool, len(corridors))
+	for _, corridor := range corridors {
+		if corridor.ID == "" {
+			return nil, fmt.Errorf("%w: id is required", ErrInvalidCorridor)
+		}
+		if seen[corridor.ID] {
+			return nil, fmt.Errorf("%w: duplicate id %s", ErrInvalidCorridor, corridor.ID)
+		}
+		seen[corridor.ID] = true
+		if corridor.MaxAmount < 0 || corridor.DailyLimit < 0 || corridor.Fee.Fixed < 0 || corridor.Fee.Percent < 0 {
+			return nil, fmt.Errorf("%w: %s has negative limits or fees", ErrInvalidCorridor, corridor.ID)
+		}
+		if corridor.MaxMemoLength < 0 {
+			return nil, fmt.Errorf("%w: %s has a negative memo length", ErrInvalidCorridor, corridor.ID)
+		}
+	}
+	return corridors, nil
+}
+
+func (p *TransactionProcessor) WithCorridors(corridors []Corridor) *TransactionProcessor {
+	p.corridors = corridors
+	return p
+}
+
+func (p *TransactionProcessor) Corridors() ([]Corridor, bool) {
+	if p.corridors == nil {
+		return nil, false
+	}
+	return p.corridors, true
+}
+
+func (p *TransactionProcessor) matchCorridor(from, to *domain.Account) (Corridor, bool) {
+	for _, corridor := range p.corridors {
+		if corridor.matches(from, to) {
+			return corridor, true
+		}
+	}
+	return Corridor{}, false
+}
+
+func (p *TransactionProcessor) checkCorridor(ctx context.Context, from, to *domain.Account, tx *domain.Transaction) error {
+	if p.corridors == nil || tx.SystemPosting != "" {
+		return nil
+	}
+
+	corridor, ok := p.matchCorridor(from, to)
+	if !ok {
+		return fmt.Errorf("%w: %s/%s to %s/%s", ErrCorridorNotSupported,
+			from.Currency, from.Country, to.Currency, to.Country)
+	}
+	tx.AddMetadata(MetadataCorridor, corridor.ID)
+
+	return p.checkCorridorLimits(ctx, from, corridor, tx, tx.Amount, 0)
+}
+
+func (p *TransactionProcessor) checkLegCorridors(ctx context.Context, from *domain.Account, legs []*domain.Account, tx *domain.Transaction) error {
+	if p.corridors == nil || tx.SystemPosting != "" {
+		return nil
+	}
+
+	pending := make(map[string]float64)
+	for i, to := range legs {
+		corridor, ok := p.matchCorridor(from, to)
+		if !ok {
+			return fmt.Errorf("leg %d %w: %s/%s to %s/%s", i, ErrCorridorNotSupported,
+				from.Currency, from.Country, to.Currency, to.Country)
+		}
+		tx.Legs[i].Corridor = corridor.ID
+
+		amount := tx.Legs[i].Amount
+		if err := p.checkCorridorLimits(ctx, from, corridor, tx, amount, pending[corridor.ID]); err != nil {
+			return fmt.Errorf("leg %d %w", i, err)
+		}
+		pending[corridor.ID] += amount
+	}
+	return nil
+}
+
+func (p *TransactionProcessor) checkCorridorLimits(ctx context.Context, from *domain.Account, corridor Corridor, tx *domain.Transaction, amount, pending float64) error {
+	if corridor.MaxAmount > 0 && !tx.AddLimitCheck("corridor_max_amount", corridor.MaxAmount, amount) {
+		return fmt.Errorf("corridor %s %w: %.2f/%.2f", corridor.ID, ErrLimitExceeded, amount, corridor.MaxAmount)
+	}
+
+	if corridor.DailyLimit > 0 {
+		volume, err := p.corridorDailyVolume(ctx, from.ID, corridor.ID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to get corridor volume: %w", err)
+		}
+		volume += pending + amount
+		if !tx.AddLimitCheck("corridor_daily_limit", corridor.DailyLimit, volume) {
+			return fmt.Errorf("corridor %s daily %w: %.2f/%.2f", corridor.ID, ErrLimitExceeded, volume, corridor.DailyLimit)
+		}
+	}
+
+	return nil
+}
+
+func (p *TransactionProcessor) corridorDailyVolume(ctx context.Context, accountID, corridorID string, date time.Time) (float64, error) {
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	filter := repository.TransactionFilter{
+		AccountID: accountID,
+		Type:      domain.TypeTransfer,
+		Status:    domain.StatusCompleted,
+		From:      startOfDay,
+		To:        startOfDay.Add(24 * time.Hour),
+	}
+
+	var volume float64
+	err := p.txRepo.Iterate(ctx, filter, func(tx *domain.Transaction) error {
+		if tx.FromAccountID != accountID {
+			return nil
+		}
+		if tx.Metadata[MetadataCorridor] == corridorID {
+			volume += tx.Amount
+		}
+		for _, leg := range tx.Legs {
+			if leg.Corridor == corridorID {
+				volume += leg.Amount
+			}
+		}
+		return nil
+	})
+	return volume, err
+}
+
+func (p *TransactionProcessor) corridorByID(id string) (Corridor, bool) {
+	if id == "" {
+		return Corridor{}, false
+	}
+	for _, corridor := range p.corridors {
+		if corridor.ID == id {
+			return corridor, true
+		}
+	}
+	return Corridor{}, false
+}
+
+func (p *TransactionProcessor) corridorFees(tx *domain.Transaction) map[string]float64 {
+	fees := make(map[string]float64)
+	add := func(corridorID string, amount float64) {
+		if corridor, ok := p.corridorByID(corridorID); ok {
+			fees[corridorID] += ledgerRounding.Round(corridor.Fee.Amount(amount), tx.Currency)
+		}
+	}
+	add(tx.Metadata[MetadataCorridor], tx.Amount)
+	for _, leg := range tx.Legs {
+		add(leg.Corridor, leg.Amount)
+	}
+	return fees
+}
+
+func (p *TransactionProcessor) chargeCorridorFee(ctx context.Context, tx *domain.Transaction) {
+	if tx.SystemPosting != "" {
+		return
+	}
+
+	fees := p.corridorFees(tx)
+	for _, corridorID := range slices.Sorted(maps.Keys(fees)) {
+		fee := fees[corridorID]
+		if fee <= 0 {
+			continue
+		}
+
+		feesAccount, err := p.SystemAccount(domain.SystemRoleFees, tx.Currency)
+		if err != nil {
+			p.logger.WarnContext(ctx, "Corridor fee not charged, no fee account for currency",
+				slog.String("transaction_id", tx.ID),
+				slog.String("corridor", corridorID),
+				slog.String("currency", tx.Currency))
+			return
+		}
+
+		feeTx := domain.NewTransaction(domain.TypeTransfer, fee, tx.Currency).
+			WithAccounts(tx.FromAccountID, feesAccount).
+			WithDescription(fmt.Sprintf("corridor %s fee", corridorID))
+		feeTx.AddMetadata(MetadataLinkedTransaction, tx.ID)
+		if err := p.PostSystemTransaction(ctx, feeTx, PostingCorridorFee); err != nil {
+			p.logger.ErrorContext(ctx, "Failed to charge corridor fee",
+				slog.String("transaction_id", tx.ID),
+				slog.String("corridor", corridorID),
+				p.logAmount("fee", fee),
+				slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	GraphAnalysisJobName = "counterparty_graph_analysis"
+
+	FlagPassThrough       = "pass_through"
+	FlagDenseCluster      = "dense_cluster"
+	FlagRiskyCounterparty = "risky_counterparty"
+)
+
+type TransferEdge struct {
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Count  int       `json:"count"`
+	Volume float64   `json:"volume"`
+	LastAt time.Time `json:"last_at"`
+}
+
+type AccountRiskFlag struct {
+	AccountID  string    `json:"account_id"`
+	Flag       string    `json:"flag"`
+	Score      float64   `json:"score"`
+	Details    string    `json:"details"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+type GraphAnalysisConfig struct {
+	Retention           time.Duration
+	PassThroughWindow   time.Duration
+	PassThroughRatio    float64
+	MinClusterDegree    int
+	MinClusterDensity   float64
+	AnalysisInterval    time.Duration
+	FlaggedRiskCategory string
+}
+
+func DefaultGraphAnalysisConfig() GraphAnalysisConfig {
+	return GraphAnalysisConfig{
+		Retention:           7 * 24 * time.Hour,
+		PassThroughWindow:   10 * time.Minute,
+		PassThroughRatio:    0.8,
+		MinClusterDegree:    3,
+		MinClusterDensity:   0.5,
+		AnalysisInterval:    15 * time.Minute,
+		FlaggedRiskCategory: "high",
+	}
+}
+
+type flowEvent struct {
+	counterparty string
+	amount       float64
+	at           time.Time
+}
+
+type TransferGraph struct {
+	mu       sync.RWMutex
+	cfg      GraphAnalysisConfig
+	edges    map[string]map[string]*TransferEdge
+	inbound  map[string][]flowEvent
+	outbound map[string][]flowEvent
+	flags    map[string][]AccountRiskFlag
+}
+
+func NewTransferGraph(cfg GraphAnalysisConfig) *TransferGraph {
+	return &TransferGraph{
+		cfg:      cfg,
+		edges:    make(map[string]map[string]*TransferEdge),
+		inbound:  make(map[string][]flowEvent),
+		outbound: make(map[string][]flowEvent),
+		flags:    make(map[string][]AccountRiskFlag),
+	}
+}
+
+func (g *TransferGraph) AddTransfer(tx *domain.Transaction) {
+	if tx.Type != domain.TypeTransfer || tx.FromAccountID == "" || tx.ToAccountID == "" {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	at := transactionTime(tx)
+
+	targets, exists := g.edges[tx.FromAccountID]
+	if !exists {
+		targets = make(map[string]*TransferEdge)
+		g.edges[tx.FromAccountID] = targets
+	}
+	edge, exists := targets[tx.ToAccountID]
+	if !exists {
+		edge = &TransferEdge{From: tx.FromAccountID, To: tx.ToAccountID}
+		targets[tx.ToAccountID] = edge
+	}
+	edge.Count++
+	edge.Volume += tx.Amount
+	edge.LastAt = at
+
+	g.outbound[tx.FromAccountID] = append(g.outbound[tx.FromAccountID], flowEvent{counterparty: tx.ToAccountID, amount: tx.Amount, at: at})
+	g.inbound[tx.ToAccountID] = append(g.inbound[tx.ToAccountID], flowEvent{counterparty: tx.FromAccountID, amount: tx.Amount, at: at})
+}
+
+func (g *TransferGraph) Edges(accountID string) []TransferEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var result []TransferEdge
+	for _, edge := range g.edges[accountID] {
+		result = append(result, *edge)
+	}
+	for from, targets := range g.edges {
+		if edge, exists := targets[accountID]; exists && from != accountID {
+			result = append(result, *edge)
+		}
+	}
+	return result
+}
+
+func (g *TransferGraph) Flags(accountID string) []AccountRiskFlag {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]AccountRiskFlag(nil), g.flags[accountID]...)
+}
+
+func (g *TransferGraph) IsFlagged(accountID string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.flags[accountID]) > 0
+}
+
+func (g *TransferGraph) Analyze(now time.Time) []AccountRiskFlag {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.pruneLocked(now)
+
+	var flags []AccountRiskFlag
+	flags = append(flags, g.detectPassThroughLocked(now)...)
+	flags = append(flags, g.detectDenseClustersLocked(now)...)
+
+	sort.Slice(flags, func(i, j int) bool {
+		if flags[i].AccountID != flags[j].AccountID {
+			return flags[i].AccountID < flags[j].AccountID
+		}
+		return flags[i].Flag < flags[j].Flag
+	})
+
+	g.flags = make(map[string][]AccountRiskFlag)
+	for _, flag := range flags {
+		g.flags[flag.AccountID] = append(g.flags[flag.AccountID], flag)
+	}
+
+	return flags
+}
+
+func (g *TransferGraph) pruneLocked(now time.Time) {
+	cutoff := now.Add(-g.cfg.Retention)
+
+	for from, targets := range g.edges {
+		for to, edge := range targets {
+			if edge.LastAt.Before(cutoff) {
+				delete(targets, to)
+			}
+		}
+		if len(targets) == 0 {
+			delete(g.edges, from)
+		}
+	}
+
+	prune := func(events map[string][]flowEvent) {
+		for accountID, list := range events {
+			kept := list[:0]
+			for _, ev := range list {
+				if !ev.at.Before(cutoff) {
+					kept = append(kept, ev)
+				}
+			}
+			if len(kept) == 0 {
+				delete(events, accountID)
+			} else {
+				events[accountID] = kept
+			}
+		}
+	}
+	prune(g.inbound)
+	prune(g.outbound)
+}
+
+func (g *TransferGraph) detectPassThroughLocked(now time.Time) []AccountRiskFlag {
+	var flags []AccountRiskFlag
+
+	for accountID, inbound := range g.inbound {
+		outbound := g.outbound[accountID]
+		if len(outbound) == 0 {
+			continue
+		}
+
+		for _, in := range inbound {
+			var forwarded float64
+			for _, out := range outbound {
+				if out.counterparty == in.counterparty || out.at.Before(in.at) || out.at.Sub(in.at) > g.cfg.PassThroughWindow {
+					continue
+				}
+				forwarded += out.amount
+			}
+
+			if in.amount > 0 && forwarded >= in.amount*g.cfg.PassThroughRatio {
+				flags = append(flags, AccountRiskFlag{
+					AccountID:  accountID,
+					Flag:       FlagPassThrough,
+					Score:      min(forwarded/in.amount, 1),
+					Details:    fmt.Sprintf("received %.2f from %s and forwarded %.2f within %s", in.amount, in.counterparty, forwarded, g.cfg.PassThroughWindow),
+					DetectedAt: now,
+				})
+				break
+			}
+		}
+	}
+
+	return flags
+}
+
+func (g *TransferGraph) detectDenseClustersLocked(now time.Time) []AccountRiskFlag {
+	neighbors := make(map[string]map[string]bool)
+	link := func(a, b string) {
+		if neighbors[a] == nil {
+			neighbors[a] = make(map[string]bool)
+		}
+		neighbors[a][b] = true
+	}
+	for from, targets := range g.edges {
+		for to := range targets {
+			if from == to {
+				continue
+			}
+			link(from, to)
+			link(to, from)
+		}
+	}
+
+	var flags []AccountRiskFlag
+	for accountID, adjacent := range neighbors {
+		degree := len(adjacent)
+		if degree < g.cfg.MinClusterDegree {
+			continue
+		}
+
+		links := 0
+		for a := range adjacent {
+			for b := range adjacent {
+				if a < b && neighbors[a][b] {
+					links++
+				}
+			}
+		}
+
+		possible := degree * (degree - 1) / 2
+		density := float64(links) / float64(possible)
+		if density >= g.cfg.MinClusterDensity {
+			flags = append(flags, AccountRiskFlag{
+				AccountID:  accountID,
+				Flag:       FlagDenseCluster,
+				Score:      density,
+				Details:    fmt.Sprintf("%d counterparties with %.0f%% interconnection", degree, density*100),
+				DetectedAt: now,
+			})
+		}
+	}
+
+	return flags
+}
+
+type GraphAnalyzer struct {
+	graph       *TransferGraph
+	accountRepo repository.AccountRepository
+	logger      *slog.Logger
+}
+
+func NewGraphAnalyzer(graph *TransferGraph, accountRepo repository.AccountRepository, logger *slog.Logger) *GraphAnalyzer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &GraphAnalyzer{graph: graph, accountRepo: accountRepo, logger: logger}
+}
+
+func (a *GraphAnalyzer) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     GraphAnalysisJobName,
+		Schedule: scheduler.Every(a.graph.cfg.AnalysisInterval),
+		Run:      a.Run,
+	})
+}
+
+func (a *GraphAnalyzer) Run(ctx context.Context) error {
+	flags := a.graph.Analyze(time.Now())
+
+	flagged := make(map[string]bool)
+	for _, flag := range flags {
+		if flagged[flag.AccountID] {
+			continue
+		}
+		flagged[flag.AccountID] = true
+
+		account, err := a.accountRepo.GetByID(ctx, flag.AccountID)
+		if err != nil {
+			continue
+		}
+		account.RiskCategory = a.graph.cfg.FlaggedRiskCategory
+		if err := a.accountRepo.Update(ctx, account); err != nil {
+			return fmt.Errorf("failed to flag account %s: %w", flag.AccountID, err)
+		}
+
+		a.logger.WarnContext(ctx, "Account flagged by counterparty graph analysis",
+			slog.String("account_id", flag.AccountID),
+			slog.String("flag", flag.Flag),
+			slog.Float64("score", flag.Score))
+	}
+
+	return nil
+}
+
+func (fd *FraudDetector) WithCounterpartyGraph(graph *TransferGraph, weight int) *FraudDetector {
+	fd.patterns = append(fd.patterns, FraudPattern{
+		Name:        FlagRiskyCounterparty,
+		Description: "Counterparty flagged by transfer graph analysis",
+		Detect: func(tx *domain.Transaction) (bool, string) {
+			if (tx.ToAccountID != "" && graph.IsFlagged(tx.ToAccountID)) ||
+				(tx.FromAccountID != "" && graph.IsFlagged(tx.FromAccountID)) {
+				return true, FlagRiskyCounterparty
+			}
+			return false, ""
+		},
+		Weight: weight,
+	})
+	return fd
+}
+
+func (p *TransactionProcessor) WithTransferGraph(graph *TransferGraph) *TransactionProcessor {
+	p.transferGraph = graph
+	p.fraudDetector.WithCounterpartyGraph(graph, 30)
+	return p
+}
@@ -0,0 +1,168 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type StatusCounts struct {
+	From     time.Time                        `json:"from"`
+	To       time.Time                        `json:"to"`
+	Total    int                              `json:"total"`
+	ByStatus map[domain.TransactionStatus]int `json:"by_status"`
+}
+
+type ApprovalQueueStats struct {
+	Depth            int     `json:"depth"`
+	OldestAgeSeconds float64 `json:"oldest_age_seconds"`
+	P50AgeSeconds    float64 `json:"p50_age_seconds"`
+	P90AgeSeconds    float64 `json:"p90_age_seconds"`
+	P99AgeSeconds    float64 `json:"p99_age_seconds"`
+}
+
+type FlagRate struct {
+	Flag  string  `json:"flag"`
+	Count int     `json:"count"`
+	Rate  float64 `json:"rate"`
+}
+
+type FraudFlagRates struct {
+	From         time.Time  `json:"from"`
+	To           time.Time  `json:"to"`
+	Transactions int        `json:"transactions"`
+	Flags        []FlagRate `json:"flags"`
+}
+
+type AccountActivity struct {
+	AccountID    string  `json:"account_id"`
+	Transactions int     `json:"transactions"`
+	Volume       float64 `json:"volume"`
+}
+
+func (p *TransactionProcessor) TransactionStatusCounts(ctx context.Context, from, to time.Time) (StatusCounts, error) {
+	counts := StatusCounts{From: from, To: to, ByStatus: make(map[domain.TransactionStatus]int)}
+	for tx, err := range p.readRepo.Stream(ctx, repository.TransactionFilter{From: from, To: to}) {
+		if err != nil {
+			return counts, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		counts.ByStatus[tx.Status]++
+		counts.Total++
+	}
+	return counts, nil
+}
+
+func (p *TransactionProcessor) ApprovalQueue(ctx context.Context, now time.Time) (ApprovalQueueStats, error) {
+	var ages []float64
+	for tx, err := range p.readRepo.Stream(ctx, repository.TransactionFilter{Status: domain.StatusPending}) {
+		if err != nil {
+			return ApprovalQueueStats{}, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		ages = append(ages, now.Sub(tx.CreatedAt).Seconds())
+	}
+
+	stats := ApprovalQueueStats{Depth: len(ages)}
+	if len(ages) == 0 {
+		return stats, nil
+	}
+	sort.Float64s(ages)
+	stats.OldestAgeSeconds = ages[len(ages)-1]
+	stats.P50AgeSeconds = percentile(ages, 50)
+	stats.P90AgeSeconds = percentile(ages, 90)
+	stats.P99AgeSeconds = percentile(ages, 99)
+	return stats, nil
+}
+
+func (p *TransactionProcessor) FraudFlagRates(ctx context.Context, from, to time.Time) (FraudFlagRates, error) {
+	rates := FraudFlagRates{From: from, To: to, Flags: []FlagRate{}}
+	counts := make(map[string]int)
+	for tx, err := range p.readRepo.Stream(ctx, repository.TransactionFilter{From: from, To: to}) {
+		if err != nil {
+			return rates, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		rates.Transactions++
+		for _, flag := range tx.FraudFlags {
+			counts[flag]++
+		}
+	}
+
+	for flag, count := range counts {
+		rates.Flags = append(rates.Flags, FlagRate{
+			Flag:  flag,
+			Count: count,
+			Rate:  float64(count) / float64(rates.Transactions),
+		})
+	}
+	sort.Slice(rates.Flags, func(i, j int) bool {
+		if rates.Flags[i].Count != rates.Flags[j].Count {
+			return rates.Flags[i].Count > rates.Flags[j].Count
+		}
+		return rates.Flags[i].Flag < rates.Flags[j].Flag
+	})
+	return rates, nil
+}
+
+func (p *TransactionProcessor) BusiestAccounts(ctx context.Context, from, to time.Time, limit int) ([]AccountActivity, error) {
+	activity := make(map[string]*AccountActivity)
+	observe := func(accountID string, amount float64) {
+		if accountID == "" {
+			return
+		}
+		entry, exists := activity[accountID]
+		if !exists {
+			entry = &AccountActivity{AccountID: accountID}
+			activity[accountID] = entry
+		}
+		entry.Transactions++
+		entry.Volume += amount
+	}
+
+	for tx, err := range p.readRepo.Stream(ctx, repository.TransactionFilter{From: from, To: to}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		observe(tx.FromAccountID, tx.Amount)
+		observe(tx.ToAccountID, tx.Amount)
+	}
+
+	result := make([]AccountActivity, 0, len(activity))
+	for _, entry := range activity {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Transactions != result[j].Transactions {
+			return result[i].Transactions > result[j].Transactions
+		}
+		if result[i].Volume != result[j].Volume {
+			return result[i].Volume > result[j].Volume
+		}
+		return result[i].AccountID < result[j].AccountID
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (e *RuleEngine) RuleLeaderboard(limit int) []RuleStats {
+	stats := e.AllRuleStats()
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Triggers > stats[j].Triggers
+	})
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	return stats
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,163 @@
+package processor
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type DataQualitySeverity string
+
+const (
+	SeverityCritical DataQualitySeverity = "critical"
+	SeverityWarning  DataQualitySeverity = "warning"
+)
+
+const (
+	CheckOrphanTransaction   = "orphan_transaction"
+	CheckNegativeBalance     = "negative_balance"
+	CheckMissingLedgerLegs   = "missing_ledger_entries"
+	dataQualityBalanceMargin = 0.005
+)
+
+type DataQualityFinding struct {
+	Check      string              `json:"check"`
+	Severity   DataQualitySeverity `json:"severity"`
+	EntityType string              `json:"entity_type"`
+	EntityID   string              `json:"entity_id"`
+	Message    string              `json:"message"`
+}
+
+type DataQualityReport struct {
+	ID                  string                      `json:"id"`
+	GeneratedAt         time.Time                   `json:"generated_at"`
+	AccountsChecked     int                         `json:"accounts_checked"`
+	TransactionsChecked int                         `json:"transactions_checked"`
+	Counts              map[DataQualitySeverity]int `json:"counts"`
+	Findings            []DataQualityFinding        `json:"findings"`
+}
+
+func (r *DataQualityReport) add(finding DataQualityFinding) {
+	r.Findings = append(r.Findings, finding)
+	r.Counts[finding.Severity]++
+}
+
+func (p *TransactionProcessor) CheckDataQuality(ctx context.Context) (*DataQualityReport, error) {
+	snapshot, err := p.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	report := &DataQualityReport{
+		ID:          domain.NewID(),
+		GeneratedAt: time.Now(),
+		Counts:      make(map[DataQualitySeverity]int),
+		Findings:    []DataQualityFinding{},
+	}
+
+	accounts, err := snapshot.Accounts.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		known[account.ID] = true
+		report.AccountsChecked++
+
+		if account.IsSystem() {
+			continue
+		}
+		overdraft, err := p.overdraftLimit(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+		if account.Balance+overdraft < -dataQualityBalanceMargin {
+			report.add(DataQualityFinding{
+				Check:      CheckNegativeBalance,
+				Severity:   SeverityCritical,
+				EntityType: AuditEntityAccount,
+				EntityID:   account.ID,
+				Message:    fmt.Sprintf("balance %.2f %s is below the overdraft limit of %.2f", account.Balance, account.Currency, overdraft),
+			})
+		}
+	}
+
+	err = snapshot.Transactions.Iterate(ctx, repository.TransactionFilter{}, func(tx *domain.Transaction) error {
+		report.TransactionsChecked++
+
+		for _, id := range []string{tx.FromAccountID, tx.ToAccountID} {
+			if id == "" || known[id] {
+				continue
+			}
+			severity := SeverityWarning
+			if tx.Status == domain.StatusCompleted {
+				severity = SeverityCritical
+			}
+			report.add(DataQualityFinding{
+				Check:      CheckOrphanTransaction,
+				Severity:   severity,
+				EntityType: AuditEntityTransaction,
+				EntityID:   tx.ID,
+				Message:    fmt.Sprintf("%s transaction references missing account %s", tx.Status, id),
+			})
+		}
+
+		if tx.Status == domain.StatusCompleted {
+			if missing := missingLedgerLegs(tx); len(missing) > 0 {
+				report.add(DataQualityFinding{
+					Check:      CheckMissingLedgerLegs,
+					Severity:   SeverityCritical,
+					EntityType: AuditEntityTransaction,
+					EntityID:   tx.ID,
+					Message:    fmt.Sprintf("completed %s has no %s entry", tx.Type, strings.Join(missing, " or ")),
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(report.Findings, func(a, b DataQualityFinding) int {
+		return cmp.Or(strings.Compare(string(a.Severity), string(b.Severity)), strings.Compare(a.Check, b.Check), strings.Compare(a.EntityID, b.EntityID))
+	})
+	return report, nil
+}
+
+func (p *TransactionProcessor) overdraftLimit(ctx context.Context, account *domain.Account) (float64, error) {
+	if p.products == nil || account.ProductID == "" {
+		return 0, nil
+	}
+	product, err := p.products.GetByID(ctx, account.ProductID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account product: %w", err)
+	}
+	return product.OverdraftLimit, nil
+}
+
+func missingLedgerLegs(tx *domain.Transaction) []string {
+	var missing []string
+	// Leg entries carry only the credit; the debit is on the linked transfer.
+	isLeg := tx.Metadata[MetadataLegIndex] != ""
+	if tx.Type != domain.TypeDeposit && tx.FromAccountID == "" && !isLeg {
+		missing = append(missing, "debit")
+	}
+	if tx.Type != domain.TypeWithdrawal && tx.ToAccountID == "" && len(tx.Legs) == 0 {
+		missing = append(missing, "credit")
+	}
+	if tx.Amount <= 0 {
+		missing = append(missing, "amount")
+	}
+	return missing
+}
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	StageValidation = "validation"
+	StageFraud      = "fraud"
+	StageRules      = "rules"
+	StageExecution  = "execution"
+	StagePersist    = "persist"
+)
+
+type StageBudgets struct {
+	Total      time.Duration
+	Validation time.Duration
+	Fraud      time.Duration
+	Rules      time.Duration
+	Execution  time.Duration
+	Persist    time.Duration
+}
+
+type StageTimeoutError struct {
+	Stage  string
+	Budget time.Duration
+	Err    error
+}
+
+func (e *StageTimeoutError) Error() string {
+	return fmt.Sprintf("stage %s exceeded budget of %s: %v", e.Stage, e.Budget, e.Err)
+}
+
+func (e *StageTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func DefaultStageBudgets() StageBudgets {
+	return StageBudgets{
+		Total:      10 * time.Second,
+		Validation: 500 * time.Millisecond,
+		Fraud:      2 * time.Second,
+		Rules:      2 * time.Second,
+		Execution:  5 * time.Second,
+		Persist:    2 * time.Second,
+	}
+}
+
+func (p *TransactionProcessor) WithStageBudgets(budgets StageBudgets) *TransactionProcessor {
+	p.budgets = budgets
+	return p
+}
+
+func (p *TransactionProcessor) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.budgets.Total <= 0 {
+		return context.WithCancel(ctx)
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < p.budgets.Total {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.budgets.Total)
+}
+
+// runStage runs fn under the stage budget. On timeout it returns while fn keeps
+// running, so fn must not share mutable state with the caller: process hands
+// each stage its own clone of the transaction.
+func runStage(ctx context.Context, stage string, budget time.Duration, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return &StageTimeoutError{Stage: stage, Budget: budget, Err: err}
+	}
+	if budget <= 0 {
+		return fn(ctx)
+	}
+
+	stageCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(stageCtx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-stageCtx.Done():
+		return &StageTimeoutError{Stage: stage, Budget: budget, Err: stageCtx.Err()}
+	}
+}
+
+func runStageInline(ctx context.Context, stage string, budget time.Duration, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return &StageTimeoutError{Stage: stage, Budget: budget, Err: err}
+	}
+	if budget <= 0 {
+		return fn(ctx)
+	}
+
+	stageCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	err := fn(stageCtx)
+	if err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+		return &StageTimeoutError{Stage: stage, Budget: budget, Err: err}
+	}
+	return err
+}
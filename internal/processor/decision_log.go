@@ -0,0 +1,242 @@
+package processor
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DecisionFormatCSV   = "csv"
+	DecisionFormatJSONL = "jsonl"
+
+	LabelFraud      = "fraud"
+	LabelLegitimate = "legitimate"
+	LabelUnreviewed = "unreviewed"
+)
+
+var ErrUnsupportedDecisionFormat = errors.New("unsupported decision log format")
+
+type DecisionRecord struct {
+	TransactionID  string         `json:"transaction_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Type           string         `json:"type"`
+	Amount         float64        `json:"amount"`
+	Currency       string         `json:"currency"`
+	Hour           int            `json:"hour"`
+	Weekday        int            `json:"weekday"`
+	HasDescription bool           `json:"has_description"`
+	RiskScore      int            `json:"risk_score"`
+	TimeModifier   int            `json:"time_modifier"`
+	Patterns       map[string]int `json:"patterns"`
+	RulesTriggered []string       `json:"rules_triggered"`
+	RulesApplied   []string       `json:"rules_applied"`
+	DecidedByRule  string         `json:"decided_by_rule"`
+	Disposition    string         `json:"disposition"`
+	Resolution     string         `json:"resolution"`
+	Label          string         `json:"label"`
+}
+
+func NewDecisionRecord(tx *domain.Transaction) DecisionRecord {
+	record := DecisionRecord{
+		TransactionID:  tx.ID,
+		CreatedAt:      tx.CreatedAt,
+		Type:           string(tx.Type),
+		Amount:         tx.Amount,
+		Currency:       tx.Currency,
+		Hour:           tx.CreatedAt.Hour(),
+		Weekday:        int(tx.CreatedAt.Weekday()),
+		HasDescription: tx.Description != "",
+		RiskScore:      tx.RiskScore,
+		Patterns:       make(map[string]int),
+		DecidedByRule:  tx.Metadata[MetadataDecidedByRule],
+		Disposition:    string(tx.Status),
+		Resolution:     tx.Metadata[AuditActionRiskOverride],
+	}
+
+	if tx.Explanation != nil {
+		record.TimeModifier = tx.Explanation.TimeModifier
+		for _, pattern := range tx.Explanation.FraudPatterns {
+			record.Patterns[pattern.Name] = pattern.Weight
+		}
+		for _, rule := range tx.Explanation.Rules {
+			record.RulesTriggered = append(record.RulesTriggered, rule.RuleID)
+			if rule.Applied {
+				record.RulesApplied = append(record.RulesApplied, rule.RuleID)
+			}
+		}
+	}
+
+	switch OverrideOutcome(record.Resolution) {
+	case OverrideReject:
+		record.Label = LabelFraud
+	case OverrideApprove:
+		record.Label = LabelLegitimate
+	default:
+		record.Label = LabelUnreviewed
+	}
+
+	return record
+}
+
+func (p *TransactionProcessor) DecisionRecords(ctx context.Context, from, to time.Time) ([]DecisionRecord, error) {
+	var records []DecisionRecord
+	for tx, err := range p.readRepo.Stream(ctx, repository.TransactionFilter{From: from, To: to}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		records = append(records, NewDecisionRecord(tx))
+	}
+	return records, nil
+}
+
+func (p *TransactionProcessor) ExportDecisions(ctx context.Context, w io.Writer, from, to time.Time, format string) error {
+	filter := repository.TransactionFilter{From: from, To: to}
+
+	switch format {
+	case DecisionFormatJSONL:
+		encoder := json.NewEncoder(w)
+		for tx, err := range p.readRepo.Stream(ctx, filter) {
+			if err != nil {
+				return err
+			}
+			if err := encoder.Encode(NewDecisionRecord(tx)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case DecisionFormatCSV:
+		patternSet := make(map[string]bool)
+		for tx, err := range p.readRepo.Stream(ctx, filter) {
+			if err != nil {
+				return err
+			}
+			if tx.Explanation != nil {
+				for _, pattern := range tx.Explanation.FraudPatterns {
+					patternSet[pattern.Name] = true
+				}
+			}
+		}
+
+		writer := newDecisionCSVWriter(w, patternSet)
+		if err := writer.writeHeader(); err != nil {
+			return err
+		}
+		for tx, err := range p.readRepo.Stream(ctx, filter) {
+			if err != nil {
+				return err
+			}
+			if err := writer.write(NewDecisionRecord(tx)); err != nil {
+				return err
+			}
+		}
+		return writer.flush()
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedDecisionFormat, format)
+	}
+}
+
+func WriteDecisionRecords(w io.Writer, records []DecisionRecord, format string) error {
+	switch format {
+	case DecisionFormatCSV:
+		return writeDecisionCSV(w, records)
+	case DecisionFormatJSONL:
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedDecisionFormat, format)
+	}
+}
+
+func writeDecisionCSV(w io.Writer, records []DecisionRecord) error {
+	patternSet := make(map[string]bool)
+	for _, record := range records {
+		for name := range record.Patterns {
+			patternSet[name] = true
+		}
+	}
+
+	writer := newDecisionCSVWriter(w, patternSet)
+	if err := writer.writeHeader(); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.write(record); err != nil {
+			return err
+		}
+	}
+	return writer.flush()
+}
+
+type decisionCSVWriter struct {
+	writer   *csv.Writer
+	patterns []string
+}
+
+func newDecisionCSVWriter(w io.Writer, patternSet map[string]bool) *decisionCSVWriter {
+	patterns := make([]string, 0, len(patternSet))
+	for name := range patternSet {
+		patterns = append(patterns, name)
+	}
+	sort.Strings(patterns)
+
+	return &decisionCSVWriter{writer: csv.NewWriter(w), patterns: patterns}
+}
+
+func (d *decisionCSVWriter) writeHeader() error {
+	header := []string{
+		"transaction_id", "created_at", "type", "amount", "currency", "hour", "weekday",
+		"has_description", "risk_score", "time_modifier",
+	}
+	for _, name := range d.patterns {
+		header = append(header, "pattern_"+name)
+	}
+	header = append(header, "rules_triggered", "rules_applied", "decided_by_rule", "disposition", "resolution", "label")
+	return d.writer.Write(header)
+}
+
+func (d *decisionCSVWriter) write(record DecisionRecord) error {
+	row := []string{
+		record.TransactionID,
+		record.CreatedAt.Format(time.RFC3339),
+		record.Type,
+		strconv.FormatFloat(record.Amount, 'f', 2, 64),
+		record.Currency,
+		strconv.Itoa(record.Hour),
+		strconv.Itoa(record.Weekday),
+		strconv.FormatBool(record.HasDescription),
+		strconv.Itoa(record.RiskScore),
+		strconv.Itoa(record.TimeModifier),
+	}
+	for _, name := range d.patterns {
+		row = append(row, strconv.Itoa(record.Patterns[name]))
+	}
+	row = append(row,
+		strings.Join(record.RulesTriggered, "|"),
+		strings.Join(record.RulesApplied, "|"),
+		record.DecidedByRule,
+		record.Disposition,
+		record.Resolution,
+		record.Label,
+	)
+	return d.writer.Write(row)
+}
+
+func (d *decisionCSVWriter) flush() error {
+	d.writer.Flush()
+	return d.writer.Error()
+}
@@ -0,0 +1,343 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	MetadataDegradedQueued = "degraded_queued"
+	DegradedDrainJobName   = "degraded_drain"
+
+	DegradedTriggerManual    = "manual"
+	DegradedTriggerAutomatic = "automatic"
+)
+
+var (
+	ErrDegradedModeDisabled  = errors.New("degraded mode is not configured")
+	ErrDependencyUnavailable = errors.New("dependency unavailable")
+)
+
+type DegradedModeConfig struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+	DrainInterval    time.Duration
+	DrainBatchSize   int
+}
+
+func DefaultDegradedModeConfig() DegradedModeConfig {
+	return DegradedModeConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+		DrainInterval:    10 * time.Second,
+		DrainBatchSize:   100,
+	}
+}
+
+type DegradedStatus struct {
+	Active              bool       `json:"active"`
+	Trigger             string     `json:"trigger,omitempty"`
+	Reason              string     `json:"reason,omitempty"`
+	Since               *time.Time `json:"since,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Queued              int        `json:"queued"`
+}
+
+type DrainResult struct {
+	Drained   int `json:"drained"`
+	Failed    int `json:"failed"`
+	Remaining int `json:"remaining"`
+}
+
+type degradedMode struct {
+	cfg DegradedModeConfig
+
+	mu          sync.Mutex
+	active      bool
+	trigger     string
+	reason      string
+	since       time.Time
+	failures    int
+	lastFailure time.Time
+	queue       []string
+
+	drainMu sync.Mutex
+}
+
+func (d *degradedMode) status() DegradedStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	status := DegradedStatus{
+		Active:              d.active,
+		Trigger:             d.trigger,
+		Reason:              d.reason,
+		ConsecutiveFailures: d.failures,
+		Queued:              len(d.queue),
+	}
+	if d.active {
+		since := d.since
+		status.Since = &since
+	}
+	return status
+}
+
+func (d *degradedMode) isActive() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.active
+}
+
+func (d *degradedMode) activate(trigger, reason string) {
+	d.active = true
+	d.trigger = trigger
+	d.reason = reason
+	d.since = time.Now()
+}
+
+func (d *degradedMode) recordFailure(err error) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failures++
+	d.lastFailure = time.Now()
+	if d.active || d.failures < d.cfg.FailureThreshold {
+		return false
+	}
+	d.activate(DegradedTriggerAutomatic, err.Error())
+	return true
+}
+
+func (d *degradedMode) recordSuccess() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failures = 0
+	if !d.active || d.trigger != DegradedTriggerAutomatic {
+		return false
+	}
+	d.active = false
+	return true
+}
+
+func (d *degradedMode) enqueue(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = append(d.queue, id)
+}
+
+func (d *degradedMode) dequeue(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = slices.DeleteFunc(d.queue, func(queued string) bool { return queued == id })
+}
+
+func (d *degradedMode) holds(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Contains(d.queue, id)
+}
+
+func (d *degradedMode) queued() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.queue)
+}
+
+func (d *degradedMode) next(now time.Time) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.active && (d.trigger == DegradedTriggerManual || now.Sub(d.lastFailure) < d.cfg.Cooldown) {
+		return "", false
+	}
+	if len(d.queue) == 0 {
+		if d.active {
+			d.active = false
+			d.failures = 0
+		}
+		return "", false
+	}
+	return d.queue[0], true
+}
+
+func dependencyFailure(err error) bool {
+	var timeout *StageTimeoutError
+	return errors.As(err, &timeout) || errors.Is(err, ErrDependencyUnavailable)
+}
+
+func (p *TransactionProcessor) WithDegradedMode(cfg DegradedModeConfig) *TransactionProcessor {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 1
+	}
+	if cfg.DrainBatchSize <= 0 {
+		cfg.DrainBatchSize = DefaultDegradedModeConfig().DrainBatchSize
+	}
+	p.degraded = &degradedMode{cfg: cfg}
+	return p
+}
+
+func (p *TransactionProcessor) DegradedStatus() (DegradedStatus, bool) {
+	if p.degraded == nil {
+		return DegradedStatus{}, false
+	}
+	return p.degraded.status(), true
+}
+
+func (p *TransactionProcessor) SetDegraded(active bool, reason string) (DegradedStatus, error) {
+	if p.degraded == nil {
+		return DegradedStatus{}, ErrDegradedModeDisabled
+	}
+
+	p.degraded.mu.Lock()
+	if active {
+		p.degraded.activate(DegradedTriggerManual, reason)
+	} else {
+		p.degraded.active = false
+		p.degraded.failures = 0
+	}
+	p.degraded.mu.Unlock()
+
+	p.logger.Warn("Degraded mode toggled manually",
+		slog.Bool("active", active),
+		slog.String("reason", reason))
+	return p.degraded.status(), nil
+}
+
+func (p *TransactionProcessor) queueIfDegraded(ctx context.Context, tx *domain.Transaction) bool {
+	if p.degraded == nil {
+		return false
+	}
+	if !p.degraded.isActive() && tx.Metadata[MetadataMaintenanceWindow] == "" {
+		return false
+	}
+	tx.AddMetadata(MetadataDegradedQueued, "true")
+	traceStep(ctx, TraceStageDecision, "degraded_queue", TraceOutcomeTriggered, nil)
+	return true
+}
+
+func (p *TransactionProcessor) observeExecution(ctx context.Context, err error) {
+	if p.degraded == nil {
+		return
+	}
+	if err == nil {
+		if p.degraded.recordSuccess() {
+			p.logger.InfoContext(ctx, "Dependencies recovered, leaving degraded mode")
+		}
+		return
+	}
+	if dependencyFailure(err) && p.degraded.recordFailure(err) {
+		p.logger.WarnContext(ctx, "Entering degraded mode after dependency failures",
+			slog.String("error", err.Error()))
+	}
+}
+
+func (p *TransactionProcessor) DrainDegradedQueue(ctx context.Context) (DrainResult, error) {
+	if p.degraded == nil {
+		return DrainResult{}, ErrDegradedModeDisabled
+	}
+	p.degraded.drainMu.Lock()
+	defer p.degraded.drainMu.Unlock()
+
+	var result DrainResult
+	if _, active := p.activeMaintenance(); active {
+		result.Remaining = p.degraded.queued()
+		return result, nil
+	}
+	for i := 0; i < p.degraded.cfg.DrainBatchSize; i++ {
+		if err := ctx.Err(); err != nil {
+			result.Remaining = p.degraded.queued()
+			return result, err
+		}
+		id, ok := p.degraded.next(time.Now())
+		if !ok {
+			break
+		}
+
+		tx, err := p.txRepo.GetByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			p.degraded.dequeue(id)
+			continue
+		}
+		if err != nil {
+			p.observeExecution(ctx, fmt.Errorf("%w: %w", ErrDependencyUnavailable, err))
+			break
+		}
+		claimed, err := p.claimForExecution(ctx, id, domain.StatusPending)
+		if err != nil {
+			p.observeExecution(ctx, fmt.Errorf("%w: %w", ErrDependencyUnavailable, err))
+			break
+		}
+		if !claimed {
+			p.degraded.dequeue(id)
+			continue
+		}
+
+		execErr := runStageInline(ctx, StageExecution, p.budgets.Execution, func(ctx context.Context) error {
+			return p.executeTransaction(ctx, tx)
+		})
+		p.observeExecution(ctx, execErr)
+		if dependencyFailure(execErr) {
+			p.releaseClaim(ctx, id, domain.StatusPending)
+			break
+		}
+
+		status := domain.StatusCompleted
+		if execErr != nil {
+			status = domain.StatusFailed
+		}
+		p.degraded.dequeue(id)
+		if err := p.txRepo.UpdateStatus(ctx, tx.ID, status); err != nil {
+			p.logger.ErrorContext(ctx, "Failed to record drained transaction",
+				slog.String("transaction_id", tx.ID),
+				slog.String("status", string(status)),
+				slog.String("error", err.Error()))
+			break
+		}
+		tx.Status = status
+
+		if execErr != nil {
+			result.Failed++
+			p.logger.WarnContext(ctx, "Queued transaction failed on drain",
+				slog.String("transaction_id", tx.ID),
+				slog.String("error", execErr.Error()))
+			continue
+		}
+		result.Drained++
+		p.observeCompleted(ctx, tx)
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "transaction_drained",
+			Timestamp:     time.Now(),
+		})
+	}
+
+	result.Remaining = p.degraded.queued()
+	if result.Drained+result.Failed > 0 {
+		p.logger.InfoContext(ctx, "Drained degraded mode backlog",
+			slog.Int("drained", result.Drained),
+			slog.Int("failed", result.Failed),
+			slog.Int("remaining", result.Remaining))
+	}
+	return result, nil
+}
+
+func (p *TransactionProcessor) RegisterDegradedDrain(sched *scheduler.Scheduler) error {
+	if p.degraded == nil {
+		return ErrDegradedModeDisabled
+	}
+	return sched.Register(scheduler.Job{
+		Name:     DegradedDrainJobName,
+		Schedule: scheduler.Every(p.degraded.cfg.DrainInterval),
+		Run: func(ctx context.Context) error {
+			return p.RunQueued(ctx, QueueReplay, func(ctx context.Context) error {
+				_, err := p.DrainDegradedQueue(ctx)
+				return err
+			})
+		},
+	})
+}
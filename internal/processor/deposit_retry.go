@@ -0,0 +1,338 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	MetadataDepositRetry = "deposit_retry"
+	DepositRetryJobName  = "deposit_retry"
+
+	DepositRetryParked       = "parked"
+	DepositRetryDeadLettered = "dead_lettered"
+)
+
+var ErrDepositRetryDisabled = errors.New("deposit retry is not configured")
+
+type DepositRetryConfig struct {
+	MaxAttempts int
+	Interval    time.Duration
+	Backoff     time.Duration
+	BatchSize   int
+}
+
+func DefaultDepositRetryConfig() DepositRetryConfig {
+	return DepositRetryConfig{
+		MaxAttempts: 5,
+		Interval:    30 * time.Second,
+		Backoff:     30 * time.Second,
+		BatchSize:   100,
+	}
+}
+
+type DeadLetterPublisher interface {
+	Publish(ctx context.Context, body []byte) error
+}
+
+type ParkedDeposit struct {
+	TransactionID string    `json:"transaction_id"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	ParkedAt      time.Time `json:"parked_at"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+}
+
+type DepositRetryStatus struct {
+	Parked       []ParkedDeposit `json:"parked"`
+	DeadLettered []ParkedDeposit `json:"dead_lettered"`
+}
+
+type DepositRetryResult struct {
+	Completed    int `json:"completed"`
+	Failed       int `json:"failed"`
+	Rescheduled  int `json:"rescheduled"`
+	DeadLettered int `json:"dead_lettered"`
+	Remaining    int `json:"remaining"`
+}
+
+type depositDeadLetter struct {
+	Reason      string              `json:"reason"`
+	Attempts    int                 `json:"attempts"`
+	Transaction *domain.Transaction `json:"transaction"`
+}
+
+type depositRetry struct {
+	cfg        DepositRetryConfig
+	deadLetter DeadLetterPublisher
+
+	mu           sync.Mutex
+	parked       []ParkedDeposit
+	deadLettered []ParkedDeposit
+
+	runMu sync.Mutex
+}
+
+func (r *depositRetry) park(id string, err error, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parked = append(r.parked, ParkedDeposit{
+		TransactionID: id,
+		LastError:     err.Error(),
+		ParkedAt:      now,
+		NextAttemptAt: now.Add(r.cfg.Backoff),
+	})
+}
+
+func (r *depositRetry) due(now time.Time, limit int) []ParkedDeposit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []ParkedDeposit
+	for _, entry := range r.parked {
+		if len(due) == limit {
+			break
+		}
+		if !entry.NextAttemptAt.After(now) {
+			due = append(due, entry)
+		}
+	}
+	return due
+}
+
+func (r *depositRetry) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parked = slices.DeleteFunc(r.parked, func(entry ParkedDeposit) bool { return entry.TransactionID == id })
+}
+
+func (r *depositRetry) reschedule(entry ParkedDeposit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.parked {
+		if r.parked[i].TransactionID == entry.TransactionID {
+			r.parked[i] = entry
+			return
+		}
+	}
+}
+
+func (r *depositRetry) bury(entry ParkedDeposit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parked = slices.DeleteFunc(r.parked, func(parked ParkedDeposit) bool { return parked.TransactionID == entry.TransactionID })
+	r.deadLettered = append(r.deadLettered, entry)
+}
+
+func (r *depositRetry) status() DepositRetryStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return DepositRetryStatus{
+		Parked:       append([]ParkedDeposit{}, r.parked...),
+		DeadLettered: append([]ParkedDeposit{}, r.deadLettered...),
+	}
+}
+
+func (r *depositRetry) holds(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.ContainsFunc(r.parked, func(entry ParkedDeposit) bool { return entry.TransactionID == id })
+}
+
+func (r *depositRetry) remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.parked)
+}
+
+func transientFailure(err error) bool {
+	return dependencyFailure(err) || errors.Is(err, repository.ErrTransactionConflict)
+}
+
+func (p *TransactionProcessor) WithDepositRetry(cfg DepositRetryConfig, deadLetter DeadLetterPublisher) *TransactionProcessor {
+	defaults := DefaultDepositRetryConfig()
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaults.MaxAttempts
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	p.depositRetry = &depositRetry{cfg: cfg, deadLetter: deadLetter}
+	return p
+}
+
+func (p *TransactionProcessor) DepositRetryStatus() (DepositRetryStatus, error) {
+	if p.depositRetry == nil {
+		return DepositRetryStatus{}, ErrDepositRetryDisabled
+	}
+	return p.depositRetry.status(), nil
+}
+
+func (p *TransactionProcessor) parkDeposit(ctx context.Context, tx *domain.Transaction, err error) bool {
+	if p.depositRetry == nil || tx.Type != domain.TypeDeposit || !transientFailure(err) {
+		return false
+	}
+	tx.AddMetadata(MetadataDepositRetry, DepositRetryParked)
+	p.logger.WarnContext(ctx, "Deposit parked for automatic retry",
+		slog.String("transaction_id", tx.ID),
+		slog.String("error", err.Error()))
+	return true
+}
+
+func (p *TransactionProcessor) RetryParkedDeposits(ctx context.Context) (DepositRetryResult, error) {
+	if p.depositRetry == nil {
+		return DepositRetryResult{}, ErrDepositRetryDisabled
+	}
+	p.depositRetry.runMu.Lock()
+	defer p.depositRetry.runMu.Unlock()
+
+	var result DepositRetryResult
+	for _, entry := range p.depositRetry.due(time.Now(), p.depositRetry.cfg.BatchSize) {
+		if err := ctx.Err(); err != nil {
+			result.Remaining = p.depositRetry.remaining()
+			return result, err
+		}
+
+		tx, err := p.txRepo.GetByID(ctx, entry.TransactionID)
+		if errors.Is(err, repository.ErrNotFound) {
+			p.depositRetry.remove(entry.TransactionID)
+			continue
+		}
+		if err != nil {
+			p.logger.WarnContext(ctx, "Failed to load parked deposit",
+				slog.String("transaction_id", entry.TransactionID),
+				slog.String("error", err.Error()))
+			break
+		}
+		claimed, err := p.claimForExecution(ctx, entry.TransactionID, domain.StatusPending)
+		if err != nil {
+			p.logger.WarnContext(ctx, "Failed to claim parked deposit",
+				slog.String("transaction_id", entry.TransactionID),
+				slog.String("error", err.Error()))
+			break
+		}
+		if !claimed {
+			p.depositRetry.remove(entry.TransactionID)
+			continue
+		}
+
+		execErr := runStageInline(ctx, StageExecution, p.budgets.Execution, func(ctx context.Context) error {
+			return p.executeTransaction(ctx, tx)
+		})
+		p.observeExecution(ctx, execErr)
+		entry.Attempts++
+
+		if transientFailure(execErr) {
+			entry.LastError = execErr.Error()
+			if entry.Attempts < p.depositRetry.cfg.MaxAttempts {
+				p.releaseClaim(ctx, tx.ID, domain.StatusPending)
+				entry.NextAttemptAt = time.Now().Add(p.depositRetry.cfg.Backoff << (entry.Attempts - 1))
+				p.depositRetry.reschedule(entry)
+				result.Rescheduled++
+				continue
+			}
+			p.deadLetterDeposit(ctx, tx, entry)
+			result.DeadLettered++
+			continue
+		}
+
+		status := domain.StatusCompleted
+		if execErr != nil {
+			status = domain.StatusFailed
+		}
+		if err := p.txRepo.UpdateStatus(ctx, tx.ID, status); err != nil {
+			p.logger.ErrorContext(ctx, "Failed to record retried deposit",
+				slog.String("transaction_id", tx.ID),
+				slog.String("status", string(status)),
+				slog.String("error", err.Error()))
+			break
+		}
+		p.depositRetry.remove(tx.ID)
+		tx.Status = status
+
+		if execErr != nil {
+			result.Failed++
+			p.logger.WarnContext(ctx, "Parked deposit failed on retry",
+				slog.String("transaction_id", tx.ID),
+				slog.String("error", execErr.Error()))
+			continue
+		}
+		result.Completed++
+		p.observeCompleted(ctx, tx)
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "deposit_retried",
+			Payload:       map[string]interface{}{"attempts": entry.Attempts},
+			Timestamp:     time.Now(),
+		})
+	}
+
+	result.Remaining = p.depositRetry.remaining()
+	if result.Completed+result.Failed+result.Rescheduled+result.DeadLettered > 0 {
+		p.logger.InfoContext(ctx, "Retried parked deposits",
+			slog.Int("completed", result.Completed),
+			slog.Int("failed", result.Failed),
+			slog.Int("rescheduled", result.Rescheduled),
+			slog.Int("dead_lettered", result.DeadLettered),
+			slog.Int("remaining", result.Remaining))
+	}
+	return result, nil
+}
+
+func (p *TransactionProcessor) deadLetterDeposit(ctx context.Context, tx *domain.Transaction, entry ParkedDeposit) {
+	p.depositRetry.bury(entry)
+	if err := p.txRepo.UpdateStatus(ctx, tx.ID, domain.StatusFailed); err != nil {
+		p.logger.ErrorContext(ctx, "Failed to mark dead-lettered deposit",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+	tx.Status = domain.StatusFailed
+	tx.AddMetadata(MetadataDepositRetry, DepositRetryDeadLettered)
+
+	p.logger.ErrorContext(ctx, "Dead-lettering deposit after retries",
+		slog.String("transaction_id", tx.ID),
+		slog.Int("attempts", entry.Attempts),
+		slog.String("error", entry.LastError))
+	p.emitEvent(ctx, domain.TransactionEvent{
+		TransactionID: tx.ID,
+		Type:          "deposit_dead_lettered",
+		Payload:       map[string]interface{}{"attempts": entry.Attempts, "error": entry.LastError},
+		Timestamp:     time.Now(),
+	})
+
+	if p.depositRetry.deadLetter == nil {
+		return
+	}
+	body, err := json.Marshal(depositDeadLetter{Reason: entry.LastError, Attempts: entry.Attempts, Transaction: tx})
+	if err == nil {
+		err = p.depositRetry.deadLetter.Publish(ctx, body)
+	}
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to publish deposit dead letter",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (p *TransactionProcessor) RegisterDepositRetry(sched *scheduler.Scheduler) error {
+	if p.depositRetry == nil {
+		return ErrDepositRetryDisabled
+	}
+	return sched.Register(scheduler.Job{
+		Name:     DepositRetryJobName,
+		Schedule: scheduler.Every(p.depositRetry.cfg.Interval),
+		Run: func(ctx context.Context) error {
+			return p.RunQueued(ctx, QueueReplay, func(ctx context.Context) error {
+				_, err := p.RetryParkedDeposits(ctx)
+				return err
+			})
+		},
+	})
+}
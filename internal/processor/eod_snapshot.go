@@ -0,0 +1,112 @@
+package processor
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (p *TransactionProcessor) EndOfDaySnapshot(ctx context.Context, businessDate string, asOf time.Time) (*domain.EODSnapshot, error) {
+	snapshot, err := p.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	accounts, err := snapshot.Accounts.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]float64, len(accounts))
+	for _, account := range accounts {
+		balances[account.ID] = account.Balance
+	}
+
+	eod := &domain.EODSnapshot{
+		ID:           domain.EODSnapshotID(businessDate),
+		BusinessDate: businessDate,
+		AsOf:         asOf,
+		TakenAt:      snapshot.Transactions.TakenAt(),
+		Holds:        []domain.SnapshotTransaction{},
+		Pending:      []domain.SnapshotTransaction{},
+	}
+	err = snapshot.Transactions.Iterate(ctx, repository.TransactionFilter{}, func(tx *domain.Transaction) error {
+		if !tx.CreatedAt.Before(asOf) {
+			// Roll balances back to the cutoff by undoing anything posted after it.
+			for _, id := range []string{tx.FromAccountID, tx.ToAccountID} {
+				if _, ok := balances[id]; ok {
+					balances[id] -= balanceEffect(tx, id)
+				}
+			}
+			return nil
+		}
+
+		entry := domain.SnapshotTransaction{
+			TransactionID: tx.ID,
+			Type:          tx.Type,
+			Status:        tx.Status,
+			FromAccountID: tx.FromAccountID,
+			ToAccountID:   tx.ToAccountID,
+			Amount:        tx.Amount,
+			Currency:      tx.Currency,
+			CreatedAt:     tx.CreatedAt,
+		}
+		switch tx.Status {
+		case domain.StatusSuspicious:
+			eod.Holds = append(eod.Holds, entry)
+		case domain.StatusPending:
+			eod.Pending = append(eod.Pending, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eod.Balances = make([]domain.SnapshotBalance, 0, len(accounts))
+	for _, account := range accounts {
+		if account.CreatedAt.After(asOf) {
+			continue
+		}
+		eod.Balances = append(eod.Balances, domain.SnapshotBalance{
+			AccountID: account.ID,
+			Currency:  account.Currency,
+			Balance:   ledgerRounding.Round(balances[account.ID], account.Currency),
+			Status:    account.Status,
+		})
+	}
+	slices.SortFunc(eod.Balances, func(a, b domain.SnapshotBalance) int { return strings.Compare(a.AccountID, b.AccountID) })
+	bySubmission := func(a, b domain.SnapshotTransaction) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.TransactionID, b.TransactionID))
+	}
+	slices.SortFunc(eod.Holds, bySubmission)
+	slices.SortFunc(eod.Pending, bySubmission)
+
+	eod.Checksum, err = snapshotChecksum(eod)
+	if err != nil {
+		return nil, err
+	}
+	return eod, nil
+}
+
+func snapshotChecksum(eod *domain.EODSnapshot) (string, error) {
+	body, err := json.Marshal(struct {
+		BusinessDate string                       `json:"business_date"`
+		AsOf         time.Time                    `json:"as_of"`
+		Balances     []domain.SnapshotBalance     `json:"balances"`
+		Holds        []domain.SnapshotTransaction `json:"holds"`
+		Pending      []domain.SnapshotTransaction `json:"pending"`
+	}{eod.BusinessDate, eod.AsOf.UTC(), eod.Balances, eod.Holds, eod.Pending})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
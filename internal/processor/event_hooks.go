@@ -0,0 +1,77 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// AllEvents registers a handler for every event type.
+const AllEvents = "*"
+
+type EventHandler func(ctx context.Context, event domain.TransactionEvent) error
+
+type eventHooks struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+// OnEvent registers a synchronous handler; handlers must not call back into the processor.
+func (p *TransactionProcessor) OnEvent(eventType string, handler EventHandler) *TransactionProcessor {
+	p.hooks.mu.Lock()
+	defer p.hooks.mu.Unlock()
+	if p.hooks.handlers == nil {
+		p.hooks.handlers = make(map[string][]EventHandler)
+	}
+	p.hooks.handlers[eventType] = append(p.hooks.handlers[eventType], handler)
+	return p
+}
+
+func (h *eventHooks) registered() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.handlers) > 0
+}
+
+func (h *eventHooks) matching(eventType string) []EventHandler {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	handlers := make([]EventHandler, 0, len(h.handlers[eventType])+len(h.handlers[AllEvents]))
+	handlers = append(handlers, h.handlers[eventType]...)
+	return append(handlers, h.handlers[AllEvents]...)
+}
+
+func (p *TransactionProcessor) runHooks(ctx context.Context, event domain.TransactionEvent) {
+	for _, handler := range p.hooks.matching(event.Type) {
+		if err := p.runHook(ctx, handler, event); err != nil {
+			p.logger.WarnContext(ctx, "Event hook failed",
+				slog.String("event_type", event.Type),
+				slog.String("transaction_id", event.TransactionID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (p *TransactionProcessor) runHook(ctx context.Context, handler EventHandler, event domain.TransactionEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+// publishedEvent adapts data passed to PublishEvent for event hooks.
+func publishedEvent(eventType string, data interface{}) domain.TransactionEvent {
+	event := domain.TransactionEvent{Type: eventType, Payload: data, Timestamp: time.Now()}
+	switch data := data.(type) {
+	case domain.TransactionEventData:
+		event.TransactionID = data.TransactionID
+	case domain.CaseEventData:
+		event.TransactionID = data.TransactionID
+	}
+	return event
+}
@@ -0,0 +1,41 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, eventType string, data interface{})
+}
+
+func (p *TransactionProcessor) WithEventPublisher(publisher EventPublisher) *TransactionProcessor {
+	p.publishers = append(p.publishers, publisher)
+	return p
+}
+
+func (p *TransactionProcessor) PublishEvent(ctx context.Context, eventType string, data interface{}) {
+	for _, publisher := range p.publishers {
+		publisher.Publish(ctx, eventType, data)
+	}
+	p.runHooks(ctx, publishedEvent(eventType, data))
+}
+
+func (p *TransactionProcessor) publishTransaction(ctx context.Context, eventType string, tx *domain.Transaction) {
+	if len(p.publishers) == 0 && !p.hooks.registered() {
+		return
+	}
+	p.PublishEvent(ctx, eventType, domain.TransactionEventData{
+		TransactionID: tx.ID,
+		Reference:     tx.Reference,
+		Type:          tx.Type,
+		Status:        tx.Status,
+		Amount:        p.AmountFor(AmountSinkWebhooks, tx.Amount),
+		Currency:      tx.Currency,
+		FromAccountID: tx.FromAccountID,
+		ToAccountID:   tx.ToAccountID,
+		RiskScore:     tx.RiskScore,
+		FraudFlags:    tx.FraudFlags,
+		Routing:       RoutingHints(tx),
+	})
+}
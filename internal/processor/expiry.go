@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"log/slog"
+	"time"
+)
+
+const MetadataExpiredAt = "expired_at"
+
+// ExpirePending expires pending transactions created before the cutoff.
+// Transactions queued by degraded mode or parked for deposit retry are left
+// alone: those subsystems still own them and will execute them later.
+func (p *TransactionProcessor) ExpirePending(ctx context.Context, createdBefore time.Time) ([]*domain.Transaction, error) {
+	p.overrideMu.Lock()
+	defer p.overrideMu.Unlock()
+
+	pending, err := p.txRepo.GetByStatus(ctx, domain.StatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expired []*domain.Transaction
+	for _, tx := range pending {
+		if !tx.CreatedAt.Before(createdBefore) || p.awaitingExecution(tx.ID) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return expired, err
+		}
+
+		err := p.txRepo.TransitionStatus(ctx, tx.ID, domain.StatusPending, domain.StatusExpired)
+		if errors.Is(err, repository.ErrTransactionConflict) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		tx.AddMetadata(MetadataExpiredAt, now.Format(time.RFC3339))
+		tx.Status = domain.StatusExpired
+		expired = append(expired, tx)
+
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "transaction_expired",
+			Payload:       map[string]interface{}{"created_at": tx.CreatedAt, "amount": p.AmountFor(AmountSinkEvents, tx.Amount)},
+			Timestamp:     now,
+		})
+		p.logger.InfoContext(ctx, "Pending transaction expired",
+			slog.String("transaction_id", tx.ID),
+			slog.Time("created_at", tx.CreatedAt))
+	}
+
+	if len(expired) > 0 {
+		p.recordMetric("transactions_expired", len(expired))
+	}
+	return expired, nil
+}
+
+func (p *TransactionProcessor) awaitingExecution(id string) bool {
+	return (p.degraded != nil && p.degraded.holds(id)) ||
+		(p.depositRetry != nil && p.depositRetry.holds(id))
+}
@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/pkg/money"
+	"fmt"
+	"log/slog"
+)
+
+const PostingFee = "fee"
+
+var ledgerRounding = money.DefaultRoundingPolicies()
+
+func (p *TransactionProcessor) chargeFees(ctx context.Context, tx *domain.Transaction) {
+	if p.products == nil || tx.SystemPosting != "" {
+		return
+	}
+
+	payerID := tx.FromAccountID
+	if tx.Type == domain.TypeDeposit {
+		payerID = tx.ToAccountID
+	}
+	payer, err := p.accountRepo.GetByID(ctx, payerID)
+	if err != nil || payer.ProductID == "" {
+		return
+	}
+	product, err := p.products.GetByID(ctx, payer.ProductID)
+	if err != nil {
+		p.logger.WarnContext(ctx, "Failed to load product for fee calculation",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	fee := ledgerRounding.Round(product.Fees.For(tx.Type).Amount(tx.Amount), tx.Currency)
+	if fee <= 0 {
+		return
+	}
+
+	feesAccount, err := p.SystemAccount(domain.SystemRoleFees, tx.Currency)
+	if err != nil {
+		p.logger.WarnContext(ctx, "Fee not charged, no fee account for currency",
+			slog.String("transaction_id", tx.ID),
+			slog.String("currency", tx.Currency))
+		return
+	}
+
+	feeTx := domain.NewTransaction(domain.TypeTransfer, fee, tx.Currency).
+		WithAccounts(payer.ID, feesAccount).
+		WithDescription(fmt.Sprintf("%s fee", tx.Type))
+	feeTx.AddMetadata(MetadataLinkedTransaction, tx.ID)
+	if err := p.PostSystemTransaction(ctx, feeTx, PostingFee); err != nil {
+		p.logger.ErrorContext(ctx, "Failed to charge transaction fee",
+			slog.String("transaction_id", tx.ID),
+			p.logAmount("fee", fee),
+			slog.String("error", err.Error()))
+	}
+}
+
+// feesDue must be called with p.mu held.
+func (p *TransactionProcessor) feesDue(tx *domain.Transaction, terms accountTerms) float64 {
+	if tx.SystemPosting != "" {
+		return 0
+	}
+	if !p.systemAccts[domain.SystemAccountID(domain.SystemRoleFees, tx.Currency)] {
+		return 0
+	}
+
+	fee := ledgerRounding.Round(terms.fee.Amount(tx.Amount), tx.Currency)
+	for _, corridorFee := range p.corridorFees(tx) {
+		fee += corridorFee
+	}
+	return fee
+}
@@ -2,11 +2,15 @@ package processor
 
 import (
 	"finance_manager/internal/domain"
+	"slices"
 	"time"
 )
 
 type FraudDetector struct {
-	patterns []FraudPattern
+	patterns      []FraudPattern
+	now           func() time.Time
+	timeModifiers *TimeModifierConfig
+	timezones     *accountTimezones
 }
 
 type FraudPattern struct {
@@ -49,26 +53,57 @@ func NewFraudDetector() *FraudDetector {
 	return fd
 }
 
+func NewFraudDetectorWithPatterns(patterns ...FraudPattern) *FraudDetector {
+	return &FraudDetector{patterns: patterns}
+}
+
+func (fd *FraudDetector) WithClock(now func() time.Time) *FraudDetector {
+	fd.now = now
+	return fd
+}
+
+func (fd *FraudDetector) AddPattern(pattern FraudPattern) *FraudDetector {
+	fd.patterns = append(fd.patterns, pattern)
+	return fd
+}
+
 func (fd *FraudDetector) AnalyzeTransaction(tx *domain.Transaction) (int, []string) {
+	riskScore, flags, _, _ := fd.analyze(tx, nil)
+	return riskScore, flags
+}
+
+func (fd *FraudDetector) ExplainTransaction(tx *domain.Transaction, skip ...string) (int, []string) {
+	riskScore, flags, matches, modifier := fd.analyze(tx, skip)
+	tx.ExplainFraud(matches, modifier)
+	return riskScore, flags
+}
+
+func (fd *FraudDetector) analyze(tx *domain.Transaction, skip []string) (int, []string, []domain.PatternMatch, int) {
 	var riskScore int
 	var flags []string
+	var matches []domain.PatternMatch
 
 	for _, pattern := range fd.patterns {
+		if slices.Contains(skip, pattern.Name) {
+			continue
+		}
 		if detected, flag := pattern.Detect(tx); detected {
 			riskScore += pattern.Weight
 			flags = append(flags, flag)
+			matches = append(matches, domain.PatternMatch{Name: pattern.Name, Flag: flag, Weight: pattern.Weight})
 		}
 	}
 
+	var modifier int
 	if riskScore > 0 {
-		riskScore = fd.applyTimeBasedModifiers(tx, riskScore)
+		modifier = fd.applyTimeBasedModifiers(tx, riskScore) - riskScore
 	}
 
-	return min(riskScore, 100), flags
+	return min(riskScore+modifier, 100), flags, matches, modifier
 }
 
 func (fd *FraudDetector) detectFrequentTransactions(tx *domain.Transaction) (bool, string) {
-	return tx.Amount > 5000 && time.Now().Hour() < 6, "frequent_transactions"
+	return tx.Amount > 5000 && fd.clock().Hour() < 6, "frequent_transactions"
 }
 
 func (fd *FraudDetector) detectGeographicalAnomaly(tx *domain.Transaction) (bool, string) {
@@ -86,9 +121,25 @@ func (fd *FraudDetector) detectVelocityAnomaly(tx *domain.Transaction) (bool, st
 }
 
 func (fd *FraudDetector) applyTimeBasedModifiers(tx *domain.Transaction, baseScore int) int {
-	hour := time.Now().Hour()
-	if hour >= 23 || hour <= 5 {
-		return baseScore + 15
+	cfg := fd.timeModifierConfig()
+	local := fd.localTime(tx, cfg)
+
+	var windowPoints int
+	for _, window := range cfg.RiskyHours {
+		if window.Contains(local.Hour()) {
+			windowPoints = max(windowPoints, window.Points)
+		}
+	}
+	score := baseScore + windowPoints
+	if _, holiday := cfg.Holidays.Holiday(local); holiday {
+		score += cfg.HolidayPoints
+	}
+	return score
+}
+
+func (fd *FraudDetector) clock() time.Time {
+	if fd.now == nil {
+		return time.Now()
 	}
-	return baseScore
+	return fd.now()
 }
@@ -0,0 +1,209 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	MetadataInstallmentPlan   = "installment_plan_id"
+	MetadataInstallmentNumber = "installment_number"
+	MetadataInstallmentDueAt  = "installment_due_at"
+)
+
+var (
+	ErrInvalidInstallmentPlan  = errors.New("invalid installment plan")
+	ErrInstallmentLimit        = errors.New("installment exceeds account limit")
+	ErrInstallmentNotScheduled = errors.New("installment is not scheduled")
+)
+
+func (p *TransactionProcessor) ScheduleInstallments(ctx context.Context, plan *domain.InstallmentPlan) ([]*domain.Transaction, error) {
+	if plan.Amount <= 0 || math.IsNaN(plan.Amount) || math.IsInf(plan.Amount, 0) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidInstallmentPlan)
+	}
+	if len(plan.DueDates) < 2 {
+		return nil, fmt.Errorf("%w: at least two installments are required", ErrInvalidInstallmentPlan)
+	}
+	if plan.FromAccountID == "" || plan.ToAccountID == "" || plan.FromAccountID == plan.ToAccountID {
+		return nil, fmt.Errorf("%w: distinct from and to accounts are required", ErrInvalidInstallmentPlan)
+	}
+
+	fromAccount, err := p.accountRepo.GetByID(ctx, plan.FromAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from account: %w", err)
+	}
+	toAccount, err := p.accountRepo.GetByID(ctx, plan.ToAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get to account: %w", err)
+	}
+	if fromAccount.Currency != plan.Currency || toAccount.Currency != plan.Currency {
+		return nil, fmt.Errorf("%w: currency mismatch: %s/%s/%s", ErrInvalidInstallmentPlan, plan.Currency, fromAccount.Currency, toAccount.Currency)
+	}
+	if fromAccount.Status != domain.AccountActive || toAccount.Status != domain.AccountActive {
+		return nil, fmt.Errorf("%w: both accounts must be active", ErrInvalidInstallmentPlan)
+	}
+
+	terms, err := p.accountTerms(ctx, fromAccount, domain.TypeTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts, err := splitInstallments(plan.Amount, plan.Currency, len(plan.DueDates))
+	if err != nil {
+		return nil, err
+	}
+
+	count := strconv.Itoa(len(plan.DueDates))
+	txs := make([]*domain.Transaction, len(amounts))
+	for i, amount := range amounts {
+		tx := domain.NewTransaction(domain.TypeTransfer, amount, plan.Currency).
+			WithAccounts(plan.FromAccountID, plan.ToAccountID).
+			WithDescription(plan.Description)
+		tx.Status = domain.StatusScheduled
+		tx.AddMetadata(MetadataInstallmentPlan, plan.ID)
+		tx.AddMetadata(MetadataInstallmentNumber, strconv.Itoa(i+1)+"/"+count)
+		tx.AddMetadata(MetadataInstallmentDueAt, plan.DueDates[i].Format(time.RFC3339))
+		txs[i] = tx
+	}
+
+	if err := checkInstallmentLimits(terms, plan.DueDates, txs); err != nil {
+		return nil, err
+	}
+
+	if err := p.txRepo.SaveAll(ctx, txs); err != nil {
+		return nil, fmt.Errorf("failed to save installments: %w", err)
+	}
+
+	plan.TransactionIDs = make([]string, len(txs))
+	for i, tx := range txs {
+		plan.TransactionIDs[i] = tx.ID
+	}
+
+	p.logger.InfoContext(ctx, "Installments scheduled",
+		slog.String("plan_id", plan.ID),
+		slog.String("from_account", plan.FromAccountID),
+		slog.Int("installments", len(txs)),
+		p.logAmount("amount", plan.Amount))
+
+	p.recordMetric("installments_scheduled", len(txs))
+	return txs, nil
+}
+
+func (p *TransactionProcessor) ExecuteInstallment(ctx context.Context, transactionID string) (*domain.Transaction, error) {
+	p.overrideMu.Lock()
+	defer p.overrideMu.Unlock()
+
+	tx, err := p.txRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Status != domain.StatusScheduled {
+		return tx, fmt.Errorf("%w: transaction %s is %s", ErrInstallmentNotScheduled, tx.ID, tx.Status)
+	}
+
+	tx.CreatedAt = time.Now()
+	err = p.process(ctx, tx, func(ctx context.Context) error {
+		return p.txRepo.UpdateStatus(ctx, tx.ID, tx.Status)
+	})
+	if err != nil {
+		if updateErr := p.txRepo.UpdateStatus(ctx, tx.ID, domain.StatusFailed); updateErr != nil {
+			return tx, errors.Join(err, updateErr)
+		}
+		tx.Status = domain.StatusFailed
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "installment_failed",
+			Payload:       map[string]interface{}{"plan_id": tx.Metadata[MetadataInstallmentPlan], "error": err.Error()},
+			Timestamp:     time.Now(),
+		})
+		return tx, err
+	}
+
+	return tx, nil
+}
+
+func (p *TransactionProcessor) CancelInstallments(ctx context.Context, transactionIDs []string) (int, error) {
+	p.overrideMu.Lock()
+	defer p.overrideMu.Unlock()
+
+	var cancelled int
+	for _, id := range transactionIDs {
+		tx, err := p.txRepo.GetByID(ctx, id)
+		if err != nil {
+			return cancelled, err
+		}
+		if tx.Status != domain.StatusScheduled {
+			continue
+		}
+		if err := p.txRepo.UpdateStatus(ctx, id, domain.StatusCancelled); err != nil {
+			return cancelled, err
+		}
+		cancelled++
+
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: id,
+			Type:          "installment_cancelled",
+			Payload:       map[string]interface{}{"plan_id": tx.Metadata[MetadataInstallmentPlan]},
+			Timestamp:     time.Now(),
+		})
+	}
+
+	if cancelled > 0 {
+		p.recordMetric("installments_cancelled", cancelled)
+	}
+	return cancelled, nil
+}
+
+func InstallmentDueAt(tx *domain.Transaction) (time.Time, bool) {
+	due, err := time.Parse(time.RFC3339, tx.Metadata[MetadataInstallmentDueAt])
+	return due, err == nil
+}
+
+func splitInstallments(amount float64, currency string, count int) ([]float64, error) {
+	factor := math.Pow10(ledgerRounding.Policy(currency).MinorUnits)
+	units := int64(math.Round(amount * factor))
+	base := units / int64(count)
+	if base == 0 {
+		return nil, fmt.Errorf("%w: %v %s cannot be split into %d installments", ErrInvalidInstallmentPlan, amount, currency, count)
+	}
+	remainder := units % int64(count)
+
+	amounts := make([]float64, count)
+	for i := range amounts {
+		share := base
+		if int64(i) < remainder {
+			share++
+		}
+		amounts[i] = float64(share) / factor
+	}
+	return amounts, nil
+}
+
+func checkInstallmentLimits(terms accountTerms, dueDates []time.Time, txs []*domain.Transaction) error {
+	daily := make(map[string]float64)
+	monthly := make(map[string]float64)
+	for i, tx := range txs {
+		due := dueDates[i].UTC()
+		if terms.dailyLimit > 0 {
+			day := due.Format(time.DateOnly)
+			daily[day] += tx.Amount
+			if !tx.AddLimitCheck("installment_daily_limit", terms.dailyLimit, daily[day]) {
+				return fmt.Errorf("%w: installment %d due %s: %.2f/%.2f daily", ErrInstallmentLimit, i+1, day, daily[day], terms.dailyLimit)
+			}
+		}
+		if terms.monthlyLimit > 0 {
+			month := due.Format("2006-01")
+			monthly[month] += tx.Amount
+			if !tx.AddLimitCheck("installment_monthly_limit", terms.monthlyLimit, monthly[month]) {
+				return fmt.Errorf("%w: installment %d due %s: %.2f/%.2f monthly", ErrInstallmentLimit, i+1, month, monthly[month], terms.monthlyLimit)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	AuditActionLimitOverride = "limit_override"
+	AuditEntityAccount       = "account"
+)
+
+var ErrInvalidLimitOverride = errors.New("invalid limit override")
+
+type LimitOverride struct {
+	AccountID string           `json:"account_id"`
+	LimitType domain.LimitType `json:"limit_type"`
+	Limit     float64          `json:"limit"`
+	Reason    string           `json:"reason"`
+	Operator  string           `json:"operator"`
+}
+
+func (o LimitOverride) validate() error {
+	if o.AccountID == "" {
+		return fmt.Errorf("%w: account is required", ErrInvalidLimitOverride)
+	}
+	if o.LimitType != domain.LimitDaily && o.LimitType != domain.LimitMonthly {
+		return fmt.Errorf("%w: unknown limit type %q", ErrInvalidLimitOverride, o.LimitType)
+	}
+	if o.Limit < 0 || math.IsNaN(o.Limit) || math.IsInf(o.Limit, 0) {
+		return fmt.Errorf("%w: limit must be a non-negative amount", ErrInvalidLimitOverride)
+	}
+	if o.Reason == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidLimitOverride)
+	}
+	if o.Operator == "" {
+		return fmt.Errorf("%w: operator is required", ErrInvalidLimitOverride)
+	}
+	return nil
+}
+
+func (p *TransactionProcessor) OverrideAccountLimit(ctx context.Context, override LimitOverride) (*domain.Account, error) {
+	if err := override.validate(); err != nil {
+		return nil, err
+	}
+	if p.auditRepo == nil {
+		return nil, ErrAuditNotConfigured
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	account, err := p.accountRepo.GetByID(ctx, override.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := account.Limit(override.LimitType)
+	account.SetLimit(override.LimitType, override.Limit)
+	if err := p.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	entry := domain.NewAuditEntry(AuditActionLimitOverride, AuditEntityAccount, account.ID, override.Operator, override.Reason)
+	entry.Details["limit_type"] = string(override.LimitType)
+	entry.Details["previous_limit"] = strconv.FormatFloat(previous, 'f', 2, 64)
+	entry.Details["new_limit"] = strconv.FormatFloat(override.Limit, 'f', 2, 64)
+	err = repository.SaveWithFreshID(
+		func() error { return p.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to record audit entry for limit override",
+			slog.String("account_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+
+	p.emitEvent(ctx, domain.TransactionEvent{
+		Type:      "limit_overridden",
+		Payload:   map[string]interface{}{"account_id": account.ID, "limit_type": override.LimitType, "previous_limit": previous, "new_limit": override.Limit, "operator": override.Operator},
+		Timestamp: time.Now(),
+	})
+
+	p.logger.InfoContext(ctx, "Account limit overridden",
+		slog.String("account_id", account.ID),
+		slog.String("operator", override.Operator),
+		slog.String("limit_type", string(override.LimitType)),
+		slog.Float64("previous_limit", previous),
+		slog.Float64("new_limit", override.Limit))
+
+	return account, nil
+}
@@ -0,0 +1,134 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const EventLimitThresholdCrossed = "limit_threshold_crossed"
+
+var ErrInvalidLimitThreshold = errors.New("invalid limit threshold")
+
+type LimitThresholdConfig struct {
+	Percentages []float64
+}
+
+func DefaultLimitThresholdConfig() LimitThresholdConfig {
+	return LimitThresholdConfig{Percentages: []float64{50, 80, 100}}
+}
+
+func ParseLimitThresholds(spec string) ([]float64, error) {
+	var percentages []float64
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(part), "%"))
+		if part == "" {
+			continue
+		}
+		percentage, err := strconv.ParseFloat(part, 64)
+		if err != nil || percentage <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLimitThreshold, part)
+		}
+		percentages = append(percentages, percentage)
+	}
+	if len(percentages) == 0 {
+		return nil, fmt.Errorf("%w: no percentages", ErrInvalidLimitThreshold)
+	}
+	return percentages, nil
+}
+
+type LimitThresholdCrossing struct {
+	AccountID     string           `json:"account_id"`
+	TransactionID string           `json:"transaction_id"`
+	LimitType     domain.LimitType `json:"limit_type"`
+	Threshold     float64          `json:"threshold"`
+	Limit         float64          `json:"limit"`
+	Used          float64          `json:"used"`
+}
+
+type LimitThresholdObserver interface {
+	LimitThresholdCrossed(ctx context.Context, crossing LimitThresholdCrossing)
+}
+
+type limitThresholds struct {
+	percentages []float64
+	observer    LimitThresholdObserver
+}
+
+func (p *TransactionProcessor) WithLimitThresholds(cfg LimitThresholdConfig, observer LimitThresholdObserver) *TransactionProcessor {
+	percentages := append([]float64(nil), cfg.Percentages...)
+	sort.Float64s(percentages)
+	p.thresholds = &limitThresholds{percentages: percentages, observer: observer}
+	return p
+}
+
+func (p *TransactionProcessor) limitThresholdCrossings(tx *domain.Transaction) []LimitThresholdCrossing {
+	if p.thresholds == nil || tx.Explanation == nil || tx.FromAccountID == "" {
+		return nil
+	}
+
+	checks := make(map[string]domain.LimitCheck)
+	for _, check := range tx.Explanation.LimitChecks {
+		checks[check.Name] = check
+	}
+
+	var crossings []LimitThresholdCrossing
+	for _, limit := range []struct {
+		check     string
+		limitType domain.LimitType
+	}{
+		{"daily_limit", domain.LimitDaily},
+		{"monthly_limit", domain.LimitMonthly},
+	} {
+		check, ok := checks[limit.check]
+		if !ok || !check.Passed || check.Limit <= 0 {
+			continue
+		}
+
+		previous := check.Amount - tx.Amount
+		crossed := 0.0
+		for _, percentage := range p.thresholds.percentages {
+			threshold := check.Limit * percentage / 100
+			if previous < threshold && check.Amount >= threshold {
+				crossed = percentage
+			}
+		}
+		if crossed == 0 {
+			continue
+		}
+		crossings = append(crossings, LimitThresholdCrossing{
+			AccountID:     tx.FromAccountID,
+			TransactionID: tx.ID,
+			LimitType:     limit.limitType,
+			Threshold:     crossed,
+			Limit:         check.Limit,
+			Used:          check.Amount,
+		})
+	}
+	return crossings
+}
+
+func (p *TransactionProcessor) notifyLimitThresholds(ctx context.Context, tx *domain.Transaction) {
+	for _, crossing := range p.limitThresholdCrossings(tx) {
+		p.logger.InfoContext(ctx, "Limit threshold crossed",
+			slog.String("account_id", crossing.AccountID),
+			slog.String("transaction_id", crossing.TransactionID),
+			slog.String("limit_type", string(crossing.LimitType)),
+			slog.Float64("threshold", crossing.Threshold))
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          EventLimitThresholdCrossed,
+			Payload:       crossing,
+			Timestamp:     time.Now(),
+		})
+		if p.thresholds.observer != nil {
+			p.thresholds.observer.LimitThresholdCrossed(ctx, crossing)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	maxDepositAmount     = 50000.0
+	dailyWithdrawalLimit = 5000.0
+)
+
+type LimitTerms struct {
+	DailyLimit   float64 `json:"daily_limit"`
+	MonthlyLimit float64 `json:"monthly_limit"`
+}
+
+// LimitService checks transactions against account limits and records usage.
+type LimitService interface {
+	CheckTransfer(ctx context.Context, account *domain.Account, terms LimitTerms, tx *domain.Transaction) error
+	CheckDeposit(ctx context.Context, account *domain.Account, tx *domain.Transaction) error
+	CheckWithdrawal(ctx context.Context, account *domain.Account, tx *domain.Transaction) error
+	RecordUsage(ctx context.Context, tx *domain.Transaction) error
+}
+
+func (p *TransactionProcessor) WithLimitService(limits LimitService) *TransactionProcessor {
+	p.limits = limits
+	return p
+}
+
+func (p *TransactionProcessor) recordLimitUsage(ctx context.Context, tx *domain.Transaction) {
+	if err := p.limits.RecordUsage(ctx, tx); err != nil {
+		p.logger.WarnContext(ctx, "Failed to record limit usage",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// RepositoryLimitService derives usage from the local transaction repository.
+type RepositoryLimitService struct {
+	txRepo repository.TransactionRepository
+}
+
+func NewRepositoryLimitService(txRepo repository.TransactionRepository) *RepositoryLimitService {
+	return &RepositoryLimitService{txRepo: txRepo}
+}
+
+func (s *RepositoryLimitService) CheckTransfer(ctx context.Context, account *domain.Account, terms LimitTerms, tx *domain.Transaction) error {
+	dailyVolume, err := s.txRepo.GetDailyVolume(ctx, account.ID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get daily volume: %w", err)
+	}
+
+	if terms.DailyLimit > 0 && !tx.AddLimitCheck("daily_limit", terms.DailyLimit, dailyVolume+tx.Amount) {
+		return fmt.Errorf("daily %w: %.2f/%.2f", ErrLimitExceeded, dailyVolume+tx.Amount, terms.DailyLimit)
+	}
+
+	now := time.Now()
+	monthlyVolume, err := s.txRepo.GetMonthlyVolume(ctx, account.ID, now.Year(), now.Month())
+	if err != nil {
+		return fmt.Errorf("failed to get monthly volume: %w", err)
+	}
+
+	if terms.MonthlyLimit > 0 && !tx.AddLimitCheck("monthly_limit", terms.MonthlyLimit, monthlyVolume+tx.Amount) {
+		return fmt.Errorf("monthly %w: %.2f/%.2f", ErrLimitExceeded, monthlyVolume+tx.Amount, terms.MonthlyLimit)
+	}
+
+	return nil
+}
+
+func (s *RepositoryLimitService) CheckDeposit(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
+	if !tx.AddLimitCheck("max_deposit", maxDepositAmount, tx.Amount) {
+		return fmt.Errorf("deposit %w: %.2f/%.2f", ErrLimitExceeded, tx.Amount, maxDepositAmount)
+	}
+
+	return nil
+}
+
+func (s *RepositoryLimitService) CheckWithdrawal(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
+	dailyWithdrawal, err := s.dailyWithdrawal(ctx, account.ID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get daily withdrawal: %w", err)
+	}
+
+	if !tx.AddLimitCheck("daily_withdrawal", dailyWithdrawalLimit, dailyWithdrawal+tx.Amount) {
+		return fmt.Errorf("daily withdrawal %w: %.2f/%.2f", ErrLimitExceeded, dailyWithdrawal+tx.Amount, dailyWithdrawalLimit)
+	}
+
+	return nil
+}
+
+func (s *RepositoryLimitService) RecordUsage(ctx context.Context, tx *domain.Transaction) error {
+	return nil
+}
+
+func (s *RepositoryLimitService) dailyWithdrawal(ctx context.Context, accountID string, date time.Time) (float64, error) {
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+
+	filter := repository.TransactionFilter{
+		AccountID: accountID,
+		Type:      domain.TypeWithdrawal,
+		Status:    domain.StatusCompleted,
+		From:      startOfDay,
+		To:        endOfDay,
+	}
+
+	var totalWithdrawal float64
+	err := s.txRepo.Iterate(ctx, filter, func(tx *domain.Transaction) error {
+		if tx.FromAccountID == accountID {
+			totalWithdrawal += tx.Amount
+		}
+		return nil
+	})
+
+	return totalWithdrawal, err
+}
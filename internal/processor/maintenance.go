@@ -0,0 +1,212 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MaintenanceReject = "reject"
+	MaintenanceQueue  = "queue"
+
+	MetadataMaintenanceWindow = "maintenance_window"
+)
+
+var (
+	ErrMaintenanceDisabled       = errors.New("maintenance windows are not configured")
+	ErrUnderMaintenance          = errors.New("system is under maintenance")
+	ErrInvalidMaintenanceWindow  = errors.New("invalid maintenance window")
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+)
+
+type MaintenanceWindow struct {
+	ID        string    `json:"id"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Mode      string    `json:"mode"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+func (w MaintenanceWindow) activeAt(now time.Time) bool {
+	return !now.Before(w.Start) && now.Before(w.End)
+}
+
+type MaintenanceError struct {
+	Window MaintenanceWindow
+}
+
+func (e *MaintenanceError) Error() string {
+	return fmt.Sprintf("%v until %s", ErrUnderMaintenance, e.Window.End.UTC().Format(time.RFC3339))
+}
+
+func (e *MaintenanceError) Unwrap() error {
+	return ErrUnderMaintenance
+}
+
+type MaintenanceStatus struct {
+	Active   bool                `json:"active"`
+	Window   *MaintenanceWindow  `json:"window,omitempty"`
+	Upcoming []MaintenanceWindow `json:"upcoming"`
+}
+
+type maintenanceWindows struct {
+	mu      sync.Mutex
+	windows []MaintenanceWindow
+}
+
+// ParseMaintenanceWindows parses comma-separated "start/end[/mode]" windows.
+func ParseMaintenanceWindows(spec string) ([]MaintenanceWindow, error) {
+	var windows []MaintenanceWindow
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		fields := strings.Split(part, "/")
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMaintenanceWindow, part)
+		}
+		start, err := time.Parse(time.RFC3339, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMaintenanceWindow, part)
+		}
+		end, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMaintenanceWindow, part)
+		}
+		window := MaintenanceWindow{Start: start, End: end, Mode: MaintenanceReject, Reason: "scheduled maintenance"}
+		if len(fields) == 3 {
+			window.Mode = fields[2]
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+func (p *TransactionProcessor) WithMaintenanceWindows(windows []MaintenanceWindow) *TransactionProcessor {
+	p.maintenance = &maintenanceWindows{}
+	for _, window := range windows {
+		if _, err := p.ScheduleMaintenance(window); err != nil {
+			p.logger.Warn("Ignoring invalid maintenance window",
+				slog.Time("start", window.Start),
+				slog.Time("end", window.End),
+				slog.String("error", err.Error()))
+		}
+	}
+	return p
+}
+
+func (p *TransactionProcessor) ScheduleMaintenance(window MaintenanceWindow) (MaintenanceWindow, error) {
+	if p.maintenance == nil {
+		return MaintenanceWindow{}, ErrMaintenanceDisabled
+	}
+	if window.Mode == "" {
+		window.Mode = MaintenanceReject
+	}
+	switch {
+	case window.Start.IsZero() || !window.End.After(window.Start):
+		return MaintenanceWindow{}, fmt.Errorf("%w: end must be after start", ErrInvalidMaintenanceWindow)
+	case !window.End.After(time.Now()):
+		return MaintenanceWindow{}, fmt.Errorf("%w: window has already ended", ErrInvalidMaintenanceWindow)
+	case window.Mode != MaintenanceReject && window.Mode != MaintenanceQueue:
+		return MaintenanceWindow{}, fmt.Errorf("%w: unsupported mode %q", ErrInvalidMaintenanceWindow, window.Mode)
+	case window.Mode == MaintenanceQueue && p.degraded == nil:
+		// Queued transactions are drained through the degraded mode backlog.
+		return MaintenanceWindow{}, fmt.Errorf("%w: queue mode requires degraded mode", ErrInvalidMaintenanceWindow)
+	}
+	window.ID = domain.NewID()
+
+	m := p.maintenance
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.windows {
+		if window.Start.Before(existing.End) && existing.Start.Before(window.End) {
+			return MaintenanceWindow{}, fmt.Errorf("%w: overlaps window %s", ErrInvalidMaintenanceWindow, existing.ID)
+		}
+	}
+	m.windows = append(m.windows, window)
+	slices.SortFunc(m.windows, func(a, b MaintenanceWindow) int { return a.Start.Compare(b.Start) })
+
+	p.logger.Info("Maintenance window scheduled",
+		slog.String("window_id", window.ID),
+		slog.Time("start", window.Start),
+		slog.Time("end", window.End),
+		slog.String("mode", window.Mode))
+	return window, nil
+}
+
+func (p *TransactionProcessor) CancelMaintenance(id string) error {
+	if p.maintenance == nil {
+		return ErrMaintenanceDisabled
+	}
+	m := p.maintenance
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	index := slices.IndexFunc(m.windows, func(window MaintenanceWindow) bool { return window.ID == id })
+	if index < 0 {
+		return fmt.Errorf("%w: %s", ErrMaintenanceWindowNotFound, id)
+	}
+	m.windows = slices.Delete(m.windows, index, index+1)
+	p.logger.Info("Maintenance window cancelled", slog.String("window_id", id))
+	return nil
+}
+
+func (p *TransactionProcessor) MaintenanceStatus(now time.Time) MaintenanceStatus {
+	status := MaintenanceStatus{Upcoming: []MaintenanceWindow{}}
+	if p.maintenance == nil {
+		return status
+	}
+	m := p.maintenance
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.windows = slices.DeleteFunc(m.windows, func(window MaintenanceWindow) bool { return !now.Before(window.End) })
+	for _, window := range m.windows {
+		if window.activeAt(now) {
+			active := window
+			status.Active = true
+			status.Window = &active
+			continue
+		}
+		status.Upcoming = append(status.Upcoming, window)
+	}
+	return status
+}
+
+// MaintenanceHold pauses scheduled jobs for the duration of a window.
+func (p *TransactionProcessor) MaintenanceHold(now time.Time) (string, bool) {
+	status := p.MaintenanceStatus(now)
+	if !status.Active {
+		return "", false
+	}
+	return fmt.Sprintf("maintenance window %s", status.Window.ID), true
+}
+
+func (p *TransactionProcessor) activeMaintenance() (MaintenanceWindow, bool) {
+	status := p.MaintenanceStatus(time.Now())
+	if !status.Active {
+		return MaintenanceWindow{}, false
+	}
+	return *status.Window, true
+}
+
+func (p *TransactionProcessor) checkMaintenance(ctx context.Context, tx *domain.Transaction) error {
+	window, active := p.activeMaintenance()
+	if !active {
+		return nil
+	}
+	if window.Mode == MaintenanceReject {
+		traceStep(ctx, TraceStageDecision, "maintenance", TraceOutcomeFailed, map[string]string{"window_id": window.ID})
+		return &MaintenanceError{Window: window}
+	}
+	tx.AddMetadata(MetadataMaintenanceWindow, window.ID)
+	return nil
+}
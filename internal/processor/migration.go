@@ -0,0 +1,185 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	MetadataMigrationReference = "migration_reference"
+	MetadataAdjustmentReason   = "adjustment_reason"
+
+	AuditActionBalanceMigration = "balance_migration"
+	AuditEntityMigration        = "migration"
+)
+
+var ErrInvalidMigration = errors.New("invalid balance migration")
+
+type BalanceAdjustment struct {
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Reason    string  `json:"reason"`
+}
+
+type BalanceMigration struct {
+	Reference   string              `json:"reference"`
+	Operator    string              `json:"operator"`
+	Adjustments []BalanceAdjustment `json:"adjustments"`
+}
+
+func (m BalanceMigration) validate() error {
+	if m.Reference == "" {
+		return fmt.Errorf("%w: reference is required", ErrInvalidMigration)
+	}
+	if m.Operator == "" {
+		return fmt.Errorf("%w: operator is required", ErrInvalidMigration)
+	}
+	if len(m.Adjustments) == 0 {
+		return fmt.Errorf("%w: at least one adjustment is required", ErrInvalidMigration)
+	}
+	for i, adjustment := range m.Adjustments {
+		if adjustment.AccountID == "" {
+			return fmt.Errorf("%w: adjustment %d has no account", ErrInvalidMigration, i)
+		}
+		if adjustment.Amount == 0 || math.IsNaN(adjustment.Amount) || math.IsInf(adjustment.Amount, 0) {
+			return fmt.Errorf("%w: adjustment %d has invalid amount", ErrInvalidMigration, i)
+		}
+	}
+	return nil
+}
+
+// ApplyBalanceMigration applies every adjustment or none.
+func (p *TransactionProcessor) ApplyBalanceMigration(ctx context.Context, migration BalanceMigration) ([]*domain.Transaction, error) {
+	if err := migration.validate(); err != nil {
+		return nil, err
+	}
+	if p.auditRepo == nil {
+		return nil, ErrAuditNotConfigured
+	}
+
+	txs, err := p.applyBalanceMigration(ctx, migration)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "balance_adjusted",
+			Payload:       map[string]interface{}{"migration_reference": migration.Reference, "operator": migration.Operator},
+			Timestamp:     tx.CreatedAt,
+		})
+	}
+	return txs, nil
+}
+
+func (p *TransactionProcessor) applyBalanceMigration(ctx context.Context, migration BalanceMigration) ([]*domain.Transaction, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	applied, err := p.migrationApplied(ctx, migration.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if applied {
+		return nil, fmt.Errorf("%w: migration %s already applied", repository.ErrDuplicate, migration.Reference)
+	}
+
+	now := time.Now()
+	updates := make([]domain.BalanceUpdate, 0, len(migration.Adjustments))
+	txs := make([]*domain.Transaction, 0, len(migration.Adjustments))
+	for _, adjustment := range migration.Adjustments {
+		account, err := p.accountRepo.GetByID(ctx, adjustment.AccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := domain.NewTransaction(domain.TypeAdjustment, math.Abs(adjustment.Amount), account.Currency)
+		if adjustment.Amount > 0 {
+			tx.ToAccountID = account.ID
+		} else {
+			tx.FromAccountID = account.ID
+		}
+		tx.Description = adjustment.Reason
+		tx.Status = domain.StatusCompleted
+		tx.CreatedAt = now
+		tx.UpdatedAt = now
+		tx.AddMetadata(MetadataMigrationReference, migration.Reference)
+		tx.AddMetadata(MetadataAdjustmentReason, adjustment.Reason)
+		txs = append(txs, tx)
+
+		updates = append(updates, domain.BalanceUpdate{
+			AccountID: account.ID,
+			Amount:    adjustment.Amount,
+			Type:      string(domain.TypeAdjustment),
+			Timestamp: now,
+		})
+	}
+
+	if err := p.accountRepo.ApplyBalanceUpdates(ctx, updates); err != nil {
+		return nil, err
+	}
+	if err := p.txRepo.SaveAll(ctx, txs); err != nil {
+		p.revertMigration(ctx, migration.Reference, updates)
+		return nil, fmt.Errorf("failed to record migration adjustments: %w", err)
+	}
+
+	var net float64
+	for _, update := range updates {
+		net += update.Amount
+	}
+
+	entry := domain.NewAuditEntry(AuditActionBalanceMigration, AuditEntityMigration, migration.Reference, migration.Operator, "legacy balance migration")
+	entry.Details["adjustments"] = strconv.Itoa(len(updates))
+	entry.Details["net_amount"] = strconv.FormatFloat(net, 'f', 2, 64)
+	err = repository.SaveWithFreshID(
+		func() error { return p.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to record audit entry for balance migration",
+			slog.String("migration_reference", migration.Reference),
+			slog.String("error", err.Error()))
+	}
+
+	p.logger.InfoContext(ctx, "Balance migration applied",
+		slog.String("migration_reference", migration.Reference),
+		slog.String("operator", migration.Operator),
+		slog.Int("adjustments", len(updates)),
+		p.logAmount("net_amount", net))
+
+	p.metrics["balance_adjustments"] += len(updates)
+	return txs, nil
+}
+
+func (p *TransactionProcessor) migrationApplied(ctx context.Context, reference string) (bool, error) {
+	var applied bool
+	err := p.txRepo.Iterate(ctx, repository.TransactionFilter{Type: domain.TypeAdjustment}, func(tx *domain.Transaction) error {
+		if tx.Status != domain.StatusFailed && tx.Metadata[MetadataMigrationReference] == reference {
+			applied = true
+			return repository.ErrStopIteration
+		}
+		return nil
+	})
+	return applied, err
+}
+
+func (p *TransactionProcessor) revertMigration(ctx context.Context, reference string, updates []domain.BalanceUpdate) {
+	reversal := make([]domain.BalanceUpdate, len(updates))
+	for i, update := range updates {
+		update.Amount = -update.Amount
+		reversal[i] = update
+	}
+	if err := p.accountRepo.ApplyBalanceUpdates(ctx, reversal); err != nil {
+		p.logger.ErrorContext(ctx, "Failed to revert balance migration",
+			slog.String("migration_reference", reference),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,137 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const MetadataLegIndex = "leg_index"
+
+// creditLegs returns the legs of a transfer, or its single credit if it has none.
+func creditLegs(tx *domain.Transaction) []domain.TransactionLeg {
+	if len(tx.Legs) > 0 {
+		return tx.Legs
+	}
+	return []domain.TransactionLeg{{ToAccountID: tx.ToAccountID, Amount: tx.Amount}}
+}
+
+func (p *TransactionProcessor) processMultiLegTransfer(ctx context.Context, tx *domain.Transaction) error {
+	p.logger.InfoContext(ctx, "Processing multi-leg transfer",
+		slog.String("transaction_id", tx.ID),
+		slog.String("from_account", tx.FromAccountID),
+		slog.Int("legs", len(tx.Legs)),
+		p.logAmount("amount", tx.Amount))
+
+	fromAccount, err := p.checkMultiLegTransfer(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	updates := make([]domain.BalanceUpdate, 0, len(tx.Legs)+1)
+	updates = append(updates, domain.BalanceUpdate{AccountID: fromAccount.ID, Amount: -tx.Amount, Type: string(tx.Type), Timestamp: now})
+	entries := make([]*domain.Transaction, 0, len(tx.Legs))
+	for i, leg := range tx.Legs {
+		updates = append(updates, domain.BalanceUpdate{AccountID: leg.ToAccountID, Amount: leg.Amount, Type: string(tx.Type), Timestamp: now})
+		entries = append(entries, legEntry(tx, i, now))
+	}
+
+	if err := p.accountRepo.ApplyBalanceUpdates(ctx, updates); err != nil {
+		return fmt.Errorf("failed to apply transfer legs: %w", err)
+	}
+	if err := p.txRepo.SaveAll(ctx, entries); err != nil {
+		for i := range updates {
+			updates[i].Amount = -updates[i].Amount
+		}
+		if revertErr := p.accountRepo.ApplyBalanceUpdates(ctx, updates); revertErr != nil {
+			p.logger.ErrorContext(ctx, "Failed to revert multi-leg transfer",
+				slog.String("transaction_id", tx.ID),
+				slog.String("error", revertErr.Error()))
+		}
+		return fmt.Errorf("failed to record transfer legs: %w", err)
+	}
+
+	p.logger.InfoContext(ctx, "Multi-leg transfer completed successfully",
+		slog.String("transaction_id", tx.ID))
+	return nil
+}
+
+func (p *TransactionProcessor) checkMultiLegTransfer(ctx context.Context, tx *domain.Transaction) (*domain.Account, error) {
+	fromAccount, err := p.accountRepo.GetByID(ctx, tx.FromAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from account: %w", err)
+	}
+	if fromAccount.Status != domain.AccountActive {
+		return nil, fmt.Errorf("from %w: %s", ErrAccountInactive, fromAccount.Status)
+	}
+
+	legAccounts := make([]*domain.Account, len(tx.Legs))
+	for i, leg := range tx.Legs {
+		toAccount, err := p.accountRepo.GetByID(ctx, leg.ToAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account for leg %d: %w", i, err)
+		}
+		if fromAccount.Currency != toAccount.Currency {
+			return nil, fmt.Errorf("leg %d %w: %s != %s", i, ErrCurrencyMismatch, fromAccount.Currency, toAccount.Currency)
+		}
+		if toAccount.Status != domain.AccountActive {
+			return nil, fmt.Errorf("leg %d %w: %s", i, ErrAccountInactive, toAccount.Status)
+		}
+		tx.Legs[i].Corridor = ""
+		legAccounts[i] = toAccount
+	}
+
+	if err := p.checkLegCorridors(ctx, fromAccount, legAccounts, tx); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkPaymentPurpose(tx); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkSpendingControls(ctx, fromAccount, tx, legAccounts...); err != nil {
+		return nil, err
+	}
+
+	terms, err := p.accountTerms(ctx, fromAccount, tx.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Balance+terms.overdraft < tx.Amount+p.feesDue(tx, terms) {
+		return nil, repository.ErrInsufficientFunds
+	}
+
+	if err := p.limits.CheckTransfer(ctx, fromAccount, LimitTerms{DailyLimit: terms.dailyLimit, MonthlyLimit: terms.monthlyLimit}, tx); err != nil {
+		return nil, err
+	}
+
+	return fromAccount, nil
+}
+
+func legEntry(tx *domain.Transaction, index int, at time.Time) *domain.Transaction {
+	leg := tx.Legs[index]
+	description := leg.Description
+	if description == "" {
+		description = tx.Description
+	}
+
+	entry := domain.NewTransaction(tx.Type, leg.Amount, tx.Currency).
+		WithAccounts("", leg.ToAccountID).
+		WithDescription(description)
+	entry.Status = domain.StatusCompleted
+	entry.CreatedAt = at
+	entry.UpdatedAt = at
+	entry.AddMetadata(MetadataLinkedTransaction, tx.ID)
+	entry.AddMetadata(MetadataLegIndex, strconv.Itoa(index))
+	return entry
+}
@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+const (
+	ActionRouteNotification = "route_notification"
+
+	MetadataRoutePrefix = "route_"
+
+	RouteTeam    = "team"
+	RouteRegion  = "region"
+	RouteProduct = "product"
+)
+
+var routingHintKeys = []string{RouteTeam, RouteRegion, RouteProduct}
+
+func (p *TransactionProcessor) WithRoutingHints() *TransactionProcessor {
+	p.routingHints = true
+	return p
+}
+
+func RoutingHints(tx *domain.Transaction) map[string]string {
+	var hints map[string]string
+	for key, value := range tx.Metadata {
+		if name, ok := strings.CutPrefix(key, MetadataRoutePrefix); ok && value != "" {
+			if hints == nil {
+				hints = make(map[string]string)
+			}
+			hints[name] = value
+		}
+	}
+	return hints
+}
+
+func (e *RuleEngine) handleRouteAction(ctx context.Context, action RuleAction, tx *domain.Transaction) error {
+	routed := false
+	for _, key := range routingHintKeys {
+		if value, _ := action.Params[key].(string); value != "" {
+			tx.AddMetadata(MetadataRoutePrefix+key, value)
+			routed = true
+		}
+	}
+	if !routed {
+		return fmt.Errorf("%s action requires a team, region or product", ActionRouteNotification)
+	}
+
+	e.logger.InfoContext(ctx, "Notification routing hints attached",
+		slog.String("transaction_id", tx.ID),
+		slog.Any("hints", RoutingHints(tx)))
+	return nil
+}
+
+func (p *TransactionProcessor) attachRoutingHints(ctx context.Context, tx *domain.Transaction) {
+	if !p.routingHints {
+		return
+	}
+	accountID := tx.FromAccountID
+	if accountID == "" {
+		accountID = tx.ToAccountID
+	}
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return
+	}
+	// Hints set by rules win over the ones derived from the account.
+	if account.ProductID != "" && tx.Metadata[MetadataRoutePrefix+RouteProduct] == "" {
+		tx.AddMetadata(MetadataRoutePrefix+RouteProduct, account.ProductID)
+	}
+	if account.Country != "" && tx.Metadata[MetadataRoutePrefix+RouteRegion] == "" {
+		tx.AddMetadata(MetadataRoutePrefix+RouteRegion, account.Country)
+	}
+}
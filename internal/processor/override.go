@@ -0,0 +1,150 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+type OverrideOutcome string
+
+const (
+	OverrideApprove OverrideOutcome = "approve"
+	OverrideReject  OverrideOutcome = "reject"
+
+	AuditActionRiskOverride = "risk_override"
+	AuditEntityTransaction  = "transaction"
+)
+
+var (
+	ErrAuditNotConfigured = errors.New("audit log is not configured")
+	ErrInvalidOverride    = errors.New("invalid risk override")
+)
+
+type RiskOverride struct {
+	Outcome   OverrideOutcome `json:"outcome"`
+	RiskScore *int            `json:"risk_score,omitempty"`
+	Reason    string          `json:"reason"`
+	Operator  string          `json:"operator"`
+}
+
+func (o RiskOverride) validate() error {
+	if o.Outcome != OverrideApprove && o.Outcome != OverrideReject {
+		return fmt.Errorf("%w: unknown outcome %q", ErrInvalidOverride, o.Outcome)
+	}
+	if o.Reason == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidOverride)
+	}
+	if o.Operator == "" {
+		return fmt.Errorf("%w: operator is required", ErrInvalidOverride)
+	}
+	if o.RiskScore != nil && (*o.RiskScore < 0 || *o.RiskScore > 100) {
+		return fmt.Errorf("%w: risk score must be between 0 and 100", ErrInvalidOverride)
+	}
+	return nil
+}
+
+func (p *TransactionProcessor) WithAuditLog(auditRepo repository.AuditRepository) *TransactionProcessor {
+	p.auditRepo = auditRepo
+	return p
+}
+
+func (p *TransactionProcessor) OverrideRiskDecision(ctx context.Context, transactionID string, override RiskOverride) (*domain.Transaction, error) {
+	if err := override.validate(); err != nil {
+		return nil, err
+	}
+	if p.auditRepo == nil {
+		return nil, ErrAuditNotConfigured
+	}
+
+	p.overrideMu.Lock()
+	defer p.overrideMu.Unlock()
+
+	tx, err := p.txRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tx.Status {
+	case domain.StatusPending, domain.StatusSuspicious, domain.StatusFailed:
+	default:
+		return nil, fmt.Errorf("%w: transaction %s is %s", repository.ErrTransactionConflict, tx.ID, tx.Status)
+	}
+
+	previousStatus, previousScore := tx.Status, tx.RiskScore
+	if override.RiskScore != nil {
+		tx.RiskScore = *override.RiskScore
+	}
+
+	fromStatus, newStatus := previousStatus, domain.StatusFailed
+	if override.Outcome == OverrideApprove {
+		fromStatus, newStatus = domain.StatusProcessing, domain.StatusCompleted
+		claimed, err := p.claimForExecution(ctx, tx.ID, previousStatus)
+		if err == nil && !claimed {
+			err = fmt.Errorf("%w: transaction %s is already being processed", repository.ErrTransactionConflict, tx.ID)
+		}
+		if err != nil {
+			tx.RiskScore = previousScore
+			return nil, err
+		}
+	}
+
+	entry := domain.NewAuditEntry(AuditActionRiskOverride, AuditEntityTransaction, tx.ID, override.Operator, override.Reason)
+	entry.Details["outcome"] = string(override.Outcome)
+	entry.Details["previous_status"] = string(previousStatus)
+	entry.Details["new_status"] = string(newStatus)
+	entry.Details["previous_risk_score"] = strconv.Itoa(previousScore)
+	entry.Details["new_risk_score"] = strconv.Itoa(tx.RiskScore)
+	err = repository.SaveWithFreshID(
+		func() error { return p.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		if override.Outcome == OverrideApprove {
+			p.releaseClaim(ctx, tx.ID, previousStatus)
+		}
+		tx.RiskScore = previousScore
+		return nil, fmt.Errorf("failed to record audit entry for risk override: %w", err)
+	}
+
+	if override.Outcome == OverrideApprove {
+		err = runStageInline(ctx, StageExecution, p.budgets.Execution, func(ctx context.Context) error {
+			return p.executeTransaction(ctx, tx)
+		})
+		if err != nil {
+			p.releaseClaim(ctx, tx.ID, previousStatus)
+			tx.RiskScore = previousScore
+			return nil, err
+		}
+	}
+
+	tx.AddMetadata(AuditActionRiskOverride, string(override.Outcome))
+	tx.AddMetadata(AuditActionRiskOverride+"_by", override.Operator)
+	if err := p.txRepo.TransitionStatus(ctx, tx.ID, fromStatus, newStatus); err != nil {
+		return nil, err
+	}
+
+	if newStatus == domain.StatusCompleted {
+		p.observeCompleted(ctx, tx)
+	}
+
+	p.emitEvent(ctx, domain.TransactionEvent{
+		TransactionID: tx.ID,
+		Type:          "risk_overridden",
+		Payload:       map[string]interface{}{"outcome": override.Outcome, "operator": override.Operator, "previous_status": previousStatus},
+		Timestamp:     time.Now(),
+	})
+
+	p.logger.InfoContext(ctx, "Risk decision overridden",
+		slog.String("transaction_id", tx.ID),
+		slog.String("operator", override.Operator),
+		slog.String("outcome", string(override.Outcome)),
+		slog.String("previous_status", string(previousStatus)))
+
+	return tx, nil
+}
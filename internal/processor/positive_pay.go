@@ -0,0 +1,178 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+const (
+	MetadataPositivePay        = "positive_pay"
+	PositivePayException       = "exception"
+	PositivePayExceptionPayee  = "unlisted_payee"
+	PositivePayExceptionAmount = "amount_out_of_range"
+	MetadataPositivePayReason  = "positive_pay_reason"
+)
+
+var (
+	ErrPositivePayNotConfigured = errors.New("positive pay is not configured")
+	ErrInvalidPositivePayList   = errors.New("invalid positive pay list")
+	ErrNotPositivePayException  = errors.New("transaction is not a positive pay exception")
+)
+
+func (p *TransactionProcessor) WithPositivePay(repo repository.PositivePayRepository) *TransactionProcessor {
+	p.positivePay = repo
+	return p
+}
+
+func (p *TransactionProcessor) SetPositivePayList(ctx context.Context, list *domain.PositivePayList) error {
+	if p.positivePay == nil {
+		return ErrPositivePayNotConfigured
+	}
+	if err := validatePositivePayList(list); err != nil {
+		return err
+	}
+	if _, err := p.accountRepo.GetByID(ctx, list.AccountID); err != nil {
+		return err
+	}
+	if err := p.positivePay.Save(ctx, list); err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "Positive pay list updated",
+		slog.String("account_id", list.AccountID),
+		slog.Int("payees", len(list.Payees)))
+	return nil
+}
+
+func (p *TransactionProcessor) PositivePayList(ctx context.Context, accountID string) (*domain.PositivePayList, error) {
+	if p.positivePay == nil {
+		return nil, ErrPositivePayNotConfigured
+	}
+	return p.positivePay.GetByAccountID(ctx, accountID)
+}
+
+func (p *TransactionProcessor) RemovePositivePayList(ctx context.Context, accountID string) error {
+	if p.positivePay == nil {
+		return ErrPositivePayNotConfigured
+	}
+	return p.positivePay.Delete(ctx, accountID)
+}
+
+func (p *TransactionProcessor) PositivePayExceptions(ctx context.Context, accountID string) ([]*domain.Transaction, error) {
+	if p.positivePay == nil {
+		return nil, ErrPositivePayNotConfigured
+	}
+
+	exceptions := []*domain.Transaction{}
+	filter := repository.TransactionFilter{AccountID: accountID, Status: domain.StatusPending}
+	for tx, err := range p.readRepo.Stream(ctx, filter) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		if tx.FromAccountID == accountID && tx.Metadata[MetadataPositivePay] == PositivePayException {
+			exceptions = append(exceptions, tx)
+		}
+	}
+	return exceptions, nil
+}
+
+func (p *TransactionProcessor) ReviewPositivePayException(ctx context.Context, accountID, transactionID string, review RiskOverride) (*domain.Transaction, error) {
+	if p.positivePay == nil {
+		return nil, ErrPositivePayNotConfigured
+	}
+
+	tx, err := p.txRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.FromAccountID != accountID || tx.Metadata[MetadataPositivePay] != PositivePayException {
+		return nil, fmt.Errorf("%w: %s", ErrNotPositivePayException, transactionID)
+	}
+
+	return p.OverrideRiskDecision(ctx, transactionID, review)
+}
+
+func (p *TransactionProcessor) holdForPositivePay(ctx context.Context, tx *domain.Transaction) bool {
+	reason, hold := p.positivePayException(ctx, tx)
+	if !hold {
+		return false
+	}
+
+	tx.AddMetadata(MetadataPositivePay, PositivePayException)
+	if reason == "" {
+		return true
+	}
+	tx.AddMetadata(MetadataPositivePayReason, reason)
+
+	p.emitEvent(ctx, domain.TransactionEvent{
+		TransactionID: tx.ID,
+		Type:          "positive_pay_exception",
+		Payload:       map[string]interface{}{"account_id": tx.FromAccountID, "payee_account_id": tx.ToAccountID, "reason": reason},
+		Timestamp:     time.Now(),
+	})
+	p.recordMetric("positive_pay_exceptions", 1)
+	return true
+}
+
+func (p *TransactionProcessor) positivePayException(ctx context.Context, tx *domain.Transaction) (string, bool) {
+	if p.positivePay == nil || tx.FromAccountID == "" {
+		return "", false
+	}
+	if tx.Type != domain.TypeTransfer && tx.Type != domain.TypeWithdrawal {
+		return "", false
+	}
+
+	list, err := p.positivePay.GetByAccountID(ctx, tx.FromAccountID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return "", false
+	}
+	if err != nil {
+		p.logger.WarnContext(ctx, "Failed to load positive pay list, holding transaction",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+		return "", true
+	}
+	for _, leg := range creditLegs(tx) {
+		if list.Authorizes(leg.ToAccountID, leg.Amount) {
+			continue
+		}
+		if !listsPayee(list, leg.ToAccountID) {
+			return PositivePayExceptionPayee, true
+		}
+		return PositivePayExceptionAmount, true
+	}
+	return "", false
+}
+
+func listsPayee(list *domain.PositivePayList, payeeAccountID string) bool {
+	for _, payee := range list.Payees {
+		if payee.PayeeAccountID == payeeAccountID {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePositivePayList(list *domain.PositivePayList) error {
+	if list.AccountID == "" {
+		return fmt.Errorf("%w: account is required", ErrInvalidPositivePayList)
+	}
+	for i, payee := range list.Payees {
+		if payee.PayeeAccountID == "" && payee.MaxAmount == 0 {
+			return fmt.Errorf("%w: payee %d has no account and no amount range", ErrInvalidPositivePayList, i)
+		}
+		if payee.MinAmount < 0 || payee.MaxAmount < 0 || math.IsNaN(payee.MinAmount) || math.IsNaN(payee.MaxAmount) {
+			return fmt.Errorf("%w: payee %d has a negative amount bound", ErrInvalidPositivePayList, i)
+		}
+		if payee.MaxAmount != 0 && payee.MaxAmount < payee.MinAmount {
+			return fmt.Errorf("%w: payee %d has max amount below min amount", ErrInvalidPositivePayList, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"fmt"
+)
+
+type Prevalidation struct {
+	Transaction *domain.Transaction
+	Err         error
+}
+
+// Prevalidate evaluates a copy of tx without persisting it or moving funds.
+func (p *TransactionProcessor) Prevalidate(ctx context.Context, tx *domain.Transaction) Prevalidation {
+	ctx, cancel := p.withDeadline(ctx)
+	defer cancel()
+
+	tx = tx.Clone()
+	reject := func(err error) Prevalidation {
+		tx.Status = domain.StatusFailed
+		return Prevalidation{Transaction: tx, Err: err}
+	}
+
+	if err := p.validator.CheckTransaction(tx); err != nil {
+		return reject(fmt.Errorf("%w: %w", ErrValidationFailed, err))
+	}
+	if err := p.checkMaintenance(ctx, tx); err != nil {
+		return reject(err)
+	}
+
+	fastPath := p.qualifiesForFastPath(ctx, tx)
+	var skippedPatterns []string
+	if fastPath {
+		skippedPatterns = p.fastPath.cfg.SkipPatterns
+		tx.AddMetadata(MetadataFastPath, FastPathTrustedBeneficiary)
+	}
+	tx.RiskScore, tx.FraudFlags = p.fraudDetector.ExplainTransaction(tx, skippedPatterns...)
+
+	results, err := p.ruleEngine.evaluateRules(ctx, tx, false)
+	if err != nil {
+		return reject(fmt.Errorf("rule evaluation failed: %w", err))
+	}
+	if fastPath {
+		results = p.fastPath.filterRules(results)
+	}
+	decision := p.ruleEngine.Resolve(results)
+	tx.ExplainRules(decision.Explain(results))
+	p.ruleEngine.previewDecision(decision, tx)
+
+	status := resolveStatus(decision, tx.RiskScore, func() bool {
+		_, hold := p.positivePayException(ctx, tx)
+		return hold
+	})
+	status, err = p.applySandbox(ctx, tx, status)
+	if err != nil {
+		return reject(err)
+	}
+
+	if status == domain.StatusCompleted {
+		if err := p.checkExecution(ctx, tx); err != nil {
+			return reject(err)
+		}
+		if p.queueIfDegraded(ctx, tx) {
+			status = domain.StatusPending
+		}
+	}
+	tx.Status = status
+	return Prevalidation{Transaction: tx}
+}
+
+func (p *TransactionProcessor) checkExecution(ctx context.Context, tx *domain.Transaction) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var err error
+	switch {
+	case tx.Type == domain.TypeTransfer && len(tx.Legs) > 0:
+		_, err = p.checkMultiLegTransfer(ctx, tx)
+	case tx.Type == domain.TypeTransfer:
+		_, _, err = p.checkTransfer(ctx, tx)
+	case tx.Type == domain.TypeDeposit:
+		_, err = p.checkDeposit(ctx, tx)
+	case tx.Type == domain.TypeWithdrawal:
+		_, err = p.checkWithdrawal(ctx, tx)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnknownTransactionType, tx.Type)
+	}
+	return err
+}
@@ -1,12 +1,19 @@
 package processor
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"finance_manager/internal/domain"
 	"finance_manager/internal/repository"
 	"finance_manager/internal/repository/memory"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestTransactionProcessor_ProcessTransaction_TransferSuccess(t *testing.T) {
@@ -137,3 +144,2180 @@ func TestTransactionProcessor_ProcessTransaction_WithdrawalInsufficientFunds(t *
 		t.Errorf("expected 100, got %f", accUpdated.Balance)
 	}
 }
+
+func TestWorkerPool_HigherPriorityQueueRunsFirst(t *testing.T) {
+	pool := NewWorkerPool(DefaultWorkerPoolConfig(1), nil)
+	defer pool.Stop(context.Background())
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = pool.Submit(QueueRealtime, func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) Job {
+		return func(ctx context.Context) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	_ = pool.Submit(QueueReplay, record(QueueReplay))
+	_ = pool.Submit(QueueBatch, record(QueueBatch))
+	_ = pool.Submit(QueueRealtime, record(QueueRealtime))
+	close(release)
+	_ = pool.Stop(context.Background())
+
+	expected := []string{QueueRealtime, QueueBatch, QueueReplay}
+	if !slices.Equal(order, expected) {
+		t.Errorf("expected execution order %v, got %v", expected, order)
+	}
+}
+
+func TestTransactionProcessor_RunQueued(t *testing.T) {
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), memory.NewAccountRepository(), memory.NewRuleRepository(), 1)
+	defer p.Shutdown(context.Background())
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = p.WorkerPool().Submit(QueueRealtime, func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+
+	result := make(chan error, 1)
+	go func() {
+		result <- p.RunQueued(context.Background(), QueueScheduled, func(ctx context.Context) error {
+			return errors.New("installment declined")
+		})
+	}()
+	deadline := time.Now().Add(time.Second)
+	for p.WorkerPool().Stats().Queued[QueueScheduled] != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if queued := p.WorkerPool().Stats().Queued[QueueScheduled]; queued != 1 {
+		t.Fatalf("expected scheduled work to wait on its queue, got %d", queued)
+	}
+	close(release)
+
+	if err := <-result; err == nil || err.Error() != "installment declined" {
+		t.Errorf("expected the job error to be returned, got %v", err)
+	}
+}
+
+func TestTransactionProcessor_ProcessBatch(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	ruleRepo := memory.NewRuleRepository()
+
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+
+	proc := NewTransactionProcessor(txRepo, accRepo, ruleRepo, 4)
+	defer proc.Shutdown(ctx)
+	txs := []*domain.Transaction{
+		{ID: "b1", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 10, Currency: "USD"},
+		{ID: "b2", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 20, Currency: "USD"},
+		{ID: "b3", Type: domain.TypeDeposit, ToAccountID: "missing", Amount: 30, Currency: "USD"},
+	}
+
+	errs := proc.ProcessBatch(ctx, txs)
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("expected first two transactions to succeed, got %v", errs)
+	}
+	if !errors.Is(errs[2], repository.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing account, got %v", errs[2])
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 30 {
+		t.Errorf("expected 30, got %f", acc.Balance)
+	}
+}
+
+type panickingTransactionRepository struct {
+	*memory.TransactionRepository
+	panicID string
+}
+
+func (r *panickingTransactionRepository) Save(ctx context.Context, tx *domain.Transaction) error {
+	if tx.ID == r.panicID {
+		panic("storage driver crashed")
+	}
+	return r.TransactionRepository.Save(ctx, tx)
+}
+
+func TestTransactionProcessor_ProcessBatchSurvivesPanic(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := &panickingTransactionRepository{TransactionRepository: memory.NewTransactionRepository(), panicID: "b2"}
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+
+	proc := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 2)
+	defer proc.Shutdown(ctx)
+	txs := []*domain.Transaction{
+		{ID: "b1", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 10, Currency: "USD"},
+		{ID: "b2", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 20, Currency: "USD"},
+	}
+
+	result := make(chan []error, 1)
+	go func() { result <- proc.ProcessBatch(ctx, txs) }()
+
+	select {
+	case errs := <-result:
+		if errs[0] != nil {
+			t.Errorf("expected first transaction to succeed, got %v", errs[0])
+		}
+		if !errors.Is(errs[1], ErrProcessingPanicked) {
+			t.Errorf("expected ErrProcessingPanicked, got %v", errs[1])
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ProcessBatch did not return after a job panicked")
+	}
+}
+
+type slowRuleRepository struct {
+	*memory.RuleRepository
+	delay time.Duration
+}
+
+func (r *slowRuleRepository) GetActiveRules(ctx context.Context) ([]*domain.Rule, error) {
+	time.Sleep(r.delay)
+	return r.RuleRepository.GetActiveRules(ctx)
+}
+
+func TestTransactionProcessor_ProcessTransaction_RuleStageTimeout(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	ruleRepo := &slowRuleRepository{RuleRepository: memory.NewRuleRepository(), delay: 200 * time.Millisecond}
+
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+
+	budgets := DefaultStageBudgets()
+	budgets.Rules = 20 * time.Millisecond
+	proc := NewTransactionProcessor(txRepo, accRepo, ruleRepo, 1).WithStageBudgets(budgets)
+	tx := &domain.Transaction{ID: "tx1", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 10, Currency: "USD"}
+
+	err := proc.ProcessTransaction(ctx, tx)
+
+	var stageErr *StageTimeoutError
+	if !errors.As(err, &stageErr) || stageErr.Stage != StageRules {
+		t.Fatalf("expected rules stage timeout, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 100 {
+		t.Errorf("expected balance untouched at 100, got %f", acc.Balance)
+	}
+}
+
+func TestTransactionProcessor_FraudStageTimeoutLeavesTransactionUntouched(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+
+	finished := make(chan struct{})
+	fd := &FraudDetector{patterns: []FraudPattern{{
+		Name: "slow",
+		Detect: func(tx *domain.Transaction) (bool, string) {
+			defer close(finished)
+			time.Sleep(100 * time.Millisecond)
+			return true, "slow"
+		},
+		Weight: 40,
+	}}}
+	budgets := DefaultStageBudgets()
+	budgets.Fraud = 10 * time.Millisecond
+	proc := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithFraudDetector(fd).
+		WithStageBudgets(budgets)
+	tx := &domain.Transaction{ID: "tx1", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 10, Currency: "USD"}
+
+	err := proc.ProcessTransaction(ctx, tx)
+	var stageErr *StageTimeoutError
+	if !errors.As(err, &stageErr) || stageErr.Stage != StageFraud {
+		t.Fatalf("expected fraud stage timeout, got %v", err)
+	}
+
+	<-finished
+	time.Sleep(10 * time.Millisecond)
+	if tx.RiskScore != 0 || tx.FraudFlags != nil || tx.Explanation != nil {
+		t.Errorf("expected timed-out stage not to write to the transaction, got score %d flags %v explanation %+v",
+			tx.RiskScore, tx.FraudFlags, tx.Explanation)
+	}
+}
+
+func TestRuleEngine_CheckDescriptionCondition(t *testing.T) {
+	engine := NewRuleEngine(memory.NewRuleRepository(), nil)
+	cases := []struct {
+		name        string
+		operator    string
+		value       interface{}
+		description string
+		expected    bool
+	}{
+		{"word matches standalone", "contains_word", "crypto", "Buy Crypto now", true},
+		{"word ignores longer token", "contains_word", "crypto", "cryptocurrency exchange", false},
+		{"word matches phrase", "contains_word", "gift card", "Amazon gift card!", true},
+		{"contains is literal", "contains", ".*", "anything", false},
+		{"prefix", "starts_with", "INV-", "inv-2024-001", true},
+		{"any of list", "contains_word", []interface{}{"casino", "bet"}, "online bet", true},
+		{"not contains", "not_contains", "refund", "salary payment", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := Condition{Field: "description", Operator: tc.operator, Value: tc.value}
+
+			got, err := engine.checkCondition(condition, &domain.Transaction{Description: tc.description})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestScheduledRuleEvaluator_FlagsAccountsOverWithdrawalCount(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	ruleRepo := memory.NewRuleRepository()
+
+	_ = accRepo.Save(ctx, &domain.Account{ID: "busy", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "quiet", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	for i := 0; i < 3; i++ {
+		_ = txRepo.Save(ctx, &domain.Transaction{ID: fmt.Sprintf("w%d", i), Type: domain.TypeWithdrawal, FromAccountID: "busy", Amount: 10, Status: domain.StatusCompleted, CreatedAt: time.Now()})
+	}
+	_ = txRepo.Save(ctx, &domain.Transaction{ID: "q1", Type: domain.TypeWithdrawal, FromAccountID: "quiet", Amount: 10, Status: domain.StatusCompleted, CreatedAt: time.Now()})
+
+	rule := &domain.Rule{
+		ID:        "nightly",
+		Name:      "Many withdrawals",
+		Schedule:  "@daily 02:00",
+		Condition: `{"field":"withdrawal_count","operator":">","value":2,"window":"24h"}`,
+		Action:    `{"type":"flag_account","params":{"category":"watchlist"}}`,
+		IsActive:  true,
+	}
+	engine := NewRuleEngine(ruleRepo, nil)
+	evaluator := NewScheduledRuleEvaluator(engine, ruleRepo, accRepo, txRepo, nil)
+
+	results, err := evaluator.EvaluateRule(ctx, rule)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].AccountID != "busy" {
+		t.Fatalf("expected only account 'busy' to trigger, got %+v", results)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "busy"); acc.RiskCategory != "watchlist" {
+		t.Errorf("expected risk category 'watchlist', got %q", acc.RiskCategory)
+	}
+	if err := engine.ValidateRule(rule); err != nil {
+		t.Errorf("expected scheduled rule to validate, got %v", err)
+	}
+}
+
+func TestRuleEngine_RuleGroupActivation(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := memory.NewRuleRepository()
+	groupRepo := memory.NewRuleGroupRepository()
+	engine := NewRuleEngine(ruleRepo, nil).WithRuleGroups(groupRepo, "staging")
+
+	_ = engine.CreateRuleGroup(ctx, &domain.RuleGroup{ID: "campaign", Name: "Campaign", Environments: []string{"staging"}})
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "r1",
+		Name:      "campaign rule",
+		GroupID:   "campaign",
+		IsActive:  true,
+		Condition: `{"field":"amount","operator":">","value":10}`,
+		Action:    `{"type":"flag_transaction","params":{"reason":"campaign"}}`,
+	})
+	tx := &domain.Transaction{ID: "tx1", Amount: 100}
+
+	inactive, _ := engine.EvaluateRules(ctx, tx)
+	_ = engine.SetRuleGroupActive(ctx, "campaign", true)
+	active, _ := engine.EvaluateRules(ctx, tx)
+	otherEnv := NewRuleEngine(ruleRepo, nil).WithRuleGroups(groupRepo, "production")
+	production, _ := otherEnv.EvaluateRules(ctx, tx)
+
+	if len(inactive) != 0 {
+		t.Errorf("expected no results while group inactive, got %+v", inactive)
+	}
+	if len(active) != 1 {
+		t.Errorf("expected rule to trigger after activation, got %+v", active)
+	}
+	if len(production) != 0 {
+		t.Errorf("expected staging-only group to be skipped in production, got %+v", production)
+	}
+}
+
+func TestRuleEngine_LookupTables(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := memory.NewRuleRepository()
+	engine := NewRuleEngine(ruleRepo, nil).WithLookupTables(memory.NewLookupTableRepository())
+
+	if err := engine.SaveLookupTable(ctx, &domain.LookupTable{Name: "country_risk", Entries: map[string]float64{"NG": 80, "DE": 10}}); err != nil {
+		t.Fatalf("save country_risk: %v", err)
+	}
+	if err := engine.SaveLookupTable(ctx, &domain.LookupTable{Name: "mcc_multiplier", Entries: map[string]float64{"7995": 2}, Default: func() *float64 { v := 1.0; return &v }()}); err != nil {
+		t.Fatalf("save mcc_multiplier: %v", err)
+	}
+
+	rule := &domain.Rule{
+		ID:        "r1",
+		Name:      "high risk country",
+		IsActive:  true,
+		Condition: `{"field":"lookup","table":"country_risk","key":"metadata.country","operator":">=","value":50}`,
+		Action:    `{"type":"adjust_risk_score","params":{"adjustment":10,"multiplier":{"table":"mcc_multiplier","key":"metadata.mcc"}}}`,
+	}
+	if err := engine.ValidateRule(rule); err != nil {
+		t.Fatalf("expected rule referencing lookup tables to validate, got %v", err)
+	}
+	_ = ruleRepo.Save(ctx, rule)
+
+	tx := &domain.Transaction{ID: "tx1", Amount: 100, Metadata: map[string]string{"country": "NG", "mcc": "7995"}}
+	results, err := engine.EvaluateRules(ctx, tx)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("expected rule to trigger for high risk country, got %+v, %v", results, err)
+	}
+	if err := engine.ExecuteAction(ctx, results[0].Action, tx); err != nil || tx.RiskScore != 20 {
+		t.Errorf("expected adjustment scaled by mcc multiplier to 20, got %d, %v", tx.RiskScore, err)
+	}
+
+	_ = engine.SaveLookupTable(ctx, &domain.LookupTable{Name: "country_risk", Entries: map[string]float64{"NG": 30}})
+	if results, _ := engine.EvaluateRules(ctx, tx); len(results) != 0 {
+		t.Errorf("expected updated table to apply without editing the rule, got %+v", results)
+	}
+
+	if err := engine.DeleteLookupTable(ctx, "mcc_multiplier"); !errors.Is(err, ErrLookupTableInUse) {
+		t.Errorf("expected referenced table deletion to be rejected, got %v", err)
+	}
+	if err := engine.ValidateRule(&domain.Rule{ID: "r2", Name: "missing", Condition: `{"field":"amount","operator":">","value":{"table":"missing","key":"currency"}}`, Action: `{"type":"notify"}`}); err == nil {
+		t.Error("expected rule referencing an unknown table to fail validation")
+	}
+}
+
+func TestRuleEngine_Resolve(t *testing.T) {
+	results := []RuleResult{
+		{RuleID: "notify", RuleType: domain.RuleTypeBusiness, Priority: 50, Action: RuleAction{Type: "notify"}},
+		{RuleID: "flag", RuleType: domain.RuleTypeFraud, Priority: 90, Action: RuleAction{Type: "flag_transaction"}},
+		{RuleID: "block", RuleType: domain.RuleTypeFraud, Priority: 10, Action: RuleAction{Type: "block_transaction"}},
+	}
+	cases := []struct {
+		strategy      ResolutionStrategy
+		expectApplied []string
+		expectDecider string
+	}{
+		{StrategyFirstMatch, []string{"flag", "notify"}, "flag"},
+		{StrategyMostSevere, []string{"notify", "block"}, "block"},
+		{StrategyMerge, []string{"flag", "notify", "block"}, "block"},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			engine := NewRuleEngine(memory.NewRuleRepository(), nil).
+				WithResolutionStrategy(domain.RuleTypeFraud, tc.strategy)
+
+			decision := engine.Resolve(slices.Clone(results))
+
+			var applied []string
+			for _, r := range decision.Applied {
+				applied = append(applied, r.RuleID)
+			}
+			if !slices.Equal(applied, tc.expectApplied) {
+				t.Errorf("expected applied %v, got %v", tc.expectApplied, applied)
+			}
+			if decision.DecidingRuleID != tc.expectDecider {
+				t.Errorf("expected deciding rule %s, got %s", tc.expectDecider, decision.DecidingRuleID)
+			}
+		})
+	}
+}
+
+func TestTransactionProcessor_ProcessTransaction_BlockedByRule(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	ruleRepo := memory.NewRuleRepository()
+
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "block-all",
+		Name:      "Block all deposits",
+		Type:      domain.RuleTypeCompliance,
+		IsActive:  true,
+		Condition: `{"field":"amount","operator":">","value":0}`,
+		Action:    `{"type":"block_transaction","params":{"reason":"test"}}`,
+	})
+
+	proc := NewTransactionProcessor(txRepo, accRepo, ruleRepo, 1)
+	tx := &domain.Transaction{ID: "tx1", Type: domain.TypeDeposit, ToAccountID: "a1", Amount: 10, Currency: "USD"}
+
+	err := proc.ProcessTransaction(ctx, tx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.Status != domain.StatusFailed || tx.Metadata[MetadataDecidedByRule] != "block-all" {
+		t.Errorf("expected failed status decided by block-all, got %s / %v", tx.Status, tx.Metadata)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 100 {
+		t.Errorf("expected balance untouched at 100, got %f", acc.Balance)
+	}
+}
+
+func TestFraudDetector_Structuring(t *testing.T) {
+	ctx := context.Background()
+	txRepo := memory.NewTransactionRepository()
+	fd := NewFraudDetector().WithStructuringDetection(txRepo, DefaultStructuringConfig())
+	for i := 0; i < 2; i++ {
+		_ = txRepo.Save(ctx, &domain.Transaction{ID: fmt.Sprintf("s%d", i), FromAccountID: "a1", ToAccountID: fmt.Sprintf("b%d", i), Amount: 9500, Status: domain.StatusCompleted, CreatedAt: time.Now()})
+	}
+
+	_, belowCount := fd.AnalyzeTransaction(&domain.Transaction{ID: "other", FromAccountID: "a2", Amount: 9500})
+	_, structured := fd.AnalyzeTransaction(&domain.Transaction{ID: "third", FromAccountID: "a1", Amount: 9800})
+
+	if slices.Contains(belowCount, ComplianceFlagStructuring) {
+		t.Errorf("expected no structuring flag for unrelated account, got %v", belowCount)
+	}
+	if !slices.Contains(structured, ComplianceFlagStructuring) {
+		t.Errorf("expected structuring flag, got %v", structured)
+	}
+}
+
+func TestTransferGraph_Analyze(t *testing.T) {
+	graph := NewTransferGraph(DefaultGraphAnalysisConfig())
+	now := time.Now()
+	transfer := func(id, from, to string, amount float64, at time.Time) {
+		graph.AddTransfer(&domain.Transaction{ID: id, Type: domain.TypeTransfer, FromAccountID: from, ToAccountID: to, Amount: amount, CreatedAt: at})
+	}
+	transfer("t1", "src", "mule", 5000, now.Add(-30*time.Minute))
+	transfer("t2", "mule", "dst", 4800, now.Add(-25*time.Minute))
+	transfer("t3", "r1", "r2", 100, now)
+	transfer("t4", "r2", "r3", 100, now)
+	transfer("t5", "r3", "r1", 100, now)
+	transfer("t6", "r1", "r4", 100, now)
+	transfer("t7", "r4", "r2", 100, now)
+
+	graph.Analyze(now)
+
+	if flags := graph.Flags("mule"); len(flags) != 1 || flags[0].Flag != FlagPassThrough {
+		t.Errorf("expected pass-through flag on mule, got %v", flags)
+	}
+	if graph.IsFlagged("src") || graph.IsFlagged("dst") {
+		t.Errorf("expected source and destination to stay unflagged")
+	}
+	if flags := graph.Flags("r1"); !slices.ContainsFunc(flags, func(f AccountRiskFlag) bool { return f.Flag == FlagDenseCluster }) {
+		t.Errorf("expected dense cluster flag on r1, got %v", flags)
+	}
+}
+
+func TestFraudDetector_AmountHeuristics(t *testing.T) {
+	fd := &FraudDetector{}
+	fd.WithAmountHeuristics(DefaultAmountHeuristicsConfig())
+
+	_, round := fd.AnalyzeTransaction(&domain.Transaction{Amount: 7000})
+	_, avoidance := fd.AnalyzeTransaction(&domain.Transaction{Amount: 9900})
+	_, ordinary := fd.AnalyzeTransaction(&domain.Transaction{Amount: 7123.45})
+
+	if !slices.Contains(round, FlagRoundAmount) {
+		t.Errorf("expected round amount flag, got %v", round)
+	}
+	if !slices.Contains(avoidance, FlagThresholdAvoidance) || slices.Contains(avoidance, FlagRoundAmount) {
+		t.Errorf("expected only threshold avoidance flag, got %v", avoidance)
+	}
+	if len(ordinary) != 0 {
+		t.Errorf("expected no flags, got %v", ordinary)
+	}
+}
+
+func TestTransactionProcessor_AmountHeuristicsAreOptIn(t *testing.T) {
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), memory.NewAccountRepository(), memory.NewRuleRepository(), 1)
+	midday := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	p.FraudDetector().WithClock(func() time.Time { return midday })
+	tx := &domain.Transaction{Type: domain.TypeDeposit, Amount: 9900, ToAccountID: "acc"}
+
+	score, flags := p.FraudDetector().AnalyzeTransaction(tx)
+	if score != 0 || len(flags) != 0 {
+		t.Fatalf("expected default processor to leave amount heuristics off, got score %d flags %v", score, flags)
+	}
+
+	p.FraudDetector().WithAmountHeuristics(DefaultAmountHeuristicsConfig())
+	if _, flags := p.FraudDetector().AnalyzeTransaction(tx); !slices.Contains(flags, FlagThresholdAvoidance) {
+		t.Errorf("expected threshold avoidance flag once enabled, got %v", flags)
+	}
+}
+
+func TestProfileTracker_FlagsDeviationsFromProfile(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewProfileTracker(memory.NewProfileRepository(), DefaultProfileConfig())
+	fd := (&FraudDetector{}).WithAccountProfiles(tracker)
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		_ = tracker.Observe(ctx, &domain.Transaction{Type: domain.TypeTransfer, FromAccountID: "a1", ToAccountID: "b1", Amount: float64(90 + i*2), Currency: "USD", CreatedAt: now})
+	}
+
+	_, usual := fd.AnalyzeTransaction(&domain.Transaction{Type: domain.TypeTransfer, FromAccountID: "a1", ToAccountID: "b1", Amount: 100, Currency: "USD", CreatedAt: now})
+	_, unusual := fd.AnalyzeTransaction(&domain.Transaction{Type: domain.TypeTransfer, FromAccountID: "a1", ToAccountID: "b2", Amount: 5000, Currency: "EUR", CreatedAt: now})
+
+	if len(usual) != 0 {
+		t.Errorf("expected no flags for typical transaction, got %v", usual)
+	}
+	for _, flag := range []string{FlagAmountDeviation, FlagUnusualCurrency, FlagNewCounterparty} {
+		if !slices.Contains(unusual, flag) {
+			t.Errorf("expected flag %s, got %v", flag, unusual)
+		}
+	}
+	if profile, _ := tracker.Profile(ctx, "b1"); profile.TransactionCount != 10 || !profile.KnowsCounterparty("a1") {
+		t.Errorf("expected counterparty profile to be updated, got %+v", profile)
+	}
+}
+
+func TestWriteDecisionRecords_CSV(t *testing.T) {
+	createdAt := time.Date(2024, 3, 4, 15, 0, 0, 0, time.UTC)
+	tx := &domain.Transaction{
+		ID: "tx1", Type: domain.TypeDeposit, Amount: 9900, Currency: "USD", Status: domain.StatusFailed, CreatedAt: createdAt,
+		Metadata: map[string]string{AuditActionRiskOverride: string(OverrideReject)},
+	}
+	tx.ExplainFraud([]domain.PatternMatch{{Name: FlagThresholdAvoidance, Flag: FlagThresholdAvoidance, Weight: 20}}, 0)
+	tx.ExplainRules([]domain.RuleMatch{{RuleID: "r1", Applied: true}, {RuleID: "r2"}})
+	var buf bytes.Buffer
+
+	err := WriteDecisionRecords(&buf, []DecisionRecord{NewDecisionRecord(tx)}, DecisionFormatCSV)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "pattern_threshold_avoidance") {
+		t.Fatalf("expected header with pattern column and one row, got %q", buf.String())
+	}
+	expected := "tx1,2024-03-04T15:00:00Z,deposit,9900.00,USD,15,1,false,0,0,20,r1|r2,r1,,failed,reject,fraud"
+	if lines[1] != expected {
+		t.Errorf("expected row %q, got %q", expected, lines[1])
+	}
+}
+
+func TestTransactionProcessor_TrustedBeneficiaryFastPath(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	ruleRepo := memory.NewRuleRepository()
+	beneficiaryRepo := memory.NewBeneficiaryRepository()
+	for _, id := range []string{"a1", "b1", "b2"} {
+		_ = accRepo.Save(ctx, &domain.Account{ID: id, UserID: "u1", Balance: 10000, Status: domain.AccountActive, Currency: "USD"})
+	}
+	trusted := domain.NewTrustedBeneficiary("a1", "b1")
+	trusted.Status = domain.BeneficiaryTrusted
+	_ = beneficiaryRepo.Save(ctx, trusted)
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "approve-transfers",
+		IsActive:  true,
+		Condition: `{"field":"amount","operator":">","value":0}`,
+		Action:    `{"type":"require_approval","params":{}}`,
+	})
+	proc := NewTransactionProcessor(txRepo, accRepo, ruleRepo, 1).
+		WithTrustedBeneficiaries(beneficiaryRepo, DefaultTrustedFastPathConfig())
+	transfer := func(id, to string, amount float64) *domain.Transaction {
+		tx := &domain.Transaction{ID: id, Type: domain.TypeTransfer, FromAccountID: "a1", ToAccountID: to, Amount: amount, Currency: "USD", CreatedAt: time.Now()}
+		if err := proc.ProcessTransaction(ctx, tx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return tx
+	}
+
+	fast := transfer("tx1", "b1", 500)
+	untrusted := transfer("tx2", "b2", 500)
+	overThreshold := transfer("tx3", "b1", 5000)
+
+	if fast.Status != domain.StatusCompleted || fast.Metadata[MetadataFastPath] != FastPathTrustedBeneficiary {
+		t.Errorf("expected trusted transfer to complete via fast path, got %s / %v", fast.Status, fast.Metadata)
+	}
+	if untrusted.Status != domain.StatusPending || overThreshold.Status != domain.StatusPending {
+		t.Errorf("expected pending approval for untrusted and large transfers, got %s / %s", untrusted.Status, overThreshold.Status)
+	}
+}
+
+func TestRuleEngine_MerchantChargebackRateField(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	ruleRepo := memory.NewRuleRepository()
+
+	_ = accRepo.Save(ctx, &domain.Account{ID: "c1", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "m1", UserID: "u2", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "high-chargebacks",
+		Name:      "Review merchants with high chargeback rate",
+		Type:      domain.RuleTypeFraud,
+		IsActive:  true,
+		Condition: `{"field":"merchant_chargeback_rate","operator":">","value":0.4}`,
+		Action:    `{"type":"require_approval"}`,
+	})
+
+	tracker := NewChargebackTracker()
+	proc := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, ruleRepo, 1).WithChargebackTracking(tracker)
+
+	first := domain.NewTransaction(domain.TypeTransfer, 10, "USD").WithAccounts("c1", "m1")
+	if err := proc.ProcessTransaction(ctx, first); err != nil || first.Status != domain.StatusCompleted {
+		t.Fatalf("expected first payment to complete, got %s / %v", first.Status, err)
+	}
+	tracker.RecordChargeback(domain.NewChargeback(first, "4837"))
+
+	second := domain.NewTransaction(domain.TypeTransfer, 10, "USD").WithAccounts("c1", "m1")
+	_ = proc.ProcessTransaction(ctx, second)
+
+	if stats := tracker.Stats("m1"); stats.Payments != 1 || stats.Rate != 1 {
+		t.Errorf("expected 1 payment at rate 1, got %+v", stats)
+	}
+	if second.Status != domain.StatusPending {
+		t.Errorf("expected payment to high-chargeback merchant to require approval, got %s", second.Status)
+	}
+}
+
+func TestRuleEngine_EvaluateRulesUsesPrecompiledRules(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := memory.NewRuleRepository()
+	for i := 0; i < 50; i++ {
+		_ = ruleRepo.Save(ctx, &domain.Rule{
+			ID:        fmt.Sprintf("r%d", i),
+			Name:      "atm channel",
+			IsActive:  true,
+			Condition: `{"field":"metadata","operator":"==","value":{"channel":"atm"}}`,
+			Action:    `{"type":"flag_transaction","params":{"reason":"atm"}}`,
+		})
+	}
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "currency",
+		Name:      "currency pattern",
+		IsActive:  true,
+		Priority:  -1,
+		Condition: `{"field":"currency","operator":"contains","value":"^EU"}`,
+		Action:    `{"type":"require_approval"}`,
+	})
+	engine := NewRuleEngine(ruleRepo, nil)
+	tx := &domain.Transaction{ID: "tx1", Amount: 10, Currency: "USD", Metadata: map[string]string{"channel": "web"}}
+
+	if _, err := engine.EvaluateRules(ctx, tx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	allocs := testing.AllocsPerRun(20, func() {
+		_, _ = engine.EvaluateRules(ctx, tx)
+	})
+	if allocs > 0 {
+		t.Errorf("expected cached evaluation without allocations, got %.0f", allocs)
+	}
+
+	tx.Currency = "EUR"
+	results, _ := engine.EvaluateRules(ctx, tx)
+	if len(results) != 1 || results[0].RuleID != "currency" || results[0].Action.Type != "require_approval" {
+		t.Errorf("expected precompiled currency pattern to match, got %+v", results)
+	}
+
+	invalid := &domain.Rule{ID: "bad", Name: "bad", Condition: `{"field":"currency","operator":"contains","value":"("}`, Action: `{"type":"notify"}`}
+	if err := engine.ValidateRule(invalid); err == nil {
+		t.Error("expected invalid pattern to be rejected at validation")
+	}
+}
+
+func TestRuleEngine_IndexedEvaluationAndStopOnFirstBlock(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := memory.NewRuleRepository()
+	rules := []struct {
+		id        string
+		priority  int
+		condition string
+		action    string
+	}{
+		{"eur", 1, `{"field":"currency","operator":"==","value":"EUR"}`, `{"type":"flag_transaction"}`},
+		{"withdrawal", 2, `{"field":"type","operator":"==","value":"withdrawal"}`, `{"type":"notify"}`},
+		{"over-100", 3, `{"field":"amount","operator":">","value":100}`, `{"type":"require_approval"}`},
+		{"at-least-500", 4, `{"field":"amount","operator":">=","value":500}`, `{"type":"block_transaction"}`},
+		{"under-10", 5, `{"field":"amount","operator":"<","value":10}`, `{"type":"flag_transaction"}`},
+		{"risky", 6, `{"field":"risk_score","operator":">","value":50}`, `{"type":"block_transaction"}`},
+	}
+	for _, r := range rules {
+		_ = ruleRepo.Save(ctx, &domain.Rule{ID: r.id, Name: r.id, IsActive: true, Priority: r.priority, Condition: r.condition, Action: r.action})
+	}
+	engine := NewRuleEngine(ruleRepo, nil)
+
+	triggered := func(tx *domain.Transaction) []string {
+		results, err := engine.EvaluateRules(ctx, tx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []string
+		for _, result := range results {
+			ids = append(ids, result.RuleID)
+		}
+		return ids
+	}
+
+	if got := triggered(&domain.Transaction{Type: domain.TypeWithdrawal, Currency: "EUR", Amount: 500}); !slices.Equal(got, []string{"eur", "withdrawal", "over-100", "at-least-500"}) {
+		t.Errorf("unexpected triggered rules: %v", got)
+	}
+	if got := triggered(&domain.Transaction{Type: domain.TypeDeposit, Currency: "USD", Amount: 5}); !slices.Equal(got, []string{"under-10"}) {
+		t.Errorf("unexpected triggered rules: %v", got)
+	}
+	if stats := engine.RuleStats("eur"); stats.Evaluations != 2 || stats.Skipped != 1 || stats.Triggers != 1 {
+		t.Errorf("expected eur rule skipped by the index once, got %+v", stats)
+	}
+
+	engine.WithStopOnFirstBlock(true)
+	got := triggered(&domain.Transaction{Type: domain.TypeWithdrawal, Currency: "EUR", Amount: 500, RiskScore: 80})
+	if !slices.Equal(got, []string{"risky"}) {
+		t.Errorf("expected evaluation to stop at the highest-priority block, got %v", got)
+	}
+}
+
+func TestTransactionProcessor_StatementReadsConsistentSnapshot(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	proc := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+
+	from := time.Now().Add(-time.Hour)
+	deposit := domain.NewTransaction(domain.TypeDeposit, 50, "USD").WithAccounts("", "a1")
+	if err := proc.ProcessTransaction(ctx, deposit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	statement, err := proc.Statement(ctx, "a1", from, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer statement.Release()
+
+	late := domain.NewTransaction(domain.TypeWithdrawal, 30, "USD").WithAccounts("a1", "")
+	_ = proc.ProcessTransaction(ctx, late)
+	_ = txRepo.UpdateStatus(ctx, deposit.ID, domain.StatusFailed)
+
+	var buf bytes.Buffer
+	if err := statement.Write(ctx, &buf, DecisionFormatJSONL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statement.OpeningBalance != 100 || statement.ClosingBalance != 150 {
+		t.Errorf("expected opening 100 and closing 150, got %f / %f", statement.OpeningBalance, statement.ClosingBalance)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 1 || !strings.Contains(lines[0], `"status":"completed"`) || !strings.Contains(lines[0], `"balance_after":150`) {
+		t.Errorf("expected only the snapshotted deposit, got %q", buf.String())
+	}
+}
+
+func TestTransactionProcessor_ApplyBalanceMigration(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Balance: 100, Status: domain.AccountActive, Currency: "EUR"})
+	proc := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).WithAuditLog(memory.NewAuditRepository())
+
+	_, err := proc.ApplyBalanceMigration(ctx, BalanceMigration{
+		Reference: "legacy-1",
+		Operator:  "ops",
+		Adjustments: []BalanceAdjustment{
+			{AccountID: "a1", Amount: 50, Reason: "opening balance"},
+			{AccountID: "missing", Amount: 10, Reason: "opening balance"},
+		},
+	})
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 100 {
+		t.Errorf("expected untouched balance 100, got %f", acc.Balance)
+	}
+
+	migration := BalanceMigration{
+		Reference: "legacy-1",
+		Operator:  "ops",
+		Adjustments: []BalanceAdjustment{
+			{AccountID: "a1", Amount: 50, Reason: "opening balance"},
+			{AccountID: "a2", Amount: -25, Reason: "legacy fee"},
+		},
+	}
+	txs, err := proc.ApplyBalanceMigration(ctx, migration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 150 {
+		t.Errorf("expected 150, got %f", acc.Balance)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a2"); acc.Balance != 75 {
+		t.Errorf("expected 75, got %f", acc.Balance)
+	}
+	if len(txs) != 2 || txs[1].FromAccountID != "a2" || txs[1].Amount != 25 || txs[1].Currency != "EUR" || txs[1].Status != domain.StatusCompleted {
+		t.Errorf("unexpected adjustment transactions: %+v", txs)
+	}
+
+	if _, err := proc.ApplyBalanceMigration(ctx, migration); !errors.Is(err, repository.ErrDuplicate) {
+		t.Errorf("expected duplicate migration to be rejected, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 150 {
+		t.Errorf("expected replay to leave balance at 150, got %f", acc.Balance)
+	}
+}
+
+type failingSaveAllRepository struct {
+	*memory.TransactionRepository
+	failures int
+}
+
+func (r *failingSaveAllRepository) SaveAll(ctx context.Context, txs []*domain.Transaction) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("connection reset")
+	}
+	return r.TransactionRepository.SaveAll(ctx, txs)
+}
+
+func TestTransactionProcessor_ApplyBalanceMigrationRetriesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := &failingSaveAllRepository{TransactionRepository: memory.NewTransactionRepository(), failures: 1}
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	proc := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).WithAuditLog(memory.NewAuditRepository())
+	var adjusted int
+	proc.OnEvent("balance_adjusted", func(ctx context.Context, event domain.TransactionEvent) error {
+		adjusted += proc.GetMetrics()["balance_adjustments"]
+		return nil
+	})
+	migration := BalanceMigration{
+		Reference:   "legacy-2",
+		Operator:    "ops",
+		Adjustments: []BalanceAdjustment{{AccountID: "a1", Amount: 50, Reason: "opening balance"}},
+	}
+
+	if _, err := proc.ApplyBalanceMigration(ctx, migration); err == nil {
+		t.Fatal("expected failed save to fail the migration")
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 100 {
+		t.Errorf("expected reverted balance 100, got %f", acc.Balance)
+	}
+
+	if _, err := proc.ApplyBalanceMigration(ctx, migration); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 150 {
+		t.Errorf("expected 150 after retry, got %f", acc.Balance)
+	}
+	if adjusted != 1 {
+		t.Errorf("expected event hook to run once after the lock is released, got %d", adjusted)
+	}
+}
+
+type blockingShadow struct{}
+
+func (blockingShadow) Name() string { return "blocking" }
+
+func (blockingShadow) Evaluate(ctx context.Context, tx *domain.Transaction) (ShadowDecision, error) {
+	tx.Amount = 0
+	return ShadowDecision{Status: domain.StatusFailed}, nil
+}
+
+func TestTransactionProcessor_ShadowPipelineComparesDecisions(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+	proc := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1)
+	cfg := DefaultShadowConfig()
+	cfg.SampleRate = 1
+
+	waitSampled := func(n int) *ShadowReport {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			report, _ := proc.ShadowReport()
+			if report.Sampled >= n || time.Now().After(deadline) {
+				return report
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	proc.WithShadowPipeline(NewDryRunPipeline(proc), cfg)
+	tx := domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("a1", "a2")
+	if err := proc.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("ProcessTransaction: %v", err)
+	}
+	if report := waitSampled(1); report.Sampled != 1 || report.Matched != 1 || report.Diverged != 0 {
+		t.Fatalf("expected dry run to match primary decision, got %+v", report)
+	}
+
+	proc.WithShadowPipeline(blockingShadow{}, cfg)
+	tx = domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("a1", "a2")
+	if err := proc.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("ProcessTransaction: %v", err)
+	}
+	report := waitSampled(1)
+	if report.Diverged != 1 || len(report.Divergences) != 1 || report.Divergences[0].Fields[0] != "status" {
+		t.Fatalf("expected status divergence, got %+v", report)
+	}
+	if tx.Amount != 50 || tx.Status != domain.StatusCompleted {
+		t.Errorf("expected shadow pipeline to leave primary transaction untouched, got %v / %s", tx.Amount, tx.Status)
+	}
+	if payee, _ := accRepo.GetByID(ctx, "a2"); payee.Balance != 100 {
+		t.Errorf("expected only primary executions to move funds, got %v", payee.Balance)
+	}
+}
+
+func TestTransactionProcessor_DryRunPipelineSkipsRuleActions(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := memory.NewRuleRepository()
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID: "r1", Name: "risk_bump", IsActive: true, Priority: 20,
+		Condition: `{"field":"amount","operator":">","value":100}`,
+		Action:    `{"type":"adjust_risk_score","params":{"adjustment":30}}`,
+	})
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID: "r2", Name: "block_large", IsActive: true, Priority: 10,
+		Condition: `{"field":"amount","operator":">","value":100}`,
+		Action:    `{"type":"block_transaction","message":"Too large"}`,
+	})
+	proc := NewTransactionProcessor(memory.NewTransactionRepository(), memory.NewAccountRepository(), ruleRepo, 1)
+	var logs bytes.Buffer
+	proc.ruleEngine.logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	tx := domain.NewTransaction(domain.TypeTransfer, 500, "USD").WithAccounts("a1", "a2")
+	decision, err := NewDryRunPipeline(proc).Evaluate(ctx, tx)
+
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if decision.Status != domain.StatusFailed || decision.DecidedByRule == "" {
+		t.Errorf("expected dry run to resolve the block decision, got %+v", decision)
+	}
+	if decision.RiskScore < 30 {
+		t.Errorf("expected dry run to include the rule's risk adjustment, got %d", decision.RiskScore)
+	}
+	for _, entry := range []string{"Transaction blocked", "Risk score adjusted"} {
+		if strings.Contains(logs.String(), entry) {
+			t.Errorf("expected dry run not to execute rule actions, found %q in %s", entry, logs.String())
+		}
+	}
+}
+
+func TestShardedPool_PreservesPerAccountOrdering(t *testing.T) {
+	pool := NewShardedPool(DefaultShardingConfig(4), nil, nil)
+
+	var mu sync.Mutex
+	order := make(map[string][]int)
+	accounts := []string{"a1", "a2", "a3", "a4", "a5", "a6"}
+	for i := 0; i < 50; i++ {
+		for _, account := range accounts {
+			account, i := account, i
+			if err := pool.Submit(account, QueueBatch, func(ctx context.Context) {
+				mu.Lock()
+				order[account] = append(order[account], i)
+				mu.Unlock()
+			}); err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+		}
+	}
+	if err := pool.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	for _, account := range accounts {
+		if len(order[account]) != 50 || !slices.IsSorted(order[account]) {
+			t.Errorf("expected 50 jobs in submission order for %s, got %v", account, order[account])
+		}
+	}
+	if router := NewHashRouter(4); router.Shard("a1") != router.Shard("a1") || router.Shards() != 4 {
+		t.Error("expected hash router to be deterministic")
+	}
+}
+
+func TestTransactionProcessor_ShardedBatch(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+	proc := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithSharding(NewShardedPool(DefaultShardingConfig(3), nil, nil))
+	defer proc.Shutdown(ctx)
+
+	var txs []*domain.Transaction
+	for i := 0; i < 12; i++ {
+		txs = append(txs, domain.NewTransaction(domain.TypeTransfer, 10, "USD").WithAccounts("a1", "a2"))
+	}
+	errs := proc.ProcessBatch(ctx, txs)
+
+	var failed int
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	payer, _ := accRepo.GetByID(ctx, "a1")
+	if failed != 2 || payer.Balance != 0 {
+		t.Errorf("expected ten sequential debits and two insufficient-funds failures, got %d failures, balance %v", failed, payer.Balance)
+	}
+	if stats, ok := proc.ShardStats(); !ok || len(stats.Shards) != 3 {
+		t.Errorf("expected stats for three shards, got %+v", stats)
+	}
+}
+
+type recordingBudgetObserver struct {
+	warnings []BudgetWarning
+}
+
+func (o *recordingBudgetObserver) BudgetExceeded(ctx context.Context, warning BudgetWarning) {
+	o.warnings = append(o.warnings, warning)
+}
+
+func TestTransactionProcessor_WarnBudgetExceededCompletesAndWarnsOnce(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	ruleRepo := memory.NewRuleRepository()
+	budgetRepo := memory.NewBudgetRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "spender", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "cafe", UserID: "u2", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+	_ = ruleRepo.Save(ctx, &domain.Rule{
+		ID:        "budget-warn",
+		Name:      "dining budget",
+		IsActive:  true,
+		Condition: `{"field":"metadata","operator":"==","value":{"category":"dining"}}`,
+		Action:    `{"type":"warn_budget_exceeded"}`,
+	})
+	_ = budgetRepo.Save(ctx, domain.NewBudget("spender", "dining", 100, domain.BudgetMonthly))
+	observer := &recordingBudgetObserver{}
+	proc := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, ruleRepo, 1).WithBudgets(budgetRepo, observer)
+
+	var txs []*domain.Transaction
+	for _, amount := range []float64{60, 50, 20} {
+		tx := domain.NewTransaction(domain.TypeTransfer, amount, "USD").WithAccounts("spender", "cafe")
+		tx.AddMetadata(MetadataCategory, "dining")
+		if err := proc.ProcessTransaction(ctx, tx); err != nil {
+			t.Fatalf("transfer failed: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	for _, tx := range txs {
+		if tx.Status != domain.StatusCompleted {
+			t.Errorf("expected soft budget enforcement to complete %s, got %s", tx.ID, tx.Status)
+		}
+	}
+	if txs[0].Metadata[MetadataBudgetExceeded] != "" {
+		t.Error("expected transaction within budget to stay unmarked")
+	}
+	if txs[1].Metadata[MetadataBudgetExceeded] != "true" || txs[1].Metadata[MetadataBudgetSpent] != "110.00" {
+		t.Errorf("expected crossing transaction to be marked, got %v", txs[1].Metadata)
+	}
+	if txs[2].Metadata[MetadataBudgetExceeded] != "true" {
+		t.Error("expected transactions over budget to stay marked")
+	}
+	if len(observer.warnings) != 1 || observer.warnings[0].TransactionID != txs[1].ID || observer.warnings[0].Spent != 110 {
+		t.Errorf("expected a single warning for the crossing transaction, got %+v", observer.warnings)
+	}
+}
+
+func TestFraudDetector_TimeBasedModifiers(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "tokyo", Timezone: "Asia/Tokyo"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "broken", Timezone: "Mars/Olympus"})
+	always := FraudPattern{Name: "always", Detect: func(*domain.Transaction) (bool, string) { return true, "always" }, Weight: 10}
+
+	holidays, err := ParseHolidayCalendar("2026-12-25=Christmas, 2027-01-01")
+	if err != nil {
+		t.Fatalf("failed to parse holidays: %v", err)
+	}
+	windows, err := ParseRiskyHours("22-24:20, 2-4:5")
+	if err != nil {
+		t.Fatalf("failed to parse risky hours: %v", err)
+	}
+	custom := TimeModifierConfig{RiskyHours: windows, Holidays: holidays, HolidayPoints: 7, Location: time.UTC}
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		cfg     *TimeModifierConfig
+		account string
+		want    int
+	}{
+		{"default before window", time.Date(2026, 3, 2, 22, 59, 0, 0, time.Local), nil, "", 10},
+		{"default window start", time.Date(2026, 3, 2, 23, 0, 0, 0, time.Local), nil, "", 25},
+		{"default window wraps midnight", time.Date(2026, 3, 2, 5, 59, 0, 0, time.Local), nil, "", 25},
+		{"default window end", time.Date(2026, 3, 2, 6, 0, 0, 0, time.Local), nil, "", 10},
+		{"custom evening window", time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC), &custom, "", 30},
+		{"custom outside windows", time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC), &custom, "", 10},
+		{"holiday adds points", time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC), &custom, "", 17},
+		{"holiday and window stack", time.Date(2027, 1, 1, 3, 0, 0, 0, time.UTC), &custom, "", 22},
+		{"account timezone shifts window", time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC), &custom, "tokyo", 30},
+		{"account timezone shifts holiday", time.Date(2026, 12, 24, 16, 0, 0, 0, time.UTC), &custom, "tokyo", 17},
+		{"invalid account timezone falls back", time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC), &custom, "broken", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := tt.at
+			fd := NewFraudDetectorWithPatterns(always).WithClock(func() time.Time { return at }).WithAccountTimezones(accRepo)
+			if tt.cfg != nil {
+				fd.WithTimeModifiers(*tt.cfg)
+			}
+			score, _ := fd.AnalyzeTransaction(&domain.Transaction{FromAccountID: tt.account})
+			if score != tt.want {
+				t.Errorf("expected score %d, got %d", tt.want, score)
+			}
+		})
+	}
+
+	if _, err := ParseRiskyHours("25-3:10"); err == nil {
+		t.Error("expected out-of-range hours to be rejected")
+	}
+	if _, err := ParseHolidayCalendar("12/25/2026"); err == nil {
+		t.Error("expected malformed holiday date to be rejected")
+	}
+}
+
+func TestTransactionProcessor_AmountTokenizationPerSink(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+
+	var logs bytes.Buffer
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithAmountTokenization(AmountTokenizationConfig{Sinks: []AmountSink{AmountSinkLogs, AmountSinkEvents}})
+	p.logger = slog.New(slog.NewJSONHandler(&logs, nil))
+
+	deposit := domain.NewTransaction(domain.TypeDeposit, 2345.67, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, deposit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(logs.String(), "2345.67") {
+		t.Errorf("expected exact amount to be absent from logs, got %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), `"amount":"1k–5k"`) {
+		t.Errorf("expected bucketed amount in logs, got %s", logs.String())
+	}
+
+	stored, _ := txRepo.GetByID(ctx, deposit.ID)
+	if stored.Amount != 2345.67 {
+		t.Errorf("expected repository to keep exact amount, got %v", stored.Amount)
+	}
+
+	pending := domain.NewTransaction(domain.TypeDeposit, 75, "USD").WithAccounts("", "a1")
+	pending.CreatedAt = time.Now().Add(-time.Hour)
+	_ = txRepo.Save(ctx, pending)
+	if _, err := p.ExpirePending(ctx, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case event := <-p.Events():
+		payload := event.Payload.(map[string]interface{})
+		if payload["amount"] != "0–100" {
+			t.Errorf("expected bucketed event amount, got %v", payload["amount"])
+		}
+	default:
+		t.Fatal("expected expiry event")
+	}
+
+	if got := p.AmountFor(AmountSinkWebhooks, 2345.67); got != 2345.67 {
+		t.Errorf("expected webhook sink to keep exact amount, got %v", got)
+	}
+	if _, err := ParseAmountSinks("logs, metrics"); err == nil {
+		t.Error("expected unknown sink to be rejected")
+	}
+}
+
+type flakyAccountRepository struct {
+	*memory.AccountRepository
+	down bool
+}
+
+func (r *flakyAccountRepository) Update(ctx context.Context, account *domain.Account) error {
+	if r.down {
+		return errors.New("connection refused")
+	}
+	return r.AccountRepository.Update(ctx, account)
+}
+
+func TestTransactionProcessor_DegradedModeQueuesAndDrains(t *testing.T) {
+	ctx := context.Background()
+	accRepo := &flakyAccountRepository{AccountRepository: memory.NewAccountRepository()}
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithDegradedMode(DegradedModeConfig{FailureThreshold: 2, DrainBatchSize: 10})
+
+	accRepo.down = true
+	for i := 0; i < 2; i++ {
+		deposit := domain.NewTransaction(domain.TypeDeposit, 40, "USD").WithAccounts("", "a1")
+		if err := p.ProcessTransaction(ctx, deposit); !errors.Is(err, ErrDependencyUnavailable) {
+			t.Fatalf("expected dependency failure, got %v", err)
+		}
+	}
+	if status, _ := p.DegradedStatus(); !status.Active || status.Trigger != DegradedTriggerAutomatic {
+		t.Fatalf("expected automatic degraded mode, got %+v", status)
+	}
+
+	before, _ := accRepo.GetByID(ctx, "a1")
+	baseline := before.Balance
+
+	queued := domain.NewTransaction(domain.TypeDeposit, 25, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, queued); err != nil {
+		t.Fatalf("expected queued transaction to be accepted, got %v", err)
+	}
+	if queued.Status != domain.StatusPending || queued.Metadata[MetadataDegradedQueued] != "true" {
+		t.Fatalf("expected pending queued transaction, got %s %v", queued.Status, queued.Metadata)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != baseline {
+		t.Fatalf("expected no balance change while degraded, got %v", acc.Balance)
+	}
+
+	result, err := p.DrainDegradedQueue(ctx)
+	if err != nil || result.Drained != 0 || result.Remaining != 1 {
+		t.Fatalf("expected drain to stop while dependency is down, got %+v %v", result, err)
+	}
+
+	accRepo.down = false
+	before, _ = accRepo.GetByID(ctx, "a1")
+	baseline = before.Balance
+	result, err = p.DrainDegradedQueue(ctx)
+	if err != nil || result.Drained != 1 || result.Remaining != 0 {
+		t.Fatalf("expected backlog to drain after recovery, got %+v %v", result, err)
+	}
+	if status, _ := p.DegradedStatus(); status.Active {
+		t.Errorf("expected degraded mode to clear after recovery, got %+v", status)
+	}
+	if stored, _ := txRepo.GetByID(ctx, queued.ID); stored.Status != domain.StatusCompleted {
+		t.Errorf("expected drained transaction completed, got %s", stored.Status)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != baseline+25 {
+		t.Errorf("expected drained deposit applied once, got %v", acc.Balance)
+	}
+
+	if _, err := p.SetDegraded(true, "maintenance"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	manual := domain.NewTransaction(domain.TypeDeposit, 10, "USD").WithAccounts("", "a1")
+	_ = p.ProcessTransaction(ctx, manual)
+	if result, _ := p.DrainDegradedQueue(ctx); result.Drained != 0 || result.Remaining != 1 {
+		t.Errorf("expected manual degraded mode to hold the backlog, got %+v", result)
+	}
+}
+
+func TestTransactionProcessor_QueuedTransactionExecutesOnce(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+
+	plain := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+	spoofed := domain.NewTransaction(domain.TypeDeposit, 10, "USD").WithAccounts("", "a1")
+	spoofed.AddMetadata(MetadataDegradedQueued, "true")
+	if err := plain.ProcessTransaction(ctx, spoofed); err != nil || spoofed.Status != domain.StatusCompleted {
+		t.Fatalf("expected queued marker to be ignored without degraded mode, got %s %v", spoofed.Status, err)
+	}
+
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithDegradedMode(DegradedModeConfig{FailureThreshold: 1, DrainBatchSize: 10}).
+		WithAuditLog(memory.NewAuditRepository())
+	_, _ = p.SetDegraded(true, "maintenance")
+	queued := domain.NewTransaction(domain.TypeDeposit, 25, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, queued); err != nil || queued.Status != domain.StatusPending {
+		t.Fatalf("expected queued transaction, got %s %v", queued.Status, err)
+	}
+	_, _ = p.SetDegraded(false, "")
+
+	if _, err := p.OverrideRiskDecision(ctx, queued.ID, RiskOverride{Outcome: OverrideApprove, Reason: "customer verified", Operator: "ops"}); err != nil {
+		t.Fatalf("override failed: %v", err)
+	}
+	result, err := p.DrainDegradedQueue(ctx)
+	if err != nil || result.Drained != 0 || result.Remaining != 0 {
+		t.Fatalf("expected drain to skip the already executed transaction, got %+v %v", result, err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 35 {
+		t.Errorf("expected queued deposit applied once, got %v", acc.Balance)
+	}
+}
+
+type failingAuditRepository struct {
+	*memory.AuditRepository
+}
+
+func (failingAuditRepository) Save(ctx context.Context, entry *domain.AuditEntry) error {
+	return errors.New("audit store unavailable")
+}
+
+func TestTransactionProcessor_OverrideRequiresAuditEntry(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	held := domain.NewTransaction(domain.TypeDeposit, 25, "USD").WithAccounts("", "a1")
+	held.Status = domain.StatusSuspicious
+	_ = txRepo.Save(ctx, held)
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithAuditLog(failingAuditRepository{memory.NewAuditRepository()})
+
+	approve := RiskOverride{Outcome: OverrideApprove, Reason: "customer verified", Operator: "ops"}
+	if _, err := p.OverrideRiskDecision(ctx, held.ID, approve); err == nil {
+		t.Fatal("expected override to fail without an audit entry")
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 0 {
+		t.Errorf("expected unaudited override not to execute, got balance %v", acc.Balance)
+	}
+	if stored, _ := txRepo.GetByID(ctx, held.ID); stored.Status != domain.StatusSuspicious {
+		t.Errorf("expected claim released, got %s", stored.Status)
+	}
+
+	p.WithAuditLog(memory.NewAuditRepository())
+	if _, err := p.OverrideRiskDecision(ctx, held.ID, approve); err != nil {
+		t.Fatalf("expected override to succeed once audited, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 25 {
+		t.Errorf("expected deposit applied, got balance %v", acc.Balance)
+	}
+}
+
+type deadLetterRecorder struct {
+	bodies [][]byte
+}
+
+func (r *deadLetterRecorder) Publish(ctx context.Context, body []byte) error {
+	r.bodies = append(r.bodies, body)
+	return nil
+}
+
+func TestTransactionProcessor_DepositRetry(t *testing.T) {
+	ctx := context.Background()
+	accRepo := &flakyAccountRepository{AccountRepository: memory.NewAccountRepository()}
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	dlq := &deadLetterRecorder{}
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithDepositRetry(DepositRetryConfig{MaxAttempts: 2}, dlq)
+
+	accRepo.down = true
+	recovered := domain.NewTransaction(domain.TypeDeposit, 30, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, recovered); err != nil {
+		t.Fatalf("expected deposit to be parked, got %v", err)
+	}
+	if recovered.Status != domain.StatusPending || recovered.Metadata[MetadataDepositRetry] != DepositRetryParked {
+		t.Fatalf("expected parked pending deposit, got %s %v", recovered.Status, recovered.Metadata)
+	}
+	withdrawal := domain.NewTransaction(domain.TypeWithdrawal, 5, "USD").WithAccounts("a1", "")
+	if err := p.ProcessTransaction(ctx, withdrawal); err == nil {
+		t.Fatal("expected withdrawal failure to be returned to the caller")
+	}
+
+	result, err := p.RetryParkedDeposits(ctx)
+	if err != nil || result.Rescheduled != 1 || result.Remaining != 1 {
+		t.Fatalf("expected failed retry to be rescheduled, got %+v %v", result, err)
+	}
+
+	accRepo.down = false
+	before, _ := accRepo.GetByID(ctx, "a1")
+	baseline := before.Balance
+	result, err = p.RetryParkedDeposits(ctx)
+	if err != nil || result.Completed != 1 || result.Remaining != 0 {
+		t.Fatalf("expected retry to complete after recovery, got %+v %v", result, err)
+	}
+	if stored, _ := txRepo.GetByID(ctx, recovered.ID); stored.Status != domain.StatusCompleted {
+		t.Errorf("expected retried deposit completed, got %s", stored.Status)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != baseline+30 {
+		t.Errorf("expected deposit applied once, got %v", acc.Balance)
+	}
+
+	accRepo.down = true
+	exhausted := domain.NewTransaction(domain.TypeDeposit, 20, "USD").WithAccounts("", "a1")
+	_ = p.ProcessTransaction(ctx, exhausted)
+	for i := 0; i < 2; i++ {
+		if _, err := p.RetryParkedDeposits(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	status, _ := p.DepositRetryStatus()
+	if len(status.Parked) != 0 || len(status.DeadLettered) != 1 || status.DeadLettered[0].Attempts != 2 {
+		t.Fatalf("expected deposit dead-lettered after capped attempts, got %+v", status)
+	}
+	if stored, _ := txRepo.GetByID(ctx, exhausted.ID); stored.Status != domain.StatusFailed {
+		t.Errorf("expected dead-lettered deposit failed, got %s", stored.Status)
+	}
+	if len(dlq.bodies) != 1 || !strings.Contains(string(dlq.bodies[0]), exhausted.ID) {
+		t.Errorf("expected dead letter published for %s, got %d", exhausted.ID, len(dlq.bodies))
+	}
+}
+
+func TestTransactionProcessor_ExpirePendingSkipsQueuedAndParked(t *testing.T) {
+	ctx := context.Background()
+	accRepo := &flakyAccountRepository{AccountRepository: memory.NewAccountRepository()}
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithDegradedMode(DegradedModeConfig{FailureThreshold: 5, DrainBatchSize: 10}).
+		WithDepositRetry(DepositRetryConfig{MaxAttempts: 2}, nil)
+
+	accRepo.down = true
+	parked := domain.NewTransaction(domain.TypeDeposit, 30, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, parked); err != nil || parked.Status != domain.StatusPending {
+		t.Fatalf("expected parked deposit, got %s %v", parked.Status, err)
+	}
+	accRepo.down = false
+	_, _ = p.SetDegraded(true, "maintenance")
+	queued := domain.NewTransaction(domain.TypeDeposit, 20, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, queued); err != nil || queued.Status != domain.StatusPending {
+		t.Fatalf("expected queued deposit, got %s %v", queued.Status, err)
+	}
+	abandoned := domain.NewTransaction(domain.TypeDeposit, 10, "USD").WithAccounts("", "a1")
+	_ = txRepo.Save(ctx, abandoned)
+	for _, tx := range []*domain.Transaction{parked, queued, abandoned} {
+		tx.CreatedAt = time.Now().Add(-2 * time.Hour)
+	}
+
+	expired, err := p.ExpirePending(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != abandoned.ID {
+		t.Fatalf("expected only the unowned transaction to expire, got %v", expired)
+	}
+	for _, tx := range []*domain.Transaction{parked, queued} {
+		if stored, _ := txRepo.GetByID(ctx, tx.ID); stored.Status != domain.StatusPending {
+			t.Errorf("expected %s to stay pending, got %s", tx.ID, stored.Status)
+		}
+	}
+}
+
+func TestTransactionProcessor_FeesCountTowardsAvailableFunds(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	productRepo := memory.NewProductRepository()
+	_ = productRepo.Save(ctx, &domain.Product{ID: "basic", Name: "Basic", Fees: domain.FeeSchedule{Transfer: domain.Fee{Fixed: 1}}})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD", ProductID: "basic"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).WithProducts(productRepo)
+	if err := p.EnsureSystemAccounts(ctx, SystemAccountConfig{Currencies: []string{"USD"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+
+	whole := domain.NewTransaction(domain.TypeTransfer, 100, "USD").WithAccounts("a1", "a2")
+	if err := p.ProcessTransaction(ctx, whole); !errors.Is(err, repository.ErrInsufficientFunds) {
+		t.Fatalf("expected the fee to make the full balance insufficient, got %v", err)
+	}
+	if err := p.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeTransfer, 99, "USD").WithAccounts("a1", "a2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 0 {
+		t.Errorf("expected amount and fee to exhaust the balance exactly, got %.2f", acc.Balance)
+	}
+}
+
+func TestTransactionProcessor_Corridors(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "us1", UserID: "u1", Balance: 10000, Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "mx1", UserID: "u2", Status: domain.AccountActive, Currency: "USD", Country: "MX"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "gb1", UserID: "u3", Status: domain.AccountActive, Currency: "USD", Country: "GB"})
+
+	corridors, err := ParseCorridors(`[{"id":"us-mx","source_currency":"USD","source_country":"US","destination_country":"MX","max_amount":1000,"daily_limit":1500,"fee":{"fixed":2,"percent":1}}]`)
+	if err != nil {
+		t.Fatalf("parse corridors: %v", err)
+	}
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).WithCorridors(corridors)
+	if err := p.EnsureSystemAccounts(ctx, SystemAccountConfig{Currencies: []string{"USD"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+
+	remittance := domain.NewTransaction(domain.TypeTransfer, 500, "USD").WithAccounts("us1", "mx1")
+	if err := p.ProcessTransaction(ctx, remittance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remittance.Metadata[MetadataCorridor] != "us-mx" {
+		t.Errorf("expected transfer tagged with its corridor, got %v", remittance.Metadata)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "us1"); acc.Balance != 9493 {
+		t.Errorf("expected amount plus 7.00 corridor fee debited, got balance %.2f", acc.Balance)
+	}
+
+	unsupported := domain.NewTransaction(domain.TypeTransfer, 100, "USD").WithAccounts("us1", "gb1")
+	if err := p.ProcessTransaction(ctx, unsupported); !errors.Is(err, ErrCorridorNotSupported) {
+		t.Errorf("expected unsupported corridor to be rejected, got %v", err)
+	}
+
+	oversized := domain.NewTransaction(domain.TypeTransfer, 1200, "USD").WithAccounts("us1", "mx1")
+	if err := p.ProcessTransaction(ctx, oversized); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected corridor max amount to apply, got %v", err)
+	}
+
+	if err := p.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeTransfer, 900, "USD").WithAccounts("us1", "mx1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	overDaily := domain.NewTransaction(domain.TypeTransfer, 200, "USD").WithAccounts("us1", "mx1")
+	if err := p.ProcessTransaction(ctx, overDaily); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected corridor daily limit to apply, got %v", err)
+	}
+
+	_ = accRepo.Save(ctx, &domain.Account{ID: "us2", UserID: "u4", Balance: 500, Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	whole := domain.NewTransaction(domain.TypeTransfer, 500, "USD").WithAccounts("us2", "mx1")
+	if err := p.ProcessTransaction(ctx, whole); !errors.Is(err, repository.ErrInsufficientFunds) {
+		t.Errorf("expected corridor fee to count towards available funds, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "us2"); acc.Balance != 500 {
+		t.Errorf("expected rejected remittance to leave balance untouched, got %.2f", acc.Balance)
+	}
+
+	if _, err := ParseCorridors(`[{"id":"a"},{"id":"a"}]`); !errors.Is(err, ErrInvalidCorridor) {
+		t.Errorf("expected duplicate corridor ids to be rejected, got %v", err)
+	}
+}
+
+func TestTransactionProcessor_PaymentPurpose(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "us1", UserID: "u1", Balance: 10000, Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "in1", UserID: "u2", Status: domain.AccountActive, Currency: "USD", Country: "IN"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "us2", UserID: "u3", Status: domain.AccountActive, Currency: "USD", Country: "US"})
+
+	corridors, err := ParseCorridors(`[
+		{"id":"us-in","source_country":"US","destination_country":"IN","require_purpose_code":true,"purpose_codes":["FAMI","EDUC"],"require_memo":true,"max_memo_length":20},
+		{"id":"domestic","source_country":"US","destination_country":"US"}
+	]`)
+	if err != nil {
+		t.Fatalf("parse corridors: %v", err)
+	}
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithCorridors(corridors).
+		WithPurposeCodes(DefaultPurposeCodes())
+
+	transfer := func(to, code, memo string) *domain.Transaction {
+		tx := domain.NewTransaction(domain.TypeTransfer, 100, "USD").WithAccounts("us1", to)
+		if code != "" {
+			tx.AddMetadata(MetadataPurposeCode, code)
+		}
+		if memo != "" {
+			tx.AddMetadata(MetadataMemo, memo)
+		}
+		return tx
+	}
+
+	for _, tc := range []struct {
+		name string
+		tx   *domain.Transaction
+		want error
+	}{
+		{"missing code", transfer("in1", "", "tuition"), ErrInvalidPurposeCode},
+		{"code outside corridor", transfer("in1", "SALA", "tuition"), ErrInvalidPurposeCode},
+		{"unknown code", transfer("us2", "XXXX", ""), ErrInvalidPurposeCode},
+		{"missing memo", transfer("in1", "EDUC", ""), ErrInvalidMemo},
+		{"memo too long", transfer("in1", "EDUC", "tuition for the autumn term"), ErrInvalidMemo},
+		{"control characters", transfer("us2", "", "line\nbreak"), ErrInvalidMemo},
+	} {
+		if err := p.ProcessTransaction(ctx, tc.tx); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	remittance := transfer("in1", "educ", "autumn tuition")
+	if err := p.ProcessTransaction(ctx, remittance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remittance.Metadata[MetadataPurposeCode] != "EDUC" {
+		t.Errorf("expected purpose code normalized, got %q", remittance.Metadata[MetadataPurposeCode])
+	}
+	if err := p.ProcessTransaction(ctx, transfer("us2", "", "")); err != nil {
+		t.Errorf("expected domestic transfer without purpose to pass, got %v", err)
+	}
+
+	record := NewTransactionExportRecord(remittance, "us1")
+	if record.PurposeCode != "EDUC" || record.Memo != "autumn tuition" {
+		t.Errorf("expected purpose and memo in export record, got %+v", record)
+	}
+}
+
+func TestTransactionProcessor_SuspenseRepair(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "right", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithSuspense(memory.NewSuspenseRepository())
+	if err := p.EnsureSystemAccounts(ctx, SystemAccountConfig{Currencies: []string{"USD"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+	suspense, _ := p.SystemAccount(domain.SystemRoleSuspense, "USD")
+
+	deposits := make([]*domain.Transaction, 2)
+	for i := range deposits {
+		deposits[i] = domain.NewTransaction(domain.TypeDeposit, 120, "USD").WithAccounts("", "typo")
+		if err := p.ProcessTransaction(ctx, deposits[i]); err != nil {
+			t.Fatalf("expected unmatched deposit to be held, got %v", err)
+		}
+	}
+	if deposits[0].ToAccountID != suspense || deposits[0].Metadata[MetadataSuspenseIntendedAccount] != "typo" {
+		t.Errorf("expected deposit credited to suspense, got %s %v", deposits[0].ToAccountID, deposits[0].Metadata)
+	}
+	if items, _ := p.SuspenseItems(ctx, domain.SuspenseOpen); len(items) != 2 {
+		t.Fatalf("expected two open suspense items, got %d", len(items))
+	}
+
+	repair := SuspenseRepair{AccountID: "right", Reason: "customer confirmed", Operator: "ops1"}
+	item, err := p.ReassignSuspense(ctx, deposits[0].ID, repair)
+	if err != nil {
+		t.Fatalf("ReassignSuspense failed: %v", err)
+	}
+	if item.Status != domain.SuspenseReassigned || item.ResolvedAccountID != "right" {
+		t.Errorf("expected item reassigned to right, got %+v", item)
+	}
+	if _, err := p.ReturnSuspense(ctx, deposits[0].ID, repair); !errors.Is(err, ErrSuspenseItemResolved) {
+		t.Errorf("expected resolved item to be final, got %v", err)
+	}
+	if _, err := p.ReturnSuspense(ctx, deposits[1].ID, SuspenseRepair{Operator: "ops1"}); !errors.Is(err, ErrInvalidSuspenseRepair) {
+		t.Errorf("expected reason to be required, got %v", err)
+	}
+	if _, err := p.ReturnSuspense(ctx, deposits[1].ID, SuspenseRepair{Reason: "no such customer", Operator: "ops1"}); err != nil {
+		t.Fatalf("ReturnSuspense failed: %v", err)
+	}
+
+	if acc, _ := accRepo.GetByID(ctx, "right"); acc.Balance != 120 {
+		t.Errorf("expected reassigned funds on the right account, got %.2f", acc.Balance)
+	}
+	if acc, _ := accRepo.GetByID(ctx, suspense); acc.Balance != 0 {
+		t.Errorf("expected suspense cleared after repairs, got %.2f", acc.Balance)
+	}
+	if items, _ := p.SuspenseItems(ctx, domain.SuspenseOpen); len(items) != 0 {
+		t.Errorf("expected empty repair queue, got %d", len(items))
+	}
+
+	plain := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1)
+	if err := plain.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeDeposit, 10, "USD").WithAccounts("", "typo")); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("expected unknown account to fail without suspense handling, got %v", err)
+	}
+}
+
+func TestTransactionProcessor_SpendingControls(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "card", UserID: "u1", Balance: 5000, Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "local", UserID: "u2", Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "abroad", UserID: "u3", Status: domain.AccountActive, Currency: "USD", Country: "FR"})
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithSpendingControls(memory.NewSpendingControlsRepository())
+
+	err := p.SetSpendingControls(ctx, &domain.SpendingControls{
+		AccountID:            "card",
+		BlockInternational:   true,
+		BlockOnline:          true,
+		BlockedCategories:    []string{"Gambling", " "},
+		MaxTransactionAmount: 500,
+	})
+	if err != nil {
+		t.Fatalf("SetSpendingControls failed: %v", err)
+	}
+
+	online := domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("card", "local")
+	online.AddMetadata(MetadataChannel, ChannelOnline)
+	gambling := domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("card", "local")
+	gambling.AddMetadata(MetadataCategory, "gambling")
+	atmAbroad := domain.NewTransaction(domain.TypeWithdrawal, 50, "USD").WithAccounts("card", "")
+	atmAbroad.AddMetadata(MetadataCountry, "MX")
+	claimsSystemPosting := domain.NewTransaction(domain.TypeTransfer, 600, "USD").WithAccounts("card", "local")
+	claimsSystemPosting.AddMetadata("system_posting", "fee")
+	for name, tx := range map[string]*domain.Transaction{
+		"system posting metadata": claimsSystemPosting,
+		"online":                  online,
+		"category":                gambling,
+		"international":           domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("card", "abroad"),
+		"withdrawal":              atmAbroad,
+		"max amount":              domain.NewTransaction(domain.TypeTransfer, 600, "USD").WithAccounts("card", "local"),
+	} {
+		if err := p.ProcessTransaction(ctx, tx); !errors.Is(err, ErrBlockedBySpendingControls) {
+			t.Errorf("%s: expected spending controls to block, got %v", name, err)
+		}
+	}
+
+	if err := p.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("card", "local")); err != nil {
+		t.Errorf("expected domestic in-person transfer to pass, got %v", err)
+	}
+
+	controls, err := p.SpendingControls(ctx, "card")
+	if err != nil {
+		t.Fatalf("SpendingControls failed: %v", err)
+	}
+	if !slices.Equal(controls.BlockedCategories, []string{"Gambling"}) {
+		t.Errorf("expected blank categories dropped, got %v", controls.BlockedCategories)
+	}
+	controls.BlockInternational = false
+	if err := p.SetSpendingControls(ctx, controls); err != nil {
+		t.Fatalf("SetSpendingControls failed: %v", err)
+	}
+	if err := p.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("card", "abroad")); err != nil {
+		t.Errorf("expected international transfer to pass once unblocked, got %v", err)
+	}
+
+	if _, err := p.SpendingControls(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("expected unknown account to be rejected, got %v", err)
+	}
+}
+
+type recordingThresholdObserver struct {
+	crossings []LimitThresholdCrossing
+}
+
+func (o *recordingThresholdObserver) LimitThresholdCrossed(ctx context.Context, crossing LimitThresholdCrossing) {
+	o.crossings = append(o.crossings, crossing)
+}
+
+func TestTransactionProcessor_LimitThresholdCrossings(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "spender", UserID: "u1", Balance: 10000, Status: domain.AccountActive, Currency: "USD", DailyLimit: 1000, MonthlyLimit: 10000})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "payee", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	observer := &recordingThresholdObserver{}
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithLimitThresholds(DefaultLimitThresholdConfig(), observer)
+
+	for _, amount := range []float64{300, 250, 100, 200, 100} {
+		tx := domain.NewTransaction(domain.TypeTransfer, amount, "USD").WithAccounts("spender", "payee")
+		if err := p.ProcessTransaction(ctx, tx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var daily []float64
+	for _, crossing := range observer.crossings {
+		if crossing.LimitType != domain.LimitDaily {
+			t.Errorf("expected only daily crossings, got %+v", crossing)
+			continue
+		}
+		daily = append(daily, crossing.Threshold)
+	}
+	if !slices.Equal(daily, []float64{50, 80}) {
+		t.Errorf("expected 50%% then 80%% crossings reported once each, got %v", daily)
+	}
+
+	p.WithLimitThresholds(LimitThresholdConfig{Percentages: []float64{10, 100}}, observer)
+	observer.crossings = nil
+	if err := p.ProcessTransaction(ctx, domain.NewTransaction(domain.TypeTransfer, 50, "USD").WithAccounts("spender", "payee")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(observer.crossings) != 2 || observer.crossings[0].Threshold != 100 || observer.crossings[0].Used != 1000 {
+		t.Errorf("expected the daily limit to be reported fully used, got %+v", observer.crossings)
+	} else if monthly := observer.crossings[1]; monthly.LimitType != domain.LimitMonthly || monthly.Threshold != 10 {
+		t.Errorf("expected the monthly 10%% threshold to be crossed, got %+v", monthly)
+	}
+
+	if _, err := ParseLimitThresholds("50,abc"); !errors.Is(err, ErrInvalidLimitThreshold) {
+		t.Errorf("expected invalid threshold spec to be rejected, got %v", err)
+	}
+}
+
+func TestTransactionProcessor_SandboxScenarios(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 50000, Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).WithSandbox(DefaultSandboxConfig())
+
+	nsf := domain.NewTransaction(domain.TypeWithdrawal, 10, "USD").WithAccounts("a1", "")
+	nsf.Amount = DefaultSandboxConfig().Amounts[SandboxInsufficientFunds]
+	if err := p.ProcessTransaction(ctx, nsf); !errors.Is(err, repository.ErrInsufficientFunds) {
+		t.Errorf("expected magic amount to fail with insufficient funds, got %v", err)
+	}
+
+	timeout := domain.NewTransaction(domain.TypeDeposit, 10, "USD").WithAccounts("", "SANDBOX-TIMEOUT")
+	var stageErr *StageTimeoutError
+	if err := p.ProcessTransaction(ctx, timeout); !errors.As(err, &stageErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected stage timeout, got %v", err)
+	}
+
+	fraud := domain.NewTransaction(domain.TypeTransfer, 10, "USD").WithAccounts("a1", "SANDBOX-FRAUD")
+	if err := p.ProcessTransaction(ctx, fraud); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fraud.Status != domain.StatusSuspicious || !slices.Contains(fraud.FraudFlags, SandboxFraudFlag) {
+		t.Errorf("expected suspicious sandbox transaction, got %s %v", fraud.Status, fraud.FraudFlags)
+	}
+
+	approval := domain.NewTransaction(domain.TypeWithdrawal, 10, "USD").WithAccounts("a1", "")
+	approval.Amount = DefaultSandboxConfig().Amounts[SandboxApprovalRequired]
+	if err := p.ProcessTransaction(ctx, approval); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approval.Status != domain.StatusPending || approval.Metadata[MetadataSandboxScenario] != SandboxApprovalRequired {
+		t.Errorf("expected pending approval, got %s %v", approval.Status, approval.Metadata)
+	}
+
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 50000 {
+		t.Errorf("expected sandbox scenarios to leave balances untouched, got %v", acc.Balance)
+	}
+
+	plain := domain.NewTransaction(domain.TypeWithdrawal, 10, "USD").WithAccounts("a1", "")
+	if err := p.ProcessTransaction(ctx, plain); err != nil || plain.Status != domain.StatusCompleted {
+		t.Errorf("expected ordinary transaction to complete, got %s %v", plain.Status, err)
+	}
+}
+
+func TestTransactionProcessor_AdmissionControl(t *testing.T) {
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), memory.NewAccountRepository(), memory.NewRuleRepository(), 1).
+		WithAdmissionControl(AdmissionConfig{MaxWorkerLoad: 2, MaxRepositoryLatency: 100 * time.Millisecond, LatencySmoothing: 0.5})
+	defer p.Shutdown(context.Background())
+
+	if err := p.Admit(); err != nil {
+		t.Fatalf("expected idle processor to admit, got %v", err)
+	}
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = p.WorkerPool().Submit(QueueBatch, func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+	for i := 0; i < 2; i++ {
+		_ = p.WorkerPool().Submit(QueueBatch, func(ctx context.Context) {})
+	}
+	var overCapacity *OverCapacityError
+	if err := p.Admit(); !errors.As(err, &overCapacity) || overCapacity.Signal != AdmissionSignalWorkerLoad || overCapacity.RetryAfter <= 0 {
+		t.Fatalf("expected worker load rejection, got %v", err)
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for p.Admit() != nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := p.Admit(); err != nil {
+		t.Fatalf("expected admission after the pool drained, got %v", err)
+	}
+
+	p.observeRepositoryLatency(300 * time.Millisecond)
+	if err := p.Admit(); !errors.As(err, &overCapacity) || overCapacity.Signal != AdmissionSignalRepositoryLatency {
+		t.Fatalf("expected repository latency rejection, got %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		p.observeRepositoryLatency(10 * time.Millisecond)
+	}
+	status, _ := p.AdmissionStatus()
+	if !status.Admitting || status.Rejected[AdmissionSignalWorkerLoad] == 0 || status.Rejected[AdmissionSignalRepositoryLatency] != 1 {
+		t.Errorf("expected latency to recover and rejections to be counted, got %+v", status)
+	}
+}
+
+func TestTransactionProcessor_MaintenanceWindows(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).
+		WithDegradedMode(DefaultDegradedModeConfig()).
+		WithMaintenanceWindows(nil)
+
+	now := time.Now()
+	rejecting, err := p.ScheduleMaintenance(MaintenanceWindow{Start: now.Add(-time.Second), End: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	if _, err := p.ScheduleMaintenance(MaintenanceWindow{Start: now.Add(30 * time.Minute), End: now.Add(2 * time.Hour)}); !errors.Is(err, ErrInvalidMaintenanceWindow) {
+		t.Errorf("expected overlapping window to be rejected, got %v", err)
+	}
+	if reason, held := p.MaintenanceHold(time.Now()); !held || reason == "" {
+		t.Error("expected scheduled jobs to be held during maintenance")
+	}
+
+	deposit := domain.NewTransaction(domain.TypeDeposit, 10, "USD").WithAccounts("", "a1")
+	var maintenanceErr *MaintenanceError
+	if err := p.ProcessTransaction(ctx, deposit); !errors.As(err, &maintenanceErr) || maintenanceErr.Window.ID != rejecting.ID {
+		t.Fatalf("expected maintenance rejection, got %v", err)
+	}
+	if err := p.CancelMaintenance(rejecting.ID); err != nil {
+		t.Fatalf("CancelMaintenance failed: %v", err)
+	}
+
+	queueing, err := p.ScheduleMaintenance(MaintenanceWindow{Start: time.Now().Add(-time.Second), End: time.Now().Add(100 * time.Millisecond), Mode: MaintenanceQueue})
+	if err != nil {
+		t.Fatalf("ScheduleMaintenance failed: %v", err)
+	}
+	queued := domain.NewTransaction(domain.TypeDeposit, 25, "USD").WithAccounts("", "a1")
+	if err := p.ProcessTransaction(ctx, queued); err != nil {
+		t.Fatalf("expected transaction to be queued, got %v", err)
+	}
+	if queued.Status != domain.StatusPending || queued.Metadata[MetadataMaintenanceWindow] != queueing.ID {
+		t.Fatalf("expected pending transaction tagged with the window, got %s %v", queued.Status, queued.Metadata)
+	}
+	if result, _ := p.DrainDegradedQueue(ctx); result.Drained != 0 || result.Remaining != 1 {
+		t.Fatalf("expected backlog held during maintenance, got %+v", result)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if status := p.MaintenanceStatus(time.Now()); status.Active || len(status.Upcoming) != 0 {
+		t.Fatalf("expected window to have ended, got %+v", status)
+	}
+	if result, err := p.DrainDegradedQueue(ctx); err != nil || result.Drained != 1 {
+		t.Fatalf("expected backlog to drain after maintenance, got %+v %v", result, err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 25 {
+		t.Errorf("expected queued deposit applied after maintenance, got %v", acc.Balance)
+	}
+}
+
+type sharedLimitEngine struct {
+	used     map[string]float64
+	cap      float64
+	recorded []string
+}
+
+func (e *sharedLimitEngine) CheckTransfer(ctx context.Context, account *domain.Account, terms LimitTerms, tx *domain.Transaction) error {
+	if !tx.AddLimitCheck("shared_daily", e.cap, e.used[account.ID]+tx.Amount) {
+		return fmt.Errorf("shared %w", ErrLimitExceeded)
+	}
+	return nil
+}
+
+func (e *sharedLimitEngine) CheckDeposit(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
+	return nil
+}
+
+func (e *sharedLimitEngine) CheckWithdrawal(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
+	return e.CheckTransfer(ctx, account, LimitTerms{}, tx)
+}
+
+func (e *sharedLimitEngine) RecordUsage(ctx context.Context, tx *domain.Transaction) error {
+	e.used[tx.FromAccountID] += tx.Amount
+	e.recorded = append(e.recorded, tx.ID)
+	return nil
+}
+
+func TestTransactionProcessor_ExternalLimitService(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	engine := &sharedLimitEngine{used: map[string]float64{"a1": 250}, cap: 300}
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithLimitService(engine)
+
+	first := domain.NewTransaction(domain.TypeTransfer, 40, "USD").WithAccounts("a1", "a2")
+	if err := p.ProcessTransaction(ctx, first); err != nil {
+		t.Fatalf("expected transfer within the shared limit, got %v", err)
+	}
+	if len(engine.recorded) != 1 || engine.recorded[0] != first.ID {
+		t.Fatalf("expected completed transfer to be recorded, got %v", engine.recorded)
+	}
+
+	second := domain.NewTransaction(domain.TypeTransfer, 40, "USD").WithAccounts("a1", "a2")
+	if err := p.ProcessTransaction(ctx, second); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected shared limit to block the transfer, got %v", err)
+	}
+	if second.Explanation == nil || len(second.Explanation.LimitChecks) != 1 || second.Explanation.LimitChecks[0].Name != "shared_daily" {
+		t.Errorf("expected the external limit check on the transaction, got %+v", second.Explanation)
+	}
+}
+
+func TestTransactionProcessor_Prevalidate(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD", DailyLimit: 500})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	txRepo := memory.NewTransactionRepository()
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+
+	ok := domain.NewTransaction(domain.TypeTransfer, 120.75, "USD").WithAccounts("a1", "a2")
+	result := p.Prevalidate(ctx, ok)
+	if result.Err != nil || result.Transaction.Status != domain.StatusCompleted {
+		t.Fatalf("expected transfer to be accepted, got %v (%s)", result.Err, result.Transaction.Status)
+	}
+	if ok.Status != domain.StatusPending || ok.Explanation != nil {
+		t.Errorf("expected input transaction untouched, got status %s", ok.Status)
+	}
+	from, _ := accRepo.GetByID(ctx, "a1")
+	if from.Balance != 1000 {
+		t.Errorf("expected balance unchanged, got %.2f", from.Balance)
+	}
+	if _, err := txRepo.GetByID(ctx, ok.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("expected nothing persisted, got %v", err)
+	}
+
+	over := domain.NewTransaction(domain.TypeTransfer, 600.25, "USD").WithAccounts("a1", "a2")
+	result = p.Prevalidate(ctx, over)
+	if !errors.Is(result.Err, ErrLimitExceeded) || result.Transaction.Status != domain.StatusFailed {
+		t.Fatalf("expected daily limit rejection, got %v", result.Err)
+	}
+	if checks := result.Transaction.Explanation.LimitChecks; len(checks) != 1 || checks[0].Name != "daily_limit" {
+		t.Errorf("expected daily limit check in explanation, got %+v", checks)
+	}
+
+	if err := p.ProcessTransaction(ctx, ok); err != nil {
+		t.Fatalf("expected prevalidated transaction to still be processable, got %v", err)
+	}
+}
+
+func TestTransactionProcessor_MultiLegTransfer(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "payer", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "p1", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "p2", UserID: "u3", Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "closed", UserID: "u4", Status: domain.AccountClosed, Currency: "USD"})
+	txRepo := memory.NewTransactionRepository()
+	p := NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+
+	payout := domain.NewTransaction(domain.TypeTransfer, 300.75, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "p1", Amount: 200.5}, domain.TransactionLeg{ToAccountID: "p2", Amount: 100.25, Description: "bonus"})
+	if err := p.ProcessTransaction(ctx, payout); err != nil {
+		t.Fatalf("expected multi-leg transfer to complete, got %v", err)
+	}
+	for id, want := range map[string]float64{"payer": 699.25, "p1": 200.5, "p2": 100.25} {
+		if account, _ := accRepo.GetByID(ctx, id); account.Balance != want {
+			t.Errorf("expected %s balance %.2f, got %.2f", id, want, account.Balance)
+		}
+	}
+	entries, _ := txRepo.GetByAccountID(ctx, "p2", 10, 0)
+	if len(entries) != 1 || entries[0].Metadata[MetadataLinkedTransaction] != payout.ID || entries[0].Metadata[MetadataLegIndex] != "1" || entries[0].Description != "bonus" {
+		t.Fatalf("expected a linked credit entry for the second leg, got %+v", entries)
+	}
+	if missing := missingLedgerLegs(payout); len(missing) > 0 {
+		t.Errorf("expected transfer with legs to have a full ledger, missing %v", missing)
+	}
+	if missing := missingLedgerLegs(entries[0]); len(missing) > 0 {
+		t.Errorf("expected leg entry to have a full ledger, missing %v", missing)
+	}
+
+	blocked := domain.NewTransaction(domain.TypeTransfer, 50.5, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "p1", Amount: 25.25}, domain.TransactionLeg{ToAccountID: "closed", Amount: 25.25})
+	if err := p.ProcessTransaction(ctx, blocked); !errors.Is(err, ErrAccountInactive) {
+		t.Fatalf("expected inactive leg to fail the whole transfer, got %v", err)
+	}
+	if account, _ := accRepo.GetByID(ctx, "p1"); account.Balance != 200.5 {
+		t.Errorf("expected no leg to be credited, got %.2f", account.Balance)
+	}
+
+	uneven := domain.NewTransaction(domain.TypeTransfer, 50.5, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "p1", Amount: 20})
+	if err := p.ProcessTransaction(ctx, uneven); !errors.Is(err, ErrValidationFailed) {
+		t.Errorf("expected legs not summing to the amount to fail validation, got %v", err)
+	}
+}
+
+func TestTransactionProcessor_MultiLegTransferChecksEachLeg(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "payer", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "local", UserID: "u2", Status: domain.AccountActive, Currency: "USD", Country: "US"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "mx1", UserID: "u3", Status: domain.AccountActive, Currency: "USD", Country: "MX"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "gb1", UserID: "u4", Status: domain.AccountActive, Currency: "USD", Country: "GB"})
+
+	corridors, err := ParseCorridors(`[{"id":"us-us","source_country":"US","destination_country":"US"},{"id":"us-mx","source_country":"US","destination_country":"MX","max_amount":300,"fee":{"fixed":5}}]`)
+	if err != nil {
+		t.Fatalf("parse corridors: %v", err)
+	}
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1).
+		WithCorridors(corridors).
+		WithSpendingControls(memory.NewSpendingControlsRepository())
+	if err := p.EnsureSystemAccounts(ctx, SystemAccountConfig{Currencies: []string{"USD"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts failed: %v", err)
+	}
+
+	payout := domain.NewTransaction(domain.TypeTransfer, 300, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "local", Amount: 100}, domain.TransactionLeg{ToAccountID: "mx1", Amount: 200})
+	if err := p.ProcessTransaction(ctx, payout); err != nil {
+		t.Fatalf("expected multi-leg transfer to complete, got %v", err)
+	}
+	if payout.Legs[0].Corridor != "us-us" || payout.Legs[1].Corridor != "us-mx" {
+		t.Errorf("expected each leg tagged with its corridor, got %+v", payout.Legs)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "payer"); acc.Balance != 695 {
+		t.Errorf("expected amount plus 5.00 leg corridor fee debited, got balance %.2f", acc.Balance)
+	}
+
+	oversized := domain.NewTransaction(domain.TypeTransfer, 400, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "local", Amount: 50}, domain.TransactionLeg{ToAccountID: "mx1", Amount: 350})
+	if err := p.ProcessTransaction(ctx, oversized); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected corridor max amount to apply to the leg, got %v", err)
+	}
+
+	unsupported := domain.NewTransaction(domain.TypeTransfer, 100, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "local", Amount: 50}, domain.TransactionLeg{ToAccountID: "gb1", Amount: 50})
+	if err := p.ProcessTransaction(ctx, unsupported); !errors.Is(err, ErrCorridorNotSupported) {
+		t.Errorf("expected unsupported leg corridor to be rejected, got %v", err)
+	}
+
+	if err := p.SetSpendingControls(ctx, &domain.SpendingControls{AccountID: "payer", BlockInternational: true}); err != nil {
+		t.Fatalf("SetSpendingControls failed: %v", err)
+	}
+	international := domain.NewTransaction(domain.TypeTransfer, 100, "USD").
+		WithAccounts("payer", "").
+		WithLegs(domain.TransactionLeg{ToAccountID: "local", Amount: 50}, domain.TransactionLeg{ToAccountID: "mx1", Amount: 50})
+	if err := p.ProcessTransaction(ctx, international); !errors.Is(err, ErrBlockedBySpendingControls) {
+		t.Errorf("expected international leg to be blocked, got %v", err)
+	}
+	if acc, _ := accRepo.GetByID(ctx, "mx1"); acc.Balance != 200 {
+		t.Errorf("expected no rejected leg to be credited, got %.2f", acc.Balance)
+	}
+}
+
+func TestTransactionProcessor_OnEvent(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Status: domain.AccountActive, Currency: "USD"})
+	p := NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1)
+
+	var completed []domain.TransactionEventData
+	var all []string
+	p.OnEvent(domain.EventTransactionCompleted, func(ctx context.Context, event domain.TransactionEvent) error {
+		completed = append(completed, event.Payload.(domain.TransactionEventData))
+		return nil
+	}).OnEvent(domain.EventTransactionCompleted, func(ctx context.Context, event domain.TransactionEvent) error {
+		panic("crm unavailable")
+	}).OnEvent(AllEvents, func(ctx context.Context, event domain.TransactionEvent) error {
+		all = append(all, event.Type)
+		return errors.New("ignored")
+	})
+
+	tx := domain.NewTransaction(domain.TypeTransfer, 75.3, "USD").WithAccounts("a1", "a2")
+	if err := p.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("expected failing hooks not to affect processing, got %v", err)
+	}
+	if len(completed) != 1 || completed[0].TransactionID != tx.ID || completed[0].Status != domain.StatusCompleted {
+		t.Errorf("expected one completed event for %s, got %+v", tx.ID, completed)
+	}
+
+	p.emitEvent(ctx, domain.TransactionEvent{TransactionID: tx.ID, Type: "transaction_queued"})
+	if len(all) != 2 || all[0] != domain.EventTransactionCompleted || all[1] != "transaction_queued" {
+		t.Errorf("expected catch-all hook to see published and lifecycle events, got %v", all)
+	}
+}
+
+func TestSplitInstallmentsUsesCurrencyMinorUnits(t *testing.T) {
+	for _, tc := range []struct {
+		amount   float64
+		currency string
+		want     []float64
+	}{
+		{100, "USD", []float64{33.34, 33.33, 33.33}},
+		{1000, "JPY", []float64{334, 333, 333}},
+		{1, "KWD", []float64{0.334, 0.333, 0.333}},
+	} {
+		got, err := splitInstallments(tc.amount, tc.currency, 3)
+		if err != nil || !slices.Equal(got, tc.want) {
+			t.Errorf("%v %s: expected %v, got %v %v", tc.amount, tc.currency, tc.want, got, err)
+		}
+	}
+	if _, err := splitInstallments(2, "JPY", 3); !errors.Is(err, ErrInvalidInstallmentPlan) {
+		t.Errorf("expected 2 JPY to be too small for 3 installments, got %v", err)
+	}
+}
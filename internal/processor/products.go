@@ -0,0 +1,61 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+)
+
+var ErrTransactionTypeNotAllowed = errors.New("transaction type not allowed by account product")
+
+type accountTerms struct {
+	dailyLimit   float64
+	monthlyLimit float64
+	overdraft    float64
+	fee          domain.Fee
+}
+
+func (p *TransactionProcessor) WithProducts(productRepo repository.ProductRepository) *TransactionProcessor {
+	p.products = productRepo
+	return p
+}
+
+func (p *TransactionProcessor) accountTerms(ctx context.Context, account *domain.Account, txType domain.TransactionType) (accountTerms, error) {
+	terms := accountTerms{dailyLimit: account.DailyLimit, monthlyLimit: account.MonthlyLimit}
+	if p.products == nil || account.ProductID == "" {
+		return terms, nil
+	}
+
+	product, err := p.products.GetByID(ctx, account.ProductID)
+	if err != nil {
+		return terms, fmt.Errorf("failed to get account product: %w", err)
+	}
+	if !product.Allows(txType) {
+		return terms, fmt.Errorf("%w: %s on product %s", ErrTransactionTypeNotAllowed, txType, product.ID)
+	}
+
+	if terms.dailyLimit == 0 {
+		terms.dailyLimit = product.DailyLimit
+	}
+	if terms.monthlyLimit == 0 {
+		terms.monthlyLimit = product.MonthlyLimit
+	}
+	terms.overdraft = product.OverdraftLimit
+	terms.fee = product.Fees.For(txType)
+
+	return terms, nil
+}
+
+func (p *TransactionProcessor) EffectiveDailyLimit(ctx context.Context, accountID string, txType domain.TransactionType) (float64, error) {
+	account, err := p.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+	terms, err := p.accountTerms(ctx, account, txType)
+	if err != nil {
+		return 0, err
+	}
+	return terms.dailyLimit, nil
+}
@@ -0,0 +1,186 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	FlagAmountDeviation = "amount_deviation"
+	FlagUnusualHour     = "unusual_hour"
+	FlagUnusualCurrency = "unusual_currency"
+	FlagNewCounterparty = "new_counterparty"
+)
+
+type ProfileConfig struct {
+	MinHistory            int
+	DeviationFactor       float64
+	UnusualHourShare      float64
+	AmountDeviationWeight int
+	UnusualHourWeight     int
+	UnusualCurrencyWeight int
+	NewCounterpartyWeight int
+}
+
+func DefaultProfileConfig() ProfileConfig {
+	return ProfileConfig{
+		MinHistory:            10,
+		DeviationFactor:       3,
+		UnusualHourShare:      0.02,
+		AmountDeviationWeight: 25,
+		UnusualHourWeight:     10,
+		UnusualCurrencyWeight: 15,
+		NewCounterpartyWeight: 5,
+	}
+}
+
+type ProfileTracker struct {
+	repo repository.ProfileRepository
+	cfg  ProfileConfig
+	mu   sync.Mutex
+}
+
+func NewProfileTracker(repo repository.ProfileRepository, cfg ProfileConfig) *ProfileTracker {
+	return &ProfileTracker{repo: repo, cfg: cfg}
+}
+
+func (t *ProfileTracker) Profile(ctx context.Context, accountID string) (*domain.AccountProfile, error) {
+	return t.repo.Get(ctx, accountID)
+}
+
+func (t *ProfileTracker) Observe(ctx context.Context, tx *domain.Transaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tx.FromAccountID != "" {
+		if err := t.observe(ctx, tx.FromAccountID, tx.ToAccountID, tx); err != nil {
+			return err
+		}
+	}
+	if tx.ToAccountID != "" {
+		if err := t.observe(ctx, tx.ToAccountID, tx.FromAccountID, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ProfileTracker) observe(ctx context.Context, accountID, counterparty string, tx *domain.Transaction) error {
+	profile, err := t.repo.Get(ctx, accountID)
+	if errors.Is(err, repository.ErrNotFound) {
+		profile = domain.NewAccountProfile(accountID)
+	} else if err != nil {
+		return fmt.Errorf("failed to get profile for account %s: %w", accountID, err)
+	}
+
+	profile.Observe(tx.Amount, tx.Currency, counterparty, transactionTime(tx))
+
+	if err := t.repo.Save(ctx, profile); err != nil {
+		return fmt.Errorf("failed to save profile for account %s: %w", accountID, err)
+	}
+	return nil
+}
+
+func (t *ProfileTracker) established(tx *domain.Transaction) (*domain.AccountProfile, bool) {
+	accountID := tx.FromAccountID
+	if accountID == "" {
+		accountID = tx.ToAccountID
+	}
+	if accountID == "" {
+		return nil, false
+	}
+
+	profile, err := t.repo.Get(context.Background(), accountID)
+	if err != nil || profile.TransactionCount < t.cfg.MinHistory {
+		return nil, false
+	}
+	return profile, true
+}
+
+func (t *ProfileTracker) detectAmountDeviation(tx *domain.Transaction) (bool, string) {
+	profile, ok := t.established(tx)
+	if !ok {
+		return false, ""
+	}
+
+	stddev := profile.AmountStdDev()
+	if stddev == 0 {
+		return tx.Amount > profile.AmountMean*t.cfg.DeviationFactor, FlagAmountDeviation
+	}
+	return tx.Amount-profile.AmountMean > stddev*t.cfg.DeviationFactor, FlagAmountDeviation
+}
+
+func (t *ProfileTracker) detectUnusualHour(tx *domain.Transaction) (bool, string) {
+	profile, ok := t.established(tx)
+	if !ok {
+		return false, ""
+	}
+	return profile.HourShare(transactionTime(tx).Hour()) < t.cfg.UnusualHourShare, FlagUnusualHour
+}
+
+func (t *ProfileTracker) detectUnusualCurrency(tx *domain.Transaction) (bool, string) {
+	profile, ok := t.established(tx)
+	if !ok || tx.Currency == "" {
+		return false, ""
+	}
+	return !profile.UsesCurrency(tx.Currency), FlagUnusualCurrency
+}
+
+func (t *ProfileTracker) detectNewCounterparty(tx *domain.Transaction) (bool, string) {
+	if tx.Type != domain.TypeTransfer {
+		return false, ""
+	}
+	profile, ok := t.established(tx)
+	if !ok {
+		return false, ""
+	}
+	return !profile.KnowsCounterparty(tx.ToAccountID), FlagNewCounterparty
+}
+
+func (fd *FraudDetector) WithAccountProfiles(tracker *ProfileTracker) *FraudDetector {
+	fd.patterns = append(fd.patterns,
+		FraudPattern{
+			Name:        FlagAmountDeviation,
+			Description: "Amount far above the account's typical range",
+			Detect:      tracker.detectAmountDeviation,
+			Weight:      tracker.cfg.AmountDeviationWeight,
+		},
+		FraudPattern{
+			Name:        FlagUnusualHour,
+			Description: "Transaction at an hour the account is rarely active",
+			Detect:      tracker.detectUnusualHour,
+			Weight:      tracker.cfg.UnusualHourWeight,
+		},
+		FraudPattern{
+			Name:        FlagUnusualCurrency,
+			Description: "Currency not previously used by the account",
+			Detect:      tracker.detectUnusualCurrency,
+			Weight:      tracker.cfg.UnusualCurrencyWeight,
+		},
+		FraudPattern{
+			Name:        FlagNewCounterparty,
+			Description: "Transfer to a counterparty the account has not paid before",
+			Detect:      tracker.detectNewCounterparty,
+			Weight:      tracker.cfg.NewCounterpartyWeight,
+		},
+	)
+	return fd
+}
+
+func (p *TransactionProcessor) WithAccountProfiles(tracker *ProfileTracker) *TransactionProcessor {
+	p.profiles = tracker
+	p.fraudDetector.WithAccountProfiles(tracker)
+	return p
+}
+
+func transactionTime(tx *domain.Transaction) time.Time {
+	if tx.CreatedAt.IsZero() {
+		return time.Now()
+	}
+	return tx.CreatedAt
+}
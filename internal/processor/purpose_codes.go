@@ -0,0 +1,129 @@
+package processor
+
+import (
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MetadataPurposeCode = "purpose_code"
+	MetadataMemo        = "memo"
+
+	DefaultMaxMemoLength = 140
+)
+
+var (
+	ErrInvalidPurposeCode = errors.New("invalid purpose code")
+	ErrInvalidMemo        = errors.New("invalid memo")
+)
+
+type PurposeCode struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+func DefaultPurposeCodes() []PurposeCode {
+	return []PurposeCode{
+		{Code: "EDUC", Description: "Education"},
+		{Code: "FAMI", Description: "Family support"},
+		{Code: "GDDS", Description: "Purchase of goods"},
+		{Code: "INTC", Description: "Intra-company payment"},
+		{Code: "LOAN", Description: "Loan"},
+		{Code: "RENT", Description: "Rent"},
+		{Code: "SALA", Description: "Salary"},
+		{Code: "SCVE", Description: "Purchase of services"},
+		{Code: "SUPP", Description: "Supplier payment"},
+		{Code: "TAXS", Description: "Tax payment"},
+	}
+}
+
+func ParsePurposeCodes(spec string) ([]PurposeCode, error) {
+	var codes []PurposeCode
+	if err := json.Unmarshal([]byte(spec), &codes); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPurposeCode, err)
+	}
+
+	seen := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		code.Code = strings.ToUpper(strings.TrimSpace(code.Code))
+		if code.Code == "" {
+			return nil, fmt.Errorf("%w: code is required", ErrInvalidPurposeCode)
+		}
+		if seen[code.Code] {
+			return nil, fmt.Errorf("%w: duplicate code %s", ErrInvalidPurposeCode, code.Code)
+		}
+		seen[code.Code] = true
+		codes[i] = code
+	}
+	return codes, nil
+}
+
+func (p *TransactionProcessor) WithPurposeCodes(codes []PurposeCode) *TransactionProcessor {
+	p.purposeCodes = codes
+	return p
+}
+
+func (p *TransactionProcessor) PurposeCodes() ([]PurposeCode, bool) {
+	if p.purposeCodes == nil {
+		return nil, false
+	}
+	return p.purposeCodes, true
+}
+
+func (p *TransactionProcessor) knownPurposeCode(code string) bool {
+	return slices.ContainsFunc(p.purposeCodes, func(known PurposeCode) bool { return known.Code == code })
+}
+
+func (p *TransactionProcessor) checkPaymentPurpose(tx *domain.Transaction) error {
+	if tx.SystemPosting != "" {
+		return nil
+	}
+
+	var corridor Corridor
+	if id := tx.Metadata[MetadataCorridor]; id != "" {
+		for _, candidate := range p.corridors {
+			if candidate.ID == id {
+				corridor = candidate
+				break
+			}
+		}
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(tx.Metadata[MetadataPurposeCode]))
+	switch {
+	case code == "" && corridor.RequirePurposeCode:
+		return fmt.Errorf("%w: corridor %s requires a purpose code", ErrInvalidPurposeCode, corridor.ID)
+	case code == "":
+	case p.purposeCodes != nil && !p.knownPurposeCode(code):
+		return fmt.Errorf("%w: unknown code %s", ErrInvalidPurposeCode, code)
+	case len(corridor.PurposeCodes) > 0 && !slices.ContainsFunc(corridor.PurposeCodes, func(allowed string) bool { return strings.EqualFold(allowed, code) }):
+		return fmt.Errorf("%w: %s is not allowed in corridor %s", ErrInvalidPurposeCode, code, corridor.ID)
+	default:
+		tx.AddMetadata(MetadataPurposeCode, code)
+	}
+
+	memo := tx.Metadata[MetadataMemo]
+	if memo == "" {
+		if corridor.RequireMemo {
+			return fmt.Errorf("%w: corridor %s requires a memo", ErrInvalidMemo, corridor.ID)
+		}
+		return nil
+	}
+	maxLength := DefaultMaxMemoLength
+	if corridor.MaxMemoLength > 0 {
+		maxLength = corridor.MaxMemoLength
+	}
+	if length := utf8.RuneCountInString(memo); length > maxLength {
+		return fmt.Errorf("%w: %d characters exceeds %d", ErrInvalidMemo, length, maxLength)
+	}
+	if !utf8.ValidString(memo) || strings.ContainsFunc(memo, unicode.IsControl) {
+		return fmt.Errorf("%w: contains control or invalid characters", ErrInvalidMemo)
+	}
+	return nil
+}
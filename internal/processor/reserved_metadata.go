@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrReservedMetadata = errors.New("reserved metadata key")
+
+// reservedMetadata are the keys the processor writes to record its own
+// decisions. Several of them steer later processing, so callers outside the
+// processor must not supply them.
+var reservedMetadata = []string{
+	MetadataAdjustmentReason,
+	MetadataBudgetCheck,
+	MetadataBudgetExceeded,
+	MetadataBudgetID,
+	MetadataBudgetSpent,
+	MetadataCorridor,
+	MetadataDecidedByRule,
+	MetadataDegradedQueued,
+	MetadataDepositRetry,
+	MetadataExpiredAt,
+	MetadataFastPath,
+	MetadataInstallmentDueAt,
+	MetadataInstallmentNumber,
+	MetadataInstallmentPlan,
+	MetadataLegIndex,
+	MetadataLinkedTransaction,
+	MetadataMaintenanceWindow,
+	MetadataMigrationReference,
+	MetadataPositivePay,
+	MetadataPositivePayReason,
+	MetadataResolutionStrategy,
+	MetadataReviewSLABreachedAt,
+	MetadataReviewSLAOutcome,
+	MetadataSuspenseIntendedAccount,
+	AuditActionRiskOverride,
+	AuditActionRiskOverride + "_by",
+}
+
+// CheckClientMetadata rejects caller-supplied metadata that uses a key the
+// processor reserves for itself.
+func CheckClientMetadata(metadata map[string]string) error {
+	for _, key := range reservedMetadata {
+		if _, ok := metadata[key]; ok {
+			return fmt.Errorf("%w: %s", ErrReservedMetadata, key)
+		}
+	}
+	return nil
+}
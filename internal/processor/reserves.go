@@ -0,0 +1,109 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"math"
+	"sort"
+	"time"
+)
+
+const reserveTolerance = 0.005
+
+type CurrencyReserves struct {
+	Currency         string                        `json:"currency"`
+	CustomerAccounts int                           `json:"customer_accounts"`
+	Liabilities      float64                       `json:"liabilities"`
+	SystemPositions  map[domain.SystemRole]float64 `json:"system_positions"`
+	SystemTotal      float64                       `json:"system_total"`
+	ExternalInflows  float64                       `json:"external_inflows"`
+	ExternalOutflows float64                       `json:"external_outflows"`
+	LedgerNet        float64                       `json:"ledger_net"`
+	Discrepancy      float64                       `json:"discrepancy"`
+	Consistent       bool                          `json:"consistent"`
+}
+
+type ReservesReport struct {
+	ID          string             `json:"id"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	AsOf        time.Time          `json:"as_of"`
+	SnapshotID  string             `json:"snapshot_id,omitempty"`
+	Currencies  []CurrencyReserves `json:"currencies"`
+	Consistent  bool               `json:"consistent"`
+}
+
+func (p *TransactionProcessor) ReservesReport(ctx context.Context) (*ReservesReport, error) {
+	snapshot, err := p.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	byCurrency := make(map[string]*CurrencyReserves)
+	reserves := func(currency string) *CurrencyReserves {
+		entry, exists := byCurrency[currency]
+		if !exists {
+			entry = &CurrencyReserves{Currency: currency, SystemPositions: make(map[domain.SystemRole]float64)}
+			byCurrency[currency] = entry
+		}
+		return entry
+	}
+
+	accounts, err := snapshot.Accounts.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		entry := reserves(account.Currency)
+		if account.IsSystem() {
+			entry.SystemPositions[account.SystemRole] += account.Balance
+			entry.SystemTotal += account.Balance
+			continue
+		}
+		entry.CustomerAccounts++
+		entry.Liabilities += account.Balance
+	}
+
+	err = snapshot.Transactions.Iterate(ctx, repository.TransactionFilter{Status: domain.StatusCompleted}, func(tx *domain.Transaction) error {
+		switch {
+		case tx.FromAccountID == "" && tx.ToAccountID != "":
+			reserves(tx.Currency).ExternalInflows += tx.Amount
+		case tx.ToAccountID == "" && tx.FromAccountID != "":
+			reserves(tx.Currency).ExternalOutflows += tx.Amount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReservesReport{
+		ID:          domain.NewID(),
+		GeneratedAt: time.Now(),
+		AsOf:        snapshot.Transactions.TakenAt(),
+		Consistent:  true,
+	}
+	for _, entry := range byCurrency {
+		round := func(amount float64) float64 { return ledgerRounding.Round(amount, entry.Currency) }
+		entry.Liabilities = round(entry.Liabilities)
+		entry.SystemTotal = round(entry.SystemTotal)
+		for role, position := range entry.SystemPositions {
+			entry.SystemPositions[role] = round(position)
+		}
+		entry.ExternalInflows = round(entry.ExternalInflows)
+		entry.ExternalOutflows = round(entry.ExternalOutflows)
+		entry.LedgerNet = round(entry.ExternalInflows - entry.ExternalOutflows)
+		entry.Discrepancy = round(entry.Liabilities + entry.SystemTotal - entry.LedgerNet)
+		entry.Consistent = math.Abs(entry.Discrepancy) < reserveTolerance
+		if !entry.Consistent {
+			report.Consistent = false
+		}
+		report.Currencies = append(report.Currencies, *entry)
+	}
+	sort.Slice(report.Currencies, func(i, j int) bool {
+		return report.Currencies[i].Currency < report.Currencies[j].Currency
+	})
+
+	return report, nil
+}
@@ -0,0 +1,97 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	ReviewSLAReject   = "reject"
+	ReviewSLAEscalate = "escalate"
+
+	MetadataReviewSLABreachedAt = "review_sla_breached_at"
+	MetadataReviewSLAOutcome    = "review_sla_outcome"
+
+	AuditActionReviewSLA = "review_sla"
+	reviewSLAOperator    = "system:review_sla"
+)
+
+var ErrInvalidReviewPolicy = errors.New("invalid review SLA policy")
+
+// ResolveOverdueReviews applies the breach policy to suspicious transactions
+// created before the deadline. Rejected transactions fail; escalated ones stay
+// suspicious and are returned only on their first breach.
+func (p *TransactionProcessor) ResolveOverdueReviews(ctx context.Context, createdBefore time.Time, policy string) ([]*domain.Transaction, error) {
+	if policy != ReviewSLAReject && policy != ReviewSLAEscalate {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidReviewPolicy, policy)
+	}
+
+	p.overrideMu.Lock()
+	defer p.overrideMu.Unlock()
+
+	suspicious, err := p.txRepo.GetByStatus(ctx, domain.StatusSuspicious)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var breached []*domain.Transaction
+	for _, tx := range suspicious {
+		if !tx.CreatedAt.Before(createdBefore) || tx.Metadata[MetadataReviewSLABreachedAt] != "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return breached, err
+		}
+
+		tx.AddMetadata(MetadataReviewSLABreachedAt, now.Format(time.RFC3339))
+		tx.AddMetadata(MetadataReviewSLAOutcome, policy)
+		if policy == ReviewSLAReject {
+			if err := p.txRepo.UpdateStatus(ctx, tx.ID, domain.StatusFailed); err != nil {
+				return breached, err
+			}
+			tx.Status = domain.StatusFailed
+			p.auditReviewSLA(ctx, tx)
+		}
+		breached = append(breached, tx)
+
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "review_sla_breached",
+			Payload:       map[string]interface{}{"created_at": tx.CreatedAt, "policy": policy, "risk_score": tx.RiskScore},
+			Timestamp:     now,
+		})
+		p.logger.WarnContext(ctx, "Suspicious transaction review SLA breached",
+			slog.String("transaction_id", tx.ID),
+			slog.String("policy", policy),
+			slog.Time("created_at", tx.CreatedAt))
+	}
+
+	if len(breached) > 0 {
+		p.recordMetric("review_sla_breached", len(breached))
+	}
+	return breached, nil
+}
+
+func (p *TransactionProcessor) auditReviewSLA(ctx context.Context, tx *domain.Transaction) {
+	if p.auditRepo == nil {
+		return
+	}
+	entry := domain.NewAuditEntry(AuditActionReviewSLA, AuditEntityTransaction, tx.ID, reviewSLAOperator, "review SLA breached")
+	entry.Details["previous_status"] = string(domain.StatusSuspicious)
+	entry.Details["new_status"] = string(domain.StatusFailed)
+	err := repository.SaveWithFreshID(
+		func() error { return p.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to record audit entry for review SLA",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+}
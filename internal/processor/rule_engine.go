@@ -9,18 +9,54 @@ import (
 	"log/slog"
 	"regexp"
 	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 type RuleEngine struct {
-	ruleRepo repository.RuleRepository
-	logger   *slog.Logger
-	cache    map[string][]*domain.Rule
+	ruleRepo    repository.RuleRepository
+	groupRepo   repository.RuleGroupRepository
+	environment string
+	logger      *slog.Logger
+	cacheMu     sync.RWMutex
+	cache       map[string]*ruleIndex
+	stats       *ruleStatsRecorder
+	strategies  map[domain.RuleType]ResolutionStrategy
+	fields      map[string]NumericField
+	stopOnBlock bool
+	lookupRepo  repository.LookupTableRepository
+	lookupMu    sync.RWMutex
+	lookups     map[string]*domain.LookupTable
 }
 
+type NumericField func(tx *domain.Transaction) float64
+
 type Condition struct {
 	Field    string      `json:"field"`
 	Operator string      `json:"operator"`
 	Value    interface{} `json:"value"`
+	Window   string      `json:"window,omitempty"`
+	Table    string      `json:"table,omitempty"`
+	Key      string      `json:"key,omitempty"`
+
+	pattern  *regexp.Regexp
+	terms    []string
+	expected map[string]string
+	lookup   *LookupRef
+}
+
+type compiledRule struct {
+	rule      *domain.Rule
+	condition Condition
+	action    RuleAction
+	err       error
+	order     int
+	threshold float64
+	evaluated atomic.Int64
 }
 
 type RuleAction struct {
@@ -32,6 +68,8 @@ type RuleAction struct {
 type RuleResult struct {
 	RuleID      string
 	RuleName    string
+	RuleType    domain.RuleType
+	Priority    int
 	Triggered   bool
 	Action      RuleAction
 	Description string
@@ -43,83 +81,175 @@ func NewRuleEngine(ruleRepo repository.RuleRepository, logger *slog.Logger) *Rul
 	}
 
 	return &RuleEngine{
-		ruleRepo: ruleRepo,
-		logger:   logger,
-		cache:    make(map[string][]*domain.Rule),
+		ruleRepo:   ruleRepo,
+		logger:     logger,
+		cache:      make(map[string]*ruleIndex),
+		stats:      newRuleStatsRecorder(),
+		strategies: make(map[domain.RuleType]ResolutionStrategy),
+		fields:     make(map[string]NumericField),
 	}
 }
 
+func (e *RuleEngine) WithNumericField(name string, field NumericField) *RuleEngine {
+	e.fields[name] = field
+	return e
+}
+
+func (e *RuleEngine) WithStopOnFirstBlock(enabled bool) *RuleEngine {
+	e.stopOnBlock = enabled
+	return e
+}
+
 func (e *RuleEngine) EvaluateRules(ctx context.Context, tx *domain.Transaction) ([]RuleResult, error) {
-	rules, err := e.getActiveRules(ctx)
+	return e.evaluateRules(ctx, tx, true)
+}
+
+func (e *RuleEngine) evaluateRules(ctx context.Context, tx *domain.Transaction, record bool) ([]RuleResult, error) {
+	index, err := e.getActiveRules(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active rules: %w", err)
 	}
 
+	if record {
+		index.passes.Add(1)
+	}
+	buf := candidatePool.Get().(*[]*compiledRule)
+	candidates := index.candidates(tx, (*buf)[:0])
+	defer func() {
+		clear(candidates)
+		*buf = candidates[:0]
+		candidatePool.Put(buf)
+	}()
+
 	var results []RuleResult
 
-	for _, rule := range rules {
-		result, err := e.evaluateRule(ctx, rule, tx)
+	for _, compiled := range candidates {
+		startTime := time.Now()
+		result, err := e.evaluateRule(compiled, tx)
+		if record {
+			compiled.evaluated.Add(1)
+			e.stats.record(compiled.rule.ID, time.Since(startTime), result.Triggered, err)
+		}
 		if err != nil {
 			e.logger.ErrorContext(ctx, "Failed to evaluate rule",
-				slog.String("rule_id", rule.ID),
+				slog.String("rule_id", compiled.rule.ID),
 				slog.String("error", err.Error()))
+			traceStep(ctx, TraceStageRules, compiled.rule.ID, TraceOutcomeError, map[string]string{"rule_name": compiled.rule.Name, "error": err.Error()})
 			continue
 		}
+		traceRule(ctx, result)
 
 		if result.Triggered {
 			results = append(results, result)
-			e.logger.InfoContext(ctx, "Rule triggered",
-				slog.String("rule_id", rule.ID),
-				slog.String("rule_name", rule.Name),
+			e.logger.LogAttrs(ctx, slog.LevelInfo, "Rule triggered",
+				slog.String("rule_id", compiled.rule.ID),
+				slog.String("rule_name", compiled.rule.Name),
 				slog.String("transaction_id", tx.ID))
+
+			if e.stopOnBlock && result.Action.Type == "block_transaction" {
+				break
+			}
 		}
 	}
 
-	slices.SortFunc(results, func(a, b RuleResult) int {
-		return e.getRulePriority(a.RuleID) - e.getRulePriority(b.RuleID)
+	slices.SortStableFunc(results, func(a, b RuleResult) int {
+		return a.Priority - b.Priority
 	})
 
 	return results, nil
 }
 
-func (e *RuleEngine) evaluateRule(ctx context.Context, rule *domain.Rule, tx *domain.Transaction) (RuleResult, error) {
+func (e *RuleEngine) evaluateRule(compiled *compiledRule, tx *domain.Transaction) (RuleResult, error) {
+	rule := compiled.rule
 	result := RuleResult{
 		RuleID:      rule.ID,
 		RuleName:    rule.Name,
+		RuleType:    rule.Type,
+		Priority:    rule.Priority,
 		Description: rule.Description,
 	}
 
-	condition, err := e.parseCondition(rule.Condition)
-	if err != nil {
-		return result, fmt.Errorf("failed to parse condition: %w", err)
+	if compiled.err != nil {
+		return result, compiled.err
 	}
 
-	triggered, err := e.checkCondition(condition, tx)
+	triggered, err := e.checkCondition(compiled.condition, tx)
 	if err != nil {
 		return result, fmt.Errorf("failed to check condition: %w", err)
 	}
 
 	result.Triggered = triggered
-
 	if triggered {
-		action, err := e.parseAction(rule.Action)
-		if err != nil {
-			return result, fmt.Errorf("failed to parse action: %w", err)
-		}
-		result.Action = action
+		result.Action = compiled.action
 	}
 
 	return result, nil
 }
 
+func (e *RuleEngine) compileRule(rule *domain.Rule) *compiledRule {
+	compiled := &compiledRule{rule: rule}
+
+	condition, err := e.parseCondition(rule.Condition)
+	if err != nil {
+		compiled.err = fmt.Errorf("failed to parse condition: %w", err)
+		return compiled
+	}
+	action, err := e.parseAction(rule.Action)
+	if err != nil {
+		compiled.err = fmt.Errorf("failed to parse action: %w", err)
+		return compiled
+	}
+
+	compiled.condition = condition
+	compiled.action = action
+	return compiled
+}
+
 func (e *RuleEngine) parseCondition(conditionStr string) (Condition, error) {
 	var condition Condition
 	if err := json.Unmarshal([]byte(conditionStr), &condition); err != nil {
 		return Condition{}, fmt.Errorf("invalid condition JSON: %w", err)
 	}
+	if err := condition.prepare(); err != nil {
+		return Condition{}, err
+	}
 	return condition, nil
 }
 
+func (c *Condition) prepare() error {
+	switch c.Field {
+	case "lookup":
+		if c.Table == "" || c.Key == "" {
+			return fmt.Errorf("lookup condition requires table and key")
+		}
+		c.lookup = &LookupRef{Table: c.Table, Key: c.Key}
+	case "currency", "type":
+		if pattern, ok := c.Value.(string); ok && c.Operator == "contains" {
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			c.pattern = compiled
+		}
+	case "description":
+		terms, err := descriptionTerms(c.Value)
+		if err != nil {
+			return err
+		}
+		c.terms = terms
+	case "metadata":
+		if values, ok := c.Value.(map[string]interface{}); ok {
+			c.expected = make(map[string]string, len(values))
+			for key, value := range values {
+				c.expected[key] = fmt.Sprintf("%v", value)
+			}
+		}
+	default:
+		c.lookup, _ = parseLookupRef(c.Value)
+	}
+	return nil
+}
+
 func (e *RuleEngine) parseAction(actionStr string) (RuleAction, error) {
 	var action RuleAction
 	if err := json.Unmarshal([]byte(actionStr), &action); err != nil {
@@ -129,6 +259,21 @@ func (e *RuleEngine) parseAction(actionStr string) (RuleAction, error) {
 }
 
 func (e *RuleEngine) checkCondition(condition Condition, tx *domain.Transaction) (bool, error) {
+	if condition.Field == "lookup" {
+		value, found, err := e.resolveLookup(LookupRef{Table: condition.Table, Key: condition.Key}, tx)
+		if err != nil || !found {
+			return false, err
+		}
+		return e.checkNumericCondition(condition, value)
+	}
+	if condition.lookup != nil {
+		target, found, err := e.resolveLookup(*condition.lookup, tx)
+		if err != nil || !found {
+			return false, err
+		}
+		condition.Value = target
+	}
+
 	switch condition.Field {
 	case "amount":
 		return e.checkAmountCondition(condition, tx.Amount)
@@ -140,7 +285,12 @@ func (e *RuleEngine) checkCondition(condition Condition, tx *domain.Transaction)
 		return e.checkNumericCondition(condition, float64(tx.RiskScore))
 	case "metadata":
 		return e.checkMetadataCondition(condition, tx.Metadata)
+	case "description":
+		return e.checkDescriptionCondition(condition, tx.Description)
 	default:
+		if field, exists := e.fields[condition.Field]; exists {
+			return e.checkNumericCondition(condition, field(tx))
+		}
 		return false, fmt.Errorf("unknown field: %s", condition.Field)
 	}
 }
@@ -205,7 +355,14 @@ func (e *RuleEngine) checkStringCondition(condition Condition, value string) (bo
 	case "!=":
 		return value != targetValue, nil
 	case "contains":
-		return regexp.MustCompile(targetValue).MatchString(value), nil
+		pattern := condition.pattern
+		if pattern == nil {
+			var err error
+			if pattern, err = regexp.Compile(targetValue); err != nil {
+				return false, fmt.Errorf("invalid pattern %q: %w", targetValue, err)
+			}
+		}
+		return pattern.MatchString(value), nil
 	case "in":
 		if arr, ok := condition.Value.([]string); ok {
 			return slices.Contains(arr, value), nil
@@ -216,19 +373,112 @@ func (e *RuleEngine) checkStringCondition(condition Condition, value string) (bo
 	}
 }
 
-func (e *RuleEngine) checkMetadataCondition(condition Condition, metadata map[string]string) (bool, error) {
-	conditions, ok := condition.Value.(map[string]interface{})
-	if !ok {
-		return false, fmt.Errorf("invalid value type for metadata condition")
+func descriptionTerms(value interface{}) ([]string, error) {
+	var patterns []string
+	switch v := value.(type) {
+	case string:
+		patterns = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			pattern, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid value type for description pattern: %v", item)
+			}
+			patterns = append(patterns, pattern)
+		}
+	default:
+		return nil, fmt.Errorf("invalid value type for description: %v", value)
 	}
 
-	for key, expectedValue := range conditions {
-		actualValue, exists := metadata[key]
-		if !exists {
-			return false, nil
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("description condition requires at least one pattern")
+	}
+
+	for i, pattern := range patterns {
+		patterns[i] = strings.ToLower(strings.TrimSpace(pattern))
+	}
+	return patterns, nil
+}
+
+func (e *RuleEngine) checkDescriptionCondition(condition Condition, description string) (bool, error) {
+	patterns := condition.terms
+	if patterns == nil {
+		var err error
+		if patterns, err = descriptionTerms(condition.Value); err != nil {
+			return false, err
 		}
+	}
 
-		if fmt.Sprintf("%v", actualValue) != fmt.Sprintf("%v", expectedValue) {
+	var match func(text, pattern string) bool
+	negate := false
+	switch condition.Operator {
+	case "==":
+		match = func(text, pattern string) bool { return text == pattern }
+	case "!=":
+		match = func(text, pattern string) bool { return text == pattern }
+		negate = true
+	case "contains":
+		match = strings.Contains
+	case "not_contains":
+		match = strings.Contains
+		negate = true
+	case "starts_with":
+		match = strings.HasPrefix
+	case "ends_with":
+		match = strings.HasSuffix
+	case "contains_word":
+		match = containsWord
+	default:
+		return false, fmt.Errorf("unknown operator for description: %s", condition.Operator)
+	}
+
+	text := strings.ToLower(strings.TrimSpace(description))
+	for _, pattern := range patterns {
+		if match(text, pattern) {
+			return !negate, nil
+		}
+	}
+	return negate, nil
+}
+
+func containsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+
+	for offset := 0; offset <= len(text)-len(word); {
+		idx := strings.Index(text[offset:], word)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(word)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func (e *RuleEngine) checkMetadataCondition(condition Condition, metadata map[string]string) (bool, error) {
+	if condition.expected == nil {
+		if err := condition.prepare(); err != nil || condition.expected == nil {
+			return false, fmt.Errorf("invalid value type for metadata condition")
+		}
+	}
+
+	for key, expectedValue := range condition.expected {
+		if actualValue, exists := metadata[key]; !exists || actualValue != expectedValue {
 			return false, nil
 		}
 	}
@@ -236,44 +486,56 @@ func (e *RuleEngine) checkMetadataCondition(condition Condition, metadata map[st
 	return true, nil
 }
 
-func (e *RuleEngine) getActiveRules(ctx context.Context) ([]*domain.Rule, error) {
-	if cached, exists := e.cache["active"]; exists {
+func (e *RuleEngine) getActiveRules(ctx context.Context) (*ruleIndex, error) {
+	e.cacheMu.RLock()
+	cached, exists := e.cache["active"]
+	e.cacheMu.RUnlock()
+	if exists {
 		return cached, nil
 	}
 
-	rules, err := e.ruleRepo.GetActiveRules(ctx)
+	activeRules, err := e.ruleRepo.GetActiveRules(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	e.cache["active"] = rules
-
-	return rules, nil
-}
-
-func (e *RuleEngine) getRulePriority(ruleID string) int {
-	if e == nil {
-		return 0
+	activeRules, err = e.filterByGroup(ctx, activeRules)
+	if err != nil {
+		return nil, err
 	}
-	if e.cache != nil {
-		for _, rules := range e.cache {
-			for _, rule := range rules {
-				if rule.ID == ruleID {
-					return rule.Priority
-				}
-			}
+
+	rules := make([]*compiledRule, 0, len(activeRules))
+	for _, rule := range activeRules {
+		if !rule.IsScheduled() {
+			rules = append(rules, e.compileRule(rule))
 		}
 	}
-	ctx := context.Background()
-	rule, err := e.ruleRepo.GetByID(ctx, ruleID)
-	if err == nil && rule != nil {
-		return rule.Priority
-	}
-	return 0
+
+	index := newRuleIndex(rules)
+
+	e.cacheMu.Lock()
+	e.cache["active"] = index
+	e.cacheMu.Unlock()
+
+	return index, nil
 }
 
 func (e *RuleEngine) InvalidateCache() {
-	e.cache = make(map[string][]*domain.Rule)
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+	for _, index := range e.cache {
+		e.stats.addSkipped(index.skipped())
+	}
+	e.cache = make(map[string]*ruleIndex)
+}
+
+func (e *RuleEngine) liveSkipped() map[string]int64 {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+	if index, exists := e.cache["active"]; exists {
+		return index.skipped()
+	}
+	return nil
 }
 
 func (e *RuleEngine) ExecuteAction(ctx context.Context, action RuleAction, tx *domain.Transaction) error {
@@ -288,6 +550,10 @@ func (e *RuleEngine) ExecuteAction(ctx context.Context, action RuleAction, tx *d
 		return e.handleNotifyAction(ctx, action, tx)
 	case "adjust_risk_score":
 		return e.handleRiskAdjustAction(ctx, action, tx)
+	case ActionWarnBudgetExceeded:
+		return e.handleBudgetWarningAction(ctx, action, tx)
+	case ActionRouteNotification:
+		return e.handleRouteAction(ctx, action, tx)
 	default:
 		return fmt.Errorf("unknown action type: %s", action.Type)
 	}
@@ -314,7 +580,7 @@ func (e *RuleEngine) handleBlockAction(ctx context.Context, action RuleAction, t
 		slog.String("reason", reason))
 
 	tx.Status = domain.StatusFailed
-	tx.Metadata["block_reason"] = reason
+	tx.AddMetadata("block_reason", reason)
 
 	return nil
 }
@@ -324,7 +590,7 @@ func (e *RuleEngine) handleApprovalAction(ctx context.Context, action RuleAction
 		slog.String("transaction_id", tx.ID))
 
 	tx.Status = domain.StatusPending
-	tx.Metadata["requires_approval"] = "true"
+	tx.AddMetadata("requires_approval", "true")
 
 	return nil
 }
@@ -342,14 +608,9 @@ func (e *RuleEngine) handleNotifyAction(ctx context.Context, action RuleAction,
 }
 
 func (e *RuleEngine) handleRiskAdjustAction(ctx context.Context, action RuleAction, tx *domain.Transaction) error {
-	adjustment, _ := action.Params["adjustment"].(float64)
-
-	tx.RiskScore += int(adjustment)
-	if tx.RiskScore > 100 {
-		tx.RiskScore = 100
-	}
-	if tx.RiskScore < 0 {
-		tx.RiskScore = 0
+	adjustment, err := e.adjustRiskScore(action, tx)
+	if err != nil {
+		return err
 	}
 
 	e.logger.InfoContext(ctx, "Risk score adjusted",
@@ -359,3 +620,26 @@ func (e *RuleEngine) handleRiskAdjustAction(ctx context.Context, action RuleActi
 
 	return nil
 }
+
+func (e *RuleEngine) adjustRiskScore(action RuleAction, tx *domain.Transaction) (float64, error) {
+	adjustment, _, err := e.actionNumber(action.Params["adjustment"], tx)
+	if err != nil {
+		return 0, err
+	}
+	multiplier, found, err := e.actionNumber(action.Params["multiplier"], tx)
+	if err != nil {
+		return 0, err
+	}
+	if found {
+		adjustment *= multiplier
+	}
+
+	tx.RiskScore += int(adjustment)
+	if tx.RiskScore > 100 {
+		tx.RiskScore = 100
+	}
+	if tx.RiskScore < 0 {
+		tx.RiskScore = 0
+	}
+	return adjustment, nil
+}
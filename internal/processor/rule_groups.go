@@ -0,0 +1,113 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+)
+
+var ErrRuleGroupsNotConfigured = errors.New("rule groups are not configured")
+
+func (e *RuleEngine) WithRuleGroups(groupRepo repository.RuleGroupRepository, environment string) *RuleEngine {
+	e.groupRepo = groupRepo
+	e.environment = environment
+	e.InvalidateCache()
+	return e
+}
+
+func (e *RuleEngine) Environment() string {
+	return e.environment
+}
+
+func (e *RuleEngine) filterByGroup(ctx context.Context, rules []*domain.Rule) ([]*domain.Rule, error) {
+	if e.groupRepo == nil {
+		return rules, nil
+	}
+
+	groups, err := e.groupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule groups: %w", err)
+	}
+
+	enabled := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		enabled[group.ID] = group.EnabledIn(e.environment)
+	}
+
+	result := make([]*domain.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.GroupID == "" || enabled[rule.GroupID] {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func (e *RuleEngine) CreateRuleGroup(ctx context.Context, group *domain.RuleGroup) error {
+	if e.groupRepo == nil {
+		return ErrRuleGroupsNotConfigured
+	}
+	if group.ID == "" || group.Name == "" {
+		return fmt.Errorf("rule group id and name are required")
+	}
+
+	if err := e.groupRepo.Save(ctx, group); err != nil {
+		return err
+	}
+
+	e.InvalidateCache()
+	return nil
+}
+
+func (e *RuleEngine) ListRuleGroups(ctx context.Context) ([]*domain.RuleGroup, error) {
+	if e.groupRepo == nil {
+		return nil, ErrRuleGroupsNotConfigured
+	}
+	return e.groupRepo.GetAll(ctx)
+}
+
+func (e *RuleEngine) GetRuleGroup(ctx context.Context, id string) (*domain.RuleGroup, []*domain.Rule, error) {
+	if e.groupRepo == nil {
+		return nil, nil, ErrRuleGroupsNotConfigured
+	}
+
+	group, err := e.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := e.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	var members []*domain.Rule
+	for _, rule := range rules {
+		if rule.GroupID == id {
+			members = append(members, rule)
+		}
+	}
+
+	return group, members, nil
+}
+
+func (e *RuleEngine) SetRuleGroupActive(ctx context.Context, id string, active bool) error {
+	if e.groupRepo == nil {
+		return ErrRuleGroupsNotConfigured
+	}
+
+	if err := e.groupRepo.SetActive(ctx, id, active); err != nil {
+		return err
+	}
+
+	e.InvalidateCache()
+	e.logger.InfoContext(ctx, "Rule group activation changed",
+		slog.String("group_id", id),
+		slog.Bool("active", active),
+		slog.String("environment", e.environment))
+
+	return nil
+}
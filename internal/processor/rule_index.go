@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"cmp"
+	"finance_manager/internal/domain"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+type ruleIndex struct {
+	rules       []*compiledRule
+	passes      atomic.Int64
+	general     []*compiledRule
+	byCurrency  map[string][]*compiledRule
+	byType      map[string][]*compiledRule
+	amountAbove []*compiledRule
+	amountBelow []*compiledRule
+	size        int
+}
+
+var candidatePool = sync.Pool{
+	New: func() any {
+		candidates := make([]*compiledRule, 0, 64)
+		return &candidates
+	},
+}
+
+func newRuleIndex(rules []*compiledRule) *ruleIndex {
+	ordered := slices.Clone(rules)
+	slices.SortStableFunc(ordered, func(a, b *compiledRule) int {
+		return cmp.Compare(b.rule.Priority, a.rule.Priority)
+	})
+
+	index := &ruleIndex{
+		rules:      ordered,
+		byCurrency: make(map[string][]*compiledRule),
+		byType:     make(map[string][]*compiledRule),
+		size:       len(ordered),
+	}
+	for i, compiled := range ordered {
+		compiled.order = i
+		index.add(compiled)
+	}
+
+	slices.SortStableFunc(index.amountAbove, func(a, b *compiledRule) int {
+		return cmp.Compare(a.threshold, b.threshold)
+	})
+	slices.SortStableFunc(index.amountBelow, func(a, b *compiledRule) int {
+		return cmp.Compare(b.threshold, a.threshold)
+	})
+	return index
+}
+
+func (idx *ruleIndex) add(compiled *compiledRule) {
+	if compiled.err != nil {
+		idx.general = append(idx.general, compiled)
+		return
+	}
+
+	condition := compiled.condition
+	switch condition.Field {
+	case "currency", "type":
+		value, ok := condition.Value.(string)
+		if !ok || condition.Operator != "==" {
+			break
+		}
+		if condition.Field == "currency" {
+			idx.byCurrency[value] = append(idx.byCurrency[value], compiled)
+		} else {
+			idx.byType[value] = append(idx.byType[value], compiled)
+		}
+		return
+	case "amount":
+		threshold, ok := condition.Value.(float64)
+		if !ok {
+			break
+		}
+		compiled.threshold = threshold
+		switch condition.Operator {
+		case ">", ">=":
+			idx.amountAbove = append(idx.amountAbove, compiled)
+			return
+		case "<", "<=":
+			idx.amountBelow = append(idx.amountBelow, compiled)
+			return
+		}
+	}
+	idx.general = append(idx.general, compiled)
+}
+
+func (idx *ruleIndex) candidates(tx *domain.Transaction, buf []*compiledRule) []*compiledRule {
+	buf = append(buf, idx.general...)
+	buf = append(buf, idx.byCurrency[tx.Currency]...)
+	buf = append(buf, idx.byType[string(tx.Type)]...)
+
+	above := sort.Search(len(idx.amountAbove), func(i int) bool { return idx.amountAbove[i].threshold > tx.Amount })
+	buf = append(buf, idx.amountAbove[:above]...)
+	below := sort.Search(len(idx.amountBelow), func(i int) bool { return idx.amountBelow[i].threshold < tx.Amount })
+	buf = append(buf, idx.amountBelow[:below]...)
+
+	slices.SortFunc(buf, func(a, b *compiledRule) int { return a.order - b.order })
+	return buf
+}
+
+func (idx *ruleIndex) skipped() map[string]int64 {
+	passes := idx.passes.Load()
+	skipped := make(map[string]int64, len(idx.rules))
+	for _, compiled := range idx.rules {
+		if n := passes - compiled.evaluated.Load(); n > 0 {
+			skipped[compiled.rule.ID] = n
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,190 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v2"
+)
+
+const (
+	RuleSetFormatJSON = "json"
+	RuleSetFormatYAML = "yaml"
+
+	ruleSetSchemaVersion = 1
+)
+
+var ErrInvalidRuleSet = errors.New("invalid rule set")
+
+var supportedActionTypes = []string{
+	"flag_transaction",
+	"block_transaction",
+	"require_approval",
+	"notify",
+	"adjust_risk_score",
+	ActionWarnBudgetExceeded,
+	ActionRouteNotification,
+}
+
+type RuleSetDocument struct {
+	Version    int            `json:"version" yaml:"version"`
+	ExportedAt time.Time      `json:"exported_at" yaml:"exported_at"`
+	Rules      []*domain.Rule `json:"rules" yaml:"rules"`
+}
+
+type RuleValidationError struct {
+	RuleID string `json:"rule_id"`
+	Index  int    `json:"index"`
+	Error  string `json:"error"`
+}
+
+type RuleSetValidationError struct {
+	Errors []RuleValidationError
+}
+
+func (e *RuleSetValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, ve := range e.Errors {
+		messages = append(messages, fmt.Sprintf("rule[%d] %s: %s", ve.Index, ve.RuleID, ve.Error))
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidRuleSet, strings.Join(messages, "; "))
+}
+
+func (e *RuleSetValidationError) Unwrap() error {
+	return ErrInvalidRuleSet
+}
+
+func (e *RuleEngine) ValidateRule(rule *domain.Rule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("rule id is required")
+	}
+	if rule.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+	if rule.IsScheduled() {
+		return validateScheduledRule(e, rule)
+	}
+
+	condition, err := e.parseCondition(rule.Condition)
+	if err != nil {
+		return err
+	}
+	probe := &domain.Transaction{Metadata: map[string]string{}}
+	if _, err := e.checkCondition(condition, probe); err != nil {
+		return fmt.Errorf("invalid condition: %w", err)
+	}
+
+	action, err := e.parseAction(rule.Action)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(supportedActionTypes, action.Type) {
+		return fmt.Errorf("unknown action type: %s", action.Type)
+	}
+	for _, ref := range actionLookupRefs(action) {
+		if _, _, err := e.resolveLookup(ref, probe); err != nil {
+			return fmt.Errorf("invalid action: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *RuleEngine) ExportRules(ctx context.Context) (RuleSetDocument, error) {
+	rules, err := e.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return RuleSetDocument{}, fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	return RuleSetDocument{
+		Version:    ruleSetSchemaVersion,
+		ExportedAt: time.Now().UTC(),
+		Rules:      rules,
+	}, nil
+}
+
+func (e *RuleEngine) ValidateRuleSet(doc RuleSetDocument) error {
+	var validationErrors []RuleValidationError
+
+	if doc.Version != 0 && doc.Version != ruleSetSchemaVersion {
+		validationErrors = append(validationErrors, RuleValidationError{
+			Index: -1,
+			Error: fmt.Sprintf("unsupported rule set version: %d", doc.Version),
+		})
+	}
+
+	seen := make(map[string]struct{}, len(doc.Rules))
+	for i, rule := range doc.Rules {
+		if rule == nil {
+			validationErrors = append(validationErrors, RuleValidationError{Index: i, Error: "rule is empty"})
+			continue
+		}
+		if _, exists := seen[rule.ID]; exists {
+			validationErrors = append(validationErrors, RuleValidationError{RuleID: rule.ID, Index: i, Error: "duplicate rule id"})
+			continue
+		}
+		seen[rule.ID] = struct{}{}
+
+		if err := e.ValidateRule(rule); err != nil {
+			validationErrors = append(validationErrors, RuleValidationError{RuleID: rule.ID, Index: i, Error: err.Error()})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return &RuleSetValidationError{Errors: validationErrors}
+	}
+	return nil
+}
+
+func (e *RuleEngine) ImportRules(ctx context.Context, doc RuleSetDocument) error {
+	if err := e.ValidateRuleSet(doc); err != nil {
+		return err
+	}
+
+	if err := e.ruleRepo.ReplaceAll(ctx, doc.Rules); err != nil {
+		return fmt.Errorf("failed to replace rules: %w", err)
+	}
+
+	e.InvalidateCache()
+	if _, err := e.getActiveRules(ctx); err != nil {
+		e.logger.WarnContext(ctx, "Failed to precompile imported rules", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+func MarshalRuleSet(doc RuleSetDocument, format string) ([]byte, error) {
+	switch format {
+	case RuleSetFormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case RuleSetFormatYAML:
+		return yaml.Marshal(doc)
+	default:
+		return nil, fmt.Errorf("unsupported rule set format: %s", format)
+	}
+}
+
+func UnmarshalRuleSet(data []byte, format string) (RuleSetDocument, error) {
+	var doc RuleSetDocument
+
+	var err error
+	switch format {
+	case RuleSetFormatJSON:
+		err = json.Unmarshal(data, &doc)
+	case RuleSetFormatYAML:
+		err = yaml.UnmarshalStrict(data, &doc)
+	default:
+		return doc, fmt.Errorf("unsupported rule set format: %s", format)
+	}
+	if err != nil {
+		return doc, fmt.Errorf("%w: %v", ErrInvalidRuleSet, err)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,209 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrLookupTablesNotConfigured = errors.New("lookup tables are not configured")
+	ErrInvalidLookupTable        = errors.New("invalid lookup table")
+	ErrLookupTableInUse          = errors.New("lookup table is referenced by rules")
+)
+
+var lookupTableName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+type LookupRef struct {
+	Table string `json:"table"`
+	Key   string `json:"key"`
+}
+
+func (e *RuleEngine) WithLookupTables(lookupRepo repository.LookupTableRepository) *RuleEngine {
+	e.lookupRepo = lookupRepo
+	if err := e.reloadLookupTables(context.Background()); err != nil {
+		e.logger.Warn("Failed to load lookup tables", slog.String("error", err.Error()))
+	}
+	return e
+}
+
+func (e *RuleEngine) reloadLookupTables(ctx context.Context) error {
+	tables, err := e.lookupRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*domain.LookupTable, len(tables))
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+
+	e.lookupMu.Lock()
+	e.lookups = byName
+	e.lookupMu.Unlock()
+	return nil
+}
+
+func (e *RuleEngine) SaveLookupTable(ctx context.Context, table *domain.LookupTable) error {
+	if e.lookupRepo == nil {
+		return ErrLookupTablesNotConfigured
+	}
+	if !lookupTableName.MatchString(table.Name) {
+		return fmt.Errorf("%w: name must be lowercase letters, digits, '_', '.' or '-'", ErrInvalidLookupTable)
+	}
+	if len(table.Entries) == 0 && table.Default == nil {
+		return fmt.Errorf("%w: entries or a default value are required", ErrInvalidLookupTable)
+	}
+	for key, value := range table.Entries {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%w: entry keys must not be empty", ErrInvalidLookupTable)
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("%w: entry %q is not a finite number", ErrInvalidLookupTable, key)
+		}
+	}
+	if table.Default != nil && (math.IsNaN(*table.Default) || math.IsInf(*table.Default, 0)) {
+		return fmt.Errorf("%w: default is not a finite number", ErrInvalidLookupTable)
+	}
+
+	if err := e.lookupRepo.Save(ctx, table); err != nil {
+		return err
+	}
+	if err := e.reloadLookupTables(ctx); err != nil {
+		return fmt.Errorf("failed to reload lookup tables: %w", err)
+	}
+
+	e.logger.InfoContext(ctx, "Lookup table saved",
+		slog.String("table", table.Name),
+		slog.Int("entries", len(table.Entries)))
+	return nil
+}
+
+func (e *RuleEngine) ListLookupTables(ctx context.Context) ([]*domain.LookupTable, error) {
+	if e.lookupRepo == nil {
+		return nil, ErrLookupTablesNotConfigured
+	}
+	return e.lookupRepo.GetAll(ctx)
+}
+
+func (e *RuleEngine) GetLookupTable(ctx context.Context, name string) (*domain.LookupTable, error) {
+	if e.lookupRepo == nil {
+		return nil, ErrLookupTablesNotConfigured
+	}
+	return e.lookupRepo.GetByName(ctx, name)
+}
+
+func (e *RuleEngine) DeleteLookupTable(ctx context.Context, name string) error {
+	if e.lookupRepo == nil {
+		return ErrLookupTablesNotConfigured
+	}
+
+	rules, err := e.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rules: %w", err)
+	}
+	for _, rule := range rules {
+		for _, ref := range e.ruleLookupRefs(rule) {
+			if ref.Table == name {
+				return fmt.Errorf("%w: rule %s uses %s", ErrLookupTableInUse, rule.ID, name)
+			}
+		}
+	}
+
+	if err := e.lookupRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+	if err := e.reloadLookupTables(ctx); err != nil {
+		return fmt.Errorf("failed to reload lookup tables: %w", err)
+	}
+
+	e.logger.InfoContext(ctx, "Lookup table deleted", slog.String("table", name))
+	return nil
+}
+
+func (e *RuleEngine) ruleLookupRefs(rule *domain.Rule) []LookupRef {
+	if rule.IsScheduled() {
+		return nil
+	}
+
+	var refs []LookupRef
+	if condition, err := e.parseCondition(rule.Condition); err == nil && condition.lookup != nil {
+		refs = append(refs, *condition.lookup)
+	}
+	if action, err := e.parseAction(rule.Action); err == nil {
+		refs = append(refs, actionLookupRefs(action)...)
+	}
+	return refs
+}
+
+func actionLookupRefs(action RuleAction) []LookupRef {
+	var refs []LookupRef
+	for _, value := range action.Params {
+		if ref, ok := parseLookupRef(value); ok {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+func parseLookupRef(value interface{}) (*LookupRef, bool) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	table, _ := fields["table"].(string)
+	key, _ := fields["key"].(string)
+	if table == "" || key == "" {
+		return nil, false
+	}
+	return &LookupRef{Table: table, Key: key}, true
+}
+
+func (e *RuleEngine) resolveLookup(ref LookupRef, tx *domain.Transaction) (float64, bool, error) {
+	e.lookupMu.RLock()
+	table, exists := e.lookups[ref.Table]
+	e.lookupMu.RUnlock()
+	if !exists {
+		return 0, false, fmt.Errorf("unknown lookup table: %s", ref.Table)
+	}
+
+	key, err := lookupKey(ref.Key, tx)
+	if err != nil {
+		return 0, false, err
+	}
+	value, found := table.Lookup(key)
+	return value, found, nil
+}
+
+func lookupKey(key string, tx *domain.Transaction) (string, error) {
+	switch key {
+	case "currency":
+		return tx.Currency, nil
+	case "type":
+		return string(tx.Type), nil
+	case "from_account_id":
+		return tx.FromAccountID, nil
+	case "to_account_id":
+		return tx.ToAccountID, nil
+	}
+	if name, ok := strings.CutPrefix(key, "metadata."); ok && name != "" {
+		return tx.Metadata[name], nil
+	}
+	return "", fmt.Errorf("unknown lookup key: %s", key)
+}
+
+func (e *RuleEngine) actionNumber(value interface{}, tx *domain.Transaction) (float64, bool, error) {
+	if number, ok := value.(float64); ok {
+		return number, true, nil
+	}
+	if ref, ok := parseLookupRef(value); ok {
+		return e.resolveLookup(*ref, tx)
+	}
+	return 0, false, nil
+}
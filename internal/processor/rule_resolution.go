@@ -0,0 +1,184 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+)
+
+type ResolutionStrategy string
+
+const (
+	StrategyFirstMatch ResolutionStrategy = "first_match"
+	StrategyMostSevere ResolutionStrategy = "most_severe"
+	StrategyMerge      ResolutionStrategy = "merge"
+)
+
+const (
+	MetadataDecidedByRule      = "decided_by_rule"
+	MetadataResolutionStrategy = "rule_resolution"
+)
+
+var actionSeverity = map[string]int{
+	"notify":                1,
+	ActionRouteNotification: 1,
+	"adjust_risk_score":     2,
+	"flag_transaction":      3,
+	"require_approval":      4,
+	"block_transaction":     5,
+}
+
+type RuleDecision struct {
+	Applied        []RuleResult
+	DecidingRuleID string
+	Strategies     map[domain.RuleType]ResolutionStrategy
+}
+
+func (d RuleDecision) Blocked() bool {
+	return d.hasAction("block_transaction")
+}
+
+func (d RuleDecision) RequiresApproval() bool {
+	return d.hasAction("require_approval")
+}
+
+func (d RuleDecision) hasAction(actionType string) bool {
+	for _, result := range d.Applied {
+		if result.Action.Type == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *RuleEngine) WithResolutionStrategy(ruleType domain.RuleType, strategy ResolutionStrategy) *RuleEngine {
+	e.strategies[ruleType] = strategy
+	return e
+}
+
+func (e *RuleEngine) strategyFor(ruleType domain.RuleType) ResolutionStrategy {
+	if strategy, exists := e.strategies[ruleType]; exists {
+		return strategy
+	}
+	return StrategyMerge
+}
+
+func (e *RuleEngine) Resolve(results []RuleResult) RuleDecision {
+	decision := RuleDecision{Strategies: make(map[domain.RuleType]ResolutionStrategy)}
+	if len(results) == 0 {
+		return decision
+	}
+
+	byType := make(map[domain.RuleType][]RuleResult)
+	var types []domain.RuleType
+	for _, result := range results {
+		if _, exists := byType[result.RuleType]; !exists {
+			types = append(types, result.RuleType)
+		}
+		byType[result.RuleType] = append(byType[result.RuleType], result)
+	}
+	slices.Sort(types)
+
+	for _, ruleType := range types {
+		group := byType[ruleType]
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Priority > group[j].Priority
+		})
+
+		strategy := e.strategyFor(ruleType)
+		decision.Strategies[ruleType] = strategy
+
+		switch strategy {
+		case StrategyFirstMatch:
+			decision.Applied = append(decision.Applied, group[0])
+		case StrategyMostSevere:
+			decision.Applied = append(decision.Applied, mostSevere(group))
+		default:
+			decision.Applied = append(decision.Applied, group...)
+		}
+	}
+
+	sort.SliceStable(decision.Applied, func(i, j int) bool {
+		return decision.Applied[i].Priority > decision.Applied[j].Priority
+	})
+	decision.DecidingRuleID = mostSevere(decision.Applied).RuleID
+
+	return decision
+}
+
+func (d RuleDecision) Explain(results []RuleResult) []domain.RuleMatch {
+	applied := make(map[string]bool, len(d.Applied))
+	for _, result := range d.Applied {
+		applied[result.RuleID] = true
+	}
+
+	matches := make([]domain.RuleMatch, 0, len(results))
+	for _, result := range results {
+		matches = append(matches, domain.RuleMatch{
+			RuleID:   result.RuleID,
+			RuleName: result.RuleName,
+			Action:   result.Action.Type,
+			Applied:  applied[result.RuleID],
+			Decisive: result.RuleID == d.DecidingRuleID,
+		})
+	}
+	return matches
+}
+
+func mostSevere(results []RuleResult) RuleResult {
+	best := results[0]
+	for _, result := range results[1:] {
+		if actionSeverity[result.Action.Type] > actionSeverity[best.Action.Type] {
+			best = result
+		}
+	}
+	return best
+}
+
+// previewDecision gives the transaction the risk score and decided-by rule
+// ApplyDecision would, without running any action handler, so dry runs
+// neither log nor notify on behalf of decisions nobody acts on.
+func (e *RuleEngine) previewDecision(decision RuleDecision, tx *domain.Transaction) {
+	for _, result := range decision.Applied {
+		if result.Action.Type == "adjust_risk_score" {
+			_, _ = e.adjustRiskScore(result.Action, tx)
+		}
+	}
+	if len(decision.Applied) > 0 {
+		tx.AddMetadata(MetadataDecidedByRule, decision.DecidingRuleID)
+	}
+}
+
+func (e *RuleEngine) ApplyDecision(ctx context.Context, decision RuleDecision, tx *domain.Transaction) error {
+	if len(decision.Applied) == 0 {
+		return nil
+	}
+
+	var failed []string
+	for _, result := range decision.Applied {
+		if err := e.ExecuteAction(ctx, result.Action, tx); err != nil {
+			e.logger.ErrorContext(ctx, "Failed to execute rule action",
+				slog.String("rule_id", result.RuleID),
+				slog.String("action", result.Action.Type),
+				slog.String("error", err.Error()))
+			failed = append(failed, result.RuleID)
+		}
+	}
+
+	tx.AddMetadata(MetadataDecidedByRule, decision.DecidingRuleID)
+	for ruleType, strategy := range decision.Strategies {
+		typeKey := string(ruleType)
+		if typeKey == "" {
+			typeKey = "untyped"
+		}
+		tx.AddMetadata(fmt.Sprintf("%s.%s", MetadataResolutionStrategy, typeKey), string(strategy))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to execute actions for rules: %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+type RuleStats struct {
+	RuleID          string        `json:"rule_id"`
+	Evaluations     int64         `json:"evaluations"`
+	Skipped         int64         `json:"skipped"`
+	Triggers        int64         `json:"triggers"`
+	Errors          int64         `json:"errors"`
+	AverageLatency  time.Duration `json:"average_latency_ns"`
+	LastTriggeredAt *time.Time    `json:"last_triggered_at,omitempty"`
+	LastError       string        `json:"last_error,omitempty"`
+	LastErrorAt     *time.Time    `json:"last_error_at,omitempty"`
+}
+
+type ruleStatsRecorder struct {
+	mu    sync.Mutex
+	stats map[string]*ruleStatsEntry
+}
+
+type ruleStatsEntry struct {
+	evaluations     int64
+	skipped         int64
+	triggers        int64
+	errors          int64
+	totalLatency    time.Duration
+	lastTriggeredAt time.Time
+	lastError       string
+	lastErrorAt     time.Time
+}
+
+func newRuleStatsRecorder() *ruleStatsRecorder {
+	return &ruleStatsRecorder{
+		stats: make(map[string]*ruleStatsEntry),
+	}
+}
+
+func (r *ruleStatsRecorder) record(ruleID string, latency time.Duration, triggered bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.stats[ruleID]
+	if !exists {
+		entry = &ruleStatsEntry{}
+		r.stats[ruleID] = entry
+	}
+
+	now := time.Now()
+	entry.evaluations++
+	entry.totalLatency += latency
+	if err != nil {
+		entry.errors++
+		entry.lastError = err.Error()
+		entry.lastErrorAt = now
+		return
+	}
+	if triggered {
+		entry.triggers++
+		entry.lastTriggeredAt = now
+	}
+}
+
+func (r *ruleStatsRecorder) addSkipped(skipped map[string]int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ruleID, n := range skipped {
+		entry, exists := r.stats[ruleID]
+		if !exists {
+			entry = &ruleStatsEntry{}
+			r.stats[ruleID] = entry
+		}
+		entry.skipped += n
+	}
+}
+
+func (r *ruleStatsRecorder) get(ruleID string, live map[string]int64) RuleStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.stats[ruleID]
+	if !exists {
+		entry = &ruleStatsEntry{}
+	}
+	return entry.snapshot(ruleID, live[ruleID])
+}
+
+func (r *ruleStatsRecorder) all(live map[string]int64) []RuleStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]RuleStats, 0, len(r.stats))
+	for ruleID, entry := range r.stats {
+		result = append(result, entry.snapshot(ruleID, live[ruleID]))
+	}
+	for ruleID, n := range live {
+		if _, exists := r.stats[ruleID]; !exists {
+			result = append(result, (&ruleStatsEntry{}).snapshot(ruleID, n))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RuleID < result[j].RuleID
+	})
+	return result
+}
+
+func (r *ruleStatsRecorder) reset(ruleID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.stats, ruleID)
+}
+
+func (e *ruleStatsEntry) snapshot(ruleID string, liveSkipped int64) RuleStats {
+	skipped := e.skipped + liveSkipped
+	stats := RuleStats{
+		RuleID:      ruleID,
+		Evaluations: e.evaluations + skipped,
+		Skipped:     skipped,
+		Triggers:    e.triggers,
+		Errors:      e.errors,
+		LastError:   e.lastError,
+	}
+	if e.evaluations > 0 {
+		stats.AverageLatency = e.totalLatency / time.Duration(e.evaluations)
+	}
+	if !e.lastTriggeredAt.IsZero() {
+		t := e.lastTriggeredAt
+		stats.LastTriggeredAt = &t
+	}
+	if !e.lastErrorAt.IsZero() {
+		t := e.lastErrorAt
+		stats.LastErrorAt = &t
+	}
+	return stats
+}
+
+func (e *RuleEngine) RuleStats(ruleID string) RuleStats {
+	return e.stats.get(ruleID, e.liveSkipped())
+}
+
+func (e *RuleEngine) AllRuleStats() []RuleStats {
+	return e.stats.all(e.liveSkipped())
+}
+
+func (e *RuleEngine) ResetRuleStats(ruleID string) {
+	e.cacheMu.RLock()
+	if index, exists := e.cache["active"]; exists {
+		for _, compiled := range index.rules {
+			if compiled.rule.ID == ruleID {
+				compiled.evaluated.Store(index.passes.Load())
+			}
+		}
+	}
+	e.cacheMu.RUnlock()
+	e.stats.reset(ruleID)
+}
+
+func (e *RuleEngine) GetRule(ctx context.Context, ruleID string) (*domain.Rule, error) {
+	return e.ruleRepo.GetByID(ctx, ruleID)
+}
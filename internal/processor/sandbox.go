@@ -0,0 +1,104 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"log/slog"
+	"slices"
+)
+
+const (
+	MetadataSandboxScenario = "sandbox_scenario"
+	SandboxFraudFlag        = "sandbox_fraud"
+
+	SandboxInsufficientFunds = "insufficient_funds"
+	SandboxFraudFlagged      = "fraud_flag"
+	SandboxTimeout           = "timeout"
+	SandboxApprovalRequired  = "approval_required"
+)
+
+type SandboxConfig struct {
+	Accounts map[string]string  `json:"accounts"`
+	Amounts  map[string]float64 `json:"amounts"`
+}
+
+func DefaultSandboxConfig() SandboxConfig {
+	return SandboxConfig{
+		Accounts: map[string]string{
+			"SANDBOX-NSF":      SandboxInsufficientFunds,
+			"SANDBOX-FRAUD":    SandboxFraudFlagged,
+			"SANDBOX-TIMEOUT":  SandboxTimeout,
+			"SANDBOX-APPROVAL": SandboxApprovalRequired,
+		},
+		Amounts: map[string]float64{
+			SandboxInsufficientFunds: 9100.01,
+			SandboxFraudFlagged:      9100.02,
+			SandboxTimeout:           9100.03,
+			SandboxApprovalRequired:  9100.04,
+		},
+	}
+}
+
+func (p *TransactionProcessor) WithSandbox(cfg SandboxConfig) *TransactionProcessor {
+	p.sandbox = &cfg
+	return p
+}
+
+func (p *TransactionProcessor) Sandbox() (SandboxConfig, bool) {
+	if p.sandbox == nil {
+		return SandboxConfig{}, false
+	}
+	return *p.sandbox, true
+}
+
+func (p *TransactionProcessor) sandboxScenario(tx *domain.Transaction) string {
+	if p.sandbox == nil {
+		return ""
+	}
+	for _, accountID := range []string{tx.FromAccountID, tx.ToAccountID} {
+		if scenario, ok := p.sandbox.Accounts[accountID]; ok && accountID != "" {
+			return scenario
+		}
+	}
+	scenarios := make([]string, 0, len(p.sandbox.Amounts))
+	for scenario := range p.sandbox.Amounts {
+		scenarios = append(scenarios, scenario)
+	}
+	slices.Sort(scenarios)
+	for _, scenario := range scenarios {
+		if p.sandbox.Amounts[scenario] == tx.Amount {
+			return scenario
+		}
+	}
+	return ""
+}
+
+func (p *TransactionProcessor) applySandbox(ctx context.Context, tx *domain.Transaction, status domain.TransactionStatus) (domain.TransactionStatus, error) {
+	scenario := p.sandboxScenario(tx)
+	if scenario == "" {
+		return status, nil
+	}
+	tx.AddMetadata(MetadataSandboxScenario, scenario)
+	traceStep(ctx, TraceStageDecision, "sandbox", TraceOutcomeTriggered, map[string]string{"scenario": scenario})
+	p.logger.InfoContext(ctx, "Sandbox scenario triggered",
+		slog.String("transaction_id", tx.ID),
+		slog.String("scenario", scenario))
+
+	switch scenario {
+	case SandboxInsufficientFunds:
+		return domain.StatusFailed, repository.ErrInsufficientFunds
+	case SandboxTimeout:
+		return domain.StatusFailed, &StageTimeoutError{Stage: StageExecution, Budget: p.budgets.Execution, Err: context.DeadlineExceeded}
+	case SandboxFraudFlagged:
+		tx.RiskScore = 95
+		if !slices.Contains(tx.FraudFlags, SandboxFraudFlag) {
+			tx.FraudFlags = append(tx.FraudFlags, SandboxFraudFlag)
+		}
+		return domain.StatusSuspicious, nil
+	case SandboxApprovalRequired:
+		tx.AddMetadata("requires_approval", "true")
+		return domain.StatusPending, nil
+	}
+	return status, nil
+}
@@ -0,0 +1,352 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const ScheduledRulesJobName = "scheduled_rules"
+
+var supportedScheduledActionTypes = []string{
+	"flag_account",
+	"suspend_account",
+	"notify",
+}
+
+type AccountAggregate struct {
+	AccountID        string
+	Balance          float64
+	TransactionCount int
+	DepositCount     int
+	WithdrawalCount  int
+	TransferCount    int
+	TotalVolume      float64
+	WithdrawalVolume float64
+	MaxRiskScore     int
+}
+
+type ScheduledRuleResult struct {
+	RuleID    string
+	AccountID string
+	Action    RuleAction
+}
+
+type ScheduledRuleEvaluator struct {
+	engine      *RuleEngine
+	ruleRepo    repository.RuleRepository
+	accountRepo repository.AccountRepository
+	txRepo      repository.TransactionRepository
+	publisher   EventPublisher
+	mu          sync.Mutex
+	nextRun     map[string]time.Time
+	logger      *slog.Logger
+}
+
+func NewScheduledRuleEvaluator(
+	engine *RuleEngine,
+	ruleRepo repository.RuleRepository,
+	accountRepo repository.AccountRepository,
+	txRepo repository.TransactionRepository,
+	logger *slog.Logger,
+) *ScheduledRuleEvaluator {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ScheduledRuleEvaluator{
+		engine:      engine,
+		ruleRepo:    ruleRepo,
+		accountRepo: accountRepo,
+		txRepo:      txRepo,
+		nextRun:     make(map[string]time.Time),
+		logger:      logger,
+	}
+}
+
+func (s *ScheduledRuleEvaluator) WithEventPublisher(publisher EventPublisher) *ScheduledRuleEvaluator {
+	s.publisher = publisher
+	return s
+}
+
+func (s *ScheduledRuleEvaluator) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     ScheduledRulesJobName,
+		Schedule: scheduler.Every(time.Minute),
+		Run:      s.RunDue,
+	})
+}
+
+func (s *ScheduledRuleEvaluator) RunDue(ctx context.Context) error {
+	rules, err := s.ruleRepo.GetActiveRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active rules: %w", err)
+	}
+
+	rules, err = s.engine.filterByGroup(ctx, rules)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		if !rule.IsScheduled() || !s.isDue(rule, now) {
+			continue
+		}
+
+		if _, err := s.EvaluateRule(ctx, rule); err != nil {
+			s.logger.ErrorContext(ctx, "Scheduled rule evaluation failed",
+				slog.String("rule_id", rule.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+func (s *ScheduledRuleEvaluator) isDue(rule *domain.Rule, now time.Time) bool {
+	schedule, err := scheduler.ParseSchedule(rule.Schedule)
+	if err != nil {
+		s.logger.Warn("Scheduled rule has invalid schedule",
+			slog.String("rule_id", rule.ID),
+			slog.String("schedule", rule.Schedule))
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, exists := s.nextRun[rule.ID]
+	if !exists {
+		s.nextRun[rule.ID] = schedule.Next(now)
+		return false
+	}
+	if now.Before(next) {
+		return false
+	}
+
+	s.nextRun[rule.ID] = schedule.Next(now)
+	return true
+}
+
+func (s *ScheduledRuleEvaluator) EvaluateRule(ctx context.Context, rule *domain.Rule) ([]ScheduledRuleResult, error) {
+	startTime := time.Now()
+	results, err := s.evaluateRule(ctx, rule)
+	s.engine.stats.record(rule.ID, time.Since(startTime), len(results) > 0, err)
+	return results, err
+}
+
+func (s *ScheduledRuleEvaluator) evaluateRule(ctx context.Context, rule *domain.Rule) ([]ScheduledRuleResult, error) {
+	condition, err := s.engine.parseCondition(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := s.engine.parseAction(rule.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := s.aggregate(ctx, condition.Window, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ScheduledRuleResult
+	for _, agg := range aggregates {
+		triggered, err := checkAggregateCondition(s.engine, condition, agg)
+		if err != nil {
+			return results, err
+		}
+		if !triggered {
+			continue
+		}
+
+		if err := s.executeAccountAction(ctx, action, agg.AccountID, rule); err != nil {
+			s.logger.ErrorContext(ctx, "Scheduled rule action failed",
+				slog.String("rule_id", rule.ID),
+				slog.String("account_id", agg.AccountID),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		results = append(results, ScheduledRuleResult{RuleID: rule.ID, AccountID: agg.AccountID, Action: action})
+	}
+
+	s.logger.InfoContext(ctx, "Scheduled rule evaluated",
+		slog.String("rule_id", rule.ID),
+		slog.Int("accounts_checked", len(aggregates)),
+		slog.Int("accounts_triggered", len(results)))
+
+	return results, nil
+}
+
+func (s *ScheduledRuleEvaluator) aggregate(ctx context.Context, window string, now time.Time) ([]*AccountAggregate, error) {
+	from, err := windowStart(window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.accountRepo.GetAllActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	byAccount := make(map[string]*AccountAggregate, len(accounts))
+	result := make([]*AccountAggregate, 0, len(accounts))
+	for _, account := range accounts {
+		agg := &AccountAggregate{AccountID: account.ID, Balance: account.Balance}
+		byAccount[account.ID] = agg
+		result = append(result, agg)
+	}
+
+	err = s.txRepo.Iterate(ctx, repository.TransactionFilter{From: from, To: now}, func(tx *domain.Transaction) error {
+		accountID := tx.FromAccountID
+		if tx.Type == domain.TypeDeposit {
+			accountID = tx.ToAccountID
+		}
+		agg, exists := byAccount[accountID]
+		if !exists {
+			return nil
+		}
+
+		agg.TransactionCount++
+		agg.TotalVolume += tx.Amount
+		agg.MaxRiskScore = max(agg.MaxRiskScore, tx.RiskScore)
+		switch tx.Type {
+		case domain.TypeDeposit:
+			agg.DepositCount++
+		case domain.TypeWithdrawal:
+			agg.WithdrawalCount++
+			agg.WithdrawalVolume += tx.Amount
+		case domain.TypeTransfer:
+			agg.TransferCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	return result, nil
+}
+
+func windowStart(window string, now time.Time) (time.Time, error) {
+	switch window {
+	case "", "24h":
+		return now.Add(-24 * time.Hour), nil
+	case "today":
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	default:
+		d, err := time.ParseDuration(window)
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid window: %s", window)
+		}
+		return now.Add(-d), nil
+	}
+}
+
+func checkAggregateCondition(engine *RuleEngine, condition Condition, agg *AccountAggregate) (bool, error) {
+	var value float64
+	switch condition.Field {
+	case "balance":
+		value = agg.Balance
+	case "transaction_count":
+		value = float64(agg.TransactionCount)
+	case "deposit_count":
+		value = float64(agg.DepositCount)
+	case "withdrawal_count":
+		value = float64(agg.WithdrawalCount)
+	case "transfer_count":
+		value = float64(agg.TransferCount)
+	case "total_volume":
+		value = agg.TotalVolume
+	case "withdrawal_volume":
+		value = agg.WithdrawalVolume
+	case "max_risk_score":
+		value = float64(agg.MaxRiskScore)
+	default:
+		return false, fmt.Errorf("unknown aggregate field: %s", condition.Field)
+	}
+
+	return engine.checkNumericCondition(condition, value)
+}
+
+func (s *ScheduledRuleEvaluator) executeAccountAction(ctx context.Context, action RuleAction, accountID string, rule *domain.Rule) error {
+	switch action.Type {
+	case "flag_account":
+		account, err := s.accountRepo.GetByID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		category, _ := action.Params["category"].(string)
+		if category == "" {
+			category = "high"
+		}
+		account.RiskCategory = category
+		s.logger.WarnContext(ctx, "Account flagged by scheduled rule",
+			slog.String("account_id", accountID),
+			slog.String("rule_id", rule.ID),
+			slog.String("risk_category", category))
+		return s.accountRepo.Update(ctx, account)
+	case "suspend_account":
+		s.logger.WarnContext(ctx, "Account suspended by scheduled rule",
+			slog.String("account_id", accountID),
+			slog.String("rule_id", rule.ID))
+		if err := s.accountRepo.UpdateStatus(ctx, accountID, domain.AccountSuspended); err != nil {
+			return err
+		}
+		if s.publisher != nil {
+			s.publisher.Publish(ctx, domain.EventAccountFrozen, domain.AccountEventData{
+				AccountID: accountID,
+				Status:    domain.AccountSuspended,
+				Reason:    action.Message,
+				RuleID:    rule.ID,
+			})
+		}
+		return nil
+	case "notify":
+		channel, _ := action.Params["channel"].(string)
+		s.logger.InfoContext(ctx, "Notification sent",
+			slog.String("channel", channel),
+			slog.String("account_id", accountID),
+			slog.String("rule_id", rule.ID),
+			slog.String("message", action.Message))
+		return nil
+	default:
+		return fmt.Errorf("unknown scheduled action type: %s", action.Type)
+	}
+}
+
+func validateScheduledRule(engine *RuleEngine, rule *domain.Rule) error {
+	if _, err := scheduler.ParseSchedule(rule.Schedule); err != nil {
+		return err
+	}
+
+	condition, err := engine.parseCondition(rule.Condition)
+	if err != nil {
+		return err
+	}
+	if _, err := windowStart(condition.Window, time.Now()); err != nil {
+		return err
+	}
+	if _, err := checkAggregateCondition(engine, condition, &AccountAggregate{}); err != nil {
+		return fmt.Errorf("invalid condition: %w", err)
+	}
+
+	action, err := engine.parseAction(rule.Action)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(supportedScheduledActionTypes, action.Type) {
+		return fmt.Errorf("unknown scheduled action type: %s", action.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+type ShadowDecision struct {
+	Status        domain.TransactionStatus `json:"status"`
+	RiskScore     int                      `json:"risk_score"`
+	DecidedByRule string                   `json:"decided_by_rule,omitempty"`
+}
+
+type ShadowPipeline interface {
+	Name() string
+	Evaluate(ctx context.Context, tx *domain.Transaction) (ShadowDecision, error)
+}
+
+type ShadowConfig struct {
+	SampleRate     float64
+	Timeout        time.Duration
+	MaxDivergences int
+}
+
+func DefaultShadowConfig() ShadowConfig {
+	return ShadowConfig{
+		SampleRate:     0.05,
+		Timeout:        2 * time.Second,
+		MaxDivergences: 100,
+	}
+}
+
+type ShadowDivergence struct {
+	TransactionID  string         `json:"transaction_id"`
+	Primary        ShadowDecision `json:"primary"`
+	Shadow         ShadowDecision `json:"shadow"`
+	Fields         []string       `json:"fields"`
+	PrimaryLatency time.Duration  `json:"primary_latency_ns"`
+	ShadowLatency  time.Duration  `json:"shadow_latency_ns"`
+	ObservedAt     time.Time      `json:"observed_at"`
+}
+
+type ShadowReport struct {
+	Pipeline          string             `json:"pipeline"`
+	SampleRate        float64            `json:"sample_rate"`
+	Since             time.Time          `json:"since"`
+	Sampled           int                `json:"sampled"`
+	Matched           int                `json:"matched"`
+	Diverged          int                `json:"diverged"`
+	Errors            int                `json:"errors"`
+	AvgPrimaryLatency time.Duration      `json:"avg_primary_latency_ns"`
+	AvgShadowLatency  time.Duration      `json:"avg_shadow_latency_ns"`
+	MaxShadowLatency  time.Duration      `json:"max_shadow_latency_ns"`
+	Divergences       []ShadowDivergence `json:"divergences"`
+}
+
+type shadowMode struct {
+	pipeline ShadowPipeline
+	cfg      ShadowConfig
+
+	mu             sync.Mutex
+	since          time.Time
+	sampled        int
+	matched        int
+	diverged       int
+	errors         int
+	primaryLatency time.Duration
+	shadowLatency  time.Duration
+	maxShadow      time.Duration
+	divergences    []ShadowDivergence
+}
+
+type shadowResult struct {
+	decision ShadowDecision
+	latency  time.Duration
+	err      error
+}
+
+type shadowRun struct {
+	mode    *shadowMode
+	logger  *slog.Logger
+	started time.Time
+	result  chan shadowResult
+}
+
+func (p *TransactionProcessor) WithShadowPipeline(pipeline ShadowPipeline, cfg ShadowConfig) *TransactionProcessor {
+	p.shadow = &shadowMode{pipeline: pipeline, cfg: cfg, since: time.Now()}
+	return p
+}
+
+func (p *TransactionProcessor) ShadowReport() (*ShadowReport, bool) {
+	if p.shadow == nil {
+		return nil, false
+	}
+	return p.shadow.report(), true
+}
+
+func (p *TransactionProcessor) ResetShadowReport() {
+	if p.shadow == nil {
+		return
+	}
+	m := p.shadow
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since = time.Now()
+	m.sampled, m.matched, m.diverged, m.errors = 0, 0, 0, 0
+	m.primaryLatency, m.shadowLatency, m.maxShadow = 0, 0, 0
+	m.divergences = nil
+}
+
+func (m *shadowMode) sample(txID string) bool {
+	switch {
+	case m.cfg.SampleRate <= 0:
+		return false
+	case m.cfg.SampleRate >= 1:
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(txID))
+	return float64(h.Sum32()%10000) < m.cfg.SampleRate*10000
+}
+
+func (p *TransactionProcessor) startShadow(ctx context.Context, tx *domain.Transaction) *shadowRun {
+	if p.shadow == nil || !p.shadow.sample(tx.ID) {
+		return nil
+	}
+
+	run := &shadowRun{mode: p.shadow, logger: p.logger, started: time.Now(), result: make(chan shadowResult, 1)}
+	clone := tx.Clone()
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.shadow.cfg.Timeout)
+	go func() {
+		defer cancel()
+		start := time.Now()
+		decision, err := run.mode.pipeline.Evaluate(ctx, clone)
+		run.result <- shadowResult{decision: decision, latency: time.Since(start), err: err}
+	}()
+	return run
+}
+
+func (r *shadowRun) complete(tx *domain.Transaction) {
+	if r == nil {
+		return
+	}
+	txID := tx.ID
+	primary := ShadowDecision{Status: tx.Status, RiskScore: tx.RiskScore, DecidedByRule: tx.Metadata[MetadataDecidedByRule]}
+	latency := time.Since(r.started)
+	go func() {
+		result := <-r.result
+		if result.err != nil {
+			r.logger.Warn("Shadow pipeline failed",
+				slog.String("transaction_id", txID),
+				slog.String("pipeline", r.mode.pipeline.Name()),
+				slog.String("error", result.err.Error()))
+		}
+		if divergence := r.mode.observe(txID, primary, latency, result); divergence != nil {
+			r.logger.Info("Shadow pipeline diverged",
+				slog.String("transaction_id", txID),
+				slog.String("pipeline", r.mode.pipeline.Name()),
+				slog.Any("fields", divergence.Fields))
+		}
+	}()
+}
+
+func (m *shadowMode) observe(txID string, primary ShadowDecision, primaryLatency time.Duration, result shadowResult) *ShadowDivergence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sampled++
+	m.primaryLatency += primaryLatency
+	m.shadowLatency += result.latency
+	m.maxShadow = max(m.maxShadow, result.latency)
+	if result.err != nil {
+		m.errors++
+		return nil
+	}
+
+	fields := diffDecisions(primary, result.decision)
+	if len(fields) == 0 {
+		m.matched++
+		return nil
+	}
+
+	m.diverged++
+	divergence := ShadowDivergence{
+		TransactionID:  txID,
+		Primary:        primary,
+		Shadow:         result.decision,
+		Fields:         fields,
+		PrimaryLatency: primaryLatency,
+		ShadowLatency:  result.latency,
+		ObservedAt:     time.Now(),
+	}
+	m.divergences = append(m.divergences, divergence)
+	if m.cfg.MaxDivergences > 0 && len(m.divergences) > m.cfg.MaxDivergences {
+		m.divergences = m.divergences[len(m.divergences)-m.cfg.MaxDivergences:]
+	}
+	return &divergence
+}
+
+func (m *shadowMode) report() *ShadowReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := &ShadowReport{
+		Pipeline:         m.pipeline.Name(),
+		SampleRate:       m.cfg.SampleRate,
+		Since:            m.since,
+		Sampled:          m.sampled,
+		Matched:          m.matched,
+		Diverged:         m.diverged,
+		Errors:           m.errors,
+		MaxShadowLatency: m.maxShadow,
+		Divergences:      slices.Clone(m.divergences),
+	}
+	if m.sampled > 0 {
+		report.AvgPrimaryLatency = m.primaryLatency / time.Duration(m.sampled)
+		report.AvgShadowLatency = m.shadowLatency / time.Duration(m.sampled)
+	}
+	if report.Divergences == nil {
+		report.Divergences = []ShadowDivergence{}
+	}
+	return report
+}
+
+func diffDecisions(primary, shadow ShadowDecision) []string {
+	var fields []string
+	if primary.Status != shadow.Status {
+		fields = append(fields, "status")
+	}
+	if primary.RiskScore != shadow.RiskScore {
+		fields = append(fields, "risk_score")
+	}
+	if primary.DecidedByRule != shadow.DecidedByRule {
+		fields = append(fields, "decided_by_rule")
+	}
+	return fields
+}
+
+type dryRunPipeline struct {
+	p *TransactionProcessor
+}
+
+func NewDryRunPipeline(p *TransactionProcessor) ShadowPipeline {
+	return &dryRunPipeline{p: p}
+}
+
+func (d *dryRunPipeline) Name() string {
+	return "dry_run"
+}
+
+func (d *dryRunPipeline) Evaluate(ctx context.Context, tx *domain.Transaction) (ShadowDecision, error) {
+	p := d.p
+	fastPath := p.qualifiesForFastPath(ctx, tx)
+	var skippedPatterns []string
+	if fastPath {
+		skippedPatterns = p.fastPath.cfg.SkipPatterns
+	}
+	tx.RiskScore, tx.FraudFlags = p.fraudDetector.ExplainTransaction(tx, skippedPatterns...)
+
+	results, err := p.ruleEngine.evaluateRules(ctx, tx, false)
+	if err != nil {
+		return ShadowDecision{}, err
+	}
+	if fastPath {
+		results = p.fastPath.filterRules(results)
+	}
+	decision := p.ruleEngine.Resolve(results)
+	p.ruleEngine.previewDecision(decision, tx)
+
+	status := resolveStatus(decision, tx.RiskScore, func() bool {
+		_, hold := p.positivePayException(ctx, tx)
+		return hold
+	})
+	return ShadowDecision{Status: status, RiskScore: tx.RiskScore, DecidedByRule: tx.Metadata[MetadataDecidedByRule]}, nil
+}
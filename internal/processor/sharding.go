@@ -0,0 +1,122 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"hash/fnv"
+	"log/slog"
+)
+
+type ShardRouter interface {
+	Shards() int
+	Shard(key string) int
+}
+
+type hashRouter struct {
+	shards int
+}
+
+func NewHashRouter(shards int) ShardRouter {
+	if shards <= 0 {
+		shards = 1
+	}
+	return hashRouter{shards: shards}
+}
+
+func (r hashRouter) Shards() int {
+	return r.shards
+}
+
+func (r hashRouter) Shard(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(r.shards))
+}
+
+type ShardingConfig struct {
+	Shards int
+	Queues []QueueConfig
+}
+
+func DefaultShardingConfig(shards int) ShardingConfig {
+	return ShardingConfig{
+		Shards: shards,
+		Queues: DefaultWorkerPoolConfig(1).Queues,
+	}
+}
+
+type ShardStats struct {
+	Shards []PoolStats `json:"shards"`
+	Busy   int         `json:"busy"`
+	Queued int         `json:"queued"`
+}
+
+type ShardedPool struct {
+	router ShardRouter
+	pools  []*WorkerPool
+}
+
+func NewShardedPool(cfg ShardingConfig, router ShardRouter, logger *slog.Logger) *ShardedPool {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if router == nil {
+		router = NewHashRouter(cfg.Shards)
+	}
+
+	pools := make([]*WorkerPool, router.Shards())
+	for i := range pools {
+		pools[i] = NewWorkerPool(WorkerPoolConfig{Workers: 1, Queues: cfg.Queues}, logger.With(slog.Int("shard", i)))
+	}
+	return &ShardedPool{router: router, pools: pools}
+}
+
+func (s *ShardedPool) Submit(key, queue string, job Job) error {
+	return s.pools[s.router.Shard(key)].Submit(queue, job)
+}
+
+func (s *ShardedPool) Stats() ShardStats {
+	stats := ShardStats{Shards: make([]PoolStats, len(s.pools))}
+	for i, pool := range s.pools {
+		poolStats := pool.Stats()
+		stats.Shards[i] = poolStats
+		stats.Busy += poolStats.Busy
+		for _, queued := range poolStats.Queued {
+			stats.Queued += queued
+		}
+	}
+	return stats
+}
+
+func (s *ShardedPool) Stop(ctx context.Context) error {
+	var errs []error
+	for _, pool := range s.pools {
+		if err := pool.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func shardKey(tx *domain.Transaction) string {
+	if tx.FromAccountID != "" {
+		return tx.FromAccountID
+	}
+	if tx.ToAccountID != "" {
+		return tx.ToAccountID
+	}
+	return tx.ID
+}
+
+func (p *TransactionProcessor) WithSharding(pool *ShardedPool) *TransactionProcessor {
+	p.shards = pool
+	return p
+}
+
+func (p *TransactionProcessor) ShardStats() (ShardStats, bool) {
+	if p.shards == nil {
+		return ShardStats{}, false
+	}
+	return p.shards.Stats(), true
+}
@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+const (
+	MetadataChannel = "channel"
+	MetadataCountry = "country"
+	ChannelOnline   = "online"
+)
+
+var (
+	ErrSpendingControlsNotConfigured = errors.New("spending controls are not configured")
+	ErrInvalidSpendingControls       = errors.New("invalid spending controls")
+	ErrBlockedBySpendingControls     = errors.New("blocked by spending controls")
+)
+
+func (p *TransactionProcessor) WithSpendingControls(repo repository.SpendingControlsRepository) *TransactionProcessor {
+	p.controls = repo
+	return p
+}
+
+func (p *TransactionProcessor) SetSpendingControls(ctx context.Context, controls *domain.SpendingControls) error {
+	if p.controls == nil {
+		return ErrSpendingControlsNotConfigured
+	}
+	if controls.MaxTransactionAmount < 0 {
+		return fmt.Errorf("%w: max_transaction_amount must not be negative", ErrInvalidSpendingControls)
+	}
+	categories := controls.BlockedCategories[:0]
+	for _, category := range controls.BlockedCategories {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
+		}
+	}
+	controls.BlockedCategories = categories
+
+	if _, err := p.accountRepo.GetByID(ctx, controls.AccountID); err != nil {
+		return err
+	}
+	if err := p.controls.Save(ctx, controls); err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "Spending controls updated",
+		slog.String("account_id", controls.AccountID),
+		slog.Bool("block_international", controls.BlockInternational),
+		slog.Bool("block_online", controls.BlockOnline),
+		slog.Int("blocked_categories", len(controls.BlockedCategories)))
+	return nil
+}
+
+func (p *TransactionProcessor) SpendingControls(ctx context.Context, accountID string) (*domain.SpendingControls, error) {
+	if p.controls == nil {
+		return nil, ErrSpendingControlsNotConfigured
+	}
+	if _, err := p.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	controls, err := p.controls.GetByAccountID(ctx, accountID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return &domain.SpendingControls{AccountID: accountID}, nil
+	}
+	return controls, err
+}
+
+func (p *TransactionProcessor) checkSpendingControls(ctx context.Context, from *domain.Account, tx *domain.Transaction, to ...*domain.Account) error {
+	if p.controls == nil || tx.SystemPosting != "" {
+		return nil
+	}
+
+	controls, err := p.controls.GetByAccountID(ctx, from.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get spending controls: %w", err)
+	}
+
+	if controls.MaxTransactionAmount > 0 && !tx.AddLimitCheck("max_transaction_amount", controls.MaxTransactionAmount, tx.Amount) {
+		return fmt.Errorf("%w: amount %.2f above %.2f", ErrBlockedBySpendingControls, tx.Amount, controls.MaxTransactionAmount)
+	}
+	if controls.BlockOnline && strings.EqualFold(tx.Metadata[MetadataChannel], ChannelOnline) {
+		return fmt.Errorf("%w: online transactions", ErrBlockedBySpendingControls)
+	}
+	if category := tx.Metadata[MetadataCategory]; controls.BlocksCategory(category) {
+		return fmt.Errorf("%w: category %s", ErrBlockedBySpendingControls, category)
+	}
+	if controls.BlockInternational {
+		for _, country := range destinationCountries(tx, to) {
+			if from.Country != "" && country != "" && !strings.EqualFold(from.Country, country) {
+				return fmt.Errorf("%w: international transactions to %s", ErrBlockedBySpendingControls, country)
+			}
+		}
+	}
+	return nil
+}
+
+func destinationCountries(tx *domain.Transaction, to []*domain.Account) []string {
+	if len(to) == 0 {
+		return []string{tx.Metadata[MetadataCountry]}
+	}
+	countries := make([]string, 0, len(to))
+	for _, account := range to {
+		country := tx.Metadata[MetadataCountry]
+		if account.Country != "" {
+			country = account.Country
+		}
+		countries = append(countries, country)
+	}
+	return countries
+}
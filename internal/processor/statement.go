@@ -0,0 +1,219 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"io"
+	"time"
+)
+
+type ReportingSnapshot struct {
+	Transactions repository.TransactionSnapshot
+	Accounts     repository.AccountSnapshot
+}
+
+func (s *ReportingSnapshot) Release() {
+	s.Transactions.Release()
+	s.Accounts.Release()
+}
+
+func (p *TransactionProcessor) Snapshot(ctx context.Context) (*ReportingSnapshot, error) {
+	txSnapshotter, ok := p.readRepo.(repository.TransactionSnapshotter)
+	if !ok {
+		return nil, fmt.Errorf("%w: transactions", repository.ErrSnapshotUnsupported)
+	}
+	accountSnapshotter, ok := p.accountRepo.(repository.AccountSnapshotter)
+	if !ok {
+		return nil, fmt.Errorf("%w: accounts", repository.ErrSnapshotUnsupported)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	transactions, err := txSnapshotter.SnapshotTransactions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot transactions: %w", err)
+	}
+	accounts, err := accountSnapshotter.SnapshotAccounts(ctx)
+	if err != nil {
+		transactions.Release()
+		return nil, fmt.Errorf("failed to snapshot accounts: %w", err)
+	}
+
+	return &ReportingSnapshot{Transactions: transactions, Accounts: accounts}, nil
+}
+
+func (p *TransactionProcessor) ExportSnapshot(ctx context.Context, w io.Writer) error {
+	snapshot, err := p.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	return repository.WriteSnapshotFile(ctx, w, snapshot.Accounts, snapshot.Transactions)
+}
+
+type Statement struct {
+	Account        *domain.Account
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	ClosingBalance float64
+	TakenAt        time.Time
+
+	snapshot *ReportingSnapshot
+}
+
+func (p *TransactionProcessor) Statement(ctx context.Context, accountID string, from, to time.Time) (*Statement, error) {
+	snapshot, err := p.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := newStatement(ctx, snapshot, accountID, from, to)
+	if err != nil {
+		snapshot.Release()
+		return nil, err
+	}
+	return statement, nil
+}
+
+func newStatement(ctx context.Context, snapshot *ReportingSnapshot, accountID string, from, to time.Time) (*Statement, error) {
+	account, err := snapshot.Accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var afterPeriod, inPeriod float64
+	err = snapshot.Transactions.Iterate(ctx, repository.TransactionFilter{AccountID: accountID, From: from}, func(tx *domain.Transaction) error {
+		if tx.CreatedAt.After(to) {
+			afterPeriod += balanceEffect(tx, accountID)
+		} else {
+			inPeriod += balanceEffect(tx, accountID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	closing := account.Balance - afterPeriod
+	return &Statement{
+		Account:        account,
+		From:           from,
+		To:             to,
+		OpeningBalance: closing - inPeriod,
+		ClosingBalance: closing,
+		TakenAt:        snapshot.Transactions.TakenAt(),
+		snapshot:       snapshot,
+	}, nil
+}
+
+func (s *Statement) Write(ctx context.Context, w io.Writer, format string) error {
+	writer, err := newTransactionExportWriter(w, format, true)
+	if err != nil {
+		return err
+	}
+
+	balance := s.OpeningBalance
+	filter := repository.TransactionFilter{AccountID: s.Account.ID, From: s.From, To: s.To}
+	for tx, err := range s.snapshot.Transactions.Stream(ctx, filter) {
+		if err != nil {
+			return err
+		}
+		balance += balanceEffect(tx, s.Account.ID)
+		record := NewTransactionExportRecord(tx, s.Account.ID)
+		balanceAfter := balance
+		record.BalanceAfter = &balanceAfter
+		if err := writer.write(record); err != nil {
+			return err
+		}
+	}
+	return writer.flush()
+}
+
+func (s *Statement) Release() {
+	s.snapshot.Release()
+}
+
+func balanceEffect(tx *domain.Transaction, accountID string) float64 {
+	if tx.Status != domain.StatusCompleted {
+		return 0
+	}
+	var effect float64
+	if tx.ToAccountID == accountID {
+		effect += tx.Amount
+	}
+	if tx.FromAccountID == accountID {
+		effect -= tx.Amount
+	}
+	return effect
+}
+
+type StatementSummary struct {
+	AccountID      string    `json:"account_id"`
+	Currency       string    `json:"currency"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	OpeningBalance float64   `json:"opening_balance"`
+	ClosingBalance float64   `json:"closing_balance"`
+	Transactions   int       `json:"transactions"`
+}
+
+func (s *ReportingSnapshot) StatementSummaries(ctx context.Context, from, to time.Time) ([]StatementSummary, error) {
+	type effects struct {
+		inPeriod, afterPeriod float64
+		count                 int
+	}
+	byAccount := make(map[string]*effects)
+	effectsFor := func(accountID string) *effects {
+		e, exists := byAccount[accountID]
+		if !exists {
+			e = &effects{}
+			byAccount[accountID] = e
+		}
+		return e
+	}
+
+	err := s.Transactions.Iterate(ctx, repository.TransactionFilter{From: from}, func(tx *domain.Transaction) error {
+		for i, accountID := range []string{tx.FromAccountID, tx.ToAccountID} {
+			if accountID == "" || (i == 1 && accountID == tx.FromAccountID) {
+				continue
+			}
+			e := effectsFor(accountID)
+			if tx.CreatedAt.Before(to) {
+				e.inPeriod += balanceEffect(tx, accountID)
+				e.count++
+			} else {
+				e.afterPeriod += balanceEffect(tx, accountID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.Accounts.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]StatementSummary, 0, len(accounts))
+	for _, account := range accounts {
+		e := effectsFor(account.ID)
+		closing := account.Balance - e.afterPeriod
+		summaries = append(summaries, StatementSummary{
+			AccountID:      account.ID,
+			Currency:       account.Currency,
+			From:           from,
+			To:             to,
+			OpeningBalance: closing - e.inPeriod,
+			ClosingBalance: closing,
+			Transactions:   e.count,
+		})
+	}
+	return summaries, nil
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"strings"
+	"time"
+)
+
+const (
+	FlagStructuring           = "structuring"
+	ComplianceFlagPrefix      = "compliance:"
+	ComplianceFlagStructuring = ComplianceFlagPrefix + FlagStructuring
+)
+
+type StructuringConfig struct {
+	Threshold float64
+	Margin    float64
+	MinCount  int
+	Window    time.Duration
+	Weight    int
+}
+
+func DefaultStructuringConfig() StructuringConfig {
+	return StructuringConfig{
+		Threshold: 10000,
+		Margin:    0.1,
+		MinCount:  3,
+		Window:    24 * time.Hour,
+		Weight:    45,
+	}
+}
+
+func (fd *FraudDetector) WithStructuringDetection(history repository.TransactionRepository, cfg StructuringConfig) *FraudDetector {
+	detector := &structuringDetector{history: history, cfg: cfg}
+	fd.patterns = append(fd.patterns, FraudPattern{
+		Name:        FlagStructuring,
+		Description: "Repeated just-below-threshold transactions from one account or to one counterparty",
+		Detect:      detector.detect,
+		Weight:      cfg.Weight,
+	})
+	return fd
+}
+
+type structuringDetector struct {
+	history repository.TransactionRepository
+	cfg     StructuringConfig
+}
+
+func (d *structuringDetector) nearThreshold(amount float64) bool {
+	return amount < d.cfg.Threshold && amount >= d.cfg.Threshold*(1-d.cfg.Margin)
+}
+
+func (d *structuringDetector) detect(tx *domain.Transaction) (bool, string) {
+	if !d.nearThreshold(tx.Amount) {
+		return false, ""
+	}
+
+	now := time.Now()
+	fromCount, toCount := 1, 1
+	err := d.history.Iterate(context.Background(), repository.TransactionFilter{From: now.Add(-d.cfg.Window), To: now}, func(prev *domain.Transaction) error {
+		if prev.ID == tx.ID || prev.Status == domain.StatusFailed || !d.nearThreshold(prev.Amount) {
+			return nil
+		}
+		if tx.FromAccountID != "" && prev.FromAccountID == tx.FromAccountID {
+			fromCount++
+		}
+		if tx.ToAccountID != "" && prev.ToAccountID == tx.ToAccountID {
+			toCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return false, ""
+	}
+
+	if fromCount >= d.cfg.MinCount || toCount >= d.cfg.MinCount {
+		return true, ComplianceFlagStructuring
+	}
+	return false, ""
+}
+
+func complianceFlags(flags []string) []string {
+	var result []string
+	for _, flag := range flags {
+		if strings.HasPrefix(flag, ComplianceFlagPrefix) {
+			result = append(result, flag)
+		}
+	}
+	return result
+}
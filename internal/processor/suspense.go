@@ -0,0 +1,201 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	MetadataSuspenseIntendedAccount = "suspense_intended_account"
+
+	PostingSuspenseReassign = "suspense_reassign"
+	PostingSuspenseReturn   = "suspense_return"
+)
+
+var (
+	ErrSuspenseNotConfigured = errors.New("suspense handling is not configured")
+	ErrInvalidSuspenseRepair = errors.New("invalid suspense repair")
+	ErrSuspenseItemResolved  = errors.New("suspense item already resolved")
+)
+
+type SuspenseRepair struct {
+	AccountID string `json:"account_id,omitempty"`
+	Reason    string `json:"reason"`
+	Operator  string `json:"operator"`
+}
+
+type suspenseQueue struct {
+	repo repository.SuspenseRepository
+	mu   sync.Mutex
+}
+
+func (p *TransactionProcessor) WithSuspense(repo repository.SuspenseRepository) *TransactionProcessor {
+	p.suspense = &suspenseQueue{repo: repo}
+	return p
+}
+
+func (p *TransactionProcessor) routeToSuspense(tx *domain.Transaction) bool {
+	if p.suspense == nil || tx.SystemPosting != "" {
+		return false
+	}
+	// Called from executeTransaction or checkExecution, which hold p.mu.
+	suspense := domain.SystemAccountID(domain.SystemRoleSuspense, tx.Currency)
+	if !p.systemAccts[suspense] {
+		return false
+	}
+
+	tx.AddMetadata(MetadataSuspenseIntendedAccount, tx.ToAccountID)
+	tx.ToAccountID = suspense
+	return true
+}
+
+func (p *TransactionProcessor) queueSuspenseItem(ctx context.Context, tx *domain.Transaction) {
+	intended := tx.Metadata[MetadataSuspenseIntendedAccount]
+	if p.suspense == nil || intended == "" {
+		return
+	}
+
+	item := &domain.SuspenseItem{
+		TransactionID:     tx.ID,
+		Reference:         tx.Reference,
+		IntendedAccountID: intended,
+		Amount:            tx.Amount,
+		Currency:          tx.Currency,
+		Status:            domain.SuspenseOpen,
+		CreatedAt:         time.Now(),
+	}
+	if err := p.suspense.repo.Save(ctx, item); err != nil {
+		if !errors.Is(err, repository.ErrDuplicate) {
+			p.logger.ErrorContext(ctx, "Failed to queue suspense item",
+				slog.String("transaction_id", tx.ID),
+				slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	p.logger.WarnContext(ctx, "Deposit for unknown account held in suspense",
+		slog.String("transaction_id", tx.ID),
+		slog.String("intended_account", intended),
+		p.logAmount("amount", tx.Amount))
+	p.emitEvent(ctx, domain.TransactionEvent{
+		TransactionID: tx.ID,
+		Type:          "deposit_suspended",
+		Payload:       map[string]interface{}{"intended_account_id": intended},
+		Timestamp:     time.Now(),
+	})
+}
+
+func (p *TransactionProcessor) SuspenseItems(ctx context.Context, status domain.SuspenseStatus) ([]*domain.SuspenseItem, error) {
+	if p.suspense == nil {
+		return nil, ErrSuspenseNotConfigured
+	}
+	return p.suspense.repo.List(ctx, status)
+}
+
+func (p *TransactionProcessor) SuspenseItem(ctx context.Context, transactionID string) (*domain.SuspenseItem, error) {
+	if p.suspense == nil {
+		return nil, ErrSuspenseNotConfigured
+	}
+	return p.suspense.repo.GetByTransactionID(ctx, transactionID)
+}
+
+func (p *TransactionProcessor) ReassignSuspense(ctx context.Context, transactionID string, repair SuspenseRepair) (*domain.SuspenseItem, error) {
+	if repair.AccountID == "" {
+		return nil, fmt.Errorf("%w: account_id is required", ErrInvalidSuspenseRepair)
+	}
+	return p.resolveSuspense(ctx, transactionID, repair, domain.SuspenseReassigned, func(item *domain.SuspenseItem, suspense string) (*domain.Transaction, error) {
+		account, err := p.accountRepo.GetByID(ctx, repair.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if account.Currency != item.Currency {
+			return nil, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, account.Currency, item.Currency)
+		}
+		if account.Status != domain.AccountActive {
+			return nil, fmt.Errorf("%w: %s", ErrAccountInactive, account.Status)
+		}
+
+		posting := domain.NewTransaction(domain.TypeTransfer, item.Amount, item.Currency).
+			WithAccounts(suspense, account.ID).
+			WithDescription(fmt.Sprintf("suspense reassignment of %s", item.TransactionID))
+		posting.AddMetadata(MetadataLinkedTransaction, item.TransactionID)
+		return posting, p.PostSystemTransaction(ctx, posting, PostingSuspenseReassign)
+	})
+}
+
+func (p *TransactionProcessor) ReturnSuspense(ctx context.Context, transactionID string, repair SuspenseRepair) (*domain.SuspenseItem, error) {
+	return p.resolveSuspense(ctx, transactionID, repair, domain.SuspenseReturned, func(item *domain.SuspenseItem, suspense string) (*domain.Transaction, error) {
+		settlement, err := p.SystemAccount(domain.SystemRoleSettlement, item.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		posting := domain.NewTransaction(domain.TypeWithdrawal, item.Amount, item.Currency).
+			WithAccounts(suspense, settlement).
+			WithDescription(fmt.Sprintf("return of unmatched deposit %s", item.TransactionID))
+		posting.AddMetadata(MetadataLinkedTransaction, item.TransactionID)
+		return posting, p.PostSystemTransaction(ctx, posting, PostingSuspenseReturn)
+	})
+}
+
+func (p *TransactionProcessor) resolveSuspense(
+	ctx context.Context,
+	transactionID string,
+	repair SuspenseRepair,
+	status domain.SuspenseStatus,
+	post func(item *domain.SuspenseItem, suspense string) (*domain.Transaction, error),
+) (*domain.SuspenseItem, error) {
+	if p.suspense == nil {
+		return nil, ErrSuspenseNotConfigured
+	}
+	if repair.Reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidSuspenseRepair)
+	}
+	if repair.Operator == "" {
+		return nil, fmt.Errorf("%w: operator is required", ErrInvalidSuspenseRepair)
+	}
+
+	p.suspense.mu.Lock()
+	defer p.suspense.mu.Unlock()
+
+	item, err := p.suspense.repo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != domain.SuspenseOpen {
+		return nil, fmt.Errorf("%w: %s is %s", ErrSuspenseItemResolved, item.TransactionID, item.Status)
+	}
+	suspense, err := p.SystemAccount(domain.SystemRoleSuspense, item.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	posting, err := post(item, suspense)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	item.Status = status
+	item.ResolvedAccountID = posting.ToAccountID
+	item.ResolutionTransactionID = posting.ID
+	item.ResolvedBy = repair.Operator
+	item.Reason = repair.Reason
+	item.ResolvedAt = &now
+	if err := p.suspense.repo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+
+	p.logger.InfoContext(ctx, "Suspense item resolved",
+		slog.String("transaction_id", item.TransactionID),
+		slog.String("status", string(status)),
+		slog.String("resolution_transaction_id", posting.ID),
+		slog.String("operator", repair.Operator))
+	return item, nil
+}
@@ -0,0 +1,112 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrSystemAccountMissing = errors.New("system account not configured")
+
+type SystemAccountConfig struct {
+	Currencies []string
+}
+
+func DefaultSystemAccountConfig() SystemAccountConfig {
+	return SystemAccountConfig{Currencies: []string{"USD", "EUR", "GBP", "JPY", "CHF"}}
+}
+
+func (p *TransactionProcessor) EnsureSystemAccounts(ctx context.Context, cfg SystemAccountConfig) error {
+	registered := make(map[string]bool)
+	for _, currency := range cfg.Currencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency == "" {
+			continue
+		}
+		for _, role := range domain.SystemRoles() {
+			id := domain.SystemAccountID(role, currency)
+			account, err := p.accountRepo.GetByID(ctx, id)
+			switch {
+			case errors.Is(err, repository.ErrNotFound):
+				account = &domain.Account{
+					ID:         id,
+					UserID:     domain.SystemUserID,
+					Currency:   currency,
+					Status:     domain.AccountActive,
+					SystemRole: role,
+					CreatedAt:  time.Now(),
+				}
+				if err := p.accountRepo.Save(ctx, account); err != nil {
+					return fmt.Errorf("failed to create system account %s: %w", id, err)
+				}
+				p.logger.InfoContext(ctx, "System account created",
+					slog.String("account_id", id),
+					slog.String("role", string(role)))
+			case err != nil:
+				return err
+			case account.SystemRole != role || account.Currency != currency:
+				return fmt.Errorf("%w: account %s exists but is not the %s %s system account", repository.ErrDuplicate, id, currency, role)
+			}
+			registered[id] = true
+		}
+	}
+
+	p.mu.Lock()
+	p.systemAccts = registered
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *TransactionProcessor) SystemAccount(role domain.SystemRole, currency string) (string, error) {
+	id := domain.SystemAccountID(role, currency)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.systemAccts[id] {
+		return "", fmt.Errorf("%w: %s %s", ErrSystemAccountMissing, strings.ToUpper(currency), role)
+	}
+	return id, nil
+}
+
+func (p *TransactionProcessor) SystemAccounts(ctx context.Context) ([]*domain.Account, error) {
+	p.mu.RLock()
+	ids := make([]string, 0, len(p.systemAccts))
+	for id := range p.systemAccts {
+		ids = append(ids, id)
+	}
+	p.mu.RUnlock()
+	sort.Strings(ids)
+
+	accounts := make([]*domain.Account, 0, len(ids))
+	for _, id := range ids {
+		account, err := p.accountRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account.Clone())
+	}
+	return accounts, nil
+}
+
+func (p *TransactionProcessor) balanceWithSuspense(tx *domain.Transaction) {
+	if tx.Type != domain.TypeDeposit && tx.Type != domain.TypeWithdrawal {
+		return
+	}
+	suspense, err := p.SystemAccount(domain.SystemRoleSuspense, tx.Currency)
+	if err != nil {
+		return
+	}
+	if tx.Type == domain.TypeDeposit && tx.FromAccountID == "" {
+		tx.FromAccountID = suspense
+	}
+	if tx.Type == domain.TypeWithdrawal && tx.ToAccountID == "" {
+		tx.ToAccountID = suspense
+	}
+}
@@ -0,0 +1,87 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const MetadataLinkedTransaction = "linked_transaction_id"
+
+func (p *TransactionProcessor) PostSystemTransaction(ctx context.Context, tx *domain.Transaction, reason string) error {
+	return p.postSystemTransaction(ctx, tx, reason, false)
+}
+
+func (p *TransactionProcessor) PostFundedSystemTransaction(ctx context.Context, tx *domain.Transaction, reason string) error {
+	return p.postSystemTransaction(ctx, tx, reason, true)
+}
+
+func (p *TransactionProcessor) postSystemTransaction(ctx context.Context, tx *domain.Transaction, reason string, requireFunds bool) error {
+	if err := p.validator.ValidateTransaction(tx); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	p.balanceWithSuspense(tx)
+	if err := p.postBalances(ctx, tx, requireFunds); err != nil {
+		return err
+	}
+
+	tx.Status = domain.StatusCompleted
+	tx.SystemPosting = reason
+	if err := p.saveTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	p.emitEvent(ctx, domain.TransactionEvent{
+		TransactionID: tx.ID,
+		Type:          "system_posted",
+		Payload:       map[string]interface{}{"reason": reason, "linked_transaction_id": tx.Metadata[MetadataLinkedTransaction]},
+		Timestamp:     time.Now(),
+	})
+
+	p.logger.InfoContext(ctx, "System transaction posted",
+		slog.String("transaction_id", tx.ID),
+		slog.String("reason", reason),
+		p.logAmount("amount", tx.Amount))
+
+	p.recordMetric("system_postings", 1)
+	return nil
+}
+
+func (p *TransactionProcessor) postBalances(ctx context.Context, tx *domain.Transaction, requireFunds bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if requireFunds && tx.FromAccountID != "" {
+		account, err := p.accountRepo.GetByID(ctx, tx.FromAccountID)
+		if err != nil {
+			return err
+		}
+		if account.Balance < tx.Amount {
+			return repository.ErrInsufficientFunds
+		}
+	}
+
+	switch tx.Type {
+	case domain.TypeDeposit, domain.TypeWithdrawal, domain.TypeTransfer, domain.TypeChargeback:
+		if tx.FromAccountID != "" {
+			if err := p.accountRepo.UpdateBalance(ctx, tx.FromAccountID, -tx.Amount); err != nil {
+				return err
+			}
+		}
+		if tx.ToAccountID != "" {
+			if err := p.accountRepo.UpdateBalance(ctx, tx.ToAccountID, tx.Amount); err != nil {
+				if tx.FromAccountID != "" {
+					p.accountRepo.UpdateBalance(ctx, tx.FromAccountID, tx.Amount)
+				}
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownTransactionType, tx.Type)
+	}
+}
@@ -0,0 +1,146 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const holidayDateLayout = "2006-01-02"
+
+type RiskyHours struct {
+	Start  int
+	End    int
+	Points int
+}
+
+func (w RiskyHours) Contains(hour int) bool {
+	if w.Start <= w.End {
+		return hour >= w.Start && hour < w.End
+	}
+	return hour >= w.Start || hour < w.End
+}
+
+type HolidayCalendar map[string]string
+
+func (c HolidayCalendar) Holiday(at time.Time) (string, bool) {
+	name, ok := c[at.Format(holidayDateLayout)]
+	return name, ok
+}
+
+type TimeModifierConfig struct {
+	RiskyHours    []RiskyHours
+	Holidays      HolidayCalendar
+	HolidayPoints int
+	Location      *time.Location
+}
+
+func DefaultTimeModifierConfig() TimeModifierConfig {
+	return TimeModifierConfig{
+		RiskyHours: []RiskyHours{{Start: 23, End: 6, Points: 15}},
+		Location:   time.Local,
+	}
+}
+
+func ParseRiskyHours(spec string) ([]RiskyHours, error) {
+	var windows []RiskyHours
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		hours, points, found := strings.Cut(part, ":")
+		startRaw, endRaw, ranged := strings.Cut(hours, "-")
+		if !found || !ranged {
+			return nil, fmt.Errorf("invalid risky hours %q, expected start-end:points", part)
+		}
+		start, errStart := strconv.Atoi(startRaw)
+		end, errEnd := strconv.Atoi(endRaw)
+		value, errPoints := strconv.Atoi(points)
+		if errStart != nil || errEnd != nil || errPoints != nil || start < 0 || start > 23 || end < 0 || end > 24 || start == end {
+			return nil, fmt.Errorf("invalid risky hours %q", part)
+		}
+		windows = append(windows, RiskyHours{Start: start, End: end % 24, Points: value})
+	}
+	return windows, nil
+}
+
+func ParseHolidayCalendar(spec string) (HolidayCalendar, error) {
+	calendar := make(HolidayCalendar)
+	for _, part := range strings.Split(spec, ",") {
+		date, name, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(holidayDateLayout, date); err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q: %w", date, err)
+		}
+		calendar[date] = name
+	}
+	return calendar, nil
+}
+
+type accountTimezones struct {
+	accounts  repository.AccountRepository
+	locations sync.Map
+}
+
+func (z *accountTimezones) locationFor(tx *domain.Transaction) (*time.Location, bool) {
+	accountID := tx.FromAccountID
+	if accountID == "" {
+		accountID = tx.ToAccountID
+	}
+	if accountID == "" {
+		return nil, false
+	}
+	account, err := z.accounts.GetByID(context.Background(), accountID)
+	if err != nil || account.Timezone == "" {
+		return nil, false
+	}
+
+	if cached, ok := z.locations.Load(account.Timezone); ok {
+		location := cached.(*time.Location)
+		return location, location != nil
+	}
+	location, err := time.LoadLocation(account.Timezone)
+	if err != nil {
+		location = nil
+	}
+	z.locations.Store(account.Timezone, location)
+	return location, location != nil
+}
+
+func (fd *FraudDetector) WithTimeModifiers(cfg TimeModifierConfig) *FraudDetector {
+	fd.timeModifiers = &cfg
+	return fd
+}
+
+func (fd *FraudDetector) WithAccountTimezones(accounts repository.AccountRepository) *FraudDetector {
+	fd.timezones = &accountTimezones{accounts: accounts}
+	return fd
+}
+
+func (fd *FraudDetector) timeModifierConfig() TimeModifierConfig {
+	if fd.timeModifiers == nil {
+		return DefaultTimeModifierConfig()
+	}
+	return *fd.timeModifiers
+}
+
+func (fd *FraudDetector) localTime(tx *domain.Transaction, cfg TimeModifierConfig) time.Time {
+	now := fd.clock()
+	if fd.timezones != nil {
+		if location, ok := fd.timezones.locationFor(tx); ok {
+			return now.In(location)
+		}
+	}
+	if cfg.Location != nil {
+		return now.In(cfg.Location)
+	}
+	return now
+}
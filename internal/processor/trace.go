@@ -0,0 +1,267 @@
+package processor
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	AuditActionTrace = "decision_trace"
+
+	TraceStageValidation = "validation"
+	TraceStageFraud      = "fraud"
+	TraceStageRules      = "rules"
+	TraceStageDecision   = "decision"
+	TraceStageLimits     = "limits"
+	TraceStageRepository = "repository"
+	TraceStageAudit      = "audit"
+
+	TraceOutcomePassed       = "passed"
+	TraceOutcomeFailed       = "failed"
+	TraceOutcomeMatched      = "matched"
+	TraceOutcomeTriggered    = "triggered"
+	TraceOutcomeNotTriggered = "not_triggered"
+	TraceOutcomeError        = "error"
+
+	traceActor = "processor"
+)
+
+var ErrTraceUnavailable = errors.New("decision tracing is not enabled")
+
+type TraceStep struct {
+	Sequence int               `json:"sequence"`
+	Stage    string            `json:"stage"`
+	Name     string            `json:"name"`
+	Outcome  string            `json:"outcome"`
+	Actor    string            `json:"actor,omitempty"`
+	Details  map[string]string `json:"details,omitempty"`
+	At       time.Time         `json:"at"`
+}
+
+type TransactionTrace struct {
+	TransactionID string                   `json:"transaction_id"`
+	Reference     string                   `json:"reference,omitempty"`
+	Status        domain.TransactionStatus `json:"status"`
+	RiskScore     int                      `json:"risk_score"`
+	Steps         []TraceStep              `json:"steps"`
+}
+
+type traceKey struct{}
+
+type decisionTrace struct {
+	mu    sync.Mutex
+	steps []TraceStep
+}
+
+func (t *decisionTrace) add(stage, name, outcome string, details map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.steps = append(t.steps, TraceStep{
+		Sequence: len(t.steps) + 1,
+		Stage:    stage,
+		Name:     name,
+		Outcome:  outcome,
+		Details:  details,
+		At:       time.Now(),
+	})
+}
+
+func tracing(ctx context.Context) bool {
+	_, ok := ctx.Value(traceKey{}).(*decisionTrace)
+	return ok
+}
+
+func traceStep(ctx context.Context, stage, name, outcome string, details map[string]string) {
+	if trace, ok := ctx.Value(traceKey{}).(*decisionTrace); ok {
+		trace.add(stage, name, outcome, details)
+	}
+}
+
+func traceRepository(ctx context.Context, operation, entityID string, err error) {
+	if !tracing(ctx) {
+		return
+	}
+	if err != nil {
+		traceStep(ctx, TraceStageRepository, operation, TraceOutcomeError, map[string]string{"entity_id": entityID, "error": err.Error()})
+		return
+	}
+	traceStep(ctx, TraceStageRepository, operation, TraceOutcomePassed, map[string]string{"entity_id": entityID})
+}
+
+func traceRule(ctx context.Context, result RuleResult) {
+	if !tracing(ctx) {
+		return
+	}
+	outcome := TraceOutcomeNotTriggered
+	details := map[string]string{"rule_name": result.RuleName, "priority": strconv.Itoa(result.Priority)}
+	if result.Triggered {
+		outcome = TraceOutcomeTriggered
+		details["action"] = result.Action.Type
+	}
+	traceStep(ctx, TraceStageRules, result.RuleID, outcome, details)
+}
+
+func (p *TransactionProcessor) WithDecisionTracing() *TransactionProcessor {
+	p.tracing = true
+	return p
+}
+
+func (p *TransactionProcessor) startTrace(ctx context.Context) (context.Context, *decisionTrace) {
+	if !p.tracing || p.auditRepo == nil {
+		return ctx, nil
+	}
+	trace := &decisionTrace{}
+	return context.WithValue(ctx, traceKey{}, trace), trace
+}
+
+func (p *TransactionProcessor) traceFraud(ctx context.Context, tx *domain.Transaction, skipped []string) {
+	if !tracing(ctx) {
+		return
+	}
+	if tx.Explanation != nil {
+		for _, pattern := range tx.Explanation.FraudPatterns {
+			traceStep(ctx, TraceStageFraud, pattern.Name, TraceOutcomeMatched, map[string]string{
+				"flag":  pattern.Flag,
+				"score": strconv.Itoa(pattern.Weight),
+			})
+		}
+		if tx.Explanation.TimeModifier != 0 {
+			traceStep(ctx, TraceStageFraud, "time_modifier", TraceOutcomeMatched, map[string]string{
+				"score": strconv.Itoa(tx.Explanation.TimeModifier),
+			})
+		}
+	}
+	details := map[string]string{"risk_score": strconv.Itoa(tx.RiskScore)}
+	if len(skipped) > 0 {
+		details["skipped_patterns"] = fmt.Sprint(skipped)
+	}
+	traceStep(ctx, TraceStageFraud, "risk_score", TraceOutcomePassed, details)
+}
+
+func (p *TransactionProcessor) traceDecision(ctx context.Context, tx *domain.Transaction, decision RuleDecision, status domain.TransactionStatus, execErr error) {
+	if !tracing(ctx) {
+		return
+	}
+	if tx.Explanation != nil {
+		for _, check := range tx.Explanation.LimitChecks {
+			outcome := TraceOutcomePassed
+			if !check.Passed {
+				outcome = TraceOutcomeFailed
+			}
+			traceStep(ctx, TraceStageLimits, check.Name, outcome, map[string]string{
+				"limit":  strconv.FormatFloat(check.Limit, 'f', 2, 64),
+				"amount": strconv.FormatFloat(check.Amount, 'f', 2, 64),
+			})
+		}
+	}
+
+	details := map[string]string{
+		"status":     string(status),
+		"risk_score": strconv.Itoa(tx.RiskScore),
+	}
+	if decision.DecidingRuleID != "" {
+		details["decided_by_rule"] = decision.DecidingRuleID
+	}
+	outcome := TraceOutcomePassed
+	if execErr != nil {
+		outcome = TraceOutcomeFailed
+		details["error"] = execErr.Error()
+	}
+	traceStep(ctx, TraceStageDecision, "status", outcome, details)
+}
+
+func (p *TransactionProcessor) flushTrace(ctx context.Context, tx *domain.Transaction, trace *decisionTrace) {
+	if trace == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	trace.mu.Lock()
+	steps := slices.Clone(trace.steps)
+	trace.mu.Unlock()
+
+	for _, step := range steps {
+		entry := domain.NewAuditEntry(AuditActionTrace, AuditEntityTransaction, tx.ID, traceActor, step.Outcome)
+		entry.CreatedAt = step.At
+		for k, v := range step.Details {
+			entry.Details[k] = v
+		}
+		entry.Details["stage"] = step.Stage
+		entry.Details["name"] = step.Name
+		entry.Details["sequence"] = strconv.Itoa(step.Sequence)
+
+		err := repository.SaveWithFreshID(
+			func() error { return p.auditRepo.Save(ctx, entry) },
+			func() { entry.ID = domain.NewID() },
+		)
+		if err != nil {
+			p.logger.WarnContext(ctx, "Failed to record decision trace",
+				slog.String("transaction_id", tx.ID),
+				slog.String("error", err.Error()))
+			return
+		}
+	}
+}
+
+func (p *TransactionProcessor) Trace(ctx context.Context, transactionID string) (*TransactionTrace, error) {
+	if !p.tracing || p.auditRepo == nil {
+		return nil, ErrTraceUnavailable
+	}
+
+	entries, err := p.auditRepo.GetByEntity(ctx, AuditEntityTransaction, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := p.txRepo.GetByID(ctx, transactionID)
+	if err != nil && (!errors.Is(err, repository.ErrNotFound) || len(entries) == 0) {
+		return nil, err
+	}
+
+	trace := &TransactionTrace{TransactionID: transactionID, Steps: make([]TraceStep, 0, len(entries))}
+	if tx != nil {
+		trace.Reference = tx.Reference
+		trace.Status = tx.Status
+		trace.RiskScore = tx.RiskScore
+	}
+
+	for _, entry := range entries {
+		details := make(map[string]string, len(entry.Details))
+		for k, v := range entry.Details {
+			details[k] = v
+		}
+		step := TraceStep{Outcome: entry.Reason, Actor: entry.Actor, Details: details, At: entry.CreatedAt}
+		if entry.Action == AuditActionTrace {
+			step.Stage, step.Name = details["stage"], details["name"]
+			step.Sequence, _ = strconv.Atoi(details["sequence"])
+			delete(details, "stage")
+			delete(details, "name")
+			delete(details, "sequence")
+		} else {
+			step.Stage, step.Name, step.Outcome = TraceStageAudit, entry.Action, details["outcome"]
+			if entry.Reason != "" {
+				details["reason"] = entry.Reason
+			}
+		}
+		if len(step.Details) == 0 {
+			step.Details = nil
+		}
+		trace.Steps = append(trace.Steps, step)
+	}
+
+	slices.SortStableFunc(trace.Steps, func(a, b TraceStep) int {
+		if c := a.At.Compare(b.At); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+	return trace, nil
+}
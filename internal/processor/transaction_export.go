@@ -0,0 +1,141 @@
+package processor
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+const (
+	DirectionDebit  = "debit"
+	DirectionCredit = "credit"
+)
+
+type TransactionExportRecord struct {
+	TransactionID string    `json:"transaction_id"`
+	Reference     string    `json:"reference"`
+	CreatedAt     time.Time `json:"created_at"`
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	FromAccountID string    `json:"from_account_id,omitempty"`
+	ToAccountID   string    `json:"to_account_id,omitempty"`
+	Direction     string    `json:"direction,omitempty"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Description   string    `json:"description,omitempty"`
+	PurposeCode   string    `json:"purpose_code,omitempty"`
+	Memo          string    `json:"memo,omitempty"`
+	BalanceAfter  *float64  `json:"balance_after,omitempty"`
+}
+
+func NewTransactionExportRecord(tx *domain.Transaction, accountID string) TransactionExportRecord {
+	record := TransactionExportRecord{
+		TransactionID: tx.ID,
+		Reference:     tx.Reference,
+		CreatedAt:     tx.CreatedAt,
+		Type:          string(tx.Type),
+		Status:        string(tx.Status),
+		FromAccountID: tx.FromAccountID,
+		ToAccountID:   tx.ToAccountID,
+		Amount:        tx.Amount,
+		Currency:      tx.Currency,
+		Description:   tx.Description,
+		PurposeCode:   tx.Metadata[MetadataPurposeCode],
+		Memo:          tx.Metadata[MetadataMemo],
+	}
+
+	switch accountID {
+	case "":
+	case tx.FromAccountID:
+		record.Direction = DirectionDebit
+	default:
+		record.Direction = DirectionCredit
+	}
+
+	return record
+}
+
+func (p *TransactionProcessor) ExportTransactions(ctx context.Context, w io.Writer, filter repository.TransactionFilter, format string) error {
+	writer, err := newTransactionExportWriter(w, format, false)
+	if err != nil {
+		return err
+	}
+	for tx, err := range p.readRepo.Stream(ctx, filter) {
+		if err != nil {
+			return err
+		}
+		if err := writer.write(NewTransactionExportRecord(tx, filter.AccountID)); err != nil {
+			return err
+		}
+	}
+	return writer.flush()
+}
+
+type transactionExportWriter struct {
+	csv         *csv.Writer
+	json        *json.Encoder
+	withBalance bool
+}
+
+func newTransactionExportWriter(w io.Writer, format string, withBalance bool) (*transactionExportWriter, error) {
+	switch format {
+	case DecisionFormatJSONL:
+		return &transactionExportWriter{json: json.NewEncoder(w), withBalance: withBalance}, nil
+	case DecisionFormatCSV:
+		writer := &transactionExportWriter{csv: csv.NewWriter(w), withBalance: withBalance}
+		header := []string{
+			"transaction_id", "reference", "created_at", "type", "status",
+			"from_account_id", "to_account_id", "direction", "amount", "currency", "description",
+			"purpose_code", "memo",
+		}
+		if withBalance {
+			header = append(header, "balance_after")
+		}
+		return writer, writer.csv.Write(header)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedExportFormat, format)
+	}
+}
+
+func (e *transactionExportWriter) write(record TransactionExportRecord) error {
+	if e.json != nil {
+		return e.json.Encode(record)
+	}
+
+	row := []string{
+		record.TransactionID,
+		record.Reference,
+		record.CreatedAt.Format(time.RFC3339),
+		record.Type,
+		record.Status,
+		record.FromAccountID,
+		record.ToAccountID,
+		record.Direction,
+		strconv.FormatFloat(record.Amount, 'f', 2, 64),
+		record.Currency,
+		record.Description,
+		record.PurposeCode,
+		record.Memo,
+	}
+	if e.withBalance && record.BalanceAfter != nil {
+		row = append(row, strconv.FormatFloat(*record.BalanceAfter, 'f', 2, 64))
+	}
+	return e.csv.Write(row)
+}
+
+func (e *transactionExportWriter) flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"finance_manager/internal/domain"
 	"finance_manager/internal/repository"
 	"finance_manager/pkg/validator"
@@ -11,17 +12,58 @@ import (
 	"time"
 )
 
+var (
+	ErrInvalidReference       = errors.New("invalid transaction reference")
+	ErrValidationFailed       = errors.New("validation failed")
+	ErrUnknownTransactionType = errors.New("unknown transaction type")
+	ErrAccountRequired        = errors.New("account is required")
+	ErrAccountInactive        = errors.New("account is not active")
+	ErrCurrencyMismatch       = errors.New("currency mismatch")
+	ErrLimitExceeded          = errors.New("limit exceeded")
+	ErrProcessingPanicked     = errors.New("transaction processing panicked")
+)
+
 type TransactionProcessor struct {
 	txRepo        repository.TransactionRepository
+	readRepo      repository.TransactionRepository
 	accountRepo   repository.AccountRepository
 	ruleRepo      repository.RuleRepository
 	fraudDetector *FraudDetector
 	ruleEngine    *RuleEngine
 	validator     *validator.TransactionValidator
 	eventCh       chan domain.TransactionEvent
-	workerPool    chan struct{}
+	workerPool    *WorkerPool
+	shards        *ShardedPool
+	transferGraph *TransferGraph
+	profiles      *ProfileTracker
+	fastPath      *trustedFastPath
+	chargebacks   *ChargebackTracker
+	products      repository.ProductRepository
+	auditRepo     repository.AuditRepository
+	positivePay   repository.PositivePayRepository
+	systemAccts   map[string]bool
+	shadow        *shadowMode
+	spending      *budgetEnforcement
+	thresholds    *limitThresholds
+	amountTokens  *amountTokenization
+	degraded      *degradedMode
+	admission     *admissionControl
+	limits        LimitService
+	maintenance   *maintenanceWindows
+	sandbox       *SandboxConfig
+	corridors     []Corridor
+	purposeCodes  []PurposeCode
+	controls      repository.SpendingControlsRepository
+	suspense      *suspenseQueue
+	depositRetry  *depositRetry
+	publishers    []EventPublisher
+	hooks         eventHooks
+	routingHints  bool
+	tracing       bool
 	mu            sync.RWMutex
+	overrideMu    sync.Mutex
 	metrics       map[string]int
+	budgets       StageBudgets
 	logger        *slog.Logger
 }
 
@@ -31,78 +73,424 @@ func NewTransactionProcessor(
 	ruleRepo repository.RuleRepository,
 	maxWorkers int,
 ) *TransactionProcessor {
+	fraudDetector := NewFraudDetector().
+		WithStructuringDetection(txRepo, DefaultStructuringConfig()).
+		WithAccountTimezones(accountRepo)
+
 	return &TransactionProcessor{
 		txRepo:        txRepo,
+		readRepo:      txRepo,
 		accountRepo:   accountRepo,
 		ruleRepo:      ruleRepo,
-		fraudDetector: NewFraudDetector(),
+		fraudDetector: fraudDetector,
 		ruleEngine:    NewRuleEngine(ruleRepo, nil),
 		validator:     validator.NewTransactionValidator(),
+		limits:        NewRepositoryLimitService(txRepo),
 		eventCh:       make(chan domain.TransactionEvent, 1000),
-		workerPool:    make(chan struct{}, maxWorkers),
+		workerPool:    NewWorkerPool(DefaultWorkerPoolConfig(maxWorkers), nil),
 		metrics:       make(map[string]int),
+		budgets:       DefaultStageBudgets(),
 		logger:        slog.Default(),
 	}
 }
 
 func (p *TransactionProcessor) ProcessTransaction(ctx context.Context, tx *domain.Transaction) error {
-	if err := p.validator.ValidateTransaction(tx); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+	return p.process(ctx, tx, func(ctx context.Context) error {
+		return p.saveTransaction(ctx, tx)
+	})
+}
+
+func (p *TransactionProcessor) process(ctx context.Context, tx *domain.Transaction, persist func(context.Context) error) error {
+	ctx, cancel := p.withDeadline(ctx)
+	defer cancel()
+	ctx, trace := p.startTrace(ctx)
+	defer p.flushTrace(ctx, tx, trace)
+
+	snapshot := tx.Clone()
+	err := runStage(ctx, StageValidation, p.budgets.Validation, func(ctx context.Context) error {
+		return p.validator.ValidateTransaction(snapshot)
+	})
+	if err != nil {
+		traceStep(ctx, TraceStageValidation, "transaction", TraceOutcomeFailed, map[string]string{"error": err.Error()})
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	traceStep(ctx, TraceStageValidation, "transaction", TraceOutcomePassed, nil)
+	if err := p.checkMaintenance(ctx, tx); err != nil {
+		return err
+	}
+
+	shadow := p.startShadow(ctx, tx)
+
+	fastPath := p.qualifiesForFastPath(ctx, tx)
+	var skippedPatterns []string
+	if fastPath {
+		skippedPatterns = p.fastPath.cfg.SkipPatterns
+		tx.AddMetadata(MetadataFastPath, FastPathTrustedBeneficiary)
 	}
 
-	riskScore, flags := p.fraudDetector.AnalyzeTransaction(tx)
-	tx.RiskScore = riskScore
-	tx.FraudFlags = flags
+	snapshot = tx.Clone()
+	err = runStage(ctx, StageFraud, p.budgets.Fraud, func(ctx context.Context) error {
+		snapshot.RiskScore, snapshot.FraudFlags = p.fraudDetector.ExplainTransaction(snapshot, skippedPatterns...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("fraud analysis failed: %w", err)
+	}
+	tx.RiskScore = snapshot.RiskScore
+	tx.FraudFlags = snapshot.FraudFlags
+	tx.Explanation = snapshot.Explanation
+	p.traceFraud(ctx, tx, skippedPatterns)
+
+	if compliance := complianceFlags(tx.FraudFlags); len(compliance) > 0 {
+		tx.AddMetadata("compliance_review", "required")
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "compliance_flagged",
+			Payload:       map[string]interface{}{"flags": compliance, "account_id": tx.FromAccountID, "counterparty_id": tx.ToAccountID},
+			Timestamp:     time.Now(),
+		})
+	}
 
-	_, err := p.ruleEngine.EvaluateRules(ctx, tx)
+	var ruleResults []RuleResult
+	snapshot = tx.Clone()
+	err = runStage(ctx, StageRules, p.budgets.Rules, func(ctx context.Context) error {
+		var err error
+		ruleResults, err = p.ruleEngine.EvaluateRules(ctx, snapshot)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("rule evaluation failed: %w", err)
 	}
 
-	if riskScore > 80 {
-		tx.Status = domain.StatusSuspicious
-		p.eventCh <- domain.TransactionEvent{
+	if fastPath {
+		ruleResults = p.fastPath.filterRules(ruleResults)
+	}
+
+	decision := p.ruleEngine.Resolve(ruleResults)
+	tx.ExplainRules(decision.Explain(ruleResults))
+	if err := p.ruleEngine.ApplyDecision(ctx, decision, tx); err != nil {
+		p.logger.WarnContext(ctx, "Rule actions partially applied",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+	p.attachRoutingHints(ctx, tx)
+
+	status := resolveStatus(decision, tx.RiskScore, func() bool { return p.holdForPositivePay(ctx, tx) })
+	status, err = p.applySandbox(ctx, tx, status)
+	if err != nil {
+		p.traceDecision(ctx, tx, decision, status, err)
+		return err
+	}
+	var queued bool
+	var parkedErr error
+	switch status {
+	case domain.StatusSuspicious:
+		p.emitEvent(ctx, domain.TransactionEvent{
 			TransactionID: tx.ID,
 			Type:          "transaction_suspicious",
-			Payload:       map[string]interface{}{"risk_score": riskScore, "flags": flags},
+			Payload:       map[string]interface{}{"risk_score": tx.RiskScore, "flags": tx.FraudFlags},
 			Timestamp:     time.Now(),
+		})
+	case domain.StatusCompleted:
+		if p.queueIfDegraded(ctx, tx) {
+			queued = true
+			status = domain.StatusPending
+			break
+		}
+		err := runStageInline(ctx, StageExecution, p.budgets.Execution, func(ctx context.Context) error {
+			return p.executeTransaction(ctx, tx)
+		})
+		p.observeExecution(ctx, err)
+		if p.parkDeposit(ctx, tx, err) {
+			parkedErr = err
+			status = domain.StatusPending
+			break
 		}
-	} else if riskScore > 50 {
-		tx.Status = domain.StatusPending
-	} else {
-		if err := p.executeTransaction(ctx, tx); err != nil {
+		if err != nil {
+			p.traceDecision(ctx, tx, decision, status, err)
 			return err
 		}
-		tx.Status = domain.StatusCompleted
 	}
+	tx.Status = status
+	p.traceDecision(ctx, tx, decision, status, nil)
 
-	if err := p.txRepo.Save(ctx, tx); err != nil {
+	var budgetWarning *BudgetWarning
+	if tx.Status == domain.StatusCompleted {
+		budgetWarning = p.checkBudget(ctx, tx)
+	}
+
+	persistStart := time.Now()
+	err = runStageInline(ctx, StagePersist, p.budgets.Persist, persist)
+	p.observeRepositoryLatency(time.Since(persistStart))
+	traceRepository(ctx, "transactions.save", tx.ID, err)
+	if err != nil {
 		return err
 	}
 
+	if tx.Status == domain.StatusCompleted {
+		p.observeCompleted(ctx, tx)
+		p.warnBudgetExceeded(ctx, budgetWarning)
+		p.notifyLimitThresholds(ctx, tx)
+		p.queueSuspenseItem(ctx, tx)
+	}
+	if tx.Status == domain.StatusSuspicious {
+		p.publishTransaction(ctx, domain.EventTransactionSuspicious, tx)
+	}
+	if queued {
+		reason := "degraded_mode"
+		if tx.Metadata[MetadataMaintenanceWindow] != "" {
+			reason = "maintenance"
+		}
+		p.degraded.enqueue(tx.ID)
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "transaction_queued",
+			Payload:       map[string]interface{}{"reason": reason},
+			Timestamp:     time.Now(),
+		})
+	}
+	if parkedErr != nil {
+		p.depositRetry.park(tx.ID, parkedErr, time.Now())
+		p.emitEvent(ctx, domain.TransactionEvent{
+			TransactionID: tx.ID,
+			Type:          "deposit_parked",
+			Payload:       map[string]interface{}{"error": parkedErr.Error()},
+			Timestamp:     time.Now(),
+		})
+	}
+
+	shadow.complete(tx)
 	p.recordMetric("transactions_processed", 1)
 	return nil
 }
 
+func resolveStatus(decision RuleDecision, riskScore int, hold func() bool) domain.TransactionStatus {
+	switch {
+	case decision.Blocked():
+		return domain.StatusFailed
+	case hold(), decision.RequiresApproval():
+		return domain.StatusPending
+	case riskScore > 80:
+		return domain.StatusSuspicious
+	case riskScore > 50:
+		return domain.StatusPending
+	default:
+		return domain.StatusCompleted
+	}
+}
+
+// claimForExecution moves a stored transaction from status to processing.
+// Every path that re-executes an already persisted transaction claims it
+// first, so only one of them can run it; claimed is false if another won.
+func (p *TransactionProcessor) claimForExecution(ctx context.Context, id string, status domain.TransactionStatus) (claimed bool, err error) {
+	err = p.txRepo.TransitionStatus(ctx, id, status, domain.StatusProcessing)
+	if errors.Is(err, repository.ErrTransactionConflict) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// releaseClaim returns a claimed transaction that was not executed to status.
+func (p *TransactionProcessor) releaseClaim(ctx context.Context, id string, status domain.TransactionStatus) {
+	if err := p.txRepo.TransitionStatus(ctx, id, domain.StatusProcessing, status); err != nil {
+		p.logger.ErrorContext(ctx, "Failed to release claimed transaction",
+			slog.String("transaction_id", id),
+			slog.String("status", string(status)),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (p *TransactionProcessor) saveTransaction(ctx context.Context, tx *domain.Transaction) error {
+	return repository.SaveWithFreshID(
+		func() error { return p.txRepo.Save(ctx, tx) },
+		func() {
+			previous := tx.ID
+			tx.ID = domain.NewID()
+			p.logger.WarnContext(ctx, "Transaction ID collision, regenerated ID",
+				slog.String("previous_id", previous),
+				slog.String("transaction_id", tx.ID))
+		},
+	)
+}
+
+func (p *TransactionProcessor) observeCompleted(ctx context.Context, tx *domain.Transaction) {
+	if p.transferGraph != nil {
+		p.transferGraph.AddTransfer(tx)
+	}
+	if p.profiles != nil {
+		if err := p.profiles.Observe(ctx, tx); err != nil {
+			p.logger.WarnContext(ctx, "Failed to update account profile",
+				slog.String("transaction_id", tx.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+	if p.chargebacks != nil {
+		p.chargebacks.ObservePayment(tx)
+	}
+	p.chargeFees(ctx, tx)
+	p.chargeCorridorFee(ctx, tx)
+	p.recordLimitUsage(ctx, tx)
+	p.publishTransaction(ctx, domain.EventTransactionCompleted, tx)
+}
+
+// SubmitTransaction queues tx on the worker pool. done, if set, is called
+// exactly once with the outcome, including when processing panics. The job
+// runs with the caller's ctx, so request values and deadlines carry over, and
+// is cancelled as well if the pool shuts down before it finishes.
+func (p *TransactionProcessor) SubmitTransaction(ctx context.Context, tx *domain.Transaction, queue string, done func(error)) error {
+	job := func(poolCtx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		defer context.AfterFunc(poolCtx, cancel)()
+
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%w: %v", ErrProcessingPanicked, r)
+			}
+			if err != nil {
+				p.logger.ErrorContext(ctx, "Async transaction processing failed",
+					slog.String("transaction_id", tx.ID),
+					slog.String("queue", queue),
+					slog.String("error", err.Error()))
+			}
+			if done != nil {
+				done(err)
+			}
+		}()
+		err = p.ProcessTransaction(ctx, tx)
+	}
+	if p.shards != nil {
+		return p.shards.Submit(shardKey(tx), queue, job)
+	}
+	return p.workerPool.Submit(queue, job)
+}
+
+func (p *TransactionProcessor) RunQueued(ctx context.Context, queue string, fn func(ctx context.Context) error) error {
+	done := make(chan error, 1)
+	err := p.workerPool.Submit(queue, func(poolCtx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		defer context.AfterFunc(poolCtx, cancel)()
+
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%w: %v", ErrProcessingPanicked, r)
+			}
+			done <- err
+		}()
+		err = fn(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *TransactionProcessor) ProcessBatch(ctx context.Context, txs []*domain.Transaction) []error {
+	errs := make([]error, len(txs))
+
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		wg.Add(1)
+		i := i
+		err := p.SubmitTransaction(ctx, tx, QueueBatch, func(err error) {
+			errs[i] = err
+			wg.Done()
+		})
+		if err != nil {
+			errs[i] = err
+			wg.Done()
+		}
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func (p *TransactionProcessor) Events() <-chan domain.TransactionEvent {
+	return p.eventCh
+}
+
+func (p *TransactionProcessor) emitEvent(ctx context.Context, event domain.TransactionEvent) {
+	p.runHooks(ctx, event)
+	select {
+	case p.eventCh <- event:
+	default:
+		p.logger.WarnContext(ctx, "Event channel full, dropping event",
+			slog.String("transaction_id", event.TransactionID),
+			slog.String("event_type", event.Type))
+	}
+}
+
+func (p *TransactionProcessor) WithFraudDetector(fd *FraudDetector) *TransactionProcessor {
+	p.fraudDetector = fd
+	return p
+}
+
+func (p *TransactionProcessor) WithReadReplica(replica repository.TransactionRepository) *TransactionProcessor {
+	p.readRepo = replica
+	return p
+}
+
+func (p *TransactionProcessor) FraudDetector() *FraudDetector {
+	return p.fraudDetector
+}
+
+func (p *TransactionProcessor) RuleEngine() *RuleEngine {
+	return p.ruleEngine
+}
+
+func (p *TransactionProcessor) WorkerPool() *WorkerPool {
+	return p.workerPool
+}
+
+func (p *TransactionProcessor) Shutdown(ctx context.Context) error {
+	if p.shards != nil {
+		return errors.Join(p.shards.Stop(ctx), p.workerPool.Stop(ctx))
+	}
+	return p.workerPool.Stop(ctx)
+}
+
 func (p *TransactionProcessor) GetTransaction(ctx context.Context, transactionID string) (*domain.Transaction, error) {
 	return p.txRepo.GetByID(ctx, transactionID)
 }
 
+func (p *TransactionProcessor) GetTransactionByReference(ctx context.Context, reference string) (*domain.Transaction, error) {
+	normalized, ok := domain.NormalizeReference(reference)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReference, reference)
+	}
+	return p.txRepo.GetByReference(ctx, normalized)
+}
+
 func (p *TransactionProcessor) executeTransaction(ctx context.Context, tx *domain.Transaction) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	switch tx.Type {
-	case domain.TypeTransfer:
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	switch {
+	case tx.Type == domain.TypeTransfer && len(tx.Legs) > 0:
+		return p.processMultiLegTransfer(ctx, tx)
+	case tx.Type == domain.TypeTransfer:
 		return p.processTransfer(ctx, tx)
-	case domain.TypeDeposit:
+	case tx.Type == domain.TypeDeposit:
 		return p.processDeposit(ctx, tx)
-	case domain.TypeWithdrawal:
+	case tx.Type == domain.TypeWithdrawal:
 		return p.processWithdrawal(ctx, tx)
 	default:
-		return fmt.Errorf("unknown transaction type: %s", tx.Type)
+		return fmt.Errorf("%w: %s", ErrUnknownTransactionType, tx.Type)
 	}
-	return nil
 }
 
 func (p *TransactionProcessor) GetMetrics() map[string]int {
@@ -122,34 +510,14 @@ func (p *TransactionProcessor) processTransfer(ctx context.Context, tx *domain.T
 		slog.String("transaction_id", tx.ID),
 		slog.String("from_account", tx.FromAccountID),
 		slog.String("to_account", tx.ToAccountID),
-		slog.Float64("amount", tx.Amount))
+		p.logAmount("amount", tx.Amount))
 
-	fromAccount, err := p.accountRepo.GetByID(ctx, tx.FromAccountID)
-	if err != nil {
-		return fmt.Errorf("failed to get from account: %w", err)
-	}
-
-	toAccount, err := p.accountRepo.GetByID(ctx, tx.ToAccountID)
+	fromAccount, toAccount, err := p.checkTransfer(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("failed to get to account: %w", err)
-	}
-
-	if fromAccount.Currency != toAccount.Currency {
-		return fmt.Errorf("currency mismatch: %s != %s", fromAccount.Currency, toAccount.Currency)
-	}
-
-	if fromAccount.Status != domain.AccountActive {
-		return fmt.Errorf("from account is not active: %s", fromAccount.Status)
-	}
-	if toAccount.Status != domain.AccountActive {
-		return fmt.Errorf("to account is not active: %s", toAccount.Status)
-	}
-
-	if fromAccount.Balance < tx.Amount {
-		return repository.ErrInsufficientFunds
+		return err
 	}
 
-	if err := p.checkAccountLimits(ctx, fromAccount, tx); err != nil {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
@@ -160,12 +528,12 @@ func (p *TransactionProcessor) processTransfer(ctx context.Context, tx *domain.T
 	fromAccount.LastActivityAt = now
 	toAccount.LastActivityAt = now
 
-	if err := p.accountRepo.Update(ctx, fromAccount); err != nil {
+	if err := p.updateAccount(ctx, fromAccount); err != nil {
 		return fmt.Errorf("failed to update from account: %w", err)
 	}
-	if err := p.accountRepo.Update(ctx, toAccount); err != nil {
+	if err := p.updateAccount(ctx, toAccount); err != nil {
 		fromAccount.Balance += tx.Amount
-		p.accountRepo.Update(ctx, fromAccount)
+		p.updateAccount(ctx, fromAccount)
 		return fmt.Errorf("failed to update to account: %w", err)
 	}
 
@@ -178,29 +546,21 @@ func (p *TransactionProcessor) processDeposit(ctx context.Context, tx *domain.Tr
 	p.logger.InfoContext(ctx, "Processing deposit",
 		slog.String("transaction_id", tx.ID),
 		slog.String("to_account", tx.ToAccountID),
-		slog.Float64("amount", tx.Amount))
-
-	if tx.ToAccountID == "" {
-		return fmt.Errorf("to account is required for deposit")
-	}
+		p.logAmount("amount", tx.Amount))
 
-	toAccount, err := p.accountRepo.GetByID(ctx, tx.ToAccountID)
+	toAccount, err := p.checkDeposit(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("failed to get to account: %w", err)
-	}
-
-	if toAccount.Status != domain.AccountActive {
-		return fmt.Errorf("account is not active: %s", toAccount.Status)
+		return err
 	}
 
-	if err := p.checkDepositLimits(ctx, toAccount, tx); err != nil {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
 	toAccount.Balance += tx.Amount
 	toAccount.LastActivityAt = time.Now()
 
-	if err := p.accountRepo.Update(ctx, toAccount); err != nil {
+	if err := p.updateAccount(ctx, toAccount); err != nil {
 		return fmt.Errorf("failed to update account: %w", err)
 	}
 
@@ -213,33 +573,21 @@ func (p *TransactionProcessor) processWithdrawal(ctx context.Context, tx *domain
 	p.logger.InfoContext(ctx, "Processing withdrawal",
 		slog.String("transaction_id", tx.ID),
 		slog.String("from_account", tx.FromAccountID),
-		slog.Float64("amount", tx.Amount))
-
-	if tx.FromAccountID == "" {
-		return fmt.Errorf("from account is required for withdrawal")
-	}
+		p.logAmount("amount", tx.Amount))
 
-	fromAccount, err := p.accountRepo.GetByID(ctx, tx.FromAccountID)
+	fromAccount, err := p.checkWithdrawal(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("failed to get from account: %w", err)
-	}
-
-	if fromAccount.Status != domain.AccountActive {
-		return fmt.Errorf("account is not active: %s", fromAccount.Status)
-	}
-
-	if fromAccount.Balance < tx.Amount {
-		return repository.ErrInsufficientFunds
+		return err
 	}
 
-	if err := p.checkWithdrawalLimits(ctx, fromAccount, tx); err != nil {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
 	fromAccount.Balance -= tx.Amount
 	fromAccount.LastActivityAt = time.Now()
 
-	if err := p.accountRepo.Update(ctx, fromAccount); err != nil {
+	if err := p.updateAccount(ctx, fromAccount); err != nil {
 		return fmt.Errorf("failed to update account: %w", err)
 	}
 
@@ -248,67 +596,132 @@ func (p *TransactionProcessor) processWithdrawal(ctx context.Context, tx *domain
 	return nil
 }
 
-func (p *TransactionProcessor) checkAccountLimits(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
-	dailyVolume, err := p.txRepo.GetDailyVolume(ctx, account.ID, time.Now())
+// checkTransfer runs every precondition of a transfer without moving funds.
+func (p *TransactionProcessor) checkTransfer(ctx context.Context, tx *domain.Transaction) (*domain.Account, *domain.Account, error) {
+	fromAccount, err := p.accountRepo.GetByID(ctx, tx.FromAccountID)
 	if err != nil {
-		return fmt.Errorf("failed to get daily volume: %w", err)
+		return nil, nil, fmt.Errorf("failed to get from account: %w", err)
 	}
 
-	if account.DailyLimit > 0 && (dailyVolume+tx.Amount) > account.DailyLimit {
-		return fmt.Errorf("daily limit exceeded: %.2f/%.2f", dailyVolume+tx.Amount, account.DailyLimit)
+	toAccount, err := p.accountRepo.GetByID(ctx, tx.ToAccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get to account: %w", err)
 	}
 
-	now := time.Now()
-	monthlyVolume, err := p.txRepo.GetMonthlyVolume(ctx, account.ID, now.Year(), now.Month())
+	if fromAccount.Currency != toAccount.Currency {
+		return nil, nil, fmt.Errorf("%w: %s != %s", ErrCurrencyMismatch, fromAccount.Currency, toAccount.Currency)
+	}
+
+	if fromAccount.Status != domain.AccountActive {
+		return nil, nil, fmt.Errorf("from %w: %s", ErrAccountInactive, fromAccount.Status)
+	}
+	if toAccount.Status != domain.AccountActive {
+		return nil, nil, fmt.Errorf("to %w: %s", ErrAccountInactive, toAccount.Status)
+	}
+
+	if err := p.checkCorridor(ctx, fromAccount, toAccount, tx); err != nil {
+		return nil, nil, err
+	}
+
+	if err := p.checkPaymentPurpose(tx); err != nil {
+		return nil, nil, err
+	}
+
+	if err := p.checkSpendingControls(ctx, fromAccount, tx, toAccount); err != nil {
+		return nil, nil, err
+	}
+
+	terms, err := p.accountTerms(ctx, fromAccount, tx.Type)
 	if err != nil {
-		return fmt.Errorf("failed to get monthly volume: %w", err)
+		return nil, nil, err
 	}
 
-	if account.MonthlyLimit > 0 && (monthlyVolume+tx.Amount) > account.MonthlyLimit {
-		return fmt.Errorf("monthly limit exceeded: %.2f/%.2f", monthlyVolume+tx.Amount, account.MonthlyLimit)
+	if fromAccount.Balance+terms.overdraft < tx.Amount+p.feesDue(tx, terms) {
+		return nil, nil, repository.ErrInsufficientFunds
 	}
 
-	return nil
+	if err := p.limits.CheckTransfer(ctx, fromAccount, LimitTerms{DailyLimit: terms.dailyLimit, MonthlyLimit: terms.monthlyLimit}, tx); err != nil {
+		return nil, nil, err
+	}
+
+	return fromAccount, toAccount, nil
 }
 
-func (p *TransactionProcessor) checkDepositLimits(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
-	const maxDepositAmount = 50000.0
-	if tx.Amount > maxDepositAmount {
-		return fmt.Errorf("deposit amount exceeds maximum limit: %.2f/%.2f", tx.Amount, maxDepositAmount)
+func (p *TransactionProcessor) checkDeposit(ctx context.Context, tx *domain.Transaction) (*domain.Account, error) {
+	if tx.ToAccountID == "" {
+		return nil, fmt.Errorf("to %w for deposit", ErrAccountRequired)
 	}
 
-	return nil
-}
+	if err := p.checkPaymentPurpose(tx); err != nil {
+		return nil, err
+	}
 
-func (p *TransactionProcessor) checkWithdrawalLimits(ctx context.Context, account *domain.Account, tx *domain.Transaction) error {
-	dailyWithdrawal, err := p.getDailyWithdrawal(ctx, account.ID, time.Now())
+	toAccount, err := p.accountRepo.GetByID(ctx, tx.ToAccountID)
+	if errors.Is(err, repository.ErrNotFound) && p.routeToSuspense(tx) {
+		toAccount, err = p.accountRepo.GetByID(ctx, tx.ToAccountID)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get daily withdrawal: %w", err)
+		return nil, fmt.Errorf("failed to get to account: %w", err)
 	}
 
-	const dailyWithdrawalLimit = 5000.0
-	if (dailyWithdrawal + tx.Amount) > dailyWithdrawalLimit {
-		return fmt.Errorf("daily withdrawal limit exceeded: %.2f/%.2f", dailyWithdrawal+tx.Amount, dailyWithdrawalLimit)
+	if toAccount.Status != domain.AccountActive {
+		return nil, fmt.Errorf("%w: %s", ErrAccountInactive, toAccount.Status)
 	}
 
-	return nil
+	if _, err := p.accountTerms(ctx, toAccount, tx.Type); err != nil {
+		return nil, err
+	}
+
+	if err := p.limits.CheckDeposit(ctx, toAccount, tx); err != nil {
+		return nil, err
+	}
+
+	return toAccount, nil
 }
 
-func (p *TransactionProcessor) getDailyWithdrawal(ctx context.Context, accountID string, date time.Time) (float64, error) {
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+func (p *TransactionProcessor) checkWithdrawal(ctx context.Context, tx *domain.Transaction) (*domain.Account, error) {
+	if tx.FromAccountID == "" {
+		return nil, fmt.Errorf("from %w for withdrawal", ErrAccountRequired)
+	}
+
+	if err := p.checkPaymentPurpose(tx); err != nil {
+		return nil, err
+	}
 
-	transactions, err := p.txRepo.GetByPeriod(ctx, startOfDay, endOfDay)
+	fromAccount, err := p.accountRepo.GetByID(ctx, tx.FromAccountID)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("failed to get from account: %w", err)
 	}
 
-	var totalWithdrawal float64
-	for _, tx := range transactions {
-		if tx.FromAccountID == accountID && tx.Type == domain.TypeWithdrawal && tx.Status == domain.StatusCompleted {
-			totalWithdrawal += tx.Amount
-		}
+	if fromAccount.Status != domain.AccountActive {
+		return nil, fmt.Errorf("%w: %s", ErrAccountInactive, fromAccount.Status)
 	}
 
-	return totalWithdrawal, nil
+	if err := p.checkSpendingControls(ctx, fromAccount, tx); err != nil {
+		return nil, err
+	}
+
+	terms, err := p.accountTerms(ctx, fromAccount, tx.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Balance+terms.overdraft < tx.Amount+p.feesDue(tx, terms) {
+		return nil, repository.ErrInsufficientFunds
+	}
+
+	if err := p.limits.CheckWithdrawal(ctx, fromAccount, tx); err != nil {
+		return nil, err
+	}
+
+	return fromAccount, nil
+}
+
+func (p *TransactionProcessor) updateAccount(ctx context.Context, account *domain.Account) error {
+	err := p.accountRepo.Update(ctx, account)
+	traceRepository(ctx, "accounts.update", account.ID, err)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) && !errors.Is(err, repository.ErrTransactionConflict) {
+		return fmt.Errorf("%w: %w", ErrDependencyUnavailable, err)
+	}
+	return err
 }
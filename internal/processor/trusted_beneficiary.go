@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"log/slog"
+	"slices"
+)
+
+const (
+	MetadataFastPath           = "fast_path"
+	FastPathTrustedBeneficiary = "trusted_beneficiary"
+)
+
+type TrustedFastPathConfig struct {
+	MaxAmount    float64
+	SkipPatterns []string
+	SkipActions  []string
+}
+
+func DefaultTrustedFastPathConfig() TrustedFastPathConfig {
+	return TrustedFastPathConfig{
+		MaxAmount: 1000,
+		SkipPatterns: []string{
+			"frequent_transactions",
+			FlagRoundAmount,
+			FlagAmountDeviation,
+			FlagUnusualHour,
+			FlagNewCounterparty,
+		},
+		SkipActions: []string{"require_approval"},
+	}
+}
+
+type trustedFastPath struct {
+	beneficiaries repository.BeneficiaryRepository
+	cfg           TrustedFastPathConfig
+}
+
+func (p *TransactionProcessor) WithTrustedBeneficiaries(beneficiaries repository.BeneficiaryRepository, cfg TrustedFastPathConfig) *TransactionProcessor {
+	p.fastPath = &trustedFastPath{beneficiaries: beneficiaries, cfg: cfg}
+	return p
+}
+
+func (p *TransactionProcessor) qualifiesForFastPath(ctx context.Context, tx *domain.Transaction) bool {
+	if p.fastPath == nil || tx.Type != domain.TypeTransfer || tx.Amount >= p.fastPath.cfg.MaxAmount {
+		return false
+	}
+
+	trusted, err := p.fastPath.beneficiaries.IsTrusted(ctx, tx.FromAccountID, tx.ToAccountID)
+	if err != nil {
+		p.logger.WarnContext(ctx, "Failed to check trusted beneficiary",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+		return false
+	}
+	return trusted
+}
+
+func (f *trustedFastPath) filterRules(results []RuleResult) []RuleResult {
+	filtered := make([]RuleResult, 0, len(results))
+	for _, result := range results {
+		if !slices.Contains(f.cfg.SkipActions, result.Action.Type) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,265 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+const (
+	QueueRealtime  = "realtime"
+	QueueBatch     = "batch"
+	QueueScheduled = "scheduled"
+	QueueReplay    = "replay"
+)
+
+var (
+	ErrQueueFull    = errors.New("worker pool queue is full")
+	ErrUnknownQueue = errors.New("unknown worker pool queue")
+	ErrPoolStopped  = errors.New("worker pool is stopped")
+)
+
+type Job func(ctx context.Context)
+
+type QueueConfig struct {
+	Name     string
+	Priority int
+	Capacity int
+}
+
+type WorkerPoolConfig struct {
+	Workers int
+	Queues  []QueueConfig
+}
+
+type PoolStats struct {
+	Workers int            `json:"workers"`
+	Busy    int            `json:"busy"`
+	Queued  map[string]int `json:"queued"`
+}
+
+type PoolObserver interface {
+	ObserveWorkerPool(busy, workers int)
+	ObserveQueueDepth(queue string, depth int)
+}
+
+type WorkerPool struct {
+	mu       sync.Mutex
+	queues   []*jobQueue
+	byName   map[string]*jobQueue
+	ready    chan struct{}
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	ctx      context.Context
+	cancel   context.CancelFunc
+	workers  int
+	busy     int
+	stopped  bool
+	observer PoolObserver
+	logger   *slog.Logger
+}
+
+type jobQueue struct {
+	name     string
+	priority int
+	capacity int
+	jobs     []Job
+}
+
+func DefaultWorkerPoolConfig(workers int) WorkerPoolConfig {
+	return WorkerPoolConfig{
+		Workers: workers,
+		Queues: []QueueConfig{
+			{Name: QueueRealtime, Priority: 100, Capacity: 1000},
+			{Name: QueueBatch, Priority: 50, Capacity: 10000},
+			{Name: QueueScheduled, Priority: 30, Capacity: 10000},
+			{Name: QueueReplay, Priority: 10, Capacity: 10000},
+		},
+	}
+}
+
+func NewWorkerPool(cfg WorkerPoolConfig, logger *slog.Logger) *WorkerPool {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &WorkerPool{
+		byName:  make(map[string]*jobQueue),
+		stop:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+		workers: cfg.Workers,
+		logger:  logger,
+	}
+
+	totalCapacity := 0
+	for _, qc := range cfg.Queues {
+		q := &jobQueue{name: qc.Name, priority: qc.Priority, capacity: qc.Capacity}
+		pool.queues = append(pool.queues, q)
+		pool.byName[q.name] = q
+		totalCapacity += qc.Capacity
+	}
+
+	sort.SliceStable(pool.queues, func(i, j int) bool {
+		return pool.queues[i].priority > pool.queues[j].priority
+	})
+
+	pool.ready = make(chan struct{}, totalCapacity)
+
+	for i := 0; i < pool.workers; i++ {
+		pool.wg.Add(1)
+		go pool.worker(i)
+	}
+
+	return pool
+}
+
+func (p *WorkerPool) SetObserver(observer PoolObserver) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observer = observer
+	p.observeLocked()
+}
+
+func (p *WorkerPool) Submit(queue string, job Job) error {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return ErrPoolStopped
+	}
+
+	q, exists := p.byName[queue]
+	if !exists {
+		p.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrUnknownQueue, queue)
+	}
+	if len(q.jobs) >= q.capacity {
+		p.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrQueueFull, queue)
+	}
+
+	q.jobs = append(q.jobs, job)
+	p.observeLocked()
+	p.mu.Unlock()
+
+	p.ready <- struct{}{}
+	return nil
+}
+
+func (p *WorkerPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := PoolStats{
+		Workers: p.workers,
+		Busy:    p.busy,
+		Queued:  make(map[string]int, len(p.queues)),
+	}
+	for _, q := range p.queues {
+		stats.Queued[q.name] = len(q.jobs)
+	}
+	return stats
+}
+
+func (p *WorkerPool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return nil
+	}
+	p.stopped = true
+	p.mu.Unlock()
+
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		p.logger.Info("Worker pool stopped")
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+func (p *WorkerPool) worker(id int) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ready:
+			p.runNext(id)
+		case <-p.stop:
+			for p.runNext(id) {
+			}
+			return
+		}
+	}
+}
+
+func (p *WorkerPool) runNext(workerID int) bool {
+	p.mu.Lock()
+	var job Job
+	var queue string
+	for _, q := range p.queues {
+		if len(q.jobs) > 0 {
+			job = q.jobs[0]
+			q.jobs[0] = nil
+			q.jobs = q.jobs[1:]
+			queue = q.name
+			break
+		}
+	}
+	if job == nil {
+		p.mu.Unlock()
+		return false
+	}
+	p.busy++
+	p.observeLocked()
+	p.mu.Unlock()
+
+	p.execute(job, queue, workerID)
+	return true
+}
+
+func (p *WorkerPool) execute(job Job, queue string, workerID int) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("Worker pool job panicked",
+				slog.Int("worker_id", workerID),
+				slog.String("queue", queue),
+				slog.Any("panic", r))
+		}
+
+		p.mu.Lock()
+		p.busy--
+		p.observeLocked()
+		p.mu.Unlock()
+	}()
+
+	job(p.ctx)
+}
+
+func (p *WorkerPool) observeLocked() {
+	if p.observer == nil {
+		return
+	}
+	p.observer.ObserveWorkerPool(p.busy, p.workers)
+	for _, q := range p.queues {
+		p.observer.ObserveQueueDepth(q.name, len(q.jobs))
+	}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+)
+
+const MaxIDAttempts = 3
+
+func SaveWithFreshID(save func() error, regenerate func()) error {
+	var err error
+	for attempt := 0; attempt < MaxIDAttempts; attempt++ {
+		if err = save(); !errors.Is(err, ErrIDCollision) {
+			return err
+		}
+		regenerate()
+	}
+	return err
+}
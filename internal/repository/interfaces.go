@@ -4,31 +4,101 @@ import (
 	"context"
 	"errors"
 	"finance_manager/internal/domain"
+	"iter"
 	"time"
 )
 
+type TransactionFilter struct {
+	AccountID string
+	Type      domain.TransactionType
+	Status    domain.TransactionStatus
+	From      time.Time
+	To        time.Time
+}
+
+func (f TransactionFilter) Matches(tx *domain.Transaction) bool {
+	if f.AccountID != "" && tx.FromAccountID != f.AccountID && tx.ToAccountID != f.AccountID {
+		return false
+	}
+	if (f.Type != "" && tx.Type != f.Type) || (f.Status != "" && tx.Status != f.Status) {
+		return false
+	}
+	if !f.From.IsZero() && tx.CreatedAt.Before(f.From) {
+		return false
+	}
+	return f.To.IsZero() || !tx.CreatedAt.After(f.To)
+}
+
 type TransactionRepository interface {
 	Save(ctx context.Context, transaction *domain.Transaction) error
+	SaveAll(ctx context.Context, transactions []*domain.Transaction) error
 	GetByID(ctx context.Context, id string) (*domain.Transaction, error)
+	GetByReference(ctx context.Context, reference string) (*domain.Transaction, error)
 	GetByAccountID(ctx context.Context, accountID string, limit, offset int) ([]*domain.Transaction, error)
 	GetByStatus(ctx context.Context, status domain.TransactionStatus) ([]*domain.Transaction, error)
 	GetByPeriod(ctx context.Context, from, to time.Time) ([]*domain.Transaction, error)
+	Stream(ctx context.Context, filter TransactionFilter) iter.Seq2[*domain.Transaction, error]
+	Iterate(ctx context.Context, filter TransactionFilter, fn func(*domain.Transaction) error) error
 	UpdateStatus(ctx context.Context, id string, status domain.TransactionStatus) error
+	// TransitionStatus sets the status only if the transaction is still in from,
+	// and otherwise fails with ErrTransactionConflict.
+	TransitionStatus(ctx context.Context, id string, from, to domain.TransactionStatus) error
 	GetDailyVolume(ctx context.Context, accountID string, date time.Time) (float64, error)
 	GetMonthlyVolume(ctx context.Context, accountID string, year int, month time.Month) (float64, error)
 }
 
+type SearchHit[T any] struct {
+	Item  T
+	Score int
+}
+
+type TransactionSearcher interface {
+	Search(ctx context.Context, query string, limit int) ([]SearchHit[*domain.Transaction], error)
+}
+
+type AccountSearcher interface {
+	Search(ctx context.Context, query string, limit int) ([]SearchHit[*domain.Account], error)
+}
+
+type DisputeSearcher interface {
+	Search(ctx context.Context, query string, limit int) ([]SearchHit[*domain.Dispute], error)
+}
+
 type AccountRepository interface {
 	Save(ctx context.Context, account *domain.Account) error
 	GetByID(ctx context.Context, id string) (*domain.Account, error)
 	GetByUserID(ctx context.Context, userID string) ([]*domain.Account, error)
 	Update(ctx context.Context, account *domain.Account) error
 	UpdateBalance(ctx context.Context, id string, amount float64) error
+	ApplyBalanceUpdates(ctx context.Context, updates []domain.BalanceUpdate) error
 	UpdateStatus(ctx context.Context, id string, status domain.AccountStatus) error
 	GetAllActive(ctx context.Context) ([]*domain.Account, error)
 	GetByRiskCategory(ctx context.Context, category string) ([]*domain.Account, error)
 }
 
+type TransactionSnapshot interface {
+	TakenAt() time.Time
+	GetByID(ctx context.Context, id string) (*domain.Transaction, error)
+	Stream(ctx context.Context, filter TransactionFilter) iter.Seq2[*domain.Transaction, error]
+	Iterate(ctx context.Context, filter TransactionFilter, fn func(*domain.Transaction) error) error
+	Release()
+}
+
+type AccountSnapshot interface {
+	TakenAt() time.Time
+	GetByID(ctx context.Context, id string) (*domain.Account, error)
+	GetAll(ctx context.Context) ([]*domain.Account, error)
+	Release()
+}
+
+type TransactionSnapshotter interface {
+	SnapshotTransactions(ctx context.Context) (TransactionSnapshot, error)
+}
+
+type AccountSnapshotter interface {
+	SnapshotAccounts(ctx context.Context) (AccountSnapshot, error)
+}
+
 type RuleRepository interface {
 	Save(ctx context.Context, rule *domain.Rule) error
 	GetByID(ctx context.Context, id string) (*domain.Rule, error)
@@ -38,12 +108,226 @@ type RuleRepository interface {
 	Update(ctx context.Context, rule *domain.Rule) error
 	Deactivate(ctx context.Context, id string) error
 	GetByPriority(ctx context.Context, minPriority, maxPriority int) ([]*domain.Rule, error)
+	ReplaceAll(ctx context.Context, rules []*domain.Rule) error
+}
+
+type RuleGroupRepository interface {
+	Save(ctx context.Context, group *domain.RuleGroup) error
+	GetByID(ctx context.Context, id string) (*domain.RuleGroup, error)
+	GetAll(ctx context.Context) ([]*domain.RuleGroup, error)
+	Update(ctx context.Context, group *domain.RuleGroup) error
+	SetActive(ctx context.Context, id string, active bool) error
+}
+
+type LookupTableRepository interface {
+	Save(ctx context.Context, table *domain.LookupTable) error
+	GetByName(ctx context.Context, name string) (*domain.LookupTable, error)
+	GetAll(ctx context.Context) ([]*domain.LookupTable, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type ProfileRepository interface {
+	Get(ctx context.Context, accountID string) (*domain.AccountProfile, error)
+	Save(ctx context.Context, profile *domain.AccountProfile) error
+}
+
+type AuditRepository interface {
+	Save(ctx context.Context, entry *domain.AuditEntry) error
+	GetByEntity(ctx context.Context, entityType, entityID string) ([]*domain.AuditEntry, error)
+}
+
+type NotificationPreferenceRepository interface {
+	Save(ctx context.Context, prefs *domain.NotificationPreferences) error
+	GetByUser(ctx context.Context, userID string) (*domain.NotificationPreferences, error)
+}
+
+type DeliveryRepository interface {
+	Save(ctx context.Context, delivery *domain.NotificationDelivery) error
+	GetByMessage(ctx context.Context, messageID string) ([]*domain.NotificationDelivery, error)
+	GetByUser(ctx context.Context, userID string) ([]*domain.NotificationDelivery, error)
+}
+
+type ConsentRepository interface {
+	Save(ctx context.Context, record *domain.ConsentRecord) error
+	GetCurrent(ctx context.Context, userID, channel string, purpose domain.ConsentPurpose) (*domain.ConsentRecord, error)
+	GetByUser(ctx context.Context, userID string) ([]*domain.ConsentRecord, error)
+	GetHistory(ctx context.Context, userID string) ([]*domain.ConsentRecord, error)
+}
+
+type ScheduledNotificationRepository interface {
+	Save(ctx context.Context, notification *domain.ScheduledNotification) error
+	GetByID(ctx context.Context, id string) (*domain.ScheduledNotification, error)
+	Update(ctx context.Context, notification *domain.ScheduledNotification) error
+	GetDue(ctx context.Context, before time.Time) ([]*domain.ScheduledNotification, error)
+}
+
+type InboxRepository interface {
+	Save(ctx context.Context, notification *domain.InboxNotification) error
+	GetByUser(ctx context.Context, userID string, unreadOnly bool) ([]*domain.InboxNotification, error)
+	MarkRead(ctx context.Context, userID, id string, at time.Time) (*domain.InboxNotification, error)
+	MarkAllRead(ctx context.Context, userID string, at time.Time) (int, error)
+}
+
+type LimitChangeRepository interface {
+	Save(ctx context.Context, change *domain.LimitChange) error
+	GetByID(ctx context.Context, id string) (*domain.LimitChange, error)
+	Update(ctx context.Context, change *domain.LimitChange) error
+	GetDue(ctx context.Context, before time.Time) ([]*domain.LimitChange, error)
+	GetByAccountID(ctx context.Context, accountID string) ([]*domain.LimitChange, error)
+}
+
+type BudgetRepository interface {
+	Save(ctx context.Context, budget *domain.Budget) error
+	GetByAccountID(ctx context.Context, accountID string) ([]*domain.Budget, error)
+	GetByCategory(ctx context.Context, accountID, category string) (*domain.Budget, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type BeneficiaryRepository interface {
+	Save(ctx context.Context, beneficiary *domain.TrustedBeneficiary) error
+	GetByID(ctx context.Context, id string) (*domain.TrustedBeneficiary, error)
+	GetByAccountID(ctx context.Context, accountID string) ([]*domain.TrustedBeneficiary, error)
+	Update(ctx context.Context, beneficiary *domain.TrustedBeneficiary) error
+	IsTrusted(ctx context.Context, accountID, beneficiaryAccountID string) (bool, error)
+}
+
+type DelegationRepository interface {
+	Save(ctx context.Context, delegation *domain.Delegation) error
+	GetByID(ctx context.Context, id string) (*domain.Delegation, error)
+	GetByAccountID(ctx context.Context, accountID string) ([]*domain.Delegation, error)
+	Update(ctx context.Context, delegation *domain.Delegation) error
+}
+
+type ImpersonationRepository interface {
+	Save(ctx context.Context, session *domain.ImpersonationSession) error
+	GetByID(ctx context.Context, id string) (*domain.ImpersonationSession, error)
+	Update(ctx context.Context, session *domain.ImpersonationSession) error
+	List(ctx context.Context) ([]*domain.ImpersonationSession, error)
+}
+
+type AccountAliasRepository interface {
+	Save(ctx context.Context, alias *domain.AccountAlias) error
+	Resolve(ctx context.Context, aliasType domain.AliasType, value string) (*domain.AccountAlias, error)
+	GetByAccountID(ctx context.Context, accountID string) ([]*domain.AccountAlias, error)
+	Delete(ctx context.Context, aliasType domain.AliasType, value string) error
+}
+
+type DisputeRepository interface {
+	Save(ctx context.Context, dispute *domain.Dispute) error
+	GetByID(ctx context.Context, id string) (*domain.Dispute, error)
+	GetByTransactionID(ctx context.Context, transactionID string) ([]*domain.Dispute, error)
+	Update(ctx context.Context, dispute *domain.Dispute) error
+}
+
+type ChargebackRepository interface {
+	Save(ctx context.Context, chargeback *domain.Chargeback) error
+	GetByID(ctx context.Context, id string) (*domain.Chargeback, error)
+	GetByTransactionID(ctx context.Context, transactionID string) (*domain.Chargeback, error)
+	Update(ctx context.Context, chargeback *domain.Chargeback) error
+	Delete(ctx context.Context, id string) error
+}
+
+type WebhookEventRepository interface {
+	Save(ctx context.Context, event *domain.WebhookEvent) error
+	GetByID(ctx context.Context, id string) (*domain.WebhookEvent, error)
+	List(ctx context.Context, from, to time.Time) ([]*domain.WebhookEvent, error)
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+type PartnerRepository interface {
+	Save(ctx context.Context, partner *domain.Partner) error
+	Update(ctx context.Context, partner *domain.Partner) error
+	GetByID(ctx context.Context, id string) (*domain.Partner, error)
+	GetByAPIKey(ctx context.Context, apiKey string) (*domain.Partner, error)
+	GetAll(ctx context.Context) ([]*domain.Partner, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type QuotaRepository interface {
+	SaveQuota(ctx context.Context, quota *domain.APIKeyQuota) error
+	GetQuota(ctx context.Context, apiKey string) (*domain.APIKeyQuota, error)
+	AddUsage(ctx context.Context, apiKey, period string, requests, transactions int64, volume float64) (*domain.APIKeyUsage, error)
+	GetUsage(ctx context.Context, apiKey, period string) (*domain.APIKeyUsage, error)
+}
+
+type IngestionRepository interface {
+	Claim(ctx context.Context, key string, lease time.Duration) (*domain.IngestionRecord, error)
+	Release(ctx context.Context, key string) error
+	Save(ctx context.Context, record *domain.IngestionRecord) error
+	GetByKey(ctx context.Context, key string) (*domain.IngestionRecord, error)
+}
+
+type ProcessedMessageRepository interface {
+	Save(ctx context.Context, msg *domain.ProcessedMessage) error
+	GetByMessageID(ctx context.Context, messageID string) (*domain.ProcessedMessage, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type ApprovalRepository interface {
+	Save(ctx context.Context, approval *domain.ApprovalRequest) error
+	GetByID(ctx context.Context, id string) (*domain.ApprovalRequest, error)
+	Update(ctx context.Context, approval *domain.ApprovalRequest) error
+	GetByStatus(ctx context.Context, status domain.ApprovalStatus) ([]*domain.ApprovalRequest, error)
+}
+
+type PositivePayRepository interface {
+	Save(ctx context.Context, list *domain.PositivePayList) error
+	GetByAccountID(ctx context.Context, accountID string) (*domain.PositivePayList, error)
+	Delete(ctx context.Context, accountID string) error
+}
+
+type SuspenseRepository interface {
+	Save(ctx context.Context, item *domain.SuspenseItem) error
+	GetByTransactionID(ctx context.Context, transactionID string) (*domain.SuspenseItem, error)
+	Update(ctx context.Context, item *domain.SuspenseItem) error
+	List(ctx context.Context, status domain.SuspenseStatus) ([]*domain.SuspenseItem, error)
+}
+
+type EODSnapshotRepository interface {
+	Save(ctx context.Context, snapshot *domain.EODSnapshot) error
+	GetByID(ctx context.Context, id string) (*domain.EODSnapshot, error)
+	List(ctx context.Context) ([]*domain.EODSnapshot, error)
+}
+
+type SpendingControlsRepository interface {
+	Save(ctx context.Context, controls *domain.SpendingControls) error
+	GetByAccountID(ctx context.Context, accountID string) (*domain.SpendingControls, error)
+}
+
+type InstallmentPlanRepository interface {
+	Save(ctx context.Context, plan *domain.InstallmentPlan) error
+	GetByID(ctx context.Context, id string) (*domain.InstallmentPlan, error)
+	Update(ctx context.Context, plan *domain.InstallmentPlan) error
+}
+
+type ReconciliationRepository interface {
+	Save(ctx context.Context, report *domain.ReconciliationReport) error
+	GetByID(ctx context.Context, id string) (*domain.ReconciliationReport, error)
+	GetAll(ctx context.Context) ([]*domain.ReconciliationReport, error)
+	Update(ctx context.Context, report *domain.ReconciliationReport) error
+}
+
+type PayoutBatchRepository interface {
+	Save(ctx context.Context, batch *domain.PayoutBatch) error
+	GetByID(ctx context.Context, id string) (*domain.PayoutBatch, error)
+	GetByStatus(ctx context.Context, statuses ...domain.PayoutBatchStatus) ([]*domain.PayoutBatch, error)
+	Update(ctx context.Context, batch *domain.PayoutBatch) error
+}
+
+type ProductRepository interface {
+	Save(ctx context.Context, product *domain.Product) error
+	GetByID(ctx context.Context, id string) (*domain.Product, error)
+	GetAll(ctx context.Context) ([]*domain.Product, error)
+	Update(ctx context.Context, product *domain.Product) error
 }
 
 var (
 	ErrNotFound            = errors.New("not found")
 	ErrDuplicate           = errors.New("duplicate entry")
+	ErrIDCollision         = errors.New("generated id collision")
 	ErrInsufficientFunds   = errors.New("insufficient funds")
 	ErrAccountSuspended    = errors.New("account suspended")
 	ErrTransactionConflict = errors.New("transaction conflict")
+	ErrStopIteration       = errors.New("stop iteration")
+	ErrSnapshotUnsupported = errors.New("snapshot reads not supported")
 )
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+	"iter"
+)
+
+func IterateSeq[T any](seq iter.Seq2[T, error], fn func(T) error) error {
+	for item, err := range seq {
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -4,21 +4,24 @@ import (
 	"context"
 	"finance_manager/internal/domain"
 	"finance_manager/internal/repository"
+	"finance_manager/pkg/search"
 	"fmt"
 	"sync"
 	"time"
 )
 
 type AccountRepository struct {
-	mu        sync.RWMutex
-	accounts  map[string]*domain.Account
-	userIndex map[string][]string
+	mu          sync.RWMutex
+	accounts    map[string]*domain.Account
+	userIndex   map[string][]string
+	searchIndex *search.Index
 }
 
 func NewAccountRepository() *AccountRepository {
 	return &AccountRepository{
-		accounts:  make(map[string]*domain.Account),
-		userIndex: make(map[string][]string),
+		accounts:    make(map[string]*domain.Account),
+		userIndex:   make(map[string][]string),
+		searchIndex: search.New(),
 	}
 }
 
@@ -30,11 +33,17 @@ func (r *AccountRepository) Save(ctx context.Context, account *domain.Account) e
 		return fmt.Errorf("%w: account %s", repository.ErrDuplicate, account.ID)
 	}
 
-	account.CreatedAt = time.Now()
-	account.LastActivityAt = time.Now()
+	now := time.Now()
+	if account.CreatedAt.IsZero() {
+		account.CreatedAt = now
+	}
+	if account.LastActivityAt.IsZero() {
+		account.LastActivityAt = now
+	}
 	r.accounts[account.ID] = account
 
 	r.userIndex[account.UserID] = append(r.userIndex[account.UserID], account.ID)
+	r.searchIndex.Put(account.ID, account.ID, account.UserID)
 
 	return nil
 }
@@ -47,7 +56,8 @@ func (r *AccountRepository) GetByID(ctx context.Context, id string) (*domain.Acc
 	if !exists {
 		return nil, fmt.Errorf("%w: account %s", repository.ErrNotFound, id)
 	}
-	return account, nil
+	copied := *account
+	return &copied, nil
 }
 
 func (r *AccountRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Account, error) {
@@ -79,10 +89,26 @@ func (r *AccountRepository) Update(ctx context.Context, account *domain.Account)
 
 	account.LastActivityAt = time.Now()
 	r.accounts[account.ID] = account
+	r.searchIndex.Put(account.ID, account.ID, account.UserID)
 
 	return nil
 }
 
+func (r *AccountRepository) Search(ctx context.Context, query string, limit int) ([]repository.SearchHit[*domain.Account], error) {
+	hits := r.searchIndex.Search(query, limit)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]repository.SearchHit[*domain.Account], 0, len(hits))
+	for _, hit := range hits {
+		if account, exists := r.accounts[hit.ID]; exists {
+			result = append(result, repository.SearchHit[*domain.Account]{Item: account, Score: hit.Score})
+		}
+	}
+	return result, nil
+}
+
 func (r *AccountRepository) UpdateBalance(ctx context.Context, id string, amount float64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -99,6 +125,26 @@ func (r *AccountRepository) UpdateBalance(ctx context.Context, id string, amount
 	return nil
 }
 
+func (r *AccountRepository) ApplyBalanceUpdates(ctx context.Context, updates []domain.BalanceUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, update := range updates {
+		if _, exists := r.accounts[update.AccountID]; !exists {
+			return fmt.Errorf("%w: account %s", repository.ErrNotFound, update.AccountID)
+		}
+	}
+
+	now := time.Now()
+	for _, update := range updates {
+		account := r.accounts[update.AccountID]
+		account.Balance += update.Amount
+		account.LastActivityAt = now
+	}
+
+	return nil
+}
+
 func (r *AccountRepository) UpdateStatus(ctx context.Context, id string, status domain.AccountStatus) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
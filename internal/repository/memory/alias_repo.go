@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type aliasKey struct {
+	aliasType domain.AliasType
+	value     string
+}
+
+type AccountAliasRepository struct {
+	mu      sync.RWMutex
+	aliases map[aliasKey]*domain.AccountAlias
+}
+
+func NewAccountAliasRepository() *AccountAliasRepository {
+	return &AccountAliasRepository{
+		aliases: make(map[aliasKey]*domain.AccountAlias),
+	}
+}
+
+func (r *AccountAliasRepository) Save(ctx context.Context, alias *domain.AccountAlias) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := aliasKey{alias.Type, alias.Value}
+	if existing, exists := r.aliases[key]; exists {
+		return fmt.Errorf("%w: %s alias %s already maps to account %s", repository.ErrDuplicate, alias.Type, alias.Value, existing.AccountID)
+	}
+
+	if alias.CreatedAt.IsZero() {
+		alias.CreatedAt = time.Now()
+	}
+	copied := *alias
+	r.aliases[key] = &copied
+
+	return nil
+}
+
+func (r *AccountAliasRepository) Resolve(ctx context.Context, aliasType domain.AliasType, value string) (*domain.AccountAlias, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alias, exists := r.aliases[aliasKey{aliasType, value}]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s alias %s", repository.ErrNotFound, aliasType, value)
+	}
+	copied := *alias
+	return &copied, nil
+}
+
+func (r *AccountAliasRepository) GetByAccountID(ctx context.Context, accountID string) ([]*domain.AccountAlias, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.AccountAlias{}
+	for _, alias := range r.aliases {
+		if alias.AccountID == accountID {
+			copied := *alias
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return result[i].Value < result[j].Value
+	})
+
+	return result, nil
+}
+
+func (r *AccountAliasRepository) Delete(ctx context.Context, aliasType domain.AliasType, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := aliasKey{aliasType, value}
+	if _, exists := r.aliases[key]; !exists {
+		return fmt.Errorf("%w: %s alias %s", repository.ErrNotFound, aliasType, value)
+	}
+	delete(r.aliases, key)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ApprovalRepository struct {
+	mu        sync.RWMutex
+	approvals map[string]*domain.ApprovalRequest
+}
+
+func NewApprovalRepository() *ApprovalRepository {
+	return &ApprovalRepository{
+		approvals: make(map[string]*domain.ApprovalRequest),
+	}
+}
+
+func (r *ApprovalRepository) Save(ctx context.Context, approval *domain.ApprovalRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.approvals[approval.ID]; exists {
+		return fmt.Errorf("%w: %w: approval %s", repository.ErrDuplicate, repository.ErrIDCollision, approval.ID)
+	}
+
+	approval.UpdatedAt = time.Now()
+	r.approvals[approval.ID] = approval.Clone()
+
+	return nil
+}
+
+func (r *ApprovalRepository) GetByID(ctx context.Context, id string) (*domain.ApprovalRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	approval, exists := r.approvals[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: approval %s", repository.ErrNotFound, id)
+	}
+	return approval.Clone(), nil
+}
+
+func (r *ApprovalRepository) Update(ctx context.Context, approval *domain.ApprovalRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.approvals[approval.ID]; !exists {
+		return fmt.Errorf("%w: approval %s", repository.ErrNotFound, approval.ID)
+	}
+
+	approval.UpdatedAt = time.Now()
+	r.approvals[approval.ID] = approval.Clone()
+
+	return nil
+}
+
+func (r *ApprovalRepository) GetByStatus(ctx context.Context, status domain.ApprovalStatus) ([]*domain.ApprovalRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.ApprovalRequest
+	for _, approval := range r.approvals {
+		if status == "" || approval.Status == status {
+			result = append(result, approval.Clone())
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+)
+
+type AuditRepository struct {
+	mu      sync.RWMutex
+	entries []*domain.AuditEntry
+	ids     map[string]bool
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		ids: make(map[string]bool),
+	}
+}
+
+func (r *AuditRepository) Save(ctx context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ids[entry.ID] {
+		return fmt.Errorf("%w: %w: audit entry %s", repository.ErrDuplicate, repository.ErrIDCollision, entry.ID)
+	}
+
+	r.ids[entry.ID] = true
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+func (r *AuditRepository) GetByEntity(ctx context.Context, entityType, entityID string) ([]*domain.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.AuditEntry
+	for _, entry := range r.entries {
+		if entry.EntityType == entityType && entry.EntityID == entityID {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	benchmarkTransactions = 1_000_000
+	benchmarkAccounts     = 10_000
+)
+
+var (
+	benchmarkRepoOnce sync.Once
+	benchmarkRepo     *TransactionRepository
+	benchmarkDay      = time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+)
+
+func loadedTransactionRepository(b *testing.B) *TransactionRepository {
+	b.Helper()
+	benchmarkRepoOnce.Do(func() {
+		ctx := context.Background()
+		repo := NewTransactionRepository()
+		start := benchmarkDay.AddDate(0, -2, 0)
+		for i := 0; i < benchmarkTransactions; i++ {
+			tx := &domain.Transaction{
+				ID:            fmt.Sprintf("tx-%07d", i),
+				Type:          domain.TypeTransfer,
+				Status:        domain.StatusCompleted,
+				Amount:        float64(i%500) + 1,
+				Currency:      "USD",
+				FromAccountID: fmt.Sprintf("acc-%d", i%benchmarkAccounts),
+				ToAccountID:   fmt.Sprintf("acc-%d", (i+1)%benchmarkAccounts),
+				CreatedAt:     start.Add(time.Duration(i) * 10 * time.Second),
+			}
+			_ = repo.Save(ctx, tx)
+		}
+		benchmarkRepo = repo
+	})
+	return benchmarkRepo
+}
+
+func BenchmarkTransactionRepository_GetDailyVolume1M(b *testing.B) {
+	repo := loadedTransactionRepository(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetDailyVolume(ctx, fmt.Sprintf("acc-%d", i%benchmarkAccounts), benchmarkDay); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTransactionRepository_GetMonthlyVolume1M(b *testing.B) {
+	repo := loadedTransactionRepository(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetMonthlyVolume(ctx, fmt.Sprintf("acc-%d", i%benchmarkAccounts), benchmarkDay.Year(), benchmarkDay.Month()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTransactionRepository_GetDailyVolume1MParallel(b *testing.B) {
+	repo := loadedTransactionRepository(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if _, err := repo.GetDailyVolume(ctx, fmt.Sprintf("acc-%d", i%benchmarkAccounts), benchmarkDay); err != nil {
+				b.Fatal(err)
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type BeneficiaryRepository struct {
+	mu            sync.RWMutex
+	beneficiaries map[string]*domain.TrustedBeneficiary
+}
+
+func NewBeneficiaryRepository() *BeneficiaryRepository {
+	return &BeneficiaryRepository{
+		beneficiaries: make(map[string]*domain.TrustedBeneficiary),
+	}
+}
+
+func (r *BeneficiaryRepository) Save(ctx context.Context, beneficiary *domain.TrustedBeneficiary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.beneficiaries[beneficiary.ID]; exists {
+		return fmt.Errorf("%w: %w: beneficiary %s", repository.ErrDuplicate, repository.ErrIDCollision, beneficiary.ID)
+	}
+	for _, existing := range r.beneficiaries {
+		if existing.AccountID == beneficiary.AccountID &&
+			existing.BeneficiaryAccountID == beneficiary.BeneficiaryAccountID &&
+			existing.Status != domain.BeneficiaryRevoked {
+			return fmt.Errorf("%w: beneficiary %s for account %s", repository.ErrDuplicate, beneficiary.BeneficiaryAccountID, beneficiary.AccountID)
+		}
+	}
+
+	beneficiary.UpdatedAt = time.Now()
+	copied := *beneficiary
+	r.beneficiaries[beneficiary.ID] = &copied
+
+	return nil
+}
+
+func (r *BeneficiaryRepository) GetByID(ctx context.Context, id string) (*domain.TrustedBeneficiary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	beneficiary, exists := r.beneficiaries[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: beneficiary %s", repository.ErrNotFound, id)
+	}
+	copied := *beneficiary
+	return &copied, nil
+}
+
+func (r *BeneficiaryRepository) GetByAccountID(ctx context.Context, accountID string) ([]*domain.TrustedBeneficiary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.TrustedBeneficiary
+	for _, beneficiary := range r.beneficiaries {
+		if beneficiary.AccountID == accountID {
+			copied := *beneficiary
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *BeneficiaryRepository) Update(ctx context.Context, beneficiary *domain.TrustedBeneficiary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.beneficiaries[beneficiary.ID]; !exists {
+		return fmt.Errorf("%w: beneficiary %s", repository.ErrNotFound, beneficiary.ID)
+	}
+
+	beneficiary.UpdatedAt = time.Now()
+	copied := *beneficiary
+	r.beneficiaries[beneficiary.ID] = &copied
+
+	return nil
+}
+
+func (r *BeneficiaryRepository) IsTrusted(ctx context.Context, accountID, beneficiaryAccountID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, beneficiary := range r.beneficiaries {
+		if beneficiary.AccountID == accountID &&
+			beneficiary.BeneficiaryAccountID == beneficiaryAccountID &&
+			beneficiary.Status == domain.BeneficiaryTrusted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type BudgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[string]*domain.Budget
+}
+
+func NewBudgetRepository() *BudgetRepository {
+	return &BudgetRepository{
+		budgets: make(map[string]*domain.Budget),
+	}
+}
+
+func (r *BudgetRepository) Save(ctx context.Context, budget *domain.Budget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.budgets[budget.ID]; exists {
+		return fmt.Errorf("%w: %w: budget %s", repository.ErrDuplicate, repository.ErrIDCollision, budget.ID)
+	}
+	for _, existing := range r.budgets {
+		if existing.AccountID == budget.AccountID && existing.Category == budget.Category {
+			return fmt.Errorf("%w: budget for category %s on account %s", repository.ErrDuplicate, budget.Category, budget.AccountID)
+		}
+	}
+
+	budget.UpdatedAt = time.Now()
+	copied := *budget
+	r.budgets[budget.ID] = &copied
+
+	return nil
+}
+
+func (r *BudgetRepository) GetByAccountID(ctx context.Context, accountID string) ([]*domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Budget
+	for _, budget := range r.budgets {
+		if budget.AccountID == accountID {
+			copied := *budget
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Category < result[j].Category
+	})
+
+	return result, nil
+}
+
+func (r *BudgetRepository) GetByCategory(ctx context.Context, accountID, category string) (*domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, budget := range r.budgets {
+		if budget.AccountID == accountID && budget.Category == category {
+			copied := *budget
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: budget for category %s on account %s", repository.ErrNotFound, category, accountID)
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.budgets[id]; !exists {
+		return fmt.Errorf("%w: budget %s", repository.ErrNotFound, id)
+	}
+	delete(r.budgets, id)
+	return nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type ChargebackRepository struct {
+	mu          sync.RWMutex
+	chargebacks map[string]*domain.Chargeback
+}
+
+func NewChargebackRepository() *ChargebackRepository {
+	return &ChargebackRepository{
+		chargebacks: make(map[string]*domain.Chargeback),
+	}
+}
+
+func (r *ChargebackRepository) Save(ctx context.Context, chargeback *domain.Chargeback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.chargebacks[chargeback.ID]; exists {
+		return fmt.Errorf("%w: %w: chargeback %s", repository.ErrDuplicate, repository.ErrIDCollision, chargeback.ID)
+	}
+	for _, existing := range r.chargebacks {
+		if existing.TransactionID == chargeback.TransactionID {
+			return fmt.Errorf("%w: chargeback for transaction %s", repository.ErrDuplicate, chargeback.TransactionID)
+		}
+	}
+
+	chargeback.UpdatedAt = time.Now()
+	copied := *chargeback
+	r.chargebacks[chargeback.ID] = &copied
+
+	return nil
+}
+
+func (r *ChargebackRepository) GetByID(ctx context.Context, id string) (*domain.Chargeback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chargeback, exists := r.chargebacks[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: chargeback %s", repository.ErrNotFound, id)
+	}
+	copied := *chargeback
+	return &copied, nil
+}
+
+func (r *ChargebackRepository) GetByTransactionID(ctx context.Context, transactionID string) (*domain.Chargeback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, chargeback := range r.chargebacks {
+		if chargeback.TransactionID == transactionID {
+			copied := *chargeback
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: chargeback for transaction %s", repository.ErrNotFound, transactionID)
+}
+
+func (r *ChargebackRepository) Update(ctx context.Context, chargeback *domain.Chargeback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.chargebacks[chargeback.ID]; !exists {
+		return fmt.Errorf("%w: chargeback %s", repository.ErrNotFound, chargeback.ID)
+	}
+
+	chargeback.UpdatedAt = time.Now()
+	copied := *chargeback
+	r.chargebacks[chargeback.ID] = &copied
+
+	return nil
+}
+
+func (r *ChargebackRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.chargebacks[id]; !exists {
+		return fmt.Errorf("%w: chargeback %s", repository.ErrNotFound, id)
+	}
+	delete(r.chargebacks, id)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type DelegationRepository struct {
+	mu          sync.RWMutex
+	delegations map[string]*domain.Delegation
+}
+
+func NewDelegationRepository() *DelegationRepository {
+	return &DelegationRepository{
+		delegations: make(map[string]*domain.Delegation),
+	}
+}
+
+func (r *DelegationRepository) Save(ctx context.Context, delegation *domain.Delegation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.delegations[delegation.ID]; exists {
+		return fmt.Errorf("%w: %w: delegation %s", repository.ErrDuplicate, repository.ErrIDCollision, delegation.ID)
+	}
+	for _, existing := range r.delegations {
+		if existing.AccountID == delegation.AccountID && existing.DelegateID == delegation.DelegateID && existing.Active() {
+			return fmt.Errorf("%w: delegation for %s on account %s", repository.ErrDuplicate, delegation.DelegateID, delegation.AccountID)
+		}
+	}
+
+	copied := *delegation
+	r.delegations[delegation.ID] = &copied
+
+	return nil
+}
+
+func (r *DelegationRepository) GetByID(ctx context.Context, id string) (*domain.Delegation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delegation, exists := r.delegations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: delegation %s", repository.ErrNotFound, id)
+	}
+	copied := *delegation
+	return &copied, nil
+}
+
+func (r *DelegationRepository) GetByAccountID(ctx context.Context, accountID string) ([]*domain.Delegation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Delegation
+	for _, delegation := range r.delegations {
+		if delegation.AccountID == accountID {
+			copied := *delegation
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *DelegationRepository) Update(ctx context.Context, delegation *domain.Delegation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.delegations[delegation.ID]; !exists {
+		return fmt.Errorf("%w: delegation %s", repository.ErrNotFound, delegation.ID)
+	}
+	copied := *delegation
+	r.delegations[delegation.ID] = &copied
+
+	return nil
+}
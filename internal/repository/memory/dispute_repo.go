@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/search"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type DisputeRepository struct {
+	mu          sync.RWMutex
+	disputes    map[string]*domain.Dispute
+	searchIndex *search.Index
+}
+
+func NewDisputeRepository() *DisputeRepository {
+	return &DisputeRepository{
+		disputes:    make(map[string]*domain.Dispute),
+		searchIndex: search.New(),
+	}
+}
+
+func (r *DisputeRepository) Save(ctx context.Context, dispute *domain.Dispute) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.disputes[dispute.ID]; exists {
+		return fmt.Errorf("%w: %w: dispute %s", repository.ErrDuplicate, repository.ErrIDCollision, dispute.ID)
+	}
+	for _, existing := range r.disputes {
+		if existing.TransactionID == dispute.TransactionID && existing.IsActive() {
+			return fmt.Errorf("%w: active dispute for transaction %s", repository.ErrDuplicate, dispute.TransactionID)
+		}
+	}
+
+	dispute.UpdatedAt = time.Now()
+	r.disputes[dispute.ID] = cloneDispute(dispute)
+	r.searchIndex.Put(dispute.ID, dispute.ID, dispute.TransactionID, dispute.AccountID, dispute.Reason)
+
+	return nil
+}
+
+func (r *DisputeRepository) GetByID(ctx context.Context, id string) (*domain.Dispute, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dispute, exists := r.disputes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: dispute %s", repository.ErrNotFound, id)
+	}
+	return cloneDispute(dispute), nil
+}
+
+func (r *DisputeRepository) GetByTransactionID(ctx context.Context, transactionID string) ([]*domain.Dispute, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Dispute
+	for _, dispute := range r.disputes {
+		if dispute.TransactionID == transactionID {
+			result = append(result, cloneDispute(dispute))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *DisputeRepository) Update(ctx context.Context, dispute *domain.Dispute) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.disputes[dispute.ID]; !exists {
+		return fmt.Errorf("%w: dispute %s", repository.ErrNotFound, dispute.ID)
+	}
+
+	dispute.UpdatedAt = time.Now()
+	r.disputes[dispute.ID] = cloneDispute(dispute)
+	r.searchIndex.Put(dispute.ID, dispute.ID, dispute.TransactionID, dispute.AccountID, dispute.Reason)
+
+	return nil
+}
+
+func (r *DisputeRepository) Search(ctx context.Context, query string, limit int) ([]repository.SearchHit[*domain.Dispute], error) {
+	hits := r.searchIndex.Search(query, limit)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]repository.SearchHit[*domain.Dispute], 0, len(hits))
+	for _, hit := range hits {
+		if dispute, exists := r.disputes[hit.ID]; exists {
+			result = append(result, repository.SearchHit[*domain.Dispute]{Item: cloneDispute(dispute), Score: hit.Score})
+		}
+	}
+	return result, nil
+}
+
+func cloneDispute(dispute *domain.Dispute) *domain.Dispute {
+	copied := *dispute
+	copied.Evidence = append([]domain.EvidenceAttachment(nil), dispute.Evidence...)
+	copied.Notes = append([]string(nil), dispute.Notes...)
+	return &copied
+}
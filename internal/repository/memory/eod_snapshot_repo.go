@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type EODSnapshotRepository struct {
+	mu        sync.RWMutex
+	snapshots map[string]*domain.EODSnapshot
+}
+
+func NewEODSnapshotRepository() *EODSnapshotRepository {
+	return &EODSnapshotRepository{
+		snapshots: make(map[string]*domain.EODSnapshot),
+	}
+}
+
+func (r *EODSnapshotRepository) Save(ctx context.Context, snapshot *domain.EODSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.snapshots[snapshot.ID]; exists {
+		return fmt.Errorf("%w: snapshot %s", repository.ErrDuplicate, snapshot.ID)
+	}
+	r.snapshots[snapshot.ID] = snapshot.Clone()
+
+	return nil
+}
+
+func (r *EODSnapshotRepository) GetByID(ctx context.Context, id string) (*domain.EODSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot, exists := r.snapshots[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: snapshot %s", repository.ErrNotFound, id)
+	}
+	return snapshot.Clone(), nil
+}
+
+func (r *EODSnapshotRepository) List(ctx context.Context) ([]*domain.EODSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.EODSnapshot, 0, len(r.snapshots))
+	for _, snapshot := range r.snapshots {
+		result = append(result, snapshot.Clone())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BusinessDate < result[j].BusinessDate
+	})
+
+	return result, nil
+}
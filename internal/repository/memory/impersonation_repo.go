@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type ImpersonationRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*domain.ImpersonationSession
+}
+
+func NewImpersonationRepository() *ImpersonationRepository {
+	return &ImpersonationRepository{
+		sessions: make(map[string]*domain.ImpersonationSession),
+	}
+}
+
+func (r *ImpersonationRepository) Save(ctx context.Context, session *domain.ImpersonationSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sessions[session.ID]; exists {
+		return fmt.Errorf("%w: %w: impersonation session %s", repository.ErrDuplicate, repository.ErrIDCollision, session.ID)
+	}
+	copied := *session
+	r.sessions[session.ID] = &copied
+
+	return nil
+}
+
+func (r *ImpersonationRepository) GetByID(ctx context.Context, id string) (*domain.ImpersonationSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: impersonation session %s", repository.ErrNotFound, id)
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *ImpersonationRepository) Update(ctx context.Context, session *domain.ImpersonationSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sessions[session.ID]; !exists {
+		return fmt.Errorf("%w: impersonation session %s", repository.ErrNotFound, session.ID)
+	}
+	copied := *session
+	r.sessions[session.ID] = &copied
+
+	return nil
+}
+
+func (r *ImpersonationRepository) List(ctx context.Context) ([]*domain.ImpersonationSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.ImpersonationSession, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		copied := *session
+		result = append(result, &copied)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.After(result[j].StartedAt)
+	})
+
+	return result, nil
+}
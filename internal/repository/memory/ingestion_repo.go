@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type IngestionRepository struct {
+	mu      sync.RWMutex
+	records map[string]*domain.IngestionRecord
+}
+
+func NewIngestionRepository() *IngestionRepository {
+	return &IngestionRepository{
+		records: make(map[string]*domain.IngestionRecord),
+	}
+}
+
+func (r *IngestionRepository) Claim(ctx context.Context, key string, lease time.Duration) (*domain.IngestionRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	record, exists := r.records[key]
+	switch {
+	case !exists:
+		record = &domain.IngestionRecord{Key: key, CreatedAt: now}
+		r.records[key] = record
+	case record.Terminal():
+		return record.Clone(), nil
+	case now.Sub(record.UpdatedAt) < lease:
+		return nil, fmt.Errorf("%w: ingestion key %s is being processed", repository.ErrTransactionConflict, key)
+	}
+
+	record.Status = domain.IngestionProcessing
+	record.Attempts++
+	record.UpdatedAt = now
+	return record.Clone(), nil
+}
+
+func (r *IngestionRepository) Release(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.records[key]
+	if !exists {
+		return fmt.Errorf("%w: ingestion key %s", repository.ErrNotFound, key)
+	}
+	if !record.Terminal() {
+		record.UpdatedAt = time.Time{}
+	}
+	return nil
+}
+
+func (r *IngestionRepository) Save(ctx context.Context, record *domain.IngestionRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.records[record.Key]; exists {
+		record.CreatedAt = existing.CreatedAt
+	} else if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	record.UpdatedAt = time.Now()
+	r.records[record.Key] = record.Clone()
+	return nil
+}
+
+func (r *IngestionRepository) GetByKey(ctx context.Context, key string) (*domain.IngestionRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, exists := r.records[key]
+	if !exists {
+		return nil, fmt.Errorf("%w: ingestion key %s", repository.ErrNotFound, key)
+	}
+	return record.Clone(), nil
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type InstallmentPlanRepository struct {
+	mu    sync.RWMutex
+	plans map[string]*domain.InstallmentPlan
+}
+
+func NewInstallmentPlanRepository() *InstallmentPlanRepository {
+	return &InstallmentPlanRepository{
+		plans: make(map[string]*domain.InstallmentPlan),
+	}
+}
+
+func (r *InstallmentPlanRepository) Save(ctx context.Context, plan *domain.InstallmentPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.plans[plan.ID]; exists {
+		return fmt.Errorf("%w: %w: installment plan %s", repository.ErrDuplicate, repository.ErrIDCollision, plan.ID)
+	}
+
+	plan.UpdatedAt = time.Now()
+	r.plans[plan.ID] = plan.Clone()
+
+	return nil
+}
+
+func (r *InstallmentPlanRepository) GetByID(ctx context.Context, id string) (*domain.InstallmentPlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plan, exists := r.plans[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: installment plan %s", repository.ErrNotFound, id)
+	}
+	return plan.Clone(), nil
+}
+
+func (r *InstallmentPlanRepository) Update(ctx context.Context, plan *domain.InstallmentPlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.plans[plan.ID]; !exists {
+		return fmt.Errorf("%w: installment plan %s", repository.ErrNotFound, plan.ID)
+	}
+
+	plan.UpdatedAt = time.Now()
+	r.plans[plan.ID] = plan.Clone()
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type LimitChangeRepository struct {
+	mu      sync.RWMutex
+	changes map[string]*domain.LimitChange
+}
+
+func NewLimitChangeRepository() *LimitChangeRepository {
+	return &LimitChangeRepository{
+		changes: make(map[string]*domain.LimitChange),
+	}
+}
+
+func (r *LimitChangeRepository) Save(ctx context.Context, change *domain.LimitChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.changes[change.ID]; exists {
+		return fmt.Errorf("%w: %w: limit change %s", repository.ErrDuplicate, repository.ErrIDCollision, change.ID)
+	}
+
+	change.UpdatedAt = time.Now()
+	copied := *change
+	r.changes[change.ID] = &copied
+
+	return nil
+}
+
+func (r *LimitChangeRepository) GetByID(ctx context.Context, id string) (*domain.LimitChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	change, exists := r.changes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: limit change %s", repository.ErrNotFound, id)
+	}
+	copied := *change
+	return &copied, nil
+}
+
+func (r *LimitChangeRepository) Update(ctx context.Context, change *domain.LimitChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.changes[change.ID]; !exists {
+		return fmt.Errorf("%w: limit change %s", repository.ErrNotFound, change.ID)
+	}
+
+	change.UpdatedAt = time.Now()
+	copied := *change
+	r.changes[change.ID] = &copied
+
+	return nil
+}
+
+func (r *LimitChangeRepository) GetDue(ctx context.Context, before time.Time) ([]*domain.LimitChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.LimitChange
+	for _, change := range r.changes {
+		if change.Status == domain.LimitChangeCoolingOff && change.EffectiveAt != nil && !change.EffectiveAt.After(before) {
+			copied := *change
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].EffectiveAt.Before(*result[j].EffectiveAt)
+	})
+
+	return result, nil
+}
+
+func (r *LimitChangeRepository) GetByAccountID(ctx context.Context, accountID string) ([]*domain.LimitChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.LimitChange
+	for _, change := range r.changes {
+		if change.AccountID == accountID {
+			copied := *change
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
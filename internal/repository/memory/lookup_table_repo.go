@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+type LookupTableRepository struct {
+	mu     sync.RWMutex
+	tables map[string]*domain.LookupTable
+}
+
+func NewLookupTableRepository() *LookupTableRepository {
+	return &LookupTableRepository{
+		tables: make(map[string]*domain.LookupTable),
+	}
+}
+
+func (r *LookupTableRepository) Save(ctx context.Context, table *domain.LookupTable) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	table.UpdatedAt = time.Now()
+	r.tables[table.Name] = cloneLookupTable(table)
+
+	return nil
+}
+
+func (r *LookupTableRepository) GetByName(ctx context.Context, name string) (*domain.LookupTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	table, exists := r.tables[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: lookup table %s", repository.ErrNotFound, name)
+	}
+	return cloneLookupTable(table), nil
+}
+
+func (r *LookupTableRepository) GetAll(ctx context.Context) ([]*domain.LookupTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.LookupTable, 0, len(r.tables))
+	for _, table := range r.tables {
+		result = append(result, cloneLookupTable(table))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+func (r *LookupTableRepository) Delete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tables[name]; !exists {
+		return fmt.Errorf("%w: lookup table %s", repository.ErrNotFound, name)
+	}
+	delete(r.tables, name)
+
+	return nil
+}
+
+func cloneLookupTable(table *domain.LookupTable) *domain.LookupTable {
+	clone := *table
+	clone.Entries = maps.Clone(table.Entries)
+	if table.Default != nil {
+		value := *table.Default
+		clone.Default = &value
+	}
+	return &clone
+}
@@ -8,4 +8,39 @@ var (
 	_ repository.TransactionRepository = (*TransactionRepository)(nil)
 	_ repository.AccountRepository     = (*AccountRepository)(nil)
 	_ repository.RuleRepository        = (*RuleRepository)(nil)
+	_ repository.RuleGroupRepository   = (*RuleGroupRepository)(nil)
+	_ repository.LookupTableRepository = (*LookupTableRepository)(nil)
+	_ repository.ProfileRepository     = (*ProfileRepository)(nil)
+	_ repository.AuditRepository       = (*AuditRepository)(nil)
+	_ repository.LimitChangeRepository = (*LimitChangeRepository)(nil)
+	_ repository.BeneficiaryRepository = (*BeneficiaryRepository)(nil)
+	_ repository.DelegationRepository  = (*DelegationRepository)(nil)
+	_ repository.BudgetRepository      = (*BudgetRepository)(nil)
+	_ repository.DisputeRepository     = (*DisputeRepository)(nil)
+	_ repository.ChargebackRepository  = (*ChargebackRepository)(nil)
+	_ repository.ProductRepository     = (*ProductRepository)(nil)
+
+	_ repository.NotificationPreferenceRepository = (*NotificationPreferenceRepository)(nil)
+	_ repository.DeliveryRepository               = (*DeliveryRepository)(nil)
+	_ repository.ScheduledNotificationRepository  = (*ScheduledNotificationRepository)(nil)
+	_ repository.InboxRepository                  = (*InboxRepository)(nil)
+	_ repository.InstallmentPlanRepository        = (*InstallmentPlanRepository)(nil)
+	_ repository.PositivePayRepository            = (*PositivePayRepository)(nil)
+	_ repository.SpendingControlsRepository       = (*SpendingControlsRepository)(nil)
+	_ repository.SuspenseRepository               = (*SuspenseRepository)(nil)
+	_ repository.EODSnapshotRepository            = (*EODSnapshotRepository)(nil)
+	_ repository.ImpersonationRepository          = (*ImpersonationRepository)(nil)
+	_ repository.ApprovalRepository               = (*ApprovalRepository)(nil)
+	_ repository.QuotaRepository                  = (*QuotaRepository)(nil)
+	_ repository.PartnerRepository                = (*PartnerRepository)(nil)
+	_ repository.WebhookEventRepository           = (*WebhookEventRepository)(nil)
+	_ repository.IngestionRepository              = (*IngestionRepository)(nil)
+	_ repository.ReconciliationRepository         = (*ReconciliationRepository)(nil)
+	_ repository.PayoutBatchRepository            = (*PayoutBatchRepository)(nil)
+
+	_ repository.TransactionSnapshotter = (*TransactionRepository)(nil)
+	_ repository.AccountSnapshotter     = (*AccountRepository)(nil)
+	_ repository.TransactionSearcher    = (*TransactionRepository)(nil)
+	_ repository.AccountSearcher        = (*AccountRepository)(nil)
+	_ repository.DisputeSearcher        = (*DisputeRepository)(nil)
 )
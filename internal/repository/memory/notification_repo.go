@@ -0,0 +1,322 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type NotificationPreferenceRepository struct {
+	mu    sync.RWMutex
+	prefs map[string]*domain.NotificationPreferences
+}
+
+func NewNotificationPreferenceRepository() *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		prefs: make(map[string]*domain.NotificationPreferences),
+	}
+}
+
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *prefs
+	stored.Channels = slices.Clone(prefs.Channels)
+	stored.UpdatedAt = time.Now()
+	r.prefs[prefs.UserID] = &stored
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) GetByUser(ctx context.Context, userID string) (*domain.NotificationPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefs, exists := r.prefs[userID]
+	if !exists {
+		return nil, fmt.Errorf("%w: notification preferences for user %s", repository.ErrNotFound, userID)
+	}
+	result := *prefs
+	result.Channels = slices.Clone(prefs.Channels)
+	return &result, nil
+}
+
+type DeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries []*domain.NotificationDelivery
+	ids        map[string]bool
+}
+
+func NewDeliveryRepository() *DeliveryRepository {
+	return &DeliveryRepository{
+		ids: make(map[string]bool),
+	}
+}
+
+func (r *DeliveryRepository) Save(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ids[delivery.ID] {
+		return fmt.Errorf("%w: %w: delivery %s", repository.ErrDuplicate, repository.ErrIDCollision, delivery.ID)
+	}
+
+	r.ids[delivery.ID] = true
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *DeliveryRepository) GetByMessage(ctx context.Context, messageID string) ([]*domain.NotificationDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.NotificationDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.MessageID == messageID {
+			result = append(result, delivery)
+		}
+	}
+	return result, nil
+}
+
+func (r *DeliveryRepository) GetByUser(ctx context.Context, userID string) ([]*domain.NotificationDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.NotificationDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.UserID == userID {
+			result = append(result, delivery)
+		}
+	}
+	return result, nil
+}
+
+type ScheduledNotificationRepository struct {
+	mu            sync.RWMutex
+	notifications map[string]*domain.ScheduledNotification
+}
+
+func NewScheduledNotificationRepository() *ScheduledNotificationRepository {
+	return &ScheduledNotificationRepository{
+		notifications: make(map[string]*domain.ScheduledNotification),
+	}
+}
+
+func (r *ScheduledNotificationRepository) Save(ctx context.Context, notification *domain.ScheduledNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.notifications[notification.ID]; exists {
+		return fmt.Errorf("%w: %w: scheduled notification %s", repository.ErrDuplicate, repository.ErrIDCollision, notification.ID)
+	}
+
+	notification.UpdatedAt = time.Now()
+	copied := *notification
+	r.notifications[notification.ID] = &copied
+
+	return nil
+}
+
+func (r *ScheduledNotificationRepository) GetByID(ctx context.Context, id string) (*domain.ScheduledNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notification, exists := r.notifications[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: scheduled notification %s", repository.ErrNotFound, id)
+	}
+	copied := *notification
+	return &copied, nil
+}
+
+func (r *ScheduledNotificationRepository) Update(ctx context.Context, notification *domain.ScheduledNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.notifications[notification.ID]; !exists {
+		return fmt.Errorf("%w: scheduled notification %s", repository.ErrNotFound, notification.ID)
+	}
+
+	notification.UpdatedAt = time.Now()
+	copied := *notification
+	r.notifications[notification.ID] = &copied
+
+	return nil
+}
+
+func (r *ScheduledNotificationRepository) GetDue(ctx context.Context, before time.Time) ([]*domain.ScheduledNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.ScheduledNotification
+	for _, notification := range r.notifications {
+		if notification.Status == domain.ScheduledNotificationPending && !notification.SendAt.After(before) {
+			copied := *notification
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SendAt.Before(result[j].SendAt)
+	})
+
+	return result, nil
+}
+
+type InboxRepository struct {
+	mu     sync.RWMutex
+	byUser map[string][]*domain.InboxNotification
+	ids    map[string]bool
+}
+
+func NewInboxRepository() *InboxRepository {
+	return &InboxRepository{
+		byUser: make(map[string][]*domain.InboxNotification),
+		ids:    make(map[string]bool),
+	}
+}
+
+func (r *InboxRepository) Save(ctx context.Context, notification *domain.InboxNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ids[notification.ID] {
+		return fmt.Errorf("%w: %w: inbox notification %s", repository.ErrDuplicate, repository.ErrIDCollision, notification.ID)
+	}
+
+	copied := *notification
+	r.ids[notification.ID] = true
+	r.byUser[notification.UserID] = append(r.byUser[notification.UserID], &copied)
+	return nil
+}
+
+func (r *InboxRepository) GetByUser(ctx context.Context, userID string, unreadOnly bool) ([]*domain.InboxNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notifications := r.byUser[userID]
+	result := make([]*domain.InboxNotification, 0, len(notifications))
+	for i := len(notifications) - 1; i >= 0; i-- {
+		if unreadOnly && notifications[i].Read {
+			continue
+		}
+		copied := *notifications[i]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *InboxRepository) MarkRead(ctx context.Context, userID, id string, at time.Time) (*domain.InboxNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, notification := range r.byUser[userID] {
+		if notification.ID != id {
+			continue
+		}
+		if !notification.Read {
+			notification.Read = true
+			notification.ReadAt = &at
+		}
+		copied := *notification
+		return &copied, nil
+	}
+	return nil, fmt.Errorf("%w: inbox notification %s", repository.ErrNotFound, id)
+}
+
+func (r *InboxRepository) MarkAllRead(ctx context.Context, userID string, at time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var marked int
+	for _, notification := range r.byUser[userID] {
+		if !notification.Read {
+			notification.Read = true
+			notification.ReadAt = &at
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+type ConsentRepository struct {
+	mu      sync.RWMutex
+	history map[string][]*domain.ConsentRecord
+}
+
+func NewConsentRepository() *ConsentRepository {
+	return &ConsentRepository{
+		history: make(map[string][]*domain.ConsentRecord),
+	}
+}
+
+func (r *ConsentRepository) Save(ctx context.Context, record *domain.ConsentRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record.RecordedAt.IsZero() {
+		record.RecordedAt = time.Now()
+	}
+	stored := *record
+	r.history[record.UserID] = append(r.history[record.UserID], &stored)
+	return nil
+}
+
+func (r *ConsentRepository) GetCurrent(ctx context.Context, userID, channel string, purpose domain.ConsentPurpose) (*domain.ConsentRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := r.history[userID]
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Channel == channel && records[i].Purpose == purpose {
+			result := *records[i]
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s consent for user %s on %s", repository.ErrNotFound, purpose, userID, channel)
+}
+
+func (r *ConsentRepository) GetByUser(ctx context.Context, userID string) ([]*domain.ConsentRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type consentKey struct {
+		channel string
+		purpose domain.ConsentPurpose
+	}
+	latest := make(map[consentKey]*domain.ConsentRecord)
+	for _, record := range r.history[userID] {
+		latest[consentKey{record.Channel, record.Purpose}] = record
+	}
+
+	result := make([]*domain.ConsentRecord, 0, len(latest))
+	for _, record := range latest {
+		copied := *record
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Channel != result[j].Channel {
+			return result[i].Channel < result[j].Channel
+		}
+		return result[i].Purpose < result[j].Purpose
+	})
+	return result, nil
+}
+
+func (r *ConsentRepository) GetHistory(ctx context.Context, userID string) ([]*domain.ConsentRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.ConsentRecord, 0, len(r.history[userID]))
+	for _, record := range r.history[userID] {
+		copied := *record
+		result = append(result, &copied)
+	}
+	return result, nil
+}
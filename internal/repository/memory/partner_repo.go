@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type PartnerRepository struct {
+	mu       sync.RWMutex
+	partners map[string]*domain.Partner
+	byAPIKey map[string]string
+}
+
+func NewPartnerRepository() *PartnerRepository {
+	return &PartnerRepository{
+		partners: make(map[string]*domain.Partner),
+		byAPIKey: make(map[string]string),
+	}
+}
+
+func (r *PartnerRepository) Save(ctx context.Context, partner *domain.Partner) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.partners[partner.ID]; exists {
+		return fmt.Errorf("%w: partner %s", repository.ErrDuplicate, partner.ID)
+	}
+	if err := r.checkAPIKeysLocked(partner); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if partner.CreatedAt.IsZero() {
+		partner.CreatedAt = now
+	}
+	partner.UpdatedAt = now
+	r.storeLocked(partner)
+
+	return nil
+}
+
+func (r *PartnerRepository) Update(ctx context.Context, partner *domain.Partner) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.partners[partner.ID]
+	if !exists {
+		return fmt.Errorf("%w: partner %s", repository.ErrNotFound, partner.ID)
+	}
+	if err := r.checkAPIKeysLocked(partner); err != nil {
+		return err
+	}
+
+	for _, key := range existing.APIKeys {
+		delete(r.byAPIKey, key)
+	}
+	partner.UpdatedAt = time.Now()
+	r.storeLocked(partner)
+
+	return nil
+}
+
+func (r *PartnerRepository) GetByID(ctx context.Context, id string) (*domain.Partner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	partner, exists := r.partners[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: partner %s", repository.ErrNotFound, id)
+	}
+	return partner.Clone(), nil
+}
+
+func (r *PartnerRepository) GetByAPIKey(ctx context.Context, apiKey string) (*domain.Partner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.byAPIKey[apiKey]
+	if !exists {
+		return nil, fmt.Errorf("%w: partner for api key", repository.ErrNotFound)
+	}
+	return r.partners[id].Clone(), nil
+}
+
+func (r *PartnerRepository) GetAll(ctx context.Context) ([]*domain.Partner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Partner, 0, len(r.partners))
+	for _, partner := range r.partners {
+		result = append(result, partner.Clone())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (r *PartnerRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	partner, exists := r.partners[id]
+	if !exists {
+		return fmt.Errorf("%w: partner %s", repository.ErrNotFound, id)
+	}
+	for _, key := range partner.APIKeys {
+		delete(r.byAPIKey, key)
+	}
+	delete(r.partners, id)
+
+	return nil
+}
+
+func (r *PartnerRepository) checkAPIKeysLocked(partner *domain.Partner) error {
+	for _, key := range partner.APIKeys {
+		if owner, exists := r.byAPIKey[key]; exists && owner != partner.ID {
+			return fmt.Errorf("%w: api key already assigned to another partner", repository.ErrDuplicate)
+		}
+	}
+	return nil
+}
+
+func (r *PartnerRepository) storeLocked(partner *domain.Partner) {
+	r.partners[partner.ID] = partner.Clone()
+	for _, key := range partner.APIKeys {
+		r.byAPIKey[key] = partner.ID
+	}
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type PayoutBatchRepository struct {
+	mu      sync.RWMutex
+	batches map[string]*domain.PayoutBatch
+}
+
+func NewPayoutBatchRepository() *PayoutBatchRepository {
+	return &PayoutBatchRepository{
+		batches: make(map[string]*domain.PayoutBatch),
+	}
+}
+
+func (r *PayoutBatchRepository) Save(ctx context.Context, batch *domain.PayoutBatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batch.ID]; exists {
+		return fmt.Errorf("%w: %w: payout batch %s", repository.ErrDuplicate, repository.ErrIDCollision, batch.ID)
+	}
+
+	batch.UpdatedAt = time.Now()
+	r.batches[batch.ID] = batch.Clone()
+
+	return nil
+}
+
+func (r *PayoutBatchRepository) GetByID(ctx context.Context, id string) (*domain.PayoutBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	batch, exists := r.batches[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: payout batch %s", repository.ErrNotFound, id)
+	}
+	return batch.Clone(), nil
+}
+
+func (r *PayoutBatchRepository) GetByStatus(ctx context.Context, statuses ...domain.PayoutBatchStatus) ([]*domain.PayoutBatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.PayoutBatch
+	for _, batch := range r.batches {
+		if slices.Contains(statuses, batch.Status) {
+			result = append(result, batch.Clone())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *PayoutBatchRepository) Update(ctx context.Context, batch *domain.PayoutBatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[batch.ID]; !exists {
+		return fmt.Errorf("%w: payout batch %s", repository.ErrNotFound, batch.ID)
+	}
+
+	batch.UpdatedAt = time.Now()
+	r.batches[batch.ID] = batch.Clone()
+
+	return nil
+}
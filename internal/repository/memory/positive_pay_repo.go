@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type PositivePayRepository struct {
+	mu    sync.RWMutex
+	lists map[string]*domain.PositivePayList
+}
+
+func NewPositivePayRepository() *PositivePayRepository {
+	return &PositivePayRepository{
+		lists: make(map[string]*domain.PositivePayList),
+	}
+}
+
+func (r *PositivePayRepository) Save(ctx context.Context, list *domain.PositivePayList) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if existing, exists := r.lists[list.AccountID]; exists {
+		list.CreatedAt = existing.CreatedAt
+	} else {
+		list.CreatedAt = now
+	}
+	list.UpdatedAt = now
+	r.lists[list.AccountID] = list.Clone()
+
+	return nil
+}
+
+func (r *PositivePayRepository) GetByAccountID(ctx context.Context, accountID string) (*domain.PositivePayList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list, exists := r.lists[accountID]
+	if !exists {
+		return nil, fmt.Errorf("%w: positive pay list for account %s", repository.ErrNotFound, accountID)
+	}
+	return list.Clone(), nil
+}
+
+func (r *PositivePayRepository) Delete(ctx context.Context, accountID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.lists[accountID]; !exists {
+		return fmt.Errorf("%w: positive pay list for account %s", repository.ErrNotFound, accountID)
+	}
+	delete(r.lists, accountID)
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type ProcessedMessageRepository struct {
+	mu       sync.RWMutex
+	messages map[string]domain.ProcessedMessage
+}
+
+func NewProcessedMessageRepository() *ProcessedMessageRepository {
+	return &ProcessedMessageRepository{
+		messages: make(map[string]domain.ProcessedMessage),
+	}
+}
+
+func (r *ProcessedMessageRepository) Save(ctx context.Context, msg *domain.ProcessedMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages[msg.MessageID] = *msg
+	return nil
+}
+
+func (r *ProcessedMessageRepository) GetByMessageID(ctx context.Context, messageID string) (*domain.ProcessedMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	msg, exists := r.messages[messageID]
+	if !exists || (!msg.ExpiresAt.IsZero() && time.Now().After(msg.ExpiresAt)) {
+		return nil, fmt.Errorf("%w: message %s", repository.ErrNotFound, messageID)
+	}
+	return &msg, nil
+}
+
+func (r *ProcessedMessageRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, msg := range r.messages {
+		if !msg.ExpiresAt.IsZero() && now.After(msg.ExpiresAt) {
+			delete(r.messages, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ProductRepository struct {
+	mu       sync.RWMutex
+	products map[string]*domain.Product
+}
+
+func NewProductRepository() *ProductRepository {
+	return &ProductRepository{
+		products: make(map[string]*domain.Product),
+	}
+}
+
+func (r *ProductRepository) Save(ctx context.Context, product *domain.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.products[product.ID]; exists {
+		return fmt.Errorf("%w: product %s", repository.ErrDuplicate, product.ID)
+	}
+
+	now := time.Now()
+	if product.CreatedAt.IsZero() {
+		product.CreatedAt = now
+	}
+	product.UpdatedAt = now
+	r.products[product.ID] = product.Clone()
+
+	return nil
+}
+
+func (r *ProductRepository) GetByID(ctx context.Context, id string) (*domain.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, exists := r.products[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: product %s", repository.ErrNotFound, id)
+	}
+	return product.Clone(), nil
+}
+
+func (r *ProductRepository) GetAll(ctx context.Context) ([]*domain.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Product, 0, len(r.products))
+	for _, product := range r.products {
+		result = append(result, product.Clone())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (r *ProductRepository) Update(ctx context.Context, product *domain.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.products[product.ID]; !exists {
+		return fmt.Errorf("%w: product %s", repository.ErrNotFound, product.ID)
+	}
+
+	product.UpdatedAt = time.Now()
+	r.products[product.ID] = product.Clone()
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+)
+
+type ProfileRepository struct {
+	mu       sync.RWMutex
+	profiles map[string]*domain.AccountProfile
+}
+
+func NewProfileRepository() *ProfileRepository {
+	return &ProfileRepository{
+		profiles: make(map[string]*domain.AccountProfile),
+	}
+}
+
+func (r *ProfileRepository) Get(ctx context.Context, accountID string) (*domain.AccountProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, exists := r.profiles[accountID]
+	if !exists {
+		return nil, fmt.Errorf("%w: profile for account %s", repository.ErrNotFound, accountID)
+	}
+	return profile.Clone(), nil
+}
+
+func (r *ProfileRepository) Save(ctx context.Context, profile *domain.AccountProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profiles[profile.AccountID] = profile.Clone()
+	return nil
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type QuotaRepository struct {
+	mu     sync.RWMutex
+	quotas map[string]*domain.APIKeyQuota
+	usage  map[string]*domain.APIKeyUsage
+}
+
+func NewQuotaRepository() *QuotaRepository {
+	return &QuotaRepository{
+		quotas: make(map[string]*domain.APIKeyQuota),
+		usage:  make(map[string]*domain.APIKeyUsage),
+	}
+}
+
+func (r *QuotaRepository) SaveQuota(ctx context.Context, quota *domain.APIKeyQuota) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	quota.UpdatedAt = time.Now()
+	copied := *quota
+	r.quotas[quota.APIKey] = &copied
+
+	return nil
+}
+
+func (r *QuotaRepository) GetQuota(ctx context.Context, apiKey string) (*domain.APIKeyQuota, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quota, exists := r.quotas[apiKey]
+	if !exists {
+		return nil, fmt.Errorf("%w: quota for api key %s", repository.ErrNotFound, apiKey)
+	}
+	copied := *quota
+	return &copied, nil
+}
+
+func (r *QuotaRepository) AddUsage(ctx context.Context, apiKey, period string, requests, transactions int64, volume float64) (*domain.APIKeyUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := apiKey + "|" + period
+	usage, exists := r.usage[key]
+	if !exists {
+		usage = &domain.APIKeyUsage{APIKey: apiKey, Period: period}
+		r.usage[key] = usage
+	}
+	usage.Requests += requests
+	usage.Transactions += transactions
+	usage.Volume += volume
+	usage.UpdatedAt = time.Now()
+
+	copied := *usage
+	return &copied, nil
+}
+
+func (r *QuotaRepository) GetUsage(ctx context.Context, apiKey, period string) (*domain.APIKeyUsage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	usage, exists := r.usage[apiKey+"|"+period]
+	if !exists {
+		return &domain.APIKeyUsage{APIKey: apiKey, Period: period}, nil
+	}
+	copied := *usage
+	return &copied, nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ReconciliationRepository struct {
+	mu      sync.RWMutex
+	reports map[string]*domain.ReconciliationReport
+}
+
+func NewReconciliationRepository() *ReconciliationRepository {
+	return &ReconciliationRepository{
+		reports: make(map[string]*domain.ReconciliationReport),
+	}
+}
+
+func (r *ReconciliationRepository) Save(ctx context.Context, report *domain.ReconciliationReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.reports[report.ID]; exists {
+		return fmt.Errorf("%w: %w: reconciliation report %s", repository.ErrDuplicate, repository.ErrIDCollision, report.ID)
+	}
+
+	report.UpdatedAt = time.Now()
+	r.reports[report.ID] = report.Clone()
+	return nil
+}
+
+func (r *ReconciliationRepository) GetByID(ctx context.Context, id string) (*domain.ReconciliationReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report, exists := r.reports[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: reconciliation report %s", repository.ErrNotFound, id)
+	}
+	return report.Clone(), nil
+}
+
+func (r *ReconciliationRepository) GetAll(ctx context.Context) ([]*domain.ReconciliationReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.ReconciliationReport, 0, len(r.reports))
+	for _, report := range r.reports {
+		result = append(result, report.Clone())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *ReconciliationRepository) Update(ctx context.Context, report *domain.ReconciliationReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.reports[report.ID]; !exists {
+		return fmt.Errorf("%w: reconciliation report %s", repository.ErrNotFound, report.ID)
+	}
+
+	report.UpdatedAt = time.Now()
+	r.reports[report.ID] = report.Clone()
+	return nil
+}
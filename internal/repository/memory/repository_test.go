@@ -2,7 +2,12 @@ package memory
 
 import (
 	"context"
+	"errors"
 	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -100,3 +105,258 @@ func TestTransactionRepository_GetDailyVolume(t *testing.T) {
 		t.Errorf("expected total 80, got %f", total)
 	}
 }
+
+func TestTransactionRepository_AssignsCheckedReferences(t *testing.T) {
+	repo := NewTransactionRepository()
+	ctx := context.Background()
+
+	first := domain.NewTransaction(domain.TypeDeposit, 10, "USD")
+	second := domain.NewTransaction(domain.TypeDeposit, 20, "USD")
+	_ = repo.Save(ctx, first)
+	_ = repo.Save(ctx, second)
+
+	if first.Reference == "" || first.Reference == second.Reference {
+		t.Fatalf("expected distinct references, got %q and %q", first.Reference, second.Reference)
+	}
+	if normalized, ok := domain.NormalizeReference(strings.ToLower(second.Reference)); !ok || normalized != second.Reference {
+		t.Errorf("expected %s to validate, got %q / %v", second.Reference, normalized, ok)
+	}
+	for _, digit := range "0123456789" {
+		tampered := second.Reference[:len(second.Reference)-1] + string(digit)
+		if _, ok := domain.NormalizeReference(tampered); ok && tampered != second.Reference {
+			t.Errorf("expected tampered reference %s to fail check digit", tampered)
+		}
+	}
+	if got, err := repo.GetByReference(ctx, first.Reference); err != nil || got.ID != first.ID {
+		t.Errorf("expected lookup of %s to return %s, got %v / %v", first.Reference, first.ID, got, err)
+	}
+}
+
+func TestTransactionRepository_RegeneratesCollidingIDs(t *testing.T) {
+	repo := NewTransactionRepository()
+	ctx := context.Background()
+
+	first := domain.NewTransaction(domain.TypeDeposit, 10, "USD")
+	colliding := domain.NewTransaction(domain.TypeDeposit, 20, "USD")
+	colliding.ID = first.ID
+	_ = repo.Save(ctx, first)
+
+	if err := repo.Save(ctx, colliding); !errors.Is(err, repository.ErrIDCollision) {
+		t.Fatalf("expected ID collision, got %v", err)
+	}
+	err := repository.SaveWithFreshID(
+		func() error { return repo.Save(ctx, colliding) },
+		func() { colliding.ID = domain.NewID() },
+	)
+	if err != nil || colliding.ID == first.ID {
+		t.Fatalf("expected colliding transaction saved under a new ID, got %s / %v", colliding.ID, err)
+	}
+	if err := repo.Save(ctx, first); !errors.Is(err, repository.ErrDuplicate) || errors.Is(err, repository.ErrIDCollision) {
+		t.Errorf("expected re-saving the same transaction to be a plain duplicate, got %v", err)
+	}
+}
+
+func TestTransactionRepository_SaveAllIsAllOrNothing(t *testing.T) {
+	repo := NewTransactionRepository()
+	ctx := context.Background()
+	existing := domain.NewTransaction(domain.TypeDeposit, 10, "USD")
+	_ = repo.Save(ctx, existing)
+	fresh := domain.NewTransaction(domain.TypeDeposit, 20, "USD")
+	colliding := domain.NewTransaction(domain.TypeDeposit, 30, "USD")
+	colliding.ID = existing.ID
+
+	err := repo.SaveAll(ctx, []*domain.Transaction{fresh, colliding})
+
+	if !errors.Is(err, repository.ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, fresh.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected no transaction saved from a rejected batch, got %v", err)
+	}
+	second := domain.NewTransaction(domain.TypeDeposit, 40, "USD")
+	if err := repo.SaveAll(ctx, []*domain.Transaction{fresh, second}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fresh.Reference == "" || second.Reference == "" || fresh.Reference == second.Reference {
+		t.Errorf("expected distinct references, got %q / %q", fresh.Reference, second.Reference)
+	}
+}
+
+func TestTransactionRepository_TransitionStatus(t *testing.T) {
+	repo := NewTransactionRepository()
+	ctx := context.Background()
+	tx := domain.NewTransaction(domain.TypeDeposit, 10, "USD")
+	tx.Status = domain.StatusPending
+	_ = repo.Save(ctx, tx)
+
+	if err := repo.TransitionStatus(ctx, tx.ID, domain.StatusPending, domain.StatusProcessing); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.TransitionStatus(ctx, tx.ID, domain.StatusPending, domain.StatusProcessing); !errors.Is(err, repository.ErrTransactionConflict) {
+		t.Errorf("expected second claim to conflict, got %v", err)
+	}
+	if err := repo.TransitionStatus(ctx, "missing", domain.StatusPending, domain.StatusProcessing); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, tx.ID); got.Status != domain.StatusProcessing {
+		t.Errorf("expected processing, got %s", got.Status)
+	}
+}
+
+func TestTransactionRepository_StreamsInCreationOrder(t *testing.T) {
+	repo := NewTransactionRepository()
+	ctx := context.Background()
+	base := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for i := 600; i > 0; i-- {
+		from := "a1"
+		if i%2 == 0 {
+			from = "a2"
+		}
+		_ = repo.Save(ctx, &domain.Transaction{ID: fmt.Sprintf("tx%03d", i), FromAccountID: from, CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+
+	var ids []string
+	filter := repository.TransactionFilter{AccountID: "a1", From: base.Add(100 * time.Hour), To: base.Add(500 * time.Hour)}
+	for tx, err := range repo.Stream(ctx, filter) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, tx.ID)
+	}
+	if len(ids) != 200 || ids[0] != "tx101" || ids[len(ids)-1] != "tx499" || !slices.IsSorted(ids) {
+		t.Errorf("expected 200 ordered a1 transactions, got %d from %v", len(ids), ids[:min(3, len(ids))])
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var seen int
+	var streamErr error
+	for _, err := range repo.Stream(cancelled, repository.TransactionFilter{}) {
+		if err != nil {
+			streamErr = err
+			break
+		}
+		if seen++; seen == 10 {
+			cancel()
+		}
+	}
+	if !errors.Is(streamErr, context.Canceled) || seen >= 600 {
+		t.Errorf("expected stream to stop after cancellation, got %v after %d", streamErr, seen)
+	}
+}
+
+func TestTransactionRepository_Iterate(t *testing.T) {
+	repo := NewTransactionRepository()
+	ctx := context.Background()
+	base := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		tx := &domain.Transaction{ID: fmt.Sprintf("tx%d", i), Type: domain.TypeDeposit, Status: domain.StatusCompleted, Amount: float64(i), CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		if i%3 == 0 {
+			tx.Type = domain.TypeWithdrawal
+		}
+		_ = repo.Save(ctx, tx)
+	}
+
+	var total float64
+	err := repo.Iterate(ctx, repository.TransactionFilter{Type: domain.TypeWithdrawal, Status: domain.StatusCompleted}, func(tx *domain.Transaction) error {
+		total += tx.Amount
+		return nil
+	})
+	if err != nil || total != 18 {
+		t.Errorf("expected withdrawals totalling 18, got %f / %v", total, err)
+	}
+
+	var visited int
+	err = repo.Iterate(ctx, repository.TransactionFilter{}, func(tx *domain.Transaction) error {
+		if visited++; visited == 4 {
+			return repository.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil || visited != 4 {
+		t.Errorf("expected early stop after 4 without error, got %d / %v", visited, err)
+	}
+
+	boom := errors.New("boom")
+	if err := repo.Iterate(ctx, repository.TransactionFilter{}, func(*domain.Transaction) error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("expected callback error to propagate, got %v", err)
+	}
+}
+
+func TestSplitRepositories_RouteReadsToReplica(t *testing.T) {
+	ctx := context.Background()
+	primary, replica := NewTransactionRepository(), NewTransactionRepository()
+	split := repository.NewSplitTransactionRepository(primary, replica)
+
+	_ = split.Save(ctx, &domain.Transaction{ID: "tx1", Status: domain.StatusPending})
+	if _, err := split.GetByID(ctx, "tx1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("expected read from replica before replication, got %v", err)
+	}
+	if _, err := split.Primary().GetByID(ctx, "tx1"); err != nil {
+		t.Errorf("expected write on primary, got %v", err)
+	}
+
+	_ = replica.Save(ctx, &domain.Transaction{ID: "tx1", Status: domain.StatusPending})
+	if err := split.UpdateStatus(ctx, "tx1", domain.StatusCompleted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx, _ := split.GetByID(ctx, "tx1"); tx.Status != domain.StatusPending {
+		t.Errorf("expected replica to lag behind primary status update, got %s", tx.Status)
+	}
+
+	accounts := repository.NewSplitAccountRepository(NewAccountRepository(), nil)
+	_ = accounts.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Status: domain.AccountActive})
+	if _, err := accounts.GetByID(ctx, "a1"); err != nil {
+		t.Errorf("expected reads to fall back to primary without replica, got %v", err)
+	}
+}
+
+func TestMigrate_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	accounts := NewAccountRepository()
+	transactions := NewTransactionRepository()
+	_ = accounts.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Currency: "USD", Balance: 70, Status: domain.AccountActive})
+	_ = accounts.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Currency: "USD", Balance: 30, Status: domain.AccountActive})
+	for i := 0; i < 5; i++ {
+		tx := domain.NewTransaction(domain.TypeTransfer, 10, "USD").WithAccounts("a1", "a2")
+		tx.Status = domain.StatusCompleted
+		_ = transactions.Save(ctx, tx)
+	}
+
+	accountSnapshot, _ := accounts.SnapshotAccounts(ctx)
+	txSnapshot, _ := transactions.SnapshotTransactions(ctx)
+	var buf strings.Builder
+	if err := repository.WriteSnapshotFile(ctx, &buf, accountSnapshot, txSnapshot); err != nil {
+		t.Fatalf("WriteSnapshotFile failed: %v", err)
+	}
+	source, err := repository.ReadSnapshotFile(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("ReadSnapshotFile failed: %v", err)
+	}
+
+	var stages []string
+	targetAccounts, targetTxs := NewAccountRepository(), NewTransactionRepository()
+	report, err := repository.Migrate(ctx, source, targetAccounts, targetTxs, repository.MigrationOptions{
+		BatchSize: 2,
+		Progress: func(p repository.MigrationProgress) {
+			stages = append(stages, fmt.Sprintf("%s %d/%d", p.Stage, p.Done, p.Total))
+		},
+	})
+	if err != nil {
+		t.Fatalf("Migrate failed: %v (%v)", err, report.Mismatches)
+	}
+	if report.Accounts != 2 || report.Transactions != 5 || report.Balances["USD"] != 100 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if !slices.Contains(stages, "transactions 4/5") || !slices.Contains(stages, "transactions 5/5") {
+		t.Errorf("expected batched transaction progress, got %v", stages)
+	}
+	if got, _ := targetAccounts.GetByID(ctx, "a1"); got.Balance != 70 {
+		t.Errorf("expected migrated balance 70, got %.2f", got.Balance)
+	}
+
+	source.Transactions[0].ToAccountID = "missing"
+	if _, err := repository.Migrate(ctx, source, NewAccountRepository(), NewTransactionRepository(), repository.MigrationOptions{DryRun: true}); !errors.Is(err, repository.ErrMigrationVerification) {
+		t.Errorf("expected dangling account reference to fail verification, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type RuleGroupRepository struct {
+	mu     sync.RWMutex
+	groups map[string]*domain.RuleGroup
+}
+
+func NewRuleGroupRepository() *RuleGroupRepository {
+	return &RuleGroupRepository{
+		groups: make(map[string]*domain.RuleGroup),
+	}
+}
+
+func (r *RuleGroupRepository) Save(ctx context.Context, group *domain.RuleGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.groups[group.ID]; exists {
+		return fmt.Errorf("%w: rule group %s", repository.ErrDuplicate, group.ID)
+	}
+
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = group.CreatedAt
+	r.groups[group.ID] = group
+
+	return nil
+}
+
+func (r *RuleGroupRepository) GetByID(ctx context.Context, id string) (*domain.RuleGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	group, exists := r.groups[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: rule group %s", repository.ErrNotFound, id)
+	}
+	return group, nil
+}
+
+func (r *RuleGroupRepository) GetAll(ctx context.Context) ([]*domain.RuleGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.RuleGroup, 0, len(r.groups))
+	for _, group := range r.groups {
+		result = append(result, group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+func (r *RuleGroupRepository) Update(ctx context.Context, group *domain.RuleGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.groups[group.ID]
+	if !exists {
+		return fmt.Errorf("%w: rule group %s", repository.ErrNotFound, group.ID)
+	}
+
+	group.CreatedAt = existing.CreatedAt
+	group.UpdatedAt = time.Now()
+	r.groups[group.ID] = group
+
+	return nil
+}
+
+func (r *RuleGroupRepository) SetActive(ctx context.Context, id string, active bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	group, exists := r.groups[id]
+	if !exists {
+		return fmt.Errorf("%w: rule group %s", repository.ErrNotFound, id)
+	}
+
+	group.IsActive = active
+	group.UpdatedAt = time.Now()
+
+	return nil
+}
@@ -144,3 +144,27 @@ func (r *RuleRepository) GetByPriority(ctx context.Context, minPriority, maxPrio
 
 	return result, nil
 }
+
+func (r *RuleRepository) ReplaceAll(ctx context.Context, rules []*domain.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replacement := make(map[string]*domain.Rule, len(rules))
+	for _, rule := range rules {
+		if _, exists := replacement[rule.ID]; exists {
+			return fmt.Errorf("%w: rule %s", repository.ErrDuplicate, rule.ID)
+		}
+		replacement[rule.ID] = rule
+	}
+
+	for _, rule := range rules {
+		rule.Version = 1
+		if existing, exists := r.rules[rule.ID]; exists {
+			rule.Version = existing.Version + 1
+		}
+	}
+
+	r.rules = replacement
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+type transactionSnapshot struct {
+	takenAt      time.Time
+	transactions []*domain.Transaction
+	byID         map[string]*domain.Transaction
+}
+
+func (r *TransactionRepository) SnapshotTransactions(ctx context.Context) (repository.TransactionSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := &transactionSnapshot{
+		takenAt:      time.Now(),
+		transactions: make([]*domain.Transaction, 0, len(r.transactions)),
+		byID:         make(map[string]*domain.Transaction, len(r.transactions)),
+	}
+	for id, tx := range r.transactions {
+		clone := tx.Clone()
+		snapshot.transactions = append(snapshot.transactions, clone)
+		snapshot.byID[id] = clone
+	}
+
+	slices.SortFunc(snapshot.transactions, func(a, b *domain.Transaction) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return snapshot, nil
+}
+
+func (s *transactionSnapshot) TakenAt() time.Time {
+	return s.takenAt
+}
+
+func (s *transactionSnapshot) GetByID(ctx context.Context, id string) (*domain.Transaction, error) {
+	tx, exists := s.byID[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: transaction %s", repository.ErrNotFound, id)
+	}
+	return tx.Clone(), nil
+}
+
+func (s *transactionSnapshot) Stream(ctx context.Context, filter repository.TransactionFilter) iter.Seq2[*domain.Transaction, error] {
+	return func(yield func(*domain.Transaction, error) bool) {
+		for i, tx := range s.transactions {
+			if i%streamBatchSize == 0 {
+				if err := ctx.Err(); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			if filter.Matches(tx) && !yield(tx.Clone(), nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *transactionSnapshot) Iterate(ctx context.Context, filter repository.TransactionFilter, fn func(*domain.Transaction) error) error {
+	return repository.IterateSeq(s.Stream(ctx, filter), fn)
+}
+
+func (s *transactionSnapshot) Release() {
+	s.transactions = nil
+	s.byID = nil
+}
+
+type accountSnapshot struct {
+	takenAt  time.Time
+	accounts map[string]*domain.Account
+}
+
+func (r *AccountRepository) SnapshotAccounts(ctx context.Context) (repository.AccountSnapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := &accountSnapshot{
+		takenAt:  time.Now(),
+		accounts: make(map[string]*domain.Account, len(r.accounts)),
+	}
+	for id, account := range r.accounts {
+		snapshot.accounts[id] = account.Clone()
+	}
+	return snapshot, nil
+}
+
+func (s *accountSnapshot) TakenAt() time.Time {
+	return s.takenAt
+}
+
+func (s *accountSnapshot) GetByID(ctx context.Context, id string) (*domain.Account, error) {
+	account, exists := s.accounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: account %s", repository.ErrNotFound, id)
+	}
+	return account.Clone(), nil
+}
+
+func (s *accountSnapshot) GetAll(ctx context.Context) ([]*domain.Account, error) {
+	result := make([]*domain.Account, 0, len(s.accounts))
+	for _, account := range s.accounts {
+		result = append(result, account.Clone())
+	}
+	slices.SortFunc(result, func(a, b *domain.Account) int { return strings.Compare(a.ID, b.ID) })
+	return result, nil
+}
+
+func (s *accountSnapshot) Release() {
+	s.accounts = nil
+}
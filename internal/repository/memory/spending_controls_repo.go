@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type SpendingControlsRepository struct {
+	mu       sync.RWMutex
+	controls map[string]*domain.SpendingControls
+}
+
+func NewSpendingControlsRepository() *SpendingControlsRepository {
+	return &SpendingControlsRepository{
+		controls: make(map[string]*domain.SpendingControls),
+	}
+}
+
+func (r *SpendingControlsRepository) Save(ctx context.Context, controls *domain.SpendingControls) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	controls.UpdatedAt = time.Now()
+	r.controls[controls.AccountID] = controls.Clone()
+
+	return nil
+}
+
+func (r *SpendingControlsRepository) GetByAccountID(ctx context.Context, accountID string) (*domain.SpendingControls, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	controls, exists := r.controls[accountID]
+	if !exists {
+		return nil, fmt.Errorf("%w: spending controls for account %s", repository.ErrNotFound, accountID)
+	}
+	return controls.Clone(), nil
+}
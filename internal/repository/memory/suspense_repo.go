@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type SuspenseRepository struct {
+	mu    sync.RWMutex
+	items map[string]*domain.SuspenseItem
+}
+
+func NewSuspenseRepository() *SuspenseRepository {
+	return &SuspenseRepository{
+		items: make(map[string]*domain.SuspenseItem),
+	}
+}
+
+func (r *SuspenseRepository) Save(ctx context.Context, item *domain.SuspenseItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.items[item.TransactionID]; exists {
+		return fmt.Errorf("%w: suspense item %s", repository.ErrDuplicate, item.TransactionID)
+	}
+	copied := *item
+	r.items[item.TransactionID] = &copied
+
+	return nil
+}
+
+func (r *SuspenseRepository) GetByTransactionID(ctx context.Context, transactionID string) (*domain.SuspenseItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, exists := r.items[transactionID]
+	if !exists {
+		return nil, fmt.Errorf("%w: suspense item %s", repository.ErrNotFound, transactionID)
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (r *SuspenseRepository) Update(ctx context.Context, item *domain.SuspenseItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.items[item.TransactionID]; !exists {
+		return fmt.Errorf("%w: suspense item %s", repository.ErrNotFound, item.TransactionID)
+	}
+	copied := *item
+	r.items[item.TransactionID] = &copied
+
+	return nil
+}
+
+func (r *SuspenseRepository) List(ctx context.Context, status domain.SuspenseStatus) ([]*domain.SuspenseItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.SuspenseItem{}
+	for _, item := range r.items {
+		if status == "" || item.Status == status {
+			copied := *item
+			result = append(result, &copied)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
@@ -5,22 +5,34 @@ import (
 	_ "errors"
 	"finance_manager/internal/domain"
 	"finance_manager/internal/repository"
+	"finance_manager/pkg/search"
 	"fmt"
+	"iter"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
+const streamBatchSize = 256
+
 type TransactionRepository struct {
 	mu           sync.RWMutex
 	transactions map[string]*domain.Transaction
 	index        map[string][]string
+	references   map[string]string
+	sequences    map[int]int64
+	searchIndex  *search.Index
 }
 
 func NewTransactionRepository() *TransactionRepository {
 	return &TransactionRepository{
 		transactions: make(map[string]*domain.Transaction),
 		index:        make(map[string][]string),
+		references:   make(map[string]string),
+		sequences:    make(map[int]int64),
+		searchIndex:  search.New(),
 	}
 }
 
@@ -28,12 +40,72 @@ func (r *TransactionRepository) Save(ctx context.Context, tx *domain.Transaction
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.transactions[tx.ID]; exists {
-		return fmt.Errorf("%w: transaction %s", repository.ErrDuplicate, tx.ID)
+	if err := r.checkNewLocked(tx); err != nil {
+		return err
+	}
+	r.saveLocked(tx)
+	return nil
+}
+
+func (r *TransactionRepository) SaveAll(ctx context.Context, txs []*domain.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[string]bool, len(txs))
+	references := make(map[string]bool, len(txs))
+	for _, tx := range txs {
+		if err := r.checkNewLocked(tx); err != nil {
+			return err
+		}
+		if ids[tx.ID] {
+			return fmt.Errorf("%w: transaction %s appears twice in batch", repository.ErrDuplicate, tx.ID)
+		}
+		ids[tx.ID] = true
+		if tx.Reference != "" {
+			if references[tx.Reference] {
+				return fmt.Errorf("%w: reference %s appears twice in batch", repository.ErrDuplicate, tx.Reference)
+			}
+			references[tx.Reference] = true
+		}
+	}
+
+	for _, tx := range txs {
+		r.saveLocked(tx)
+	}
+	return nil
+}
+
+func (r *TransactionRepository) checkNewLocked(tx *domain.Transaction) error {
+	if existing, exists := r.transactions[tx.ID]; exists {
+		if existing == tx {
+			return fmt.Errorf("%w: transaction %s", repository.ErrDuplicate, tx.ID)
+		}
+		return fmt.Errorf("%w: %w: transaction %s", repository.ErrDuplicate, repository.ErrIDCollision, tx.ID)
+	}
+	if tx.Reference != "" {
+		if _, exists := r.references[tx.Reference]; exists {
+			return fmt.Errorf("%w: reference %s", repository.ErrDuplicate, tx.Reference)
+		}
+	}
+	return nil
+}
+
+func (r *TransactionRepository) saveLocked(tx *domain.Transaction) {
+	if tx.Reference == "" {
+		year := time.Now().Year()
+		for tx.Reference == "" {
+			r.sequences[year]++
+			reference := domain.FormatReference(year, r.sequences[year])
+			if _, taken := r.references[reference]; !taken {
+				tx.Reference = reference
+			}
+		}
 	}
 
 	tx.UpdatedAt = time.Now()
 	r.transactions[tx.ID] = tx
+	r.references[tx.Reference] = tx.ID
+	r.searchIndex.Put(tx.ID, tx.ID, tx.Reference, tx.Description, tx.FromAccountID, tx.ToAccountID)
 
 	if tx.FromAccountID != "" {
 		r.index[tx.FromAccountID] = append(r.index[tx.FromAccountID], tx.ID)
@@ -41,8 +113,6 @@ func (r *TransactionRepository) Save(ctx context.Context, tx *domain.Transaction
 	if tx.ToAccountID != "" {
 		r.index[tx.ToAccountID] = append(r.index[tx.ToAccountID], tx.ID)
 	}
-
-	return nil
 }
 
 func (r *TransactionRepository) GetByID(ctx context.Context, id string) (*domain.Transaction, error) {
@@ -56,6 +126,17 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id string) (*domain
 	return tx, nil
 }
 
+func (r *TransactionRepository) GetByReference(ctx context.Context, reference string) (*domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.references[reference]
+	if !exists {
+		return nil, fmt.Errorf("%w: transaction reference %s", repository.ErrNotFound, reference)
+	}
+	return r.transactions[id], nil
+}
+
 func (r *TransactionRepository) GetByAccountID(ctx context.Context, accountID string, limit, offset int) ([]*domain.Transaction, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -86,6 +167,21 @@ func (r *TransactionRepository) GetByAccountID(ctx context.Context, accountID st
 	return result, nil
 }
 
+func (r *TransactionRepository) Search(ctx context.Context, query string, limit int) ([]repository.SearchHit[*domain.Transaction], error) {
+	hits := r.searchIndex.Search(query, limit)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]repository.SearchHit[*domain.Transaction], 0, len(hits))
+	for _, hit := range hits {
+		if tx, exists := r.transactions[hit.ID]; exists {
+			result = append(result, repository.SearchHit[*domain.Transaction]{Item: tx, Score: hit.Score})
+		}
+	}
+	return result, nil
+}
+
 func (r *TransactionRepository) GetByStatus(ctx context.Context, status domain.TransactionStatus) ([]*domain.Transaction, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -122,6 +218,78 @@ func (r *TransactionRepository) GetByPeriod(ctx context.Context, from, to time.T
 	return result, nil
 }
 
+func (r *TransactionRepository) Stream(ctx context.Context, filter repository.TransactionFilter) iter.Seq2[*domain.Transaction, error] {
+	return func(yield func(*domain.Transaction, error) bool) {
+		ids := r.matchingIDs(filter)
+		for start := 0; start < len(ids); start += streamBatchSize {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, tx := range r.batch(ids[start:min(start+streamBatchSize, len(ids))]) {
+				if !yield(tx, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *TransactionRepository) Iterate(ctx context.Context, filter repository.TransactionFilter, fn func(*domain.Transaction) error) error {
+	return repository.IterateSeq(r.Stream(ctx, filter), fn)
+}
+
+func (r *TransactionRepository) matchingIDs(filter repository.TransactionFilter) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type key struct {
+		id        string
+		createdAt time.Time
+	}
+	var keys []key
+	collect := func(tx *domain.Transaction) {
+		if filter.Matches(tx) {
+			keys = append(keys, key{id: tx.ID, createdAt: tx.CreatedAt})
+		}
+	}
+	if filter.AccountID != "" {
+		for _, id := range r.index[filter.AccountID] {
+			collect(r.transactions[id])
+		}
+	} else {
+		for _, tx := range r.transactions {
+			collect(tx)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b key) int {
+		if c := a.createdAt.Compare(b.createdAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	ids := make([]string, len(keys))
+	for i, k := range keys {
+		ids[i] = k.id
+	}
+	return ids
+}
+
+func (r *TransactionRepository) batch(ids []string) []*domain.Transaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Transaction, 0, len(ids))
+	for _, id := range ids {
+		if tx, exists := r.transactions[id]; exists {
+			result = append(result, tx)
+		}
+	}
+	return result
+}
+
 func (r *TransactionRepository) UpdateStatus(ctx context.Context, id string, status domain.TransactionStatus) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -138,6 +306,23 @@ func (r *TransactionRepository) UpdateStatus(ctx context.Context, id string, sta
 	return nil
 }
 
+func (r *TransactionRepository) TransitionStatus(ctx context.Context, id string, from, to domain.TransactionStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx, exists := r.transactions[id]
+	if !exists {
+		return fmt.Errorf("%w: transaction %s", repository.ErrNotFound, id)
+	}
+	if tx.Status != from {
+		return fmt.Errorf("%w: transaction %s is %s, not %s", repository.ErrTransactionConflict, id, tx.Status, from)
+	}
+
+	tx.Status = to
+	tx.UpdatedAt = time.Now()
+	return nil
+}
+
 func (r *TransactionRepository) GetDailyVolume(ctx context.Context, accountID string, date time.Time) (float64, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+type WebhookEventRepository struct {
+	mu     sync.RWMutex
+	events []*domain.WebhookEvent
+	byID   map[string]*domain.WebhookEvent
+}
+
+func NewWebhookEventRepository() *WebhookEventRepository {
+	return &WebhookEventRepository{
+		byID: make(map[string]*domain.WebhookEvent),
+	}
+}
+
+func (r *WebhookEventRepository) Save(ctx context.Context, event *domain.WebhookEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byID[event.ID]; exists {
+		return fmt.Errorf("%w: webhook event %s", repository.ErrDuplicate, event.ID)
+	}
+
+	r.byID[event.ID] = event
+	idx, _ := slices.BinarySearchFunc(r.events, event.OccurredAt, func(e *domain.WebhookEvent, t time.Time) int {
+		return e.OccurredAt.Compare(t)
+	})
+	for idx < len(r.events) && !r.events[idx].OccurredAt.After(event.OccurredAt) {
+		idx++
+	}
+	r.events = slices.Insert(r.events, idx, event)
+
+	return nil
+}
+
+func (r *WebhookEventRepository) GetByID(ctx context.Context, id string) (*domain.WebhookEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event, exists := r.byID[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: webhook event %s", repository.ErrNotFound, id)
+	}
+	return event, nil
+}
+
+func (r *WebhookEventRepository) List(ctx context.Context, from, to time.Time) ([]*domain.WebhookEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.WebhookEvent
+	for _, event := range r.events {
+		if event.OccurredAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !event.OccurredAt.Before(to) {
+			break
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func (r *WebhookEventRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := 0
+	for removed < len(r.events) && r.events[removed].OccurredAt.Before(cutoff) {
+		delete(r.byID, r.events[removed].ID)
+		removed++
+	}
+	r.events = slices.Delete(r.events, 0, removed)
+
+	return removed, nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const SnapshotFileVersion = 1
+
+var ErrMigrationVerification = errors.New("migration verification failed")
+
+type SnapshotFile struct {
+	Version      int                   `json:"version"`
+	TakenAt      time.Time             `json:"taken_at"`
+	Accounts     []*domain.Account     `json:"accounts"`
+	Transactions []*domain.Transaction `json:"transactions"`
+}
+
+func WriteSnapshotFile(ctx context.Context, w io.Writer, accounts AccountSnapshot, transactions TransactionSnapshot) error {
+	file := SnapshotFile{Version: SnapshotFileVersion, TakenAt: transactions.TakenAt()}
+
+	var err error
+	file.Accounts, err = accounts.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for tx, err := range transactions.Stream(ctx, TransactionFilter{}) {
+		if err != nil {
+			return err
+		}
+		file.Transactions = append(file.Transactions, tx)
+	}
+
+	return json.NewEncoder(w).Encode(file)
+}
+
+func ReadSnapshotFile(r io.Reader) (*SnapshotFile, error) {
+	var file SnapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if file.Version < 1 || file.Version > SnapshotFileVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", file.Version)
+	}
+	return &file, nil
+}
+
+type MigrationProgress struct {
+	Stage string
+	Done  int
+	Total int
+}
+
+type MigrationOptions struct {
+	DryRun    bool
+	BatchSize int
+	Progress  func(MigrationProgress)
+}
+
+type MigrationReport struct {
+	DryRun       bool
+	Accounts     int
+	Transactions int
+	Balances     map[string]float64
+	Mismatches   []string
+	Duration     time.Duration
+}
+
+func Migrate(ctx context.Context, src *SnapshotFile, accounts AccountRepository, transactions TransactionRepository, opts MigrationOptions) (*MigrationReport, error) {
+	start := time.Now()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	progress := func(stage string, done, total int) {
+		if opts.Progress != nil {
+			opts.Progress(MigrationProgress{Stage: stage, Done: done, Total: total})
+		}
+	}
+
+	report := &MigrationReport{
+		DryRun:       opts.DryRun,
+		Accounts:     len(src.Accounts),
+		Transactions: len(src.Transactions),
+		Balances:     make(map[string]float64),
+	}
+
+	known := make(map[string]bool, len(src.Accounts))
+	for _, account := range src.Accounts {
+		if known[account.ID] {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("account %s appears more than once", account.ID))
+		}
+		known[account.ID] = true
+		report.Balances[account.Currency] += account.Balance
+	}
+	for _, tx := range src.Transactions {
+		for _, id := range []string{tx.FromAccountID, tx.ToAccountID} {
+			if id != "" && !known[id] {
+				report.Mismatches = append(report.Mismatches, fmt.Sprintf("transaction %s references unknown account %s", tx.ID, id))
+			}
+		}
+	}
+	if len(report.Mismatches) > 0 {
+		report.Duration = time.Since(start)
+		return report, fmt.Errorf("%w: source snapshot has %d problems", ErrMigrationVerification, len(report.Mismatches))
+	}
+	if opts.DryRun {
+		report.Duration = time.Since(start)
+		return report, nil
+	}
+
+	for i, account := range src.Accounts {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if err := accounts.Save(ctx, account.Clone()); err != nil {
+			return report, fmt.Errorf("failed to migrate account %s: %w", account.ID, err)
+		}
+		if (i+1)%opts.BatchSize == 0 || i+1 == len(src.Accounts) {
+			progress("accounts", i+1, len(src.Accounts))
+		}
+	}
+
+	for offset := 0; offset < len(src.Transactions); offset += opts.BatchSize {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		end := min(offset+opts.BatchSize, len(src.Transactions))
+		batch := make([]*domain.Transaction, 0, end-offset)
+		for _, tx := range src.Transactions[offset:end] {
+			batch = append(batch, tx.Clone())
+		}
+		if err := transactions.SaveAll(ctx, batch); err != nil {
+			return report, fmt.Errorf("failed to migrate transactions %d-%d: %w", offset, end-1, err)
+		}
+		progress("transactions", end, len(src.Transactions))
+	}
+
+	report.Mismatches = verifyMigration(ctx, src, accounts, transactions)
+	progress("verification", len(src.Accounts)+len(src.Transactions), len(src.Accounts)+len(src.Transactions))
+	report.Duration = time.Since(start)
+	if len(report.Mismatches) > 0 {
+		return report, fmt.Errorf("%w: %d mismatches", ErrMigrationVerification, len(report.Mismatches))
+	}
+	return report, nil
+}
+
+func verifyMigration(ctx context.Context, src *SnapshotFile, accounts AccountRepository, transactions TransactionRepository) []string {
+	var mismatches []string
+
+	totals := make(map[string]float64)
+	for _, want := range src.Accounts {
+		got, err := accounts.GetByID(ctx, want.ID)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("account %s: %v", want.ID, err))
+			continue
+		}
+		if math.Abs(got.Balance-want.Balance) > 1e-9 || got.Currency != want.Currency || got.Status != want.Status || got.UserID != want.UserID {
+			mismatches = append(mismatches, fmt.Sprintf("account %s: balance %.2f %s, want %.2f %s", want.ID, got.Balance, got.Currency, want.Balance, want.Currency))
+		}
+		totals[got.Currency] += got.Balance
+		totals[want.Currency] -= want.Balance
+	}
+	for currency, delta := range totals {
+		if math.Abs(delta) > 1e-9 {
+			mismatches = append(mismatches, fmt.Sprintf("total %s balance differs by %.2f", currency, delta))
+		}
+	}
+
+	for _, want := range src.Transactions {
+		got, err := transactions.GetByID(ctx, want.ID)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("transaction %s: %v", want.ID, err))
+			continue
+		}
+		if got.Amount != want.Amount || got.Status != want.Status || got.FromAccountID != want.FromAccountID || got.ToAccountID != want.ToAccountID {
+			mismatches = append(mismatches, fmt.Sprintf("transaction %s differs after migration", want.ID))
+		}
+	}
+	return mismatches
+}
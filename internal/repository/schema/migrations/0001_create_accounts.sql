@@ -0,0 +1,17 @@
+CREATE TABLE accounts (
+    id               TEXT PRIMARY KEY,
+    user_id          TEXT NOT NULL,
+    balance          NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    currency         CHAR(3) NOT NULL,
+    product_id       TEXT,
+    status           TEXT NOT NULL,
+    daily_limit      NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    monthly_limit    NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    risk_category    TEXT NOT NULL DEFAULT '',
+    system_role      TEXT,
+    created_at       TIMESTAMPTZ NOT NULL,
+    last_activity_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX accounts_user_id_idx ON accounts (user_id);
+CREATE INDEX accounts_risk_category_idx ON accounts (risk_category);
@@ -0,0 +1,22 @@
+CREATE TABLE transactions (
+    id              TEXT PRIMARY KEY,
+    reference       TEXT UNIQUE,
+    type            TEXT NOT NULL,
+    amount          NUMERIC(20, 4) NOT NULL,
+    currency        CHAR(3) NOT NULL,
+    from_account_id TEXT REFERENCES accounts (id),
+    to_account_id   TEXT REFERENCES accounts (id),
+    description     TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL,
+    risk_score      INTEGER NOT NULL DEFAULT 0,
+    fraud_flags     JSONB,
+    explanation     JSONB,
+    metadata        JSONB,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX transactions_from_account_idx ON transactions (from_account_id, created_at);
+CREATE INDEX transactions_to_account_idx ON transactions (to_account_id, created_at);
+CREATE INDEX transactions_status_idx ON transactions (status);
+CREATE INDEX transactions_created_at_idx ON transactions (created_at);
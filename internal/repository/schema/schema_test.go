@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrations_EmbeddedAreOrdered(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations failed: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+	for i, migration := range migrations {
+		if migration.SQL == "" || migration.Name == "" {
+			t.Errorf("migration %d is incomplete: %+v", migration.Version, migration)
+		}
+		if i > 0 && migration.Version <= migrations[i-1].Version {
+			t.Errorf("migrations out of order at version %d", migration.Version)
+		}
+	}
+}
+
+func TestLoadMigrations_RejectsBadFiles(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing version": {"m/create.sql": {Data: []byte("SELECT 1")}},
+		"duplicate":       {"m/0001_a.sql": {Data: []byte("SELECT 1")}, "m/1_b.sql": {Data: []byte("SELECT 1")}},
+	}
+	for name, fsys := range cases {
+		if _, err := loadMigrations(fsys, "m"); !errors.Is(err, ErrInvalidMigration) {
+			t.Errorf("%s: expected ErrInvalidMigration, got %v", name, err)
+		}
+	}
+
+	migrations, err := loadMigrations(fstest.MapFS{
+		"m/0010_later.sql": {Data: []byte("SELECT 2")},
+		"m/0002_first.sql": {Data: []byte("SELECT 1")},
+		"m/README.md":      {Data: []byte("ignored")},
+	}, "m")
+	if err != nil || len(migrations) != 2 || migrations[0].Name != "first" || migrations[1].Version != 10 {
+		t.Errorf("expected two sorted migrations, got %+v (%v)", migrations, err)
+	}
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"iter"
+	"time"
+)
+
+var (
+	_ TransactionRepository = (*SplitTransactionRepository)(nil)
+	_ AccountRepository     = (*SplitAccountRepository)(nil)
+)
+
+type SplitTransactionRepository struct {
+	primary TransactionRepository
+	replica TransactionRepository
+}
+
+func NewSplitTransactionRepository(primary, replica TransactionRepository) *SplitTransactionRepository {
+	if replica == nil {
+		replica = primary
+	}
+	return &SplitTransactionRepository{primary: primary, replica: replica}
+}
+
+func (r *SplitTransactionRepository) Primary() TransactionRepository {
+	return r.primary
+}
+
+func (r *SplitTransactionRepository) Replica() TransactionRepository {
+	return r.replica
+}
+
+func (r *SplitTransactionRepository) SnapshotTransactions(ctx context.Context) (TransactionSnapshot, error) {
+	if snapshotter, ok := r.replica.(TransactionSnapshotter); ok {
+		return snapshotter.SnapshotTransactions(ctx)
+	}
+	return nil, ErrSnapshotUnsupported
+}
+
+func (r *SplitTransactionRepository) Save(ctx context.Context, transaction *domain.Transaction) error {
+	return r.primary.Save(ctx, transaction)
+}
+
+func (r *SplitTransactionRepository) SaveAll(ctx context.Context, transactions []*domain.Transaction) error {
+	return r.primary.SaveAll(ctx, transactions)
+}
+
+func (r *SplitTransactionRepository) UpdateStatus(ctx context.Context, id string, status domain.TransactionStatus) error {
+	return r.primary.UpdateStatus(ctx, id, status)
+}
+
+func (r *SplitTransactionRepository) TransitionStatus(ctx context.Context, id string, from, to domain.TransactionStatus) error {
+	return r.primary.TransitionStatus(ctx, id, from, to)
+}
+
+func (r *SplitTransactionRepository) GetByID(ctx context.Context, id string) (*domain.Transaction, error) {
+	return r.replica.GetByID(ctx, id)
+}
+
+func (r *SplitTransactionRepository) GetByReference(ctx context.Context, reference string) (*domain.Transaction, error) {
+	return r.replica.GetByReference(ctx, reference)
+}
+
+func (r *SplitTransactionRepository) GetByAccountID(ctx context.Context, accountID string, limit, offset int) ([]*domain.Transaction, error) {
+	return r.replica.GetByAccountID(ctx, accountID, limit, offset)
+}
+
+func (r *SplitTransactionRepository) GetByStatus(ctx context.Context, status domain.TransactionStatus) ([]*domain.Transaction, error) {
+	return r.replica.GetByStatus(ctx, status)
+}
+
+func (r *SplitTransactionRepository) GetByPeriod(ctx context.Context, from, to time.Time) ([]*domain.Transaction, error) {
+	return r.replica.GetByPeriod(ctx, from, to)
+}
+
+func (r *SplitTransactionRepository) Stream(ctx context.Context, filter TransactionFilter) iter.Seq2[*domain.Transaction, error] {
+	return r.replica.Stream(ctx, filter)
+}
+
+func (r *SplitTransactionRepository) Iterate(ctx context.Context, filter TransactionFilter, fn func(*domain.Transaction) error) error {
+	return r.replica.Iterate(ctx, filter, fn)
+}
+
+func (r *SplitTransactionRepository) GetDailyVolume(ctx context.Context, accountID string, date time.Time) (float64, error) {
+	return r.replica.GetDailyVolume(ctx, accountID, date)
+}
+
+func (r *SplitTransactionRepository) GetMonthlyVolume(ctx context.Context, accountID string, year int, month time.Month) (float64, error) {
+	return r.replica.GetMonthlyVolume(ctx, accountID, year, month)
+}
+
+type SplitAccountRepository struct {
+	primary AccountRepository
+	replica AccountRepository
+}
+
+func NewSplitAccountRepository(primary, replica AccountRepository) *SplitAccountRepository {
+	if replica == nil {
+		replica = primary
+	}
+	return &SplitAccountRepository{primary: primary, replica: replica}
+}
+
+func (r *SplitAccountRepository) Primary() AccountRepository {
+	return r.primary
+}
+
+func (r *SplitAccountRepository) Replica() AccountRepository {
+	return r.replica
+}
+
+func (r *SplitAccountRepository) SnapshotAccounts(ctx context.Context) (AccountSnapshot, error) {
+	if snapshotter, ok := r.replica.(AccountSnapshotter); ok {
+		return snapshotter.SnapshotAccounts(ctx)
+	}
+	return nil, ErrSnapshotUnsupported
+}
+
+func (r *SplitAccountRepository) Save(ctx context.Context, account *domain.Account) error {
+	return r.primary.Save(ctx, account)
+}
+
+func (r *SplitAccountRepository) Update(ctx context.Context, account *domain.Account) error {
+	return r.primary.Update(ctx, account)
+}
+
+func (r *SplitAccountRepository) UpdateBalance(ctx context.Context, id string, amount float64) error {
+	return r.primary.UpdateBalance(ctx, id, amount)
+}
+
+func (r *SplitAccountRepository) ApplyBalanceUpdates(ctx context.Context, updates []domain.BalanceUpdate) error {
+	return r.primary.ApplyBalanceUpdates(ctx, updates)
+}
+
+func (r *SplitAccountRepository) UpdateStatus(ctx context.Context, id string, status domain.AccountStatus) error {
+	return r.primary.UpdateStatus(ctx, id, status)
+}
+
+func (r *SplitAccountRepository) GetByID(ctx context.Context, id string) (*domain.Account, error) {
+	return r.replica.GetByID(ctx, id)
+}
+
+func (r *SplitAccountRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Account, error) {
+	return r.replica.GetByUserID(ctx, userID)
+}
+
+func (r *SplitAccountRepository) GetAllActive(ctx context.Context) ([]*domain.Account, error) {
+	return r.replica.GetAllActive(ctx)
+}
+
+func (r *SplitAccountRepository) GetByRiskCategory(ctx context.Context, category string) ([]*domain.Account, error) {
+	return r.replica.GetByRiskCategory(ctx, category)
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const activityTransactionBatch = 500
+
+var ErrInvalidActivityCursor = errors.New("invalid activity cursor")
+
+type ActivityKind string
+
+const (
+	ActivityTransaction  ActivityKind = "transaction"
+	ActivityStatusChange ActivityKind = "status_change"
+	ActivityLimitChange  ActivityKind = "limit_change"
+	ActivityNotification ActivityKind = "notification"
+)
+
+type ActivityEntry struct {
+	ID          string            `json:"id"`
+	Kind        ActivityKind      `json:"kind"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+	Summary     string            `json:"summary"`
+	ReferenceID string            `json:"reference_id"`
+	Status      string            `json:"status,omitempty"`
+	Amount      float64           `json:"amount,omitempty"`
+	Currency    string            `json:"currency,omitempty"`
+	Direction   string            `json:"direction,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+type ActivityFeed struct {
+	AccountID  string          `json:"account_id"`
+	Entries    []ActivityEntry `json:"entries"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type ActivityService struct {
+	accounts     repository.AccountRepository
+	transactions repository.TransactionRepository
+	limitChanges repository.LimitChangeRepository
+	audit        repository.AuditRepository
+	inbox        repository.InboxRepository
+	logger       *slog.Logger
+}
+
+func NewActivityService(accounts repository.AccountRepository, transactions repository.TransactionRepository, logger *slog.Logger) *ActivityService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ActivityService{accounts: accounts, transactions: transactions, logger: logger}
+}
+
+func (s *ActivityService) WithLimitChanges(repo repository.LimitChangeRepository) *ActivityService {
+	s.limitChanges = repo
+	return s
+}
+
+func (s *ActivityService) WithAuditLog(repo repository.AuditRepository) *ActivityService {
+	s.audit = repo
+	return s
+}
+
+func (s *ActivityService) WithInbox(repo repository.InboxRepository) *ActivityService {
+	s.inbox = repo
+	return s
+}
+
+func (s *ActivityService) Feed(ctx context.Context, accountID, cursor string, limit int) (*ActivityFeed, error) {
+	after, hasCursor, err := decodeActivityCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.collect(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []ActivityEntry{}
+	}
+	slices.SortFunc(entries, compareActivity)
+	if hasCursor {
+		start, _ := slices.BinarySearchFunc(entries, after, compareActivity)
+		if start < len(entries) && compareActivity(entries[start], after) == 0 {
+			start++
+		}
+		entries = entries[start:]
+	}
+
+	feed := &ActivityFeed{AccountID: account.ID, Entries: entries}
+	if limit > 0 && len(entries) > limit {
+		feed.Entries = entries[:limit]
+		feed.NextCursor = encodeActivityCursor(feed.Entries[limit-1])
+	}
+	return feed, nil
+}
+
+func (s *ActivityService) collect(ctx context.Context, account *domain.Account) ([]ActivityEntry, error) {
+	transactions, err := s.accountTransactions(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ActivityEntry
+	txIDs := make(map[string]bool, len(transactions))
+	for _, tx := range transactions {
+		txIDs[tx.ID] = true
+		entries = append(entries, transactionActivity(tx, account.ID))
+		if tx.Status == domain.StatusExpired || tx.Status == domain.StatusCancelled {
+			entries = append(entries, ActivityEntry{
+				ID:          "status:" + tx.ID + ":" + string(tx.Status),
+				Kind:        ActivityStatusChange,
+				OccurredAt:  tx.UpdatedAt,
+				Summary:     transactionDescription(tx, account.ID) + " " + string(tx.Status),
+				ReferenceID: tx.ID,
+				Status:      string(tx.Status),
+			})
+		}
+	}
+
+	if s.audit != nil {
+		audits, err := s.audit.GetByEntity(ctx, processor.AuditEntityAccount, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account audit log: %w", err)
+		}
+		for _, tx := range transactions {
+			overrides, err := s.audit.GetByEntity(ctx, processor.AuditEntityTransaction, tx.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load transaction audit log: %w", err)
+			}
+			audits = append(audits, overrides...)
+		}
+		for _, entry := range audits {
+			if entry.Action == processor.AuditActionTrace {
+				continue
+			}
+			entries = append(entries, auditActivity(entry))
+		}
+	}
+
+	if s.limitChanges != nil {
+		changes, err := s.limitChanges.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load limit changes: %w", err)
+		}
+		for _, change := range changes {
+			entries = append(entries, limitChangeActivity(change)...)
+		}
+	}
+
+	if s.inbox != nil && account.UserID != "" {
+		notifications, err := s.inbox.GetByUser(ctx, account.UserID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load notifications: %w", err)
+		}
+		for _, notification := range notifications {
+			if notification.Metadata["account_id"] == account.ID || txIDs[notification.Metadata["transaction_id"]] {
+				entries = append(entries, notificationActivity(notification))
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *ActivityService) accountTransactions(ctx context.Context, accountID string) ([]*domain.Transaction, error) {
+	var result []*domain.Transaction
+	for offset := 0; ; offset += activityTransactionBatch {
+		batch, err := s.transactions.GetByAccountID(ctx, accountID, activityTransactionBatch, offset)
+		if errors.Is(err, repository.ErrNotFound) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transactions: %w", err)
+		}
+		for _, tx := range batch {
+			result = append(result, tx.Clone())
+		}
+		if len(batch) < activityTransactionBatch {
+			return result, nil
+		}
+	}
+}
+
+func transactionActivity(tx *domain.Transaction, accountID string) ActivityEntry {
+	direction := "credit"
+	if tx.FromAccountID == accountID {
+		direction = "debit"
+	}
+
+	summary := transactionDescription(tx, accountID)
+	if tx.Status != domain.StatusCompleted {
+		summary += " (" + string(tx.Status) + ")"
+	}
+	if tx.Description != "" {
+		summary += " - " + tx.Description
+	}
+
+	return ActivityEntry{
+		ID:          "transaction:" + tx.ID,
+		Kind:        ActivityTransaction,
+		OccurredAt:  tx.CreatedAt,
+		Summary:     summary,
+		ReferenceID: tx.ID,
+		Status:      string(tx.Status),
+		Amount:      tx.Amount,
+		Currency:    tx.Currency,
+		Direction:   direction,
+	}
+}
+
+func transactionDescription(tx *domain.Transaction, accountID string) string {
+	amount := fmt.Sprintf("%.2f %s", tx.Amount, tx.Currency)
+	switch {
+	case tx.Type == domain.TypeTransfer && tx.FromAccountID == accountID:
+		return fmt.Sprintf("Transfer of %s to %s", amount, tx.ToAccountID)
+	case tx.Type == domain.TypeTransfer:
+		return fmt.Sprintf("Transfer of %s from %s", amount, tx.FromAccountID)
+	default:
+		return fmt.Sprintf("%s of %s", humanize(string(tx.Type)), amount)
+	}
+}
+
+func auditActivity(entry *domain.AuditEntry) ActivityEntry {
+	summary := humanize(entry.Action)
+	if entry.Actor != "" {
+		summary += " by " + entry.Actor
+	}
+	if entry.Reason != "" {
+		summary += ": " + entry.Reason
+	}
+
+	return ActivityEntry{
+		ID:          "audit:" + entry.ID,
+		Kind:        ActivityStatusChange,
+		OccurredAt:  entry.CreatedAt,
+		Summary:     summary,
+		ReferenceID: entry.EntityID,
+		Status:      entry.Action,
+		Details:     entry.Details,
+	}
+}
+
+func limitChangeActivity(change *domain.LimitChange) []ActivityEntry {
+	limit := humanize(string(change.LimitType)) + " limit"
+	entries := []ActivityEntry{{
+		ID:          "limit:" + change.ID + ":requested",
+		Kind:        ActivityLimitChange,
+		OccurredAt:  change.CreatedAt,
+		Summary:     fmt.Sprintf("%s change to %.2f requested", limit, change.RequestedLimit),
+		ReferenceID: change.ID,
+		Status:      "requested",
+	}}
+
+	var summary string
+	switch change.Status {
+	case domain.LimitChangeApplied:
+		summary = fmt.Sprintf("%s changed from %.2f to %.2f", limit, change.PreviousLimit, change.RequestedLimit)
+	case domain.LimitChangeCancelled, domain.LimitChangeExpired:
+		summary = fmt.Sprintf("%s change to %.2f %s", limit, change.RequestedLimit, change.Status)
+	default:
+		return entries
+	}
+	return append(entries, ActivityEntry{
+		ID:          "limit:" + change.ID + ":" + string(change.Status),
+		Kind:        ActivityLimitChange,
+		OccurredAt:  change.UpdatedAt,
+		Summary:     summary,
+		ReferenceID: change.ID,
+		Status:      string(change.Status),
+	})
+}
+
+func notificationActivity(notification *domain.InboxNotification) ActivityEntry {
+	summary := notification.Subject
+	if summary == "" {
+		summary = notification.Message
+	}
+	status := "unread"
+	if notification.Read {
+		status = "read"
+	}
+
+	return ActivityEntry{
+		ID:          "notification:" + notification.ID,
+		Kind:        ActivityNotification,
+		OccurredAt:  notification.CreatedAt,
+		Summary:     summary,
+		ReferenceID: notification.ID,
+		Status:      status,
+	}
+}
+
+func humanize(value string) string {
+	value = strings.ReplaceAll(value, "_", " ")
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
+
+func compareActivity(a, b ActivityEntry) int {
+	if c := b.OccurredAt.Compare(a.OccurredAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.ID, a.ID)
+}
+
+func encodeActivityCursor(entry ActivityEntry) string {
+	raw := strconv.FormatInt(entry.OccurredAt.UnixNano(), 10) + "|" + entry.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeActivityCursor(cursor string) (ActivityEntry, bool, error) {
+	if cursor == "" {
+		return ActivityEntry{}, false, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ActivityEntry{}, false, fmt.Errorf("%w: %v", ErrInvalidActivityCursor, err)
+	}
+	nanos, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return ActivityEntry{}, false, fmt.Errorf("%w: malformed cursor", ErrInvalidActivityCursor)
+	}
+	unix, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return ActivityEntry{}, false, fmt.Errorf("%w: %v", ErrInvalidActivityCursor, err)
+	}
+	return ActivityEntry{ID: id, OccurredAt: time.Unix(0, unix)}, true, nil
+}
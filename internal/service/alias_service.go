@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+)
+
+const (
+	MetadataFromAccountAlias = "from_account_alias"
+	MetadataToAccountAlias   = "to_account_alias"
+)
+
+var ErrInvalidAlias = errors.New("invalid account alias")
+
+type AliasService struct {
+	aliasRepo   repository.AccountAliasRepository
+	accountRepo repository.AccountRepository
+	logger      *slog.Logger
+}
+
+func NewAliasService(aliasRepo repository.AccountAliasRepository, accountRepo repository.AccountRepository, logger *slog.Logger) *AliasService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &AliasService{
+		aliasRepo:   aliasRepo,
+		accountRepo: accountRepo,
+		logger:      logger,
+	}
+}
+
+func (s *AliasService) Register(ctx context.Context, accountID string, aliasType domain.AliasType, value string) (*domain.AccountAlias, error) {
+	alias := &domain.AccountAlias{
+		Type:      aliasType,
+		Value:     domain.NormalizeAliasValue(aliasType, value),
+		AccountID: accountID,
+	}
+	if err := validateAlias(alias); err != nil {
+		return nil, err
+	}
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	if err := s.aliasRepo.Save(ctx, alias); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Account alias registered",
+		slog.String("account_id", accountID),
+		slog.String("alias_type", string(alias.Type)))
+	return alias, nil
+}
+
+func (s *AliasService) List(ctx context.Context, accountID string) ([]*domain.AccountAlias, error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return s.aliasRepo.GetByAccountID(ctx, accountID)
+}
+
+func (s *AliasService) Remove(ctx context.Context, accountID string, aliasType domain.AliasType, value string) error {
+	value = domain.NormalizeAliasValue(aliasType, value)
+	alias, err := s.aliasRepo.Resolve(ctx, aliasType, value)
+	if err != nil {
+		return err
+	}
+	if alias.AccountID != accountID {
+		return fmt.Errorf("%w: %s alias %s on account %s", repository.ErrNotFound, aliasType, value, accountID)
+	}
+
+	if err := s.aliasRepo.Delete(ctx, aliasType, value); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Account alias removed",
+		slog.String("account_id", accountID),
+		slog.String("alias_type", string(aliasType)))
+	return nil
+}
+
+func (s *AliasService) Lookup(ctx context.Context, aliasType domain.AliasType, value string) (*domain.AccountAlias, error) {
+	if !aliasType.Valid() {
+		return nil, fmt.Errorf("%w: unknown alias type %q", ErrInvalidAlias, aliasType)
+	}
+	return s.aliasRepo.Resolve(ctx, aliasType, domain.NormalizeAliasValue(aliasType, value))
+}
+
+func (s *AliasService) ResolveAccountID(ctx context.Context, ref string) (string, bool, error) {
+	aliasType, value, ok := domain.ParseAccountRef(ref)
+	if !ok {
+		return ref, false, nil
+	}
+	alias, err := s.aliasRepo.Resolve(ctx, aliasType, value)
+	if err != nil {
+		return "", true, err
+	}
+	return alias.AccountID, true, nil
+}
+
+func validateAlias(alias *domain.AccountAlias) error {
+	if !alias.Type.Valid() {
+		return fmt.Errorf("%w: unknown alias type %q", ErrInvalidAlias, alias.Type)
+	}
+	if alias.Value == "" {
+		return fmt.Errorf("%w: value is required", ErrInvalidAlias)
+	}
+	if alias.AccountID == "" {
+		return fmt.Errorf("%w: account_id is required", ErrInvalidAlias)
+	}
+	if alias.Type == domain.AliasIBAN && !validIBAN(alias.Value) {
+		return fmt.Errorf("%w: %q is not a valid IBAN", ErrInvalidAlias, alias.Value)
+	}
+	return nil
+}
+
+func validIBAN(iban string) bool {
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	for i, r := range iban {
+		switch {
+		case i < 2 && (r < 'A' || r > 'Z'):
+			return false
+		case i >= 2 && i < 4 && (r < '0' || r > '9'):
+			return false
+		case (r < 'A' || r > 'Z') && (r < '0' || r > '9'):
+			return false
+		}
+	}
+
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/objectstore"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"path"
+	"time"
+)
+
+const (
+	ArchiveJobName = "cold_storage_archive"
+
+	archivePeriodLayout   = "2006-01"
+	archiveManifestFormat = 1
+)
+
+var (
+	ErrInvalidArchivePeriod = errors.New("invalid archive period")
+	ErrPeriodNotClosed      = errors.New("archive period is not closed")
+	ErrAlreadyArchived      = errors.New("period is already archived")
+	ErrArchiveCorrupt       = errors.New("archive integrity check failed")
+)
+
+type ArchiveConfig struct {
+	Prefix   string
+	Interval time.Duration
+}
+
+func DefaultArchiveConfig() ArchiveConfig {
+	return ArchiveConfig{
+		Prefix:   "archive",
+		Interval: 24 * time.Hour,
+	}
+}
+
+type ArchiveContents struct {
+	Manifest     *domain.ArchiveManifest      `json:"manifest"`
+	Transactions []*domain.Transaction        `json:"transactions"`
+	Statements   []processor.StatementSummary `json:"statements"`
+}
+
+type ArchiveService struct {
+	processor *processor.TransactionProcessor
+	store     objectstore.Store
+	cfg       ArchiveConfig
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewArchiveService(txProcessor *processor.TransactionProcessor, store objectstore.Store, cfg ArchiveConfig, logger *slog.Logger) *ArchiveService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultArchiveConfig().Interval
+	}
+
+	return &ArchiveService{
+		processor: txProcessor,
+		store:     store,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *ArchiveService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     ArchiveJobName,
+		Schedule: scheduler.Every(s.cfg.Interval),
+		Run: func(ctx context.Context) error {
+			period := s.now().UTC().AddDate(0, -1, 0).Format(archivePeriodLayout)
+			_, err := s.Archive(ctx, period)
+			if errors.Is(err, ErrAlreadyArchived) {
+				return nil
+			}
+			return err
+		},
+	})
+}
+
+func ParseArchivePeriod(period string) (time.Time, time.Time, error) {
+	from, err := time.Parse(archivePeriodLayout, period)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %q is not YYYY-MM", ErrInvalidArchivePeriod, period)
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+func archiveKey(prefix, period, name string) string {
+	return path.Join(prefix, period, name)
+}
+
+func (s *ArchiveService) Archive(ctx context.Context, period string) (*domain.ArchiveManifest, error) {
+	from, to, err := ParseArchivePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	if to.After(s.now()) {
+		return nil, fmt.Errorf("%w: %s ends %s", ErrPeriodNotClosed, period, to.Format(time.DateOnly))
+	}
+
+	manifestKey := archiveKey(s.cfg.Prefix, period, "manifest.json")
+	if _, err := s.store.Get(ctx, manifestKey); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyArchived, period)
+	} else if !errors.Is(err, objectstore.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check existing manifest: %w", err)
+	}
+
+	snapshot, err := s.processor.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	var transactions []*domain.Transaction
+	filter := repository.TransactionFilter{From: from}
+	err = snapshot.Transactions.Iterate(ctx, filter, func(tx *domain.Transaction) error {
+		if tx.CreatedAt.Before(to) {
+			transactions = append(transactions, tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transactions: %w", err)
+	}
+	statements, err := snapshot.StatementSummaries(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build statements: %w", err)
+	}
+
+	manifest := &domain.ArchiveManifest{
+		Version:   archiveManifestFormat,
+		Period:    period,
+		From:      from,
+		To:        to,
+		CreatedAt: s.now().UTC(),
+	}
+	for _, part := range []struct {
+		kind    domain.ArchiveFileKind
+		records []any
+	}{
+		{domain.ArchiveTransactions, toAny(transactions)},
+		{domain.ArchiveStatements, toAny(statements)},
+	} {
+		file, err := s.writeArchiveFile(ctx, period, part.kind, part.records)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, manifestKey, data, "application/json"); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Archived period to cold storage",
+		slog.String("period", period),
+		slog.Int("transactions", len(transactions)),
+		slog.Int("statements", len(statements)))
+	return manifest, nil
+}
+
+func (s *ArchiveService) writeArchiveFile(ctx context.Context, period string, kind domain.ArchiveFileKind, records []any) (domain.ArchiveFile, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return domain.ArchiveFile{}, err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return domain.ArchiveFile{}, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	file := domain.ArchiveFile{
+		Key:     archiveKey(s.cfg.Prefix, period, string(kind)+".jsonl.gz"),
+		Kind:    kind,
+		Format:  domain.ArchiveFormatJSONLinesGzip,
+		Records: len(records),
+		Bytes:   buf.Len(),
+		SHA256:  hex.EncodeToString(sum[:]),
+	}
+	if err := s.store.Put(ctx, file.Key, buf.Bytes(), "application/gzip"); err != nil {
+		return domain.ArchiveFile{}, fmt.Errorf("failed to write %s archive: %w", kind, err)
+	}
+	return file, nil
+}
+
+func toAny[T any](items []T) []any {
+	result := make([]any, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result
+}
+
+func (s *ArchiveService) Manifest(ctx context.Context, period string) (*domain.ArchiveManifest, error) {
+	return ReadArchiveManifest(ctx, s.store, s.cfg.Prefix, period)
+}
+
+func (s *ArchiveService) Restore(ctx context.Context, period string) (*ArchiveContents, error) {
+	return RestoreArchive(ctx, s.store, s.cfg.Prefix, period)
+}
+
+func ReadArchiveManifest(ctx context.Context, store objectstore.Store, prefix, period string) (*domain.ArchiveManifest, error) {
+	if _, _, err := ParseArchivePeriod(period); err != nil {
+		return nil, err
+	}
+
+	data, err := store.Get(ctx, archiveKey(prefix, period, "manifest.json"))
+	if err != nil {
+		return nil, err
+	}
+	var manifest domain.ArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: manifest: %v", ErrArchiveCorrupt, err)
+	}
+	return &manifest, nil
+}
+
+func RestoreArchive(ctx context.Context, store objectstore.Store, prefix, period string) (*ArchiveContents, error) {
+	manifest, err := ReadArchiveManifest(ctx, store, prefix, period)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := &ArchiveContents{Manifest: manifest}
+	for _, file := range manifest.Files {
+		var err error
+		switch file.Kind {
+		case domain.ArchiveTransactions:
+			contents.Transactions, err = readArchiveFile[*domain.Transaction](ctx, store, file)
+		case domain.ArchiveStatements:
+			contents.Statements, err = readArchiveFile[processor.StatementSummary](ctx, store, file)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+func readArchiveFile[T any](ctx context.Context, store objectstore.Store, file domain.ArchiveFile) ([]T, error) {
+	data, err := store.Get(ctx, file.Key)
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != file.SHA256 {
+		return nil, fmt.Errorf("%w: %s checksum mismatch", ErrArchiveCorrupt, file.Key)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, file.Key, err)
+	}
+	defer gz.Close()
+
+	records := make([]T, 0, file.Records)
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, file.Key, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrArchiveCorrupt, file.Key, err)
+	}
+	if len(records) != file.Records {
+		return nil, fmt.Errorf("%w: %s has %d records, manifest lists %d", ErrArchiveCorrupt, file.Key, len(records), file.Records)
+	}
+	return records, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"finance_manager/pkg/objectstore"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestArchiveService_ArchiveAndRestoreClosedPeriod(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 300, Status: domain.AccountActive, Currency: "USD"})
+
+	september := time.Date(2026, time.September, 10, 12, 0, 0, 0, time.UTC)
+	for i, created := range []time.Time{september, september.AddDate(0, 0, 5), september.AddDate(0, 1, 0)} {
+		tx := domain.NewTransaction(domain.TypeDeposit, float64(50*(i+1)), "USD").WithAccounts("", "a1")
+		tx.Status = domain.StatusCompleted
+		tx.CreatedAt = created
+		_ = txRepo.Save(ctx, tx)
+	}
+
+	store := objectstore.NewFileStore(t.TempDir())
+	proc := processor.NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+	svc := NewArchiveService(proc, store, DefaultArchiveConfig(), logger)
+	svc.now = func() time.Time { return time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC) }
+
+	if _, err := svc.Archive(ctx, "2026-10"); !errors.Is(err, ErrPeriodNotClosed) {
+		t.Errorf("expected open period to be rejected, got %v", err)
+	}
+
+	manifest, err := svc.Archive(ctx, "2026-09")
+	if err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	if file, _ := manifest.File(domain.ArchiveTransactions); file.Records != 2 || file.SHA256 == "" {
+		t.Errorf("expected two September transactions with a checksum, got %+v", file)
+	}
+	if _, err := svc.Archive(ctx, "2026-09"); !errors.Is(err, ErrAlreadyArchived) {
+		t.Errorf("expected second archive of the period to be rejected, got %v", err)
+	}
+
+	contents, err := svc.Restore(ctx, "2026-09")
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if len(contents.Transactions) != 2 || len(contents.Statements) != 1 {
+		t.Fatalf("expected restored transactions and statements, got %d and %d", len(contents.Transactions), len(contents.Statements))
+	}
+	if statement := contents.Statements[0]; statement.OpeningBalance != 0 || statement.ClosingBalance != 150 || statement.Transactions != 2 {
+		t.Errorf("unexpected statement summary %+v", statement)
+	}
+
+	file, _ := manifest.File(domain.ArchiveStatements)
+	_ = store.Put(ctx, file.Key, []byte("tampered"), "")
+	if _, err := svc.Restore(ctx, "2026-09"); !errors.Is(err, ErrArchiveCorrupt) {
+		t.Errorf("expected checksum mismatch on tampered file, got %v", err)
+	}
+}
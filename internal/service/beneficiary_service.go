@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/crypto"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidBeneficiary = errors.New("invalid beneficiary")
+	ErrBeneficiaryState   = errors.New("beneficiary is not in a valid state for this operation")
+)
+
+type BeneficiaryConfig struct {
+	OTPDigits      int
+	OTPTTL         time.Duration
+	MaxOTPAttempts int
+}
+
+func DefaultBeneficiaryConfig() BeneficiaryConfig {
+	return BeneficiaryConfig{
+		OTPDigits:      6,
+		OTPTTL:         10 * time.Minute,
+		MaxOTPAttempts: 3,
+	}
+}
+
+type BeneficiaryService struct {
+	accountRepo     repository.AccountRepository
+	beneficiaryRepo repository.BeneficiaryRepository
+	otp             otpChallenge
+	notifier        Notifier
+	logger          *slog.Logger
+}
+
+func NewBeneficiaryService(
+	accountRepo repository.AccountRepository,
+	beneficiaryRepo repository.BeneficiaryRepository,
+	signer *crypto.Signer,
+	notifier Notifier,
+	cfg BeneficiaryConfig,
+	logger *slog.Logger,
+) *BeneficiaryService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &BeneficiaryService{
+		accountRepo:     accountRepo,
+		beneficiaryRepo: beneficiaryRepo,
+		otp:             otpChallenge{signer: signer, digits: cfg.OTPDigits, ttl: cfg.OTPTTL, maxAttempts: cfg.MaxOTPAttempts},
+		notifier:        notifier,
+		logger:          logger,
+	}
+}
+
+func (s *BeneficiaryService) AddTrusted(ctx context.Context, accountID, beneficiaryAccountID string) (*domain.TrustedBeneficiary, error) {
+	if beneficiaryAccountID == "" || beneficiaryAccountID == accountID {
+		return nil, fmt.Errorf("%w: beneficiary must be a different account", ErrInvalidBeneficiary)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.accountRepo.GetByID(ctx, beneficiaryAccountID); err != nil {
+		return nil, err
+	}
+
+	beneficiary := domain.NewTrustedBeneficiary(accountID, beneficiaryAccountID)
+	otp, hash, expiresAt, err := s.otp.issue(beneficiary.ID)
+	if err != nil {
+		return nil, err
+	}
+	beneficiary.OTPHash = hash
+	beneficiary.OTPExpiresAt = expiresAt
+
+	if err := s.beneficiaryRepo.Save(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, account, NotificationSMS, "Trusted beneficiary verification",
+		fmt.Sprintf("Your code to trust beneficiary %s is %s.", beneficiaryAccountID, otp))
+
+	s.logger.InfoContext(ctx, "Trusted beneficiary requested",
+		slog.String("beneficiary_id", beneficiary.ID),
+		slog.String("account_id", accountID),
+		slog.String("beneficiary_account_id", beneficiaryAccountID))
+
+	return beneficiary, nil
+}
+
+func (s *BeneficiaryService) Confirm(ctx context.Context, id, otp string) (*domain.TrustedBeneficiary, error) {
+	beneficiary, err := s.beneficiaryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if beneficiary.Status != domain.BeneficiaryPendingVerification {
+		return nil, fmt.Errorf("%w: beneficiary %s is %s", ErrBeneficiaryState, beneficiary.ID, beneficiary.Status)
+	}
+
+	if err := s.otp.verify(beneficiary.ID, otp, beneficiary.OTPHash, beneficiary.OTPExpiresAt, beneficiary.OTPAttempts); err != nil {
+		if errors.Is(err, ErrOTPExpired) {
+			beneficiary.Status = domain.BeneficiaryRevoked
+		} else {
+			beneficiary.OTPAttempts++
+		}
+		if updateErr := s.beneficiaryRepo.Update(ctx, beneficiary); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	beneficiary.Status = domain.BeneficiaryTrusted
+	beneficiary.TrustedAt = &now
+	beneficiary.OTPHash = ""
+	if err := s.beneficiaryRepo.Update(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+
+	if account, err := s.accountRepo.GetByID(ctx, beneficiary.AccountID); err == nil {
+		s.notify(ctx, account, NotificationEmail, "Trusted beneficiary added",
+			fmt.Sprintf("Account %s is now a trusted beneficiary. If this was not you, contact support immediately.", beneficiary.BeneficiaryAccountID))
+	}
+
+	return beneficiary, nil
+}
+
+func (s *BeneficiaryService) Revoke(ctx context.Context, id string) (*domain.TrustedBeneficiary, error) {
+	beneficiary, err := s.beneficiaryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if beneficiary.Status == domain.BeneficiaryRevoked {
+		return nil, fmt.Errorf("%w: beneficiary %s is already revoked", ErrBeneficiaryState, beneficiary.ID)
+	}
+
+	beneficiary.Status = domain.BeneficiaryRevoked
+	if err := s.beneficiaryRepo.Update(ctx, beneficiary); err != nil {
+		return nil, err
+	}
+	return beneficiary, nil
+}
+
+func (s *BeneficiaryService) List(ctx context.Context, accountID string) ([]*domain.TrustedBeneficiary, error) {
+	return s.beneficiaryRepo.GetByAccountID(ctx, accountID)
+}
+
+func (s *BeneficiaryService) notify(ctx context.Context, account *domain.Account, notificationType NotificationType, subject, message string) {
+	if s.notifier == nil {
+		return
+	}
+
+	err := s.notifier.Enqueue(ctx, NotificationMessage{
+		Type:      notificationType,
+		UserID:    account.UserID,
+		Recipient: account.UserID,
+		Subject:   subject,
+		Message:   message,
+		Priority:  8,
+		Metadata:  map[string]string{"account_id": account.ID},
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to queue beneficiary notification",
+			slog.String("account_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+}
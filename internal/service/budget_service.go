@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var ErrInvalidBudget = errors.New("invalid budget")
+
+type BudgetStatus struct {
+	*domain.Budget
+	Spent       float64   `json:"spent"`
+	Remaining   float64   `json:"remaining"`
+	Exceeded    bool      `json:"exceeded"`
+	PeriodStart time.Time `json:"period_start"`
+}
+
+type BudgetService struct {
+	repo        repository.BudgetRepository
+	accountRepo repository.AccountRepository
+	processor   *processor.TransactionProcessor
+	notifier    Notifier
+	logger      *slog.Logger
+}
+
+func NewBudgetService(
+	repo repository.BudgetRepository,
+	accountRepo repository.AccountRepository,
+	txProcessor *processor.TransactionProcessor,
+	notifier Notifier,
+	logger *slog.Logger,
+) *BudgetService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &BudgetService{
+		repo:        repo,
+		accountRepo: accountRepo,
+		processor:   txProcessor,
+		notifier:    notifier,
+		logger:      logger,
+	}
+}
+
+func (s *BudgetService) Create(ctx context.Context, accountID, category string, limit float64, period domain.BudgetPeriod) (*domain.Budget, error) {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return nil, fmt.Errorf("%w: category is required", ErrInvalidBudget)
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidBudget)
+	}
+	if period == "" {
+		period = domain.BudgetMonthly
+	}
+	if period != domain.BudgetMonthly && period != domain.BudgetWeekly {
+		return nil, fmt.Errorf("%w: unsupported period %s", ErrInvalidBudget, period)
+	}
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	budget := domain.NewBudget(accountID, category, limit, period)
+	err := repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, budget) },
+		func() { budget.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (s *BudgetService) List(ctx context.Context, accountID string) ([]BudgetStatus, error) {
+	budgets, err := s.repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		spent, err := s.processor.BudgetSpent(ctx, budget, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute spending for budget %s: %w", budget.ID, err)
+		}
+		statuses = append(statuses, BudgetStatus{
+			Budget:      budget,
+			Spent:       spent,
+			Remaining:   max(budget.Limit-spent, 0),
+			Exceeded:    spent > budget.Limit,
+			PeriodStart: budget.PeriodStart(now),
+		})
+	}
+	return statuses, nil
+}
+
+func (s *BudgetService) Delete(ctx context.Context, accountID, budgetID string) error {
+	budgets, err := s.repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	for _, budget := range budgets {
+		if budget.ID == budgetID {
+			return s.repo.Delete(ctx, budgetID)
+		}
+	}
+	return fmt.Errorf("%w: budget %s", repository.ErrNotFound, budgetID)
+}
+
+func (s *BudgetService) BudgetExceeded(ctx context.Context, warning processor.BudgetWarning) {
+	if s.notifier == nil {
+		return
+	}
+	account, err := s.accountRepo.GetByID(ctx, warning.AccountID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load account for budget warning",
+			slog.String("account_id", warning.AccountID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	err = s.notifier.Enqueue(ctx, NotificationMessage{
+		Type:      NotificationEmail,
+		UserID:    account.UserID,
+		Recipient: account.UserID,
+		Subject:   fmt.Sprintf("Budget exceeded: %s", warning.Category),
+		Message: fmt.Sprintf("You have spent %.2f %s of your %.2f %s %s budget.",
+			warning.Spent, account.Currency, warning.Limit, warning.Period, warning.Category),
+		Priority: 6,
+		Metadata: map[string]string{
+			"account_id":     warning.AccountID,
+			"transaction_id": warning.TransactionID,
+			"budget_id":      warning.BudgetID,
+		},
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to queue budget warning",
+			slog.String("budget_id", warning.BudgetID),
+			slog.String("error", err.Error()))
+	}
+}
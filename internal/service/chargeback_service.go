@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	PostingChargeback         = "chargeback"
+	PostingChargebackReversal = "chargeback_reversal"
+)
+
+var (
+	ErrInvalidChargeback = errors.New("invalid chargeback")
+	ErrChargebackState   = errors.New("chargeback is not in a valid state for this operation")
+)
+
+type ChargebackService struct {
+	txRepo         repository.TransactionRepository
+	chargebackRepo repository.ChargebackRepository
+	processor      *processor.TransactionProcessor
+	tracker        *processor.ChargebackTracker
+	logger         *slog.Logger
+}
+
+func NewChargebackService(
+	txRepo repository.TransactionRepository,
+	chargebackRepo repository.ChargebackRepository,
+	txProcessor *processor.TransactionProcessor,
+	tracker *processor.ChargebackTracker,
+	logger *slog.Logger,
+) *ChargebackService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ChargebackService{
+		txRepo:         txRepo,
+		chargebackRepo: chargebackRepo,
+		processor:      txProcessor,
+		tracker:        tracker,
+		logger:         logger,
+	}
+}
+
+func (s *ChargebackService) Receive(ctx context.Context, transactionID, reasonCode string) (*domain.Chargeback, error) {
+	if reasonCode == "" {
+		return nil, fmt.Errorf("%w: reason_code is required", ErrInvalidChargeback)
+	}
+
+	tx, err := s.txRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type != domain.TypeTransfer || tx.Status != domain.StatusCompleted {
+		return nil, fmt.Errorf("%w: only completed transfers can be charged back", ErrInvalidChargeback)
+	}
+
+	chargeback := domain.NewChargeback(tx, reasonCode)
+	if err := s.chargebackRepo.Save(ctx, chargeback); err != nil {
+		return nil, err
+	}
+
+	posting := s.linkedPosting(domain.TypeChargeback, chargeback)
+	posting.WithAccounts(chargeback.MerchantAccountID, chargeback.CustomerAccountID)
+	if err := s.processor.PostSystemTransaction(ctx, posting, PostingChargeback); err != nil {
+		if deleteErr := s.chargebackRepo.Delete(ctx, chargeback.ID); deleteErr != nil {
+			s.logger.ErrorContext(ctx, "Failed to release chargeback after posting failure",
+				slog.String("chargeback_id", chargeback.ID),
+				slog.String("error", deleteErr.Error()))
+		}
+		return nil, fmt.Errorf("failed to post chargeback: %w", err)
+	}
+
+	chargeback.ChargebackTxID = posting.ID
+	if err := s.chargebackRepo.Update(ctx, chargeback); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to link chargeback posting",
+			slog.String("chargeback_id", chargeback.ID),
+			slog.String("posting_id", posting.ID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if s.tracker != nil {
+		s.tracker.RecordChargeback(chargeback)
+	}
+
+	s.logger.InfoContext(ctx, "Chargeback received",
+		slog.String("chargeback_id", chargeback.ID),
+		slog.String("transaction_id", tx.ID),
+		slog.String("merchant_account_id", chargeback.MerchantAccountID),
+		slog.String("reason_code", reasonCode))
+
+	return chargeback, nil
+}
+
+func (s *ChargebackService) Get(ctx context.Context, id string) (*domain.Chargeback, error) {
+	return s.chargebackRepo.GetByID(ctx, id)
+}
+
+func (s *ChargebackService) Represent(ctx context.Context, id, note string) (*domain.Chargeback, error) {
+	if note == "" {
+		return nil, fmt.Errorf("%w: representment note is required", ErrInvalidChargeback)
+	}
+
+	chargeback, err := s.chargebackRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if chargeback.Status != domain.ChargebackReceived {
+		return nil, fmt.Errorf("%w: chargeback %s is %s", ErrChargebackState, chargeback.ID, chargeback.Status)
+	}
+
+	chargeback.Status = domain.ChargebackRepresented
+	chargeback.RepresentmentNote = note
+	if err := s.chargebackRepo.Update(ctx, chargeback); err != nil {
+		return nil, err
+	}
+
+	return chargeback, nil
+}
+
+func (s *ChargebackService) Decide(ctx context.Context, id string, outcome domain.ChargebackStatus) (*domain.Chargeback, error) {
+	chargeback, err := s.chargebackRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch outcome {
+	case domain.ChargebackWon:
+		if chargeback.Status != domain.ChargebackRepresented {
+			return nil, fmt.Errorf("%w: chargeback %s must be represented before it can be won", ErrChargebackState, chargeback.ID)
+		}
+		reversal := s.linkedPosting(domain.TypeTransfer, chargeback)
+		reversal.WithAccounts(chargeback.CustomerAccountID, chargeback.MerchantAccountID)
+		if err := s.processor.PostSystemTransaction(ctx, reversal, PostingChargebackReversal); err != nil {
+			return nil, fmt.Errorf("failed to reverse chargeback: %w", err)
+		}
+		chargeback.ReversalTxID = reversal.ID
+	case domain.ChargebackLost:
+		if chargeback.IsClosed() {
+			return nil, fmt.Errorf("%w: chargeback %s is already %s", ErrChargebackState, chargeback.ID, chargeback.Status)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown outcome %q", ErrInvalidChargeback, outcome)
+	}
+
+	now := time.Now()
+	chargeback.Status = outcome
+	chargeback.ClosedAt = &now
+	if err := s.chargebackRepo.Update(ctx, chargeback); err != nil {
+		return nil, err
+	}
+	if s.tracker != nil {
+		s.tracker.RecordOutcome(chargeback)
+	}
+
+	s.logger.InfoContext(ctx, "Chargeback decided",
+		slog.String("chargeback_id", chargeback.ID),
+		slog.String("outcome", string(outcome)))
+
+	return chargeback, nil
+}
+
+func (s *ChargebackService) MerchantStats(merchantID string) processor.MerchantChargebackStats {
+	if s.tracker == nil {
+		return processor.MerchantChargebackStats{MerchantID: merchantID}
+	}
+	return s.tracker.Stats(merchantID)
+}
+
+func (s *ChargebackService) linkedPosting(txType domain.TransactionType, chargeback *domain.Chargeback) *domain.Transaction {
+	posting := domain.NewTransaction(txType, chargeback.Amount, chargeback.Currency).
+		WithDescription(fmt.Sprintf("Chargeback %s for transaction %s", chargeback.ID, chargeback.TransactionID))
+	posting.AddMetadata(processor.MetadataLinkedTransaction, chargeback.TransactionID)
+	posting.AddMetadata("chargeback_id", chargeback.ID)
+	return posting
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type slowPostingRepository struct {
+	*memory.TransactionRepository
+}
+
+func (r slowPostingRepository) Save(ctx context.Context, tx *domain.Transaction) error {
+	if tx.SystemPosting != "" {
+		time.Sleep(20 * time.Millisecond)
+	}
+	return r.TransactionRepository.Save(ctx, tx)
+}
+
+func TestChargebackService_ConcurrentReceiveDebitsOnce(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	txRepo := slowPostingRepository{memory.NewTransactionRepository()}
+	_ = accRepo.Save(ctx, &domain.Account{ID: "cust", UserID: "u1", Balance: 500, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "merch", UserID: "u2", Balance: 1000, Status: domain.AccountActive, Currency: "USD"})
+	proc := processor.NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+	tx := domain.NewTransaction(domain.TypeTransfer, 100, "USD").WithAccounts("cust", "merch")
+	if err := proc.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("ProcessTransaction: %v", err)
+	}
+	svc := NewChargebackService(txRepo, memory.NewChargebackRepository(), proc, nil, logger)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.Receive(ctx, tx.ID, "10.4")
+		}(i)
+	}
+	wg.Wait()
+
+	received := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			received++
+		case !errors.Is(err, repository.ErrDuplicate):
+			t.Errorf("expected duplicate rejection, got %v", err)
+		}
+	}
+	if received != 1 {
+		t.Fatalf("expected exactly one chargeback received, got %d", received)
+	}
+	if merch, _ := accRepo.GetByID(ctx, "merch"); merch.Balance != 1000 {
+		t.Errorf("expected merchant debited once, got balance %v", merch.Balance)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidConsent  = errors.New("invalid consent")
+	ErrConsentWithheld = errors.New("consent withheld")
+)
+
+type ConsentService struct {
+	repo   repository.ConsentRepository
+	logger *slog.Logger
+}
+
+func NewConsentService(repo repository.ConsentRepository, logger *slog.Logger) *ConsentService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ConsentService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *ConsentService) Record(ctx context.Context, record *domain.ConsentRecord) (*domain.ConsentRecord, error) {
+	if err := validateConsent(record); err != nil {
+		return nil, err
+	}
+	record.ID = domain.NewID()
+	record.RecordedAt = time.Now()
+
+	if err := s.repo.Save(ctx, record); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Consent recorded",
+		slog.String("user_id", record.UserID),
+		slog.String("channel", record.Channel),
+		slog.String("purpose", string(record.Purpose)),
+		slog.Bool("granted", record.Granted),
+		slog.String("source", record.Source))
+	return record, nil
+}
+
+func (s *ConsentService) Current(ctx context.Context, userID string) ([]*domain.ConsentRecord, error) {
+	return s.repo.GetByUser(ctx, userID)
+}
+
+func (s *ConsentService) History(ctx context.Context, userID string) ([]*domain.ConsentRecord, error) {
+	return s.repo.GetHistory(ctx, userID)
+}
+
+func (s *ConsentService) Allows(ctx context.Context, userID string, channel NotificationType, purpose domain.ConsentPurpose) (bool, error) {
+	if purpose == "" {
+		purpose = domain.ConsentTransactional
+	}
+
+	record, err := s.repo.GetCurrent(ctx, userID, string(channel), purpose)
+	if errors.Is(err, repository.ErrNotFound) {
+		return purpose == domain.ConsentTransactional, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return record.Granted, nil
+}
+
+func validateConsent(record *domain.ConsentRecord) error {
+	if record.UserID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidConsent)
+	}
+	switch NotificationType(record.Channel) {
+	case NotificationEmail, NotificationSMS, NotificationPush, NotificationInbox:
+	default:
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidConsent, record.Channel)
+	}
+	switch record.Purpose {
+	case domain.ConsentTransactional, domain.ConsentMarketing:
+	default:
+		return fmt.Errorf("%w: unknown purpose %q", ErrInvalidConsent, record.Purpose)
+	}
+	if record.Source == "" {
+		return fmt.Errorf("%w: source is required", ErrInvalidConsent)
+	}
+	return nil
+}
+
+func (s *NotificationService) WithConsent(consents *ConsentService) *NotificationService {
+	s.consents = consents
+	return s
+}
+
+func (s *NotificationService) checkConsent(msg NotificationMessage) error {
+	if s.consents == nil || msg.UserID == "" {
+		return nil
+	}
+
+	allowed, err := s.consents.Allows(context.Background(), msg.UserID, msg.Type, msg.Purpose)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		purpose := msg.Purpose
+		if purpose == "" {
+			purpose = domain.ConsentTransactional
+		}
+		return fmt.Errorf("%w: %w: no %s consent on %s", ErrPermanentDelivery, ErrConsentWithheld, purpose, msg.Type)
+	}
+	return nil
+}
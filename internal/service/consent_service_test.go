@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestNotificationService_EnforcesConsent(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	email := &MockEmailService{}
+	history := memory.NewDeliveryRepository()
+	consents := NewConsentService(memory.NewConsentRepository(), logger)
+	svc := NewNotificationService(email, &MockSMSService{}, nil, nil, NotificationScalingConfig{MinWorkers: 1, MaxWorkers: 1}, logger).
+		WithDeliveryHistory(history).
+		WithConsent(consents)
+	defer svc.Shutdown(ctx)
+
+	if _, err := consents.Record(ctx, &domain.ConsentRecord{UserID: "u1", Channel: "fax", Purpose: domain.ConsentMarketing, Source: "web"}); !errors.Is(err, ErrInvalidConsent) {
+		t.Errorf("expected unknown channel to be rejected, got %v", err)
+	}
+	_, _ = consents.Record(ctx, &domain.ConsentRecord{UserID: "u1", Channel: string(NotificationSMS), Purpose: domain.ConsentTransactional, Granted: false, Source: "support_call"})
+
+	send := func(id string, channel NotificationType, purpose domain.ConsentPurpose) domain.DeliveryStatus {
+		_ = svc.Enqueue(ctx, NotificationMessage{ID: id, UserID: "u1", Type: channel, Recipient: "u1", Message: id, Purpose: purpose})
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if deliveries, _ := history.GetByMessage(ctx, id); len(deliveries) == 1 {
+				return deliveries[0].Status
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for %s", id)
+		return ""
+	}
+
+	if status := send("promo-1", NotificationEmail, domain.ConsentMarketing); status != domain.DeliverySuppressed {
+		t.Errorf("expected marketing without opt-in to be suppressed, got %s", status)
+	}
+	if status := send("receipt", NotificationEmail, ""); status != domain.DeliverySent {
+		t.Errorf("expected transactional email to send by default, got %s", status)
+	}
+	if status := send("otp", NotificationSMS, domain.ConsentTransactional); status != domain.DeliverySuppressed {
+		t.Errorf("expected transactional sms opt-out to be honored, got %s", status)
+	}
+
+	_, _ = consents.Record(ctx, &domain.ConsentRecord{UserID: "u1", Channel: string(NotificationEmail), Purpose: domain.ConsentMarketing, Granted: true, Source: "preference_center"})
+	if status := send("promo-2", NotificationEmail, domain.ConsentMarketing); status != domain.DeliverySent {
+		t.Errorf("expected marketing after opt-in to send, got %s", status)
+	}
+	if len(email.SentEmails) != 2 {
+		t.Errorf("expected only the receipt and opted-in promo to be emailed, got %d", len(email.SentEmails))
+	}
+
+	if current, _ := consents.Current(ctx, "u1"); len(current) != 2 {
+		t.Errorf("expected current consent per channel and purpose, got %+v", current)
+	}
+}
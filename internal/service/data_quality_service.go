@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const DataQualityJobName = "data_quality"
+
+type DataQualityConfig struct {
+	Interval time.Duration
+}
+
+func DefaultDataQualityConfig() DataQualityConfig {
+	return DataQualityConfig{Interval: 6 * time.Hour}
+}
+
+type DataQualityMetrics interface {
+	ResetDataQualityFindings()
+	ObserveDataQualityFindings(check, severity string, count int)
+}
+
+type DataQualityService struct {
+	processor *processor.TransactionProcessor
+	cfg       DataQualityConfig
+	metrics   DataQualityMetrics
+	mu        sync.RWMutex
+	latest    *processor.DataQualityReport
+	logger    *slog.Logger
+}
+
+func NewDataQualityService(txProcessor *processor.TransactionProcessor, cfg DataQualityConfig, logger *slog.Logger) *DataQualityService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DataQualityService{
+		processor: txProcessor,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+func (s *DataQualityService) WithMetrics(metrics DataQualityMetrics) *DataQualityService {
+	s.metrics = metrics
+	return s
+}
+
+func (s *DataQualityService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     DataQualityJobName,
+		Schedule: scheduler.Every(s.cfg.Interval),
+		Run: func(ctx context.Context) error {
+			_, err := s.Run(ctx)
+			return err
+		},
+	})
+}
+
+func (s *DataQualityService) Run(ctx context.Context) (*processor.DataQualityReport, error) {
+	report, err := s.processor.CheckDataQuality(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check data quality: %w", err)
+	}
+
+	s.mu.Lock()
+	s.latest = report
+	s.mu.Unlock()
+
+	type findingKey struct{ check, severity string }
+	counts := make(map[findingKey]int)
+	for _, finding := range report.Findings {
+		counts[findingKey{finding.Check, string(finding.Severity)}]++
+		if finding.Severity != processor.SeverityCritical {
+			continue
+		}
+		s.logger.ErrorContext(ctx, "Data quality invariant violated",
+			slog.String("report_id", report.ID),
+			slog.String("check", finding.Check),
+			slog.String("entity_type", finding.EntityType),
+			slog.String("entity_id", finding.EntityID),
+			slog.String("message", finding.Message))
+	}
+	if s.metrics != nil {
+		s.metrics.ResetDataQualityFindings()
+		for key, count := range counts {
+			s.metrics.ObserveDataQualityFindings(key.check, key.severity, count)
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Data quality check completed",
+		slog.String("report_id", report.ID),
+		slog.Int("accounts", report.AccountsChecked),
+		slog.Int("transactions", report.TransactionsChecked),
+		slog.Int("critical", report.Counts[processor.SeverityCritical]),
+		slog.Int("warning", report.Counts[processor.SeverityWarning]))
+	return report, nil
+}
+
+func (s *DataQualityService) Latest() (*processor.DataQualityReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.latest == nil {
+		return nil, fmt.Errorf("%w: no data quality check has run yet", repository.ErrNotFound)
+	}
+	return s.latest, nil
+}
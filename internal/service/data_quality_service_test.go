@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+type recordingDataQualityMetrics struct {
+	findings map[string]int
+}
+
+func (m *recordingDataQualityMetrics) ResetDataQualityFindings() {
+	m.findings = make(map[string]int)
+}
+
+func (m *recordingDataQualityMetrics) ObserveDataQualityFindings(check, severity string, count int) {
+	m.findings[check+"/"+severity] = count
+}
+
+func TestDataQualityService_ReportsInvariantViolations(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	products := memory.NewProductRepository()
+	_ = products.Save(ctx, &domain.Product{ID: "overdraft", Name: "Overdraft", OverdraftLimit: 100})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "healthy", UserID: "u1", Balance: 10, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "covered", UserID: "u2", Balance: -80, ProductID: "overdraft", Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "negative", UserID: "u3", Balance: -5, Status: domain.AccountActive, Currency: "USD"})
+
+	orphan := domain.NewTransaction(domain.TypeTransfer, 20, "USD").WithAccounts("healthy", "closed")
+	orphan.Status = domain.StatusCompleted
+	pendingOrphan := domain.NewTransaction(domain.TypeDeposit, 20, "USD").WithAccounts("", "ghost")
+	legless := domain.NewTransaction(domain.TypeTransfer, 15, "USD").WithAccounts("healthy", "")
+	legless.Status = domain.StatusCompleted
+	for _, tx := range []*domain.Transaction{orphan, pendingOrphan, legless} {
+		_ = txRepo.Save(ctx, tx)
+	}
+
+	proc := processor.NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1).WithProducts(products)
+	metrics := &recordingDataQualityMetrics{}
+	svc := NewDataQualityService(proc, DefaultDataQualityConfig(), logger).WithMetrics(metrics)
+
+	report, err := svc.Run(ctx)
+	if err != nil {
+		t.Fatalf("run data quality check: %v", err)
+	}
+	if report.AccountsChecked != 3 || report.TransactionsChecked != 3 {
+		t.Errorf("expected 3 accounts and 3 transactions checked, got %d and %d", report.AccountsChecked, report.TransactionsChecked)
+	}
+	if report.Counts[processor.SeverityCritical] != 3 || report.Counts[processor.SeverityWarning] != 1 {
+		t.Fatalf("unexpected severity counts %v: %+v", report.Counts, report.Findings)
+	}
+
+	found := make(map[string]string)
+	for _, finding := range report.Findings {
+		found[finding.EntityID] = finding.Check
+	}
+	for entity, check := range map[string]string{
+		"negative":       processor.CheckNegativeBalance,
+		orphan.ID:        processor.CheckOrphanTransaction,
+		pendingOrphan.ID: processor.CheckOrphanTransaction,
+		legless.ID:       processor.CheckMissingLedgerLegs,
+	} {
+		if found[entity] != check {
+			t.Errorf("expected %s finding for %s, got %q", check, entity, found[entity])
+		}
+	}
+	if _, flagged := found["covered"]; flagged {
+		t.Error("expected balance within overdraft limit not to be flagged")
+	}
+
+	if metrics.findings["orphan_transaction/critical"] != 1 || metrics.findings["orphan_transaction/warning"] != 1 {
+		t.Errorf("expected findings exported per check and severity, got %v", metrics.findings)
+	}
+	if latest, err := svc.Latest(); err != nil || latest.ID != report.ID {
+		t.Errorf("expected latest report to be retained, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	AuditActionDelegationGranted = "delegation_granted"
+	AuditActionDelegationRevoked = "delegation_revoked"
+	AuditActionDelegatedAccess   = "delegated_access"
+
+	MetadataDelegationID = "delegation_id"
+	MetadataInitiatedBy  = "initiated_by"
+)
+
+var (
+	ErrInvalidDelegation = errors.New("invalid delegation")
+	ErrDelegationRevoked = errors.New("delegation already revoked")
+	ErrAccessDenied      = errors.New("access denied")
+)
+
+type DelegationService struct {
+	repo        repository.DelegationRepository
+	accountRepo repository.AccountRepository
+	auditRepo   repository.AuditRepository
+	logger      *slog.Logger
+}
+
+func NewDelegationService(
+	repo repository.DelegationRepository,
+	accountRepo repository.AccountRepository,
+	auditRepo repository.AuditRepository,
+	logger *slog.Logger,
+) *DelegationService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DelegationService{
+		repo:        repo,
+		accountRepo: accountRepo,
+		auditRepo:   auditRepo,
+		logger:      logger,
+	}
+}
+
+func (s *DelegationService) Grant(ctx context.Context, accountID, grantorID, delegateID string, scope domain.DelegationScope, maxAmount float64) (*domain.Delegation, error) {
+	if delegateID == "" {
+		return nil, fmt.Errorf("%w: delegate_id is required", ErrInvalidDelegation)
+	}
+	if delegateID == grantorID {
+		return nil, fmt.Errorf("%w: cannot delegate to yourself", ErrInvalidDelegation)
+	}
+	switch scope {
+	case domain.DelegationViewOnly:
+		if maxAmount != 0 {
+			return nil, fmt.Errorf("%w: max_amount only applies to %s", ErrInvalidDelegation, domain.DelegationInitiate)
+		}
+	case domain.DelegationInitiate:
+		if maxAmount <= 0 {
+			return nil, fmt.Errorf("%w: max_amount must be positive", ErrInvalidDelegation)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidDelegation, scope)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != grantorID {
+		return nil, fmt.Errorf("%w: only the account owner can grant access", ErrAccessDenied)
+	}
+
+	delegation := domain.NewDelegation(accountID, grantorID, delegateID, scope, maxAmount)
+	err = repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, delegation) },
+		func() { delegation.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, AuditActionDelegationGranted, delegation, grantorID, map[string]string{
+		"scope":      string(scope),
+		"max_amount": strconv.FormatFloat(maxAmount, 'f', 2, 64),
+	})
+	s.logger.InfoContext(ctx, "Delegated access granted",
+		slog.String("delegation_id", delegation.ID),
+		slog.String("account_id", accountID),
+		slog.String("delegate_id", delegateID),
+		slog.String("scope", string(scope)))
+	return delegation, nil
+}
+
+func (s *DelegationService) Revoke(ctx context.Context, delegationID, actorID string) (*domain.Delegation, error) {
+	delegation, err := s.repo.GetByID(ctx, delegationID)
+	if err != nil {
+		return nil, err
+	}
+	if actorID != delegation.GrantorID && actorID != delegation.DelegateID {
+		return nil, fmt.Errorf("%w: only the grantor or delegate can revoke access", ErrAccessDenied)
+	}
+	if !delegation.Active() {
+		return nil, fmt.Errorf("%w: %s", ErrDelegationRevoked, delegation.ID)
+	}
+
+	now := time.Now()
+	delegation.RevokedAt = &now
+	delegation.RevokedBy = actorID
+	if err := s.repo.Update(ctx, delegation); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, AuditActionDelegationRevoked, delegation, actorID, nil)
+	s.logger.InfoContext(ctx, "Delegated access revoked",
+		slog.String("delegation_id", delegation.ID),
+		slog.String("account_id", delegation.AccountID),
+		slog.String("revoked_by", actorID))
+	return delegation, nil
+}
+
+func (s *DelegationService) List(ctx context.Context, accountID, userID string) ([]*domain.Delegation, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, fmt.Errorf("%w: only the account owner can list delegations", ErrAccessDenied)
+	}
+	return s.repo.GetByAccountID(ctx, accountID)
+}
+
+func (s *DelegationService) Authorize(ctx context.Context, userID, accountID string, scope domain.DelegationScope, amount float64) (*domain.Delegation, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID == userID {
+		return nil, nil
+	}
+
+	delegations, err := s.repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, delegation := range delegations {
+		if delegation.DelegateID != userID || !delegation.Active() {
+			continue
+		}
+		if !delegation.Permits(scope, amount) {
+			return nil, fmt.Errorf("%w: delegation %s does not permit %s of %.2f", ErrAccessDenied, delegation.ID, scope, amount)
+		}
+		details := map[string]string{"scope": string(scope)}
+		if scope == domain.DelegationInitiate {
+			details["amount"] = strconv.FormatFloat(amount, 'f', 2, 64)
+		}
+		s.audit(ctx, AuditActionDelegatedAccess, delegation, userID, details)
+		return delegation, nil
+	}
+	return nil, fmt.Errorf("%w: user %s has no access to account %s", ErrAccessDenied, userID, accountID)
+}
+
+func (s *DelegationService) audit(ctx context.Context, action string, delegation *domain.Delegation, actor string, details map[string]string) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	entry := domain.NewAuditEntry(action, processor.AuditEntityAccount, delegation.AccountID, actor, "delegated access")
+	entry.Details["delegation_id"] = delegation.ID
+	entry.Details["delegate_id"] = delegation.DelegateID
+	for key, value := range details {
+		entry.Details[key] = value
+	}
+	err := repository.SaveWithFreshID(
+		func() error { return s.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record audit entry for delegation",
+			slog.String("delegation_id", delegation.ID),
+			slog.String("error", err.Error()))
+	}
+}
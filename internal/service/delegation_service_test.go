@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestDelegationService_ScopedAccess(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accounts := memory.NewAccountRepository()
+	_ = accounts.Save(ctx, &domain.Account{ID: "acc1", UserID: "owner", Status: domain.AccountActive, Currency: "USD"})
+	audit := memory.NewAuditRepository()
+	svc := NewDelegationService(memory.NewDelegationRepository(), accounts, audit, logger)
+
+	if _, err := svc.Grant(ctx, "acc1", "stranger", "helper", domain.DelegationViewOnly, 0); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected non-owner grant to be denied, got %v", err)
+	}
+	if _, err := svc.Grant(ctx, "acc1", "owner", "helper", domain.DelegationInitiate, 0); !errors.Is(err, ErrInvalidDelegation) {
+		t.Errorf("expected initiate scope without a cap to be rejected, got %v", err)
+	}
+
+	viewer, err := svc.Grant(ctx, "acc1", "owner", "viewer", domain.DelegationViewOnly, 0)
+	if err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+	if _, err := svc.Grant(ctx, "acc1", "owner", "attorney", domain.DelegationInitiate, 250); err != nil {
+		t.Fatalf("Grant failed: %v", err)
+	}
+
+	if delegation, err := svc.Authorize(ctx, "owner", "acc1", domain.DelegationInitiate, 10000); err != nil || delegation != nil {
+		t.Errorf("expected owner to need no delegation, got %v %v", delegation, err)
+	}
+	if _, err := svc.Authorize(ctx, "viewer", "acc1", domain.DelegationViewOnly, 0); err != nil {
+		t.Errorf("expected viewer to read the account, got %v", err)
+	}
+	if _, err := svc.Authorize(ctx, "viewer", "acc1", domain.DelegationInitiate, 10); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected viewer to be unable to initiate, got %v", err)
+	}
+	if _, err := svc.Authorize(ctx, "attorney", "acc1", domain.DelegationInitiate, 250); err != nil {
+		t.Errorf("expected attorney to initiate up to the cap, got %v", err)
+	}
+	if _, err := svc.Authorize(ctx, "attorney", "acc1", domain.DelegationInitiate, 251); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected attorney to be capped, got %v", err)
+	}
+	if _, err := svc.Authorize(ctx, "stranger", "acc1", domain.DelegationViewOnly, 0); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected stranger to be denied, got %v", err)
+	}
+
+	if _, err := svc.Revoke(ctx, viewer.ID, "stranger"); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected stranger revocation to be denied, got %v", err)
+	}
+	if _, err := svc.Revoke(ctx, viewer.ID, "owner"); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if _, err := svc.Revoke(ctx, viewer.ID, "owner"); !errors.Is(err, ErrDelegationRevoked) {
+		t.Errorf("expected double revocation to fail, got %v", err)
+	}
+	if _, err := svc.Authorize(ctx, "viewer", "acc1", domain.DelegationViewOnly, 0); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected revoked viewer to be denied, got %v", err)
+	}
+
+	entries, _ := audit.GetByEntity(ctx, processor.AuditEntityAccount, "acc1")
+	actions := make(map[string]int)
+	for _, entry := range entries {
+		actions[entry.Action]++
+	}
+	if actions[AuditActionDelegationGranted] != 2 || actions[AuditActionDelegationRevoked] != 1 || actions[AuditActionDelegatedAccess] != 2 {
+		t.Errorf("expected grants, revocation and delegated access in the audit journal, got %v", actions)
+	}
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	PostingDisputeProvisionalCredit = "dispute_provisional_credit"
+	PostingDisputeReversal          = "dispute_reversal"
+)
+
+var (
+	ErrInvalidDispute = errors.New("invalid dispute")
+	ErrDisputeState   = errors.New("dispute is not in a valid state for this operation")
+)
+
+type DisputeConfig struct {
+	ProvisionalCredit bool
+	Window            time.Duration
+}
+
+func DefaultDisputeConfig() DisputeConfig {
+	return DisputeConfig{
+		ProvisionalCredit: true,
+		Window:            120 * 24 * time.Hour,
+	}
+}
+
+type DisputeUpdate struct {
+	Status   domain.DisputeStatus
+	Evidence []domain.EvidenceAttachment
+	Note     string
+}
+
+type DisputeService struct {
+	txRepo      repository.TransactionRepository
+	disputeRepo repository.DisputeRepository
+	processor   *processor.TransactionProcessor
+	cfg         DisputeConfig
+	logger      *slog.Logger
+}
+
+func NewDisputeService(
+	txRepo repository.TransactionRepository,
+	disputeRepo repository.DisputeRepository,
+	txProcessor *processor.TransactionProcessor,
+	cfg DisputeConfig,
+	logger *slog.Logger,
+) *DisputeService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DisputeService{
+		txRepo:      txRepo,
+		disputeRepo: disputeRepo,
+		processor:   txProcessor,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (s *DisputeService) Open(ctx context.Context, transactionID, reason string, evidence []domain.EvidenceAttachment) (*domain.Dispute, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidDispute)
+	}
+
+	tx, err := s.txRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Status != domain.StatusCompleted {
+		return nil, fmt.Errorf("%w: only completed transactions can be disputed, transaction is %s", ErrInvalidDispute, tx.Status)
+	}
+	if s.cfg.Window > 0 && time.Since(tx.CreatedAt) > s.cfg.Window {
+		return nil, fmt.Errorf("%w: dispute window of %s has passed", ErrInvalidDispute, s.cfg.Window)
+	}
+
+	accountID := tx.FromAccountID
+	if accountID == "" {
+		accountID = tx.ToAccountID
+	}
+
+	evidence, err = stampEvidence(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	dispute := domain.NewDispute(tx, accountID, reason)
+	dispute.Evidence = evidence
+	err = repository.SaveWithFreshID(
+		func() error { return s.disputeRepo.Save(ctx, dispute) },
+		func() { dispute.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	credited, err := s.previouslyCredited(ctx, dispute)
+	if err != nil {
+		return nil, err
+	}
+	if credited {
+		s.logger.InfoContext(ctx, "Skipping provisional credit already granted for transaction",
+			slog.String("dispute_id", dispute.ID),
+			slog.String("transaction_id", tx.ID))
+	}
+
+	if s.cfg.ProvisionalCredit && tx.FromAccountID != "" && !credited {
+		credit := s.linkedPosting(domain.TypeDeposit, dispute, tx)
+		credit.WithAccounts("", dispute.AccountID)
+		if err := s.processor.PostSystemTransaction(ctx, credit, PostingDisputeProvisionalCredit); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to post provisional credit",
+				slog.String("dispute_id", dispute.ID),
+				slog.String("error", err.Error()))
+		} else {
+			dispute.ProvisionalCreditID = credit.ID
+			if err := s.disputeRepo.Update(ctx, dispute); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.processor.PublishEvent(ctx, domain.EventCaseOpened, domain.CaseEventData{
+		CaseID:        dispute.ID,
+		TransactionID: tx.ID,
+		AccountID:     dispute.AccountID,
+		Reason:        dispute.Reason,
+		Status:        dispute.Status,
+	})
+	s.logger.InfoContext(ctx, "Dispute opened",
+		slog.String("dispute_id", dispute.ID),
+		slog.String("transaction_id", tx.ID),
+		slog.String("account_id", accountID))
+
+	return dispute, nil
+}
+
+func (s *DisputeService) previouslyCredited(ctx context.Context, dispute *domain.Dispute) (bool, error) {
+	disputes, err := s.disputeRepo.GetByTransactionID(ctx, dispute.TransactionID)
+	if err != nil {
+		return false, err
+	}
+	for _, earlier := range disputes {
+		if earlier.ID != dispute.ID && earlier.ProvisionalCreditID != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *DisputeService) Get(ctx context.Context, id string) (*domain.Dispute, error) {
+	return s.disputeRepo.GetByID(ctx, id)
+}
+
+func (s *DisputeService) Update(ctx context.Context, id string, update DisputeUpdate) (*domain.Dispute, error) {
+	dispute, err := s.disputeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !dispute.IsActive() {
+		return nil, fmt.Errorf("%w: dispute %s is resolved", ErrDisputeState, dispute.ID)
+	}
+
+	switch update.Status {
+	case "":
+	case domain.DisputeOpen, domain.DisputeUnderReview:
+		dispute.Status = update.Status
+	default:
+		return nil, fmt.Errorf("%w: status %q cannot be set directly", ErrInvalidDispute, update.Status)
+	}
+
+	evidence, err := stampEvidence(update.Evidence)
+	if err != nil {
+		return nil, err
+	}
+	dispute.Evidence = append(dispute.Evidence, evidence...)
+	if update.Note != "" {
+		dispute.Notes = append(dispute.Notes, update.Note)
+	}
+
+	if err := s.disputeRepo.Update(ctx, dispute); err != nil {
+		return nil, err
+	}
+	return dispute, nil
+}
+
+func (s *DisputeService) Resolve(ctx context.Context, id string, outcome domain.DisputeOutcome, note string) (*domain.Dispute, error) {
+	if outcome != domain.DisputeOutcomeCustomer && outcome != domain.DisputeOutcomeMerchant {
+		return nil, fmt.Errorf("%w: unknown outcome %q", ErrInvalidDispute, outcome)
+	}
+
+	dispute, err := s.disputeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !dispute.IsActive() {
+		return nil, fmt.Errorf("%w: dispute %s is already resolved", ErrDisputeState, dispute.ID)
+	}
+
+	if outcome == domain.DisputeOutcomeMerchant && dispute.ProvisionalCreditID != "" {
+		tx, err := s.txRepo.GetByID(ctx, dispute.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		reversal := s.linkedPosting(domain.TypeWithdrawal, dispute, tx)
+		reversal.WithAccounts(dispute.AccountID, "")
+		if err := s.processor.PostSystemTransaction(ctx, reversal, PostingDisputeReversal); err != nil {
+			return nil, fmt.Errorf("failed to reverse provisional credit: %w", err)
+		}
+		dispute.ReversalID = reversal.ID
+	}
+
+	now := time.Now()
+	dispute.Status = domain.DisputeResolved
+	dispute.Outcome = outcome
+	dispute.ResolvedAt = &now
+	if note != "" {
+		dispute.Notes = append(dispute.Notes, note)
+	}
+
+	if err := s.disputeRepo.Update(ctx, dispute); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Dispute resolved",
+		slog.String("dispute_id", dispute.ID),
+		slog.String("outcome", string(outcome)))
+
+	return dispute, nil
+}
+
+func (s *DisputeService) linkedPosting(txType domain.TransactionType, dispute *domain.Dispute, original *domain.Transaction) *domain.Transaction {
+	posting := domain.NewTransaction(txType, dispute.Amount, dispute.Currency).
+		WithDescription(fmt.Sprintf("Dispute %s for transaction %s", dispute.ID, original.ID))
+	posting.AddMetadata(processor.MetadataLinkedTransaction, original.ID)
+	posting.AddMetadata("dispute_id", dispute.ID)
+	return posting
+}
+
+func stampEvidence(evidence []domain.EvidenceAttachment) ([]domain.EvidenceAttachment, error) {
+	now := time.Now()
+	for i := range evidence {
+		if evidence[i].FileName == "" || evidence[i].StorageKey == "" {
+			return nil, fmt.Errorf("%w: evidence %d requires file_name and storage_key", ErrInvalidDispute, i)
+		}
+		if evidence[i].UploadedAt.IsZero() {
+			evidence[i].UploadedAt = now
+		}
+	}
+	return evidence, nil
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	ActionBalanceMigration    = "balance_migration"
+	ActionRuleGroupActivation = "rule_group_activation"
+	ActionRuleImport          = "rule_import"
+	ActionLimitOverride       = "limit_override"
+	ActionRiskOverride        = "risk_override"
+	ActionSuspenseReassign    = "suspense_reassign"
+	ActionSuspenseReturn      = "suspense_return"
+
+	AuditActionApprovalStaged   = "approval_staged"
+	AuditActionApprovalExecuted = "approval_executed"
+	AuditActionApprovalRejected = "approval_rejected"
+	AuditActionApprovalFailed   = "approval_failed"
+	AuditEntityApproval         = "approval"
+)
+
+var (
+	ErrUnknownAction     = errors.New("unknown dual control action")
+	ErrInvalidApproval   = errors.New("invalid approval request")
+	ErrSelfApproval      = errors.New("an operator cannot approve their own request")
+	ErrApprovalState     = errors.New("approval request is not pending")
+	ErrApprovalExecution = errors.New("approved action failed")
+)
+
+type ActionExecutor func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error)
+
+type RuleGroupActivation struct {
+	GroupID string `json:"group_id"`
+	Active  bool   `json:"active"`
+}
+
+type TransactionRiskOverride struct {
+	TransactionID string                 `json:"transaction_id"`
+	Override      processor.RiskOverride `json:"override"`
+}
+
+type TransactionSuspenseRepair struct {
+	TransactionID string                   `json:"transaction_id"`
+	Repair        processor.SuspenseRepair `json:"repair"`
+}
+
+type DualControlService struct {
+	repo      repository.ApprovalRepository
+	auditRepo repository.AuditRepository
+	mu        sync.Mutex
+	executors map[string]ActionExecutor
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewDualControlService(repo repository.ApprovalRepository, auditRepo repository.AuditRepository, logger *slog.Logger) *DualControlService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DualControlService{
+		repo:      repo,
+		auditRepo: auditRepo,
+		executors: make(map[string]ActionExecutor),
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *DualControlService) RegisterAction(action string, executor ActionExecutor) *DualControlService {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executors[action] = executor
+	return s
+}
+
+func (s *DualControlService) WithAdminActions(txProcessor *processor.TransactionProcessor) *DualControlService {
+	s.RegisterAction(ActionBalanceMigration, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var migration processor.BalanceMigration
+		if err := json.Unmarshal(payload, &migration); err != nil {
+			return nil, err
+		}
+		migration.Operator = maker
+		return txProcessor.ApplyBalanceMigration(ctx, migration)
+	})
+	s.RegisterAction(ActionRuleGroupActivation, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var activation RuleGroupActivation
+		if err := json.Unmarshal(payload, &activation); err != nil {
+			return nil, err
+		}
+		engine := txProcessor.RuleEngine()
+		if err := engine.SetRuleGroupActive(ctx, activation.GroupID, activation.Active); err != nil {
+			return nil, err
+		}
+		group, _, err := engine.GetRuleGroup(ctx, activation.GroupID)
+		return group, err
+	})
+	s.RegisterAction(ActionRuleImport, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var doc processor.RuleSetDocument
+		if err := json.Unmarshal(payload, &doc); err != nil {
+			return nil, err
+		}
+		if err := txProcessor.RuleEngine().ImportRules(ctx, doc); err != nil {
+			return nil, err
+		}
+		return map[string]int{"imported": len(doc.Rules)}, nil
+	})
+	s.RegisterAction(ActionLimitOverride, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var override processor.LimitOverride
+		if err := json.Unmarshal(payload, &override); err != nil {
+			return nil, err
+		}
+		override.Operator = maker
+		return txProcessor.OverrideAccountLimit(ctx, override)
+	})
+	s.RegisterAction(ActionRiskOverride, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var staged TransactionRiskOverride
+		if err := json.Unmarshal(payload, &staged); err != nil {
+			return nil, err
+		}
+		staged.Override.Operator = maker
+		return txProcessor.OverrideRiskDecision(ctx, staged.TransactionID, staged.Override)
+	})
+	s.RegisterAction(ActionSuspenseReassign, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var staged TransactionSuspenseRepair
+		if err := json.Unmarshal(payload, &staged); err != nil {
+			return nil, err
+		}
+		staged.Repair.Operator = maker
+		return txProcessor.ReassignSuspense(ctx, staged.TransactionID, staged.Repair)
+	})
+	s.RegisterAction(ActionSuspenseReturn, func(ctx context.Context, payload json.RawMessage, maker string) (interface{}, error) {
+		var staged TransactionSuspenseRepair
+		if err := json.Unmarshal(payload, &staged); err != nil {
+			return nil, err
+		}
+		staged.Repair.Operator = maker
+		return txProcessor.ReturnSuspense(ctx, staged.TransactionID, staged.Repair)
+	})
+	return s
+}
+
+func (s *DualControlService) Stage(ctx context.Context, action, maker, reason string, payload interface{}) (*domain.ApprovalRequest, error) {
+	if maker == "" {
+		return nil, fmt.Errorf("%w: maker is required", ErrInvalidApproval)
+	}
+	s.mu.Lock()
+	_, known := s.executors[action]
+	s.mu.Unlock()
+	if !known {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidApproval, err)
+	}
+
+	approval := domain.NewApprovalRequest(action, maker, reason, data)
+	approval.CreatedAt = s.now()
+	err = repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, approval) },
+		func() { approval.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, AuditActionApprovalStaged, approval, maker, reason)
+	s.logger.InfoContext(ctx, "Admin action staged for approval",
+		slog.String("approval_id", approval.ID),
+		slog.String("action", action),
+		slog.String("maker", maker))
+
+	return approval, nil
+}
+
+func (s *DualControlService) Approve(ctx context.Context, id, checker string) (*domain.ApprovalRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	approval, err := s.decidable(ctx, id, checker)
+	if err != nil {
+		return nil, err
+	}
+	executor, known := s.executors[approval.Action]
+	if !known {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, approval.Action)
+	}
+
+	result, execErr := executor(ctx, approval.Payload, approval.Maker)
+
+	now := s.now()
+	approval.Checker = checker
+	approval.DecidedAt = &now
+	approval.Status = domain.ApprovalExecuted
+	auditAction := AuditActionApprovalExecuted
+	if execErr != nil {
+		approval.Status = domain.ApprovalFailed
+		approval.Error = execErr.Error()
+		auditAction = AuditActionApprovalFailed
+	} else if result != nil {
+		if data, err := json.Marshal(result); err == nil {
+			approval.Result = data
+		}
+	}
+	if err := s.repo.Update(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, auditAction, approval, checker, approval.Error)
+	s.logger.InfoContext(ctx, "Staged admin action approved",
+		slog.String("approval_id", approval.ID),
+		slog.String("action", approval.Action),
+		slog.String("maker", approval.Maker),
+		slog.String("checker", checker),
+		slog.String("status", string(approval.Status)))
+
+	if execErr != nil {
+		return approval, fmt.Errorf("%w: %w", ErrApprovalExecution, execErr)
+	}
+	return approval, nil
+}
+
+func (s *DualControlService) Reject(ctx context.Context, id, checker, note string) (*domain.ApprovalRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	approval, err := s.decidable(ctx, id, checker)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	approval.Checker = checker
+	approval.DecisionNote = note
+	approval.DecidedAt = &now
+	approval.Status = domain.ApprovalRejected
+	if err := s.repo.Update(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, AuditActionApprovalRejected, approval, checker, note)
+	s.logger.InfoContext(ctx, "Staged admin action rejected",
+		slog.String("approval_id", approval.ID),
+		slog.String("action", approval.Action),
+		slog.String("checker", checker))
+
+	return approval, nil
+}
+
+func (s *DualControlService) Get(ctx context.Context, id string) (*domain.ApprovalRequest, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *DualControlService) List(ctx context.Context, status domain.ApprovalStatus) ([]*domain.ApprovalRequest, error) {
+	return s.repo.GetByStatus(ctx, status)
+}
+
+func (s *DualControlService) decidable(ctx context.Context, id, checker string) (*domain.ApprovalRequest, error) {
+	if checker == "" {
+		return nil, fmt.Errorf("%w: checker is required", ErrInvalidApproval)
+	}
+
+	approval, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status != domain.ApprovalPending {
+		return nil, fmt.Errorf("%w: approval %s is %s", ErrApprovalState, approval.ID, approval.Status)
+	}
+	if approval.Maker == checker {
+		return nil, ErrSelfApproval
+	}
+	return approval, nil
+}
+
+func (s *DualControlService) audit(ctx context.Context, action string, approval *domain.ApprovalRequest, actor, reason string) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	entry := domain.NewAuditEntry(action, AuditEntityApproval, approval.ID, actor, reason)
+	entry.Details["action"] = approval.Action
+	entry.Details["maker"] = approval.Maker
+	if approval.Checker != "" {
+		entry.Details["checker"] = approval.Checker
+	}
+	err := repository.SaveWithFreshID(
+		func() error { return s.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record audit entry for approval",
+			slog.String("approval_id", approval.ID),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEmail = errors.New("invalid email")
+
+type EmailAttachment struct {
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	ContentID   string `json:"content_id,omitempty"`
+	Content     []byte `json:"content"`
+}
+
+type Email struct {
+	To          string            `json:"to,omitempty"`
+	Subject     string            `json:"subject,omitempty"`
+	TextBody    string            `json:"text_body,omitempty"`
+	HTMLBody    string            `json:"html_body,omitempty"`
+	Inline      []EmailAttachment `json:"inline,omitempty"`
+	Attachments []EmailAttachment `json:"attachments,omitempty"`
+}
+
+func (e Email) validate() error {
+	if e.To == "" {
+		return fmt.Errorf("%w: recipient is required", ErrInvalidEmail)
+	}
+	if e.TextBody == "" && e.HTMLBody == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidEmail)
+	}
+	for _, asset := range e.Inline {
+		if asset.ContentID == "" {
+			return fmt.Errorf("%w: inline asset %q has no content id", ErrInvalidEmail, asset.Filename)
+		}
+	}
+	for _, attachment := range e.Attachments {
+		if attachment.Filename == "" {
+			return fmt.Errorf("%w: attachment has no filename", ErrInvalidEmail)
+		}
+	}
+	return nil
+}
+
+func (e Email) MIME(from string) ([]byte, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", e.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", e.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	mixed := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: %s\r\n\r\n", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mixed.Boundary()}))
+
+	related, err := nestedWriter(mixed, "multipart/related")
+	if err != nil {
+		return nil, err
+	}
+	alternative, err := nestedWriter(related, "multipart/alternative")
+	if err != nil {
+		return nil, err
+	}
+	if e.TextBody != "" {
+		if err := writeTextPart(alternative, "text/plain", e.TextBody); err != nil {
+			return nil, err
+		}
+	}
+	if e.HTMLBody != "" {
+		if err := writeTextPart(alternative, "text/html", e.HTMLBody); err != nil {
+			return nil, err
+		}
+	}
+	if err := alternative.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, asset := range e.Inline {
+		if err := writeBinaryPart(related, asset, "inline"); err != nil {
+			return nil, err
+		}
+	}
+	if err := related.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range e.Attachments {
+		if err := writeBinaryPart(mixed, attachment, "attachment"); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func nestedWriter(parent *multipart.Writer, contentType string) (*multipart.Writer, error) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	part, err := parent.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType(contentType, map[string]string{"boundary": boundary})},
+	})
+	if err != nil {
+		return nil, err
+	}
+	nested := multipart.NewWriter(part)
+	if err := nested.SetBoundary(boundary); err != nil {
+		return nil, err
+	}
+	return nested, nil
+}
+
+func writeTextPart(w *multipart.Writer, contentType, body string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	return writeBase64(part, []byte(body))
+}
+
+func writeBinaryPart(w *multipart.Writer, attachment EmailAttachment, disposition string) error {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+	}
+	if attachment.Filename != "" {
+		header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	} else {
+		header.Set("Content-Disposition", disposition)
+	}
+	if attachment.ContentID != "" {
+		header.Set("Content-ID", "<"+strings.Trim(attachment.ContentID, "<>")+">")
+	}
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	return writeBase64(part, attachment.Content)
+}
+
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		if _, err := fmt.Fprintf(w, "%s\r\n", encoded[:76]); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := fmt.Fprintf(w, "%s\r\n", encoded)
+	return err
+}
+
+type SMTPEmailService struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPEmailService(addr, from string, auth smtp.Auth) *SMTPEmailService {
+	return &SMTPEmailService{addr: addr, from: from, auth: auth}
+}
+
+func (s *SMTPEmailService) SendEmail(email Email) error {
+	msg, err := email.MIME(s.from)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{email.To}, msg)
+}
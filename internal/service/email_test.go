@@ -0,0 +1,75 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestEmail_MIME(t *testing.T) {
+	email := Email{
+		To:       "customer@example.com",
+		Subject:  "Your statement",
+		TextBody: "See attached.",
+		HTMLBody: `<p>See attached.</p><img src="cid:logo">`,
+		Inline:   []EmailAttachment{{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte{0x89, 'P', 'N', 'G'}}},
+		Attachments: []EmailAttachment{
+			{Filename: "statement.csv", ContentType: "text/csv", Content: []byte("transaction_id,amount\ntx-1,10.00\n")},
+		},
+	}
+
+	raw, err := email.MIME("statements@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parts := make(map[string]string)
+	var walk func(r io.Reader, contentType string)
+	walk = func(r io.Reader, contentType string) {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(mediaType, "multipart/") {
+			body, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, r))
+			parts[mediaType] = string(body)
+			return
+		}
+		reader := multipart.NewReader(r, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id := part.Header.Get("Content-ID"); id != "" {
+				parts["cid"] = id
+			}
+			walk(part, part.Header.Get("Content-Type"))
+		}
+	}
+	walk(msg.Body, msg.Header.Get("Content-Type"))
+
+	if parts["text/plain"] != "See attached." || !strings.Contains(parts["text/html"], "cid:logo") {
+		t.Errorf("expected text and html alternatives, got %q", parts)
+	}
+	if parts["text/csv"] != "transaction_id,amount\ntx-1,10.00\n" || parts["image/png"] != "\x89PNG" || parts["cid"] != "<logo>" {
+		t.Errorf("expected inline asset and attachment, got %q", parts)
+	}
+
+	if _, err := (Email{To: "customer@example.com"}).MIME("statements@example.com"); !errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("expected empty body to be rejected, got %v", err)
+	}
+}
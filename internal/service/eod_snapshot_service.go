@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const EODSnapshotJobName = "eod_snapshot"
+
+var ErrInvalidBusinessDate = errors.New("invalid business date")
+
+type EODSnapshotConfig struct {
+	Interval time.Duration
+	Location *time.Location
+}
+
+func DefaultEODSnapshotConfig() EODSnapshotConfig {
+	return EODSnapshotConfig{Interval: time.Hour, Location: time.UTC}
+}
+
+type EODSnapshotService struct {
+	processor *processor.TransactionProcessor
+	repo      repository.EODSnapshotRepository
+	cfg       EODSnapshotConfig
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewEODSnapshotService(
+	txProcessor *processor.TransactionProcessor,
+	repo repository.EODSnapshotRepository,
+	cfg EODSnapshotConfig,
+	logger *slog.Logger,
+) *EODSnapshotService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+
+	return &EODSnapshotService{
+		processor: txProcessor,
+		repo:      repo,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *EODSnapshotService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     EODSnapshotJobName,
+		Schedule: scheduler.Every(s.cfg.Interval),
+		Run: func(ctx context.Context) error {
+			_, err := s.TakeLatest(ctx)
+			return err
+		},
+	})
+}
+
+func (s *EODSnapshotService) TakeLatest(ctx context.Context) (*domain.EODSnapshot, error) {
+	yesterday := s.now().In(s.cfg.Location).AddDate(0, 0, -1)
+	return s.Take(ctx, yesterday.Format(time.DateOnly))
+}
+
+func (s *EODSnapshotService) Take(ctx context.Context, businessDate string) (*domain.EODSnapshot, error) {
+	day, err := time.ParseInLocation(time.DateOnly, businessDate, s.cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBusinessDate, businessDate)
+	}
+	asOf := day.AddDate(0, 0, 1)
+	if asOf.After(s.now()) {
+		return nil, fmt.Errorf("%w: %s has not closed yet", ErrInvalidBusinessDate, businessDate)
+	}
+
+	if existing, err := s.repo.GetByID(ctx, domain.EODSnapshotID(businessDate)); err == nil {
+		return existing, nil
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	snapshot, err := s.processor.EndOfDaySnapshot(ctx, businessDate, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take end-of-day snapshot: %w", err)
+	}
+	if err := s.repo.Save(ctx, snapshot); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return s.repo.GetByID(ctx, snapshot.ID)
+		}
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "End-of-day snapshot taken",
+		slog.String("snapshot_id", snapshot.ID),
+		slog.Int("accounts", len(snapshot.Balances)),
+		slog.Int("holds", len(snapshot.Holds)),
+		slog.Int("pending", len(snapshot.Pending)),
+		slog.String("checksum", snapshot.Checksum))
+	return snapshot, nil
+}
+
+func (s *EODSnapshotService) Get(ctx context.Context, id string) (*domain.EODSnapshot, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *EODSnapshotService) List(ctx context.Context) ([]*domain.EODSnapshot, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *EODSnapshotService) SnapshotIDFor(ctx context.Context, at time.Time) string {
+	businessDate := at.Add(-time.Nanosecond).In(s.cfg.Location).Format(time.DateOnly)
+	snapshot, err := s.repo.GetByID(ctx, domain.EODSnapshotID(businessDate))
+	if err != nil {
+		return ""
+	}
+	return snapshot.ID
+}
+
+func (s *EODSnapshotService) Latest(ctx context.Context) (*domain.EODSnapshot, error) {
+	snapshots, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%w: no end-of-day snapshot taken yet", repository.ErrNotFound)
+	}
+	return snapshots[len(snapshots)-1], nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestEODSnapshotService_TakeIsDeterministicAndImmutable(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	opened := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 250, Status: domain.AccountActive, Currency: "USD", CreatedAt: opened})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Balance: 40, Status: domain.AccountActive, Currency: "USD", CreatedAt: opened})
+
+	day := time.Date(2026, time.October, 14, 15, 0, 0, 0, time.UTC)
+	for _, seed := range []struct {
+		amount  float64
+		status  domain.TransactionStatus
+		created time.Time
+	}{
+		{100, domain.StatusCompleted, day},
+		{30, domain.StatusPending, day},
+		{20, domain.StatusSuspicious, day},
+		{150, domain.StatusCompleted, day.AddDate(0, 0, 1)},
+	} {
+		tx := domain.NewTransaction(domain.TypeDeposit, seed.amount, "USD").WithAccounts("", "a1")
+		tx.Status = seed.status
+		tx.CreatedAt = seed.created
+		_ = txRepo.Save(ctx, tx)
+	}
+
+	proc := processor.NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+	svc := NewEODSnapshotService(proc, memory.NewEODSnapshotRepository(), DefaultEODSnapshotConfig(), logger)
+	svc.now = func() time.Time { return time.Date(2026, time.October, 16, 8, 0, 0, 0, time.UTC) }
+
+	if _, err := svc.Take(ctx, "2026-10-16"); !errors.Is(err, ErrInvalidBusinessDate) {
+		t.Errorf("expected open business day to be rejected, got %v", err)
+	}
+
+	snapshot, err := svc.Take(ctx, "2026-10-14")
+	if err != nil {
+		t.Fatalf("take snapshot: %v", err)
+	}
+	if snapshot.ID != "eod-2026-10-14" || snapshot.Checksum == "" {
+		t.Errorf("expected date-derived id with checksum, got %s %q", snapshot.ID, snapshot.Checksum)
+	}
+	if len(snapshot.Balances) != 2 || snapshot.Balances[0].AccountID != "a1" || snapshot.Balances[0].Balance != 100 {
+		t.Errorf("expected a1 rolled back to its end-of-day balance, got %+v", snapshot.Balances)
+	}
+	if len(snapshot.Holds) != 1 || len(snapshot.Pending) != 1 {
+		t.Errorf("expected one hold and one pending transaction, got %d and %d", len(snapshot.Holds), len(snapshot.Pending))
+	}
+
+	_ = accRepo.UpdateBalance(ctx, "a2", 500)
+	again, err := svc.Take(ctx, "2026-10-14")
+	if err != nil || again.Checksum != snapshot.Checksum || again.TakenAt != snapshot.TakenAt {
+		t.Errorf("expected stored snapshot to be returned unchanged, got %+v %v", again, err)
+	}
+
+	rebuilt, err := proc.EndOfDaySnapshot(ctx, "2026-10-13", snapshot.AsOf.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("rebuild snapshot: %v", err)
+	}
+	repeat, _ := proc.EndOfDaySnapshot(ctx, "2026-10-13", snapshot.AsOf.AddDate(0, 0, -1))
+	if rebuilt.Checksum != repeat.Checksum {
+		t.Errorf("expected identical ledger state to produce identical checksums")
+	}
+
+	if id := svc.SnapshotIDFor(ctx, time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)); id != snapshot.ID {
+		t.Errorf("expected period ending at midnight to reference %s, got %q", snapshot.ID, id)
+	}
+}
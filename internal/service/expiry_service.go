@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const TransactionExpiryJobName = "transaction_expiry_sweep"
+
+type TransactionExpiryConfig struct {
+	TTL           time.Duration
+	SweepInterval time.Duration
+	Channel       NotificationType
+}
+
+func DefaultTransactionExpiryConfig() TransactionExpiryConfig {
+	return TransactionExpiryConfig{
+		TTL:           24 * time.Hour,
+		SweepInterval: 5 * time.Minute,
+		Channel:       NotificationEmail,
+	}
+}
+
+type TransactionExpiryService struct {
+	processor   *processor.TransactionProcessor
+	accountRepo repository.AccountRepository
+	notifier    Notifier
+	cfg         TransactionExpiryConfig
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+func NewTransactionExpiryService(
+	txProcessor *processor.TransactionProcessor,
+	accountRepo repository.AccountRepository,
+	notifier Notifier,
+	cfg TransactionExpiryConfig,
+	logger *slog.Logger,
+) *TransactionExpiryService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &TransactionExpiryService{
+		processor:   txProcessor,
+		accountRepo: accountRepo,
+		notifier:    notifier,
+		cfg:         cfg,
+		now:         time.Now,
+		logger:      logger,
+	}
+}
+
+func (s *TransactionExpiryService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     TransactionExpiryJobName,
+		Schedule: scheduler.Every(s.cfg.SweepInterval),
+		Run:      s.Sweep,
+	})
+}
+
+func (s *TransactionExpiryService) Sweep(ctx context.Context) error {
+	expired, err := s.processor.ExpirePending(ctx, s.now().Add(-s.cfg.TTL))
+	for _, tx := range expired {
+		s.notifyInitiator(ctx, tx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to expire pending transactions: %w", err)
+	}
+	return nil
+}
+
+func (s *TransactionExpiryService) notifyInitiator(ctx context.Context, tx *domain.Transaction) {
+	if s.notifier == nil {
+		return
+	}
+
+	accountID := tx.FromAccountID
+	if accountID == "" {
+		accountID = tx.ToAccountID
+	}
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load initiator of expired transaction",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	err = s.notifier.Enqueue(ctx, NotificationMessage{
+		Type:      s.cfg.Channel,
+		UserID:    account.UserID,
+		Recipient: account.UserID,
+		Subject:   "Transaction expired",
+		Message:   fmt.Sprintf("Your %s of %.2f %s was not approved in time and has expired.", tx.Type, tx.Amount, tx.Currency),
+		Priority:  6,
+		Metadata:  map[string]string{"account_id": account.ID, "transaction_id": tx.ID},
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to queue transaction expiry notification",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ExposureCheckJobName = "currency_exposure"
+
+var ErrInvalidExposureThreshold = errors.New("invalid exposure threshold")
+
+type ExposureConfig struct {
+	Interval         time.Duration
+	DefaultThreshold float64
+	Thresholds       map[string]float64
+	AlertChannel     string
+}
+
+func DefaultExposureConfig() ExposureConfig {
+	return ExposureConfig{
+		Interval:         15 * time.Minute,
+		DefaultThreshold: 100000,
+		AlertChannel:     "#treasury-alerts",
+	}
+}
+
+func ParseExposureThresholds(spec string) (map[string]float64, error) {
+	thresholds := make(map[string]float64)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		currency, raw, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not CURRENCY=AMOUNT", ErrInvalidExposureThreshold, part)
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidExposureThreshold, part)
+		}
+		thresholds[strings.ToUpper(strings.TrimSpace(currency))] = threshold
+	}
+	return thresholds, nil
+}
+
+type CurrencyExposure struct {
+	Currency       string  `json:"currency"`
+	FXPosition     float64 `json:"fx_position"`
+	Fees           float64 `json:"fees"`
+	NetPosition    float64 `json:"net_position"`
+	BaseEquivalent float64 `json:"base_equivalent"`
+	Unpriced       bool    `json:"unpriced,omitempty"`
+	Threshold      float64 `json:"threshold,omitempty"`
+	Breached       bool    `json:"breached"`
+}
+
+type ExposureAlert struct {
+	Currency       string    `json:"currency"`
+	BaseEquivalent float64   `json:"base_equivalent"`
+	Threshold      float64   `json:"threshold"`
+	RaisedAt       time.Time `json:"raised_at"`
+}
+
+type ExposureReport struct {
+	BaseCurrency  string             `json:"base_currency"`
+	GeneratedAt   time.Time          `json:"generated_at"`
+	Currencies    []CurrencyExposure `json:"currencies"`
+	NetExposure   float64            `json:"net_exposure"`
+	GrossExposure float64            `json:"gross_exposure"`
+	Alerts        []ExposureAlert    `json:"alerts"`
+}
+
+type ExposureService struct {
+	processor     *processor.TransactionProcessor
+	fx            *FXService
+	notifications *NotificationService
+	cfg           ExposureConfig
+	mu            sync.Mutex
+	alerts        map[string]ExposureAlert
+	logger        *slog.Logger
+}
+
+func NewExposureService(txProcessor *processor.TransactionProcessor, fx *FXService, cfg ExposureConfig, logger *slog.Logger) *ExposureService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	thresholds := make(map[string]float64, len(cfg.Thresholds))
+	for currency, threshold := range cfg.Thresholds {
+		thresholds[strings.ToUpper(currency)] = threshold
+	}
+	cfg.Thresholds = thresholds
+
+	return &ExposureService{
+		processor: txProcessor,
+		fx:        fx,
+		cfg:       cfg,
+		alerts:    make(map[string]ExposureAlert),
+		logger:    logger,
+	}
+}
+
+func (s *ExposureService) WithNotifications(notifications *NotificationService) *ExposureService {
+	s.notifications = notifications
+	return s
+}
+
+func (s *ExposureService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     ExposureCheckJobName,
+		Schedule: scheduler.Every(s.cfg.Interval),
+		Run: func(ctx context.Context) error {
+			_, err := s.Report(ctx)
+			return err
+		},
+	})
+}
+
+func (s *ExposureService) Thresholds() (float64, map[string]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg.DefaultThreshold, maps.Clone(s.cfg.Thresholds)
+}
+
+func (s *ExposureService) SetThreshold(currency string, threshold float64) error {
+	if threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return fmt.Errorf("%w: threshold must be a non-negative amount", ErrInvalidExposureThreshold)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if currency == "" {
+		s.cfg.DefaultThreshold = threshold
+		return nil
+	}
+	s.cfg.Thresholds[strings.ToUpper(currency)] = threshold
+	return nil
+}
+
+func (s *ExposureService) Report(ctx context.Context) (*ExposureReport, error) {
+	accounts, err := s.processor.SystemAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load system accounts: %w", err)
+	}
+
+	byCurrency := make(map[string]*CurrencyExposure)
+	for _, account := range accounts {
+		if account.SystemRole != domain.SystemRoleFXPosition && account.SystemRole != domain.SystemRoleFees {
+			continue
+		}
+		entry, exists := byCurrency[account.Currency]
+		if !exists {
+			entry = &CurrencyExposure{Currency: account.Currency}
+			byCurrency[account.Currency] = entry
+		}
+		if account.SystemRole == domain.SystemRoleFXPosition {
+			entry.FXPosition += account.Balance
+		} else {
+			entry.Fees += account.Balance
+		}
+	}
+
+	report := &ExposureReport{
+		BaseCurrency: s.fx.Base(),
+		GeneratedAt:  time.Now(),
+		Currencies:   make([]CurrencyExposure, 0, len(byCurrency)),
+	}
+	for _, entry := range byCurrency {
+		rounding := s.fx.Rounding()
+		entry.NetPosition = rounding.Round(entry.FXPosition+entry.Fees, entry.Currency)
+		if base, _, err := s.fx.Convert(entry.NetPosition, entry.Currency, report.BaseCurrency); err != nil {
+			entry.Unpriced = true
+		} else {
+			entry.BaseEquivalent = base
+		}
+		report.NetExposure += entry.BaseEquivalent
+		report.GrossExposure += math.Abs(entry.BaseEquivalent)
+		report.Currencies = append(report.Currencies, *entry)
+	}
+	sort.Slice(report.Currencies, func(i, j int) bool {
+		return report.Currencies[i].Currency < report.Currencies[j].Currency
+	})
+
+	report.Alerts = s.evaluate(ctx, report)
+	return report, nil
+}
+
+func (s *ExposureService) Alerts() []ExposureAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activeAlertsLocked()
+}
+
+func (s *ExposureService) evaluate(ctx context.Context, report *ExposureReport) []ExposureAlert {
+	s.mu.Lock()
+	var raised []ExposureAlert
+	for i := range report.Currencies {
+		entry := &report.Currencies[i]
+		threshold, exists := s.cfg.Thresholds[entry.Currency]
+		if !exists {
+			threshold = s.cfg.DefaultThreshold
+		}
+		entry.Threshold = threshold
+		entry.Breached = threshold > 0 && !entry.Unpriced && math.Abs(entry.BaseEquivalent) > threshold
+
+		_, active := s.alerts[entry.Currency]
+		switch {
+		case entry.Breached && !active:
+			alert := ExposureAlert{
+				Currency:       entry.Currency,
+				BaseEquivalent: entry.BaseEquivalent,
+				Threshold:      threshold,
+				RaisedAt:       report.GeneratedAt,
+			}
+			s.alerts[entry.Currency] = alert
+			raised = append(raised, alert)
+		case entry.Breached:
+			alert := s.alerts[entry.Currency]
+			alert.BaseEquivalent = entry.BaseEquivalent
+			alert.Threshold = threshold
+			s.alerts[entry.Currency] = alert
+		case active:
+			delete(s.alerts, entry.Currency)
+			s.logger.InfoContext(ctx, "Currency exposure back within threshold",
+				slog.String("currency", entry.Currency),
+				slog.Float64("threshold", threshold))
+		}
+	}
+	alerts := s.activeAlertsLocked()
+	s.mu.Unlock()
+
+	for _, alert := range raised {
+		s.raise(ctx, report.BaseCurrency, alert)
+	}
+	return alerts
+}
+
+func (s *ExposureService) activeAlertsLocked() []ExposureAlert {
+	alerts := make([]ExposureAlert, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Currency < alerts[j].Currency })
+	return alerts
+}
+
+func (s *ExposureService) raise(ctx context.Context, base string, alert ExposureAlert) {
+	s.logger.WarnContext(ctx, "Currency exposure exceeds threshold",
+		slog.String("currency", alert.Currency),
+		slog.Float64("base_equivalent", alert.BaseEquivalent),
+		slog.Float64("threshold", alert.Threshold))
+
+	if s.notifications == nil || s.cfg.AlertChannel == "" {
+		return
+	}
+	err := s.notifications.Enqueue(ctx, NotificationMessage{
+		Type:      NotificationSlack,
+		Recipient: s.cfg.AlertChannel,
+		Subject:   fmt.Sprintf("Currency exposure alert - %s", alert.Currency),
+		Message: fmt.Sprintf("Net %s exposure of %.2f %s exceeds the %.2f %s threshold",
+			alert.Currency, alert.BaseEquivalent, base, alert.Threshold, base),
+		Priority: 8,
+		Metadata: map[string]string{
+			"currency":  alert.Currency,
+			"threshold": strconv.FormatFloat(alert.Threshold, 'f', 2, 64),
+		},
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to queue exposure alert",
+			slog.String("currency", alert.Currency),
+			slog.String("error", err.Error()))
+	}
+}
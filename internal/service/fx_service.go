@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"finance_manager/pkg/money"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+type FXService struct {
+	mu       sync.RWMutex
+	base     string
+	rates    map[string]float64
+	version  uint64
+	rounding *money.RoundingPolicies
+}
+
+func NewFXService(base string, rates map[string]float64) *FXService {
+	fx := &FXService{
+		base:     strings.ToUpper(base),
+		rates:    map[string]float64{strings.ToUpper(base): 1},
+		rounding: money.DefaultRoundingPolicies(),
+	}
+	for currency, rate := range rates {
+		fx.rates[strings.ToUpper(currency)] = rate
+	}
+	return fx
+}
+
+func DefaultFXRates() map[string]float64 {
+	return map[string]float64{
+		"USD": 1,
+		"EUR": 0.92,
+		"GBP": 0.79,
+		"JPY": 149.5,
+		"CHF": 0.88,
+	}
+}
+
+func (s *FXService) WithRounding(rounding *money.RoundingPolicies) *FXService {
+	s.rounding = rounding
+	return s
+}
+
+func (s *FXService) Rounding() *money.RoundingPolicies {
+	return s.rounding
+}
+
+func (s *FXService) Base() string {
+	return s.base
+}
+
+func (s *FXService) SetRate(currency string, rate float64) error {
+	if rate <= 0 {
+		return fmt.Errorf("%w: rate for %s must be positive", ErrUnsupportedCurrency, currency)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rates[strings.ToUpper(currency)] = rate
+	s.version++
+	return nil
+}
+
+func (s *FXService) Rates() (map[string]float64, uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.rates), s.version
+}
+
+func (s *FXService) Rate(from, to string) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fromRate, exists := s.rates[strings.ToUpper(from)]
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, from)
+	}
+	toRate, exists := s.rates[strings.ToUpper(to)]
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, to)
+	}
+	return toRate / fromRate, nil
+}
+
+func (s *FXService) Convert(amount float64, from, to string) (float64, float64, error) {
+	rate, err := s.Rate(from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return s.rounding.Round(amount*rate, to), rate, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	AuditActionImpersonationStarted = "impersonation_started"
+	AuditActionImpersonationEnded   = "impersonation_ended"
+	AuditActionImpersonatedRequest  = "impersonated_request"
+	AuditEntityImpersonation        = "impersonation"
+)
+
+var (
+	ErrInvalidImpersonation   = errors.New("invalid impersonation request")
+	ErrImpersonationForbidden = errors.New("operator is not allowed to impersonate users")
+	ErrImpersonationEnded     = errors.New("impersonation session is no longer active")
+)
+
+type ImpersonationConfig struct {
+	SupportOperators []string
+	MaxDuration      time.Duration
+}
+
+func DefaultImpersonationConfig() ImpersonationConfig {
+	return ImpersonationConfig{MaxDuration: 30 * time.Minute}
+}
+
+type ImpersonatedRequest struct {
+	Method string
+	Path   string
+	Status int
+}
+
+type ImpersonationService struct {
+	repo      repository.ImpersonationRepository
+	auditRepo repository.AuditRepository
+	cfg       ImpersonationConfig
+	mu        sync.Mutex
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewImpersonationService(
+	repo repository.ImpersonationRepository,
+	auditRepo repository.AuditRepository,
+	cfg ImpersonationConfig,
+	logger *slog.Logger,
+) *ImpersonationService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.MaxDuration <= 0 {
+		cfg.MaxDuration = DefaultImpersonationConfig().MaxDuration
+	}
+
+	return &ImpersonationService{
+		repo:      repo,
+		auditRepo: auditRepo,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *ImpersonationService) CanImpersonate(operator string) bool {
+	return operator != "" && slices.Contains(s.cfg.SupportOperators, operator)
+}
+
+func (s *ImpersonationService) Start(ctx context.Context, operator, userID, reason string) (*domain.ImpersonationSession, error) {
+	if !s.CanImpersonate(operator) {
+		return nil, fmt.Errorf("%w: %s", ErrImpersonationForbidden, operator)
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidImpersonation)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidImpersonation)
+	}
+
+	session := domain.NewImpersonationSession(operator, userID, reason, s.cfg.MaxDuration)
+	err := repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, session) },
+		func() { session.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, AuditActionImpersonationStarted, session, reason, map[string]string{
+		"expires_at": session.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+	s.logger.InfoContext(ctx, "Impersonation session started",
+		slog.String("session_id", session.ID),
+		slog.String("operator", operator),
+		slog.String("user_id", userID))
+	return session, nil
+}
+
+func (s *ImpersonationService) End(ctx context.Context, sessionID, operator string) (*domain.ImpersonationSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.OperatorID != operator {
+		return nil, fmt.Errorf("%w: session %s belongs to %s", ErrImpersonationForbidden, session.ID, session.OperatorID)
+	}
+	if session.EndedAt != nil {
+		return nil, fmt.Errorf("%w: %s", ErrImpersonationEnded, session.ID)
+	}
+
+	now := s.now()
+	session.EndedAt = &now
+	if err := s.repo.Update(ctx, session); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, AuditActionImpersonationEnded, session, "ended by operator", map[string]string{
+		"requests": strconv.Itoa(session.Requests),
+	})
+	s.logger.InfoContext(ctx, "Impersonation session ended",
+		slog.String("session_id", session.ID),
+		slog.String("operator", operator),
+		slog.Int("requests", session.Requests))
+	return session, nil
+}
+
+func (s *ImpersonationService) Authorize(ctx context.Context, sessionID, operator string) (*domain.ImpersonationSession, error) {
+	session, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.OperatorID != operator || !s.CanImpersonate(operator) {
+		return nil, fmt.Errorf("%w: session %s", ErrImpersonationForbidden, session.ID)
+	}
+	if !session.Active(s.now()) {
+		return nil, fmt.Errorf("%w: %s", ErrImpersonationEnded, session.ID)
+	}
+	return session, nil
+}
+
+func (s *ImpersonationService) RecordRequest(ctx context.Context, session *domain.ImpersonationSession, request ImpersonatedRequest) {
+	s.mu.Lock()
+	stored, err := s.repo.GetByID(ctx, session.ID)
+	if err == nil {
+		stored.Requests++
+		err = s.repo.Update(ctx, stored)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count impersonated request",
+			slog.String("session_id", session.ID),
+			slog.String("error", err.Error()))
+	}
+
+	s.audit(ctx, AuditActionImpersonatedRequest, session, session.Reason, map[string]string{
+		"method": request.Method,
+		"path":   request.Path,
+		"status": strconv.Itoa(request.Status),
+	})
+}
+
+func (s *ImpersonationService) Get(ctx context.Context, sessionID string) (*domain.ImpersonationSession, []*domain.AuditEntry, error) {
+	session, err := s.repo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.auditRepo == nil {
+		return session, []*domain.AuditEntry{}, nil
+	}
+	trail, err := s.auditRepo.GetByEntity(ctx, AuditEntityImpersonation, session.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, trail, nil
+}
+
+func (s *ImpersonationService) List(ctx context.Context) ([]*domain.ImpersonationSession, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *ImpersonationService) audit(ctx context.Context, action string, session *domain.ImpersonationSession, reason string, details map[string]string) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	entry := domain.NewAuditEntry(action, AuditEntityImpersonation, session.ID, session.OperatorID, reason)
+	entry.Details["user_id"] = session.UserID
+	for key, value := range details {
+		entry.Details[key] = value
+	}
+	err := repository.SaveWithFreshID(
+		func() error { return s.auditRepo.Save(ctx, entry) },
+		func() { entry.ID = domain.NewID() },
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record audit entry for impersonation",
+			slog.String("session_id", session.ID),
+			slog.String("error", err.Error()))
+	}
+}
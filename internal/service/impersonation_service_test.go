@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestImpersonationService_AuditedSession(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	audit := memory.NewAuditRepository()
+	cfg := ImpersonationConfig{SupportOperators: []string{"support-1"}, MaxDuration: 10 * time.Minute}
+	svc := NewImpersonationService(memory.NewImpersonationRepository(), audit, cfg, logger)
+
+	if _, err := svc.Start(ctx, "ops-9", "user1", "ticket 42"); !errors.Is(err, ErrImpersonationForbidden) {
+		t.Errorf("expected operator without the support role to be rejected, got %v", err)
+	}
+	if _, err := svc.Start(ctx, "support-1", "user1", ""); !errors.Is(err, ErrInvalidImpersonation) {
+		t.Errorf("expected missing reason to be rejected, got %v", err)
+	}
+
+	session, err := svc.Start(ctx, "support-1", "user1", "ticket 42")
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if _, err := svc.Authorize(ctx, session.ID, "support-2"); !errors.Is(err, ErrImpersonationForbidden) {
+		t.Errorf("expected another operator to be unable to use the session, got %v", err)
+	}
+
+	authorized, err := svc.Authorize(ctx, session.ID, "support-1")
+	if err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	svc.RecordRequest(ctx, authorized, ImpersonatedRequest{Method: "GET", Path: "/api/v1/accounts/acc1", Status: 200})
+	svc.RecordRequest(ctx, authorized, ImpersonatedRequest{Method: "GET", Path: "/api/v1/transactions", Status: 200})
+
+	stored, trail, err := svc.Get(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if stored.Requests != 2 {
+		t.Errorf("expected 2 recorded requests, got %d", stored.Requests)
+	}
+	requests := 0
+	for _, entry := range trail {
+		if entry.Action == AuditActionImpersonatedRequest {
+			requests++
+			if entry.Actor != "support-1" || entry.Details["user_id"] != "user1" {
+				t.Errorf("expected request audit to name operator and user, got %+v", entry)
+			}
+		}
+	}
+	if requests != 2 || len(trail) != 3 {
+		t.Errorf("expected start plus 2 request audit entries, got %d entries", len(trail))
+	}
+
+	svc.now = func() time.Time { return time.Now().Add(11 * time.Minute) }
+	if _, err := svc.Authorize(ctx, session.ID, "support-1"); !errors.Is(err, ErrImpersonationEnded) {
+		t.Errorf("expected expired session to be rejected, got %v", err)
+	}
+	svc.now = time.Now
+
+	if _, err := svc.End(ctx, session.ID, "support-1"); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	if _, err := svc.Authorize(ctx, session.ID, "support-1"); !errors.Is(err, ErrImpersonationEnded) {
+		t.Errorf("expected ended session to be rejected, got %v", err)
+	}
+	if _, err := svc.End(ctx, session.ID, "support-1"); !errors.Is(err, ErrImpersonationEnded) {
+		t.Errorf("expected double end to fail, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"maps"
+	"time"
+)
+
+type Inbox struct {
+	Notifications []*domain.InboxNotification `json:"notifications"`
+	Unread        int                         `json:"unread"`
+}
+
+type InboxService struct {
+	repo repository.InboxRepository
+}
+
+func NewInboxService(repo repository.InboxRepository) *InboxService {
+	return &InboxService{repo: repo}
+}
+
+func (s *InboxService) Deliver(ctx context.Context, msg NotificationMessage) error {
+	userID := msg.UserID
+	if userID == "" {
+		userID = msg.Recipient
+	}
+	if userID == "" {
+		return fmt.Errorf("%w: inbox notification has no user", ErrPermanentDelivery)
+	}
+
+	notification := &domain.InboxNotification{
+		ID:        domain.NewID(),
+		UserID:    userID,
+		MessageID: msg.ID,
+		Subject:   msg.Subject,
+		Message:   msg.Message,
+		Priority:  msg.Priority,
+		Metadata:  maps.Clone(msg.Metadata),
+		CreatedAt: time.Now(),
+	}
+	return repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, notification) },
+		func() { notification.ID = domain.NewID() },
+	)
+}
+
+func (s *InboxService) List(ctx context.Context, userID string, unreadOnly bool) (*Inbox, error) {
+	notifications, err := s.repo.GetByUser(ctx, userID, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox := &Inbox{Notifications: notifications}
+	for _, notification := range notifications {
+		if !notification.Read {
+			inbox.Unread++
+		}
+	}
+	return inbox, nil
+}
+
+func (s *InboxService) MarkRead(ctx context.Context, userID, id string) (*domain.InboxNotification, error) {
+	return s.repo.MarkRead(ctx, userID, id, time.Now())
+}
+
+func (s *InboxService) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	return s.repo.MarkAllRead(ctx, userID, time.Now())
+}
@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	MetadataIngestionKey           = "ingestion_key"
+	IngestionMessageCleanupJobName = "ingestion_message_cleanup"
+
+	DuplicateByMessageID      = "message_id"
+	DuplicateByIdempotencyKey = "idempotency_key"
+)
+
+type IngestionRequest struct {
+	IdempotencyKey string                 `json:"idempotency_key"`
+	Type           domain.TransactionType `json:"type"`
+	Amount         float64                `json:"amount"`
+	Currency       string                 `json:"currency"`
+	FromAccountID  string                 `json:"from_account_id,omitempty"`
+	ToAccountID    string                 `json:"to_account_id,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Metadata       map[string]string      `json:"metadata,omitempty"`
+}
+
+type IngestionConfig struct {
+	Consumers       int
+	MaxAttempts     int
+	ProcessTimeout  time.Duration
+	MessageTTL      time.Duration
+	CleanupInterval time.Duration
+}
+
+func DefaultIngestionConfig() IngestionConfig {
+	return IngestionConfig{
+		Consumers:       4,
+		MaxAttempts:     5,
+		ProcessTimeout:  30 * time.Second,
+		MessageTTL:      7 * 24 * time.Hour,
+		CleanupInterval: time.Hour,
+	}
+}
+
+type IngestionStats struct {
+	Received     int64 `json:"received"`
+	Processed    int64 `json:"processed"`
+	Duplicates   int64 `json:"duplicates"`
+	Redelivered  int64 `json:"redelivered"`
+	Rejected     int64 `json:"rejected"`
+	Retried      int64 `json:"retried"`
+	DeadLettered int64 `json:"dead_lettered"`
+}
+
+type deadLetter struct {
+	Reason string          `json:"reason"`
+	Body   json.RawMessage `json:"body"`
+}
+
+type IngestionMetrics interface {
+	ObserveIngestionDuplicate(source string)
+}
+
+type IngestionService struct {
+	broker     Broker
+	deadLetter Broker
+	processor  *processor.TransactionProcessor
+	repo       repository.IngestionRepository
+	messages   repository.ProcessedMessageRepository
+	metrics    IngestionMetrics
+	cfg        IngestionConfig
+	wg         sync.WaitGroup
+	cancel     context.CancelFunc
+	stats      struct{ received, processed, duplicates, redelivered, rejected, retried, deadLettered atomic.Int64 }
+	logger     *slog.Logger
+}
+
+func NewIngestionService(
+	broker Broker,
+	txProcessor *processor.TransactionProcessor,
+	repo repository.IngestionRepository,
+	cfg IngestionConfig,
+	logger *slog.Logger,
+) *IngestionService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Consumers <= 0 {
+		cfg.Consumers = 1
+	}
+
+	return &IngestionService{
+		broker:    broker,
+		processor: txProcessor,
+		repo:      repo,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+func (s *IngestionService) WithDeadLetter(broker Broker) *IngestionService {
+	s.deadLetter = broker
+	return s
+}
+
+// WithProcessedMessages remembers which broker message produced which
+// transaction, so a redelivered message is acknowledged without decoding or
+// claiming it again.
+func (s *IngestionService) WithProcessedMessages(messages repository.ProcessedMessageRepository) *IngestionService {
+	s.messages = messages
+	return s
+}
+
+func (s *IngestionService) WithMetrics(metrics IngestionMetrics) *IngestionService {
+	s.metrics = metrics
+	return s
+}
+
+func (s *IngestionService) Register(sched *scheduler.Scheduler) error {
+	if s.messages == nil {
+		return nil
+	}
+	return sched.Register(scheduler.Job{
+		Name:     IngestionMessageCleanupJobName,
+		Schedule: scheduler.Every(s.cfg.CleanupInterval),
+		Run: func(ctx context.Context) error {
+			_, err := s.CleanupMessages(ctx)
+			return err
+		},
+	})
+}
+
+func (s *IngestionService) CleanupMessages(ctx context.Context) (int, error) {
+	if s.messages == nil {
+		return 0, nil
+	}
+	deleted, err := s.messages.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired processed messages: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.InfoContext(ctx, "Expired processed messages deleted", slog.Int("count", deleted))
+	}
+	return deleted, nil
+}
+
+func (s *IngestionService) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	deliveries, err := s.broker.Consume(ctx)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to consume ingestion queue: %w", err)
+	}
+	s.cancel = cancel
+
+	for i := 0; i < s.cfg.Consumers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for delivery := range deliveries {
+				s.Handle(ctx, delivery)
+			}
+		}()
+	}
+	s.logger.Info("Ingestion consumers started", slog.Int("consumers", s.cfg.Consumers))
+	return nil
+}
+
+func (s *IngestionService) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Ingestion consumers stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *IngestionService) Stats() IngestionStats {
+	return IngestionStats{
+		Received:     s.stats.received.Load(),
+		Processed:    s.stats.processed.Load(),
+		Duplicates:   s.stats.duplicates.Load(),
+		Redelivered:  s.stats.redelivered.Load(),
+		Rejected:     s.stats.rejected.Load(),
+		Retried:      s.stats.retried.Load(),
+		DeadLettered: s.stats.deadLettered.Load(),
+	}
+}
+
+func (s *IngestionService) Status(ctx context.Context, key string) (*domain.IngestionRecord, error) {
+	return s.repo.GetByKey(ctx, key)
+}
+
+func (s *IngestionService) Handle(ctx context.Context, delivery BrokerDelivery) {
+	s.stats.received.Add(1)
+	if s.redelivered(ctx, delivery) {
+		s.ack(delivery)
+		return
+	}
+
+	var req IngestionRequest
+	if err := json.Unmarshal(delivery.Body, &req); err != nil {
+		s.poison(ctx, delivery, fmt.Sprintf("undecodable message: %v", err))
+		return
+	}
+	if req.IdempotencyKey == "" {
+		s.poison(ctx, delivery, "idempotency_key is required")
+		return
+	}
+	if err := processor.CheckClientMetadata(req.Metadata); err != nil {
+		s.poison(ctx, delivery, err.Error())
+		return
+	}
+
+	record, err := s.repo.Claim(ctx, req.IdempotencyKey, 2*s.cfg.ProcessTimeout)
+	if err != nil {
+		s.retry(delivery, req.IdempotencyKey, err)
+		return
+	}
+	if record.Terminal() {
+		s.duplicate(DuplicateByIdempotencyKey)
+		s.remember(ctx, delivery, record)
+		s.ack(delivery)
+		return
+	}
+	if record.TransactionID != "" {
+		if tx, err := s.processor.GetTransaction(ctx, record.TransactionID); err == nil {
+			s.duplicate(DuplicateByIdempotencyKey)
+			s.finish(ctx, delivery, record, tx, nil)
+			return
+		}
+	}
+	if record.Attempts > s.cfg.MaxAttempts {
+		record.Status = domain.IngestionDeadLettered
+		record.Error = fmt.Sprintf("exceeded %d attempts", s.cfg.MaxAttempts)
+		s.save(ctx, record)
+		s.poison(ctx, delivery, record.Error)
+		return
+	}
+
+	tx := domain.NewTransaction(req.Type, req.Amount, req.Currency).
+		WithDescription(req.Description).
+		WithAccounts(req.FromAccountID, req.ToAccountID)
+	for k, v := range req.Metadata {
+		tx.AddMetadata(k, v)
+	}
+	tx.AddMetadata(MetadataIngestionKey, req.IdempotencyKey)
+
+	record.TransactionID = tx.ID
+	if err := s.repo.Save(ctx, record); err != nil {
+		s.release(ctx, record.Key)
+		s.retry(delivery, req.IdempotencyKey, err)
+		return
+	}
+
+	processCtx, cancel := context.WithTimeout(ctx, s.cfg.ProcessTimeout)
+	err = s.processor.ProcessTransaction(processCtx, tx)
+	cancel()
+	if retryableIngestion(err) {
+		s.release(ctx, record.Key)
+		s.retry(delivery, req.IdempotencyKey, err)
+		return
+	}
+	s.finish(ctx, delivery, record, tx, err)
+}
+
+func (s *IngestionService) finish(ctx context.Context, delivery BrokerDelivery, record *domain.IngestionRecord, tx *domain.Transaction, err error) {
+	record.TransactionStatus = tx.Status
+	record.Status = domain.IngestionCompleted
+	record.Error = ""
+	if err != nil {
+		record.Status = domain.IngestionRejected
+		record.Error = err.Error()
+		s.stats.rejected.Add(1)
+	} else {
+		s.stats.processed.Add(1)
+	}
+	s.save(ctx, record)
+	s.remember(ctx, delivery, record)
+	s.ack(delivery)
+}
+
+func (s *IngestionService) redelivered(ctx context.Context, delivery BrokerDelivery) bool {
+	if s.messages == nil || delivery.MessageID == "" {
+		return false
+	}
+	msg, err := s.messages.GetByMessageID(ctx, delivery.MessageID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			s.logger.WarnContext(ctx, "Failed to look up processed message",
+				slog.String("message_id", delivery.MessageID),
+				slog.String("error", err.Error()))
+		}
+		return false
+	}
+	s.stats.redelivered.Add(1)
+	s.duplicate(DuplicateByMessageID)
+	s.logger.InfoContext(ctx, "Acknowledging redelivered ingestion message",
+		slog.String("message_id", msg.MessageID),
+		slog.String("transaction_id", msg.TransactionID))
+	return true
+}
+
+func (s *IngestionService) remember(ctx context.Context, delivery BrokerDelivery, record *domain.IngestionRecord) {
+	if s.messages == nil || delivery.MessageID == "" {
+		return
+	}
+	now := time.Now()
+	msg := &domain.ProcessedMessage{
+		MessageID:      delivery.MessageID,
+		IdempotencyKey: record.Key,
+		TransactionID:  record.TransactionID,
+		ProcessedAt:    now,
+	}
+	if s.cfg.MessageTTL > 0 {
+		msg.ExpiresAt = now.Add(s.cfg.MessageTTL)
+	}
+	if err := s.messages.Save(ctx, msg); err != nil {
+		s.logger.WarnContext(ctx, "Failed to record processed message",
+			slog.String("message_id", delivery.MessageID),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (s *IngestionService) duplicate(source string) {
+	s.stats.duplicates.Add(1)
+	if s.metrics != nil {
+		s.metrics.ObserveIngestionDuplicate(source)
+	}
+}
+
+func (s *IngestionService) save(ctx context.Context, record *domain.IngestionRecord) {
+	if err := s.repo.Save(ctx, record); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record ingestion outcome",
+			slog.String("idempotency_key", record.Key),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (s *IngestionService) release(ctx context.Context, key string) {
+	if err := s.repo.Release(context.WithoutCancel(ctx), key); err != nil {
+		s.logger.WarnContext(ctx, "Failed to release ingestion claim",
+			slog.String("idempotency_key", key),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (s *IngestionService) retry(delivery BrokerDelivery, key string, err error) {
+	s.stats.retried.Add(1)
+	s.logger.Warn("Requeueing ingestion message",
+		slog.String("idempotency_key", key),
+		slog.String("error", err.Error()))
+	if delivery.Nack != nil {
+		if err := delivery.Nack(true); err != nil {
+			s.logger.Error("Failed to requeue ingestion message", slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (s *IngestionService) poison(ctx context.Context, delivery BrokerDelivery, reason string) {
+	s.stats.deadLettered.Add(1)
+	s.logger.ErrorContext(ctx, "Dead-lettering ingestion message", slog.String("reason", reason))
+
+	if s.deadLetter == nil {
+		if delivery.Nack != nil {
+			_ = delivery.Nack(false)
+		}
+		return
+	}
+	body, err := json.Marshal(deadLetter{Reason: reason, Body: validJSON(delivery.Body)})
+	if err == nil {
+		err = s.deadLetter.Publish(ctx, body)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to publish dead letter", slog.String("error", err.Error()))
+		if delivery.Nack != nil {
+			_ = delivery.Nack(true)
+		}
+		return
+	}
+	s.ack(delivery)
+}
+
+func (s *IngestionService) ack(delivery BrokerDelivery) {
+	if delivery.Ack == nil {
+		return
+	}
+	if err := delivery.Ack(); err != nil {
+		s.logger.Error("Failed to acknowledge ingestion message", slog.String("error", err.Error()))
+	}
+}
+
+func retryableIngestion(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, repository.ErrTransactionConflict)
+}
+
+func validJSON(body []byte) json.RawMessage {
+	if json.Valid(body) {
+		return body
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type deliveryOutcome struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func testDelivery(body []byte) (BrokerDelivery, *deliveryOutcome) {
+	outcome := &deliveryOutcome{}
+	return BrokerDelivery{
+		Body: body,
+		Ack: func() error {
+			outcome.acked = true
+			return nil
+		},
+		Nack: func(requeue bool) error {
+			outcome.nacked = true
+			outcome.requeue = requeue
+			return nil
+		},
+	}, outcome
+}
+
+func TestIngestionService_IdempotentAtLeastOnceDelivery(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a2", UserID: "u2", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+	proc := processor.NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+	ingestionRepo := memory.NewIngestionRepository()
+	deadLetters := &memoryBroker{bodies: make(chan []byte, 10)}
+	svc := NewIngestionService(nil, proc, ingestionRepo, DefaultIngestionConfig(), logger).WithDeadLetter(deadLetters)
+
+	body, _ := json.Marshal(IngestionRequest{IdempotencyKey: "upstream-1", Type: domain.TypeTransfer, Amount: 40, Currency: "USD", FromAccountID: "a1", ToAccountID: "a2"})
+	for i := 0; i < 2; i++ {
+		delivery, outcome := testDelivery(body)
+		svc.Handle(ctx, delivery)
+		if !outcome.acked {
+			t.Fatalf("delivery %d: expected ack, got %+v", i, outcome)
+		}
+	}
+
+	payee, _ := accRepo.GetByID(ctx, "a2")
+	if payee.Balance != 40 {
+		t.Errorf("expected redelivered message to be applied once, got balance %v", payee.Balance)
+	}
+	record, err := svc.Status(ctx, "upstream-1")
+	if err != nil || record.Status != domain.IngestionCompleted || record.TransactionStatus != domain.StatusCompleted || record.Attempts != 1 {
+		t.Fatalf("expected completed ingestion record, got %+v (%v)", record, err)
+	}
+	if tx, _ := txRepo.GetByID(ctx, record.TransactionID); tx == nil || tx.Metadata[MetadataIngestionKey] != "upstream-1" {
+		t.Errorf("expected transaction tagged with ingestion key, got %+v", tx)
+	}
+
+	overdraft, _ := json.Marshal(IngestionRequest{IdempotencyKey: "upstream-2", Type: domain.TypeTransfer, Amount: 500, Currency: "USD", FromAccountID: "a1", ToAccountID: "a2"})
+	delivery, outcome := testDelivery(overdraft)
+	svc.Handle(ctx, delivery)
+	if record, _ := svc.Status(ctx, "upstream-2"); !outcome.acked || record.Status != domain.IngestionRejected || record.Error == "" {
+		t.Errorf("expected business rejection to be recorded and acked, got %+v / %+v", outcome, record)
+	}
+
+	delivery, outcome = testDelivery([]byte("not json"))
+	svc.Handle(ctx, delivery)
+	if !outcome.acked || len(deadLetters.bodies) != 1 {
+		t.Errorf("expected poison message to be dead-lettered, got %+v with %d dead letters", outcome, len(deadLetters.bodies))
+	}
+
+	if stats := svc.Stats(); stats.Received != 4 || stats.Processed != 1 || stats.Duplicates != 1 || stats.Rejected != 1 || stats.DeadLettered != 1 {
+		t.Errorf("unexpected ingestion stats %+v", stats)
+	}
+}
+
+func TestIngestionService_RequeuesWhileClaimed(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewIngestionRepository()
+	if _, err := repo.Claim(ctx, "k1", DefaultIngestionConfig().ProcessTimeout); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	proc := processor.NewTransactionProcessor(memory.NewTransactionRepository(), memory.NewAccountRepository(), memory.NewRuleRepository(), 1)
+	svc := NewIngestionService(nil, proc, repo, DefaultIngestionConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	body, _ := json.Marshal(IngestionRequest{IdempotencyKey: "k1", Type: domain.TypeDeposit, Amount: 5, Currency: "USD", ToAccountID: "a1"})
+	delivery, outcome := testDelivery(body)
+	svc.Handle(ctx, delivery)
+	if !outcome.nacked || !outcome.requeue || outcome.acked {
+		t.Errorf("expected in-flight duplicate to be requeued, got %+v", outcome)
+	}
+}
+
+type duplicateRecorder struct {
+	sources []string
+}
+
+func (r *duplicateRecorder) ObserveIngestionDuplicate(source string) {
+	r.sources = append(r.sources, source)
+}
+
+func TestIngestionService_AcknowledgesRedeliveredMessages(t *testing.T) {
+	ctx := context.Background()
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "a1", UserID: "u1", Balance: 0, Status: domain.AccountActive, Currency: "USD"})
+	proc := processor.NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1)
+	messages := memory.NewProcessedMessageRepository()
+	metrics := &duplicateRecorder{}
+	cfg := DefaultIngestionConfig()
+	cfg.MessageTTL = 50 * time.Millisecond
+	svc := NewIngestionService(nil, proc, memory.NewIngestionRepository(), cfg, slog.New(slog.NewTextHandler(io.Discard, nil))).
+		WithProcessedMessages(messages).
+		WithMetrics(metrics)
+
+	body, _ := json.Marshal(IngestionRequest{IdempotencyKey: "dep-1", Type: domain.TypeDeposit, Amount: 25, Currency: "USD", ToAccountID: "a1"})
+	for i := 0; i < 2; i++ {
+		delivery, outcome := testDelivery(body)
+		delivery.MessageID = "msg-1"
+		svc.Handle(ctx, delivery)
+		if !outcome.acked {
+			t.Fatalf("delivery %d: expected ack, got %+v", i, outcome)
+		}
+	}
+	record, _ := svc.Status(ctx, "dep-1")
+	if msg, err := messages.GetByMessageID(ctx, "msg-1"); err != nil || msg.TransactionID != record.TransactionID {
+		t.Fatalf("expected message mapped to transaction %s, got %+v (%v)", record.TransactionID, msg, err)
+	}
+
+	delivery, _ := testDelivery(body)
+	delivery.MessageID = "msg-2"
+	svc.Handle(ctx, delivery)
+
+	if acc, _ := accRepo.GetByID(ctx, "a1"); acc.Balance != 25 {
+		t.Errorf("expected deposit applied once, got balance %v", acc.Balance)
+	}
+	if stats := svc.Stats(); stats.Processed != 1 || stats.Duplicates != 2 || stats.Redelivered != 1 {
+		t.Errorf("unexpected ingestion stats %+v", stats)
+	}
+	if len(metrics.sources) != 2 || metrics.sources[0] != DuplicateByMessageID || metrics.sources[1] != DuplicateByIdempotencyKey {
+		t.Errorf("unexpected duplicate metrics %v", metrics.sources)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if deleted, err := svc.CleanupMessages(ctx); err != nil || deleted != 2 {
+		t.Errorf("expected both processed messages to expire, got %d (%v)", deleted, err)
+	}
+}
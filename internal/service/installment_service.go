@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+const InstallmentDispatchJobName = "installment_dispatch"
+
+var ErrInstallmentPlanState = errors.New("installment plan is not in a valid state for this operation")
+
+type InstallmentConfig struct {
+	DispatchInterval time.Duration
+	MaxInstallments  int
+}
+
+func DefaultInstallmentConfig() InstallmentConfig {
+	return InstallmentConfig{
+		DispatchInterval: time.Minute,
+		MaxInstallments:  60,
+	}
+}
+
+type InstallmentRequest struct {
+	FromAccountID string
+	ToAccountID   string
+	Amount        float64
+	Currency      string
+	Count         int
+	Interval      time.Duration
+	FirstDueAt    time.Time
+	Description   string
+}
+
+type InstallmentService struct {
+	planRepo  repository.InstallmentPlanRepository
+	txRepo    repository.TransactionRepository
+	processor *processor.TransactionProcessor
+	cfg       InstallmentConfig
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewInstallmentService(
+	planRepo repository.InstallmentPlanRepository,
+	txRepo repository.TransactionRepository,
+	txProcessor *processor.TransactionProcessor,
+	cfg InstallmentConfig,
+	logger *slog.Logger,
+) *InstallmentService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &InstallmentService{
+		planRepo:  planRepo,
+		txRepo:    txRepo,
+		processor: txProcessor,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *InstallmentService) Create(ctx context.Context, req InstallmentRequest) (*domain.InstallmentPlan, []*domain.Transaction, error) {
+	if req.Count < 2 || (s.cfg.MaxInstallments > 0 && req.Count > s.cfg.MaxInstallments) {
+		return nil, nil, fmt.Errorf("%w: installment count must be between 2 and %d", processor.ErrInvalidInstallmentPlan, s.cfg.MaxInstallments)
+	}
+	if req.Interval <= 0 {
+		return nil, nil, fmt.Errorf("%w: interval must be positive", processor.ErrInvalidInstallmentPlan)
+	}
+
+	first := req.FirstDueAt
+	if first.IsZero() {
+		first = s.now()
+	}
+	dueDates := make([]time.Time, req.Count)
+	for i := range dueDates {
+		dueDates[i] = first.Add(time.Duration(i) * req.Interval)
+	}
+
+	plan := domain.NewInstallmentPlan(req.FromAccountID, req.ToAccountID, req.Amount, req.Currency, dueDates)
+	plan.Description = req.Description
+
+	txs, err := s.processor.ScheduleInstallments(ctx, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = repository.SaveWithFreshID(
+		func() error { return s.planRepo.Save(ctx, plan) },
+		func() { plan.ID = domain.NewID() },
+	)
+	if err != nil {
+		if _, cancelErr := s.processor.CancelInstallments(ctx, plan.TransactionIDs); cancelErr != nil {
+			err = errors.Join(err, cancelErr)
+		}
+		return nil, nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Installment plan created",
+		slog.String("plan_id", plan.ID),
+		slog.String("from_account", plan.FromAccountID),
+		slog.String("to_account", plan.ToAccountID),
+		slog.Int("installments", len(txs)))
+
+	return plan, txs, nil
+}
+
+func (s *InstallmentService) Get(ctx context.Context, id string) (*domain.InstallmentPlan, []*domain.Transaction, error) {
+	plan, err := s.planRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txs, err := s.installments(ctx, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan, txs, nil
+}
+
+func (s *InstallmentService) Cancel(ctx context.Context, id string) (*domain.InstallmentPlan, error) {
+	plan, err := s.planRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Status != domain.InstallmentPlanActive {
+		return nil, fmt.Errorf("%w: plan %s is %s", ErrInstallmentPlanState, plan.ID, plan.Status)
+	}
+
+	cancelled, err := s.processor.CancelInstallments(ctx, plan.TransactionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	plan.Status = domain.InstallmentPlanCancelled
+	plan.CancelledAt = &now
+	if err := s.planRepo.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Installment plan cancelled",
+		slog.String("plan_id", plan.ID),
+		slog.Int("cancelled_installments", cancelled))
+
+	return plan, nil
+}
+
+func (s *InstallmentService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     InstallmentDispatchJobName,
+		Schedule: scheduler.Every(s.cfg.DispatchInterval),
+		Run:      s.ProcessDue,
+	})
+}
+
+func (s *InstallmentService) ProcessDue(ctx context.Context) error {
+	scheduled, err := s.txRepo.GetByStatus(ctx, domain.StatusScheduled)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled installments: %w", err)
+	}
+
+	now := s.now()
+	type dueInstallment struct {
+		id     string
+		planID string
+		due    time.Time
+	}
+	var due []dueInstallment
+	for _, tx := range scheduled {
+		planID := tx.Metadata[processor.MetadataInstallmentPlan]
+		dueAt, ok := processor.InstallmentDueAt(tx)
+		if planID == "" || !ok || dueAt.After(now) {
+			continue
+		}
+		due = append(due, dueInstallment{id: tx.ID, planID: planID, due: dueAt})
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].due.Before(due[j].due)
+	})
+
+	var errs []error
+	plans := make(map[string]bool)
+	for _, installment := range due {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		var tx *domain.Transaction
+		err := s.processor.RunQueued(ctx, processor.QueueScheduled, func(ctx context.Context) error {
+			var err error
+			tx, err = s.processor.ExecuteInstallment(ctx, installment.id)
+			return err
+		})
+		if errors.Is(err, processor.ErrInstallmentNotScheduled) {
+			continue
+		}
+		plans[installment.planID] = true
+		if err != nil {
+			s.logger.WarnContext(ctx, "Installment failed",
+				slog.String("plan_id", installment.planID),
+				slog.String("transaction_id", installment.id),
+				slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("installment %s: %w", installment.id, err))
+			continue
+		}
+
+		s.logger.InfoContext(ctx, "Installment executed",
+			slog.String("plan_id", installment.planID),
+			slog.String("transaction_id", tx.ID),
+			slog.String("status", string(tx.Status)))
+	}
+
+	for planID := range plans {
+		if err := s.refreshPlan(ctx, planID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *InstallmentService) refreshPlan(ctx context.Context, planID string) error {
+	plan, err := s.planRepo.GetByID(ctx, planID)
+	if err != nil {
+		return err
+	}
+	if plan.Status != domain.InstallmentPlanActive {
+		return nil
+	}
+
+	txs, err := s.installments(ctx, plan)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		if tx.Status == domain.StatusScheduled {
+			return nil
+		}
+	}
+
+	plan.Status = domain.InstallmentPlanCompleted
+	return s.planRepo.Update(ctx, plan)
+}
+
+func (s *InstallmentService) installments(ctx context.Context, plan *domain.InstallmentPlan) ([]*domain.Transaction, error) {
+	txs := make([]*domain.Transaction, 0, len(plan.TransactionIDs))
+	for _, id := range plan.TransactionIDs {
+		tx, err := s.txRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get installment %s: %w", id, err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
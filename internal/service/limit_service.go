@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/crypto"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const LimitActivationJobName = "limit_change_activation"
+
+var (
+	ErrInvalidLimitChange = errors.New("invalid limit change")
+	ErrInvalidOTP         = errors.New("invalid otp")
+	ErrOTPExpired         = errors.New("otp expired")
+	ErrLimitChangeState   = errors.New("limit change is not in a valid state for this operation")
+)
+
+type Notifier interface {
+	Enqueue(ctx context.Context, msg NotificationMessage) error
+}
+
+type LimitChangeConfig struct {
+	ConfirmationThreshold float64
+	ConfirmationWindow    time.Duration
+	CoolingOff            time.Duration
+	OTPDigits             int
+	OTPTTL                time.Duration
+	MaxOTPAttempts        int
+	ActivationInterval    time.Duration
+}
+
+func DefaultLimitChangeConfig() LimitChangeConfig {
+	return LimitChangeConfig{
+		ConfirmationThreshold: 1000,
+		ConfirmationWindow:    24 * time.Hour,
+		CoolingOff:            24 * time.Hour,
+		OTPDigits:             6,
+		OTPTTL:                10 * time.Minute,
+		MaxOTPAttempts:        3,
+		ActivationInterval:    time.Minute,
+	}
+}
+
+type LimitChangeService struct {
+	accountRepo repository.AccountRepository
+	changeRepo  repository.LimitChangeRepository
+	products    repository.ProductRepository
+	otp         otpChallenge
+	notifier    Notifier
+	cfg         LimitChangeConfig
+	logger      *slog.Logger
+}
+
+func NewLimitChangeService(
+	accountRepo repository.AccountRepository,
+	changeRepo repository.LimitChangeRepository,
+	signer *crypto.Signer,
+	notifier Notifier,
+	cfg LimitChangeConfig,
+	logger *slog.Logger,
+) *LimitChangeService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &LimitChangeService{
+		accountRepo: accountRepo,
+		changeRepo:  changeRepo,
+		otp:         otpChallenge{signer: signer, digits: cfg.OTPDigits, ttl: cfg.OTPTTL, maxAttempts: cfg.MaxOTPAttempts},
+		notifier:    notifier,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (s *LimitChangeService) WithProducts(repo repository.ProductRepository) *LimitChangeService {
+	s.products = repo
+	return s
+}
+
+func (s *LimitChangeService) RequestChange(ctx context.Context, accountID string, limitType domain.LimitType, requested float64) (*domain.LimitChange, error) {
+	if limitType != domain.LimitDaily && limitType != domain.LimitMonthly {
+		return nil, fmt.Errorf("%w: unknown limit type %q", ErrInvalidLimitChange, limitType)
+	}
+	if requested <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidLimitChange)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.currentLimit(ctx, account, limitType)
+	if err != nil {
+		return nil, err
+	}
+	change := domain.NewLimitChange(accountID, limitType, previous, requested)
+
+	baseline, err := s.confirmationBaseline(ctx, accountID, limitType, previous)
+	if err != nil {
+		return nil, err
+	}
+	if requested-baseline <= s.cfg.ConfirmationThreshold {
+		if err := s.apply(ctx, change); err != nil {
+			return nil, err
+		}
+		return change, nil
+	}
+
+	otp, hash, expiresAt, err := s.otp.issue(change.ID)
+	if err != nil {
+		return nil, err
+	}
+	change.Status = domain.LimitChangePendingConfirmation
+	change.OTPHash = hash
+	change.OTPExpiresAt = expiresAt
+
+	if err := s.changeRepo.Save(ctx, change); err != nil {
+		return nil, fmt.Errorf("failed to save limit change: %w", err)
+	}
+
+	s.notify(ctx, account, NotificationSMS, "Limit increase verification",
+		fmt.Sprintf("Your code to confirm the %s limit increase to %.2f is %s.", limitType, requested, otp))
+	s.notify(ctx, account, NotificationEmail, "Limit increase requested",
+		fmt.Sprintf("A request to raise your %s limit from %.2f to %.2f was received. If this was not you, contact support immediately.", limitType, change.PreviousLimit, requested))
+
+	s.logger.InfoContext(ctx, "Limit increase requested",
+		slog.String("change_id", change.ID),
+		slog.String("account_id", accountID),
+		slog.String("limit_type", string(limitType)),
+		slog.Float64("requested_limit", requested))
+
+	return change, nil
+}
+
+func (s *LimitChangeService) Confirm(ctx context.Context, changeID, otp string) (*domain.LimitChange, error) {
+	change, err := s.changeRepo.GetByID(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != domain.LimitChangePendingConfirmation {
+		return nil, fmt.Errorf("%w: limit change %s is %s", ErrLimitChangeState, change.ID, change.Status)
+	}
+
+	if err := s.otp.verify(change.ID, otp, change.OTPHash, change.OTPExpiresAt, change.OTPAttempts); err != nil {
+		if errors.Is(err, ErrOTPExpired) {
+			change.Status = domain.LimitChangeExpired
+		} else {
+			change.OTPAttempts++
+		}
+		if updateErr := s.changeRepo.Update(ctx, change); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	effectiveAt := time.Now().Add(s.cfg.CoolingOff)
+	change.Status = domain.LimitChangeCoolingOff
+	change.EffectiveAt = &effectiveAt
+	change.OTPHash = ""
+	if err := s.changeRepo.Update(ctx, change); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Limit increase confirmed",
+		slog.String("change_id", change.ID),
+		slog.Time("effective_at", effectiveAt))
+
+	return change, nil
+}
+
+func (s *LimitChangeService) Cancel(ctx context.Context, changeID string) (*domain.LimitChange, error) {
+	change, err := s.changeRepo.GetByID(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != domain.LimitChangePendingConfirmation && change.Status != domain.LimitChangeCoolingOff {
+		return nil, fmt.Errorf("%w: limit change %s is %s", ErrLimitChangeState, change.ID, change.Status)
+	}
+
+	change.Status = domain.LimitChangeCancelled
+	if err := s.changeRepo.Update(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *LimitChangeService) Get(ctx context.Context, changeID string) (*domain.LimitChange, error) {
+	return s.changeRepo.GetByID(ctx, changeID)
+}
+
+func (s *LimitChangeService) ActivateDue(ctx context.Context) error {
+	due, err := s.changeRepo.GetDue(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get due limit changes: %w", err)
+	}
+
+	var errs []error
+	for _, change := range due {
+		if err := s.apply(ctx, change); err != nil {
+			errs = append(errs, fmt.Errorf("limit change %s: %w", change.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *LimitChangeService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     LimitActivationJobName,
+		Schedule: scheduler.Every(s.cfg.ActivationInterval),
+		Run:      s.ActivateDue,
+	})
+}
+
+func (s *LimitChangeService) currentLimit(ctx context.Context, account *domain.Account, limitType domain.LimitType) (float64, error) {
+	limit := account.Limit(limitType)
+	if limit != 0 || s.products == nil || account.ProductID == "" {
+		return limit, nil
+	}
+
+	product, err := s.products.GetByID(ctx, account.ProductID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account product: %w", err)
+	}
+	return product.Limit(limitType), nil
+}
+
+func (s *LimitChangeService) confirmationBaseline(ctx context.Context, accountID string, limitType domain.LimitType, current float64) (float64, error) {
+	changes, err := s.changeRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get recent limit changes: %w", err)
+	}
+
+	since := time.Now().Add(-s.cfg.ConfirmationWindow)
+	baseline := current
+	for _, change := range changes {
+		unconfirmed := change.Status == domain.LimitChangeApplied && change.EffectiveAt == nil
+		if change.LimitType != limitType || !unconfirmed || change.CreatedAt.Before(since) {
+			continue
+		}
+		baseline = min(baseline, change.PreviousLimit)
+	}
+	return baseline, nil
+}
+
+func (s *LimitChangeService) apply(ctx context.Context, change *domain.LimitChange) error {
+	account, err := s.accountRepo.GetByID(ctx, change.AccountID)
+	if err != nil {
+		return err
+	}
+
+	account.SetLimit(change.LimitType, change.RequestedLimit)
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return fmt.Errorf("failed to update account limit: %w", err)
+	}
+
+	wasPending := change.Status == domain.LimitChangeCoolingOff
+	change.Status = domain.LimitChangeApplied
+	if wasPending {
+		err = s.changeRepo.Update(ctx, change)
+	} else {
+		err = s.changeRepo.Save(ctx, change)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record limit change: %w", err)
+	}
+
+	if wasPending {
+		s.notify(ctx, account, NotificationEmail, "Limit increase activated",
+			fmt.Sprintf("Your %s limit is now %.2f.", change.LimitType, change.RequestedLimit))
+	}
+
+	s.logger.InfoContext(ctx, "Account limit changed",
+		slog.String("change_id", change.ID),
+		slog.String("account_id", account.ID),
+		slog.String("limit_type", string(change.LimitType)),
+		slog.Float64("limit", change.RequestedLimit))
+
+	return nil
+}
+
+func (s *LimitChangeService) notify(ctx context.Context, account *domain.Account, notificationType NotificationType, subject, message string) {
+	if s.notifier == nil {
+		return
+	}
+
+	err := s.notifier.Enqueue(ctx, NotificationMessage{
+		Type:      notificationType,
+		UserID:    account.UserID,
+		Recipient: account.UserID,
+		Subject:   subject,
+		Message:   message,
+		Priority:  8,
+		Metadata:  map[string]string{"account_id": account.ID},
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to queue limit change notification",
+			slog.String("account_id", account.ID),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (s *LimitChangeService) LimitThresholdCrossed(ctx context.Context, crossing processor.LimitThresholdCrossing) {
+	if s.notifier == nil {
+		return
+	}
+	account, err := s.accountRepo.GetByID(ctx, crossing.AccountID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load account for limit threshold notification",
+			slog.String("account_id", crossing.AccountID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	err = s.notifier.Enqueue(ctx, NotificationMessage{
+		Type:      NotificationPush,
+		UserID:    account.UserID,
+		Recipient: account.UserID,
+		Subject:   fmt.Sprintf("You have used %.0f%% of your %s limit", crossing.Threshold, crossing.LimitType),
+		Message: fmt.Sprintf("You have used %.2f %s of your %.2f %s %s limit.",
+			crossing.Used, account.Currency, crossing.Limit, account.Currency, crossing.LimitType),
+		Priority: 6,
+		Metadata: map[string]string{
+			"account_id":     crossing.AccountID,
+			"transaction_id": crossing.TransactionID,
+			"limit_type":     string(crossing.LimitType),
+			"threshold":      strconv.FormatFloat(crossing.Threshold, 'f', -1, 64),
+		},
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to queue limit threshold notification",
+			slog.String("account_id", crossing.AccountID),
+			slog.String("error", err.Error()))
+	}
+}
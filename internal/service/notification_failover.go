@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"log/slog"
+)
+
+var ErrPermanentDelivery = errors.New("permanent delivery failure")
+
+type FailoverConfig struct {
+	MinPriority int
+}
+
+func DefaultFailoverConfig() FailoverConfig {
+	return FailoverConfig{MinPriority: 8}
+}
+
+func (s *NotificationService) WithFailover(preferences repository.NotificationPreferenceRepository, cfg FailoverConfig) *NotificationService {
+	s.preferences = preferences
+	s.failover = cfg
+	return s
+}
+
+func (s *NotificationService) WithDeliveryHistory(history repository.DeliveryRepository) *NotificationService {
+	s.history = history
+	return s
+}
+
+func (s *NotificationService) shouldFailover(msg NotificationMessage, err error) bool {
+	return s.preferences != nil &&
+		msg.UserID != "" &&
+		msg.Priority >= s.failover.MinPriority &&
+		errors.Is(err, ErrPermanentDelivery)
+}
+
+func (s *NotificationService) failoverDelivery(msg NotificationMessage, workerID int) {
+	ctx := context.Background()
+
+	prefs, err := s.preferences.GetByUser(ctx, msg.UserID)
+	if err != nil {
+		s.logger.Warn("No fallback channels for notification",
+			slog.String("message_id", msg.ID),
+			slog.String("user_id", msg.UserID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	attempted := map[NotificationType]bool{msg.Type: true}
+	from := msg.Type
+	for _, contact := range prefs.Channels {
+		channel := NotificationType(contact.Channel)
+		if attempted[channel] {
+			continue
+		}
+		attempted[channel] = true
+
+		next := msg
+		next.Type = channel
+		next.Recipient = contact.Address
+		if next.Email != nil {
+			email := *next.Email
+			email.To = contact.Address
+			next.Email = &email
+		}
+
+		err := s.deliver(next, workerID, from)
+		if err == nil {
+			s.logger.Warn("Notification delivered via fallback channel",
+				slog.String("message_id", msg.ID),
+				slog.String("user_id", msg.UserID),
+				slog.String("preferred_channel", string(msg.Type)),
+				slog.String("channel", string(channel)))
+			return
+		}
+		from = channel
+	}
+
+	s.logger.Error("Notification failed on all channels",
+		slog.String("message_id", msg.ID),
+		slog.String("user_id", msg.UserID))
+}
+
+func (s *NotificationService) recordDelivery(msg NotificationMessage, failoverFrom NotificationType, sendErr error) {
+	if s.history == nil {
+		return
+	}
+
+	delivery := domain.NewNotificationDelivery(msg.ID, msg.UserID, string(msg.Type), msg.Recipient)
+	delivery.FailoverFrom = string(failoverFrom)
+	delivery.Status = domain.DeliverySent
+	if sendErr != nil {
+		delivery.Status = domain.DeliveryFailed
+		delivery.Error = sendErr.Error()
+	}
+	if errors.Is(sendErr, ErrConsentWithheld) {
+		delivery.Status = domain.DeliverySuppressed
+	}
+
+	err := repository.SaveWithFreshID(
+		func() error { return s.history.Save(context.Background(), delivery) },
+		func() { delivery.ID = domain.NewID() },
+	)
+	if err != nil {
+		s.logger.Error("Failed to record notification delivery",
+			slog.String("message_id", msg.ID),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository/memory"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type failingSMSService struct{}
+
+func (failingSMSService) SendSMS(to, message string) error {
+	return fmt.Errorf("%w: provider outage", ErrPermanentDelivery)
+}
+
+func TestNotificationService_FailsOverToNextChannel(t *testing.T) {
+	ctx := context.Background()
+	email := &MockEmailService{}
+	prefs := memory.NewNotificationPreferenceRepository()
+	history := memory.NewDeliveryRepository()
+	_ = prefs.Save(ctx, &domain.NotificationPreferences{UserID: "u1", Channels: []domain.ContactChannel{
+		{Channel: string(NotificationSMS), Address: "+15550100"},
+		{Channel: string(NotificationPush), Address: "device-1"},
+		{Channel: string(NotificationEmail), Address: "u1@example.com"},
+	}})
+	svc := NewNotificationService(email, failingSMSService{}, nil, nil, NotificationScalingConfig{MinWorkers: 1, MaxWorkers: 1}, slog.New(slog.NewTextHandler(io.Discard, nil))).
+		WithFailover(prefs, DefaultFailoverConfig()).
+		WithDeliveryHistory(history)
+
+	_ = svc.Enqueue(ctx, NotificationMessage{ID: "low", UserID: "u1", Type: NotificationSMS, Recipient: "+15550100", Message: "digest", Priority: 1})
+	_ = svc.Enqueue(ctx, NotificationMessage{ID: "high", UserID: "u1", Type: NotificationSMS, Recipient: "+15550100", Subject: "Code", Message: "123456", Priority: 9})
+	defer svc.Shutdown(ctx)
+
+	deadline := time.Now().Add(time.Second)
+	var deliveries []*domain.NotificationDelivery
+	for len(deliveries) < 3 && time.Now().Before(deadline) {
+		deliveries, _ = history.GetByMessage(ctx, "high")
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(deliveries) != 3 {
+		t.Fatalf("expected sms, push and email attempts, got %+v", deliveries)
+	}
+	last := deliveries[2]
+	if last.Channel != string(NotificationEmail) || last.Status != domain.DeliverySent || last.FailoverFrom != string(NotificationPush) {
+		t.Errorf("expected email delivery failed over from push, got %+v", last)
+	}
+	if len(email.SentEmails) != 1 || email.SentEmails[0].To != "u1@example.com" || email.SentEmails[0].TextBody != "123456" {
+		t.Errorf("expected one fallback email, got %+v", email.SentEmails)
+	}
+	if low, _ := history.GetByMessage(ctx, "low"); len(low) != 1 || low[0].Status != domain.DeliveryFailed {
+		t.Errorf("expected low priority message not to fail over, got %+v", low)
+	}
+}
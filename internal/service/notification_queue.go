@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+const DefaultNotificationQueueCapacity = 1000
+
+var ErrQueueClosed = errors.New("notification queue is closed")
+
+type NotificationQueue interface {
+	Publish(ctx context.Context, msg NotificationMessage) error
+	Deliveries() <-chan QueuedNotification
+	Depth() int
+	Close() error
+}
+
+type QueuedNotification struct {
+	Message NotificationMessage
+	Ack     func() error
+	Nack    func(requeue bool) error
+}
+
+func (q QueuedNotification) ack() error {
+	if q.Ack == nil {
+		return nil
+	}
+	return q.Ack()
+}
+
+type ChannelQueue struct {
+	mu       sync.RWMutex
+	messages chan QueuedNotification
+	closed   bool
+}
+
+func NewChannelQueue(capacity int) *ChannelQueue {
+	if capacity <= 0 {
+		capacity = DefaultNotificationQueueCapacity
+	}
+	return &ChannelQueue{messages: make(chan QueuedNotification, capacity)}
+}
+
+func (q *ChannelQueue) Publish(ctx context.Context, msg NotificationMessage) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.messages <- QueuedNotification{Message: msg}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *ChannelQueue) Deliveries() <-chan QueuedNotification {
+	return q.messages
+}
+
+func (q *ChannelQueue) Depth() int {
+	return len(q.messages)
+}
+
+func (q *ChannelQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	return nil
+}
+
+type BrokerDelivery struct {
+	MessageID string
+	Body      []byte
+	Ack       func() error
+	Nack      func(requeue bool) error
+}
+
+type Broker interface {
+	Publish(ctx context.Context, body []byte) error
+	Consume(ctx context.Context) (<-chan BrokerDelivery, error)
+	Depth(ctx context.Context) (int, error)
+	Close() error
+}
+
+type BrokerQueue struct {
+	broker     Broker
+	deliveries chan QueuedNotification
+	once       sync.Once
+	ctx        context.Context
+	cancel     context.CancelFunc
+	logger     *slog.Logger
+}
+
+func NewBrokerQueue(broker Broker, logger *slog.Logger) *BrokerQueue {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BrokerQueue{
+		broker:     broker,
+		deliveries: make(chan QueuedNotification),
+		ctx:        ctx,
+		cancel:     cancel,
+		logger:     logger,
+	}
+}
+
+func (q *BrokerQueue) Publish(ctx context.Context, msg NotificationMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return q.broker.Publish(ctx, body)
+}
+
+func (q *BrokerQueue) Deliveries() <-chan QueuedNotification {
+	q.once.Do(func() {
+		go q.consume()
+	})
+	return q.deliveries
+}
+
+func (q *BrokerQueue) consume() {
+	defer close(q.deliveries)
+
+	incoming, err := q.broker.Consume(q.ctx)
+	if err != nil {
+		q.logger.Error("Failed to consume notifications from broker", slog.String("error", err.Error()))
+		return
+	}
+
+	for {
+		select {
+		case delivery, ok := <-incoming:
+			if !ok {
+				return
+			}
+			var msg NotificationMessage
+			if err := json.Unmarshal(delivery.Body, &msg); err != nil {
+				q.logger.Error("Discarding undecodable notification", slog.String("error", err.Error()))
+				if delivery.Nack != nil {
+					_ = delivery.Nack(false)
+				}
+				continue
+			}
+			select {
+			case q.deliveries <- QueuedNotification{Message: msg, Ack: delivery.Ack, Nack: delivery.Nack}:
+			case <-q.ctx.Done():
+				if delivery.Nack != nil {
+					_ = delivery.Nack(true)
+				}
+				return
+			}
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+func (q *BrokerQueue) Depth() int {
+	depth, err := q.broker.Depth(q.ctx)
+	if err != nil {
+		q.logger.Warn("Failed to read broker queue depth", slog.String("error", err.Error()))
+		return 0
+	}
+	return depth
+}
+
+func (q *BrokerQueue) Close() error {
+	q.cancel()
+	return q.broker.Close()
+}
+
+type QueueNotifier struct {
+	queue NotificationQueue
+}
+
+func NewQueueNotifier(queue NotificationQueue) *QueueNotifier {
+	return &QueueNotifier{queue: queue}
+}
+
+func (n *QueueNotifier) Enqueue(ctx context.Context, msg NotificationMessage) error {
+	return n.queue.Publish(ctx, stampNotification(msg))
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memoryBroker struct {
+	mu     sync.Mutex
+	bodies chan []byte
+	acked  int
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, body []byte) error {
+	b.bodies <- body
+	return nil
+}
+
+func (b *memoryBroker) Consume(ctx context.Context) (<-chan BrokerDelivery, error) {
+	out := make(chan BrokerDelivery)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case body := <-b.bodies:
+				ack := func() error {
+					b.mu.Lock()
+					defer b.mu.Unlock()
+					b.acked++
+					return nil
+				}
+				select {
+				case out <- BrokerDelivery{Body: body, Ack: ack}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (b *memoryBroker) Depth(ctx context.Context) (int, error) { return len(b.bodies), nil }
+func (b *memoryBroker) Close() error                           { return nil }
+
+func (b *memoryBroker) ackedCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.acked
+}
+
+func TestBrokerQueue_PublisherAndNotifierDeployments(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	broker := &memoryBroker{bodies: make(chan []byte, 10)}
+
+	publisher := NewQueueNotifier(NewBrokerQueue(broker, logger))
+	err := publisher.Enqueue(ctx, NotificationMessage{
+		Type:      NotificationEmail,
+		Recipient: "u1@example.com",
+		Subject:   "Statement",
+		Email:     &Email{HTMLBody: "<p>Attached</p>", Attachments: []EmailAttachment{{Filename: "statement.csv", Content: []byte("a,b")}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	email := &MockEmailService{}
+	notifier := NewNotificationServiceWithQueue(NewBrokerQueue(broker, logger), email, nil, nil, nil, NotificationScalingConfig{MinWorkers: 1, MaxWorkers: 1}, logger)
+	defer notifier.Shutdown(ctx)
+
+	deadline := time.Now().Add(time.Second)
+	for broker.ackedCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if broker.ackedCount() != 1 {
+		t.Fatalf("expected the notification to be consumed and acked")
+	}
+	sent := email.SentEmails
+	if len(sent) != 1 || sent[0].To != "u1@example.com" || sent[0].HTMLBody != "<p>Attached</p>" || string(sent[0].Attachments[0].Content) != "a,b" {
+		t.Errorf("expected the email to survive the broker round trip, got %+v", sent)
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+var ErrInvalidNotificationRoute = errors.New("invalid notification route")
+
+type RouteTarget struct {
+	Channel   NotificationType `json:"channel"`
+	Recipient string           `json:"recipient"`
+}
+
+type NotificationRoute struct {
+	Name    string            `json:"name"`
+	Events  []string          `json:"events,omitempty"`
+	Match   map[string]string `json:"match"`
+	Targets []RouteTarget     `json:"targets"`
+}
+
+func (r NotificationRoute) matches(event string, hints map[string]string) bool {
+	if len(r.Events) > 0 && !slices.Contains(r.Events, event) {
+		return false
+	}
+	for key, value := range r.Match {
+		if hints[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+type NotificationRoutingTable struct {
+	routes []NotificationRoute
+}
+
+func NewNotificationRoutingTable(routes []NotificationRoute) (*NotificationRoutingTable, error) {
+	names := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		if route.Name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidNotificationRoute)
+		}
+		if names[route.Name] {
+			return nil, fmt.Errorf("%w: duplicate route %s", ErrInvalidNotificationRoute, route.Name)
+		}
+		names[route.Name] = true
+		if len(route.Match) == 0 {
+			return nil, fmt.Errorf("%w: route %s must match at least one hint", ErrInvalidNotificationRoute, route.Name)
+		}
+		if len(route.Targets) == 0 {
+			return nil, fmt.Errorf("%w: route %s has no targets", ErrInvalidNotificationRoute, route.Name)
+		}
+		for _, target := range route.Targets {
+			switch target.Channel {
+			case NotificationEmail, NotificationSMS, NotificationPush, NotificationSlack:
+			default:
+				return nil, fmt.Errorf("%w: route %s has unsupported channel %q", ErrInvalidNotificationRoute, route.Name, target.Channel)
+			}
+			if target.Recipient == "" {
+				return nil, fmt.Errorf("%w: route %s has a target without a recipient", ErrInvalidNotificationRoute, route.Name)
+			}
+		}
+	}
+
+	sorted := slices.Clone(routes)
+	// The most specific route wins; ties keep the configured order.
+	slices.SortStableFunc(sorted, func(a, b NotificationRoute) int {
+		return len(b.Match) - len(a.Match)
+	})
+	return &NotificationRoutingTable{routes: sorted}, nil
+}
+
+func ParseNotificationRoutes(spec string) (*NotificationRoutingTable, error) {
+	var routes []NotificationRoute
+	if err := json.Unmarshal([]byte(spec), &routes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotificationRoute, err)
+	}
+	return NewNotificationRoutingTable(routes)
+}
+
+func (t *NotificationRoutingTable) Routes() []NotificationRoute {
+	return slices.Clone(t.routes)
+}
+
+func (t *NotificationRoutingTable) Resolve(event string, hints map[string]string) (NotificationRoute, bool) {
+	if len(hints) == 0 {
+		return NotificationRoute{}, false
+	}
+	for _, route := range t.routes {
+		if route.matches(event, hints) {
+			return route, true
+		}
+	}
+	return NotificationRoute{}, false
+}
+
+func (s *NotificationService) WithRouting(table *NotificationRoutingTable) *NotificationService {
+	s.routing = table
+	return s
+}
+
+func (s *NotificationService) Routing() *NotificationRoutingTable {
+	return s.routing
+}
+
+func (s *NotificationService) route(msg NotificationMessage) []NotificationMessage {
+	if s.routing == nil {
+		return []NotificationMessage{msg}
+	}
+	route, ok := s.routing.Resolve(msg.Metadata["event"], msg.Routing)
+	if !ok {
+		return []NotificationMessage{msg}
+	}
+	return routedMessages(msg, route)
+}
+
+func routedMessages(msg NotificationMessage, route NotificationRoute) []NotificationMessage {
+	routed := make([]NotificationMessage, 0, len(route.Targets))
+	for _, target := range route.Targets {
+		copied := msg
+		copied.ID = ""
+		// Routed copies go to staff recipients, so the user's consent does not apply.
+		copied.UserID = ""
+		copied.Type = target.Channel
+		copied.Recipient = target.Recipient
+		copied.Metadata = maps.Clone(msg.Metadata)
+		if copied.Metadata == nil {
+			copied.Metadata = make(map[string]string)
+		}
+		copied.Metadata["route"] = route.Name
+		if msg.Email != nil {
+			email := *msg.Email
+			email.To = target.Recipient
+			copied.Email = &email
+		}
+		routed = append(routed, copied)
+	}
+	return routed
+}
+
+func (s *NotificationService) Publish(ctx context.Context, eventType string, data interface{}) {
+	event, ok := data.(domain.TransactionEventData)
+	if !ok || s.routing == nil {
+		return
+	}
+	route, ok := s.routing.Resolve(eventType, event.Routing)
+	if !ok {
+		return
+	}
+
+	priority := 5
+	if eventType == domain.EventTransactionSuspicious {
+		priority = 10
+	}
+	msg := NotificationMessage{
+		Subject: fmt.Sprintf("%s: %s", eventType, event.TransactionID),
+		Message: fmt.Sprintf("Transaction %s (%s) of %v %s is %s, risk score %d",
+			event.TransactionID, event.Type, event.Amount, event.Currency, event.Status, event.RiskScore),
+		Priority: priority,
+		Metadata: map[string]string{
+			"event":          eventType,
+			"transaction_id": event.TransactionID,
+		},
+		Routing:   event.Routing,
+		CreatedAt: time.Now(),
+	}
+	for _, routed := range routedMessages(msg, route) {
+		if err := s.queue.Publish(ctx, stampNotification(routed)); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to queue routed notification",
+				slog.String("route", route.Name),
+				slog.String("transaction_id", event.TransactionID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingSlackService struct {
+	mu       sync.Mutex
+	channels []string
+}
+
+func (s *recordingSlackService) SendMessage(channel, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels = append(s.channels, channel)
+	return nil
+}
+
+func TestNotificationService_RoutesByHints(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	if _, err := NewNotificationRoutingTable([]NotificationRoute{{Name: "catch-all", Targets: []RouteTarget{{Channel: NotificationEmail, Recipient: "ops@example.com"}}}}); !errors.Is(err, ErrInvalidNotificationRoute) {
+		t.Errorf("expected route without hints to be rejected, got %v", err)
+	}
+	table, err := ParseNotificationRoutes(`[
+		{"name": "cards", "match": {"team": "cards"}, "targets": [{"channel": "email", "recipient": "cards@example.com"}, {"channel": "slack", "recipient": "#cards"}]},
+		{"name": "cards-de", "match": {"team": "cards", "region": "DE"}, "targets": [{"channel": "email", "recipient": "cards-de@example.com"}]},
+		{"name": "savings-holds", "events": ["transaction.suspicious"], "match": {"product": "savings"}, "targets": [{"channel": "email", "recipient": "risk@example.com"}]}
+	]`)
+	if err != nil {
+		t.Fatalf("ParseNotificationRoutes failed: %v", err)
+	}
+	if route, ok := table.Resolve("", map[string]string{"team": "cards", "region": "DE"}); !ok || route.Name != "cards-de" {
+		t.Errorf("expected most specific route, got %+v", route)
+	}
+	if route, ok := table.Resolve("", map[string]string{"team": "cards", "region": "US"}); !ok || route.Name != "cards" {
+		t.Errorf("expected team route, got %+v", route)
+	}
+	if _, ok := table.Resolve(domain.EventTransactionCompleted, map[string]string{"product": "savings"}); ok {
+		t.Error("expected event-scoped route not to match other events")
+	}
+
+	engine := processor.NewRuleEngine(memory.NewRuleRepository(), logger)
+	tx := &domain.Transaction{ID: "tx1", Amount: 50, Currency: "USD", Status: domain.StatusSuspicious}
+	action := processor.RuleAction{Type: processor.ActionRouteNotification, Params: map[string]interface{}{"team": "cards"}}
+	if err := engine.ExecuteAction(ctx, action, tx); err != nil {
+		t.Fatalf("ExecuteAction failed: %v", err)
+	}
+
+	email := &MockEmailService{}
+	slack := &recordingSlackService{}
+	svc := NewNotificationService(email, nil, nil, slack, NotificationScalingConfig{MinWorkers: 1, MaxWorkers: 1}, logger).
+		WithRouting(table)
+	defer svc.Shutdown(ctx)
+
+	if err := svc.Enqueue(ctx, NotificationMessage{Type: NotificationEmail, Recipient: "user@example.com", Message: "plain"}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if err := svc.Enqueue(ctx, NotificationMessage{Type: NotificationEmail, Recipient: "user@example.com", Message: "routed", Routing: processor.RoutingHints(tx)}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	hints := map[string]string{processor.RouteProduct: "savings"}
+	svc.Publish(ctx, domain.EventTransactionCompleted, domain.TransactionEventData{TransactionID: "tx2", Routing: hints})
+	svc.Publish(ctx, domain.EventTransactionSuspicious, domain.TransactionEventData{TransactionID: "tx3", Routing: hints})
+
+	deadline := time.Now().Add(time.Second)
+	for svc.Stats().QueueDepth > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	var recipients []string
+	for _, sent := range email.SentEmails {
+		recipients = append(recipients, sent.To)
+	}
+	slices.Sort(recipients)
+	if !slices.Equal(recipients, []string{"cards@example.com", "risk@example.com", "user@example.com"}) {
+		t.Errorf("unexpected email recipients %v", recipients)
+	}
+	slack.mu.Lock()
+	defer slack.mu.Unlock()
+	if !slices.Equal(slack.channels, []string{"#cards"}) {
+		t.Errorf("expected routed slack message to #cards, got %v", slack.channels)
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"log/slog"
+	"time"
+)
+
+const (
+	ScaleUp   = "up"
+	ScaleDown = "down"
+
+	ScaleReasonBacklog = "backlog"
+	ScaleReasonLatency = "latency"
+	ScaleReasonIdle    = "idle"
+)
+
+type NotificationScalingConfig struct {
+	MinWorkers       int
+	MaxWorkers       int
+	Interval         time.Duration
+	BacklogPerWorker int
+	LatencyTarget    time.Duration
+	ScaleDownAfter   int
+}
+
+type NotificationScalingObserver interface {
+	ObserveNotificationWorkers(workers, queueDepth int, latency time.Duration)
+	ObserveNotificationScaling(direction, reason string)
+}
+
+type NotificationStats struct {
+	Workers     int           `json:"workers"`
+	QueueDepth  int           `json:"queue_depth"`
+	SendLatency time.Duration `json:"send_latency"`
+}
+
+func DefaultNotificationScalingConfig() NotificationScalingConfig {
+	return NotificationScalingConfig{
+		MinWorkers:       2,
+		MaxWorkers:       16,
+		Interval:         5 * time.Second,
+		BacklogPerWorker: 50,
+		LatencyTarget:    2 * time.Second,
+		ScaleDownAfter:   3,
+	}
+}
+
+func (c NotificationScalingConfig) normalize() NotificationScalingConfig {
+	if c.MinWorkers <= 0 {
+		c.MinWorkers = 1
+	}
+	if c.MaxWorkers < c.MinWorkers {
+		c.MaxWorkers = c.MinWorkers
+	}
+	if c.Interval <= 0 {
+		c.Interval = DefaultNotificationScalingConfig().Interval
+	}
+	if c.BacklogPerWorker <= 0 {
+		c.BacklogPerWorker = DefaultNotificationScalingConfig().BacklogPerWorker
+	}
+	if c.ScaleDownAfter <= 0 {
+		c.ScaleDownAfter = 1
+	}
+	return c
+}
+
+type notificationScaler struct {
+	config    NotificationScalingConfig
+	idleTicks int
+}
+
+func (s *notificationScaler) decide(stats NotificationStats) (int, string) {
+	workers := stats.Workers
+
+	switch {
+	case stats.QueueDepth > workers*s.config.BacklogPerWorker:
+		s.idleTicks = 0
+		target := min(workers*2, workers+stats.QueueDepth/s.config.BacklogPerWorker, s.config.MaxWorkers)
+		return max(target, workers), ScaleReasonBacklog
+	case stats.QueueDepth > 0 && s.config.LatencyTarget > 0 && stats.SendLatency > s.config.LatencyTarget:
+		s.idleTicks = 0
+		return min(workers+1, s.config.MaxWorkers), ScaleReasonLatency
+	case stats.QueueDepth == 0:
+		s.idleTicks++
+		if s.idleTicks < s.config.ScaleDownAfter {
+			return workers, ""
+		}
+		s.idleTicks = 0
+		return max(workers-1, s.config.MinWorkers), ScaleReasonIdle
+	default:
+		s.idleTicks = 0
+		return workers, ""
+	}
+}
+
+func (s *NotificationService) runScaler() {
+	defer s.wg.Done()
+
+	scaler := &notificationScaler{config: s.scaling}
+	ticker := time.NewTicker(s.scaling.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			stats := s.Stats()
+			target, reason := scaler.decide(stats)
+			if target != stats.Workers {
+				s.scaleTo(target, reason)
+			}
+			s.observe()
+		case <-s.shutdownChan:
+			return
+		}
+	}
+}
+
+func (s *NotificationService) scaleTo(target int, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.shutdownChan:
+		return
+	default:
+	}
+
+	current := len(s.workerStops)
+	direction := ScaleUp
+	for len(s.workerStops) < target {
+		s.startWorkerLocked()
+	}
+	for len(s.workerStops) > target {
+		direction = ScaleDown
+		last := len(s.workerStops) - 1
+		close(s.workerStops[last])
+		s.workerStops = s.workerStops[:last]
+	}
+
+	if current == target {
+		return
+	}
+	if s.observer != nil {
+		s.observer.ObserveNotificationScaling(direction, reason)
+	}
+	s.logger.Info("Notification workers scaled",
+		slog.String("direction", direction),
+		slog.String("reason", reason),
+		slog.Int("from", current),
+		slog.Int("to", target))
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestNotificationScaler_Decide(t *testing.T) {
+	scaler := &notificationScaler{config: NotificationScalingConfig{
+		MinWorkers:       2,
+		MaxWorkers:       8,
+		BacklogPerWorker: 10,
+		LatencyTarget:    time.Second,
+		ScaleDownAfter:   2,
+	}.normalize()}
+
+	steps := []struct {
+		stats  NotificationStats
+		target int
+		reason string
+	}{
+		{NotificationStats{Workers: 2, QueueDepth: 100}, 4, ScaleReasonBacklog},
+		{NotificationStats{Workers: 6, QueueDepth: 500}, 8, ScaleReasonBacklog},
+		{NotificationStats{Workers: 4, QueueDepth: 5, SendLatency: 3 * time.Second}, 5, ScaleReasonLatency},
+		{NotificationStats{Workers: 4, QueueDepth: 5, SendLatency: 100 * time.Millisecond}, 4, ""},
+		{NotificationStats{Workers: 4}, 4, ""},
+		{NotificationStats{Workers: 4}, 3, ScaleReasonIdle},
+		{NotificationStats{Workers: 2}, 2, ""},
+		{NotificationStats{Workers: 2}, 2, ScaleReasonIdle},
+	}
+	for i, step := range steps {
+		target, reason := scaler.decide(step.stats)
+		if target != step.target || reason != step.reason {
+			t.Errorf("step %d: expected %d/%q, got %d/%q", i, step.target, step.reason, target, reason)
+		}
+	}
+}
+
+func TestNotificationService_ScalesWorkersWithBacklog(t *testing.T) {
+	email := &blockingEmailService{release: make(chan struct{})}
+	svc := NewNotificationService(email, nil, nil, nil, NotificationScalingConfig{
+		MinWorkers:       1,
+		MaxWorkers:       4,
+		Interval:         10 * time.Millisecond,
+		BacklogPerWorker: 2,
+		ScaleDownAfter:   1,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	for i := 0; i < 20; i++ {
+		_ = svc.Enqueue(context.Background(), NotificationMessage{Type: NotificationEmail, Recipient: "ops@example.com"})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for svc.Stats().Workers < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if workers := svc.Stats().Workers; workers != 4 {
+		t.Fatalf("expected scale up to 4 workers, got %d", workers)
+	}
+
+	close(email.release)
+	deadline = time.Now().Add(time.Second)
+	for svc.Stats().Workers > 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if workers := svc.Stats().Workers; workers != 1 {
+		t.Errorf("expected scale down to 1 worker once drained, got %d", workers)
+	}
+
+	if err := svc.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type blockingEmailService struct {
+	release chan struct{}
+}
+
+func (b *blockingEmailService) SendEmail(email Email) error {
+	<-b.release
+	return nil
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -16,6 +19,7 @@ const (
 	NotificationSMS   NotificationType = "sms"
 	NotificationPush  NotificationType = "push"
 	NotificationSlack NotificationType = "slack"
+	NotificationInbox NotificationType = "inbox"
 )
 
 type NotificationService struct {
@@ -23,25 +27,43 @@ type NotificationService struct {
 	smsService   SMSService
 	pushService  PushService
 	slackService SlackService
-	messageQueue chan NotificationMessage
-	workers      int
+	inbox        *InboxService
+	smsFormatter *SMSFormatter
+	templates    *TemplateRegistry
+	queue        NotificationQueue
+	scaling      NotificationScalingConfig
+	mu           sync.Mutex
+	workerStops  []chan struct{}
+	nextWorkerID int
+	sendLatency  time.Duration
+	observer     NotificationScalingObserver
+	preferences  repository.NotificationPreferenceRepository
+	failover     FailoverConfig
+	history      repository.DeliveryRepository
+	consents     *ConsentService
+	routing      *NotificationRoutingTable
 	shutdownChan chan struct{}
 	wg           sync.WaitGroup
 	logger       *slog.Logger
 }
 
 type NotificationMessage struct {
-	Type      NotificationType
-	Recipient string
-	Subject   string
-	Message   string
-	Priority  int
-	Metadata  map[string]string
-	CreatedAt time.Time
+	ID        string                `json:"id"`
+	UserID    string                `json:"user_id,omitempty"`
+	Type      NotificationType      `json:"type"`
+	Recipient string                `json:"recipient"`
+	Subject   string                `json:"subject,omitempty"`
+	Message   string                `json:"message"`
+	Priority  int                   `json:"priority"`
+	Metadata  map[string]string     `json:"metadata,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+	Email     *Email                `json:"email,omitempty"`
+	Purpose   domain.ConsentPurpose `json:"purpose,omitempty"`
+	Routing   map[string]string     `json:"routing,omitempty"`
 }
 
 type EmailService interface {
-	SendEmail(to, subject, body string) error
+	SendEmail(email Email) error
 }
 
 type SMSService interface {
@@ -61,7 +83,19 @@ func NewNotificationService(
 	smsService SMSService,
 	pushService PushService,
 	slackService SlackService,
-	workers int,
+	scaling NotificationScalingConfig,
+	logger *slog.Logger,
+) *NotificationService {
+	return NewNotificationServiceWithQueue(NewChannelQueue(DefaultNotificationQueueCapacity), emailService, smsService, pushService, slackService, scaling, logger)
+}
+
+func NewNotificationServiceWithQueue(
+	queue NotificationQueue,
+	emailService EmailService,
+	smsService SMSService,
+	pushService PushService,
+	slackService SlackService,
+	scaling NotificationScalingConfig,
 	logger *slog.Logger,
 ) *NotificationService {
 	if logger == nil {
@@ -73,8 +107,10 @@ func NewNotificationService(
 		smsService:   smsService,
 		pushService:  pushService,
 		slackService: slackService,
-		messageQueue: make(chan NotificationMessage, 1000),
-		workers:      workers,
+		queue:        queue,
+		smsFormatter: NewSMSFormatter(SMSConfig{}),
+		templates:    MustDefaultTemplateRegistry(),
+		scaling:      scaling.normalize(),
 		shutdownChan: make(chan struct{}),
 		logger:       logger,
 	}
@@ -84,34 +120,57 @@ func NewNotificationService(
 	return service
 }
 
+func (s *NotificationService) WithInbox(inbox *InboxService) *NotificationService {
+	s.inbox = inbox
+	return s
+}
+
+func (s *NotificationService) WithSMSFormatting(cfg SMSConfig) *NotificationService {
+	s.smsFormatter = NewSMSFormatter(cfg)
+	return s
+}
+
+func (s *NotificationService) WithTemplates(templates *TemplateRegistry) *NotificationService {
+	s.templates = templates
+	return s
+}
+
+func (s *NotificationService) Templates() *TemplateRegistry {
+	return s.templates
+}
+
 func (s *NotificationService) SendTransactionNotification(
 	ctx context.Context,
 	tx *domain.Transaction,
 	recipient string,
 	notificationType NotificationType,
 ) error {
-	var subject, message string
-
+	templateName := TemplateTransactionUpdate
 	switch tx.Status {
 	case domain.StatusCompleted:
-		subject = "Transaction Completed"
-		message = fmt.Sprintf("Your transaction of %.2f %s has been completed successfully.", tx.Amount, tx.Currency)
+		templateName = TemplateTransactionCompleted
 	case domain.StatusFailed:
-		subject = "Transaction Failed"
-		message = fmt.Sprintf("Your transaction of %.2f %s has failed. Reason: %s", tx.Amount, tx.Currency, tx.Metadata["failure_reason"])
+		templateName = TemplateTransactionFailed
 	case domain.StatusSuspicious:
-		subject = "Suspicious Transaction Detected"
-		message = fmt.Sprintf("A suspicious transaction of %.2f %s has been detected and is under review.", tx.Amount, tx.Currency)
-	default:
-		subject = "Transaction Update"
-		message = fmt.Sprintf("Your transaction of %.2f %s is now %s.", tx.Amount, tx.Currency, tx.Status)
+		templateName = TemplateTransactionSuspicious
+	}
+
+	rendered, err := s.templates.Render(templateName, map[string]any{
+		"TransactionID": tx.ID,
+		"Amount":        tx.Amount,
+		"Currency":      tx.Currency,
+		"Status":        string(tx.Status),
+		"FailureReason": tx.Metadata["failure_reason"],
+	})
+	if err != nil {
+		return err
 	}
 
 	notification := NotificationMessage{
 		Type:      notificationType,
 		Recipient: recipient,
-		Subject:   subject,
-		Message:   message,
+		Subject:   rendered.Subject,
+		Message:   rendered.Body,
 		Priority:  5,
 		Metadata: map[string]string{
 			"transaction_id":   tx.ID,
@@ -119,18 +178,20 @@ func (s *NotificationService) SendTransactionNotification(
 			"risk_score":       fmt.Sprintf("%d", tx.RiskScore),
 		},
 		CreatedAt: time.Now(),
+		Email:     rendered.email(),
+		Routing:   processor.RoutingHints(tx),
 	}
 
-	select {
-	case s.messageQueue <- notification:
+	for _, routed := range s.route(notification) {
+		if err := s.queue.Publish(ctx, stampNotification(routed)); err != nil {
+			return err
+		}
 		s.logger.Info("Notification queued",
-			slog.String("type", string(notificationType)),
-			slog.String("recipient", recipient),
+			slog.String("type", string(routed.Type)),
+			slog.String("recipient", routed.Recipient),
 			slog.String("transaction_id", tx.ID))
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
 	}
+	return nil
 }
 
 func (s *NotificationService) SendFraudAlert(
@@ -157,6 +218,7 @@ func (s *NotificationService) SendFraudAlert(
 				"risk_score":     fmt.Sprintf("%d", tx.RiskScore),
 			},
 			CreatedAt: time.Now(),
+			Routing:   processor.RoutingHints(tx),
 		},
 		{
 			Type:      NotificationEmail,
@@ -169,40 +231,107 @@ func (s *NotificationService) SendFraudAlert(
 				"severity":       severity,
 			},
 			CreatedAt: time.Now(),
+			Routing:   processor.RoutingHints(tx),
 		},
 	}
 
 	for _, notification := range notifications {
-		select {
-		case s.messageQueue <- notification:
+		for _, routed := range s.route(notification) {
+			if err := s.queue.Publish(ctx, stampNotification(routed)); err != nil {
+				return err
+			}
 			s.logger.Warn("Fraud alert notification queued",
-				slog.String("type", string(notification.Type)),
+				slog.String("type", string(routed.Type)),
+				slog.String("recipient", routed.Recipient),
 				slog.String("transaction_id", tx.ID),
 				slog.String("severity", severity))
-		case <-ctx.Done():
-			return ctx.Err()
 		}
 	}
 
 	return nil
 }
 
+func (s *NotificationService) Enqueue(ctx context.Context, msg NotificationMessage) error {
+	for _, routed := range s.route(msg) {
+		if err := s.queue.Publish(ctx, stampNotification(routed)); err != nil {
+			return err
+		}
+		s.logger.Info("Notification queued",
+			slog.String("type", string(routed.Type)),
+			slog.String("recipient", routed.Recipient))
+	}
+	return nil
+}
+
+func stampNotification(msg NotificationMessage) NotificationMessage {
+	if msg.ID == "" {
+		msg.ID = domain.NewID()
+	}
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now()
+	}
+	return msg
+}
+
+func (m NotificationMessage) email() Email {
+	email := Email{To: m.Recipient, Subject: m.Subject, TextBody: m.Message}
+	if m.Email != nil {
+		email = *m.Email
+		if email.To == "" {
+			email.To = m.Recipient
+		}
+		if email.Subject == "" {
+			email.Subject = m.Subject
+		}
+		if email.TextBody == "" {
+			email.TextBody = m.Message
+		}
+	}
+	return email
+}
+
 func (s *NotificationService) startWorkers() {
-	for i := 0; i < s.workers; i++ {
+	s.mu.Lock()
+	for i := 0; i < s.scaling.MinWorkers; i++ {
+		s.startWorkerLocked()
+	}
+	s.mu.Unlock()
+
+	if s.scaling.MaxWorkers > s.scaling.MinWorkers {
 		s.wg.Add(1)
-		go s.worker(i)
+		go s.runScaler()
 	}
 }
 
-func (s *NotificationService) worker(id int) {
+func (s *NotificationService) startWorkerLocked() {
+	stop := make(chan struct{})
+	s.workerStops = append(s.workerStops, stop)
+	s.wg.Add(1)
+	go s.worker(s.nextWorkerID, stop)
+	s.nextWorkerID++
+}
+
+func (s *NotificationService) worker(id int, stop <-chan struct{}) {
 	defer s.wg.Done()
 
 	s.logger.Info("Notification worker started", slog.Int("worker_id", id))
 
 	for {
 		select {
-		case msg := <-s.messageQueue:
-			s.processNotification(msg, id)
+		case delivery, ok := <-s.queue.Deliveries():
+			if !ok {
+				s.logger.Info("Notification queue closed", slog.Int("worker_id", id))
+				return
+			}
+			s.processNotification(delivery.Message, id)
+			if err := delivery.ack(); err != nil {
+				s.logger.Error("Failed to acknowledge notification",
+					slog.String("message_id", delivery.Message.ID),
+					slog.String("error", err.Error()))
+			}
+		case <-stop:
+			s.logger.Info("Notification worker scaled down", slog.Int("worker_id", id))
+			return
 		case <-s.shutdownChan:
 			s.logger.Info("Notification worker stopping", slog.Int("worker_id", id))
 			return
@@ -210,24 +339,72 @@ func (s *NotificationService) worker(id int) {
 	}
 }
 
+func (s *NotificationService) SetObserver(observer NotificationScalingObserver) {
+	s.mu.Lock()
+	s.observer = observer
+	s.mu.Unlock()
+	s.observe()
+}
+
+func (s *NotificationService) Stats() NotificationStats {
+	depth := s.queue.Depth()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return NotificationStats{
+		Workers:     len(s.workerStops),
+		QueueDepth:  depth,
+		SendLatency: s.sendLatency,
+	}
+}
+
+func (s *NotificationService) observe() {
+	stats := s.Stats()
+	s.mu.Lock()
+	observer := s.observer
+	s.mu.Unlock()
+	if observer != nil {
+		observer.ObserveNotificationWorkers(stats.Workers, stats.QueueDepth, stats.SendLatency)
+	}
+}
+
+func (s *NotificationService) recordLatency(duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendLatency == 0 {
+		s.sendLatency = duration
+		return
+	}
+	s.sendLatency += (duration - s.sendLatency) / 5
+}
+
 func (s *NotificationService) processNotification(msg NotificationMessage, workerID int) {
-	startTime := time.Now()
-	var err error
+	if msg.ID == "" {
+		msg.ID = domain.NewID()
+	}
 
-	switch msg.Type {
-	case NotificationEmail:
-		err = s.emailService.SendEmail(msg.Recipient, msg.Subject, msg.Message)
-	case NotificationSMS:
-		err = s.smsService.SendSMS(msg.Recipient, msg.Message)
-	case NotificationPush:
-		err = s.pushService.SendPush(msg.Recipient, msg.Subject, msg.Message)
-	case NotificationSlack:
-		err = s.slackService.SendMessage(msg.Recipient, msg.Message)
-	default:
-		err = fmt.Errorf("unknown notification type: %s", msg.Type)
+	err := s.deliver(msg, workerID, "")
+	if err != nil && s.shouldFailover(msg, err) {
+		s.failoverDelivery(msg, workerID)
+	}
+}
+
+func (s *NotificationService) deliver(msg NotificationMessage, workerID int, failoverFrom NotificationType) error {
+	if err := s.checkConsent(msg); err != nil {
+		s.recordDelivery(msg, failoverFrom, err)
+		s.logger.Info("Notification suppressed",
+			slog.String("type", string(msg.Type)),
+			slog.String("user_id", msg.UserID),
+			slog.String("reason", err.Error()),
+			slog.Int("worker_id", workerID))
+		return err
 	}
 
+	startTime := time.Now()
+	err := s.send(msg)
 	duration := time.Since(startTime)
+	s.recordLatency(duration)
+	s.recordDelivery(msg, failoverFrom, err)
 
 	if err != nil {
 		s.logger.Error("Failed to send notification",
@@ -243,10 +420,92 @@ func (s *NotificationService) processNotification(msg NotificationMessage, worke
 			slog.Int("worker_id", workerID),
 			slog.Duration("duration", duration))
 	}
+	return err
+}
+
+func (s *NotificationService) send(msg NotificationMessage) error {
+	switch msg.Type {
+	case NotificationEmail:
+		if s.emailService == nil {
+			return fmt.Errorf("%w: email channel is not configured", ErrPermanentDelivery)
+		}
+		return s.emailService.SendEmail(msg.email())
+	case NotificationSMS:
+		if s.smsService == nil {
+			return fmt.Errorf("%w: sms channel is not configured", ErrPermanentDelivery)
+		}
+		return s.sendSMS(msg)
+	case NotificationPush:
+		if s.pushService == nil {
+			return fmt.Errorf("%w: push channel is not configured", ErrPermanentDelivery)
+		}
+		return s.pushService.SendPush(msg.Recipient, msg.Subject, msg.Message)
+	case NotificationSlack:
+		if s.slackService == nil {
+			return fmt.Errorf("%w: slack channel is not configured", ErrPermanentDelivery)
+		}
+		return s.slackService.SendMessage(msg.Recipient, msg.Message)
+	case NotificationInbox:
+		if s.inbox == nil {
+			return fmt.Errorf("%w: inbox channel is not configured", ErrPermanentDelivery)
+		}
+		return s.inbox.Deliver(context.Background(), msg)
+	default:
+		return fmt.Errorf("%w: unknown notification type: %s", ErrPermanentDelivery, msg.Type)
+	}
+}
+
+func (s *NotificationService) SendTest(ctx context.Context, template NotificationTemplate, data any, channel NotificationType, recipient string) (NotificationMessage, error) {
+	if recipient == "" {
+		return NotificationMessage{}, fmt.Errorf("%w: recipient is required", ErrInvalidTemplate)
+	}
+
+	rendered, err := RenderTemplate(template, data)
+	if err != nil {
+		return NotificationMessage{}, err
+	}
+
+	msg := stampNotification(NotificationMessage{
+		Type:      channel,
+		Recipient: recipient,
+		Subject:   rendered.Subject,
+		Message:   rendered.Body,
+		Priority:  1,
+		Metadata:  map[string]string{"template": template.Name, "test_send": "true"},
+		Email:     rendered.email(),
+	})
+	if err := s.queue.Publish(ctx, msg); err != nil {
+		return NotificationMessage{}, err
+	}
+
+	s.logger.Info("Test notification queued",
+		slog.String("template", template.Name),
+		slog.String("type", string(channel)),
+		slog.String("recipient", recipient))
+	return msg, nil
+}
+
+func (s *NotificationService) sendSMS(msg NotificationMessage) error {
+	formatted, err := s.smsFormatter.Format(msg.Message)
+	if errors.Is(err, ErrSMSTooLong) {
+		return fmt.Errorf("%w: %w", ErrPermanentDelivery, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, segment := range formatted.Segments {
+		if err := s.smsService.SendSMS(msg.Recipient, segment); err != nil {
+			return fmt.Errorf("segment %d/%d: %w", i+1, len(formatted.Segments), err)
+		}
+	}
+	return nil
 }
 
 func (s *NotificationService) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
 	close(s.shutdownChan)
+	s.mu.Unlock()
 
 	done := make(chan struct{})
 	go func() {
@@ -256,6 +515,9 @@ func (s *NotificationService) Shutdown(ctx context.Context) error {
 
 	select {
 	case <-done:
+		if err := s.queue.Close(); err != nil {
+			return err
+		}
 		s.logger.Info("Notification service shutdown complete")
 		return nil
 	case <-ctx.Done():
@@ -264,19 +526,11 @@ func (s *NotificationService) Shutdown(ctx context.Context) error {
 }
 
 type MockEmailService struct {
-	SentEmails []struct {
-		To      string
-		Subject string
-		Body    string
-	}
+	SentEmails []Email
 }
 
-func (m *MockEmailService) SendEmail(to, subject, body string) error {
-	m.SentEmails = append(m.SentEmails, struct {
-		To      string
-		Subject string
-		Body    string
-	}{to, subject, body})
+func (m *MockEmailService) SendEmail(email Email) error {
+	m.SentEmails = append(m.SentEmails, email)
 	return nil
 }
 
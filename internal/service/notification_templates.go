@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"sync"
+	"text/template"
+)
+
+const (
+	TemplateTransactionCompleted  = "transaction_completed"
+	TemplateTransactionFailed     = "transaction_failed"
+	TemplateTransactionSuspicious = "transaction_suspicious"
+	TemplateTransactionUpdate     = "transaction_update"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("notification template not found")
+	ErrInvalidTemplate  = errors.New("invalid notification template")
+)
+
+type NotificationTemplate struct {
+	Name     string `json:"name"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	HTMLBody string `json:"html_body,omitempty"`
+}
+
+type RenderedTemplate struct {
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	HTMLBody string `json:"html_body,omitempty"`
+}
+
+func DefaultNotificationTemplates() []NotificationTemplate {
+	return []NotificationTemplate{
+		{
+			Name:    TemplateTransactionCompleted,
+			Subject: "Transaction Completed",
+			Body:    `Your transaction of {{printf "%.2f" .Amount}} {{.Currency}} has been completed successfully.`,
+		},
+		{
+			Name:    TemplateTransactionFailed,
+			Subject: "Transaction Failed",
+			Body:    `Your transaction of {{printf "%.2f" .Amount}} {{.Currency}} has failed. Reason: {{.FailureReason}}`,
+		},
+		{
+			Name:    TemplateTransactionSuspicious,
+			Subject: "Suspicious Transaction Detected",
+			Body:    `A suspicious transaction of {{printf "%.2f" .Amount}} {{.Currency}} has been detected and is under review.`,
+		},
+		{
+			Name:    TemplateTransactionUpdate,
+			Subject: "Transaction Update",
+			Body:    `Your transaction of {{printf "%.2f" .Amount}} {{.Currency}} is now {{.Status}}.`,
+		},
+	}
+}
+
+type compiledTemplate struct {
+	source  NotificationTemplate
+	subject *template.Template
+	body    *template.Template
+	html    *htmltemplate.Template
+}
+
+func compileTemplate(t NotificationTemplate) (*compiledTemplate, error) {
+	if t.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+	if t.Body == "" && t.HTMLBody == "" {
+		return nil, fmt.Errorf("%w: %s has no body", ErrInvalidTemplate, t.Name)
+	}
+
+	compiled := &compiledTemplate{source: t}
+	var err error
+	if compiled.subject, err = template.New("subject").Option("missingkey=error").Parse(t.Subject); err != nil {
+		return nil, fmt.Errorf("%w: %s subject: %v", ErrInvalidTemplate, t.Name, err)
+	}
+	if compiled.body, err = template.New("body").Option("missingkey=error").Parse(t.Body); err != nil {
+		return nil, fmt.Errorf("%w: %s body: %v", ErrInvalidTemplate, t.Name, err)
+	}
+	if t.HTMLBody != "" {
+		if compiled.html, err = htmltemplate.New("html").Option("missingkey=error").Parse(t.HTMLBody); err != nil {
+			return nil, fmt.Errorf("%w: %s html body: %v", ErrInvalidTemplate, t.Name, err)
+		}
+	}
+	return compiled, nil
+}
+
+func (c *compiledTemplate) render(data any) (RenderedTemplate, error) {
+	var rendered RenderedTemplate
+	var buf bytes.Buffer
+
+	if err := c.subject.Execute(&buf, data); err != nil {
+		return rendered, fmt.Errorf("%w: %s subject: %v", ErrInvalidTemplate, c.source.Name, err)
+	}
+	rendered.Subject = buf.String()
+
+	buf.Reset()
+	if err := c.body.Execute(&buf, data); err != nil {
+		return rendered, fmt.Errorf("%w: %s body: %v", ErrInvalidTemplate, c.source.Name, err)
+	}
+	rendered.Body = buf.String()
+
+	if c.html != nil {
+		buf.Reset()
+		if err := c.html.Execute(&buf, data); err != nil {
+			return rendered, fmt.Errorf("%w: %s html body: %v", ErrInvalidTemplate, c.source.Name, err)
+		}
+		rendered.HTMLBody = buf.String()
+	}
+	return rendered, nil
+}
+
+func (r RenderedTemplate) email() *Email {
+	if r.HTMLBody == "" {
+		return nil
+	}
+	return &Email{Subject: r.Subject, TextBody: r.Body, HTMLBody: r.HTMLBody}
+}
+
+func RenderTemplate(t NotificationTemplate, data any) (RenderedTemplate, error) {
+	compiled, err := compileTemplate(t)
+	if err != nil {
+		return RenderedTemplate{}, err
+	}
+	return compiled.render(data)
+}
+
+type TemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]*compiledTemplate
+}
+
+func NewTemplateRegistry() *TemplateRegistry {
+	return &TemplateRegistry{templates: make(map[string]*compiledTemplate)}
+}
+
+func (r *TemplateRegistry) Register(t NotificationTemplate) error {
+	compiled, err := compileTemplate(t)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[t.Name] = compiled
+	return nil
+}
+
+func (r *TemplateRegistry) Get(name string) (NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	compiled, exists := r.templates[name]
+	if !exists {
+		return NotificationTemplate{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return compiled.source, nil
+}
+
+func (r *TemplateRegistry) List() []NotificationTemplate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]NotificationTemplate, 0, len(r.templates))
+	for _, compiled := range r.templates {
+		result = append(result, compiled.source)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func (r *TemplateRegistry) Render(name string, data any) (RenderedTemplate, error) {
+	r.mu.RLock()
+	compiled, exists := r.templates[name]
+	r.mu.RUnlock()
+	if !exists {
+		return RenderedTemplate{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return compiled.render(data)
+}
+
+func DefaultTemplateRegistry() (*TemplateRegistry, error) {
+	registry := NewTemplateRegistry()
+	for _, t := range DefaultNotificationTemplates() {
+		if err := registry.Register(t); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+func MustDefaultTemplateRegistry() *TemplateRegistry {
+	registry, err := DefaultTemplateRegistry()
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"crypto/hmac"
+	"finance_manager/pkg/crypto"
+	"time"
+)
+
+type otpChallenge struct {
+	signer      *crypto.Signer
+	digits      int
+	ttl         time.Duration
+	maxAttempts int
+}
+
+func (c otpChallenge) issue(subjectID string) (otp, hash string, expiresAt time.Time, err error) {
+	otp, err = crypto.GenerateOTP(c.digits)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return otp, c.hash(subjectID, otp), time.Now().Add(c.ttl), nil
+}
+
+func (c otpChallenge) verify(subjectID, otp, hash string, expiresAt time.Time, attempts int) error {
+	if time.Now().After(expiresAt) || attempts >= c.maxAttempts {
+		return ErrOTPExpired
+	}
+	if !hmac.Equal([]byte(hash), []byte(c.hash(subjectID, otp))) {
+		return ErrInvalidOTP
+	}
+	return nil
+}
+
+func (c otpChallenge) hash(subjectID, otp string) string {
+	return c.signer.Sign([]byte(subjectID + ":" + otp))
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+const (
+	MetadataPartnerID = "partner_id"
+
+	SigningAlgorithmEd25519 = "ed25519"
+	minAPIKeyLength         = 16
+)
+
+var (
+	ErrInvalidPartner    = errors.New("invalid partner")
+	ErrPartnerSuspended  = errors.New("partner is suspended")
+	ErrPartnerNotAllowed = errors.New("transaction type not allowed for partner")
+)
+
+type PartnerService struct {
+	partnerRepo repository.PartnerRepository
+	accountRepo repository.AccountRepository
+	logger      *slog.Logger
+}
+
+func NewPartnerService(partnerRepo repository.PartnerRepository, accountRepo repository.AccountRepository, logger *slog.Logger) *PartnerService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PartnerService{
+		partnerRepo: partnerRepo,
+		accountRepo: accountRepo,
+		logger:      logger,
+	}
+}
+
+func (s *PartnerService) Create(ctx context.Context, partner *domain.Partner) (*domain.Partner, error) {
+	if partner.ID == "" {
+		partner.ID = domain.NewID()
+	}
+	if partner.Status == "" {
+		partner.Status = domain.PartnerActive
+	}
+	if len(partner.APIKeys) == 0 {
+		key, err := generateAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		partner.APIKeys = []string{key}
+	}
+	if err := s.validate(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	if err := s.partnerRepo.Save(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Partner onboarded",
+		slog.String("partner_id", partner.ID),
+		slog.String("settlement_account_id", partner.SettlementAccountID))
+	return partner, nil
+}
+
+func (s *PartnerService) Get(ctx context.Context, id string) (*domain.Partner, error) {
+	return s.partnerRepo.GetByID(ctx, id)
+}
+
+func (s *PartnerService) List(ctx context.Context) ([]*domain.Partner, error) {
+	return s.partnerRepo.GetAll(ctx)
+}
+
+func (s *PartnerService) Update(ctx context.Context, partner *domain.Partner) (*domain.Partner, error) {
+	existing, err := s.partnerRepo.GetByID(ctx, partner.ID)
+	if err != nil {
+		return nil, err
+	}
+	if partner.Status == "" {
+		partner.Status = existing.Status
+	}
+	if len(partner.APIKeys) == 0 {
+		partner.APIKeys = existing.APIKeys
+	}
+	if err := s.validate(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	partner.CreatedAt = existing.CreatedAt
+	if err := s.partnerRepo.Update(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Partner updated",
+		slog.String("partner_id", partner.ID),
+		slog.String("status", string(partner.Status)))
+	return partner, nil
+}
+
+func (s *PartnerService) Delete(ctx context.Context, id string) error {
+	if err := s.partnerRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Partner offboarded", slog.String("partner_id", id))
+	return nil
+}
+
+func (s *PartnerService) Authorize(ctx context.Context, apiKey string, txType domain.TransactionType) (*domain.Partner, error) {
+	partner, err := s.partnerRepo.GetByAPIKey(ctx, apiKey)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if partner.Status != domain.PartnerActive {
+		return nil, fmt.Errorf("%w: %s", ErrPartnerSuspended, partner.ID)
+	}
+	if !partner.Allows(txType) {
+		return nil, fmt.Errorf("%w: partner %s cannot submit %s transactions", ErrPartnerNotAllowed, partner.ID, txType)
+	}
+	return partner, nil
+}
+
+func (s *PartnerService) validate(ctx context.Context, partner *domain.Partner) error {
+	var problems []string
+
+	if partner.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	switch partner.Status {
+	case domain.PartnerActive, domain.PartnerSuspended:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown status %q", partner.Status))
+	}
+
+	seenKeys := make(map[string]bool, len(partner.APIKeys))
+	for _, key := range partner.APIKeys {
+		if len(key) < minAPIKeyLength {
+			problems = append(problems, fmt.Sprintf("api keys must be at least %d characters", minAPIKeyLength))
+		} else if seenKeys[key] {
+			problems = append(problems, "api keys must be unique")
+		}
+		seenKeys[key] = true
+	}
+
+	seenSigningKeys := make(map[string]bool, len(partner.SigningKeys))
+	for i := range partner.SigningKeys {
+		key := &partner.SigningKeys[i]
+		if key.Algorithm == "" {
+			key.Algorithm = SigningAlgorithmEd25519
+		}
+		if key.KeyID == "" || seenSigningKeys[key.KeyID] {
+			problems = append(problems, "signing keys need a unique key_id")
+		}
+		seenSigningKeys[key.KeyID] = true
+		if key.Algorithm != SigningAlgorithmEd25519 {
+			problems = append(problems, fmt.Sprintf("unsupported signing algorithm %q", key.Algorithm))
+			continue
+		}
+		if raw, err := base64.StdEncoding.DecodeString(key.PublicKey); err != nil || len(raw) != ed25519.PublicKeySize {
+			problems = append(problems, fmt.Sprintf("signing key %q is not a base64 ed25519 public key", key.KeyID))
+		}
+	}
+
+	for _, webhook := range partner.Webhooks {
+		endpoint, err := url.Parse(webhook.URL)
+		if err != nil || endpoint.Host == "" || (endpoint.Scheme != "https" && endpoint.Scheme != "http") {
+			problems = append(problems, fmt.Sprintf("webhook url %q must be an absolute http(s) url", webhook.URL))
+		}
+		for _, eventType := range webhook.Events {
+			if _, ok := domain.LookupEventSchema(eventType, ""); !ok {
+				problems = append(problems, fmt.Sprintf("unknown webhook event type %q", eventType))
+			}
+		}
+	}
+
+	for _, txType := range partner.AllowedTypes {
+		switch txType {
+		case domain.TypeDeposit, domain.TypeWithdrawal, domain.TypeTransfer:
+		default:
+			problems = append(problems, fmt.Sprintf("unknown transaction type %q", txType))
+		}
+	}
+
+	if partner.SettlementAccountID == "" {
+		problems = append(problems, "settlement_account_id is required")
+	} else if _, err := s.accountRepo.GetByID(ctx, partner.SettlementAccountID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		problems = append(problems, fmt.Sprintf("settlement account %s does not exist", partner.SettlementAccountID))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPartner, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func generateAPIKey() (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return "pk_" + hex.EncodeToString(raw), nil
+}
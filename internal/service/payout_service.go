@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	PayoutDispatchJobName = "payout_dispatch"
+	MetadataPayoutBatch   = "payout_batch"
+)
+
+var ErrInvalidPayoutBatch = errors.New("invalid payout batch")
+
+type PayoutConfig struct {
+	SlotInterval    time.Duration
+	MaxPerSlot      int
+	MaxItems        int
+	MaxDeferralDays int
+}
+
+func DefaultPayoutConfig() PayoutConfig {
+	return PayoutConfig{
+		SlotInterval:    15 * time.Minute,
+		MaxPerSlot:      50,
+		MaxItems:        5000,
+		MaxDeferralDays: 7,
+	}
+}
+
+type PayoutItemRequest struct {
+	ToAccountID string  `json:"to_account_id"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description,omitempty"`
+}
+
+type PayoutRequest struct {
+	FromAccountID string              `json:"from_account_id"`
+	Currency      string              `json:"currency"`
+	StartAt       time.Time           `json:"start_at,omitempty"`
+	Items         []PayoutItemRequest `json:"items"`
+}
+
+type PayoutBatchView struct {
+	*domain.PayoutBatch
+	Progress domain.PayoutBatchProgress `json:"progress"`
+}
+
+type PayoutService struct {
+	repo      repository.PayoutBatchRepository
+	txRepo    repository.TransactionRepository
+	processor *processor.TransactionProcessor
+	cfg       PayoutConfig
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewPayoutService(
+	repo repository.PayoutBatchRepository,
+	txRepo repository.TransactionRepository,
+	txProcessor *processor.TransactionProcessor,
+	cfg PayoutConfig,
+	logger *slog.Logger,
+) *PayoutService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.SlotInterval <= 0 {
+		cfg.SlotInterval = DefaultPayoutConfig().SlotInterval
+	}
+	if cfg.MaxPerSlot <= 0 {
+		cfg.MaxPerSlot = 1
+	}
+
+	return &PayoutService{
+		repo:      repo,
+		txRepo:    txRepo,
+		processor: txProcessor,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *PayoutService) Submit(ctx context.Context, req PayoutRequest) (*PayoutBatchView, error) {
+	if err := s.validate(req); err != nil {
+		return nil, err
+	}
+
+	start := req.StartAt
+	if now := s.now(); start.IsZero() || start.Before(now) {
+		start = now
+	}
+
+	batch := &domain.PayoutBatch{
+		ID:            domain.NewID(),
+		FromAccountID: req.FromAccountID,
+		Currency:      req.Currency,
+		Status:        domain.PayoutBatchScheduled,
+		Items:         make([]domain.PayoutItem, len(req.Items)),
+		CreatedAt:     s.now(),
+	}
+	for i, item := range req.Items {
+		batch.Items[i] = domain.PayoutItem{
+			Index:       i,
+			ToAccountID: item.ToAccountID,
+			Amount:      item.Amount,
+			Description: item.Description,
+			Status:      domain.PayoutItemScheduled,
+		}
+	}
+
+	if err := s.plan(ctx, batch, start); err != nil {
+		return nil, err
+	}
+	s.complete(batch)
+
+	err := repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, batch) },
+		func() { batch.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := batch.Progress()
+	s.logger.InfoContext(ctx, "Payout batch scheduled",
+		slog.String("batch_id", batch.ID),
+		slog.String("from_account", batch.FromAccountID),
+		slog.Int("items", progress.Total),
+		slog.Int("deferred", progress.Deferred))
+
+	return &PayoutBatchView{PayoutBatch: batch, Progress: progress}, nil
+}
+
+func (s *PayoutService) Get(ctx context.Context, id string) (*PayoutBatchView, error) {
+	batch, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &PayoutBatchView{PayoutBatch: batch, Progress: batch.Progress()}, nil
+}
+
+func (s *PayoutService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     PayoutDispatchJobName,
+		Schedule: scheduler.Every(s.cfg.SlotInterval),
+		Run:      s.ProcessDue,
+	})
+}
+
+func (s *PayoutService) ProcessDue(ctx context.Context) error {
+	batches, err := s.repo.GetByStatus(ctx, domain.PayoutBatchScheduled, domain.PayoutBatchRunning)
+	if err != nil {
+		return fmt.Errorf("failed to get payout batches: %w", err)
+	}
+
+	now := s.now()
+	capacity := s.cfg.MaxPerSlot
+	var errs []error
+	for _, batch := range batches {
+		if capacity == 0 || ctx.Err() != nil {
+			break
+		}
+
+		executed, err := s.dispatch(ctx, batch, now, capacity)
+		capacity -= executed
+		if err != nil {
+			errs = append(errs, fmt.Errorf("payout batch %s: %w", batch.ID, err))
+		}
+		s.complete(batch)
+		if err := s.repo.Update(ctx, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *PayoutService) dispatch(ctx context.Context, batch *domain.PayoutBatch, now time.Time, capacity int) (int, error) {
+	due := make([]*domain.PayoutItem, 0)
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		if item.Status == domain.PayoutItemScheduled && !item.ScheduledAt.After(now) {
+			due = append(due, item)
+		}
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].ScheduledAt.Before(due[j].ScheduledAt)
+	})
+
+	limit, err := s.processor.EffectiveDailyLimit(ctx, batch.FromAccountID, domain.TypeTransfer)
+	if err != nil {
+		return 0, err
+	}
+	batch.Status = domain.PayoutBatchRunning
+
+	executed := 0
+	for _, item := range due {
+		if executed >= capacity {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return executed, err
+		}
+
+		if limit > 0 {
+			used, err := s.txRepo.GetDailyVolume(ctx, batch.FromAccountID, now)
+			if err != nil {
+				return executed, err
+			}
+			if used+item.Amount > limit+1e-9 {
+				s.postpone(item, now)
+				continue
+			}
+		}
+
+		tx := domain.NewTransaction(domain.TypeTransfer, item.Amount, batch.Currency).
+			WithAccounts(batch.FromAccountID, item.ToAccountID).
+			WithDescription(item.Description)
+		tx.AddMetadata(MetadataPayoutBatch, batch.ID)
+
+		executed++
+		err := s.processor.RunQueued(ctx, processor.QueueScheduled, func(ctx context.Context) error {
+			return s.processor.ProcessTransaction(ctx, tx)
+		})
+		executedAt := s.now()
+		item.TransactionID = tx.ID
+		item.TransactionStatus = tx.Status
+		item.ExecutedAt = &executedAt
+		if err != nil {
+			item.Status = domain.PayoutItemFailed
+			item.Error = err.Error()
+			s.logger.WarnContext(ctx, "Payout failed",
+				slog.String("batch_id", batch.ID),
+				slog.Int("index", item.Index),
+				slog.String("error", err.Error()))
+			continue
+		}
+		item.Status = domain.PayoutItemExecuted
+	}
+	return executed, nil
+}
+
+func (s *PayoutService) plan(ctx context.Context, batch *domain.PayoutBatch, start time.Time) error {
+	limit, err := s.processor.EffectiveDailyLimit(ctx, batch.FromAccountID, domain.TypeTransfer)
+	if err != nil {
+		return err
+	}
+	reserved, err := s.reserved(ctx, batch.FromAccountID)
+	if err != nil {
+		return err
+	}
+	headroom := func(day time.Time) (float64, error) {
+		if limit <= 0 {
+			return math.Inf(1), nil
+		}
+		used, err := s.txRepo.GetDailyVolume(ctx, batch.FromAccountID, day)
+		if err != nil {
+			return 0, err
+		}
+		return limit - used - reserved[day], nil
+	}
+
+	day, windowStart, offset := startOfDay(start), start, 0
+	remaining, err := headroom(day)
+	if err != nil {
+		return err
+	}
+	var today []*domain.PayoutItem
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		if limit > 0 && item.Amount > limit {
+			item.Status = domain.PayoutItemFailed
+			item.Error = fmt.Sprintf("amount %.2f exceeds daily limit %.2f", item.Amount, limit)
+			continue
+		}
+
+		for offset <= s.cfg.MaxDeferralDays && (item.Amount > remaining+1e-9 || len(today) >= s.capacity(windowStart, day)) {
+			s.spread(today, windowStart, day)
+			today = nil
+			day = day.AddDate(0, 0, 1)
+			windowStart = day
+			offset++
+			if remaining, err = headroom(day); err != nil {
+				return err
+			}
+		}
+		if offset > s.cfg.MaxDeferralDays {
+			item.Status = domain.PayoutItemFailed
+			item.Error = fmt.Sprintf("cannot be scheduled within %d days", s.cfg.MaxDeferralDays)
+			continue
+		}
+
+		item.Deferrals = offset
+		remaining -= item.Amount
+		today = append(today, item)
+	}
+	s.spread(today, windowStart, day)
+	return nil
+}
+
+func (s *PayoutService) reserved(ctx context.Context, accountID string) (map[time.Time]float64, error) {
+	active, err := s.repo.GetByStatus(ctx, domain.PayoutBatchScheduled, domain.PayoutBatchRunning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payout batches: %w", err)
+	}
+	reserved := make(map[time.Time]float64)
+	for _, batch := range active {
+		if batch.FromAccountID != accountID {
+			continue
+		}
+		for _, item := range batch.Items {
+			if item.Status == domain.PayoutItemScheduled {
+				reserved[startOfDay(item.ScheduledAt)] += item.Amount
+			}
+		}
+	}
+	return reserved, nil
+}
+
+func (s *PayoutService) capacity(windowStart, day time.Time) int {
+	return s.slots(windowStart, day) * s.cfg.MaxPerSlot
+}
+
+func (s *PayoutService) slots(windowStart, day time.Time) int {
+	window := day.AddDate(0, 0, 1).Sub(windowStart)
+	return max(1, int((window+s.cfg.SlotInterval-1)/s.cfg.SlotInterval))
+}
+
+func (s *PayoutService) spread(items []*domain.PayoutItem, windowStart, day time.Time) {
+	slots := s.slots(windowStart, day)
+	for k, item := range items {
+		slot := k * slots / len(items)
+		item.ScheduledAt = windowStart.Add(time.Duration(slot) * s.cfg.SlotInterval)
+	}
+}
+
+func (s *PayoutService) postpone(item *domain.PayoutItem, now time.Time) {
+	item.Deferrals++
+	if item.Deferrals > s.cfg.MaxDeferralDays {
+		item.Status = domain.PayoutItemFailed
+		item.Error = fmt.Sprintf("daily limit headroom unavailable for %d days", s.cfg.MaxDeferralDays)
+		return
+	}
+	item.ScheduledAt = startOfDay(now).AddDate(0, 0, 1)
+}
+
+func (s *PayoutService) complete(batch *domain.PayoutBatch) {
+	for _, item := range batch.Items {
+		if item.Status == domain.PayoutItemScheduled {
+			return
+		}
+	}
+	completedAt := s.now()
+	batch.Status = domain.PayoutBatchCompleted
+	batch.CompletedAt = &completedAt
+}
+
+func (s *PayoutService) validate(req PayoutRequest) error {
+	if req.FromAccountID == "" {
+		return fmt.Errorf("%w: from_account_id is required", ErrInvalidPayoutBatch)
+	}
+	if req.Currency == "" {
+		return fmt.Errorf("%w: currency is required", ErrInvalidPayoutBatch)
+	}
+	if len(req.Items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", ErrInvalidPayoutBatch)
+	}
+	if s.cfg.MaxItems > 0 && len(req.Items) > s.cfg.MaxItems {
+		return fmt.Errorf("%w: batch exceeds maximum of %d items", ErrInvalidPayoutBatch, s.cfg.MaxItems)
+	}
+	for i, item := range req.Items {
+		if item.ToAccountID == "" || item.ToAccountID == req.FromAccountID {
+			return fmt.Errorf("%w: item %d needs a distinct to_account_id", ErrInvalidPayoutBatch, i)
+		}
+		if item.Amount <= 0 || math.IsInf(item.Amount, 0) || math.IsNaN(item.Amount) {
+			return fmt.Errorf("%w: item %d amount must be positive", ErrInvalidPayoutBatch, i)
+		}
+	}
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
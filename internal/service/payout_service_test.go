@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestPayoutService_SmoothsBatchWithinDailyLimit(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	txRepo := memory.NewTransactionRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "payer", UserID: "u1", Balance: 10000, DailyLimit: 1000, Status: domain.AccountActive, Currency: "USD"})
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		_ = accRepo.Save(ctx, &domain.Account{ID: id, UserID: "u-" + id, Status: domain.AccountActive, Currency: "USD"})
+	}
+	proc := processor.NewTransactionProcessor(txRepo, accRepo, memory.NewRuleRepository(), 1)
+
+	today := startOfDay(time.Now())
+	clock := today.Add(time.Hour)
+	svc := NewPayoutService(memory.NewPayoutBatchRepository(), txRepo, proc, PayoutConfig{SlotInterval: time.Hour, MaxPerSlot: 1, MaxDeferralDays: 3}, logger)
+	svc.now = func() time.Time { return clock }
+
+	req := PayoutRequest{FromAccountID: "payer", Currency: "USD"}
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		req.Items = append(req.Items, PayoutItemRequest{ToAccountID: id, Amount: 310.25})
+	}
+	req.Items = append(req.Items, PayoutItemRequest{ToAccountID: "p1", Amount: 1500})
+
+	view, err := svc.Submit(ctx, req)
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if view.Progress.Deferred != 3 || view.Progress.Failed != 1 || view.Progress.Scheduled != 6 {
+		t.Fatalf("expected 3 items today, 3 deferred and the oversized item failed, got %+v", view.Progress)
+	}
+	if first, second := view.Items[0].ScheduledAt, view.Items[1].ScheduledAt; !first.Before(second) || second.After(today.AddDate(0, 0, 1)) {
+		t.Errorf("expected today's items spread across slots, got %v and %v", first, second)
+	}
+	if !view.Items[3].ScheduledAt.Equal(today.AddDate(0, 0, 1)) {
+		t.Errorf("expected overflow to roll into tomorrow, got %v", view.Items[3].ScheduledAt)
+	}
+
+	clock = today.Add(23 * time.Hour)
+	for run := 0; run < 4; run++ {
+		if err := svc.ProcessDue(ctx); err != nil {
+			t.Fatalf("dispatch run %d failed: %v", run, err)
+		}
+	}
+
+	view, _ = svc.Get(ctx, view.ID)
+	if view.Status != domain.PayoutBatchRunning || view.Progress.Executed != 3 || view.Progress.Scheduled != 3 {
+		t.Fatalf("expected three payouts executed at one per slot, got status %s %+v", view.Status, view.Progress)
+	}
+	if view.Progress.NextScheduledAt == nil || !view.Progress.NextScheduledAt.Equal(today.AddDate(0, 0, 1)) {
+		t.Errorf("expected remaining payouts to resume tomorrow, got %v", view.Progress.NextScheduledAt)
+	}
+
+	next, err := svc.Submit(ctx, PayoutRequest{FromAccountID: "payer", Currency: "USD", Items: []PayoutItemRequest{{ToAccountID: "p2", Amount: 200}}})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if item := next.Items[0]; item.Deferrals != 2 {
+		t.Errorf("expected headroom reserved by the first batch to push the payout two days out, got %+v", item)
+	}
+}
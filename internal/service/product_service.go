@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+var (
+	ErrInvalidProduct = errors.New("invalid product")
+	ErrProductRetired = errors.New("product is retired")
+)
+
+type ProductService struct {
+	productRepo repository.ProductRepository
+	accountRepo repository.AccountRepository
+	logger      *slog.Logger
+}
+
+func NewProductService(productRepo repository.ProductRepository, accountRepo repository.AccountRepository, logger *slog.Logger) *ProductService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ProductService{
+		productRepo: productRepo,
+		accountRepo: accountRepo,
+		logger:      logger,
+	}
+}
+
+func (s *ProductService) Create(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+
+	product.Status = domain.ProductActive
+	if err := s.productRepo.Save(ctx, product); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Product created", slog.String("product_id", product.ID))
+	return product, nil
+}
+
+func (s *ProductService) Get(ctx context.Context, id string) (*domain.Product, error) {
+	return s.productRepo.GetByID(ctx, id)
+}
+
+func (s *ProductService) List(ctx context.Context) ([]*domain.Product, error) {
+	return s.productRepo.GetAll(ctx)
+}
+
+func (s *ProductService) Update(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	existing, err := s.productRepo.GetByID(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status == domain.ProductRetired {
+		return nil, fmt.Errorf("%w: %s", ErrProductRetired, existing.ID)
+	}
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+
+	product.Status = existing.Status
+	product.CreatedAt = existing.CreatedAt
+	if err := s.productRepo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Product updated", slog.String("product_id", product.ID))
+	return product, nil
+}
+
+func (s *ProductService) Retire(ctx context.Context, id string) (*domain.Product, error) {
+	product, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Status = domain.ProductRetired
+	if err := s.productRepo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Product retired", slog.String("product_id", product.ID))
+	return product, nil
+}
+
+func (s *ProductService) AssignToAccount(ctx context.Context, accountID, productID string) (*domain.Account, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.Status == domain.ProductRetired {
+		return nil, fmt.Errorf("%w: %s", ErrProductRetired, product.ID)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if product.Currency != "" && !strings.EqualFold(product.Currency, account.Currency) {
+		return nil, fmt.Errorf("%w: product %s is offered in %s, account is %s", ErrInvalidProduct, product.ID, product.Currency, account.Currency)
+	}
+
+	account.ProductID = product.ID
+	account.DailyLimit = 0
+	account.MonthlyLimit = 0
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Product assigned to account",
+		slog.String("account_id", account.ID),
+		slog.String("product_id", product.ID))
+
+	return account, nil
+}
+
+func validateProduct(product *domain.Product) error {
+	var problems []string
+
+	if product.ID == "" {
+		problems = append(problems, "id is required")
+	}
+	if product.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if product.InterestRate < 0 {
+		problems = append(problems, "interest_rate cannot be negative")
+	}
+	if product.DailyLimit < 0 || product.MonthlyLimit < 0 || product.OverdraftLimit < 0 {
+		problems = append(problems, "limits cannot be negative")
+	}
+	fees := []struct {
+		name string
+		fee  domain.Fee
+	}{
+		{"deposit", product.Fees.Deposit},
+		{"withdrawal", product.Fees.Withdrawal},
+		{"transfer", product.Fees.Transfer},
+	}
+	for _, f := range fees {
+		if f.fee.Fixed < 0 || f.fee.Percent < 0 || f.fee.Percent > 100 {
+			problems = append(problems, fmt.Sprintf("%s fee must have non-negative fixed amount and percent between 0 and 100", f.name))
+		}
+	}
+	for _, txType := range product.AllowedTypes {
+		switch txType {
+		case domain.TypeDeposit, domain.TypeWithdrawal, domain.TypeTransfer:
+		default:
+			problems = append(problems, fmt.Sprintf("unknown transaction type %q", txType))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidProduct, strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidQuota  = errors.New("invalid api key quota")
+	ErrQuotaExceeded = errors.New("api key quota exceeded")
+)
+
+type QuotaCheck struct {
+	APIKey   string
+	Period   string
+	Volume   float64
+	Limited  bool
+	Exceeded bool
+	Usage    *domain.APIKeyUsage
+	Quota    *domain.APIKeyQuota
+}
+
+func (c *QuotaCheck) RemainingRequests() int64 {
+	if c.Quota == nil || c.Quota.MonthlyRequests == 0 {
+		return -1
+	}
+	return max(c.Quota.MonthlyRequests-c.Usage.Requests, 0)
+}
+
+type UsageReport struct {
+	APIKey            string              `json:"api_key"`
+	Period            string              `json:"period"`
+	Usage             *domain.APIKeyUsage `json:"usage"`
+	Quota             *domain.APIKeyQuota `json:"quota,omitempty"`
+	RemainingRequests *int64              `json:"remaining_requests,omitempty"`
+	RemainingVolume   *float64            `json:"remaining_volume,omitempty"`
+	RequestsExceeded  bool                `json:"requests_exceeded"`
+	VolumeExceeded    bool                `json:"volume_exceeded"`
+}
+
+type QuotaService struct {
+	repo   repository.QuotaRepository
+	mu     sync.Mutex
+	now    func() time.Time
+	logger *slog.Logger
+}
+
+func NewQuotaService(repo repository.QuotaRepository, logger *slog.Logger) *QuotaService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &QuotaService{
+		repo:   repo,
+		now:    time.Now,
+		logger: logger,
+	}
+}
+
+func (s *QuotaService) SetQuota(ctx context.Context, quota *domain.APIKeyQuota) error {
+	if quota.APIKey == "" {
+		return fmt.Errorf("%w: api key is required", ErrInvalidQuota)
+	}
+	if quota.MonthlyRequests < 0 {
+		return fmt.Errorf("%w: monthly requests cannot be negative", ErrInvalidQuota)
+	}
+	if quota.MonthlyVolume < 0 || math.IsNaN(quota.MonthlyVolume) || math.IsInf(quota.MonthlyVolume, 0) {
+		return fmt.Errorf("%w: monthly volume must be a non-negative number", ErrInvalidQuota)
+	}
+	switch quota.Enforcement {
+	case "":
+		quota.Enforcement = domain.QuotaHard
+	case domain.QuotaSoft, domain.QuotaHard:
+	default:
+		return fmt.Errorf("%w: unknown enforcement %q", ErrInvalidQuota, quota.Enforcement)
+	}
+
+	if err := s.repo.SaveQuota(ctx, quota); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "API key quota updated",
+		slog.String("api_key", quota.APIKey),
+		slog.Int64("monthly_requests", quota.MonthlyRequests),
+		slog.Float64("monthly_volume", quota.MonthlyVolume),
+		slog.String("enforcement", string(quota.Enforcement)))
+	return nil
+}
+
+func (s *QuotaService) GetQuota(ctx context.Context, apiKey string) (*domain.APIKeyQuota, error) {
+	return s.repo.GetQuota(ctx, apiKey)
+}
+
+func (s *QuotaService) RecordRequest(ctx context.Context, apiKey string) (*QuotaCheck, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	check, err := s.load(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.Quota != nil && check.Quota.MonthlyRequests > 0 && check.Usage.Requests >= check.Quota.MonthlyRequests {
+		check.Limited = true
+		if check.Quota.Enforcement == domain.QuotaHard {
+			return check, fmt.Errorf("%w: %d of %d monthly requests used", ErrQuotaExceeded, check.Usage.Requests, check.Quota.MonthlyRequests)
+		}
+		check.Exceeded = true
+	}
+
+	check.Usage, err = s.repo.AddUsage(ctx, apiKey, check.Period, 1, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+func (s *QuotaService) ReserveVolume(ctx context.Context, apiKey string, amount float64) (*QuotaCheck, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	check, err := s.load(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	check.Volume = amount
+
+	if check.Quota != nil && check.Quota.MonthlyVolume > 0 && check.Usage.Volume+amount > check.Quota.MonthlyVolume {
+		check.Limited = true
+		if check.Quota.Enforcement == domain.QuotaHard {
+			return check, fmt.Errorf("%w: %.2f of %.2f monthly volume used", ErrQuotaExceeded, check.Usage.Volume, check.Quota.MonthlyVolume)
+		}
+		check.Exceeded = true
+	}
+
+	check.Usage, err = s.repo.AddUsage(ctx, apiKey, check.Period, 0, 1, amount)
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+func (s *QuotaService) ReleaseVolume(ctx context.Context, check *QuotaCheck) {
+	if check == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.repo.AddUsage(ctx, check.APIKey, check.Period, 0, -1, -check.Volume); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to release reserved quota volume",
+			slog.String("api_key", check.APIKey),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (s *QuotaService) Usage(ctx context.Context, apiKey, period string) (*UsageReport, error) {
+	if period == "" {
+		period = domain.UsagePeriod(s.now())
+	} else if _, err := time.Parse("2006-01", period); err != nil {
+		return nil, fmt.Errorf("%w: period must be formatted as YYYY-MM", ErrInvalidQuota)
+	}
+
+	usage, err := s.repo.GetUsage(ctx, apiKey, period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{APIKey: apiKey, Period: period, Usage: usage}
+
+	quota, err := s.repo.GetQuota(ctx, apiKey)
+	if errors.Is(err, repository.ErrNotFound) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report.Quota = quota
+	if quota.MonthlyRequests > 0 {
+		remaining := max(quota.MonthlyRequests-usage.Requests, 0)
+		report.RemainingRequests = &remaining
+		report.RequestsExceeded = usage.Requests > quota.MonthlyRequests
+	}
+	if quota.MonthlyVolume > 0 {
+		remaining := max(quota.MonthlyVolume-usage.Volume, 0)
+		report.RemainingVolume = &remaining
+		report.VolumeExceeded = usage.Volume > quota.MonthlyVolume
+	}
+	return report, nil
+}
+
+func (s *QuotaService) load(ctx context.Context, apiKey string) (*QuotaCheck, error) {
+	check := &QuotaCheck{APIKey: apiKey, Period: domain.UsagePeriod(s.now())}
+
+	quota, err := s.repo.GetQuota(ctx, apiKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	check.Quota = quota
+
+	check.Usage, err = s.repo.GetUsage(ctx, apiKey, check.Period)
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/money"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MatchedByReference  = "reference"
+	MatchedByAmountDate = "amount_date"
+)
+
+var (
+	ErrInvalidSettlementFile = errors.New("invalid settlement file")
+	ErrInvalidResolution     = errors.New("invalid reconciliation resolution")
+	ErrReconciliationState   = errors.New("reconciliation item is not in a valid state for this operation")
+)
+
+var settlementDateLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+type ReconciliationConfig struct {
+	DateTolerance   time.Duration
+	AmountTolerance float64
+	MaxEntries      int
+}
+
+func DefaultReconciliationConfig() ReconciliationConfig {
+	return ReconciliationConfig{
+		DateTolerance:   48 * time.Hour,
+		AmountTolerance: 0.005,
+		MaxEntries:      100000,
+	}
+}
+
+type BreakResolution struct {
+	Resolution    domain.ReconciliationResolution `json:"resolution"`
+	TransactionID string                          `json:"transaction_id,omitempty"`
+	Note          string                          `json:"note,omitempty"`
+}
+
+type settlementEntry struct {
+	line      int
+	reference string
+	amount    float64
+	currency  string
+	date      time.Time
+	span      time.Duration
+}
+
+type ReconciliationService struct {
+	transactions repository.TransactionRepository
+	repo         repository.ReconciliationRepository
+	cfg          ReconciliationConfig
+	rounding     *money.RoundingPolicies
+	snapshots    *EODSnapshotService
+	logger       *slog.Logger
+}
+
+func NewReconciliationService(
+	transactions repository.TransactionRepository,
+	repo repository.ReconciliationRepository,
+	cfg ReconciliationConfig,
+	logger *slog.Logger,
+) *ReconciliationService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ReconciliationService{
+		transactions: transactions,
+		repo:         repo,
+		cfg:          cfg,
+		rounding:     money.DefaultRoundingPolicies(),
+		logger:       logger,
+	}
+}
+
+func (s *ReconciliationService) WithRounding(rounding *money.RoundingPolicies) *ReconciliationService {
+	s.rounding = rounding
+	return s
+}
+
+func (s *ReconciliationService) WithSnapshots(snapshots *EODSnapshotService) *ReconciliationService {
+	s.snapshots = snapshots
+	return s
+}
+
+func (s *ReconciliationService) Import(ctx context.Context, source string, file io.Reader) (*domain.ReconciliationReport, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, fmt.Errorf("%w: source is required", ErrInvalidSettlementFile)
+	}
+	entries, err := s.parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ReconciliationReport{
+		ID:          domain.NewID(),
+		Source:      source,
+		PeriodStart: entries[0].date,
+		PeriodEnd:   entries[0].date.Add(entries[0].span),
+		CreatedAt:   time.Now(),
+	}
+	for _, entry := range entries[1:] {
+		report.PeriodStart = minTime(report.PeriodStart, entry.date)
+		report.PeriodEnd = maxTime(report.PeriodEnd, entry.date.Add(entry.span))
+	}
+	if s.snapshots != nil {
+		report.SnapshotID = s.snapshots.SnapshotIDFor(ctx, report.PeriodEnd)
+	}
+
+	if err := s.match(ctx, report, entries); err != nil {
+		return nil, err
+	}
+	report.Summarize()
+
+	err = repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, report) },
+		func() { report.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Settlement file reconciled",
+		slog.String("report_id", report.ID),
+		slog.String("source", source),
+		slog.Int("entries", report.Summary.Entries),
+		slog.Int("matched", report.Summary.Matched),
+		slog.Int("open_breaks", report.Summary.OpenBreaks))
+
+	return report, nil
+}
+
+func (s *ReconciliationService) Get(ctx context.Context, id string) (*domain.ReconciliationReport, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ReconciliationService) List(ctx context.Context) ([]*domain.ReconciliationReport, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *ReconciliationService) Resolve(ctx context.Context, reportID, itemID, operator string, resolution BreakResolution) (*domain.ReconciliationReport, error) {
+	if operator == "" {
+		return nil, fmt.Errorf("%w: operator is required", ErrInvalidResolution)
+	}
+	switch resolution.Resolution {
+	case domain.ResolutionAcceptInternal, domain.ResolutionAcceptExternal, domain.ResolutionWrittenOff, domain.ResolutionLinked:
+	default:
+		return nil, fmt.Errorf("%w: unknown resolution %q", ErrInvalidResolution, resolution.Resolution)
+	}
+
+	report, err := s.repo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	item := report.Item(itemID)
+	if item == nil {
+		return nil, fmt.Errorf("%w: reconciliation item %s", repository.ErrNotFound, itemID)
+	}
+	if !item.IsOpen() {
+		return nil, fmt.Errorf("%w: item %s is %s", ErrReconciliationState, item.ID, itemState(item))
+	}
+
+	now := time.Now()
+	if resolution.Resolution == domain.ResolutionLinked {
+		if err := s.link(ctx, report, item, resolution.TransactionID, operator, now); err != nil {
+			return nil, err
+		}
+	}
+	item.Resolution = resolution.Resolution
+	item.ResolvedBy = operator
+	item.ResolutionNote = resolution.Note
+	item.ResolvedAt = &now
+
+	report.Summarize()
+	if err := s.repo.Update(ctx, report); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Reconciliation break resolved",
+		slog.String("report_id", report.ID),
+		slog.String("item_id", item.ID),
+		slog.String("resolution", string(item.Resolution)),
+		slog.String("operator", operator))
+
+	return report, nil
+}
+
+func (s *ReconciliationService) link(ctx context.Context, report *domain.ReconciliationReport, item *domain.ReconciliationItem, transactionID, operator string, now time.Time) error {
+	if item.Status != domain.ReconciliationMissingInternal {
+		return fmt.Errorf("%w: only missing_internal items can be linked", ErrInvalidResolution)
+	}
+	if transactionID == "" {
+		return fmt.Errorf("%w: transaction_id is required to link", ErrInvalidResolution)
+	}
+	tx, err := s.transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	for i := range report.Items {
+		other := &report.Items[i]
+		if other.TransactionID != tx.ID {
+			continue
+		}
+		if other.Status != domain.ReconciliationMissingExternal || !other.IsOpen() {
+			return fmt.Errorf("%w: transaction %s is already reconciled", ErrReconciliationState, tx.ID)
+		}
+		other.Resolution = domain.ResolutionLinked
+		other.ResolvedBy = operator
+		other.ResolutionNote = "linked to settlement line " + strconv.Itoa(item.Line)
+		other.ResolvedAt = &now
+	}
+
+	setInternal(item, tx)
+	return nil
+}
+
+func (s *ReconciliationService) parse(file io.Reader) ([]settlementEntry, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidSettlementFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSettlementFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"amount", "currency", "date"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing %q column", ErrInvalidSettlementFile, required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var entries []settlementEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSettlementFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(entries) >= s.cfg.MaxEntries {
+			return nil, fmt.Errorf("%w: more than %d entries", ErrInvalidSettlementFile, s.cfg.MaxEntries)
+		}
+
+		entry := settlementEntry{
+			line:      line,
+			reference: field(record, "reference"),
+			currency:  strings.ToUpper(field(record, "currency")),
+		}
+		if normalized, ok := domain.NormalizeReference(entry.reference); ok {
+			entry.reference = normalized
+		}
+		entry.amount, err = strconv.ParseFloat(strings.ReplaceAll(field(record, "amount"), ",", ""), 64)
+		if err != nil || math.IsNaN(entry.amount) || math.IsInf(entry.amount, 0) {
+			return nil, fmt.Errorf("%w: line %d: invalid amount %q", ErrInvalidSettlementFile, line, field(record, "amount"))
+		}
+		if entry.currency == "" {
+			return nil, fmt.Errorf("%w: line %d: currency is required", ErrInvalidSettlementFile, line)
+		}
+		if entry.date, entry.span, err = parseSettlementDate(field(record, "date")); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidSettlementFile, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no entries", ErrInvalidSettlementFile)
+	}
+	return entries, nil
+}
+
+func (s *ReconciliationService) match(ctx context.Context, report *domain.ReconciliationReport, entries []settlementEntry) error {
+	filter := repository.TransactionFilter{
+		Status: domain.StatusCompleted,
+		From:   report.PeriodStart.Add(-s.cfg.DateTolerance),
+		To:     report.PeriodEnd.Add(s.cfg.DateTolerance),
+	}
+	var candidates []*domain.Transaction
+	byReference := make(map[string]*domain.Transaction)
+	byAmount := make(map[string][]*domain.Transaction)
+	err := s.transactions.Iterate(ctx, filter, func(tx *domain.Transaction) error {
+		candidates = append(candidates, tx)
+		if tx.Reference != "" {
+			byReference[tx.Reference] = tx
+		}
+		key := s.amountKey(tx.Amount, tx.Currency)
+		byAmount[key] = append(byAmount[key], tx)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	claimed := make(map[string]int)
+	for _, entry := range entries {
+		item := domain.ReconciliationItem{
+			ID:               domain.NewID(),
+			Status:           domain.ReconciliationMissingInternal,
+			Line:             entry.line,
+			Reference:        entry.reference,
+			ExternalAmount:   entry.amount,
+			ExternalCurrency: entry.currency,
+			ExternalDate:     &entry.date,
+		}
+
+		var tx *domain.Transaction
+		if entry.reference != "" {
+			tx = byReference[entry.reference]
+			if tx == nil {
+				found, err := s.transactions.GetByReference(ctx, entry.reference)
+				if err != nil && !errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("failed to look up reference %s: %w", entry.reference, err)
+				}
+				tx = found
+			}
+			item.MatchedBy = MatchedByReference
+		}
+		if tx == nil {
+			tx = s.closest(entry, byAmount[s.amountKey(entry.amount, entry.currency)], claimed)
+			item.MatchedBy = MatchedByAmountDate
+		}
+
+		if tx != nil {
+			setInternal(&item, tx)
+			item.Status = domain.ReconciliationMatched
+			item.Differences = s.differences(entry, tx)
+			if line, dup := claimed[tx.ID]; dup {
+				item.Differences = append(item.Differences, fmt.Sprintf("transaction already matched on line %d", line))
+			}
+			if len(item.Differences) > 0 {
+				item.Status = domain.ReconciliationMismatched
+			}
+			claimed[tx.ID] = entry.line
+		} else {
+			item.MatchedBy = ""
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	for _, tx := range candidates {
+		if _, ok := claimed[tx.ID]; ok || tx.CreatedAt.Before(report.PeriodStart) || !tx.CreatedAt.Before(report.PeriodEnd) {
+			continue
+		}
+		item := domain.ReconciliationItem{
+			ID:        domain.NewID(),
+			Status:    domain.ReconciliationMissingExternal,
+			Reference: tx.Reference,
+		}
+		setInternal(&item, tx)
+		report.Items = append(report.Items, item)
+	}
+	return nil
+}
+
+func (s *ReconciliationService) closest(entry settlementEntry, candidates []*domain.Transaction, claimed map[string]int) *domain.Transaction {
+	var best *domain.Transaction
+	var bestDistance time.Duration
+	for _, tx := range candidates {
+		if _, ok := claimed[tx.ID]; ok || !s.withinDate(entry, tx.CreatedAt) {
+			continue
+		}
+		distance := tx.CreatedAt.Sub(entry.date).Abs()
+		if best == nil || distance < bestDistance {
+			best, bestDistance = tx, distance
+		}
+	}
+	return best
+}
+
+func (s *ReconciliationService) differences(entry settlementEntry, tx *domain.Transaction) []string {
+	var diffs []string
+	if math.Abs(entry.amount-tx.Amount) > s.cfg.AmountTolerance {
+		diffs = append(diffs, fmt.Sprintf("amount: external %.2f, internal %.2f", entry.amount, tx.Amount))
+	}
+	if entry.currency != tx.Currency {
+		diffs = append(diffs, fmt.Sprintf("currency: external %s, internal %s", entry.currency, tx.Currency))
+	}
+	if !s.withinDate(entry, tx.CreatedAt) {
+		diffs = append(diffs, fmt.Sprintf("date: external %s, internal %s", entry.date.Format(time.DateOnly), tx.CreatedAt.UTC().Format(time.DateOnly)))
+	}
+	if tx.Status != domain.StatusCompleted {
+		diffs = append(diffs, fmt.Sprintf("status: internal transaction is %s", tx.Status))
+	}
+	return diffs
+}
+
+func (s *ReconciliationService) withinDate(entry settlementEntry, at time.Time) bool {
+	return !at.Before(entry.date.Add(-s.cfg.DateTolerance)) && !at.After(entry.date.Add(entry.span+s.cfg.DateTolerance))
+}
+
+func (s *ReconciliationService) amountKey(amount float64, currency string) string {
+	factor := math.Pow10(s.rounding.Policy(currency).MinorUnits)
+	return currency + "|" + strconv.FormatInt(int64(math.Round(amount*factor)), 10)
+}
+
+func setInternal(item *domain.ReconciliationItem, tx *domain.Transaction) {
+	createdAt := tx.CreatedAt
+	item.TransactionID = tx.ID
+	item.InternalAmount = tx.Amount
+	item.InternalCurrency = tx.Currency
+	item.InternalDate = &createdAt
+	if item.Reference == "" {
+		item.Reference = tx.Reference
+	}
+}
+
+func itemState(item *domain.ReconciliationItem) string {
+	if item.Resolution != "" {
+		return "already resolved"
+	}
+	return string(item.Status)
+}
+
+func parseSettlementDate(value string) (time.Time, time.Duration, error) {
+	for _, layout := range settlementDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == time.DateOnly {
+				return t, 24 * time.Hour, nil
+			}
+			return t, 0, nil
+		}
+	}
+	return time.Time{}, 0, fmt.Errorf("invalid date %q", value)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"finance_manager/internal/repository/memory"
+	"testing"
+)
+
+func TestReconciliationService_AmountKeyUsesMinorUnits(t *testing.T) {
+	svc := NewReconciliationService(memory.NewTransactionRepository(), memory.NewReconciliationRepository(), DefaultReconciliationConfig(), nil)
+
+	if svc.amountKey(1.231, "KWD") == svc.amountKey(1.234, "KWD") {
+		t.Error("expected KWD amounts differing in fils to get distinct keys")
+	}
+	if svc.amountKey(100, "JPY") != svc.amountKey(100.004, "JPY") {
+		t.Error("expected JPY amounts within a yen to share a key")
+	}
+	if svc.amountKey(10.01, "USD") == svc.amountKey(10.02, "USD") {
+		t.Error("expected USD amounts differing in cents to get distinct keys")
+	}
+}
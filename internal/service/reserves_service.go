@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const ReservesReportJobName = "reserves_report"
+
+type ReservesConfig struct {
+	Interval time.Duration
+}
+
+func DefaultReservesConfig() ReservesConfig {
+	return ReservesConfig{Interval: time.Hour}
+}
+
+type ReservesService struct {
+	processor *processor.TransactionProcessor
+	cfg       ReservesConfig
+	snapshots *EODSnapshotService
+	mu        sync.RWMutex
+	latest    *processor.ReservesReport
+	logger    *slog.Logger
+}
+
+func NewReservesService(txProcessor *processor.TransactionProcessor, cfg ReservesConfig, logger *slog.Logger) *ReservesService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ReservesService{
+		processor: txProcessor,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+func (s *ReservesService) WithSnapshots(snapshots *EODSnapshotService) *ReservesService {
+	s.snapshots = snapshots
+	return s
+}
+
+func (s *ReservesService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     ReservesReportJobName,
+		Schedule: scheduler.Every(s.cfg.Interval),
+		Run: func(ctx context.Context) error {
+			_, err := s.Generate(ctx)
+			return err
+		},
+	})
+}
+
+func (s *ReservesService) Generate(ctx context.Context) (*processor.ReservesReport, error) {
+	report, err := s.processor.ReservesReport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reserves report: %w", err)
+	}
+	if s.snapshots != nil {
+		if snapshot, err := s.snapshots.Latest(ctx); err == nil {
+			report.SnapshotID = snapshot.ID
+		}
+	}
+
+	s.mu.Lock()
+	s.latest = report
+	s.mu.Unlock()
+
+	for _, currency := range report.Currencies {
+		if currency.Consistent {
+			continue
+		}
+		s.logger.ErrorContext(ctx, "Reserves do not reconcile with the ledger",
+			slog.String("report_id", report.ID),
+			slog.String("currency", currency.Currency),
+			slog.Float64("liabilities", currency.Liabilities),
+			slog.Float64("system_total", currency.SystemTotal),
+			slog.Float64("ledger_net", currency.LedgerNet),
+			slog.Float64("discrepancy", currency.Discrepancy))
+	}
+	return report, nil
+}
+
+func (s *ReservesService) Latest() (*processor.ReservesReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.latest == nil {
+		return nil, fmt.Errorf("%w: no reserves report generated yet", repository.ErrNotFound)
+	}
+	return s.latest, nil
+}
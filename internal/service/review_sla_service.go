@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const ReviewSLAJobName = "suspicious_review_sla_sweep"
+
+type ReviewSLAConfig struct {
+	SLA           time.Duration
+	Policy        string
+	SweepInterval time.Duration
+	AlertChannel  string
+}
+
+func DefaultReviewSLAConfig() ReviewSLAConfig {
+	return ReviewSLAConfig{
+		SLA:           48 * time.Hour,
+		Policy:        processor.ReviewSLAEscalate,
+		SweepInterval: 15 * time.Minute,
+		AlertChannel:  "#fraud-alerts",
+	}
+}
+
+type ReviewSLAMetrics interface {
+	ObserveReviewSLABreach(policy string)
+}
+
+type ReviewSLAService struct {
+	processor *processor.TransactionProcessor
+	notifier  Notifier
+	metrics   ReviewSLAMetrics
+	cfg       ReviewSLAConfig
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+func NewReviewSLAService(
+	txProcessor *processor.TransactionProcessor,
+	notifier Notifier,
+	cfg ReviewSLAConfig,
+	logger *slog.Logger,
+) *ReviewSLAService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ReviewSLAService{
+		processor: txProcessor,
+		notifier:  notifier,
+		cfg:       cfg,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+func (s *ReviewSLAService) WithMetrics(metrics ReviewSLAMetrics) *ReviewSLAService {
+	s.metrics = metrics
+	return s
+}
+
+func (s *ReviewSLAService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     ReviewSLAJobName,
+		Schedule: scheduler.Every(s.cfg.SweepInterval),
+		Run:      s.Sweep,
+	})
+}
+
+func (s *ReviewSLAService) Sweep(ctx context.Context) error {
+	breached, err := s.processor.ResolveOverdueReviews(ctx, s.now().Add(-s.cfg.SLA), s.cfg.Policy)
+	for _, tx := range breached {
+		if s.metrics != nil {
+			s.metrics.ObserveReviewSLABreach(s.cfg.Policy)
+		}
+		s.notifyFraudTeam(ctx, tx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve overdue reviews: %w", err)
+	}
+	return nil
+}
+
+func (s *ReviewSLAService) notifyFraudTeam(ctx context.Context, tx *domain.Transaction) {
+	if s.notifier == nil || s.cfg.AlertChannel == "" {
+		return
+	}
+
+	subject := "Suspicious transaction escalated"
+	outcome := "is still awaiting review and has been escalated"
+	priority := 9
+	if s.cfg.Policy == processor.ReviewSLAReject {
+		subject = "Suspicious transaction auto-rejected"
+		outcome = "was not reviewed in time and has been rejected"
+		priority = 7
+	}
+
+	err := s.notifier.Enqueue(ctx, NotificationMessage{
+		Type:      NotificationSlack,
+		Recipient: s.cfg.AlertChannel,
+		Subject:   subject,
+		Message: fmt.Sprintf("Transaction %s (%.2f %s, risk score %d) %s after the %s review SLA.",
+			tx.ID, tx.Amount, tx.Currency, tx.RiskScore, outcome, s.cfg.SLA),
+		Priority: priority,
+		Metadata: map[string]string{"transaction_id": tx.ID, "policy": s.cfg.Policy},
+		Routing:  processor.RoutingHints(tx),
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to queue review SLA notification",
+			slog.String("transaction_id", tx.ID),
+			slog.String("error", err.Error()))
+	}
+}
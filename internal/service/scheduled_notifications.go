@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const ScheduledNotificationJobName = "scheduled_notification_dispatch"
+
+var (
+	ErrInvalidScheduledNotification = errors.New("invalid scheduled notification")
+	ErrScheduledNotificationState   = errors.New("scheduled notification is not in a valid state for this operation")
+)
+
+type ScheduledNotificationConfig struct {
+	DispatchInterval time.Duration
+	QuietHoursStart  int
+	QuietHoursEnd    int
+	Location         *time.Location
+	MaxAttempts      int
+}
+
+func DefaultScheduledNotificationConfig() ScheduledNotificationConfig {
+	return ScheduledNotificationConfig{
+		DispatchInterval: time.Minute,
+		QuietHoursStart:  22,
+		QuietHoursEnd:    7,
+		Location:         time.UTC,
+		MaxAttempts:      3,
+	}
+}
+
+func (c ScheduledNotificationConfig) quietUntil(t time.Time) (time.Time, bool) {
+	if c.QuietHoursStart == c.QuietHoursEnd {
+		return t, false
+	}
+
+	local := t.In(c.Location)
+	hour := local.Hour()
+	var quiet bool
+	if c.QuietHoursStart < c.QuietHoursEnd {
+		quiet = hour >= c.QuietHoursStart && hour < c.QuietHoursEnd
+	} else {
+		quiet = hour >= c.QuietHoursStart || hour < c.QuietHoursEnd
+	}
+	if !quiet {
+		return t, false
+	}
+
+	end := time.Date(local.Year(), local.Month(), local.Day(), c.QuietHoursEnd, 0, 0, 0, c.Location)
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, true
+}
+
+type ScheduledNotificationService struct {
+	repo     repository.ScheduledNotificationRepository
+	notifier Notifier
+	cfg      ScheduledNotificationConfig
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+func NewScheduledNotificationService(
+	repo repository.ScheduledNotificationRepository,
+	notifier Notifier,
+	cfg ScheduledNotificationConfig,
+	logger *slog.Logger,
+) *ScheduledNotificationService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+
+	return &ScheduledNotificationService{
+		repo:     repo,
+		notifier: notifier,
+		cfg:      cfg,
+		now:      time.Now,
+		logger:   logger,
+	}
+}
+
+func (s *ScheduledNotificationService) Schedule(ctx context.Context, msg NotificationMessage, sendAt time.Time, honorQuietHours bool) (*domain.ScheduledNotification, error) {
+	if msg.Recipient == "" || msg.Type == "" {
+		return nil, fmt.Errorf("%w: type and recipient are required", ErrInvalidScheduledNotification)
+	}
+	if sendAt.IsZero() {
+		return nil, fmt.Errorf("%w: send time is required", ErrInvalidScheduledNotification)
+	}
+
+	msg = stampNotification(msg)
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScheduledNotification, err)
+	}
+
+	notification := domain.NewScheduledNotification(msg.UserID, string(msg.Type), sendAt, payload)
+	notification.HonorQuietHours = honorQuietHours
+	err = repository.SaveWithFreshID(
+		func() error { return s.repo.Save(ctx, notification) },
+		func() { notification.ID = domain.NewID() },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Notification scheduled",
+		slog.String("scheduled_id", notification.ID),
+		slog.String("message_id", msg.ID),
+		slog.String("channel", notification.Channel),
+		slog.Time("send_at", sendAt))
+
+	return notification, nil
+}
+
+func (s *ScheduledNotificationService) Cancel(ctx context.Context, id string) (*domain.ScheduledNotification, error) {
+	notification, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if notification.Status != domain.ScheduledNotificationPending {
+		return nil, fmt.Errorf("%w: scheduled notification %s is %s", ErrScheduledNotificationState, notification.ID, notification.Status)
+	}
+
+	notification.Status = domain.ScheduledNotificationCancelled
+	if err := s.repo.Update(ctx, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+func (s *ScheduledNotificationService) Get(ctx context.Context, id string) (*domain.ScheduledNotification, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ScheduledNotificationService) DispatchDue(ctx context.Context) error {
+	now := s.now()
+	due, err := s.repo.GetDue(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get due notifications: %w", err)
+	}
+
+	var errs []error
+	for _, notification := range due {
+		if err := s.dispatch(ctx, notification, now); err != nil {
+			errs = append(errs, fmt.Errorf("scheduled notification %s: %w", notification.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ScheduledNotificationService) Register(sched *scheduler.Scheduler) error {
+	return sched.Register(scheduler.Job{
+		Name:     ScheduledNotificationJobName,
+		Schedule: scheduler.Every(s.cfg.DispatchInterval),
+		Run:      s.DispatchDue,
+	})
+}
+
+func (s *ScheduledNotificationService) dispatch(ctx context.Context, notification *domain.ScheduledNotification, now time.Time) error {
+	if notification.HonorQuietHours {
+		if until, quiet := s.cfg.quietUntil(now); quiet {
+			notification.SendAt = until
+			s.logger.InfoContext(ctx, "Scheduled notification deferred for quiet hours",
+				slog.String("scheduled_id", notification.ID),
+				slog.Time("send_at", until))
+			return s.repo.Update(ctx, notification)
+		}
+	}
+
+	var msg NotificationMessage
+	if err := json.Unmarshal(notification.Payload, &msg); err != nil {
+		notification.Status = domain.ScheduledNotificationFailed
+		notification.LastError = err.Error()
+		return errors.Join(err, s.repo.Update(ctx, notification))
+	}
+
+	notification.Attempts++
+	if err := s.notifier.Enqueue(ctx, msg); err != nil {
+		notification.LastError = err.Error()
+		if notification.Attempts >= s.cfg.MaxAttempts {
+			notification.Status = domain.ScheduledNotificationFailed
+		}
+		return errors.Join(err, s.repo.Update(ctx, notification))
+	}
+
+	notification.Status = domain.ScheduledNotificationSent
+	notification.LastError = ""
+	notification.SentAt = &now
+	return s.repo.Update(ctx, notification)
+}
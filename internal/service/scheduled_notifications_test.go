@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type recordingNotifier struct {
+	messages []NotificationMessage
+	err      error
+}
+
+func (n *recordingNotifier) Enqueue(ctx context.Context, msg NotificationMessage) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+func TestScheduledNotificationService_DispatchHonorsQuietHours(t *testing.T) {
+	ctx := context.Background()
+	notifier := &recordingNotifier{}
+	svc := NewScheduledNotificationService(memory.NewScheduledNotificationRepository(), notifier, DefaultScheduledNotificationConfig(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	clock := time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC)
+	svc.now = func() time.Time { return clock }
+
+	reminder, _ := svc.Schedule(ctx, NotificationMessage{UserID: "u1", Type: NotificationEmail, Recipient: "u1@example.com", Message: "Payment due"}, clock.Add(-time.Minute), true)
+	alert, _ := svc.Schedule(ctx, NotificationMessage{UserID: "u1", Type: NotificationSMS, Recipient: "+15550100", Message: "Statement ready"}, clock.Add(-time.Minute), false)
+	later, _ := svc.Schedule(ctx, NotificationMessage{UserID: "u1", Type: NotificationSMS, Recipient: "+15550100", Message: "Later"}, clock.Add(time.Hour), false)
+	cancelled, _ := svc.Schedule(ctx, NotificationMessage{UserID: "u1", Type: NotificationSMS, Recipient: "+15550100", Message: "Cancelled"}, clock.Add(-time.Minute), false)
+	if _, err := svc.Cancel(ctx, cancelled.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.DispatchDue(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.messages) != 1 || notifier.messages[0].Message != "Statement ready" {
+		t.Fatalf("expected only the non-quiet notification to go out, got %+v", notifier.messages)
+	}
+	deferred, _ := svc.Get(ctx, reminder.ID)
+	if deferred.Status != domain.ScheduledNotificationPending || !deferred.SendAt.Equal(time.Date(2024, 3, 2, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected reminder deferred to 07:00, got %+v", deferred)
+	}
+	if sent, _ := svc.Get(ctx, alert.ID); sent.Status != domain.ScheduledNotificationSent || sent.SentAt == nil {
+		t.Errorf("expected alert marked sent, got %+v", sent)
+	}
+	if pending, _ := svc.Get(ctx, later.ID); pending.Status != domain.ScheduledNotificationPending {
+		t.Errorf("expected future notification to stay scheduled, got %+v", pending)
+	}
+
+	clock = time.Date(2024, 3, 2, 7, 1, 0, 0, time.UTC)
+	notifier.err = errors.New("queue unavailable")
+	_ = svc.DispatchDue(ctx)
+	notifier.err = nil
+	if err := svc.DispatchDue(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.messages) != 3 || notifier.messages[2].Message != "Payment due" {
+		t.Errorf("expected reminder delivered after quiet hours, got %+v", notifier.messages)
+	}
+	if retried, _ := svc.Get(ctx, reminder.ID); retried.Attempts != 2 || retried.Status != domain.ScheduledNotificationSent {
+		t.Errorf("expected reminder sent on second attempt, got %+v", retried)
+	}
+}
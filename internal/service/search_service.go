@@ -0,0 +1,139 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxSearchQueryLength = 100
+
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+type SearchResultType string
+
+const (
+	SearchResultAccount     SearchResultType = "account"
+	SearchResultCase        SearchResultType = "case"
+	SearchResultTransaction SearchResultType = "transaction"
+)
+
+type SearchResult struct {
+	Type     SearchResultType `json:"type"`
+	ID       string           `json:"id"`
+	Title    string           `json:"title"`
+	Subtitle string           `json:"subtitle,omitempty"`
+	Status   string           `json:"status,omitempty"`
+	Score    int              `json:"score"`
+}
+
+type SearchResults struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+	Took    time.Duration  `json:"took_ns"`
+}
+
+type SearchService struct {
+	transactions repository.TransactionSearcher
+	accounts     repository.AccountSearcher
+	cases        repository.DisputeSearcher
+	logger       *slog.Logger
+}
+
+func NewSearchService(transactions repository.TransactionSearcher, accounts repository.AccountSearcher, logger *slog.Logger) *SearchService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SearchService{transactions: transactions, accounts: accounts, logger: logger}
+}
+
+func (s *SearchService) WithCases(cases repository.DisputeSearcher) *SearchService {
+	s.cases = cases
+	return s
+}
+
+func (s *SearchService) Search(ctx context.Context, query string, limit int) (*SearchResults, error) {
+	start := time.Now()
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is required", ErrInvalidSearchQuery)
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, fmt.Errorf("%w: query must be at most %d characters", ErrInvalidSearchQuery, maxSearchQueryLength)
+	}
+
+	var results []SearchResult
+	if s.accounts != nil {
+		hits, err := s.accounts.Search(ctx, query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search accounts: %w", err)
+		}
+		for _, hit := range hits {
+			account := hit.Item
+			results = append(results, SearchResult{
+				Type:     SearchResultAccount,
+				ID:       account.ID,
+				Title:    account.ID,
+				Subtitle: fmt.Sprintf("%s account of %s", account.Currency, account.UserID),
+				Status:   string(account.Status),
+				Score:    hit.Score,
+			})
+		}
+	}
+	if s.cases != nil {
+		hits, err := s.cases.Search(ctx, query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search cases: %w", err)
+		}
+		for _, hit := range hits {
+			dispute := hit.Item
+			results = append(results, SearchResult{
+				Type:     SearchResultCase,
+				ID:       dispute.ID,
+				Title:    "Dispute on " + dispute.TransactionID,
+				Subtitle: dispute.Reason,
+				Status:   string(dispute.Status),
+				Score:    hit.Score,
+			})
+		}
+	}
+	if s.transactions != nil {
+		hits, err := s.transactions.Search(ctx, query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search transactions: %w", err)
+		}
+		for _, hit := range hits {
+			tx := hit.Item
+			subtitle := transactionDescription(tx, tx.FromAccountID)
+			if tx.Description != "" {
+				subtitle += " - " + tx.Description
+			}
+			results = append(results, SearchResult{
+				Type:     SearchResultTransaction,
+				ID:       tx.ID,
+				Title:    tx.Reference,
+				Subtitle: subtitle,
+				Status:   string(tx.Status),
+				Score:    hit.Score,
+			})
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+	return &SearchResults{Query: query, Results: results, Took: time.Since(start)}, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+type SMSEncoding string
+
+const (
+	EncodingGSM7 SMSEncoding = "gsm7"
+	EncodingUCS2 SMSEncoding = "ucs2"
+
+	gsm7SingleLimit    = 160
+	gsm7MultipartLimit = 153
+	ucs2SingleLimit    = 70
+	ucs2MultipartLimit = 67
+
+	gsm7Basic     = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7Extension = "\f^{}\\[~]|€"
+)
+
+var (
+	ErrProhibitedContent = errors.New("sms content is prohibited")
+	ErrSMSTooLong        = errors.New("sms exceeds maximum segments")
+)
+
+type SMSConfig struct {
+	OptOutFooter      string
+	ProhibitedContent []string
+	MaxSegments       int
+}
+
+func DefaultSMSConfig() SMSConfig {
+	return SMSConfig{
+		OptOutFooter: "Reply STOP to opt out",
+		MaxSegments:  6,
+	}
+}
+
+type FormattedSMS struct {
+	Encoding SMSEncoding
+	Segments []string
+}
+
+type SMSFormatter struct {
+	cfg        SMSConfig
+	prohibited []string
+}
+
+func NewSMSFormatter(cfg SMSConfig) *SMSFormatter {
+	prohibited := make([]string, 0, len(cfg.ProhibitedContent))
+	for _, term := range cfg.ProhibitedContent {
+		if term = strings.ToLower(strings.TrimSpace(term)); term != "" {
+			prohibited = append(prohibited, term)
+		}
+	}
+	return &SMSFormatter{cfg: cfg, prohibited: prohibited}
+}
+
+func (f *SMSFormatter) Format(message string) (FormattedSMS, error) {
+	lowered := strings.ToLower(message)
+	for _, term := range f.prohibited {
+		if strings.Contains(lowered, term) {
+			return FormattedSMS{}, fmt.Errorf("%w: contains %q", ErrProhibitedContent, term)
+		}
+	}
+
+	text := strings.TrimSpace(message)
+	if f.cfg.OptOutFooter != "" && !strings.HasSuffix(text, f.cfg.OptOutFooter) {
+		text += "\n" + f.cfg.OptOutFooter
+	}
+
+	encoding := DetectSMSEncoding(text)
+	segments := segmentSMS(text, encoding)
+	if f.cfg.MaxSegments > 0 && len(segments) > f.cfg.MaxSegments {
+		return FormattedSMS{}, fmt.Errorf("%w: %d segments, limit %d", ErrSMSTooLong, len(segments), f.cfg.MaxSegments)
+	}
+
+	return FormattedSMS{Encoding: encoding, Segments: segments}, nil
+}
+
+func DetectSMSEncoding(text string) SMSEncoding {
+	for _, r := range text {
+		if !strings.ContainsRune(gsm7Basic, r) && !strings.ContainsRune(gsm7Extension, r) {
+			return EncodingUCS2
+		}
+	}
+	return EncodingGSM7
+}
+
+func smsUnits(r rune, encoding SMSEncoding) int {
+	if encoding == EncodingUCS2 {
+		return utf16.RuneLen(r)
+	}
+	if strings.ContainsRune(gsm7Extension, r) {
+		return 2
+	}
+	return 1
+}
+
+func smsLength(text string, encoding SMSEncoding) int {
+	var n int
+	for _, r := range text {
+		n += smsUnits(r, encoding)
+	}
+	return n
+}
+
+func segmentSMS(text string, encoding SMSEncoding) []string {
+	single, multipart := gsm7SingleLimit, gsm7MultipartLimit
+	if encoding == EncodingUCS2 {
+		single, multipart = ucs2SingleLimit, ucs2MultipartLimit
+	}
+	if smsLength(text, encoding) <= single {
+		return []string{text}
+	}
+
+	total := 2
+	for {
+		counterWidth := len(fmt.Sprintf("(%d/%d) ", total, total))
+		parts := splitSMS(text, encoding, multipart-counterWidth)
+		if len(parts) <= total {
+			segments := make([]string, len(parts))
+			for i, part := range parts {
+				segments[i] = fmt.Sprintf("(%d/%d) %s", i+1, len(parts), part)
+			}
+			return segments
+		}
+		total = len(parts)
+	}
+}
+
+func splitSMS(text string, encoding SMSEncoding, capacity int) []string {
+	runes := []rune(text)
+	var parts []string
+	for len(runes) > 0 {
+		var used, end, lastSpace int
+		lastSpace = -1
+		for end < len(runes) {
+			units := smsUnits(runes[end], encoding)
+			if used+units > capacity {
+				break
+			}
+			used += units
+			if runes[end] == ' ' || runes[end] == '\n' {
+				lastSpace = end
+			}
+			end++
+		}
+		if end < len(runes) && lastSpace > 0 {
+			end = lastSpace + 1
+		}
+		parts = append(parts, strings.TrimRight(string(runes[:end]), " \n"))
+		runes = runes[end:]
+	}
+	return parts
+}
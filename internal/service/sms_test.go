@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSMSFormatter_Format(t *testing.T) {
+	formatter := NewSMSFormatter(SMSConfig{
+		OptOutFooter:      "Reply STOP to opt out",
+		ProhibitedContent: []string{"guaranteed returns"},
+		MaxSegments:       3,
+	})
+
+	short, err := formatter.Format("Your code is 123456")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if short.Encoding != EncodingGSM7 || len(short.Segments) != 1 || short.Segments[0] != "Your code is 123456\nReply STOP to opt out" {
+		t.Errorf("expected single gsm7 segment with footer, got %+v", short)
+	}
+
+	long, err := formatter.Format(strings.Repeat("Payment of 10 EUR received. ", 8))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if long.Encoding != EncodingGSM7 || len(long.Segments) != 2 || !strings.HasPrefix(long.Segments[0], "(1/2) ") || !strings.HasPrefix(long.Segments[1], "(2/2) ") {
+		t.Fatalf("expected two counted segments, got %+v", long.Segments)
+	}
+	for _, segment := range long.Segments {
+		if n := smsLength(segment, EncodingGSM7); n > gsm7MultipartLimit {
+			t.Errorf("segment exceeds %d septets: %d", gsm7MultipartLimit, n)
+		}
+	}
+
+	unicode, err := formatter.Format(strings.Repeat("Платёж получен. ", 5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unicode.Encoding != EncodingUCS2 || len(unicode.Segments) != 2 {
+		t.Errorf("expected two ucs2 segments, got %+v", unicode)
+	}
+
+	if _, err := formatter.Format("GUARANTEED RETURNS on your deposit"); !errors.Is(err, ErrProhibitedContent) {
+		t.Errorf("expected prohibited content to be blocked, got %v", err)
+	}
+	if _, err := formatter.Format(strings.Repeat("x", 600)); !errors.Is(err, ErrSMSTooLong) {
+		t.Errorf("expected message over the segment limit to be rejected, got %v", err)
+	}
+	if got := smsLength("€[", EncodingGSM7); got != 4 {
+		t.Errorf("expected extension characters to take two septets, got %d", got)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	PostingExchangeDebit  = "fx_exchange_debit"
+	PostingExchangeCredit = "fx_exchange_credit"
+	PostingExchangeRefund = "fx_exchange_refund"
+	MetadataExchangeID    = "exchange_id"
+)
+
+var ErrInvalidExchange = errors.New("invalid exchange")
+
+type WalletService struct {
+	accountRepo repository.AccountRepository
+	processor   *processor.TransactionProcessor
+	fx          *FXService
+	logger      *slog.Logger
+}
+
+func NewWalletService(accountRepo repository.AccountRepository, txProcessor *processor.TransactionProcessor, fx *FXService, logger *slog.Logger) *WalletService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WalletService{
+		accountRepo: accountRepo,
+		processor:   txProcessor,
+		fx:          fx,
+		logger:      logger,
+	}
+}
+
+func (s *WalletService) FX() *FXService {
+	return s.fx
+}
+
+func (s *WalletService) Get(ctx context.Context, userID, baseCurrency string) (*domain.Wallet, error) {
+	if baseCurrency == "" {
+		baseCurrency = s.fx.Base()
+	}
+	baseCurrency = strings.ToUpper(baseCurrency)
+
+	accounts, err := s.accountRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("%w: wallet for user %s", repository.ErrNotFound, userID)
+	}
+
+	wallet := &domain.Wallet{UserID: userID, BaseCurrency: baseCurrency, AsOf: time.Now()}
+	for _, account := range accounts {
+		converted, rate, err := s.fx.Convert(account.Balance, account.Currency, baseCurrency)
+		if err != nil {
+			return nil, err
+		}
+		wallet.Accounts = append(wallet.Accounts, domain.WalletAccount{
+			AccountID:        account.ID,
+			Currency:         account.Currency,
+			Status:           account.Status,
+			Balance:          account.Balance,
+			Rate:             rate,
+			ConvertedBalance: converted,
+		})
+		if account.Status != domain.AccountClosed {
+			wallet.TotalBalance += converted
+		}
+	}
+	wallet.TotalBalance = s.fx.Rounding().Round(wallet.TotalBalance, baseCurrency)
+	sort.Slice(wallet.Accounts, func(i, j int) bool {
+		return wallet.Accounts[i].AccountID < wallet.Accounts[j].AccountID
+	})
+
+	return wallet, nil
+}
+
+func (s *WalletService) Exchange(ctx context.Context, userID, fromAccountID, toAccountID string, amount float64) (*domain.CurrencyExchange, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidExchange)
+	}
+	if fromAccountID == toAccountID {
+		return nil, fmt.Errorf("%w: source and destination accounts must differ", ErrInvalidExchange)
+	}
+
+	from, err := s.ownedAccount(ctx, userID, fromAccountID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.ownedAccount(ctx, userID, toAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if from.Currency == to.Currency {
+		return nil, fmt.Errorf("%w: accounts share currency %s, use a transfer instead", ErrInvalidExchange, from.Currency)
+	}
+	if from.Status != domain.AccountActive || to.Status != domain.AccountActive {
+		return nil, fmt.Errorf("%w: both accounts must be active", repository.ErrAccountSuspended)
+	}
+	if rounded := s.fx.Rounding().Round(amount, from.Currency); rounded != amount {
+		return nil, fmt.Errorf("%w: amount %v has more precision than %s allows", ErrInvalidExchange, amount, from.Currency)
+	}
+	if from.Balance < amount {
+		return nil, repository.ErrInsufficientFunds
+	}
+
+	credit, rate, err := s.fx.Convert(amount, from.Currency, to.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	exchange := &domain.CurrencyExchange{
+		UserID:        userID,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		FromCurrency:  from.Currency,
+		ToCurrency:    to.Currency,
+		DebitAmount:   amount,
+		CreditAmount:  credit,
+		Rate:          rate,
+		CreatedAt:     time.Now(),
+	}
+
+	debitTx := domain.NewTransaction(domain.TypeWithdrawal, amount, from.Currency).
+		WithAccounts(from.ID, s.fxPosition(from.Currency)).
+		WithDescription(fmt.Sprintf("Exchange %s to %s", from.Currency, to.Currency))
+	exchange.ID = debitTx.ID
+	debitTx.AddMetadata(MetadataExchangeID, exchange.ID)
+	if err := s.processor.PostFundedSystemTransaction(ctx, debitTx, PostingExchangeDebit); err != nil {
+		return nil, fmt.Errorf("failed to debit %s: %w", from.ID, err)
+	}
+
+	creditTx := domain.NewTransaction(domain.TypeDeposit, credit, to.Currency).
+		WithAccounts(s.fxPosition(to.Currency), to.ID).
+		WithDescription(fmt.Sprintf("Exchange %s to %s", from.Currency, to.Currency))
+	creditTx.AddMetadata(MetadataExchangeID, exchange.ID)
+	creditTx.AddMetadata(processor.MetadataLinkedTransaction, debitTx.ID)
+	if err := s.processor.PostSystemTransaction(ctx, creditTx, PostingExchangeCredit); err != nil {
+		refund := domain.NewTransaction(domain.TypeDeposit, amount, from.Currency).
+			WithAccounts(s.fxPosition(from.Currency), from.ID).
+			WithDescription("Exchange refund")
+		refund.AddMetadata(MetadataExchangeID, exchange.ID)
+		refund.AddMetadata(processor.MetadataLinkedTransaction, debitTx.ID)
+		if refundErr := s.processor.PostSystemTransaction(ctx, refund, PostingExchangeRefund); refundErr != nil {
+			s.logger.ErrorContext(ctx, "Failed to refund exchange debit",
+				slog.String("exchange_id", exchange.ID),
+				slog.String("error", refundErr.Error()))
+		}
+		return nil, fmt.Errorf("failed to credit %s: %w", to.ID, err)
+	}
+
+	exchange.DebitTxID = debitTx.ID
+	exchange.CreditTxID = creditTx.ID
+
+	s.logger.InfoContext(ctx, "Currency exchange completed",
+		slog.String("exchange_id", exchange.ID),
+		slog.String("user_id", userID),
+		slog.Any("debit", s.processor.AmountFor(processor.AmountSinkLogs, amount)),
+		slog.Any("credit", s.processor.AmountFor(processor.AmountSinkLogs, credit)),
+		slog.Float64("rate", rate))
+
+	return exchange, nil
+}
+
+func (s *WalletService) fxPosition(currency string) string {
+	id, err := s.processor.SystemAccount(domain.SystemRoleFXPosition, currency)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+func (s *WalletService) ownedAccount(ctx context.Context, userID, accountID string) (*domain.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, fmt.Errorf("%w: account %s", repository.ErrNotFound, accountID)
+	}
+	return account, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/repository/memory"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+type slowAccountRepository struct {
+	*memory.AccountRepository
+}
+
+func (r slowAccountRepository) GetByID(ctx context.Context, id string) (*domain.Account, error) {
+	account, err := r.AccountRepository.GetByID(ctx, id)
+	time.Sleep(20 * time.Millisecond)
+	return account, err
+}
+
+func TestWalletService_ConcurrentExchangesDoNotOverdraw(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accRepo := memory.NewAccountRepository()
+	_ = accRepo.Save(ctx, &domain.Account{ID: "usd", UserID: "u1", Balance: 100, Status: domain.AccountActive, Currency: "USD"})
+	_ = accRepo.Save(ctx, &domain.Account{ID: "eur", UserID: "u1", Status: domain.AccountActive, Currency: "EUR"})
+	proc := processor.NewTransactionProcessor(memory.NewTransactionRepository(), accRepo, memory.NewRuleRepository(), 1)
+	if err := proc.EnsureSystemAccounts(ctx, processor.SystemAccountConfig{Currencies: []string{"USD", "EUR"}}); err != nil {
+		t.Fatalf("EnsureSystemAccounts: %v", err)
+	}
+	svc := NewWalletService(slowAccountRepository{accRepo}, proc, NewFXService("USD", map[string]float64{"EUR": 0.5}), logger)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.Exchange(ctx, "u1", "usd", "eur", 30)
+		}(i)
+	}
+	wg.Wait()
+
+	exchanged := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			exchanged++
+		case !errors.Is(err, repository.ErrInsufficientFunds):
+			t.Errorf("expected insufficient funds, got %v", err)
+		}
+	}
+	if exchanged != 3 {
+		t.Errorf("expected exactly three exchanges to fit the balance, got %d", exchanged)
+	}
+	if usd, _ := accRepo.GetByID(ctx, "usd"); usd.Balance != 10 {
+		t.Errorf("expected balance 10, got %v", usd.Balance)
+	}
+}
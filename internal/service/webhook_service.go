@@ -0,0 +1,359 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/pkg/scheduler"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	WebhookEventHeader         = "X-Webhook-Event"
+	WebhookEventIDHeader       = "X-Webhook-Event-ID"
+	WebhookSchemaVersionHeader = "X-Webhook-Schema-Version"
+	WebhookReplayHeader        = "X-Webhook-Replay"
+
+	WebhookEventLogPruneJobName = "webhook_event_log_prune"
+)
+
+var (
+	ErrEventLogNotConfigured = errors.New("webhook event log is not configured")
+	ErrInvalidReplay         = errors.New("invalid webhook replay request")
+	ErrWebhookQueueFull      = errors.New("webhook queue is full")
+)
+
+type WebhookConfig struct {
+	QueueSize      int
+	Workers        int
+	Timeout        time.Duration
+	ReplayLimit    int
+	EventRetention time.Duration
+	PruneInterval  time.Duration
+}
+
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		QueueSize:      1000,
+		Workers:        2,
+		Timeout:        5 * time.Second,
+		ReplayLimit:    500,
+		EventRetention: 7 * 24 * time.Hour,
+		PruneInterval:  time.Hour,
+	}
+}
+
+type WebhookReplayRequest struct {
+	EventIDs []string   `json:"event_ids,omitempty"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+}
+
+type WebhookReplayResult struct {
+	PartnerID string   `json:"partner_id"`
+	Queued    int      `json:"queued"`
+	EventIDs  []string `json:"event_ids"`
+	Missing   []string `json:"missing,omitempty"`
+}
+
+type webhookDelivery struct {
+	event     *domain.WebhookEvent
+	partnerID string
+}
+
+type WebhookService struct {
+	partnerRepo repository.PartnerRepository
+	eventLog    repository.WebhookEventRepository
+	client      *http.Client
+	cfg         WebhookConfig
+	queue       chan webhookDelivery
+	stop        chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+	logger      *slog.Logger
+}
+
+func NewWebhookService(partnerRepo repository.PartnerRepository, cfg WebhookConfig, logger *slog.Logger) *WebhookService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultWebhookConfig().QueueSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.ReplayLimit <= 0 {
+		cfg.ReplayLimit = DefaultWebhookConfig().ReplayLimit
+	}
+	if cfg.PruneInterval <= 0 {
+		cfg.PruneInterval = DefaultWebhookConfig().PruneInterval
+	}
+
+	return &WebhookService{
+		partnerRepo: partnerRepo,
+		client:      &http.Client{Timeout: cfg.Timeout},
+		cfg:         cfg,
+		queue:       make(chan webhookDelivery, cfg.QueueSize),
+		stop:        make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+func (s *WebhookService) WithHTTPClient(client *http.Client) *WebhookService {
+	s.client = client
+	return s
+}
+
+func (s *WebhookService) WithEventLog(eventLog repository.WebhookEventRepository) *WebhookService {
+	s.eventLog = eventLog
+	return s
+}
+
+func (s *WebhookService) Register(sched *scheduler.Scheduler) error {
+	if s.eventLog == nil {
+		return ErrEventLogNotConfigured
+	}
+	return sched.Register(scheduler.Job{
+		Name:     WebhookEventLogPruneJobName,
+		Schedule: scheduler.Every(s.cfg.PruneInterval),
+		Run: func(ctx context.Context) error {
+			_, err := s.PruneEventLog(ctx)
+			return err
+		},
+	})
+}
+
+func (s *WebhookService) Start() {
+	for i := 0; i < s.cfg.Workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+}
+
+func (s *WebhookService) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *WebhookService) Publish(ctx context.Context, eventType string, data interface{}) {
+	event, ok := domain.NewWebhookEvent(eventType, data)
+	if !ok {
+		s.logger.WarnContext(ctx, "Dropping event without a catalog schema",
+			slog.String("event_type", eventType))
+		return
+	}
+
+	if s.eventLog != nil {
+		if err := s.eventLog.Save(ctx, event); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to record webhook event",
+				slog.String("event_id", event.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	select {
+	case s.queue <- webhookDelivery{event: event}:
+	default:
+		s.logger.WarnContext(ctx, "Webhook queue full, dropping event",
+			slog.String("event_id", event.ID),
+			slog.String("event_type", eventType))
+	}
+}
+
+func (s *WebhookService) Replay(ctx context.Context, partnerID string, req WebhookReplayRequest) (*WebhookReplayResult, error) {
+	if s.eventLog == nil {
+		return nil, ErrEventLogNotConfigured
+	}
+	if len(req.EventIDs) > 0 && (req.From != nil || req.To != nil) {
+		return nil, fmt.Errorf("%w: specify either event_ids or a time range, not both", ErrInvalidReplay)
+	}
+	if len(req.EventIDs) == 0 && req.From == nil {
+		return nil, fmt.Errorf("%w: event_ids or from is required", ErrInvalidReplay)
+	}
+
+	partner, err := s.partnerRepo.GetByID(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	if partner.Status != domain.PartnerActive {
+		return nil, fmt.Errorf("%w: partner %s is %s", ErrInvalidReplay, partner.ID, partner.Status)
+	}
+
+	result := &WebhookReplayResult{PartnerID: partner.ID, EventIDs: []string{}}
+	var events []*domain.WebhookEvent
+	if len(req.EventIDs) > 0 {
+		for _, id := range req.EventIDs {
+			event, err := s.eventLog.GetByID(ctx, id)
+			if errors.Is(err, repository.ErrNotFound) {
+				result.Missing = append(result.Missing, id)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	} else {
+		to := time.Now()
+		if req.To != nil {
+			to = *req.To
+		}
+		if !req.From.Before(to) {
+			return nil, fmt.Errorf("%w: from must be before to", ErrInvalidReplay)
+		}
+		if events, err = s.eventLog.List(ctx, *req.From, to); err != nil {
+			return nil, err
+		}
+	}
+
+	events = slices.DeleteFunc(events, func(event *domain.WebhookEvent) bool {
+		return !subscribed(partner, event.Type)
+	})
+	if len(events) > s.cfg.ReplayLimit {
+		return nil, fmt.Errorf("%w: %d events exceed the replay limit of %d, narrow the range", ErrInvalidReplay, len(events), s.cfg.ReplayLimit)
+	}
+
+	for _, event := range events {
+		select {
+		case s.queue <- webhookDelivery{event: event, partnerID: partner.ID}:
+			result.Queued++
+			result.EventIDs = append(result.EventIDs, event.ID)
+		default:
+			return result, fmt.Errorf("%w: %d of %d events queued", ErrWebhookQueueFull, result.Queued, len(events))
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Webhook replay queued",
+		slog.String("partner_id", partner.ID),
+		slog.Int("events", result.Queued))
+	return result, nil
+}
+
+func (s *WebhookService) PruneEventLog(ctx context.Context) (int, error) {
+	if s.eventLog == nil {
+		return 0, ErrEventLogNotConfigured
+	}
+	if s.cfg.EventRetention <= 0 {
+		return 0, nil
+	}
+
+	removed, err := s.eventLog.DeleteBefore(ctx, time.Now().Add(-s.cfg.EventRetention))
+	if err != nil {
+		return 0, err
+	}
+	if removed > 0 {
+		s.logger.InfoContext(ctx, "Pruned webhook event log", slog.Int("removed", removed))
+	}
+	return removed, nil
+}
+
+func subscribed(partner *domain.Partner, eventType string) bool {
+	for _, endpoint := range partner.Webhooks {
+		if len(endpoint.Events) == 0 || slices.Contains(endpoint.Events, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *WebhookService) worker() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case delivery := <-s.queue:
+			s.dispatch(context.Background(), delivery)
+		}
+	}
+}
+
+func (s *WebhookService) subscribers(ctx context.Context, partnerID string) ([]*domain.Partner, error) {
+	if partnerID == "" {
+		return s.partnerRepo.GetAll(ctx)
+	}
+	partner, err := s.partnerRepo.GetByID(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	return []*domain.Partner{partner}, nil
+}
+
+func (s *WebhookService) dispatch(ctx context.Context, delivery webhookDelivery) {
+	event := delivery.event
+	partners, err := s.subscribers(ctx, delivery.partnerID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to load webhook subscribers",
+			slog.String("event_id", event.ID),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	replay := delivery.partnerID != ""
+	for _, partner := range partners {
+		if partner.Status != domain.PartnerActive {
+			continue
+		}
+		for _, endpoint := range partner.Webhooks {
+			if len(endpoint.Events) > 0 && !slices.Contains(endpoint.Events, event.Type) {
+				continue
+			}
+			if err := s.deliver(ctx, endpoint.URL, event, replay); err != nil {
+				s.logger.WarnContext(ctx, "Webhook delivery failed",
+					slog.String("partner_id", partner.ID),
+					slog.String("event_id", event.ID),
+					slog.String("event_type", event.Type),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+func (s *WebhookService) deliver(ctx context.Context, url string, event *domain.WebhookEvent, replay bool) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event.Type)
+	req.Header.Set(WebhookEventIDHeader, event.ID)
+	req.Header.Set(WebhookSchemaVersionHeader, event.SchemaVersion)
+	if replay {
+		req.Header.Set(WebhookReplayHeader, "true")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type Observer interface {
+	ObserveCacheLookup(cache string, hit bool)
+}
+
+type Stats struct {
+	Name    string `json:"name"`
+	Entries int    `json:"entries"`
+	Hits    int64  `json:"hits"`
+	Misses  int64  `json:"misses"`
+}
+
+type entry[V any] struct {
+	value     V
+	version   string
+	expiresAt time.Time
+}
+
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	name       string
+	ttl        time.Duration
+	maxEntries int
+	entries    map[K]entry[V]
+	hits       int64
+	misses     int64
+	observer   Observer
+	now        func() time.Time
+}
+
+func New[K comparable, V any](name string, ttl time.Duration, maxEntries int) *Cache[K, V] {
+	return &Cache[K, V]{
+		name:       name,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[K]entry[V]),
+		now:        time.Now,
+	}
+}
+
+func (c *Cache[K, V]) SetObserver(observer Observer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observer = observer
+}
+
+func (c *Cache[K, V]) Get(key K, version string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, exists := c.entries[key]
+	hit := exists && e.version == version && c.now().Before(e.expiresAt)
+	if exists && !hit {
+		delete(c.entries, key)
+	}
+	c.record(hit)
+	if !hit {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, version string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, exists := c.entries[key]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.entries[key] = entry[V]{value: value, version: version, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Name: c.name, Entries: len(c.entries), Hits: c.hits, Misses: c.misses}
+}
+
+func (c *Cache[K, V]) record(hit bool) {
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	if c.observer != nil {
+		c.observer.ObserveCacheLookup(c.name, hit)
+	}
+}
+
+func (c *Cache[K, V]) evict(now time.Time) {
+	var oldest K
+	var oldestExpiry time.Time
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestExpiry.IsZero() || e.expiresAt.Before(oldestExpiry) {
+			oldest, oldestExpiry = key, e.expiresAt
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldest)
+	}
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+type countingObserver struct {
+	hits, misses int
+}
+
+func (o *countingObserver) ObserveCacheLookup(cache string, hit bool) {
+	if hit {
+		o.hits++
+	} else {
+		o.misses++
+	}
+}
+
+func TestCache_VersionTTLAndEviction(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := New[string, int]("test", time.Minute, 2)
+	c.now = func() time.Time { return now }
+	observer := &countingObserver{}
+	c.SetObserver(observer)
+
+	c.Set("a", "v1", 1)
+	if v, ok := c.Get("a", "v1"); !ok || v != 1 {
+		t.Fatalf("expected hit for current version, got %v %v", v, ok)
+	}
+	if _, ok := c.Get("a", "v2"); ok {
+		t.Error("expected miss for a newer resource version")
+	}
+	if _, ok := c.Get("a", "v1"); ok {
+		t.Error("expected stale version to be dropped")
+	}
+
+	c.Set("b", "v1", 2)
+	now = now.Add(10 * time.Second)
+	c.Set("c", "v1", 3)
+	c.Set("d", "v1", 4)
+	if _, ok := c.Get("b", "v1"); ok {
+		t.Error("expected oldest entry to be evicted at capacity")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("d", "v1"); ok {
+		t.Error("expected entry to expire after TTL")
+	}
+
+	if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 4 || observer.hits != 1 || observer.misses != 4 {
+		t.Errorf("unexpected stats %+v / %+v", stats, observer)
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) GetWallet(ctx context.Context, userID, currency string) (*Wallet, error) {
+	req := request{method: http.MethodGet, path: "/api/v1/users/" + url.PathEscape(userID) + "/wallet"}
+	if currency != "" {
+		req.query = url.Values{"currency": {currency}}
+	}
+
+	var wallet Wallet
+	if _, err := c.do(ctx, req, &wallet); err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (c *Client) RequestLimitChange(ctx context.Context, accountID, limitType string, newLimit float64) (*LimitChange, error) {
+	var change LimitChange
+	body := map[string]interface{}{"limit_type": limitType, "new_limit": newLimit}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/accounts/"+url.PathEscape(accountID)+"/limits", body, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *Client) ConfirmLimitChange(ctx context.Context, changeID, otp string) (*LimitChange, error) {
+	var change LimitChange
+	body := map[string]string{"otp": otp}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/limit-changes/"+url.PathEscape(changeID)+"/confirm", body, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *Client) AddBeneficiary(ctx context.Context, accountID, beneficiaryAccountID string) (*Beneficiary, error) {
+	var beneficiary Beneficiary
+	body := map[string]string{"beneficiary_account_id": beneficiaryAccountID}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/accounts/"+url.PathEscape(accountID)+"/beneficiaries", body, &beneficiary); err != nil {
+		return nil, err
+	}
+	return &beneficiary, nil
+}
+
+func (c *Client) ListBeneficiaries(ctx context.Context, accountID string) ([]Beneficiary, error) {
+	var beneficiaries []Beneficiary
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/accounts/"+url.PathEscape(accountID)+"/beneficiaries", nil, &beneficiaries); err != nil {
+		return nil, err
+	}
+	return beneficiaries, nil
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"finance_manager/pkg/crypto"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	replayedHeader       = "Idempotent-Replayed"
+	operatorHeader       = "X-Operator-ID"
+)
+
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"error"`
+	Code       string `json:"code,omitempty"`
+	Details    string `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("finance api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func IsStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+	}
+}
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	signer     *crypto.Signer
+	operatorID string
+	retry      RetryPolicy
+}
+
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		retry:      DefaultRetryPolicy(),
+	}
+}
+
+func (c *Client) WithSigner(signer *crypto.Signer) *Client {
+	c.signer = signer
+	return c
+}
+
+func (c *Client) WithOperator(operatorID string) *Client {
+	c.operatorID = operatorID
+	return c
+}
+
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.retry = policy
+	return c
+}
+
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("client: reading idempotency key entropy: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+type request struct {
+	method         string
+	path           string
+	query          url.Values
+	body           []byte
+	contentType    string
+	idempotencyKey string
+}
+
+func (c *Client) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	req := request{method: method, path: path}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		req.body = body
+		req.contentType = "application/json"
+	}
+	_, err := c.do(ctx, req, out)
+	return err
+}
+
+func (c *Client) do(ctx context.Context, req request, out interface{}) (http.Header, error) {
+	retryable := req.method == http.MethodGet || req.idempotencyKey != ""
+	attempts := max(c.retry.MaxAttempts, 1)
+	backoff := c.retry.InitialBackoff
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		header, retry, err := c.attempt(ctx, req, out)
+		if err == nil {
+			return header, nil
+		}
+		lastErr = err
+		if !retry || !retryable || attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, c.retry.MaxBackoff)
+	}
+	return nil, lastErr
+}
+
+func (c *Client) attempt(ctx context.Context, req request, out interface{}) (http.Header, bool, error) {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, false, fmt.Errorf("build request: %w", err)
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set(idempotencyKeyHeader, req.idempotencyKey)
+	}
+	if c.operatorID != "" {
+		httpReq.Header.Set(operatorHeader, c.operatorID)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, retryableStatus(resp.StatusCode), apiErr
+	}
+
+	switch dst := out.(type) {
+	case nil:
+	case *[]byte:
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, true, fmt.Errorf("read response: %w", err)
+		}
+		*dst = data
+	default:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, false, fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return resp.Header, false, nil
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesOnlyIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Method == http.MethodPost && r.Header.Get(idempotencyKeyHeader) == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if calls.Load() < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"tx1","status":"completed"}`))
+	}))
+	defer server.Close()
+
+	sdk := New(server.URL, server.Client()).WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	tx, err := sdk.GetTransaction(context.Background(), "tx1")
+	if err != nil || tx.ID != "tx1" || calls.Load() != 3 {
+		t.Fatalf("expected GET to succeed on third attempt, got %v / %v after %d calls", tx, err, calls.Load())
+	}
+
+	calls.Store(0)
+	_, err = sdk.CreateBatch(context.Background(), []CreateTransactionRequest{{Type: TypeDeposit, Amount: 1, Currency: "USD"}})
+	if !IsStatus(err, http.StatusServiceUnavailable) || calls.Load() != 1 {
+		t.Errorf("expected non-idempotent POST to fail without retry, got %v after %d calls", err, calls.Load())
+	}
+}
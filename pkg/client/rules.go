@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func (c *Client) ExportRules(ctx context.Context, format string) ([]byte, error) {
+	var data []byte
+	req := request{method: http.MethodGet, path: "/api/v1/rules/export", query: url.Values{"format": {format}}}
+	if _, err := c.do(ctx, req, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) ImportRules(ctx context.Context, data []byte, format string, dryRun bool) (*RuleImportResult, error) {
+	var result RuleImportResult
+	req := request{
+		method:      http.MethodPost,
+		path:        "/api/v1/rules/import",
+		query:       url.Values{"format": {format}, "dry_run": {strconv.FormatBool(dryRun)}},
+		body:        data,
+		contentType: "application/" + format,
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) RuleStats(ctx context.Context, ruleID string) (*RuleStats, error) {
+	var stats RuleStats
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/rules/"+url.PathEscape(ruleID)+"/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (c *Client) AllRuleStats(ctx context.Context) ([]RuleStats, error) {
+	var stats []RuleStats
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/rules/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
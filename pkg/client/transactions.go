@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (c *Client) CreateTransaction(ctx context.Context, req CreateTransactionRequest) (*TransactionResponse, error) {
+	return c.CreateTransactionWithKey(ctx, NewIdempotencyKey(), req)
+}
+
+func (c *Client) CreateTransactionWithKey(ctx context.Context, idempotencyKey string, req CreateTransactionRequest) (*TransactionResponse, error) {
+	if c.signer != nil && req.Signature == "" {
+		req.Timestamp = time.Now().Unix()
+		req.Signature = c.signer.SignTransaction("", req.Amount, req.Currency, req.Timestamp)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+
+	var resp TransactionResponse
+	header, err := c.do(ctx, request{
+		method:         http.MethodPost,
+		path:           "/api/v1/transactions",
+		body:           body,
+		contentType:    "application/json",
+		idempotencyKey: idempotencyKey,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Replayed = header.Get(replayedHeader) == "true"
+	return &resp, nil
+}
+
+func (c *Client) CreateBatch(ctx context.Context, reqs []CreateTransactionRequest) (*BatchResponse, error) {
+	var resp BatchResponse
+	err := c.doJSON(ctx, http.MethodPost, "/api/v1/transactions/batch", map[string]interface{}{"transactions": reqs}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) GetTransaction(ctx context.Context, id string) (*Transaction, error) {
+	return c.getTransaction(ctx, url.Values{"id": {id}})
+}
+
+func (c *Client) GetTransactionByReference(ctx context.Context, reference string) (*Transaction, error) {
+	return c.getTransaction(ctx, url.Values{"reference": {reference}})
+}
+
+func (c *Client) getTransaction(ctx context.Context, query url.Values) (*Transaction, error) {
+	var tx Transaction
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/transactions", query: query}, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (c *Client) OverrideRisk(ctx context.Context, transactionID string, req RiskOverrideRequest) (*TransactionResponse, error) {
+	var resp TransactionResponse
+	err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/transactions/"+url.PathEscape(transactionID)+"/override", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
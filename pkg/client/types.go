@@ -0,0 +1,133 @@
+package client
+
+import (
+	"time"
+)
+
+type TransactionType string
+
+const (
+	TypeDeposit    TransactionType = "deposit"
+	TypeWithdrawal TransactionType = "withdrawal"
+	TypeTransfer   TransactionType = "transfer"
+)
+
+type CreateTransactionRequest struct {
+	Type          TransactionType   `json:"type"`
+	Amount        float64           `json:"amount"`
+	Currency      string            `json:"currency"`
+	FromAccountID string            `json:"from_account_id,omitempty"`
+	ToAccountID   string            `json:"to_account_id,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	SchemaVersion string            `json:"metadata_schema_version,omitempty"`
+	Timestamp     int64             `json:"timestamp,omitempty"`
+	Signature     string            `json:"signature,omitempty"`
+}
+
+type TransactionResponse struct {
+	ID            string   `json:"id"`
+	Reference     string   `json:"reference,omitempty"`
+	Status        string   `json:"status"`
+	RiskScore     int      `json:"risk_score"`
+	FraudFlags    []string `json:"fraud_flags,omitempty"`
+	DecidedByRule string   `json:"decided_by_rule,omitempty"`
+	Message       string   `json:"message,omitempty"`
+	Replayed      bool     `json:"-"`
+}
+
+type Transaction struct {
+	ID            string            `json:"id"`
+	Reference     string            `json:"reference,omitempty"`
+	Type          TransactionType   `json:"type"`
+	Amount        float64           `json:"amount"`
+	Currency      string            `json:"currency"`
+	FromAccountID string            `json:"from_account_id,omitempty"`
+	ToAccountID   string            `json:"to_account_id,omitempty"`
+	Description   string            `json:"description"`
+	Status        string            `json:"status"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	RiskScore     int               `json:"risk_score"`
+	FraudFlags    []string          `json:"fraud_flags,omitempty"`
+}
+
+type BatchResult struct {
+	Index       int                  `json:"index"`
+	Transaction *TransactionResponse `json:"transaction,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Results   []BatchResult `json:"results"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+}
+
+type WalletAccount struct {
+	AccountID        string  `json:"account_id"`
+	Currency         string  `json:"currency"`
+	Status           string  `json:"status"`
+	Balance          float64 `json:"balance"`
+	Rate             float64 `json:"rate"`
+	ConvertedBalance float64 `json:"converted_balance"`
+}
+
+type Wallet struct {
+	UserID       string          `json:"user_id"`
+	BaseCurrency string          `json:"base_currency"`
+	Accounts     []WalletAccount `json:"accounts"`
+	TotalBalance float64         `json:"total_balance"`
+	AsOf         time.Time       `json:"as_of"`
+}
+
+type LimitChange struct {
+	ID             string     `json:"id"`
+	AccountID      string     `json:"account_id"`
+	LimitType      string     `json:"limit_type"`
+	PreviousLimit  float64    `json:"previous_limit"`
+	RequestedLimit float64    `json:"requested_limit"`
+	Status         string     `json:"status"`
+	EffectiveAt    *time.Time `json:"effective_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type Beneficiary struct {
+	ID                   string     `json:"id"`
+	AccountID            string     `json:"account_id"`
+	BeneficiaryAccountID string     `json:"beneficiary_account_id"`
+	Status               string     `json:"status"`
+	TrustedAt            *time.Time `json:"trusted_at,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+type RuleImportResult struct {
+	Imported int    `json:"imported"`
+	DryRun   bool   `json:"dry_run"`
+	Message  string `json:"message,omitempty"`
+}
+
+type RuleStats struct {
+	RuleID          string     `json:"rule_id"`
+	Evaluations     int64      `json:"evaluations"`
+	Skipped         int64      `json:"skipped"`
+	Triggers        int64      `json:"triggers"`
+	Errors          int64      `json:"errors"`
+	AverageLatency  int64      `json:"average_latency_ns"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+}
+
+type OverrideOutcome string
+
+const (
+	OverrideApprove OverrideOutcome = "approve"
+	OverrideReject  OverrideOutcome = "reject"
+)
+
+type RiskOverrideRequest struct {
+	Outcome   OverrideOutcome `json:"outcome"`
+	RiskScore *int            `json:"risk_score,omitempty"`
+	Reason    string          `json:"reason"`
+}
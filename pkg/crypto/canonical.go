@@ -0,0 +1,31 @@
+package crypto
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const SigningPayloadVersion byte = 1
+
+type SigningPayload struct {
+	TransactionID string
+	Amount        float64
+	Currency      string
+	Timestamp     int64
+	Fields        map[string]string
+}
+
+func (p SigningPayload) Canonical() []byte {
+	fields := make(map[string]string, len(p.Fields)+4)
+	for key, value := range p.Fields {
+		fields[key] = value
+	}
+	fields["transaction_id"] = p.TransactionID
+	fields["amount"] = strconv.FormatFloat(p.Amount, 'f', -1, 64)
+	fields["currency"] = strings.ToUpper(p.Currency)
+	fields["timestamp"] = strconv.FormatInt(p.Timestamp, 10)
+
+	body, _ := json.Marshal(fields)
+	return append([]byte{SigningPayloadVersion}, body...)
+}
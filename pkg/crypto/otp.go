@@ -0,0 +1,16 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+func GenerateOTP(digits int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate otp: %w", err)
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
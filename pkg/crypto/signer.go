@@ -43,12 +43,18 @@ func (s *Signer) Verify(data []byte, signature string) (bool, error) {
 	return true, nil
 }
 
+func (s *Signer) SignPayload(payload SigningPayload) string {
+	return s.Sign(payload.Canonical())
+}
+
+func (s *Signer) VerifyPayload(payload SigningPayload, signature string) (bool, error) {
+	return s.Verify(payload.Canonical(), signature)
+}
+
 func (s *Signer) SignTransaction(transactionID string, amount float64, currency string, timestamp int64) string {
-	data := fmt.Sprintf("%s:%.2f:%s:%d", transactionID, amount, currency, timestamp)
-	return s.Sign([]byte(data))
+	return s.SignPayload(SigningPayload{TransactionID: transactionID, Amount: amount, Currency: currency, Timestamp: timestamp})
 }
 
 func (s *Signer) VerifyTransaction(transactionID string, amount float64, currency string, timestamp int64, signature string) (bool, error) {
-	data := fmt.Sprintf("%s:%.2f:%s:%d", transactionID, amount, currency, timestamp)
-	return s.Verify([]byte(data), signature)
+	return s.VerifyPayload(SigningPayload{TransactionID: transactionID, Amount: amount, Currency: currency, Timestamp: timestamp}, signature)
 }
@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSigningPayload_Canonical(t *testing.T) {
+	payload := SigningPayload{
+		TransactionID: "tx1",
+		Amount:        10.125,
+		Currency:      "bhd",
+		Timestamp:     1700000000,
+		Fields:        map[string]string{"channel": "mobile", "amount": "999"},
+	}
+
+	canonical := payload.Canonical()
+	expected := append([]byte{SigningPayloadVersion}, `{"amount":"10.125","channel":"mobile","currency":"BHD","timestamp":"1700000000","transaction_id":"tx1"}`...)
+
+	if !bytes.Equal(canonical, expected) {
+		t.Fatalf("unexpected canonical payload:\n%q\n%q", canonical, expected)
+	}
+}
+
+func TestSigner_SignTransactionDistinguishesSubCentAmounts(t *testing.T) {
+	signer := NewSigner("secret", nil)
+
+	signature := signer.SignTransaction("tx1", 10.125, "BHD", 1700000000)
+
+	if valid, err := signer.VerifyTransaction("tx1", 10.125, "BHD", 1700000000, signature); !valid || err != nil {
+		t.Fatalf("expected signature to verify, got %v / %v", valid, err)
+	}
+	if valid, _ := signer.VerifyTransaction("tx1", 10.12, "BHD", 1700000000, signature); valid {
+		t.Error("expected signature over 10.125 to be rejected for 10.12")
+	}
+}
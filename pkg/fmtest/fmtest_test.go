@@ -0,0 +1,56 @@
+package fmtest
+
+import (
+	"context"
+	"errors"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"finance_manager/internal/repository/memory"
+	"finance_manager/internal/service"
+	"testing"
+)
+
+func TestFakes_DriveProcessorDeterministically(t *testing.T) {
+	ctx := context.Background()
+	accounts := NewAccountRepository(&domain.Account{ID: "A1", UserID: "U1", Currency: "USD", Balance: 100, Status: domain.AccountActive})
+	txs := NewTransactionRepository()
+	scorer := NewFraudScorer().ScoreAccount("A1", 42, "scripted_risk")
+	proc := processor.NewTransactionProcessor(txs, accounts, memory.NewRuleRepository(), 1).WithFraudDetector(scorer.Detector())
+
+	tx := domain.NewTransaction(domain.TypeWithdrawal, 10, "USD")
+	tx.FromAccountID = "A1"
+	if err := proc.ProcessTransaction(ctx, tx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.RiskScore != 42 || len(tx.FraudFlags) != 1 || tx.FraudFlags[0] != "scripted_risk" {
+		t.Errorf("expected scripted score 42, got %d %v", tx.RiskScore, tx.FraudFlags)
+	}
+	if len(txs.Calls("Save")) != 1 || len(accounts.Calls("Update")) != 1 {
+		t.Errorf("expected one save and one account update, got %+v / %+v", txs.Calls(""), accounts.Calls(""))
+	}
+
+	boom := errors.New("boom")
+	txs.FailNext("Save", boom)
+	failed := domain.NewTransaction(domain.TypeWithdrawal, 10, "USD")
+	failed.FromAccountID = "A1"
+	if err := proc.ProcessTransaction(ctx, failed); !errors.Is(err, boom) {
+		t.Errorf("expected injected save error, got %v", err)
+	}
+}
+
+func TestRecordingNotifier(t *testing.T) {
+	notifier := NewRecordingNotifier()
+	if _, ok := notifier.Last(); ok {
+		t.Fatal("expected no messages")
+	}
+	_ = notifier.Enqueue(context.Background(), service.NotificationMessage{Subject: "first"})
+	_ = notifier.Enqueue(context.Background(), service.NotificationMessage{Subject: "second"})
+	if last, _ := notifier.Last(); last.Subject != "second" || len(notifier.Messages()) != 2 {
+		t.Errorf("unexpected messages: %+v", notifier.Messages())
+	}
+
+	boom := errors.New("queue down")
+	if err := notifier.FailWith(boom).Enqueue(context.Background(), service.NotificationMessage{Subject: "third"}); !errors.Is(err, boom) {
+		t.Errorf("expected scripted failure, got %v", err)
+	}
+}
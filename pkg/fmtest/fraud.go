@@ -0,0 +1,45 @@
+package fmtest
+
+import (
+	"finance_manager/internal/domain"
+	"finance_manager/internal/processor"
+	"time"
+)
+
+var FixedTime = time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+type FraudScorer struct {
+	detector *processor.FraudDetector
+}
+
+func NewFraudScorer() *FraudScorer {
+	return &FraudScorer{
+		detector: processor.NewFraudDetectorWithPatterns().WithClock(func() time.Time { return FixedTime }),
+	}
+}
+
+func (s *FraudScorer) ScoreTransaction(transactionID string, score int, flag string) *FraudScorer {
+	return s.ScoreWhen(flag, score, func(tx *domain.Transaction) bool { return tx.ID == transactionID })
+}
+
+func (s *FraudScorer) ScoreAccount(accountID string, score int, flag string) *FraudScorer {
+	return s.ScoreWhen(flag, score, func(tx *domain.Transaction) bool {
+		return tx.FromAccountID == accountID || tx.ToAccountID == accountID
+	})
+}
+
+func (s *FraudScorer) ScoreWhen(flag string, score int, match func(*domain.Transaction) bool) *FraudScorer {
+	s.detector.AddPattern(processor.FraudPattern{
+		Name:        flag,
+		Description: "Scripted test score",
+		Detect: func(tx *domain.Transaction) (bool, string) {
+			return match(tx), flag
+		},
+		Weight: score,
+	})
+	return s
+}
+
+func (s *FraudScorer) Detector() *processor.FraudDetector {
+	return s.detector
+}
@@ -0,0 +1,55 @@
+package fmtest
+
+import (
+	"context"
+	"finance_manager/internal/service"
+	"sync"
+)
+
+type RecordingNotifier struct {
+	mu       sync.Mutex
+	messages []service.NotificationMessage
+	err      error
+}
+
+func NewRecordingNotifier() *RecordingNotifier {
+	return &RecordingNotifier{}
+}
+
+func (n *RecordingNotifier) FailWith(err error) *RecordingNotifier {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.err = err
+	return n
+}
+
+func (n *RecordingNotifier) Enqueue(ctx context.Context, msg service.NotificationMessage) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+func (n *RecordingNotifier) Messages() []service.NotificationMessage {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]service.NotificationMessage(nil), n.messages...)
+}
+
+func (n *RecordingNotifier) Last() (service.NotificationMessage, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.messages) == 0 {
+		return service.NotificationMessage{}, false
+	}
+	return n.messages[len(n.messages)-1], true
+}
+
+func (n *RecordingNotifier) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = nil
+}
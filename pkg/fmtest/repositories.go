@@ -0,0 +1,191 @@
+package fmtest
+
+import (
+	"context"
+	"finance_manager/internal/domain"
+	"finance_manager/internal/repository"
+	"finance_manager/internal/repository/memory"
+	"iter"
+	"time"
+)
+
+var (
+	_ repository.TransactionRepository = (*TransactionRepository)(nil)
+	_ repository.AccountRepository     = (*AccountRepository)(nil)
+)
+
+type TransactionRepository struct {
+	script
+	inner repository.TransactionRepository
+}
+
+func NewTransactionRepository() *TransactionRepository {
+	return &TransactionRepository{inner: memory.NewTransactionRepository()}
+}
+
+func (r *TransactionRepository) Save(ctx context.Context, tx *domain.Transaction) error {
+	if err := r.record("Save", tx); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, tx)
+}
+
+func (r *TransactionRepository) SaveAll(ctx context.Context, txs []*domain.Transaction) error {
+	if err := r.record("SaveAll", txs); err != nil {
+		return err
+	}
+	return r.inner.SaveAll(ctx, txs)
+}
+
+func (r *TransactionRepository) GetByID(ctx context.Context, id string) (*domain.Transaction, error) {
+	if err := r.record("GetByID", id); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByID(ctx, id)
+}
+
+func (r *TransactionRepository) GetByReference(ctx context.Context, reference string) (*domain.Transaction, error) {
+	if err := r.record("GetByReference", reference); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByReference(ctx, reference)
+}
+
+func (r *TransactionRepository) GetByAccountID(ctx context.Context, accountID string, limit, offset int) ([]*domain.Transaction, error) {
+	if err := r.record("GetByAccountID", accountID, limit, offset); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByAccountID(ctx, accountID, limit, offset)
+}
+
+func (r *TransactionRepository) GetByStatus(ctx context.Context, status domain.TransactionStatus) ([]*domain.Transaction, error) {
+	if err := r.record("GetByStatus", status); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByStatus(ctx, status)
+}
+
+func (r *TransactionRepository) GetByPeriod(ctx context.Context, from, to time.Time) ([]*domain.Transaction, error) {
+	if err := r.record("GetByPeriod", from, to); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByPeriod(ctx, from, to)
+}
+
+func (r *TransactionRepository) Stream(ctx context.Context, filter repository.TransactionFilter) iter.Seq2[*domain.Transaction, error] {
+	if err := r.record("Stream", filter); err != nil {
+		return func(yield func(*domain.Transaction, error) bool) { yield(nil, err) }
+	}
+	return r.inner.Stream(ctx, filter)
+}
+
+func (r *TransactionRepository) Iterate(ctx context.Context, filter repository.TransactionFilter, fn func(*domain.Transaction) error) error {
+	if err := r.record("Iterate", filter); err != nil {
+		return err
+	}
+	return r.inner.Iterate(ctx, filter, fn)
+}
+
+func (r *TransactionRepository) UpdateStatus(ctx context.Context, id string, status domain.TransactionStatus) error {
+	if err := r.record("UpdateStatus", id, status); err != nil {
+		return err
+	}
+	return r.inner.UpdateStatus(ctx, id, status)
+}
+
+func (r *TransactionRepository) TransitionStatus(ctx context.Context, id string, from, to domain.TransactionStatus) error {
+	if err := r.record("TransitionStatus", id, from, to); err != nil {
+		return err
+	}
+	return r.inner.TransitionStatus(ctx, id, from, to)
+}
+
+func (r *TransactionRepository) GetDailyVolume(ctx context.Context, accountID string, date time.Time) (float64, error) {
+	if err := r.record("GetDailyVolume", accountID, date); err != nil {
+		return 0, err
+	}
+	return r.inner.GetDailyVolume(ctx, accountID, date)
+}
+
+func (r *TransactionRepository) GetMonthlyVolume(ctx context.Context, accountID string, year int, month time.Month) (float64, error) {
+	if err := r.record("GetMonthlyVolume", accountID, year, month); err != nil {
+		return 0, err
+	}
+	return r.inner.GetMonthlyVolume(ctx, accountID, year, month)
+}
+
+type AccountRepository struct {
+	script
+	inner repository.AccountRepository
+}
+
+func NewAccountRepository(accounts ...*domain.Account) *AccountRepository {
+	inner := memory.NewAccountRepository()
+	for _, account := range accounts {
+		_ = inner.Save(context.Background(), account)
+	}
+	return &AccountRepository{inner: inner}
+}
+
+func (r *AccountRepository) Save(ctx context.Context, account *domain.Account) error {
+	if err := r.record("Save", account); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, account)
+}
+
+func (r *AccountRepository) GetByID(ctx context.Context, id string) (*domain.Account, error) {
+	if err := r.record("GetByID", id); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByID(ctx, id)
+}
+
+func (r *AccountRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Account, error) {
+	if err := r.record("GetByUserID", userID); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByUserID(ctx, userID)
+}
+
+func (r *AccountRepository) Update(ctx context.Context, account *domain.Account) error {
+	if err := r.record("Update", account); err != nil {
+		return err
+	}
+	return r.inner.Update(ctx, account)
+}
+
+func (r *AccountRepository) UpdateBalance(ctx context.Context, id string, amount float64) error {
+	if err := r.record("UpdateBalance", id, amount); err != nil {
+		return err
+	}
+	return r.inner.UpdateBalance(ctx, id, amount)
+}
+
+func (r *AccountRepository) ApplyBalanceUpdates(ctx context.Context, updates []domain.BalanceUpdate) error {
+	if err := r.record("ApplyBalanceUpdates", updates); err != nil {
+		return err
+	}
+	return r.inner.ApplyBalanceUpdates(ctx, updates)
+}
+
+func (r *AccountRepository) UpdateStatus(ctx context.Context, id string, status domain.AccountStatus) error {
+	if err := r.record("UpdateStatus", id, status); err != nil {
+		return err
+	}
+	return r.inner.UpdateStatus(ctx, id, status)
+}
+
+func (r *AccountRepository) GetAllActive(ctx context.Context) ([]*domain.Account, error) {
+	if err := r.record("GetAllActive"); err != nil {
+		return nil, err
+	}
+	return r.inner.GetAllActive(ctx)
+}
+
+func (r *AccountRepository) GetByRiskCategory(ctx context.Context, category string) ([]*domain.Account, error) {
+	if err := r.record("GetByRiskCategory", category); err != nil {
+		return nil, err
+	}
+	return r.inner.GetByRiskCategory(ctx, category)
+}
@@ -0,0 +1,51 @@
+package fmtest
+
+import (
+	"sync"
+)
+
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+type script struct {
+	mu       sync.Mutex
+	calls    []Call
+	failures map[string][]error
+}
+
+func (s *script) FailNext(method string, errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures == nil {
+		s.failures = make(map[string][]error)
+	}
+	s.failures[method] = append(s.failures[method], errs...)
+}
+
+func (s *script) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []Call
+	for _, call := range s.calls {
+		if method == "" || call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+func (s *script) record(method string, args ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, Call{Method: method, Args: args})
+	queued := s.failures[method]
+	if len(queued) == 0 {
+		return nil
+	}
+	s.failures[method] = queued[1:]
+	return queued[0]
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const TraceparentHeader = "traceparent"
+
+type traceIDKey struct{}
+
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	if traceID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+func ParseTraceparent(header string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return "", false
+	}
+	if parts[0] == "ff" || !isLowerHex(parts[0]) || !isLowerHex(parts[1]) || !isLowerHex(parts[2]) || !isLowerHex(parts[3]) {
+		return "", false
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func exemplarLabels(ctx context.Context, accountID string) prometheus.Labels {
+	traceID := TraceIDFromContext(ctx)
+	if traceID == "" {
+		return nil
+	}
+
+	labels := prometheus.Labels{"trace_id": traceID}
+	if accountID != "" && exemplarLength(labels)+utf8.RuneCountInString("account_id"+accountID) <= prometheus.ExemplarMaxRunes {
+		labels["account_id"] = accountID
+	}
+	return labels
+}
+
+func exemplarLength(labels prometheus.Labels) int {
+	var n int
+	for name, value := range labels {
+		n += utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+	}
+	return n
+}
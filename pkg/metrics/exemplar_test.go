@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := map[string]bool{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": true,
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01": false,
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01": false,
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": false,
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-01":                  false,
+	}
+	for header, valid := range cases {
+		if _, ok := ParseTraceparent(header); ok != valid {
+			t.Errorf("ParseTraceparent(%q) = %v, want %v", header, ok, valid)
+		}
+	}
+}
+
+func TestRecordTransaction_AttachesExemplar(t *testing.T) {
+	m := NewMetricsCollector(nil)
+	ctx := ContextWithTraceID(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	m.RecordTransaction(context.Background(), 10*time.Millisecond, 0, true, "acc-1")
+	m.RecordTransaction(ctx, 20*time.Millisecond, 0, true, "acc-1")
+
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var exemplars []map[string]string
+	for _, family := range families {
+		if family.GetName() != "transaction_processing_duration_seconds" {
+			continue
+		}
+		for _, bucket := range family.GetMetric()[0].GetHistogram().GetBucket() {
+			if exemplar := bucket.GetExemplar(); exemplar != nil {
+				labels := make(map[string]string)
+				for _, pair := range exemplar.GetLabel() {
+					labels[pair.GetName()] = pair.GetValue()
+				}
+				exemplars = append(exemplars, labels)
+			}
+		}
+	}
+	if len(exemplars) != 1 || exemplars[0]["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || exemplars[0]["account_id"] != "acc-1" {
+		t.Errorf("expected one exemplar for the traced observation, got %+v", exemplars)
+	}
+}
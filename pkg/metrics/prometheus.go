@@ -19,6 +19,19 @@ type MetricsCollector struct {
 	transactionDuration   prometheus.Histogram
 	riskScoreDistribution prometheus.Histogram
 	accountBalance        *prometheus.GaugeVec
+	workerPoolBusy        prometheus.Gauge
+	workerPoolSize        prometheus.Gauge
+	workerPoolUtilization prometheus.Gauge
+	workerPoolQueueDepth  *prometheus.GaugeVec
+	chargebackRate        *prometheus.GaugeVec
+	notificationWorkers   prometheus.Gauge
+	notificationQueue     prometheus.Gauge
+	notificationLatency   prometheus.Gauge
+	notificationScaling   *prometheus.CounterVec
+	cacheLookups          *prometheus.CounterVec
+	dataQualityFindings   *prometheus.GaugeVec
+	ingestionDuplicates   *prometheus.CounterVec
+	reviewSLABreaches     *prometheus.CounterVec
 	mu                    sync.RWMutex
 	logger                *slog.Logger
 }
@@ -54,13 +67,65 @@ func NewMetricsCollector(logger *slog.Logger) *MetricsCollector {
 			Name: "account_balance",
 			Help: "Current account balance",
 		}, []string{"account_id", "currency"}),
+		workerPoolBusy: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "worker_pool_busy_workers",
+			Help: "Number of worker pool workers currently running a job",
+		}),
+		workerPoolSize: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "worker_pool_workers",
+			Help: "Configured number of worker pool workers",
+		}),
+		workerPoolUtilization: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "worker_pool_utilization_ratio",
+			Help: "Share of worker pool workers currently busy",
+		}),
+		workerPoolQueueDepth: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "worker_pool_queue_depth",
+			Help: "Number of jobs waiting in a worker pool queue",
+		}, []string{"queue"}),
+		chargebackRate: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "merchant_chargeback_rate",
+			Help: "Share of a merchant's payments that received a chargeback",
+		}, []string{"merchant_id"}),
+		notificationWorkers: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "notification_workers",
+			Help: "Number of running notification workers",
+		}),
+		notificationQueue: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "notification_queue_depth",
+			Help: "Number of notifications waiting to be sent",
+		}),
+		notificationLatency: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "notification_send_latency_seconds",
+			Help: "Smoothed time taken to send a notification",
+		}),
+		notificationScaling: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "notification_scaling_decisions_total",
+			Help: "Number of notification worker scaling decisions",
+		}, []string{"direction", "reason"}),
+		cacheLookups: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "response_cache_lookups_total",
+			Help: "Number of response cache lookups by result",
+		}, []string{"cache", "result"}),
+		dataQualityFindings: promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "data_quality_findings",
+			Help: "Number of invariant violations found by the latest data quality check",
+		}, []string{"check", "severity"}),
+		ingestionDuplicates: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "ingestion_duplicate_messages_total",
+			Help: "Number of ingestion messages acknowledged without reprocessing, by how the duplicate was detected",
+		}, []string{"source"}),
+		reviewSLABreaches: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "suspicious_review_sla_breaches_total",
+			Help: "Number of suspicious transactions left unreviewed past the review SLA, by breach policy",
+		}, []string{"policy"}),
 		logger: logger,
 	}
 
 	return collector
 }
 
-func (m *MetricsCollector) RecordTransaction(duration time.Duration, riskScore int, success bool) {
+func (m *MetricsCollector) RecordTransaction(ctx context.Context, duration time.Duration, riskScore int, success bool, accountID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -70,7 +135,11 @@ func (m *MetricsCollector) RecordTransaction(duration time.Duration, riskScore i
 		m.transactionsFailed.Inc()
 	}
 
-	m.transactionDuration.Observe(duration.Seconds())
+	if labels := exemplarLabels(ctx, accountID); labels != nil {
+		m.transactionDuration.(prometheus.ExemplarObserver).ObserveWithExemplar(duration.Seconds(), labels)
+	} else {
+		m.transactionDuration.Observe(duration.Seconds())
+	}
 	m.riskScoreDistribution.Observe(float64(riskScore))
 }
 
@@ -80,8 +149,58 @@ func (m *MetricsCollector) UpdateAccountBalance(accountID, currency string, bala
 	m.accountBalance.WithLabelValues(accountID, currency).Set(balance)
 }
 
+func (m *MetricsCollector) ObserveWorkerPool(busy, workers int) {
+	m.workerPoolBusy.Set(float64(busy))
+	m.workerPoolSize.Set(float64(workers))
+	if workers > 0 {
+		m.workerPoolUtilization.Set(float64(busy) / float64(workers))
+	}
+}
+
+func (m *MetricsCollector) ObserveQueueDepth(queue string, depth int) {
+	m.workerPoolQueueDepth.WithLabelValues(queue).Set(float64(depth))
+}
+
+func (m *MetricsCollector) ObserveChargebackRate(merchantID string, rate float64) {
+	m.chargebackRate.WithLabelValues(merchantID).Set(rate)
+}
+
+func (m *MetricsCollector) ObserveNotificationWorkers(workers, queueDepth int, latency time.Duration) {
+	m.notificationWorkers.Set(float64(workers))
+	m.notificationQueue.Set(float64(queueDepth))
+	m.notificationLatency.Set(latency.Seconds())
+}
+
+func (m *MetricsCollector) ObserveNotificationScaling(direction, reason string) {
+	m.notificationScaling.WithLabelValues(direction, reason).Inc()
+}
+
+func (m *MetricsCollector) ObserveCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+func (m *MetricsCollector) ResetDataQualityFindings() {
+	m.dataQualityFindings.Reset()
+}
+
+func (m *MetricsCollector) ObserveDataQualityFindings(check, severity string, count int) {
+	m.dataQualityFindings.WithLabelValues(check, severity).Set(float64(count))
+}
+
+func (m *MetricsCollector) ObserveIngestionDuplicate(source string) {
+	m.ingestionDuplicates.WithLabelValues(source).Inc()
+}
+
+func (m *MetricsCollector) ObserveReviewSLABreach(policy string) {
+	m.reviewSLABreaches.WithLabelValues(policy).Inc()
+}
+
 func (m *MetricsCollector) GetHandler() http.Handler {
-	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
 }
 
 func (m *MetricsCollector) StartMetricsServer(addr string) *http.Server {
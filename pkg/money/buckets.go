@@ -0,0 +1,58 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type AmountBuckets []float64
+
+func DefaultAmountBuckets() AmountBuckets {
+	return AmountBuckets{100, 1000, 5000, 10000, 50000, 100000}
+}
+
+func ParseAmountBuckets(spec string) (AmountBuckets, error) {
+	var buckets AmountBuckets
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(part, 64)
+		if err != nil || bound <= 0 || math.IsInf(bound, 0) {
+			return nil, fmt.Errorf("invalid amount bucket bound %q", part)
+		}
+		buckets = append(buckets, bound)
+	}
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("no amount bucket bounds in %q", spec)
+	}
+	slices.Sort(buckets)
+	return slices.Compact(buckets), nil
+}
+
+func (b AmountBuckets) Bucket(amount float64) string {
+	amount = math.Abs(amount)
+	lower := 0.0
+	for _, upper := range b {
+		if amount < upper {
+			return formatBound(lower) + "–" + formatBound(upper)
+		}
+		lower = upper
+	}
+	return formatBound(lower) + "+"
+}
+
+func formatBound(v float64) string {
+	switch {
+	case v >= 1e6 && math.Mod(v, 1e5) == 0:
+		return strconv.FormatFloat(v/1e6, 'f', -1, 64) + "m"
+	case v >= 1e3 && math.Mod(v, 1e2) == 0:
+		return strconv.FormatFloat(v/1e3, 'f', -1, 64) + "k"
+	default:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
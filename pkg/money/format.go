@@ -0,0 +1,136 @@
+package money
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Locale struct {
+	Tag         string
+	Decimal     string
+	Group       string
+	SymbolAfter bool
+	DateLayout  string
+}
+
+var DefaultLocale = Locale{Tag: "en-US", Decimal: ".", Group: ",", DateLayout: "Jan 2, 2006 3:04 PM"}
+
+var locales = map[string]Locale{
+	"en-us": DefaultLocale,
+	"en-gb": {Tag: "en-GB", Decimal: ".", Group: ",", DateLayout: "2 Jan 2006 15:04"},
+	"de-de": {Tag: "de-DE", Decimal: ",", Group: ".", SymbolAfter: true, DateLayout: "02.01.2006 15:04"},
+	"fr-fr": {Tag: "fr-FR", Decimal: ",", Group: " ", SymbolAfter: true, DateLayout: "02/01/2006 15:04"},
+	"es-es": {Tag: "es-ES", Decimal: ",", Group: ".", SymbolAfter: true, DateLayout: "02/01/2006 15:04"},
+	"it-it": {Tag: "it-IT", Decimal: ",", Group: ".", SymbolAfter: true, DateLayout: "02/01/2006 15:04"},
+	"pt-br": {Tag: "pt-BR", Decimal: ",", Group: ".", DateLayout: "02/01/2006 15:04"},
+	"ja-jp": {Tag: "ja-JP", Decimal: ".", Group: ",", DateLayout: "2006/01/02 15:04"},
+}
+
+// languageDefaults maps a bare language to the locale used when no region matches.
+var languageDefaults = map[string]string{
+	"en": "en-us",
+	"de": "de-de",
+	"fr": "fr-fr",
+	"es": "es-es",
+	"it": "it-it",
+	"pt": "pt-br",
+	"ja": "ja-jp",
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"BRL": "R$",
+	"INR": "₹",
+}
+
+// ParseAcceptLanguage returns the best supported locale for an Accept-Language header.
+func ParseAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if raw, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(raw, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+			q = parsed
+		}
+		candidates = append(candidates, candidate{tag: strings.ToLower(strings.ReplaceAll(tag, "_", "-")), q: q})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if locale, ok := locales[c.tag]; ok {
+			return locale, true
+		}
+		language, _, _ := strings.Cut(c.tag, "-")
+		if key, ok := languageDefaults[language]; ok {
+			return locales[key], true
+		}
+	}
+	return DefaultLocale, false
+}
+
+func (l Locale) Language() string {
+	language, _, _ := strings.Cut(l.Tag, "-")
+	return language
+}
+
+// FormatAmount renders an amount with the locale's separators and symbol placement.
+func (l Locale) FormatAmount(amount float64, currency string, policies *RoundingPolicies) string {
+	minorUnits := 2
+	if policies != nil {
+		policy := policies.Policy(currency)
+		minorUnits = policy.MinorUnits
+		amount = policy.Round(amount)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", math.Abs(amount)
+	}
+	digits := strconv.FormatFloat(amount, 'f', minorUnits, 64)
+	whole, fraction, _ := strings.Cut(digits, ".")
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(l.Group)
+		}
+		grouped.WriteRune(digit)
+	}
+	number := grouped.String()
+	if fraction != "" {
+		number += l.Decimal + fraction
+	}
+
+	symbol, known := currencySymbols[strings.ToUpper(currency)]
+	if !known {
+		symbol = strings.ToUpper(currency)
+	}
+	if l.SymbolAfter || !known {
+		return sign + number + " " + symbol
+	}
+	return sign + symbol + number
+}
+
+func (l Locale) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(l.DateLayout)
+}
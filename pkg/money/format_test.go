@@ -0,0 +1,59 @@
+package money
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := []struct {
+		header    string
+		expected  string
+		supported bool
+	}{
+		{"de-DE,de;q=0.9,en;q=0.8", "de-DE", true},
+		{"en;q=0.5, fr-CA", "fr-FR", true},
+		{"en-GB", "en-GB", true},
+		{"zh-CN, *;q=0.1", "en-US", false},
+		{"", "en-US", false},
+	}
+
+	for _, tc := range cases {
+		locale, supported := ParseAcceptLanguage(tc.header)
+		if locale.Tag != tc.expected || supported != tc.supported {
+			t.Errorf("%q: expected %s (%v), got %s (%v)", tc.header, tc.expected, tc.supported, locale.Tag, supported)
+		}
+	}
+}
+
+func TestLocale_FormatAmount(t *testing.T) {
+	policies := DefaultRoundingPolicies()
+	german, _ := ParseAcceptLanguage("de")
+	cases := []struct {
+		locale   Locale
+		amount   float64
+		currency string
+		expected string
+	}{
+		{DefaultLocale, 1234.5, "USD", "$1,234.50"},
+		{DefaultLocale, -1234567.891, "USD", "-$1,234,567.89"},
+		{DefaultLocale, 1500, "JPY", "¥1,500"},
+		{DefaultLocale, 12.3456, "KWD", "12.346 KWD"},
+		{german, 1234.5, "EUR", "1.234,50 €"},
+		{german, 999, "USD", "999,00 $"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.locale.FormatAmount(tc.amount, tc.currency, policies); got != tc.expected {
+			t.Errorf("%s %v %s: expected %q, got %q", tc.locale.Tag, tc.amount, tc.currency, tc.expected, got)
+		}
+	}
+
+	at := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+	if got := german.FormatDate(at); got != "10.03.2024 14:30" {
+		t.Errorf("unexpected german date %q", got)
+	}
+	if got := DefaultLocale.FormatDate(at); got != "Mar 10, 2024 2:30 PM" {
+		t.Errorf("unexpected default date %q", got)
+	}
+}
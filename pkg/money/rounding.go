@@ -0,0 +1,120 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"
+	RoundHalfEven RoundingMode = "half_even"
+	RoundDown     RoundingMode = "down"
+	RoundUp       RoundingMode = "up"
+)
+
+const epsilon = 1e-9
+
+type RoundingPolicy struct {
+	MinorUnits int          `json:"minor_units"`
+	Mode       RoundingMode `json:"mode"`
+}
+
+func (p RoundingPolicy) Round(amount float64) float64 {
+	factor := math.Pow10(p.MinorUnits)
+	scaled := amount * factor
+	if nearest := math.Round(scaled); math.Abs(scaled-nearest) < epsilon*math.Max(1, math.Abs(scaled)) {
+		return nearest / factor
+	}
+
+	sign := 1.0
+	if scaled < 0 {
+		sign, scaled = -1, -scaled
+	}
+
+	floor := math.Floor(scaled)
+	frac := scaled - floor
+	var rounded float64
+	switch p.Mode {
+	case RoundDown:
+		rounded = floor
+	case RoundUp:
+		rounded = floor + 1
+	case RoundHalfEven:
+		switch {
+		case math.Abs(frac-0.5) < epsilon:
+			rounded = floor
+			if math.Mod(floor, 2) != 0 {
+				rounded = floor + 1
+			}
+		case frac > 0.5:
+			rounded = floor + 1
+		default:
+			rounded = floor
+		}
+	default:
+		rounded = floor
+		if frac > 0.5-epsilon {
+			rounded = floor + 1
+		}
+	}
+
+	return sign * rounded / factor
+}
+
+type RoundingPolicies struct {
+	mu       sync.RWMutex
+	fallback RoundingPolicy
+	policies map[string]RoundingPolicy
+}
+
+func NewRoundingPolicies(fallback RoundingPolicy) *RoundingPolicies {
+	return &RoundingPolicies{
+		fallback: fallback,
+		policies: make(map[string]RoundingPolicy),
+	}
+}
+
+func DefaultRoundingPolicies() *RoundingPolicies {
+	policies := NewRoundingPolicies(RoundingPolicy{MinorUnits: 2, Mode: RoundHalfUp})
+	for _, currency := range []string{"JPY", "KRW", "VND", "CLP", "ISK", "HUF"} {
+		policies.Set(currency, RoundingPolicy{MinorUnits: 0, Mode: RoundHalfUp})
+	}
+	for _, currency := range []string{"BHD", "KWD", "OMR", "JOD", "TND", "IQD", "LYD"} {
+		policies.Set(currency, RoundingPolicy{MinorUnits: 3, Mode: RoundHalfUp})
+	}
+	return policies
+}
+
+func (r *RoundingPolicies) Set(currency string, policy RoundingPolicy) error {
+	if policy.MinorUnits < 0 || policy.MinorUnits > 8 {
+		return fmt.Errorf("invalid minor units for %s: %d", currency, policy.MinorUnits)
+	}
+	switch policy.Mode {
+	case RoundHalfUp, RoundHalfEven, RoundDown, RoundUp:
+	default:
+		return fmt.Errorf("invalid rounding mode for %s: %q", currency, policy.Mode)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies[strings.ToUpper(currency)] = policy
+	return nil
+}
+
+func (r *RoundingPolicies) Policy(currency string) RoundingPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if policy, exists := r.policies[strings.ToUpper(currency)]; exists {
+		return policy
+	}
+	return r.fallback
+}
+
+func (r *RoundingPolicies) Round(amount float64, currency string) float64 {
+	return r.Policy(currency).Round(amount)
+}
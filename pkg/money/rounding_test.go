@@ -0,0 +1,78 @@
+package money
+
+import (
+	"testing"
+)
+
+func TestRoundingPolicies_Round(t *testing.T) {
+	policies := DefaultRoundingPolicies()
+	_ = policies.Set("XTS", RoundingPolicy{MinorUnits: 2, Mode: RoundHalfEven})
+	_ = policies.Set("XTD", RoundingPolicy{MinorUnits: 2, Mode: RoundDown})
+
+	tests := []struct {
+		amount   float64
+		currency string
+		expected float64
+	}{
+		{2.675, "USD", 2.68},
+		{-2.675, "USD", -2.68},
+		{0.1 + 0.2, "USD", 0.3},
+		{1234.5, "JPY", 1235},
+		{1.2345, "BHD", 1.235},
+		{2.665, "XTS", 2.66},
+		{2.675, "XTS", 2.68},
+		{9.999, "XTD", 9.99},
+		{10, "usd", 10},
+	}
+
+	for _, tc := range tests {
+		if got := policies.Round(tc.amount, tc.currency); got != tc.expected {
+			t.Errorf("Round(%v, %s) = %v, expected %v", tc.amount, tc.currency, got, tc.expected)
+		}
+	}
+}
+
+func TestRoundingPolicies_SetRejectsInvalidPolicy(t *testing.T) {
+	policies := DefaultRoundingPolicies()
+
+	if err := policies.Set("USD", RoundingPolicy{MinorUnits: 2, Mode: "nearest"}); err == nil {
+		t.Error("expected unknown mode to be rejected")
+	}
+	if err := policies.Set("USD", RoundingPolicy{MinorUnits: -1, Mode: RoundHalfUp}); err == nil {
+		t.Error("expected negative minor units to be rejected")
+	}
+}
+
+func TestAmountBuckets_Bucket(t *testing.T) {
+	buckets := DefaultAmountBuckets()
+
+	tests := []struct {
+		amount   float64
+		expected string
+	}{
+		{0, "0–100"},
+		{99.99, "0–100"},
+		{100, "100–1k"},
+		{2500, "1k–5k"},
+		{-2500, "1k–5k"},
+		{10000, "10k–50k"},
+		{250000, "100k+"},
+	}
+
+	for _, tc := range tests {
+		if got := buckets.Bucket(tc.amount); got != tc.expected {
+			t.Errorf("Bucket(%v) = %q, expected %q", tc.amount, got, tc.expected)
+		}
+	}
+
+	custom, err := ParseAmountBuckets("2500000, 500, 1500, 500")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := custom.Bucket(2000); got != "1.5k–2.5m" {
+		t.Errorf("Bucket(2000) = %q, expected %q", got, "1.5k–2.5m")
+	}
+	if _, err := ParseAmountBuckets("100, -5"); err == nil {
+		t.Error("expected negative bound to be rejected")
+	}
+}
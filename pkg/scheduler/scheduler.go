@@ -0,0 +1,513 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrDuplicateJob    = errors.New("job already registered")
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrJobNotFound     = errors.New("job not found")
+	ErrNotStarted      = errors.New("scheduler not started")
+	ErrTriggerPending  = errors.New("job trigger already pending")
+)
+
+const defaultLockTTL = 10 * time.Minute
+
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Hold reports whether scheduled, non-manual runs should be skipped and why.
+type Hold func(now time.Time) (reason string, held bool)
+
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+	Jitter   time.Duration
+	LockTTL  time.Duration
+}
+
+type JobStatus struct {
+	Name       string    `json:"name"`
+	Spec       string    `json:"spec,omitempty"`
+	Paused     bool      `json:"paused"`
+	Running    bool      `json:"running"`
+	NextRun    time.Time `json:"next_run"`
+	LastRun    time.Time `json:"last_run"`
+	LastStatus RunStatus `json:"last_status,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+type entry struct {
+	job      Job
+	schedule Schedule
+	paused   bool
+	running  bool
+	nextRun  time.Time
+	lastRun  Run
+	trigger  chan struct{}
+	reset    chan struct{}
+}
+
+type Scheduler struct {
+	mu       sync.Mutex
+	jobs     map[string]*entry
+	store    Store
+	locker   Locker
+	instance string
+	hold     Hold
+	started  bool
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	logger   *slog.Logger
+}
+
+func New(logger *slog.Logger) *Scheduler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		jobs:   make(map[string]*entry),
+		store:  NewMemoryStore(defaultHistoryLimit),
+		ctx:    ctx,
+		cancel: cancel,
+		logger: logger,
+	}
+}
+
+func (s *Scheduler) WithStore(store Store) *Scheduler {
+	s.store = store
+	return s
+}
+
+func (s *Scheduler) WithLocker(locker Locker, instance string) *Scheduler {
+	s.locker = locker
+	s.instance = instance
+	return s
+}
+
+func (s *Scheduler) WithHold(hold Hold) *Scheduler {
+	s.hold = hold
+	return s
+}
+
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return fmt.Errorf("%w: job requires name, schedule and run function", ErrInvalidSchedule)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateJob, job.Name)
+	}
+
+	e := &entry{
+		job:      job,
+		schedule: job.Schedule,
+		trigger:  make(chan struct{}, 1),
+		reset:    make(chan struct{}, 1),
+	}
+	if err := s.restore(e); err != nil {
+		return err
+	}
+	s.jobs[job.Name] = e
+
+	if s.started {
+		s.launch(e)
+	}
+	return nil
+}
+
+func (s *Scheduler) restore(e *entry) error {
+	def, exists, err := s.store.GetDefinition(s.ctx, e.job.Name)
+	if err != nil {
+		return fmt.Errorf("failed to load job definition %s: %w", e.job.Name, err)
+	}
+	if !exists {
+		return s.store.SaveDefinition(s.ctx, JobDefinition{Name: e.job.Name, Spec: describe(e.schedule), UpdatedAt: time.Now()})
+	}
+
+	e.paused = def.Paused
+	if def.Spec != "" && def.Spec != describe(e.schedule) {
+		schedule, err := ParseSchedule(def.Spec)
+		if err != nil {
+			s.logger.Warn("Ignoring stored schedule for job",
+				slog.String("job", e.job.Name),
+				slog.String("spec", def.Spec),
+				slog.String("error", err.Error()))
+			return nil
+		}
+		e.schedule = schedule
+	}
+	return nil
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, e := range s.jobs {
+		s.launch(e)
+	}
+	s.logger.Info("Scheduler started", slog.Int("jobs", len(s.jobs)))
+}
+
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) Jobs() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		statuses = append(statuses, e.status())
+	}
+	slices.SortFunc(statuses, func(a, b JobStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return statuses
+}
+
+func (s *Scheduler) Job(name string) (JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.jobs[name]
+	if !exists {
+		return JobStatus{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return e.status(), nil
+}
+
+func (s *Scheduler) History(ctx context.Context, name string, limit int) ([]Run, error) {
+	if _, err := s.Job(name); err != nil {
+		return nil, err
+	}
+	return s.store.Runs(ctx, name, limit)
+}
+
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.jobs[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if !s.started {
+		return ErrNotStarted
+	}
+
+	select {
+	case e.trigger <- struct{}{}:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrTriggerPending, name)
+	}
+}
+
+func (s *Scheduler) Pause(ctx context.Context, name string) (JobStatus, error) {
+	return s.update(ctx, name, func(e *entry) error {
+		e.paused = true
+		return nil
+	})
+}
+
+func (s *Scheduler) Resume(ctx context.Context, name string) (JobStatus, error) {
+	return s.update(ctx, name, func(e *entry) error {
+		e.paused = false
+		return nil
+	})
+}
+
+func (s *Scheduler) Reschedule(ctx context.Context, name, spec string) (JobStatus, error) {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return s.update(ctx, name, func(e *entry) error {
+		e.schedule = schedule
+		select {
+		case e.reset <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+}
+
+func (s *Scheduler) update(ctx context.Context, name string, apply func(e *entry) error) (JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.jobs[name]
+	if !exists {
+		return JobStatus{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if err := apply(e); err != nil {
+		return JobStatus{}, err
+	}
+
+	def := JobDefinition{Name: name, Spec: describe(e.schedule), Paused: e.paused, UpdatedAt: time.Now()}
+	if err := s.store.SaveDefinition(ctx, def); err != nil {
+		return JobStatus{}, fmt.Errorf("failed to persist job definition %s: %w", name, err)
+	}
+	return e.status(), nil
+}
+
+func (e *entry) status() JobStatus {
+	return JobStatus{
+		Name:       e.job.Name,
+		Spec:       describe(e.schedule),
+		Paused:     e.paused,
+		Running:    e.running,
+		NextRun:    e.nextRun,
+		LastRun:    e.lastRun.StartedAt,
+		LastStatus: e.lastRun.Status,
+		LastError:  e.lastRun.Error,
+	}
+}
+
+func (s *Scheduler) launch(e *entry) {
+	s.wg.Add(1)
+	go s.loop(e)
+}
+
+func (s *Scheduler) loop(e *entry) {
+	defer s.wg.Done()
+
+	for {
+		s.mu.Lock()
+		next := e.schedule.Next(time.Now())
+		if e.job.Jitter > 0 {
+			next = next.Add(rand.N(e.job.Jitter))
+		}
+		e.nextRun = next
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-timer.C:
+			s.mu.Lock()
+			paused := e.paused
+			s.mu.Unlock()
+			if paused {
+				break
+			}
+			if reason, held := s.held(); held {
+				s.finish(e, Run{Job: e.job.Name, Trigger: TriggerSchedule, Instance: s.instance, Status: RunSkipped, Error: reason, StartedAt: time.Now()})
+				break
+			}
+			s.run(e, TriggerSchedule)
+		case <-e.trigger:
+			timer.Stop()
+			s.run(e, TriggerManual)
+		case <-e.reset:
+			timer.Stop()
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (s *Scheduler) held() (string, bool) {
+	if s.hold == nil {
+		return "", false
+	}
+	return s.hold(time.Now())
+}
+
+func (s *Scheduler) run(e *entry, trigger Trigger) {
+	job := e.job
+	run := Run{Job: job.Name, Trigger: trigger, Instance: s.instance, StartedAt: time.Now()}
+
+	if s.locker != nil {
+		ttl := job.LockTTL
+		if ttl <= 0 {
+			ttl = defaultLockTTL
+		}
+		acquired, err := s.locker.TryLock(s.ctx, job.Name, s.instance, ttl)
+		if err != nil || !acquired {
+			run.Status = RunSkipped
+			if err != nil {
+				run.Error = err.Error()
+			}
+			s.finish(e, run)
+			return
+		}
+		defer func() {
+			if err := s.locker.Unlock(context.WithoutCancel(s.ctx), job.Name, s.instance); err != nil {
+				s.logger.Warn("Failed to release job lock",
+					slog.String("job", job.Name),
+					slog.String("error", err.Error()))
+			}
+		}()
+	}
+
+	s.mu.Lock()
+	e.running = true
+	s.mu.Unlock()
+
+	err := s.execute(job)
+	run.Status = RunSucceeded
+	if err != nil {
+		run.Status = RunFailed
+		run.Error = err.Error()
+	}
+	s.finish(e, run)
+}
+
+func (s *Scheduler) execute(job Job) (err error) {
+	startTime := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Scheduled job panicked",
+				slog.String("job", job.Name),
+				slog.Any("panic", r))
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	if err := job.Run(s.ctx); err != nil {
+		s.logger.Error("Scheduled job failed",
+			slog.String("job", job.Name),
+			slog.String("error", err.Error()),
+			slog.Duration("duration", time.Since(startTime)))
+		return err
+	}
+
+	s.logger.Info("Scheduled job completed",
+		slog.String("job", job.Name),
+		slog.Duration("duration", time.Since(startTime)))
+	return nil
+}
+
+func (s *Scheduler) finish(e *entry, run Run) {
+	run.FinishedAt = time.Now()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt)
+
+	s.mu.Lock()
+	e.running = false
+	e.lastRun = run
+	s.mu.Unlock()
+
+	if err := s.store.RecordRun(context.WithoutCancel(s.ctx), run); err != nil {
+		s.logger.Warn("Failed to record job run",
+			slog.String("job", run.Job),
+			slog.String("error", err.Error()))
+	}
+}
+
+func describe(schedule Schedule) string {
+	if stringer, ok := schedule.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return ""
+}
+
+type everySchedule struct {
+	interval time.Duration
+}
+
+func Every(interval time.Duration) Schedule {
+	return everySchedule{interval: interval}
+}
+
+func (e everySchedule) Next(after time.Time) time.Time {
+	return after.Add(e.interval)
+}
+
+func (e everySchedule) String() string {
+	return "@every " + e.interval.String()
+}
+
+type dailySchedule struct {
+	hour   int
+	minute int
+}
+
+func Daily(hour, minute int) Schedule {
+	return dailySchedule{hour: hour, minute: minute}
+}
+
+func (d dailySchedule) Next(after time.Time) time.Time {
+	next := time.Date(after.Year(), after.Month(), after.Day(), d.hour, d.minute, 0, 0, after.Location())
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func (d dailySchedule) String() string {
+	return fmt.Sprintf("@daily %02d:%02d", d.hour, d.minute)
+}
+
+func ParseSchedule(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty schedule", ErrInvalidSchedule)
+	}
+
+	switch fields[0] {
+	case "@every":
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: @every requires a duration", ErrInvalidSchedule)
+		}
+		interval, err := time.ParseDuration(fields[1])
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%w: invalid interval %q", ErrInvalidSchedule, fields[1])
+		}
+		return Every(interval), nil
+	case "@hourly":
+		return Every(time.Hour), nil
+	case "@daily":
+		if len(fields) == 1 {
+			return Daily(0, 0), nil
+		}
+		at, err := time.Parse("15:04", fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid time of day %q", ErrInvalidSchedule, fields[1])
+		}
+		return Daily(at.Hour(), at.Minute()), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported schedule %q", ErrInvalidSchedule, spec)
+	}
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	base := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+	cases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"@every 15m", base.Add(15 * time.Minute)},
+		{"@hourly", base.Add(time.Hour)},
+		{"@daily", time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"@daily 02:00", time.Date(2024, 3, 11, 2, 0, 0, 0, time.UTC)},
+		{"@daily 18:45", time.Date(2024, 3, 10, 18, 45, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		schedule, err := ParseSchedule(tc.spec)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tc.spec, err)
+		}
+		if next := schedule.Next(base); !next.Equal(tc.expected) {
+			t.Errorf("%q: expected next run %v, got %v", tc.spec, tc.expected, next)
+		}
+	}
+
+	if _, err := ParseSchedule("* * * * *"); err == nil {
+		t.Error("expected error for unsupported schedule")
+	}
+}
+
+func TestScheduler_RunsRegisteredJob(t *testing.T) {
+	s := New(nil)
+	ran := make(chan struct{}, 1)
+	_ = s.Register(Job{
+		Name:     "tick",
+		Schedule: Every(10 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	})
+
+	s.Start()
+	defer s.Stop(context.Background())
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected job to run")
+	}
+}
+
+func TestScheduler_TriggerPauseAndHistory(t *testing.T) {
+	store := NewMemoryStore(10)
+	s := New(nil).WithStore(store)
+	ran := make(chan struct{}, 4)
+	_ = s.Register(Job{
+		Name:     "report",
+		Schedule: Every(time.Hour),
+		Run: func(ctx context.Context) error {
+			ran <- struct{}{}
+			return errors.New("upstream unavailable")
+		},
+	})
+
+	if err := s.Trigger("report"); !errors.Is(err, ErrNotStarted) {
+		t.Fatalf("expected ErrNotStarted before start, got %v", err)
+	}
+	s.Start()
+	defer s.Stop(context.Background())
+
+	if _, err := s.Pause(context.Background(), "report"); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if err := s.Trigger("report"); err != nil {
+		t.Fatalf("Trigger: %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected manual trigger to run paused job")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	var runs []Run
+	for len(runs) == 0 && time.Now().Before(deadline) {
+		runs, _ = s.History(context.Background(), "report", 5)
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(runs) != 1 || runs[0].Trigger != TriggerManual || runs[0].Status != RunFailed || runs[0].Error != "upstream unavailable" {
+		t.Fatalf("expected one failed manual run, got %+v", runs)
+	}
+
+	if _, err := s.Reschedule(context.Background(), "report", "@daily 03:00"); err != nil {
+		t.Fatalf("Reschedule: %v", err)
+	}
+	def, _, _ := store.GetDefinition(context.Background(), "report")
+	if !def.Paused || def.Spec != "@daily 03:00" {
+		t.Errorf("expected paused definition with new spec persisted, got %+v", def)
+	}
+
+	restored := New(nil).WithStore(store)
+	_ = restored.Register(Job{Name: "report", Schedule: Every(time.Hour), Run: func(ctx context.Context) error { return nil }})
+	if status, _ := restored.Job("report"); !status.Paused || status.Spec != "@daily 03:00" {
+		t.Errorf("expected stored definition to survive restart, got %+v", status)
+	}
+	if _, err := s.Pause(context.Background(), "missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestScheduler_SingletonLockSkipsRun(t *testing.T) {
+	locker := NewMemoryLocker()
+	if ok, _ := locker.TryLock(context.Background(), "sweep", "other-instance", time.Minute); !ok {
+		t.Fatal("expected other instance to acquire lock")
+	}
+
+	s := New(nil).WithLocker(locker, "this-instance")
+	ran := false
+	_ = s.Register(Job{Name: "sweep", Schedule: Every(time.Hour), Run: func(ctx context.Context) error {
+		ran = true
+		return nil
+	}})
+	s.Start()
+	defer s.Stop(context.Background())
+
+	_ = s.Trigger("sweep")
+	deadline := time.Now().Add(time.Second)
+	var runs []Run
+	for len(runs) == 0 && time.Now().Before(deadline) {
+		runs, _ = s.History(context.Background(), "sweep", 1)
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(runs) != 1 || runs[0].Status != RunSkipped || ran {
+		t.Fatalf("expected run to be skipped while another instance holds the lock, got %+v", runs)
+	}
+}
+
+func TestScheduler_HoldSkipsScheduledRuns(t *testing.T) {
+	var mu sync.Mutex
+	held := true
+	s := New(nil).WithHold(func(now time.Time) (string, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		return "maintenance", held
+	})
+	ran := make(chan struct{}, 1)
+	_ = s.Register(Job{Name: "tick", Schedule: Every(5 * time.Millisecond), Run: func(ctx context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	}})
+	s.Start()
+	defer s.Stop(context.Background())
+
+	time.Sleep(30 * time.Millisecond)
+	select {
+	case <-ran:
+		t.Fatal("expected held job not to run")
+	default:
+	}
+	if runs, _ := s.History(context.Background(), "tick", 1); len(runs) != 1 || runs[0].Status != RunSkipped || runs[0].Error != "maintenance" {
+		t.Fatalf("expected skipped runs while held, got %+v", runs)
+	}
+
+	mu.Lock()
+	held = false
+	mu.Unlock()
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected job to run once the hold is lifted")
+	}
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultHistoryLimit = 100
+
+type RunStatus string
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+	RunSkipped   RunStatus = "skipped"
+)
+
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule"
+	TriggerManual   Trigger = "manual"
+)
+
+type JobDefinition struct {
+	Name      string    `json:"name"`
+	Spec      string    `json:"spec,omitempty"`
+	Paused    bool      `json:"paused"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Run struct {
+	Job        string        `json:"job"`
+	Trigger    Trigger       `json:"trigger"`
+	Instance   string        `json:"instance,omitempty"`
+	Status     RunStatus     `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Duration   time.Duration `json:"duration_ns"`
+}
+
+type Store interface {
+	SaveDefinition(ctx context.Context, def JobDefinition) error
+	GetDefinition(ctx context.Context, name string) (JobDefinition, bool, error)
+	RecordRun(ctx context.Context, run Run) error
+	Runs(ctx context.Context, name string, limit int) ([]Run, error)
+}
+
+type Locker interface {
+	TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, name, owner string) error
+}
+
+type MemoryStore struct {
+	mu    sync.RWMutex
+	defs  map[string]JobDefinition
+	runs  map[string][]Run
+	limit int
+}
+
+func NewMemoryStore(historyLimit int) *MemoryStore {
+	if historyLimit <= 0 {
+		historyLimit = defaultHistoryLimit
+	}
+	return &MemoryStore{
+		defs:  make(map[string]JobDefinition),
+		runs:  make(map[string][]Run),
+		limit: historyLimit,
+	}
+}
+
+func (m *MemoryStore) SaveDefinition(ctx context.Context, def JobDefinition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defs[def.Name] = def
+	return nil
+}
+
+func (m *MemoryStore) GetDefinition(ctx context.Context, name string) (JobDefinition, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	def, exists := m.defs[name]
+	return def, exists, nil
+}
+
+func (m *MemoryStore) RecordRun(ctx context.Context, run Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs := append(m.runs[run.Job], run)
+	if len(runs) > m.limit {
+		runs = slices.Clone(runs[len(runs)-m.limit:])
+	}
+	m.runs[run.Job] = runs
+	return nil
+}
+
+func (m *MemoryStore) Runs(ctx context.Context, name string, limit int) ([]Run, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	runs := m.runs[name]
+	if limit > 0 && len(runs) > limit {
+		runs = runs[len(runs)-limit:]
+	}
+	result := slices.Clone(runs)
+	slices.Reverse(result)
+	return result, nil
+}
+
+type lease struct {
+	owner   string
+	expires time.Time
+}
+
+type MemoryLocker struct {
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{leases: make(map[string]lease)}
+}
+
+func (l *MemoryLocker) TryLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if current, held := l.leases[name]; held && current.owner != owner && now.Before(current.expires) {
+		return false, nil
+	}
+	l.leases[name] = lease{owner: owner, expires: now.Add(ttl)}
+	return true, nil
+}
+
+func (l *MemoryLocker) Unlock(ctx context.Context, name, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if current, held := l.leases[name]; held && current.owner == owner {
+		delete(l.leases, name)
+	}
+	return nil
+}
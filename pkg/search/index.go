@@ -0,0 +1,219 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	gramSize      = 3
+	maxCandidates = 5000
+
+	scoreContains = 1
+	scorePrefix   = 2
+	scoreExact    = 3
+)
+
+type Hit struct {
+	ID    string
+	Score int
+}
+
+type document struct {
+	terms []string
+	keys  []indexKey
+}
+
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]document
+	grams    map[string]map[string]struct{}
+	prefixes map[string]map[string]struct{}
+}
+
+func New() *Index {
+	return &Index{
+		docs:     make(map[string]document),
+		grams:    make(map[string]map[string]struct{}),
+		prefixes: make(map[string]map[string]struct{}),
+	}
+}
+
+func (i *Index) Put(id string, fields ...string) {
+	terms, fieldCount := tokenize(fields)
+	keys := indexKeys(terms, fieldCount)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.removeLocked(id)
+	i.docs[id] = document{terms: terms, keys: keys}
+	for _, key := range keys {
+		index := i.postingsLocked(key.short)
+		postings := index[key.value]
+		if postings == nil {
+			postings = make(map[string]struct{})
+			index[key.value] = postings
+		}
+		postings[id] = struct{}{}
+	}
+}
+
+func (i *Index) Remove(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.removeLocked(id)
+}
+
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.docs)
+}
+
+func (i *Index) Search(query string, limit int) []Hit {
+	query = normalize(query)
+	if query == "" {
+		return nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var candidates map[string]struct{}
+	if runes := []rune(query); len(runes) < gramSize {
+		candidates = i.prefixes[query]
+	} else {
+		for _, gram := range trigrams(runes) {
+			postings := i.grams[gram]
+			if candidates == nil || len(postings) < len(candidates) {
+				candidates = postings
+			}
+			if len(candidates) == 0 {
+				return nil
+			}
+		}
+	}
+
+	var hits []Hit
+	for id := range candidates {
+		if score := match(i.docs[id].terms, query); score > 0 {
+			hits = append(hits, Hit{ID: id, Score: score})
+			if len(hits) >= maxCandidates {
+				break
+			}
+		}
+	}
+
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+func (i *Index) removeLocked(id string) {
+	for _, key := range i.docs[id].keys {
+		index := i.postingsLocked(key.short)
+		delete(index[key.value], id)
+		if len(index[key.value]) == 0 {
+			delete(index, key.value)
+		}
+	}
+	delete(i.docs, id)
+}
+
+func (i *Index) postingsLocked(short bool) map[string]map[string]struct{} {
+	if short {
+		return i.prefixes
+	}
+	return i.grams
+}
+
+type indexKey struct {
+	value string
+	short bool
+}
+
+func indexKeys(terms []string, fieldCount int) []indexKey {
+	var result []indexKey
+	for n, term := range terms {
+		runes := []rune(term)
+		for size := 1; size < gramSize && size <= len(runes); size++ {
+			result = append(result, indexKey{value: string(runes[:size]), short: true})
+		}
+		if n < fieldCount {
+			for _, gram := range trigrams(runes) {
+				result = append(result, indexKey{value: gram})
+			}
+		}
+	}
+	slices.SortFunc(result, func(a, b indexKey) int {
+		if a.short != b.short {
+			if a.short {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.value, b.value)
+	})
+	return slices.Compact(result)
+}
+
+func trigrams(runes []rune) []string {
+	if len(runes) < gramSize {
+		return nil
+	}
+	grams := make([]string, 0, len(runes)-gramSize+1)
+	for n := 0; n+gramSize <= len(runes); n++ {
+		grams = append(grams, string(runes[n:n+gramSize]))
+	}
+	return grams
+}
+
+func match(terms []string, query string) int {
+	best := 0
+	for _, term := range terms {
+		switch {
+		case term == query:
+			return scoreExact
+		case strings.HasPrefix(term, query):
+			best = max(best, scorePrefix)
+		case strings.Contains(term, query):
+			best = max(best, scoreContains)
+		}
+	}
+	return best
+}
+
+func tokenize(fields []string) ([]string, int) {
+	var terms, words []string
+	for _, field := range fields {
+		field = normalize(field)
+		if field == "" {
+			continue
+		}
+		terms = append(terms, field)
+		words = append(words, strings.FieldsFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	fieldCount := len(terms)
+	for _, word := range words {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms, fieldCount
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIndex_PrefixAndTrigramMatches(t *testing.T) {
+	idx := New()
+	idx.Put("tx-1", "TX-2024-000123", "Rent payment March", "acc-100")
+	idx.Put("tx-2", "TX-2024-000124", "Grocery store", "acc-200")
+	idx.Put("acc-100", "acc-100", "user-7")
+
+	ids := func(hits []Hit) []string {
+		var result []string
+		for _, hit := range hits {
+			result = append(result, hit.ID)
+		}
+		return result
+	}
+
+	if got := ids(idx.Search("re", 10)); len(got) != 1 || got[0] != "tx-1" {
+		t.Errorf("expected short prefix to match rent payment only, got %v", got)
+	}
+	if got := ids(idx.Search("ymen", 10)); len(got) != 1 || got[0] != "tx-1" {
+		t.Errorf("expected infix trigram match, got %v", got)
+	}
+	if got := idx.Search("acc-100", 10); len(got) != 2 || got[0].Score != scoreExact || got[1].Score != scoreExact {
+		t.Errorf("expected exact account matches, got %+v", got)
+	}
+	if got := ids(idx.Search("000124", 10)); len(got) != 1 || got[0] != "tx-2" {
+		t.Errorf("expected reference match, got %v", got)
+	}
+
+	idx.Put("tx-1", "TX-2024-000123", "Utilities")
+	if got := idx.Search("rent", 10); len(got) != 0 {
+		t.Errorf("expected re-indexed document to drop stale terms, got %+v", got)
+	}
+	idx.Remove("tx-2")
+	if got := idx.Search("grocery", 10); len(got) != 0 || idx.Len() != 2 {
+		t.Errorf("expected removed document to disappear, got %+v (len %d)", got, idx.Len())
+	}
+}
+
+func TestIndex_SearchLatency(t *testing.T) {
+	idx := New()
+	for i := 0; i < 20_000; i++ {
+		idx.Put(fmt.Sprintf("tx-%06d", i), fmt.Sprintf("TX-2024-%06d", i), fmt.Sprintf("Invoice %d for merchant %d", i, i%500))
+	}
+
+	start := time.Now()
+	for _, query := range []string{"in", "merchant 42", "TX-2024-0199", "voice 1234"} {
+		if hits := idx.Search(query, 20); len(hits) == 0 {
+			t.Errorf("expected hits for %q", query)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("expected searches to stay fast, took %s", elapsed)
+	}
+}
@@ -0,0 +1,239 @@
+package validator
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var (
+	ErrInvalidMetadata      = errors.New("invalid metadata")
+	ErrUnknownSchemaVersion = errors.New("unknown metadata schema version")
+)
+
+type MetadataSchema struct {
+	ID         string                         `json:"$id"`
+	Version    string                         `json:"version"`
+	Properties map[string]MetadataFieldSchema `json:"properties"`
+	patterns   map[string]*regexp.Regexp
+}
+
+type MetadataFieldSchema struct {
+	Type      string   `json:"type"`
+	Enum      []string `json:"enum,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	MaxLength int      `json:"maxLength,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+}
+
+type MetadataFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type MetadataValidationError struct {
+	Version string               `json:"version"`
+	Errors  []MetadataFieldError `json:"errors"`
+}
+
+func (e *MetadataValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message)
+	}
+	return fmt.Sprintf("invalid metadata (schema %s): %s", e.Version, strings.Join(messages, "; "))
+}
+
+func (e *MetadataValidationError) Unwrap() error {
+	return ErrInvalidMetadata
+}
+
+func ParseMetadataSchema(data []byte) (*MetadataSchema, error) {
+	var schema MetadataSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata schema: %w", err)
+	}
+	if schema.Version == "" {
+		return nil, fmt.Errorf("metadata schema %s has no version", schema.ID)
+	}
+
+	schema.patterns = make(map[string]*regexp.Regexp)
+	for field, fieldSchema := range schema.Properties {
+		switch fieldSchema.Type {
+		case "string", "integer", "number", "boolean":
+		default:
+			return nil, fmt.Errorf("metadata schema %s: field %s has unsupported type %q", schema.Version, field, fieldSchema.Type)
+		}
+		if fieldSchema.Pattern != "" {
+			re, err := regexp.Compile(fieldSchema.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("metadata schema %s: field %s has invalid pattern: %w", schema.Version, field, err)
+			}
+			schema.patterns[field] = re
+		}
+	}
+
+	return &schema, nil
+}
+
+func (s *MetadataSchema) Validate(metadata map[string]string) error {
+	var fieldErrs []MetadataFieldError
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldSchema, known := s.Properties[key]
+		if !known {
+			continue
+		}
+		if msg := s.validateField(key, fieldSchema, metadata[key]); msg != "" {
+			fieldErrs = append(fieldErrs, MetadataFieldError{Field: key, Message: msg})
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		return &MetadataValidationError{Version: s.Version, Errors: fieldErrs}
+	}
+	return nil
+}
+
+func (s *MetadataSchema) validateField(field string, schema MetadataFieldSchema, value string) string {
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		return checkRange(float64(n), schema)
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		return checkRange(n, schema)
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be a boolean"
+		}
+		return ""
+	}
+
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, value) {
+		return fmt.Sprintf("must be one of %s", strings.Join(schema.Enum, ", "))
+	}
+	if schema.MaxLength > 0 && utf8.RuneCountInString(value) > schema.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", schema.MaxLength)
+	}
+	if re, exists := s.patterns[field]; exists && !re.MatchString(value) {
+		return fmt.Sprintf("must match pattern %s", schema.Pattern)
+	}
+	if schema.Format == "ip" && net.ParseIP(value) == nil {
+		return "must be a valid IP address"
+	}
+	return ""
+}
+
+func checkRange(n float64, schema MetadataFieldSchema) string {
+	if schema.Minimum != nil && n < *schema.Minimum {
+		return fmt.Sprintf("must be at least %v", *schema.Minimum)
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		return fmt.Sprintf("must be at most %v", *schema.Maximum)
+	}
+	return ""
+}
+
+type MetadataSchemaRegistry struct {
+	schemas map[string]*MetadataSchema
+	latest  string
+}
+
+func NewMetadataSchemaRegistry(schemas ...*MetadataSchema) *MetadataSchemaRegistry {
+	registry := &MetadataSchemaRegistry{schemas: make(map[string]*MetadataSchema)}
+	for _, schema := range schemas {
+		registry.schemas[schema.Version] = schema
+		if registry.latest == "" || compareVersions(schema.Version, registry.latest) > 0 {
+			registry.latest = schema.Version
+		}
+	}
+	return registry
+}
+
+func DefaultMetadataSchemas() (*MetadataSchemaRegistry, error) {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []*MetadataSchema
+	for _, entry := range entries {
+		data, err := schemaFiles.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		schema, err := ParseMetadataSchema(data)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+
+	return NewMetadataSchemaRegistry(schemas...), nil
+}
+
+func MustDefaultMetadataSchemas() *MetadataSchemaRegistry {
+	registry, err := DefaultMetadataSchemas()
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+func (r *MetadataSchemaRegistry) Latest() string {
+	return r.latest
+}
+
+func (r *MetadataSchemaRegistry) Schema(version string) (*MetadataSchema, error) {
+	if version == "" {
+		version = r.latest
+	}
+	schema, exists := r.schemas[version]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSchemaVersion, version)
+	}
+	return schema, nil
+}
+
+func (r *MetadataSchemaRegistry) Validate(version string, metadata map[string]string) error {
+	schema, err := r.Schema(version)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(metadata)
+}
+
+func compareVersions(a, b string) int {
+	na, errA := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	nb, errB := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return na - nb
+}
@@ -0,0 +1,28 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMetadataSchemaRegistry_Validate(t *testing.T) {
+	registry := MustDefaultMetadataSchemas()
+
+	valid := registry.Validate("", map[string]string{"velocity": "high", "ip_address": "10.0.0.1", "custom": "anything"})
+	invalid := registry.Validate("v1", map[string]string{"velocity": "HIGH", "session_age_seconds": "-5"})
+	unknown := registry.Validate("v99", nil)
+
+	if valid != nil {
+		t.Errorf("expected valid metadata, got %v", valid)
+	}
+	var metadataErr *MetadataValidationError
+	if !errors.As(invalid, &metadataErr) || len(metadataErr.Errors) != 2 {
+		t.Fatalf("expected 2 field errors, got %v", invalid)
+	}
+	if metadataErr.Errors[0].Field != "session_age_seconds" || metadataErr.Errors[1].Field != "velocity" {
+		t.Errorf("expected errors sorted by field, got %+v", metadataErr.Errors)
+	}
+	if !errors.Is(unknown, ErrUnknownSchemaVersion) {
+		t.Errorf("expected ErrUnknownSchemaVersion, got %v", unknown)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"finance_manager/internal/domain"
 	"fmt"
+	"math"
 	"regexp"
+	"sync"
 	"time"
 )
 
@@ -13,10 +15,12 @@ var (
 	ErrInvalidCurrency      = errors.New("invalid currency")
 	ErrInvalidAccount       = errors.New("invalid account")
 	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	ErrInvalidLegs          = errors.New("invalid transaction legs")
 )
 
 type TransactionValidator struct {
 	currencyRegex *regexp.Regexp
+	mu            sync.Mutex
 	seen          map[string]struct{}
 }
 
@@ -28,6 +32,22 @@ func NewTransactionValidator() *TransactionValidator {
 }
 
 func (v *TransactionValidator) ValidateTransaction(tx *domain.Transaction) error {
+	err := v.CheckTransaction(tx)
+
+	v.mu.Lock()
+	if _, ok := v.seen[tx.ID]; ok {
+		v.mu.Unlock()
+		return ErrDuplicateTransaction
+	}
+	v.seen[tx.ID] = struct{}{}
+	v.mu.Unlock()
+
+	return err
+}
+
+// CheckTransaction validates the transaction fields without registering its ID
+// for duplicate detection.
+func (v *TransactionValidator) CheckTransaction(tx *domain.Transaction) error {
 	var errs []error
 
 	if tx.Amount <= 0 {
@@ -38,7 +58,11 @@ func (v *TransactionValidator) ValidateTransaction(tx *domain.Transaction) error
 		errs = append(errs, ErrInvalidCurrency)
 	}
 
-	if tx.Type == domain.TypeTransfer {
+	if len(tx.Legs) > 0 {
+		if err := validateLegs(tx); err != nil {
+			errs = append(errs, err)
+		}
+	} else if tx.Type == domain.TypeTransfer || tx.Type == domain.TypeChargeback {
 		if tx.FromAccountID == "" || tx.ToAccountID == "" {
 			errs = append(errs, ErrInvalidAccount)
 		}
@@ -51,15 +75,52 @@ func (v *TransactionValidator) ValidateTransaction(tx *domain.Transaction) error
 		errs = append(errs, errors.New("transaction date cannot be in the future"))
 	}
 
-	if _, ok := v.seen[tx.ID]; ok {
-		return ErrDuplicateTransaction
+	if len(errs) > 0 {
+		return fmt.Errorf("validation errors: %w", errors.Join(errs...))
 	}
-	v.seen[tx.ID] = struct{}{}
 
-	if len(errs) > 0 {
-		return fmt.Errorf("validation errors: %v", errs)
+	return nil
+}
+
+const (
+	maxLegs = 100
+	// legSumTolerance absorbs float error when summing leg amounts.
+	legSumTolerance = 0.000001
+)
+
+// validateLegs checks a multi-leg transfer: one source account, distinct
+// destinations, and leg amounts summing to the transaction amount.
+func validateLegs(tx *domain.Transaction) error {
+	switch {
+	case tx.Type != domain.TypeTransfer:
+		return fmt.Errorf("%w: only transfers can have legs", ErrInvalidLegs)
+	case tx.FromAccountID == "":
+		return fmt.Errorf("%w: from account is required", ErrInvalidLegs)
+	case tx.ToAccountID != "":
+		return fmt.Errorf("%w: to account must be set per leg", ErrInvalidLegs)
+	case len(tx.Legs) > maxLegs:
+		return fmt.Errorf("%w: at most %d legs are allowed", ErrInvalidLegs, maxLegs)
 	}
 
+	seen := make(map[string]bool, len(tx.Legs))
+	var total float64
+	for i, leg := range tx.Legs {
+		switch {
+		case leg.ToAccountID == "":
+			return fmt.Errorf("%w: leg %d has no account", ErrInvalidLegs, i)
+		case leg.ToAccountID == tx.FromAccountID:
+			return fmt.Errorf("%w: leg %d credits the source account", ErrInvalidLegs, i)
+		case seen[leg.ToAccountID]:
+			return fmt.Errorf("%w: account %s appears in more than one leg", ErrInvalidLegs, leg.ToAccountID)
+		case leg.Amount <= 0 || math.IsNaN(leg.Amount) || math.IsInf(leg.Amount, 0):
+			return fmt.Errorf("%w: leg %d has invalid amount", ErrInvalidLegs, i)
+		}
+		seen[leg.ToAccountID] = true
+		total += leg.Amount
+	}
+	if math.Abs(total-tx.Amount) > legSumTolerance {
+		return fmt.Errorf("%w: legs sum to %.2f, expected %.2f", ErrInvalidLegs, total, tx.Amount)
+	}
 	return nil
 }
 
@@ -75,7 +136,7 @@ func (v *TransactionValidator) ValidateAmount(amount float64, currency string) e
 	}
 
 	if max, exists := limits[currency]; exists && amount > max {
-		return fmt.Errorf("amount exceeds maximum limit for %s: %f", currency, max)
+		return fmt.Errorf("%w: exceeds maximum limit for %s: %f", ErrInvalidAmount, currency, max)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -37,8 +38,8 @@ func TestTransactionValidator_InvalidAmount(t *testing.T) {
 	}
 	err := v.ValidateTransaction(tx)
 
-	if err == nil {
-		t.Fatal("expected error for invalid amount, got nil")
+	if !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("expected ErrInvalidAmount, got %v", err)
 	}
 }
 
@@ -69,8 +70,8 @@ func TestTransactionValidator_ExceedsLimit(t *testing.T) {
 		CreatedAt:   time.Now(),
 	}
 	err := v.ValidateAmount(tx.Amount, tx.Currency)
-	if err == nil {
-		t.Fatal("expected error for exceeding limit, got nil")
+	if !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("expected ErrInvalidAmount for exceeding limit, got %v", err)
 	}
 }
 
@@ -109,3 +110,28 @@ func TestTransactionValidator_DuplicateTransaction(t *testing.T) {
 		t.Fatal("expected error for duplicate transaction, got nil")
 	}
 }
+
+func TestTransactionValidator_Legs(t *testing.T) {
+	v := NewTransactionValidator()
+	split := func(id string, legs ...domain.TransactionLeg) *domain.Transaction {
+		return &domain.Transaction{
+			ID:            id,
+			Amount:        100.3,
+			Currency:      "USD",
+			Type:          domain.TypeTransfer,
+			FromAccountID: "A1",
+			Legs:          legs,
+			CreatedAt:     time.Now(),
+		}
+	}
+
+	if err := v.ValidateTransaction(split("legs1", domain.TransactionLeg{ToAccountID: "A2", Amount: 60.1}, domain.TransactionLeg{ToAccountID: "A3", Amount: 40.2})); err != nil {
+		t.Fatalf("expected legs summing to the amount to be valid, got %v", err)
+	}
+	if err := v.ValidateTransaction(split("legs2", domain.TransactionLeg{ToAccountID: "A2", Amount: 60}, domain.TransactionLeg{ToAccountID: "A3", Amount: 40})); !errors.Is(err, ErrInvalidLegs) {
+		t.Errorf("expected ErrInvalidLegs for a short sum, got %v", err)
+	}
+	if err := v.ValidateTransaction(split("legs3", domain.TransactionLeg{ToAccountID: "A2", Amount: 50.15}, domain.TransactionLeg{ToAccountID: "A2", Amount: 50.15})); !errors.Is(err, ErrInvalidLegs) {
+		t.Errorf("expected ErrInvalidLegs for a repeated destination, got %v", err)
+	}
+}